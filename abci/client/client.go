@@ -34,6 +34,7 @@ type Client interface {
 	InfoAsync(types.RequestInfo, ResponseCallback) *ReqRes
 	SetOptionAsync(types.RequestSetOption, ResponseCallback) *ReqRes
 	DeliverTxAsync(types.RequestDeliverTx, ResponseCallback) *ReqRes
+	DeliverTxBatchAsync(ocabci.RequestDeliverTxBatch, ResponseCallback) *ReqRes
 	CheckTxAsync(types.RequestCheckTx, ResponseCallback) *ReqRes
 	QueryAsync(types.RequestQuery, ResponseCallback) *ReqRes
 	CommitAsync(ResponseCallback) *ReqRes
@@ -52,6 +53,7 @@ type Client interface {
 	InfoSync(types.RequestInfo) (*types.ResponseInfo, error)
 	SetOptionSync(types.RequestSetOption) (*types.ResponseSetOption, error)
 	DeliverTxSync(types.RequestDeliverTx) (*types.ResponseDeliverTx, error)
+	DeliverTxBatchSync(ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error)
 	CheckTxSync(types.RequestCheckTx) (*ocabci.ResponseCheckTx, error)
 	QuerySync(types.RequestQuery) (*types.ResponseQuery, error)
 	CommitSync() (*types.ResponseCommit, error)
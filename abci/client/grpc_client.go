@@ -183,6 +183,15 @@ func (cli *grpcClient) DeliverTxAsync(params types.RequestDeliverTx, cb Response
 	return cli.finishAsyncCall(req, &ocabci.Response{Value: &ocabci.Response_DeliverTx{DeliverTx: res}}, cb)
 }
 
+func (cli *grpcClient) DeliverTxBatchAsync(params ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	req := ocabci.ToRequestDeliverTxBatch(params)
+	res, err := cli.client.DeliverTxBatch(context.Background(), req.GetDeliverTxBatch(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &ocabci.Response{Value: &ocabci.Response_DeliverTxBatch{DeliverTxBatch: res}}, cb)
+}
+
 func (cli *grpcClient) CheckTxAsync(params types.RequestCheckTx, cb ResponseCallback) *ReqRes {
 	req := ocabci.ToRequestCheckTx(params)
 	res, err := cli.client.CheckTx(context.Background(), req.GetCheckTx(), grpc.WaitForReady(true))
@@ -341,6 +350,12 @@ func (cli *grpcClient) DeliverTxSync(params types.RequestDeliverTx) (*types.Resp
 	return reqres.Response.GetDeliverTx(), cli.Error()
 }
 
+func (cli *grpcClient) DeliverTxBatchSync(params ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	reqres := cli.DeliverTxBatchAsync(params, nil)
+	reqres.Wait()
+	return reqres.Response.GetDeliverTxBatch(), cli.Error()
+}
+
 func (cli *grpcClient) CheckTxSync(params types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
 	reqres := cli.CheckTxAsync(params, nil)
 	reqres.Wait()
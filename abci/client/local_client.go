@@ -104,6 +104,15 @@ func (app *localClient) DeliverTxAsync(req types.RequestDeliverTx, cb ResponseCa
 	return app.done(reqRes, ocabci.ToResponseDeliverTx(res))
 }
 
+func (app *localClient) DeliverTxBatchAsync(req ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	reqRes := NewReqRes(ocabci.ToRequestDeliverTxBatch(req), cb)
+	res := app.Application.DeliverTxBatch(req)
+	return app.done(reqRes, ocabci.ToResponseDeliverTxBatch(res))
+}
+
 func (app *localClient) CheckTxAsync(req types.RequestCheckTx, cb ResponseCallback) *ReqRes {
 	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
 	// app.mtx.Lock()
@@ -257,6 +266,14 @@ func (app *localClient) DeliverTxSync(req types.RequestDeliverTx) (*types.Respon
 	return &res, nil
 }
 
+func (app *localClient) DeliverTxBatchSync(req ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.DeliverTxBatch(req)
+	return &res, nil
+}
+
 func (app *localClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
 	// NOTE: commented out for performance. delete all after commenting out all `app.mtx`
 	// app.mtx.Lock()
@@ -47,6 +47,7 @@ func TestLocalClientCalls(t *testing.T) {
 	c.InfoAsync(types.RequestInfo{}, getResponseCallback(t))
 	c.SetOptionAsync(types.RequestSetOption{}, getResponseCallback(t))
 	c.DeliverTxAsync(types.RequestDeliverTx{}, getResponseCallback(t))
+	c.DeliverTxBatchAsync(ocabci.RequestDeliverTxBatch{}, getResponseCallback(t))
 	c.CheckTxAsync(types.RequestCheckTx{}, getResponseCallback(t))
 	c.QueryAsync(types.RequestQuery{}, getResponseCallback(t))
 	c.CommitAsync(getResponseCallback(t))
@@ -75,6 +76,9 @@ func TestLocalClientCalls(t *testing.T) {
 	_, err = c.DeliverTxSync(types.RequestDeliverTx{})
 	require.NoError(t, err)
 
+	_, err = c.DeliverTxBatchSync(ocabci.RequestDeliverTxBatch{})
+	require.NoError(t, err)
+
 	_, err = c.CheckTxSync(types.RequestCheckTx{})
 	require.NoError(t, err)
 
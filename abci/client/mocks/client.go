@@ -244,6 +244,48 @@ func (_m *Client) DeliverTxAsync(_a0 types.RequestDeliverTx, _a1 abcicli.Respons
 	return r0
 }
 
+// DeliverTxBatchAsync provides a mock function with given fields: _a0, _a1
+func (_m *Client) DeliverTxBatchAsync(_a0 abcitypes.RequestDeliverTxBatch, _a1 abcicli.ResponseCallback) *abcicli.ReqRes {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch, abcicli.ResponseCallback) *abcicli.ReqRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// DeliverTxBatchSync provides a mock function with given fields: _a0
+func (_m *Client) DeliverTxBatchSync(_a0 abcitypes.RequestDeliverTxBatch) (*abcitypes.ResponseDeliverTxBatch, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *abcitypes.ResponseDeliverTxBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch) (*abcitypes.ResponseDeliverTxBatch, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch) *abcitypes.ResponseDeliverTxBatch); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcitypes.ResponseDeliverTxBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(abcitypes.RequestDeliverTxBatch) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeliverTxSync provides a mock function with given fields: _a0
 func (_m *Client) DeliverTxSync(_a0 types.RequestDeliverTx) (*types.ResponseDeliverTx, error) {
 	ret := _m.Called(_a0)
@@ -246,6 +246,10 @@ func (cli *socketClient) DeliverTxAsync(req types.RequestDeliverTx, cb ResponseC
 	return cli.queueRequest(ocabci.ToRequestDeliverTx(req), cb)
 }
 
+func (cli *socketClient) DeliverTxBatchAsync(req ocabci.RequestDeliverTxBatch, cb ResponseCallback) *ReqRes {
+	return cli.queueRequest(ocabci.ToRequestDeliverTxBatch(req), cb)
+}
+
 func (cli *socketClient) CheckTxAsync(req types.RequestCheckTx, cb ResponseCallback) *ReqRes {
 	return cli.queueRequest(ocabci.ToRequestCheckTx(req), cb)
 }
@@ -341,6 +345,15 @@ func (cli *socketClient) DeliverTxSync(req types.RequestDeliverTx) (*types.Respo
 	return reqres.Response.GetDeliverTx(), cli.Error()
 }
 
+func (cli *socketClient) DeliverTxBatchSync(req ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	reqres := cli.queueRequest(ocabci.ToRequestDeliverTxBatch(req), nil)
+	if _, err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+
+	return reqres.Response.GetDeliverTxBatch(), cli.Error()
+}
+
 func (cli *socketClient) CheckTxSync(req types.RequestCheckTx) (*ocabci.ResponseCheckTx, error) {
 	reqres := cli.queueRequest(ocabci.ToRequestCheckTx(req), nil)
 	if _, err := cli.FlushSync(); err != nil {
@@ -505,6 +518,8 @@ func resMatchesReq(req *ocabci.Request, res *ocabci.Response) (ok bool) {
 		_, ok = res.Value.(*ocabci.Response_SetOption)
 	case *ocabci.Request_DeliverTx:
 		_, ok = res.Value.(*ocabci.Response_DeliverTx)
+	case *ocabci.Request_DeliverTxBatch:
+		_, ok = res.Value.(*ocabci.Response_DeliverTxBatch)
 	case *ocabci.Request_CheckTx:
 		_, ok = res.Value.(*ocabci.Response_CheckTx)
 	case *ocabci.Request_Commit:
@@ -117,6 +117,16 @@ func (app *Application) DeliverTx(req types.RequestDeliverTx) types.ResponseDeli
 	return types.ResponseDeliverTx{Code: code.CodeTypeOK, Events: events}
 }
 
+// DeliverTxBatch executes the txs one by one; the kvstore declares no tx hints.
+func (app *Application) DeliverTxBatch(req ocabci.RequestDeliverTxBatch) ocabci.ResponseDeliverTxBatch {
+	res := ocabci.ResponseDeliverTxBatch{DeliverTxs: make([]*types.ResponseDeliverTx, len(req.Txs))}
+	for i, tx := range req.Txs {
+		r := app.DeliverTx(tx)
+		res.DeliverTxs[i] = &r
+	}
+	return res
+}
+
 func (app *Application) CheckTxSync(req types.RequestCheckTx) ocabci.ResponseCheckTx {
 	return app.checkTx(req)
 }
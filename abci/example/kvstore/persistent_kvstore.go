@@ -81,6 +81,16 @@ func (app *PersistentKVStoreApplication) DeliverTx(req types.RequestDeliverTx) t
 	return app.app.DeliverTx(req)
 }
 
+func (app *PersistentKVStoreApplication) DeliverTxBatch(
+	req ocabci.RequestDeliverTxBatch) ocabci.ResponseDeliverTxBatch {
+	res := ocabci.ResponseDeliverTxBatch{DeliverTxs: make([]*types.ResponseDeliverTx, len(req.Txs))}
+	for i, tx := range req.Txs {
+		r := app.DeliverTx(tx)
+		res.DeliverTxs[i] = &r
+	}
+	return res
+}
+
 func (app *PersistentKVStoreApplication) CheckTxSync(req types.RequestCheckTx) ocabci.ResponseCheckTx {
 	return app.app.CheckTxSync(req)
 }
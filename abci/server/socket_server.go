@@ -206,6 +206,9 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 	case *types.Request_DeliverTx:
 		res := s.app.DeliverTx(*r.DeliverTx)
 		responses <- types.ToResponseDeliverTx(res)
+	case *types.Request_DeliverTxBatch:
+		res := s.app.DeliverTxBatch(*r.DeliverTxBatch)
+		responses <- types.ToResponseDeliverTxBatch(res)
 	case *types.Request_CheckTx:
 		res := s.app.CheckTxSync(*r.CheckTx)
 		responses <- types.ToResponseCheckTx(res)
@@ -27,11 +27,12 @@ type Application interface {
 	EndRecheckTx(RequestEndRecheckTx) ResponseEndRecheckTx       // Signals the end of rechecking
 
 	// Consensus Connection
-	InitChain(types.RequestInitChain) types.ResponseInitChain // Initialize blockchain w validators/other info from OstraconCore
-	BeginBlock(RequestBeginBlock) types.ResponseBeginBlock    // Signals the beginning of a block
-	DeliverTx(types.RequestDeliverTx) types.ResponseDeliverTx // Deliver a tx for full processing
-	EndBlock(types.RequestEndBlock) types.ResponseEndBlock    // Signals the end of a block, returns changes to the validator set
-	Commit() types.ResponseCommit                             // Commit the state and return the application Merkle root hash
+	InitChain(types.RequestInitChain) types.ResponseInitChain    // Initialize blockchain w validators/other info from OstraconCore
+	BeginBlock(RequestBeginBlock) types.ResponseBeginBlock       // Signals the beginning of a block
+	DeliverTx(types.RequestDeliverTx) types.ResponseDeliverTx    // Deliver a tx for full processing
+	DeliverTxBatch(RequestDeliverTxBatch) ResponseDeliverTxBatch // Deliver all txs of a block at once
+	EndBlock(types.RequestEndBlock) types.ResponseEndBlock       // Signals the end of a block, returns changes to the validator set
+	Commit() types.ResponseCommit                                // Commit the state and return the application Merkle root hash
 
 	// State Sync Connection
 	ListSnapshots(types.RequestListSnapshots) types.ResponseListSnapshots                // List available snapshots
@@ -64,6 +65,12 @@ func (BaseApplication) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliv
 	return types.ResponseDeliverTx{Code: CodeTypeOK}
 }
 
+// DeliverTxBatch of BaseApplication returns no results. Applications that enable
+// `deliver_tx_batch` must override it and return one result per tx.
+func (BaseApplication) DeliverTxBatch(req RequestDeliverTxBatch) ResponseDeliverTxBatch {
+	return ResponseDeliverTxBatch{}
+}
+
 func (BaseApplication) CheckTxSync(req types.RequestCheckTx) ResponseCheckTx {
 	return ResponseCheckTx{Code: CodeTypeOK}
 }
@@ -150,6 +157,12 @@ func (app *GRPCApplication) DeliverTx(ctx context.Context, req *types.RequestDel
 	return &res, nil
 }
 
+func (app *GRPCApplication) DeliverTxBatch(ctx context.Context, req *RequestDeliverTxBatch) (
+	*ResponseDeliverTxBatch, error) {
+	res := app.app.DeliverTxBatch(*req)
+	return &res, nil
+}
+
 func (app *GRPCApplication) CheckTx(ctx context.Context, req *types.RequestCheckTx) (*ResponseCheckTx, error) {
 	res := app.app.CheckTxSync(*req)
 	return &res, nil
@@ -149,6 +149,12 @@ func ToRequestEndRecheckTx(req RequestEndRecheckTx) *Request {
 	}
 }
 
+func ToRequestDeliverTxBatch(req RequestDeliverTxBatch) *Request {
+	return &Request{
+		Value: &Request_DeliverTxBatch{&req},
+	}
+}
+
 func ToRequestListSnapshots(req types.RequestListSnapshots) *Request {
 	return &Request{
 		Value: &Request_ListSnapshots{&req},
@@ -259,6 +265,12 @@ func ToResponseEndRecheckTx(res ResponseEndRecheckTx) *Response {
 	}
 }
 
+func ToResponseDeliverTxBatch(res ResponseDeliverTxBatch) *Response {
+	return &Response{
+		Value: &Response_DeliverTxBatch{&res},
+	}
+}
+
 func ToResponseListSnapshots(res types.ResponseListSnapshots) *Response {
 	return &Response{
 		Value: &Response_ListSnapshots{&res},
@@ -103,6 +103,20 @@ func (_m *Application) DeliverTx(_a0 types.RequestDeliverTx) types.ResponseDeliv
 	return r0
 }
 
+// DeliverTxBatch provides a mock function with given fields: _a0
+func (_m *Application) DeliverTxBatch(_a0 abcitypes.RequestDeliverTxBatch) abcitypes.ResponseDeliverTxBatch {
+	ret := _m.Called(_a0)
+
+	var r0 abcitypes.ResponseDeliverTxBatch
+	if rf, ok := ret.Get(0).(func(abcitypes.RequestDeliverTxBatch) abcitypes.ResponseDeliverTxBatch); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(abcitypes.ResponseDeliverTxBatch)
+	}
+
+	return r0
+}
+
 // EndBlock provides a mock function with given fields: _a0
 func (_m *Application) EndBlock(_a0 types.RequestEndBlock) types.ResponseEndBlock {
 	ret := _m.Called(_a0)
@@ -51,6 +51,7 @@ type Request struct {
 	//	*Request_ApplySnapshotChunk
 	//	*Request_BeginRecheckTx
 	//	*Request_EndRecheckTx
+	//	*Request_DeliverTxBatch
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -144,6 +145,9 @@ type Request_BeginRecheckTx struct {
 type Request_EndRecheckTx struct {
 	EndRecheckTx *RequestEndRecheckTx `protobuf:"bytes,1001,opt,name=end_recheck_tx,json=endRecheckTx,proto3,oneof" json:"end_recheck_tx,omitempty"`
 }
+type Request_DeliverTxBatch struct {
+	DeliverTxBatch *RequestDeliverTxBatch `protobuf:"bytes,1002,opt,name=deliver_tx_batch,json=deliverTxBatch,proto3,oneof" json:"deliver_tx_batch,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -162,6 +166,7 @@ func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
 func (*Request_BeginRecheckTx) isRequest_Value()     {}
 func (*Request_EndRecheckTx) isRequest_Value()       {}
+func (*Request_DeliverTxBatch) isRequest_Value()     {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -289,6 +294,13 @@ func (m *Request) GetEndRecheckTx() *RequestEndRecheckTx {
 	return nil
 }
 
+func (m *Request) GetDeliverTxBatch() *RequestDeliverTxBatch {
+	if x, ok := m.GetValue().(*Request_DeliverTxBatch); ok {
+		return x.DeliverTxBatch
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -309,6 +321,7 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_ApplySnapshotChunk)(nil),
 		(*Request_BeginRecheckTx)(nil),
 		(*Request_EndRecheckTx)(nil),
+		(*Request_DeliverTxBatch)(nil),
 	}
 }
 
@@ -477,6 +490,61 @@ func (m *RequestEndRecheckTx) GetHeight() int64 {
 	return 0
 }
 
+// RequestDeliverTxBatch delivers all txs of a block at once. hints[i] holds the
+// dependencies that the application declared for txs[i] at CheckTx; it is empty
+// if the tx was not checked by this node's mempool.
+type RequestDeliverTxBatch struct {
+	Txs   []types.RequestDeliverTx `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs"`
+	Hints []TxHint                 `protobuf:"bytes,2,rep,name=hints,proto3" json:"hints"`
+}
+
+func (m *RequestDeliverTxBatch) Reset()         { *m = RequestDeliverTxBatch{} }
+func (m *RequestDeliverTxBatch) String() string { return proto.CompactTextString(m) }
+func (*RequestDeliverTxBatch) ProtoMessage()    {}
+func (*RequestDeliverTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{4}
+}
+func (m *RequestDeliverTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestDeliverTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestDeliverTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestDeliverTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestDeliverTxBatch.Merge(m, src)
+}
+func (m *RequestDeliverTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestDeliverTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestDeliverTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestDeliverTxBatch proto.InternalMessageInfo
+
+func (m *RequestDeliverTxBatch) GetTxs() []types.RequestDeliverTx {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *RequestDeliverTxBatch) GetHints() []TxHint {
+	if m != nil {
+		return m.Hints
+	}
+	return nil
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -497,6 +565,7 @@ type Response struct {
 	//	*Response_ApplySnapshotChunk
 	//	*Response_BeginRecheckTx
 	//	*Response_EndRecheckTx
+	//	*Response_DeliverTxBatch
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -504,7 +573,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{4}
+	return fileDescriptor_addf585b2317eb36, []int{5}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -593,6 +662,9 @@ type Response_BeginRecheckTx struct {
 type Response_EndRecheckTx struct {
 	EndRecheckTx *ResponseEndRecheckTx `protobuf:"bytes,1001,opt,name=end_recheck_tx,json=endRecheckTx,proto3,oneof" json:"end_recheck_tx,omitempty"`
 }
+type Response_DeliverTxBatch struct {
+	DeliverTxBatch *ResponseDeliverTxBatch `protobuf:"bytes,1002,opt,name=deliver_tx_batch,json=deliverTxBatch,proto3,oneof" json:"deliver_tx_batch,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -612,6 +684,7 @@ func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
 func (*Response_BeginRecheckTx) isResponse_Value()     {}
 func (*Response_EndRecheckTx) isResponse_Value()       {}
+func (*Response_DeliverTxBatch) isResponse_Value()     {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -746,6 +819,13 @@ func (m *Response) GetEndRecheckTx() *ResponseEndRecheckTx {
 	return nil
 }
 
+func (m *Response) GetDeliverTxBatch() *ResponseDeliverTxBatch {
+	if x, ok := m.GetValue().(*Response_DeliverTxBatch); ok {
+		return x.DeliverTxBatch
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -767,6 +847,7 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_ApplySnapshotChunk)(nil),
 		(*Response_BeginRecheckTx)(nil),
 		(*Response_EndRecheckTx)(nil),
+		(*Response_DeliverTxBatch)(nil),
 	}
 }
 
@@ -784,13 +865,16 @@ type ResponseCheckTx struct {
 	// mempool_error is set by Ostracon.
 	// ABCI applictions creating a ResponseCheckTX should not set mempool_error.
 	MempoolError string `protobuf:"bytes,11,opt,name=mempool_error,json=mempoolError,proto3" json:"mempool_error,omitempty"`
+	// hint declares the state keys the tx reads and writes. It is handed back to
+	// the application with RequestDeliverTxBatch.
+	Hint *TxHint `protobuf:"bytes,12,opt,name=hint,proto3" json:"hint,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{5}
+	return fileDescriptor_addf585b2317eb36, []int{6}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -896,6 +980,13 @@ func (m *ResponseCheckTx) GetMempoolError() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetHint() *TxHint {
+	if m != nil {
+		return m.Hint
+	}
+	return nil
+}
+
 type ResponseBeginRecheckTx struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 }
@@ -904,7 +995,7 @@ func (m *ResponseBeginRecheckTx) Reset()         { *m = ResponseBeginRecheckTx{}
 func (m *ResponseBeginRecheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginRecheckTx) ProtoMessage()    {}
 func (*ResponseBeginRecheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{6}
+	return fileDescriptor_addf585b2317eb36, []int{7}
 }
 func (m *ResponseBeginRecheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -948,7 +1039,7 @@ func (m *ResponseEndRecheckTx) Reset()         { *m = ResponseEndRecheckTx{} }
 func (m *ResponseEndRecheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndRecheckTx) ProtoMessage()    {}
 func (*ResponseEndRecheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_addf585b2317eb36, []int{7}
+	return fileDescriptor_addf585b2317eb36, []int{8}
 }
 func (m *ResponseEndRecheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -984,113 +1075,227 @@ func (m *ResponseEndRecheckTx) GetCode() uint32 {
 	return 0
 }
 
+// ResponseDeliverTxBatch must contain exactly one result per tx of the request,
+// in the same order.
+type ResponseDeliverTxBatch struct {
+	DeliverTxs []*types.ResponseDeliverTx `protobuf:"bytes,1,rep,name=deliver_txs,json=deliverTxs,proto3" json:"deliver_txs,omitempty"`
+}
+
+func (m *ResponseDeliverTxBatch) Reset()         { *m = ResponseDeliverTxBatch{} }
+func (m *ResponseDeliverTxBatch) String() string { return proto.CompactTextString(m) }
+func (*ResponseDeliverTxBatch) ProtoMessage()    {}
+func (*ResponseDeliverTxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{9}
+}
+func (m *ResponseDeliverTxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseDeliverTxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseDeliverTxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseDeliverTxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseDeliverTxBatch.Merge(m, src)
+}
+func (m *ResponseDeliverTxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseDeliverTxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseDeliverTxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseDeliverTxBatch proto.InternalMessageInfo
+
+func (m *ResponseDeliverTxBatch) GetDeliverTxs() []*types.ResponseDeliverTx {
+	if m != nil {
+		return m.DeliverTxs
+	}
+	return nil
+}
+
+// TxHint lists the keys of the application state that a tx reads and writes.
+// Txs whose hints do not conflict may be executed in parallel. Hints are
+// advisory only; the application must not rely on them for correctness.
+type TxHint struct {
+	ReadKeys  [][]byte `protobuf:"bytes,1,rep,name=read_keys,json=readKeys,proto3" json:"read_keys,omitempty"`
+	WriteKeys [][]byte `protobuf:"bytes,2,rep,name=write_keys,json=writeKeys,proto3" json:"write_keys,omitempty"`
+}
+
+func (m *TxHint) Reset()         { *m = TxHint{} }
+func (m *TxHint) String() string { return proto.CompactTextString(m) }
+func (*TxHint) ProtoMessage()    {}
+func (*TxHint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_addf585b2317eb36, []int{10}
+}
+func (m *TxHint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxHint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxHint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxHint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxHint.Merge(m, src)
+}
+func (m *TxHint) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxHint) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxHint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxHint proto.InternalMessageInfo
+
+func (m *TxHint) GetReadKeys() [][]byte {
+	if m != nil {
+		return m.ReadKeys
+	}
+	return nil
+}
+
+func (m *TxHint) GetWriteKeys() [][]byte {
+	if m != nil {
+		return m.WriteKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Request)(nil), "ostracon.abci.Request")
 	proto.RegisterType((*RequestBeginBlock)(nil), "ostracon.abci.RequestBeginBlock")
 	proto.RegisterType((*RequestBeginRecheckTx)(nil), "ostracon.abci.RequestBeginRecheckTx")
 	proto.RegisterType((*RequestEndRecheckTx)(nil), "ostracon.abci.RequestEndRecheckTx")
+	proto.RegisterType((*RequestDeliverTxBatch)(nil), "ostracon.abci.RequestDeliverTxBatch")
 	proto.RegisterType((*Response)(nil), "ostracon.abci.Response")
 	proto.RegisterType((*ResponseCheckTx)(nil), "ostracon.abci.ResponseCheckTx")
 	proto.RegisterType((*ResponseBeginRecheckTx)(nil), "ostracon.abci.ResponseBeginRecheckTx")
 	proto.RegisterType((*ResponseEndRecheckTx)(nil), "ostracon.abci.ResponseEndRecheckTx")
+	proto.RegisterType((*ResponseDeliverTxBatch)(nil), "ostracon.abci.ResponseDeliverTxBatch")
+	proto.RegisterType((*TxHint)(nil), "ostracon.abci.TxHint")
 }
 
 func init() { proto.RegisterFile("ostracon/abci/types.proto", fileDescriptor_addf585b2317eb36) }
 
 var fileDescriptor_addf585b2317eb36 = []byte{
-	// 1457 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x98, 0x4b, 0x8f, 0xdb, 0x54,
-	0x14, 0xc7, 0x33, 0x4d, 0x26, 0x19, 0x9f, 0xc9, 0xa4, 0xd3, 0xd3, 0xa1, 0xb8, 0x66, 0x9a, 0x29,
-	0x29, 0x85, 0x52, 0xca, 0x44, 0x9a, 0x8a, 0xaa, 0x08, 0x24, 0x68, 0xc2, 0x8c, 0x32, 0x50, 0x11,
-	0xf5, 0x16, 0x81, 0xc4, 0xa3, 0x91, 0x63, 0xdf, 0x24, 0x66, 0x1c, 0x5f, 0xd7, 0xbe, 0x19, 0x1a,
-	0xf6, 0xec, 0xf9, 0x26, 0x2c, 0xd9, 0xb1, 0xee, 0xb2, 0x4b, 0x16, 0xa8, 0x42, 0xed, 0x06, 0xf8,
-	0x14, 0xe8, 0x5e, 0x3f, 0xea, 0x3c, 0x1c, 0x7b, 0x76, 0xf7, 0x71, 0xce, 0xdf, 0xf7, 0x5c, 0x1f,
-	0x9f, 0x5f, 0x4e, 0xe0, 0x32, 0xf3, 0xb9, 0xa7, 0x1b, 0xcc, 0x69, 0xea, 0x7d, 0xc3, 0x6a, 0xf2,
-	0xa9, 0x4b, 0xfd, 0x7d, 0xd7, 0x63, 0x9c, 0xe1, 0x56, 0xb4, 0xb5, 0x2f, 0xb6, 0xb4, 0x2b, 0x9c,
-	0x3a, 0x26, 0xf5, 0xc6, 0x96, 0xc3, 0x9b, 0x86, 0x37, 0x75, 0x39, 0x6b, 0xba, 0x1e, 0x63, 0x83,
-	0xc0, 0x7a, 0x66, 0x5b, 0xaa, 0x34, 0x5d, 0xdd, 0xd3, 0xc7, 0xa1, 0x98, 0xf6, 0x46, 0x62, 0x7b,
-	0xfe, 0x49, 0xda, 0xee, 0x82, 0x6f, 0x72, 0x57, 0x8b, 0x8f, 0xb8, 0xb8, 0xb7, 0xbb, 0x78, 0xa8,
-	0x13, 0x3a, 0x8d, 0x76, 0xf7, 0x86, 0x8c, 0x0d, 0x6d, 0xda, 0x94, 0xb3, 0xfe, 0x64, 0xd0, 0xe4,
-	0xd6, 0x98, 0xfa, 0x5c, 0x1f, 0xbb, 0xa1, 0xc1, 0xce, 0x90, 0x0d, 0x99, 0x1c, 0x36, 0xc5, 0x28,
-	0x58, 0x6d, 0xfc, 0xa6, 0x40, 0x85, 0xd0, 0xc7, 0x13, 0xea, 0x73, 0x3c, 0x80, 0x12, 0x35, 0x46,
-	0x4c, 0x5d, 0xbb, 0xba, 0x76, 0x63, 0xf3, 0x60, 0x77, 0xff, 0xd5, 0xf3, 0xe4, 0xad, 0xec, 0x87,
-	0x76, 0x87, 0xc6, 0x88, 0x75, 0x0a, 0x44, 0xda, 0xe2, 0x07, 0xb0, 0x3e, 0xb0, 0x27, 0xfe, 0x48,
-	0x3d, 0x27, 0x9d, 0xae, 0xa4, 0x39, 0x1d, 0x09, 0xa3, 0x4e, 0x81, 0x04, 0xd6, 0xe2, 0x51, 0x96,
-	0x33, 0x60, 0x6a, 0x71, 0xf5, 0xa3, 0x8e, 0x9d, 0x81, 0x7c, 0x94, 0xb0, 0xc5, 0x16, 0x80, 0x4f,
-	0x79, 0x8f, 0xb9, 0xdc, 0x62, 0x8e, 0x5a, 0x92, 0x9e, 0x6f, 0xa6, 0x79, 0x3e, 0xa4, 0xbc, 0x2b,
-	0x0d, 0x3b, 0x05, 0xa2, 0xf8, 0xd1, 0x44, 0x68, 0x58, 0x8e, 0xc5, 0x7b, 0xc6, 0x48, 0xb7, 0x1c,
-	0x75, 0x7d, 0xb5, 0xc6, 0xb1, 0x63, 0xf1, 0xb6, 0x30, 0x14, 0x1a, 0x56, 0x34, 0x11, 0x21, 0x3f,
-	0x9e, 0x50, 0x6f, 0xaa, 0x96, 0x57, 0x87, 0xfc, 0x40, 0x18, 0x89, 0x90, 0xa5, 0x35, 0xb6, 0x61,
-	0xb3, 0x4f, 0x87, 0x96, 0xd3, 0xeb, 0xdb, 0xcc, 0x38, 0x51, 0x2b, 0xd2, 0xf9, 0xea, 0xfe, 0x4c,
-	0xe2, 0x45, 0xae, 0x2d, 0x61, 0xd8, 0x12, 0x76, 0x9d, 0x02, 0x81, 0x7e, 0x3c, 0xc3, 0x8f, 0x61,
-	0xc3, 0x18, 0x51, 0xe3, 0xa4, 0xc7, 0x9f, 0xa8, 0x1b, 0x52, 0x61, 0x2f, 0xed, 0xf1, 0x6d, 0x61,
-	0xf7, 0xd5, 0x93, 0x4e, 0x81, 0x54, 0x8c, 0x60, 0x28, 0xa2, 0x37, 0xa9, 0x6d, 0x9d, 0x52, 0x4f,
-	0xf8, 0x2b, 0xab, 0xa3, 0xff, 0x2c, 0xb0, 0x94, 0x0a, 0x8a, 0x19, 0x4d, 0xf0, 0x13, 0x50, 0xa8,
-	0x63, 0x86, 0x41, 0x40, 0x18, 0x44, 0x5a, 0xa6, 0x38, 0x66, 0x14, 0xc4, 0x06, 0x0d, 0xc7, 0x78,
-	0x17, 0xca, 0x06, 0x1b, 0x8f, 0x2d, 0xae, 0x6e, 0x4a, 0xef, 0x7a, 0x6a, 0x00, 0xd2, 0xaa, 0x53,
-	0x20, 0xa1, 0x3d, 0x7e, 0x09, 0x35, 0xdb, 0xf2, 0x79, 0xcf, 0x77, 0x74, 0xd7, 0x1f, 0x31, 0xee,
-	0xab, 0x55, 0xa9, 0x70, 0x3d, 0x4d, 0xe1, 0xbe, 0xe5, 0xf3, 0x87, 0x91, 0x71, 0xa7, 0x40, 0xb6,
-	0xec, 0xe4, 0x82, 0xd0, 0x63, 0x83, 0x01, 0xf5, 0x62, 0x41, 0x75, 0x6b, 0xb5, 0x5e, 0x57, 0x58,
-	0x47, 0xfe, 0x42, 0x8f, 0x25, 0x17, 0xf0, 0x3b, 0xb8, 0x68, 0x33, 0xdd, 0x8c, 0xe5, 0x7a, 0xc6,
-	0x68, 0xe2, 0x9c, 0xa8, 0x35, 0x29, 0xfa, 0x6e, 0xea, 0x21, 0x99, 0x6e, 0x46, 0x12, 0x6d, 0xe1,
-	0xd0, 0x29, 0x90, 0x0b, 0xf6, 0xfc, 0x22, 0x3e, 0x82, 0x1d, 0xdd, 0x75, 0xed, 0xe9, 0xbc, 0xfa,
-	0x79, 0xa9, 0x7e, 0x33, 0x4d, 0xfd, 0x9e, 0xf0, 0x99, 0x97, 0x47, 0x7d, 0x61, 0x15, 0x1f, 0xc0,
-	0x76, 0x90, 0x9e, 0x1e, 0x8d, 0x33, 0xec, 0x9f, 0x20, 0x49, 0xdf, 0x5a, 0x91, 0xa4, 0x84, 0x1a,
-	0x71, 0x9e, 0xd5, 0xfa, 0x33, 0x2b, 0xf8, 0x05, 0xd4, 0x44, 0xaa, 0x24, 0x04, 0xff, 0x0d, 0x04,
-	0x1b, 0xcb, 0x05, 0x0f, 0x1d, 0x33, 0x29, 0x57, 0xa5, 0x89, 0x79, 0xab, 0x02, 0xeb, 0xa7, 0xba,
-	0x3d, 0xa1, 0x8d, 0x3f, 0xce, 0xc1, 0x85, 0x85, 0xcf, 0x04, 0x11, 0x4a, 0x23, 0xdd, 0x1f, 0xc9,
-	0xda, 0x55, 0x25, 0x72, 0x8c, 0x77, 0xa0, 0x3c, 0xa2, 0xba, 0x49, 0xbd, 0xb0, 0x38, 0xa9, 0xc9,
-	0x4b, 0x0a, 0x2a, 0x6b, 0x47, 0xee, 0xb7, 0x4a, 0x4f, 0x9f, 0xef, 0x15, 0x48, 0x68, 0x8d, 0x5d,
-	0xd8, 0xb6, 0x75, 0x9f, 0xf7, 0x82, 0xb4, 0xeb, 0x25, 0x0a, 0xd5, 0xe2, 0xc7, 0x76, 0x5f, 0x8f,
-	0x12, 0x55, 0xd4, 0xaa, 0x50, 0xa8, 0x66, 0xcf, 0xac, 0x22, 0x81, 0x9d, 0xfe, 0xf4, 0x67, 0xdd,
-	0xe1, 0x96, 0x43, 0x7b, 0xa7, 0xba, 0x6d, 0x99, 0x3a, 0x67, 0x9e, 0xaf, 0x96, 0xae, 0x16, 0x6f,
-	0x6c, 0x1e, 0x5c, 0x5e, 0x10, 0x3d, 0x3c, 0xb5, 0x4c, 0xea, 0x18, 0x34, 0x94, 0xbb, 0x18, 0x3b,
-	0x7f, 0x1d, 0xfb, 0xe2, 0x5d, 0xa8, 0x50, 0x87, 0x7b, 0xcc, 0x9d, 0x46, 0xaf, 0xe9, 0xf5, 0x57,
-	0xb7, 0x1a, 0x04, 0x77, 0x18, 0xec, 0x87, 0x2a, 0x91, 0x79, 0xa3, 0x0b, 0xaf, 0x2d, 0x7d, 0x83,
-	0x89, 0xfb, 0x5a, 0x3b, 0xcb, 0x7d, 0x35, 0xde, 0x87, 0x8b, 0x4b, 0xde, 0x20, 0x5e, 0x12, 0x72,
-	0xd6, 0x70, 0xc4, 0xa5, 0x5c, 0x91, 0x84, 0xb3, 0xc6, 0x2f, 0x00, 0x1b, 0x84, 0xfa, 0x2e, 0x73,
-	0x7c, 0x8a, 0x2d, 0x50, 0xe8, 0x13, 0x83, 0x06, 0x35, 0x7d, 0x2d, 0xcc, 0x8e, 0xc5, 0x5c, 0x0e,
-	0xac, 0x0f, 0x23, 0x4b, 0x51, 0x92, 0x62, 0x37, 0xbc, 0x1d, 0x72, 0x2b, 0x1d, 0x41, 0xa1, 0x7b,
-	0x12, 0x5c, 0x77, 0x22, 0x70, 0x15, 0x53, 0xab, 0x50, 0xe0, 0x35, 0x47, 0xae, 0xdb, 0x21, 0xb9,
-	0x4a, 0x19, 0x0f, 0x9b, 0x41, 0x57, 0x7b, 0x06, 0x5d, 0xeb, 0x19, 0x61, 0xa6, 0xb0, 0xab, 0x3d,
-	0xc3, 0xae, 0x72, 0x86, 0x48, 0x0a, 0xbc, 0xee, 0x44, 0xf0, 0xaa, 0x64, 0x84, 0x3d, 0x47, 0xaf,
-	0xa3, 0x59, 0x7a, 0x05, 0xec, 0xb9, 0x96, 0xea, 0x9d, 0x0a, 0xb0, 0x8f, 0x12, 0x00, 0x53, 0xc2,
-	0x23, 0xcc, 0x17, 0x83, 0x40, 0x62, 0x09, 0xbf, 0xda, 0x33, 0xfc, 0x82, 0x8c, 0x1b, 0x48, 0x01,
-	0xd8, 0xa7, 0x49, 0x80, 0x6d, 0xa6, 0x32, 0x30, 0x4c, 0x99, 0x65, 0x04, 0xfb, 0x30, 0x26, 0x58,
-	0x35, 0x15, 0xc1, 0x61, 0x0c, 0xf3, 0x08, 0xeb, 0x2e, 0x20, 0x2c, 0x40, 0xce, 0xdb, 0xa9, 0x12,
-	0x19, 0x0c, 0xeb, 0x2e, 0x30, 0xac, 0x96, 0x21, 0x98, 0x01, 0xb1, 0xef, 0x97, 0x43, 0x2c, 0x1d,
-	0x33, 0xe1, 0x31, 0xf3, 0x51, 0xac, 0x97, 0x42, 0xb1, 0x6d, 0x29, 0xff, 0x5e, 0xaa, 0x7c, 0x6e,
-	0x8c, 0x91, 0x74, 0x8c, 0x5d, 0x4f, 0x49, 0xb4, 0x4c, 0x8e, 0xdd, 0x4f, 0xe3, 0xd8, 0xb5, 0x14,
-	0xc5, 0x7c, 0x20, 0xfb, 0xeb, 0x1c, 0x9c, 0x9f, 0x4b, 0x76, 0x81, 0x31, 0x83, 0x99, 0x54, 0x56,
-	0xc2, 0x2d, 0x22, 0xc7, 0x62, 0xcd, 0xd4, 0xb9, 0x2e, 0xcb, 0x5b, 0x95, 0xc8, 0x31, 0x6e, 0x43,
-	0xd1, 0x66, 0x43, 0x59, 0xbb, 0x14, 0x22, 0x86, 0xc2, 0x2a, 0xae, 0x4b, 0x4a, 0x58, 0x76, 0xea,
-	0x00, 0x43, 0xdd, 0xef, 0xfd, 0xa4, 0x3b, 0x9c, 0x9a, 0xb2, 0xec, 0x14, 0x49, 0x62, 0x05, 0x35,
-	0xd8, 0x10, 0xb3, 0x89, 0x4f, 0x4d, 0x59, 0x4f, 0x8a, 0x24, 0x9e, 0x63, 0x07, 0xca, 0xf4, 0x94,
-	0x3a, 0xdc, 0x57, 0x2b, 0x92, 0x52, 0x97, 0x96, 0x50, 0x8a, 0x3a, 0xbc, 0xa5, 0x0a, 0x14, 0xfc,
-	0xf7, 0x7c, 0x6f, 0x3b, 0xb0, 0xbe, 0xc5, 0xc6, 0x16, 0xa7, 0x63, 0x97, 0x4f, 0x49, 0xe8, 0x8f,
-	0xbb, 0xa0, 0x88, 0x38, 0x7c, 0x57, 0x37, 0xa8, 0x2c, 0x1c, 0x0a, 0x79, 0xb5, 0x20, 0x28, 0xe1,
-	0x4b, 0x61, 0x59, 0x0e, 0x14, 0x12, 0xce, 0xc4, 0xd9, 0x5c, 0xcf, 0x62, 0x9e, 0xc5, 0xa7, 0xf2,
-	0x4b, 0x2f, 0x92, 0x78, 0x8e, 0xd7, 0x60, 0x6b, 0x4c, 0xc7, 0x2e, 0x63, 0x76, 0x8f, 0x7a, 0x1e,
-	0xf3, 0xe4, 0x67, 0xac, 0x90, 0x6a, 0xb8, 0x78, 0x28, 0xd6, 0x1a, 0xb7, 0xe0, 0xd2, 0xf2, 0x37,
-	0xbc, 0xec, 0x92, 0x1b, 0x37, 0x61, 0x67, 0xd9, 0xdb, 0x5b, 0x66, 0x7b, 0xf0, 0xfb, 0x26, 0x9c,
-	0xbf, 0xd7, 0x6a, 0x1f, 0x8b, 0xa4, 0xb4, 0x0c, 0x3d, 0x2c, 0xce, 0x25, 0x81, 0x17, 0x5c, 0xd9,
-	0x35, 0x69, 0xab, 0xd9, 0x84, 0x47, 0xb0, 0x2e, 0x69, 0x83, 0xab, 0xdb, 0x28, 0x2d, 0x03, 0x56,
-	0xe2, 0x30, 0xf2, 0x77, 0xc7, 0xca, 0xbe, 0x4a, 0x5b, 0xcd, 0x2e, 0x24, 0xa0, 0xc4, 0x20, 0xc2,
-	0xec, 0x3e, 0x4b, 0xcb, 0xc1, 0x33, 0xa1, 0x19, 0x57, 0x65, 0xcc, 0xee, 0x3c, 0xb4, 0x1c, 0xc5,
-	0x1d, 0x3f, 0x87, 0x4a, 0xf4, 0xf5, 0x64, 0xf5, 0x42, 0x5a, 0x06, 0x6b, 0xc4, 0x0b, 0x90, 0xdc,
-	0xc3, 0xd5, 0x4d, 0x9d, 0x96, 0x81, 0x4d, 0x3c, 0x86, 0x72, 0x50, 0xfa, 0x31, 0xa3, 0xbb, 0xd1,
-	0xb2, 0xd8, 0x21, 0xae, 0x2c, 0x46, 0x39, 0x66, 0xb7, 0xaa, 0x5a, 0x8e, 0x5f, 0x04, 0xf8, 0x10,
-	0x20, 0xf1, 0xd3, 0x39, 0xb3, 0x07, 0xd5, 0xf2, 0x70, 0x1e, 0xbb, 0xb0, 0x11, 0xd1, 0x12, 0x33,
-	0x3b, 0x42, 0x2d, 0x1b, 0xb9, 0xf8, 0x08, 0xb6, 0x66, 0xe0, 0x87, 0xf9, 0xfa, 0x3c, 0x2d, 0x27,
-	0x4b, 0x85, 0xfe, 0x0c, 0x0b, 0x31, 0x5f, 0xdf, 0xa7, 0xe5, 0x44, 0x2b, 0xfe, 0x08, 0x17, 0x16,
-	0xa8, 0x88, 0xf9, 0xdb, 0x40, 0xed, 0x0c, 0xb0, 0xc5, 0x31, 0xe0, 0x22, 0x22, 0xf1, 0x0c, 0x5d,
-	0xa1, 0x76, 0x16, 0xf6, 0xe2, 0x0f, 0x50, 0x9b, 0xab, 0xa9, 0xb9, 0x7a, 0x44, 0x2d, 0x1f, 0x82,
-	0xf1, 0x1b, 0xa8, 0xce, 0x14, 0xe1, 0x1c, 0xfd, 0xa2, 0x96, 0x87, 0xc5, 0xad, 0x7b, 0x4f, 0x5f,
-	0xd4, 0xd7, 0x9e, 0xbd, 0xa8, 0xaf, 0xfd, 0xfd, 0xa2, 0xbe, 0xf6, 0xeb, 0xcb, 0x7a, 0xe1, 0xd9,
-	0xcb, 0x7a, 0xe1, 0xcf, 0x97, 0xf5, 0xc2, 0xb7, 0xef, 0x0c, 0x2d, 0x3e, 0x9a, 0xf4, 0xf7, 0x0d,
-	0x36, 0x6e, 0x1e, 0x59, 0x8e, 0x6f, 0x8c, 0x2c, 0xbd, 0xb9, 0xe4, 0xff, 0xc2, 0x7e, 0x59, 0xfe,
-	0x6f, 0x76, 0xfb, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0x38, 0x72, 0x1d, 0x52, 0x4d, 0x14, 0x00,
-	0x00,
+	// 1624 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x98, 0xdf, 0x73, 0xd3, 0xc6,
+	0x16, 0xc7, 0xed, 0xd8, 0xb1, 0xa3, 0x13, 0x27, 0x84, 0x25, 0xe4, 0x0a, 0x11, 0x9c, 0x5c, 0x73,
+	0xb9, 0x05, 0x4a, 0xe3, 0x69, 0x98, 0x32, 0x30, 0xed, 0x4c, 0x8b, 0x4d, 0x32, 0x4e, 0x61, 0x9a,
+	0x61, 0x61, 0xda, 0x99, 0xb6, 0xe0, 0x91, 0xa5, 0xb5, 0xa5, 0x46, 0xd6, 0x0a, 0x69, 0x1d, 0xe2,
+	0x3e, 0x76, 0xa6, 0xd3, 0xd7, 0x3e, 0xf5, 0xcf, 0xe9, 0x33, 0x8f, 0x3c, 0xb6, 0x2f, 0x4c, 0x07,
+	0x5e, 0x5a, 0xfe, 0x8a, 0xce, 0xae, 0x7e, 0x44, 0xb6, 0x25, 0x4b, 0x79, 0xdb, 0x1f, 0xe7, 0x7c,
+	0xb5, 0x2b, 0x1d, 0x9d, 0xcf, 0xd9, 0x85, 0x4b, 0xd4, 0x63, 0xae, 0xaa, 0x51, 0xbb, 0xa9, 0xf6,
+	0x34, 0xb3, 0xc9, 0xc6, 0x0e, 0xf1, 0x76, 0x1c, 0x97, 0x32, 0x8a, 0x56, 0xc2, 0xa9, 0x1d, 0x3e,
+	0xa5, 0x5c, 0x61, 0xc4, 0xd6, 0x89, 0x3b, 0x34, 0x6d, 0xd6, 0xd4, 0xdc, 0xb1, 0xc3, 0x68, 0xd3,
+	0x71, 0x29, 0xed, 0xfb, 0xd6, 0x13, 0xd3, 0x42, 0xa5, 0xe9, 0xa8, 0xae, 0x3a, 0x0c, 0xc4, 0x94,
+	0xcb, 0xb1, 0xe9, 0xe9, 0x27, 0x29, 0x9b, 0x33, 0xbe, 0xf1, 0x59, 0x25, 0x5a, 0xe2, 0xec, 0xdc,
+	0xe6, 0xec, 0xa2, 0x8e, 0xc8, 0x38, 0x9c, 0xdd, 0x1a, 0x50, 0x3a, 0xb0, 0x48, 0x53, 0xf4, 0x7a,
+	0xa3, 0x7e, 0x93, 0x99, 0x43, 0xe2, 0x31, 0x75, 0xe8, 0x04, 0x06, 0xeb, 0x03, 0x3a, 0xa0, 0xa2,
+	0xd9, 0xe4, 0x2d, 0x7f, 0xb4, 0xf1, 0x13, 0x40, 0x15, 0x93, 0x17, 0x23, 0xe2, 0x31, 0xb4, 0x0b,
+	0x65, 0xa2, 0x19, 0x54, 0x2e, 0x6e, 0x17, 0xaf, 0x2f, 0xef, 0x6e, 0xee, 0x9c, 0x3e, 0x4f, 0xbc,
+	0x95, 0x9d, 0xc0, 0x6e, 0x4f, 0x33, 0x68, 0xa7, 0x80, 0x85, 0x2d, 0xfa, 0x04, 0x16, 0xfb, 0xd6,
+	0xc8, 0x33, 0xe4, 0x05, 0xe1, 0x74, 0x25, 0xcd, 0x69, 0x9f, 0x1b, 0x75, 0x0a, 0xd8, 0xb7, 0xe6,
+	0x8f, 0x32, 0xed, 0x3e, 0x95, 0x4b, 0xf3, 0x1f, 0x75, 0x60, 0xf7, 0xc5, 0xa3, 0xb8, 0x2d, 0x6a,
+	0x01, 0x78, 0x84, 0x75, 0xa9, 0xc3, 0x4c, 0x6a, 0xcb, 0x65, 0xe1, 0xf9, 0xdf, 0x34, 0xcf, 0x27,
+	0x84, 0x1d, 0x0a, 0xc3, 0x4e, 0x01, 0x4b, 0x5e, 0xd8, 0xe1, 0x1a, 0xa6, 0x6d, 0xb2, 0xae, 0x66,
+	0xa8, 0xa6, 0x2d, 0x2f, 0xce, 0xd7, 0x38, 0xb0, 0x4d, 0xd6, 0xe6, 0x86, 0x5c, 0xc3, 0x0c, 0x3b,
+	0x7c, 0xcb, 0x2f, 0x46, 0xc4, 0x1d, 0xcb, 0x95, 0xf9, 0x5b, 0x7e, 0xcc, 0x8d, 0xf8, 0x96, 0x85,
+	0x35, 0x6a, 0xc3, 0x72, 0x8f, 0x0c, 0x4c, 0xbb, 0xdb, 0xb3, 0xa8, 0x76, 0x24, 0x57, 0x85, 0xf3,
+	0xf6, 0xce, 0x44, 0xe0, 0x85, 0xae, 0x2d, 0x6e, 0xd8, 0xe2, 0x76, 0x9d, 0x02, 0x86, 0x5e, 0xd4,
+	0x43, 0x9f, 0xc1, 0x92, 0x66, 0x10, 0xed, 0xa8, 0xcb, 0x4e, 0xe4, 0x25, 0xa1, 0xb0, 0x95, 0xf6,
+	0xf8, 0x36, 0xb7, 0x7b, 0x7a, 0xd2, 0x29, 0xe0, 0xaa, 0xe6, 0x37, 0xf9, 0xee, 0x75, 0x62, 0x99,
+	0xc7, 0xc4, 0xe5, 0xfe, 0xd2, 0xfc, 0xdd, 0x3f, 0xf0, 0x2d, 0x85, 0x82, 0xa4, 0x87, 0x1d, 0xf4,
+	0x39, 0x48, 0xc4, 0xd6, 0x83, 0x4d, 0x40, 0xb0, 0x89, 0xb4, 0x48, 0xb1, 0xf5, 0x70, 0x13, 0x4b,
+	0x24, 0x68, 0xa3, 0xbb, 0x50, 0xd1, 0xe8, 0x70, 0x68, 0x32, 0x79, 0x59, 0x78, 0xd7, 0x53, 0x37,
+	0x20, 0xac, 0x3a, 0x05, 0x1c, 0xd8, 0xa3, 0xaf, 0x60, 0xd5, 0x32, 0x3d, 0xd6, 0xf5, 0x6c, 0xd5,
+	0xf1, 0x0c, 0xca, 0x3c, 0xb9, 0x26, 0x14, 0xae, 0xa5, 0x29, 0x3c, 0x32, 0x3d, 0xf6, 0x24, 0x34,
+	0xee, 0x14, 0xf0, 0x8a, 0x15, 0x1f, 0xe0, 0x7a, 0xb4, 0xdf, 0x27, 0x6e, 0x24, 0x28, 0xaf, 0xcc,
+	0xd7, 0x3b, 0xe4, 0xd6, 0xa1, 0x3f, 0xd7, 0xa3, 0xf1, 0x01, 0xf4, 0x1d, 0x5c, 0xb0, 0xa8, 0xaa,
+	0x47, 0x72, 0x5d, 0xcd, 0x18, 0xd9, 0x47, 0xf2, 0xaa, 0x10, 0xbd, 0x91, 0xba, 0x48, 0xaa, 0xea,
+	0xa1, 0x44, 0x9b, 0x3b, 0x74, 0x0a, 0xf8, 0xbc, 0x35, 0x3d, 0x88, 0x9e, 0xc3, 0xba, 0xea, 0x38,
+	0xd6, 0x78, 0x5a, 0xfd, 0x9c, 0x50, 0xbf, 0x99, 0xa6, 0x7e, 0x9f, 0xfb, 0x4c, 0xcb, 0x23, 0x75,
+	0x66, 0x14, 0x3d, 0x86, 0x35, 0x3f, 0x3c, 0x5d, 0x12, 0x45, 0xd8, 0xdf, 0x7e, 0x90, 0xfe, 0x6f,
+	0x4e, 0x90, 0x62, 0xa2, 0x45, 0x71, 0xb6, 0xda, 0x9b, 0x18, 0x41, 0x0f, 0x61, 0x95, 0x87, 0x4a,
+	0x4c, 0xf0, 0x1f, 0x5f, 0xb0, 0x91, 0x2c, 0xb8, 0x67, 0xeb, 0x71, 0xb9, 0x1a, 0x89, 0xf5, 0xf9,
+	0xfa, 0x4e, 0x63, 0xb7, 0xdb, 0x53, 0x99, 0x66, 0xc8, 0xef, 0xe7, 0xae, 0x2f, 0x0a, 0xe0, 0x16,
+	0x37, 0xe6, 0xeb, 0xd3, 0x27, 0x46, 0x5a, 0x55, 0x58, 0x3c, 0x56, 0xad, 0x11, 0x69, 0xfc, 0xbe,
+	0x00, 0xe7, 0x67, 0xfe, 0x3c, 0x84, 0xa0, 0x6c, 0xa8, 0x9e, 0x21, 0xd2, 0x61, 0x0d, 0x8b, 0x36,
+	0xba, 0x03, 0x15, 0x83, 0xa8, 0x3a, 0x71, 0x83, 0x7c, 0x27, 0xc7, 0xdf, 0xbb, 0x9f, 0xac, 0x3b,
+	0x62, 0xbe, 0x55, 0x7e, 0xf5, 0x66, 0xab, 0x80, 0x03, 0x6b, 0x74, 0x08, 0x6b, 0x96, 0xea, 0xb1,
+	0xae, 0x1f, 0xc9, 0xdd, 0x58, 0xee, 0x9b, 0xfd, 0x7f, 0x1f, 0xa9, 0x61, 0xec, 0xf3, 0xf4, 0x17,
+	0x08, 0xad, 0x5a, 0x13, 0xa3, 0x08, 0xc3, 0x7a, 0x6f, 0xfc, 0xa3, 0x6a, 0x33, 0xd3, 0x26, 0xdd,
+	0x63, 0xd5, 0x32, 0x75, 0x95, 0x51, 0xd7, 0x93, 0xcb, 0xdb, 0xa5, 0xeb, 0xcb, 0xbb, 0x97, 0x66,
+	0x44, 0xf7, 0x8e, 0x4d, 0x9d, 0xd8, 0x1a, 0x09, 0xe4, 0x2e, 0x44, 0xce, 0x5f, 0x47, 0xbe, 0xe8,
+	0x2e, 0x54, 0x89, 0xcd, 0x5c, 0xea, 0x8c, 0xc3, 0x2f, 0xff, 0x9f, 0xd3, 0x37, 0xeb, 0x6f, 0x6e,
+	0xcf, 0x9f, 0x0f, 0x54, 0x42, 0xf3, 0xc6, 0x21, 0x5c, 0x4c, 0x0c, 0x8a, 0xd8, 0xfb, 0x2a, 0x9e,
+	0xe5, 0x7d, 0x35, 0x3e, 0x82, 0x0b, 0x09, 0x41, 0x81, 0x36, 0xb8, 0x9c, 0x39, 0x30, 0x98, 0x90,
+	0x2b, 0xe1, 0xa0, 0xd7, 0xf8, 0xb9, 0x08, 0x17, 0x13, 0xbf, 0x3a, 0xba, 0x07, 0x25, 0x76, 0xe2,
+	0xc9, 0xc5, 0xed, 0x52, 0xae, 0x5c, 0x17, 0x2c, 0x83, 0xfb, 0xa0, 0x8f, 0x61, 0xd1, 0x30, 0x6d,
+	0xe6, 0xc9, 0x0b, 0xc2, 0xf9, 0xe2, 0x54, 0x94, 0x3d, 0x3d, 0xe9, 0x98, 0x36, 0x0b, 0x1c, 0x7c,
+	0xcb, 0xc6, 0x9f, 0x00, 0x4b, 0x98, 0x78, 0x0e, 0xb5, 0x3d, 0x82, 0x5a, 0x20, 0x91, 0x13, 0x8d,
+	0xf8, 0xb8, 0x2a, 0x06, 0x81, 0x3f, 0xbb, 0x00, 0xdf, 0x7a, 0x2f, 0xb4, 0xe4, 0xd9, 0x36, 0x72,
+	0x43, 0xb7, 0x03, 0x24, 0xa7, 0xd3, 0x35, 0x70, 0x8f, 0x33, 0xf9, 0x4e, 0xc8, 0xe4, 0x52, 0x6a,
+	0x82, 0xf5, 0xbd, 0xa6, 0xa0, 0x7c, 0x3b, 0x80, 0x72, 0x39, 0xe3, 0x61, 0x13, 0x54, 0x6e, 0x4f,
+	0x50, 0x79, 0x31, 0x63, 0x9b, 0x29, 0x58, 0x6e, 0x4f, 0x60, 0xb9, 0x92, 0x21, 0x92, 0xc2, 0xe5,
+	0x3b, 0x21, 0x97, 0xab, 0x19, 0xdb, 0x9e, 0x02, 0xf3, 0xfe, 0x24, 0x98, 0x7d, 0xac, 0x5e, 0x4d,
+	0xf5, 0x4e, 0x65, 0xf3, 0xa7, 0x31, 0x36, 0x4b, 0xc1, 0x12, 0xa6, 0x13, 0x93, 0x2f, 0x91, 0x80,
+	0xe6, 0xf6, 0x04, 0x9a, 0x21, 0xe3, 0x0d, 0xa4, 0xb0, 0xf9, 0x8b, 0x38, 0x9b, 0x97, 0x53, 0xf1,
+	0x1e, 0x84, 0x4c, 0x12, 0x9c, 0xef, 0x45, 0x70, 0xae, 0xa5, 0x56, 0x17, 0xc1, 0x1e, 0xa6, 0xe9,
+	0x7c, 0x38, 0x43, 0x67, 0x9f, 0xa6, 0xff, 0x4f, 0x95, 0xc8, 0xc0, 0xf3, 0xe1, 0x0c, 0x9e, 0x57,
+	0x33, 0x04, 0x33, 0xf8, 0xfc, 0x7d, 0x32, 0x9f, 0xd3, 0x09, 0x1a, 0x2c, 0x33, 0x1f, 0xa0, 0xbb,
+	0x29, 0x80, 0x5e, 0x13, 0xf2, 0x1f, 0xa6, 0xca, 0xe7, 0x26, 0x34, 0x4e, 0x27, 0xf4, 0xb5, 0x94,
+	0x40, 0xcb, 0x44, 0xf4, 0xa3, 0x34, 0x44, 0x5f, 0x4d, 0x51, 0x9c, 0xcb, 0x68, 0x9c, 0xce, 0xe8,
+	0xb4, 0x15, 0xe6, 0x87, 0xf4, 0x2f, 0x25, 0x38, 0x37, 0xf5, 0x03, 0x71, 0x44, 0x6b, 0x54, 0x27,
+	0x22, 0xbb, 0xae, 0x60, 0xd1, 0xe6, 0x63, 0xba, 0xca, 0x54, 0x91, 0x32, 0x6b, 0x58, 0xb4, 0xd1,
+	0x1a, 0x94, 0x2c, 0x3a, 0x10, 0xf9, 0x50, 0xc2, 0xbc, 0xc9, 0xad, 0xa2, 0x5c, 0x27, 0x05, 0xa9,
+	0xac, 0x0e, 0x30, 0x50, 0xbd, 0xee, 0x4b, 0xd5, 0x66, 0x44, 0x17, 0xa9, 0xac, 0x84, 0x63, 0x23,
+	0x48, 0x81, 0x25, 0xde, 0x1b, 0x79, 0x44, 0x17, 0x39, 0xaa, 0x84, 0xa3, 0x3e, 0xea, 0x40, 0x85,
+	0x1c, 0x13, 0x4e, 0x8b, 0xaa, 0xa0, 0xc5, 0x46, 0x02, 0x81, 0x89, 0xcd, 0x5a, 0x32, 0xc7, 0xc5,
+	0xfb, 0x37, 0x5b, 0x6b, 0xbe, 0xf5, 0x2d, 0x3a, 0x34, 0x19, 0x19, 0x3a, 0x6c, 0x8c, 0x03, 0x7f,
+	0xb4, 0x09, 0x12, 0xdf, 0x87, 0xe7, 0xa8, 0x1a, 0x11, 0xc9, 0x48, 0xc2, 0xa7, 0x03, 0x9c, 0x80,
+	0x9e, 0x10, 0x16, 0x29, 0x46, 0xc2, 0x41, 0x8f, 0xaf, 0xcd, 0x71, 0x4d, 0xea, 0x9a, 0x6c, 0x2c,
+	0xb2, 0x47, 0x09, 0x47, 0x7d, 0x74, 0x15, 0x56, 0x86, 0x64, 0xe8, 0x50, 0x6a, 0x75, 0x89, 0xeb,
+	0x52, 0x57, 0xa4, 0x06, 0x09, 0xd7, 0x82, 0xc1, 0x3d, 0x3e, 0x86, 0x6e, 0x40, 0x99, 0x33, 0x2c,
+	0xf8, 0xef, 0x93, 0x61, 0x87, 0x85, 0x49, 0xe3, 0x16, 0x6c, 0x24, 0x07, 0x58, 0xd2, 0xf7, 0x68,
+	0xdc, 0x84, 0xf5, 0xa4, 0xe0, 0x49, 0xb4, 0x7d, 0x06, 0x1b, 0xc9, 0x81, 0xc1, 0x4f, 0x4f, 0xa7,
+	0xa1, 0x15, 0xf2, 0x3c, 0x47, 0x82, 0xc4, 0x10, 0xc5, 0x93, 0xd7, 0x78, 0x00, 0x15, 0x7f, 0x23,
+	0xe8, 0x32, 0x48, 0x2e, 0x51, 0xf5, 0x2e, 0x3f, 0x40, 0x0b, 0xb1, 0x1a, 0x5e, 0xe2, 0x03, 0x0f,
+	0xc9, 0xd8, 0x43, 0x57, 0x00, 0x5e, 0xba, 0x26, 0x23, 0xfe, 0xec, 0x82, 0x98, 0x95, 0xc4, 0x08,
+	0x9f, 0xde, 0xfd, 0xad, 0x06, 0xe7, 0xee, 0xb7, 0xda, 0x07, 0xfc, 0xc7, 0x35, 0x35, 0x35, 0x00,
+	0x58, 0x99, 0x23, 0x18, 0xcd, 0x3d, 0x34, 0x2b, 0xf3, 0xf9, 0x8d, 0xf6, 0x61, 0x51, 0x10, 0x19,
+	0xcd, 0x3f, 0x45, 0x2b, 0x19, 0x40, 0xe7, 0x8b, 0x11, 0x35, 0xe2, 0xdc, 0x63, 0xb5, 0x32, 0x9f,
+	0xef, 0x08, 0x83, 0x14, 0xc1, 0x1a, 0x65, 0x1f, 0xb3, 0x95, 0x1c, 0xcc, 0xe7, 0x9a, 0xd1, 0x87,
+	0x41, 0xd9, 0xc5, 0x98, 0x92, 0xe3, 0xfb, 0xa2, 0x2f, 0xa1, 0x1a, 0x66, 0x83, 0xac, 0xa3, 0xb0,
+	0x92, 0xc1, 0x63, 0xfe, 0x01, 0x44, 0x6d, 0x80, 0xe6, 0x9f, 0xe9, 0x95, 0x8c, 0xd2, 0x02, 0x1d,
+	0x40, 0xc5, 0xc7, 0x23, 0xca, 0x38, 0xdc, 0x2a, 0x59, 0x7c, 0xe5, 0xaf, 0x2c, 0x2a, 0x77, 0x50,
+	0xf6, 0x4d, 0x85, 0x92, 0xa3, 0x6a, 0x42, 0x4f, 0x00, 0x62, 0xc7, 0x9c, 0xcc, 0x2b, 0x08, 0x25,
+	0x4f, 0x2d, 0x84, 0x0e, 0x61, 0x29, 0xac, 0x28, 0x50, 0xe6, 0x85, 0x80, 0x92, 0x5d, 0x96, 0xa0,
+	0xe7, 0xb0, 0x32, 0x51, 0x20, 0xa0, 0x7c, 0xc7, 0x7c, 0x25, 0x67, 0xbd, 0xc1, 0xf5, 0x27, 0xea,
+	0x05, 0x94, 0xef, 0xd8, 0xaf, 0xe4, 0x2c, 0x3f, 0xd0, 0x0f, 0x70, 0x7e, 0xa6, 0x72, 0x40, 0xf9,
+	0x6f, 0x01, 0x94, 0x33, 0x14, 0x24, 0x68, 0x08, 0x68, 0xb6, 0x8c, 0x40, 0x67, 0xb8, 0x14, 0x50,
+	0xce, 0x52, 0x9f, 0xa0, 0x67, 0xb0, 0x3a, 0x95, 0xf8, 0x73, 0x5d, 0x11, 0x28, 0xf9, 0xca, 0x14,
+	0xf4, 0x0d, 0xd4, 0x26, 0x48, 0x91, 0xe3, 0xba, 0x40, 0xc9, 0x53, 0xaf, 0xf0, 0x75, 0x4f, 0x61,
+	0x25, 0xd7, 0xd5, 0x81, 0x92, 0xaf, 0x78, 0x69, 0xdd, 0x7f, 0xf5, 0xb6, 0x5e, 0x7c, 0xfd, 0xb6,
+	0x5e, 0xfc, 0xeb, 0x6d, 0xbd, 0xf8, 0xeb, 0xbb, 0x7a, 0xe1, 0xf5, 0xbb, 0x7a, 0xe1, 0x8f, 0x77,
+	0xf5, 0xc2, 0xb7, 0x1f, 0x0c, 0x4c, 0x66, 0x8c, 0x7a, 0x3b, 0x1a, 0x1d, 0x36, 0xf7, 0x4d, 0xdb,
+	0xd3, 0x0c, 0x53, 0x6d, 0x26, 0xdc, 0x46, 0xf7, 0x2a, 0xe2, 0x56, 0xf6, 0xf6, 0xbf, 0x03, 0x00,
+	0xe2, 0x73, 0xdb, 0xd3, 0xab, 0x16, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1122,6 +1327,7 @@ type ABCIApplicationClient interface {
 	ApplySnapshotChunk(ctx context.Context, in *types.RequestApplySnapshotChunk, opts ...grpc.CallOption) (*types.ResponseApplySnapshotChunk, error)
 	BeginRecheckTx(ctx context.Context, in *RequestBeginRecheckTx, opts ...grpc.CallOption) (*ResponseBeginRecheckTx, error)
 	EndRecheckTx(ctx context.Context, in *RequestEndRecheckTx, opts ...grpc.CallOption) (*ResponseEndRecheckTx, error)
+	DeliverTxBatch(ctx context.Context, in *RequestDeliverTxBatch, opts ...grpc.CallOption) (*ResponseDeliverTxBatch, error)
 }
 
 type aBCIApplicationClient struct {
@@ -1285,6 +1491,15 @@ func (c *aBCIApplicationClient) EndRecheckTx(ctx context.Context, in *RequestEnd
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) DeliverTxBatch(ctx context.Context, in *RequestDeliverTxBatch, opts ...grpc.CallOption) (*ResponseDeliverTxBatch, error) {
+	out := new(ResponseDeliverTxBatch)
+	err := c.cc.Invoke(ctx, "/ostracon.abci.ABCIApplication/DeliverTxBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *types.RequestEcho) (*types.ResponseEcho, error)
@@ -1304,6 +1519,7 @@ type ABCIApplicationServer interface {
 	ApplySnapshotChunk(context.Context, *types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
 	BeginRecheckTx(context.Context, *RequestBeginRecheckTx) (*ResponseBeginRecheckTx, error)
 	EndRecheckTx(context.Context, *RequestEndRecheckTx) (*ResponseEndRecheckTx, error)
+	DeliverTxBatch(context.Context, *RequestDeliverTxBatch) (*ResponseDeliverTxBatch, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -1361,6 +1577,9 @@ func (*UnimplementedABCIApplicationServer) BeginRecheckTx(ctx context.Context, r
 func (*UnimplementedABCIApplicationServer) EndRecheckTx(ctx context.Context, req *RequestEndRecheckTx) (*ResponseEndRecheckTx, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EndRecheckTx not implemented")
 }
+func (*UnimplementedABCIApplicationServer) DeliverTxBatch(ctx context.Context, req *RequestDeliverTxBatch) (*ResponseDeliverTxBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeliverTxBatch not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -1672,6 +1891,24 @@ func _ABCIApplication_EndRecheckTx_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_DeliverTxBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestDeliverTxBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).DeliverTxBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ostracon.abci.ABCIApplication/DeliverTxBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).DeliverTxBatch(ctx, req.(*RequestDeliverTxBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ostracon.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -1744,6 +1981,10 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EndRecheckTx",
 			Handler:    _ABCIApplication_EndRecheckTx_Handler,
 		},
+		{
+			MethodName: "DeliverTxBatch",
+			Handler:    _ABCIApplication_DeliverTxBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ostracon/abci/types.proto",
@@ -2142,6 +2383,29 @@ func (m *Request_EndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_DeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_DeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DeliverTxBatch != nil {
+		{
+			size, err := m.DeliverTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xd2
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestBeginBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2279,6 +2543,57 @@ func (m *RequestEndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RequestDeliverTxBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestDeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestDeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hints) > 0 {
+		for iNdEx := len(m.Hints) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hints[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Txs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2695,6 +3010,29 @@ func (m *Response_EndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_DeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_DeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DeliverTxBatch != nil {
+		{
+			size, err := m.DeliverTxBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xd2
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseCheckTx) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2715,6 +3053,18 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Hint != nil {
+		{
+			size, err := m.Hint.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
 	if len(m.MempoolError) > 0 {
 		i -= len(m.MempoolError)
 		copy(dAtA[i:], m.MempoolError)
@@ -2850,38 +3200,116 @@ func (m *ResponseEndRecheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ResponseDeliverTxBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Value != nil {
-		n += m.Value.Size()
-	}
-	return n
+
+func (m *ResponseDeliverTxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Request_Echo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ResponseDeliverTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Echo != nil {
-		l = m.Echo.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.DeliverTxs) > 0 {
+		for iNdEx := len(m.DeliverTxs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeliverTxs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TxHint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxHint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxHint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.WriteKeys) > 0 {
+		for iNdEx := len(m.WriteKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.WriteKeys[iNdEx])
+			copy(dAtA[i:], m.WriteKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.WriteKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ReadKeys) > 0 {
+		for iNdEx := len(m.ReadKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ReadKeys[iNdEx])
+			copy(dAtA[i:], m.ReadKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ReadKeys[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *Request_Echo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Echo != nil {
+		l = m.Echo.Size()
+		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
@@ -3077,6 +3505,18 @@ func (m *Request_EndRecheckTx) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_DeliverTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeliverTxBatch != nil {
+		l = m.DeliverTxBatch.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestBeginBlock) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3125,6 +3565,27 @@ func (m *RequestEndRecheckTx) Size() (n int) {
 	return n
 }
 
+func (m *RequestDeliverTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, e := range m.Txs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.Hints) > 0 {
+		for _, e := range m.Hints {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3353,6 +3814,18 @@ func (m *Response_EndRecheckTx) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_DeliverTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeliverTxBatch != nil {
+		l = m.DeliverTxBatch.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseCheckTx) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3401,6 +3874,10 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Hint != nil {
+		l = m.Hint.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3428,6 +3905,42 @@ func (m *ResponseEndRecheckTx) Size() (n int) {
 	return n
 }
 
+func (m *ResponseDeliverTxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DeliverTxs) > 0 {
+		for _, e := range m.DeliverTxs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TxHint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ReadKeys) > 0 {
+		for _, b := range m.ReadKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.WriteKeys) > 0 {
+		for _, b := range m.WriteKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -4058,6 +4571,41 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_EndRecheckTx{v}
 			iNdEx = postIndex
+		case 1002:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestDeliverTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_DeliverTxBatch{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -4448,7 +4996,7 @@ func (m *RequestEndRecheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RequestDeliverTxBatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4471,15 +5019,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestDeliverTxBatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestDeliverTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4506,15 +5054,14 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseException{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, types.RequestDeliverTx{})
+			if err := m.Txs[len(m.Txs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hints", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4541,15 +5088,64 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseEcho{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hints = append(m.Hints, TxHint{})
+			if err := m.Hints[len(m.Hints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4576,15 +5172,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseFlush{}
+			v := &types.ResponseException{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Flush{v}
+			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4611,15 +5207,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseInfo{}
+			v := &types.ResponseEcho{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Info{v}
+			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4646,15 +5242,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseSetOption{}
+			v := &types.ResponseFlush{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_SetOption{v}
+			m.Value = &Response_Flush{v}
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4681,11 +5277,81 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.ResponseInitChain{}
+			v := &types.ResponseInfo{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_InitChain{v}
+			m.Value = &Response_Info{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &types.ResponseSetOption{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_SetOption{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &types.ResponseInitChain{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_InitChain{v}
 			iNdEx = postIndex
 		case 7:
 			if wireType != 2 {
@@ -5107,6 +5773,41 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Response_EndRecheckTx{v}
 			iNdEx = postIndex
+		case 1002:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseDeliverTxBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_DeliverTxBatch{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -5461,6 +6162,42 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.MempoolError = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hint == nil {
+				m.Hint = &TxHint{}
+			}
+			if err := m.Hint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -5620,6 +6357,204 @@ func (m *ResponseEndRecheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResponseDeliverTxBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseDeliverTxBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseDeliverTxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverTxs = append(m.DeliverTxs, &types.ResponseDeliverTx{})
+			if err := m.DeliverTxs[len(m.DeliverTxs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxHint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxHint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxHint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReadKeys = append(m.ReadKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ReadKeys[len(m.ReadKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WriteKeys = append(m.WriteKeys, make([]byte, postIndex-iNdEx))
+			copy(m.WriteKeys[len(m.WriteKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2,7 +2,9 @@
 Package Behaviour provides a mechanism for reactors to report behaviour of peers.
 
 Instead of a reactor calling the switch directly it will call the behaviour module which will
-handle the stoping and marking peer as good on behalf of the reactor.
+handle the stoping and marking peer as good or bad on behalf of the reactor.
+The behaviours are recorded in the trust metrics of the peers, if the switch has
+a trust metric store.
 
 There are four different behaviours a reactor can report.
 
@@ -12,7 +14,8 @@ type badMessage struct {
 	explanation string
 }
 
-This message will request the peer be stopped for an error
+This message will request the peer be marked as bad, which stops it and
+bans it if its trust score is too low
 
 2. message out of order
 
@@ -20,7 +23,8 @@ type messageOutOfOrder struct {
 	explanation string
 }
 
-This message will request the peer be stopped for an error
+This message will request the peer be marked as bad, which stops it and
+bans it if its trust score is too low
 
 3. consesnsus Vote
 
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/threshold"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/privval"
+)
+
+var (
+	keyShareThreshold int
+	keyShareTotal     int
+	cosignerLaddr     string
+	cosignerAllowed   string
+)
+
+// SplitPrivValidatorKeyCmd splits the validator key into shares for
+// cosigners.
+var SplitPrivValidatorKeyCmd = &cobra.Command{
+	Use:   "split-priv-validator-key [output dir]",
+	Short: "Split this node's validator key into shares for threshold signing",
+	Long: `Split this node's ed25519 validator key into shares, any threshold of which
+can sign votes, proposals and VRF proofs. Each share is written to
+priv_validator_key_share_<id>.json in the output directory, to be copied to the
+priv_validator_key_share_file of its cosigner. The validator key should then be
+removed from this node.`,
+	Args: cobra.ExactArgs(1),
+	RunE: splitPrivValidatorKey,
+}
+
+func init() {
+	SplitPrivValidatorKeyCmd.Flags().IntVar(&keyShareThreshold, "threshold", 2,
+		"number of shares needed to sign")
+	SplitPrivValidatorKeyCmd.Flags().IntVar(&keyShareTotal, "shares", 3,
+		"number of shares to split the key into")
+	StartCosignerCmd.Flags().StringVar(&cosignerLaddr, "laddr", "tcp://127.0.0.1:26660",
+		"address to listen on for signing requests")
+	StartCosignerCmd.Flags().StringVar(&cosignerAllowed, "allowed-nodes", "",
+		"comma separated list of the IDs of the nodes allowed to request signatures")
+}
+
+func splitPrivValidatorKey(cmd *cobra.Command, args []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !tmos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+	unlocker, err := keyUnlocker(false)
+	if err != nil {
+		return err
+	}
+	pv := privval.LoadFilePVWithUnlocker(keyFilePath, config.PrivValidatorStateFile(), unlocker)
+	privKey, ok := pv.Key.PrivKey.(ed25519.PrivKey)
+	if !ok {
+		return fmt.Errorf("only ed25519 keys can be split, got %s", pv.Key.PrivKey.Type())
+	}
+
+	shares, err := threshold.SplitKey(privKey, keyShareThreshold, keyShareTotal)
+	if err != nil {
+		return err
+	}
+	if err := tmos.EnsureDir(args[0], 0700); err != nil {
+		return err
+	}
+	for _, share := range shares {
+		shareFile := filepath.Join(args[0], fmt.Sprintf("priv_validator_key_share_%d.json", share.ID))
+		if err := privval.SaveKeyShare(share, shareFile); err != nil {
+			return err
+		}
+		logger.Info("Generated key share", "id", share.ID, "path", shareFile)
+	}
+	return nil
+}
+
+// StartCosignerCmd runs a cosigner signing with this node's key share for
+// the threshold signers of other nodes.
+var StartCosignerCmd = &cobra.Command{
+	Use:   "start-cosigner",
+	Short: "Run a cosigner signing with this node's validator key share",
+	Long: `Run a cosigner serving signing requests from the nodes of a validator
+signing with a threshold of cosigners. The cosigner refuses to double sign.
+Connections are encrypted and authenticated with this node's node key, and only
+the nodes given by --allowed-nodes can request signatures.`,
+	RunE: startCosigner,
+}
+
+func startCosigner(cmd *cobra.Command, args []string) error {
+	if cosignerAllowed == "" {
+		return errors.New("--allowed-nodes is required")
+	}
+	allowedNodes := strings.Split(cosignerAllowed, ",")
+	for i, id := range allowedNodes {
+		id = strings.TrimSpace(id)
+		allowedNodes[i] = id
+		if bz, err := hex.DecodeString(id); err != nil || len(bz) != crypto.AddressSize {
+			return fmt.Errorf("invalid node ID %q", id)
+		}
+	}
+
+	cosigner, err := privval.LoadLocalCosigner(config.PrivValidatorKeyShareFile(), config.PrivValidatorCosignerStateFile())
+	if err != nil {
+		return err
+	}
+	unlocker, err := keyUnlocker(false)
+	if err != nil {
+		return err
+	}
+	nodeKey, err := p2p.LoadNodeKeyWithUnlocker(config.NodeKeyFile(), unlocker)
+	if err != nil {
+		return err
+	}
+
+	server := privval.NewCosignerServer(cosignerLaddr, cosigner, nodeKey.PrivKey, allowedNodes,
+		logger.With("module", "cosigner"))
+	if err := server.Start(); err != nil {
+		return err
+	}
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	tmos.TrapSignal(logger, func() {
+		if err := server.Stop(); err != nil {
+			logger.Error("Failed to stop cosigner", "err", err)
+		}
+	})
+
+	// Run forever.
+	select {}
+}
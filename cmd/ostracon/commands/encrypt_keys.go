@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Finschia/ostracon/crypto/armor"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/node"
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/privval"
+)
+
+var decryptKeys bool
+
+// EncryptKeysCmd encrypts the plaintext validator and node key files of this
+// node, or decrypts them back.
+var EncryptKeysCmd = &cobra.Command{
+	Use:   "encrypt-keys",
+	Short: "Encrypt this node's validator and node key files",
+	Long: `Encrypt this node's validator and node key files with the passphrase of
+key_passphrase_file or of the OC_KEY_PASSPHRASE environment variable, or with
+the KMS plugin of key_kms_command. Files that are already encrypted are
+re-encrypted. With --decrypt, the files are written back in plaintext.`,
+	RunE: encryptKeys,
+}
+
+func init() {
+	EncryptKeysCmd.Flags().BoolVar(&decryptKeys, "decrypt", false,
+		"decrypt the key files instead of encrypting them")
+}
+
+func encryptKeys(cmd *cobra.Command, args []string) error {
+	unlocker, err := keyUnlocker(true)
+	if err != nil {
+		return err
+	}
+	var saveUnlocker armor.Unlocker
+	if !decryptKeys {
+		saveUnlocker = unlocker
+	}
+
+	privValKeyFile := config.PrivValidatorKeyFile()
+	if tmos.FileExists(privValKeyFile) {
+		pv := privval.LoadFilePVEmptyStateWithUnlocker(privValKeyFile, config.PrivValidatorStateFile(), unlocker)
+		pv.Key.SetUnlocker(saveUnlocker)
+		pv.Key.Save()
+		logger.Info("Saved private validator key", "keyFile", privValKeyFile, "encrypted", !decryptKeys)
+	} else {
+		logger.Info("No private validator key", "keyFile", privValKeyFile)
+	}
+
+	nodeKeyFile := config.NodeKeyFile()
+	if tmos.FileExists(nodeKeyFile) {
+		nodeKey, err := p2p.LoadNodeKeyWithUnlocker(nodeKeyFile, unlocker)
+		if err != nil {
+			return fmt.Errorf("failed to load node key %s: %w", nodeKeyFile, err)
+		}
+		if err := nodeKey.SaveAsEncrypted(nodeKeyFile, saveUnlocker); err != nil {
+			return err
+		}
+		logger.Info("Saved node key", "path", nodeKeyFile, "encrypted", !decryptKeys)
+	} else {
+		logger.Info("No node key", "path", nodeKeyFile)
+	}
+	return nil
+}
+
+// keyUnlocker returns the unlocker of encrypted key files in the config, or
+// nil if none is set. If required is true, an error is returned instead of
+// nil.
+func keyUnlocker(required bool) (armor.Unlocker, error) {
+	unlocker, err := node.KeyUnlocker(config)
+	if err != nil {
+		return nil, err
+	}
+	if required && unlocker == nil {
+		return nil, errors.New("key_passphrase_file, key_kms_command or the " + node.KeyPassphraseEnvVar +
+			" environment variable is required to encrypt keys")
+	}
+	return unlocker, nil
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/armor"
+	"github.com/Finschia/ostracon/node"
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/privval"
+)
+
+func TestEncryptKeys(t *testing.T) {
+	original := config
+	defer func() {
+		config = original
+	}()
+
+	setupEnv(t)
+	config = cfg.DefaultConfig()
+	err := RootCmd.PersistentPreRunE(RootCmd, nil)
+	require.NoError(t, err)
+	init := NewInitCmd()
+	err = init.RunE(init, nil)
+	require.NoError(t, err)
+	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+
+	// a passphrase is required
+	err = EncryptKeysCmd.RunE(EncryptKeysCmd, nil)
+	require.Error(t, err)
+
+	t.Setenv(node.KeyPassphraseEnvVar, "passphrase")
+	err = EncryptKeysCmd.RunE(EncryptKeysCmd, nil)
+	require.NoError(t, err)
+	requireArmored(t, config.PrivValidatorKeyFile(), true)
+	requireArmored(t, config.NodeKeyFile(), true)
+
+	unlocker := armor.Passphrase("passphrase")
+	encryptedPV := privval.LoadFilePVWithUnlocker(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(),
+		unlocker)
+	require.Equal(t, pv.Key.PrivKey, encryptedPV.Key.PrivKey)
+	encryptedNodeKey, err := p2p.LoadNodeKeyWithUnlocker(config.NodeKeyFile(), unlocker)
+	require.NoError(t, err)
+	require.Equal(t, nodeKey, encryptedNodeKey)
+
+	// and back
+	decryptKeys = true
+	defer func() {
+		decryptKeys = false
+	}()
+	err = EncryptKeysCmd.RunE(EncryptKeysCmd, nil)
+	require.NoError(t, err)
+	requireArmored(t, config.PrivValidatorKeyFile(), false)
+	requireArmored(t, config.NodeKeyFile(), false)
+}
+
+func requireArmored(t *testing.T, filePath string, armored bool) {
+	bz, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, armored, armor.IsArmored(bz))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/Finschia/ostracon/crypto/armor"
 	tmos "github.com/Finschia/ostracon/libs/os"
 	"github.com/Finschia/ostracon/p2p"
 )
@@ -19,13 +20,27 @@ var GenNodeKeyCmd = &cobra.Command{
 	RunE:    genNodeKey,
 }
 
+var genEncryptedNodeKey bool
+
+func init() {
+	GenNodeKeyCmd.Flags().BoolVar(&genEncryptedNodeKey, "encrypt", false,
+		"encrypt the node key file")
+}
+
 func genNodeKey(cmd *cobra.Command, args []string) error {
 	nodeKeyFile := config.NodeKeyFile()
 	if tmos.FileExists(nodeKeyFile) {
 		return fmt.Errorf("node key at %s already exists", nodeKeyFile)
 	}
 
-	nodeKey, err := p2p.LoadOrGenNodeKey(nodeKeyFile)
+	var unlocker armor.Unlocker
+	if genEncryptedNodeKey {
+		var err error
+		if unlocker, err = keyUnlocker(true); err != nil {
+			return err
+		}
+	}
+	nodeKey, err := p2p.LoadOrGenNodeKeyWithUnlocker(nodeKeyFile, unlocker)
 	if err != nil {
 		return err
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	tmjson "github.com/Finschia/ostracon/libs/json"
+	"github.com/Finschia/ostracon/node"
 	"github.com/Finschia/ostracon/privval"
 )
 
@@ -16,15 +17,71 @@ var GenValidatorCmd = &cobra.Command{
 	Aliases: []string{"gen_validator"},
 	Short:   "Generate new validator keypair",
 	PreRun:  deprecateSnakeCase,
-	Run:     genValidator,
+	RunE:    genValidator,
 }
 
-func genValidator(cmd *cobra.Command, args []string) {
+var (
+	genPKCS11Validator    bool
+	genEncryptedValidator bool
+)
+
+func init() {
+	GenValidatorCmd.Flags().BoolVar(&genPKCS11Validator, "pkcs11", false,
+		"generate the keypair in the PKCS#11 token of the config, and print its public key")
+	GenValidatorCmd.Flags().BoolVar(&genEncryptedValidator, "encrypt", false,
+		"print the keypair as an encrypted private validator key file")
+}
+
+func genValidator(cmd *cobra.Command, args []string) error {
+	if genPKCS11Validator {
+		return genPKCS11ValidatorKey()
+	}
+
 	pv := privval.GenFilePV("", "")
+	if genEncryptedValidator {
+		unlocker, err := keyUnlocker(true)
+		if err != nil {
+			return err
+		}
+		pv.Key.SetUnlocker(unlocker)
+		bz, err := pv.Key.Bytes()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bz))
+		return nil
+	}
 	jsbz, err := tmjson.Marshal(pv)
 	if err != nil {
 		panic(err)
 	}
 	fmt.Printf(`%v
 `, string(jsbz))
+	return nil
+}
+
+func genPKCS11ValidatorKey() error {
+	if config.PrivValidatorPKCS11Module == "" {
+		return fmt.Errorf("priv_validator_pkcs11_module is not set")
+	}
+	pv, err := privval.GenPKCS11PV(node.PKCS11Config(config), config.PrivValidatorStateFile())
+	if err != nil {
+		return err
+	}
+	defer pv.Close()
+	logger.Info("Generated private validator in PKCS#11 token", "token", config.PrivValidatorPKCS11Token,
+		"label", config.PrivValidatorPKCS11KeyLabel, "vrfKeyFile", config.PrivValidatorVRFKeyFile())
+	logger.Info("The VRF key file is an encrypted copy of the signing key, which the node decrypts " +
+		"into memory on every proposal: the token doesn't keep the signing key out of a compromised node")
+
+	pubKey, err := pv.GetPubKey()
+	if err != nil {
+		return err
+	}
+	jsbz, err := tmjson.Marshal(pubKey)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsbz))
+	return nil
 }
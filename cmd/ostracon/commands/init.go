@@ -1,25 +1,32 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/armor"
 	tmos "github.com/Finschia/ostracon/libs/os"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/node"
 	"github.com/Finschia/ostracon/p2p"
 	"github.com/Finschia/ostracon/privval"
 	"github.com/Finschia/ostracon/types"
 	tmtime "github.com/Finschia/ostracon/types/time"
 )
 
+var initEncryptKeys bool
+
 func NewInitCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize Ostracon",
 		RunE:  initFiles,
 	}
+	cmd.Flags().BoolVar(&initEncryptKeys, "encrypt", false,
+		"encrypt the generated validator and node key files")
 
 	return cmd
 }
@@ -29,17 +36,42 @@ func initFiles(cmd *cobra.Command, args []string) error {
 }
 
 func initFilesWithConfig(config *cfg.Config) error {
+	unlocker, err := node.KeyUnlocker(config)
+	if err != nil {
+		return err
+	}
+	var genUnlocker armor.Unlocker
+	if initEncryptKeys {
+		if unlocker == nil {
+			return errors.New("key_passphrase_file, key_kms_command or the " + node.KeyPassphraseEnvVar +
+				" environment variable is required to encrypt keys")
+		}
+		genUnlocker = unlocker
+	}
+
 	// private validator
 	privValKeyFile := config.PrivValidatorKeyFile()
 	privValStateFile := config.PrivValidatorStateFile()
-	var pv *privval.FilePV
-	if tmos.FileExists(privValKeyFile) {
-		pv = privval.LoadFilePV(privValKeyFile, privValStateFile)
+	var pv types.PrivValidator
+	if config.PrivValidatorPKCS11Module != "" {
+		// the key must have been generated with gen-validator --pkcs11
+		pkcs11PV, err := privval.LoadPKCS11PV(node.PKCS11Config(config), privValStateFile)
+		if err != nil {
+			return err
+		}
+		defer pkcs11PV.Close()
+		pv = pkcs11PV
+		logger.Info("Found private validator in PKCS#11 token", "token", config.PrivValidatorPKCS11Token,
+			"label", config.PrivValidatorPKCS11KeyLabel)
+	} else if tmos.FileExists(privValKeyFile) {
+		pv = privval.LoadFilePVWithUnlocker(privValKeyFile, privValStateFile, unlocker)
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
-		pv = privval.GenFilePV(privValKeyFile, privValStateFile)
-		pv.Save()
+		filePV := privval.GenFilePV(privValKeyFile, privValStateFile)
+		filePV.Key.SetUnlocker(genUnlocker)
+		filePV.Save()
+		pv = filePV
 		logger.Info("Generated private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	}
@@ -48,7 +80,7 @@ func initFilesWithConfig(config *cfg.Config) error {
 	if tmos.FileExists(nodeKeyFile) {
 		logger.Info("Found node key", "path", nodeKeyFile)
 	} else {
-		if _, err := p2p.LoadOrGenNodeKey(nodeKeyFile); err != nil {
+		if _, err := p2p.LoadOrGenNodeKeyWithUnlocker(nodeKeyFile, genUnlocker); err != nil {
 			return err
 		}
 		logger.Info("Generated node key", "path", nodeKeyFile)
@@ -22,7 +22,9 @@ import (
 	lproxy "github.com/Finschia/ostracon/light/proxy"
 	lrpc "github.com/Finschia/ostracon/light/rpc"
 	dbs "github.com/Finschia/ostracon/light/store/db"
+	rpchttp "github.com/Finschia/ostracon/rpc/client/http"
 	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	"github.com/Finschia/ostracon/types"
 )
 
 // LightCmd represents the base command when called without any subcommands
@@ -66,6 +68,9 @@ var (
 	trustedHeight  int64
 	trustedHash    []byte
 	trustLevelStr  string
+	reanchor       bool
+
+	evidenceAddrsJoined string
 
 	verbose bool
 
@@ -98,6 +103,17 @@ func init() {
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	LightCmd.Flags().BoolVar(&reanchor, "reanchor", false,
+		"re-anchor an expired light client to the header given by --height and --hash, keeping the stored headers",
+	)
+
+	LightCmd.Flags().StringVar(&evidenceAddrsJoined, "evidence-nodes", "",
+		"ostracon nodes to broadcast evidence of detected attacks to, in addition to the primary and witnesses, "+
+			"comma-separated",
+	)
+
+	LightCmd.AddCommand(LightExportDivergencesCmd)
+	LightCmd.AddCommand(LightDaemonCmd)
 }
 
 func runProxy(cmd *cobra.Command, args []string) error {
@@ -148,6 +164,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 
 	options := []light.Option{
 		light.Logger(logger),
+		light.RecordDivergences(light.NewDivergenceStore(db, chainID)),
 		light.ConfirmationFunction(func(action string) bool {
 			fmt.Println(action)
 			scanner := bufio.NewScanner(os.Stdin)
@@ -166,6 +183,14 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		}),
 	}
 
+	if evidenceAddrsJoined != "" {
+		pool, err := newEvidenceBroadcaster(strings.Split(evidenceAddrsJoined, ","))
+		if err != nil {
+			return err
+		}
+		options = append(options, light.BroadcastEvidence(pool))
+	}
+
 	if sequential {
 		options = append(options, light.SequentialVerification())
 	} else {
@@ -173,7 +198,30 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	}
 
 	var c *light.Client
-	if trustedHeight > 0 && len(trustedHash) > 0 { // fresh installation
+	if reanchor { // expired state
+		if len(trustedHash) == 0 {
+			return errors.New("--reanchor requires the new trusted header's --height and --hash")
+		}
+		c, err = light.NewHTTPClientFromTrustedStore(
+			chainID,
+			trustingPeriod,
+			primaryAddr,
+			witnessesAddrs,
+			dbs.New(db, chainID),
+			options...,
+		)
+		if err == nil {
+			err = c.Reanchor(
+				context.Background(),
+				light.TrustOptions{
+					Period: trustingPeriod,
+					Height: trustedHeight,
+					Hash:   trustedHash,
+				},
+				time.Now(),
+			)
+		}
+	} else if trustedHeight > 0 && len(trustedHash) > 0 { // fresh installation
 		c, err = light.NewHTTPClient(
 			context.Background(),
 			chainID,
@@ -231,6 +279,36 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// evidenceBroadcaster broadcasts evidence to the RPC servers of full nodes, which gossip it to
+// their peers. It implements light.EvidencePool.
+type evidenceBroadcaster []*rpchttp.HTTP
+
+func newEvidenceBroadcaster(addrs []string) (evidenceBroadcaster, error) {
+	b := make(evidenceBroadcaster, 0, len(addrs))
+	for _, addr := range addrs {
+		c, err := rpchttp.New(addr, "/websocket")
+		if err != nil {
+			return nil, fmt.Errorf("can't create a client for evidence node %v: %w", addr, err)
+		}
+		b = append(b, c)
+	}
+	return b, nil
+}
+
+// AddEvidence broadcasts the evidence to every node, succeeding if any of them accepts it.
+func (b evidenceBroadcaster) AddEvidence(ev types.Evidence) error {
+	var errs []string
+	for _, c := range b {
+		if _, err := c.BroadcastEvidence(context.Background(), ev); err != nil {
+			errs = append(errs, fmt.Sprintf("%v: %v", c.Remote(), err))
+		}
+	}
+	if len(errs) == len(b) {
+		return fmt.Errorf("failed to broadcast evidence: %v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/libs/log"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/light/daemon"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+)
+
+// LightDaemonCmd runs light client proxies for several chains in one process.
+var LightDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run light client proxies for several chains, managed through an admin API",
+	Long: `Run light client proxies for several chains, managed through an admin API.
+
+Each chain has its own trust options, primary and witnesses, and its verifying
+proxy is served under its chain ID, e.g. http://localhost:8888/cosmoshub-3/status.
+
+Chains are added, removed and inspected through the JSON-RPC admin API, served
+on a unix socket in the home directory by default. Its methods are chains,
+chain_status, add_chain, remove_chain, add_witness and remove_witness. Only
+POST requests carrying the token saved in the home directory as a bearer token
+are served, and requests from browsers are rejected. Chains are saved in the
+home directory and tracked again after a restart.
+`,
+	RunE: runLightDaemon,
+	Args: cobra.NoArgs,
+	Example: `light daemon --laddr tcp://localhost:8888
+curl --unix-socket ~/.ostracon-light-daemon/admin.sock http://localhost/ ` +
+		`-H "Authorization: Bearer $(cat ~/.ostracon-light-daemon/admin_token)" ` +
+		`-d '{"jsonrpc":"2.0","id":1,"method":"add_chain","params":{"chain_id":"cosmoshub-3",` +
+		`"primary":"http://52.57.29.196:26657","witnesses":"http://public-seed-node.cosmoshub.certus.one:26657",` +
+		`"trusting_period":"168h","height":"962118",` +
+		`"hash":"28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD"}}'`,
+}
+
+const (
+	// adminSocketFile is the unix socket of the admin API in the home directory, by default.
+	adminSocketFile = "admin.sock"
+	// adminTokenFile holds the bearer token of the admin API in the home directory.
+	adminTokenFile = "admin_token"
+)
+
+var (
+	adminListenAddr string
+	daemonHome      string
+	updatePeriod    time.Duration
+)
+
+func init() {
+	LightDaemonCmd.Flags().StringVar(&listenAddr, "laddr", "tcp://localhost:8888",
+		"serve the proxies of the chains on the given address")
+	LightDaemonCmd.Flags().StringVar(&adminListenAddr, "admin-laddr", "",
+		"serve the admin API on the given address (default: unix://<home-dir>/"+adminSocketFile+")")
+	LightDaemonCmd.Flags().StringVar(&daemonHome, "home-dir",
+		os.ExpandEnv(filepath.Join("$HOME", ".ostracon-light-daemon")), "specify the home directory")
+	LightDaemonCmd.Flags().IntVar(&maxOpenConnections, "max-open-connections", 900,
+		"maximum number of simultaneous connections (including WebSocket).")
+	LightDaemonCmd.Flags().DurationVar(&updatePeriod, "update-period", 5*time.Second,
+		"how often each chain is updated to the latest header")
+	LightDaemonCmd.Flags().BoolVar(&verbose, "verbose", false, "Verbose output")
+}
+
+func runLightDaemon(cmd *cobra.Command, args []string) error {
+	logger := log.NewOCLogger(log.NewSyncWriter(os.Stdout))
+	var option log.Option
+	if verbose {
+		option, _ = log.AllowLevel("debug")
+	} else {
+		option, _ = log.AllowLevel("info")
+	}
+	logger = log.NewFilter(logger, option)
+
+	db, err := dbm.NewGoLevelDB("light-daemon-db", daemonHome)
+	if err != nil {
+		return fmt.Errorf("can't create a db: %w", err)
+	}
+
+	cfg := rpcserver.DefaultConfig()
+	cfg.MaxBodyBytes = config.RPC.MaxBodyBytes
+	cfg.MaxHeaderBytes = config.RPC.MaxHeaderBytes
+	cfg.MaxOpenConnections = maxOpenConnections
+	// If necessary adjust global WriteTimeout to ensure it's greater than
+	// TimeoutBroadcastTxCommit.
+	// See https://github.com/tendermint/tendermint/issues/3435
+	if cfg.WriteTimeout <= config.RPC.TimeoutBroadcastTxCommit {
+		cfg.WriteTimeout = config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
+	}
+
+	d := daemon.NewDaemon(db, cfg, daemon.UpdatePeriod(updatePeriod))
+	d.SetLogger(logger)
+	if err := d.Start(); err != nil {
+		return err
+	}
+
+	token, err := loadOrGenAdminToken(filepath.Join(daemonHome, adminTokenFile))
+	if err != nil {
+		return err
+	}
+	adminHandler, err := daemon.AdminHandler(d, token, logger.With("module", "admin"))
+	if err != nil {
+		return err
+	}
+	if adminListenAddr == "" {
+		// The database lock keeps other daemons away from the home directory, so a socket left
+		// there is stale.
+		socket := filepath.Join(daemonHome, adminSocketFile)
+		if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		adminListenAddr = "unix://" + socket
+	}
+	adminListener, err := rpcserver.Listen(adminListenAddr, rpcserver.DefaultConfig())
+	if err != nil {
+		return err
+	}
+	listener, err := rpcserver.Listen(listenAddr, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	tmos.TrapSignal(logger, func() {
+		adminListener.Close()
+		listener.Close()
+		if err := d.Stop(); err != nil {
+			logger.Error("Failed to stop daemon", "err", err)
+		}
+		db.Close()
+	})
+
+	go func() {
+		logger.Info("Starting admin API...", "laddr", adminListenAddr)
+		if err := rpcserver.Serve(adminListener, adminHandler, logger, rpcserver.DefaultConfig()); err != http.ErrServerClosed {
+			logger.Error("admin API Serve", "err", err)
+		}
+	}()
+
+	logger.Info("Starting proxies...", "laddr", listenAddr)
+	if err := rpcserver.Serve(listener, d, logger, cfg); err != http.ErrServerClosed {
+		// Error starting or closing listener:
+		logger.Error("proxy Serve", "err", err)
+	}
+
+	return nil
+}
+
+// loadOrGenAdminToken loads the bearer token of the admin API from the given file, generating it
+// if the file doesn't exist.
+func loadOrGenAdminToken(path string) (string, error) {
+	if tmos.FileExists(path) {
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("can't read admin token: %w", err)
+		}
+		return strings.TrimSpace(string(bz)), nil
+	}
+	token := hex.EncodeToString(crypto.CRandBytes(32))
+	if err := tmos.WriteFile(path, []byte(token), 0600); err != nil {
+		return "", fmt.Errorf("can't save admin token: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	"github.com/Finschia/ostracon/light"
+)
+
+// LightExportDivergencesCmd exports the divergences recorded by the light client proxy.
+var LightExportDivergencesCmd = &cobra.Command{
+	Use:   "export-divergences [chainID]",
+	Short: "Export the divergences detected by the light client proxy as JSON",
+	Long: `Export the divergences detected by the light client proxy as JSON.
+
+Every time a witness returns a header conflicting with the primary's, the light
+client proxy records the conflicting light blocks, the traces verified while
+examining them and the evidence created against the primary and the witness.
+This command prints these records, oldest first, for forensics.
+
+The light client proxy must be stopped, since it holds the database.
+`,
+	RunE:    exportDivergences,
+	Args:    cobra.ExactArgs(1),
+	Example: `light export-divergences cosmoshub-3 --output divergences.json`,
+}
+
+var divergencesOutput string
+
+func init() {
+	LightExportDivergencesCmd.Flags().StringVar(&home, "home-dir",
+		os.ExpandEnv(filepath.Join("$HOME", ".ostracon-light")), "specify the home directory")
+	LightExportDivergencesCmd.Flags().StringVarP(&divergencesOutput, "output", "o", "",
+		"file to write the divergences to, instead of stdout")
+}
+
+func exportDivergences(cmd *cobra.Command, args []string) error {
+	db, err := dbm.NewGoLevelDB("light-client-db", home)
+	if err != nil {
+		return fmt.Errorf("can't open the db: %w", err)
+	}
+	defer db.Close()
+
+	divergences, err := light.NewDivergenceStore(db, args[0]).List()
+	if err != nil {
+		return fmt.Errorf("failed to load divergences: %w", err)
+	}
+	bz, err := tmjson.MarshalIndent(divergences, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal divergences: %w", err)
+	}
+	bz = append(bz, '\n')
+
+	if divergencesOutput == "" {
+		_, err = cmd.OutOrStdout().Write(bz)
+		return err
+	}
+	return os.WriteFile(divergencesOutput, bz, 0600)
+}
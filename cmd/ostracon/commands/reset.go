@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/Finschia/ostracon/crypto/armor"
 	"github.com/Finschia/ostracon/libs/log"
 	tmos "github.com/Finschia/ostracon/libs/os"
 	"github.com/Finschia/ostracon/privval"
@@ -59,11 +60,17 @@ func resetAllCmd(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	unlocker, err := keyUnlocker(false)
+	if err != nil {
+		return err
+	}
+
 	return resetAll(
 		config.DBDir(),
 		config.P2P.AddrBookFile(),
 		config.PrivValidatorKeyFile(),
 		config.PrivValidatorStateFile(),
+		unlocker,
 		logger,
 	)
 }
@@ -76,12 +83,18 @@ func resetPrivValidator(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
+	unlocker, err := keyUnlocker(false)
+	if err != nil {
+		return err
+	}
+
+	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), unlocker, logger)
 	return nil
 }
 
 // resetAll removes address book files plus all data, and resets the privValdiator data.
-func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logger log.Logger) error {
+func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, unlocker armor.Unlocker,
+	logger log.Logger) error {
 	if keepAddrBook {
 		logger.Info("The address book remains intact")
 	} else {
@@ -99,7 +112,7 @@ func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	}
 
 	// recreate the dbDir since the privVal state needs to live there
-	resetFilePV(privValKeyFile, privValStateFile, logger)
+	resetFilePV(privValKeyFile, privValStateFile, unlocker, logger)
 	return nil
 }
 
@@ -109,6 +122,7 @@ func resetState(dbDir string, logger log.Logger) error {
 	state := filepath.Join(dbDir, "state.db")
 	wal := filepath.Join(dbDir, "cs.wal")
 	evidence := filepath.Join(dbDir, "evidence.db")
+	keyRotation := filepath.Join(dbDir, "keyrotation.db")
 	txIndex := filepath.Join(dbDir, "tx_index.db")
 
 	if tmos.FileExists(blockdb) {
@@ -143,6 +157,14 @@ func resetState(dbDir string, logger log.Logger) error {
 		}
 	}
 
+	if tmos.FileExists(keyRotation) {
+		if err := os.RemoveAll(keyRotation); err == nil {
+			logger.Info("Removed all keyrotation.db", "dir", keyRotation)
+		} else {
+			logger.Error("error removing all keyrotation.db", "dir", keyRotation, "err", err)
+		}
+	}
+
 	if tmos.FileExists(txIndex) {
 		if err := os.RemoveAll(txIndex); err == nil {
 			logger.Info("Removed tx_index.db", "dir", txIndex)
@@ -157,9 +179,11 @@ func resetState(dbDir string, logger log.Logger) error {
 	return nil
 }
 
-func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
+// resetFilePV resets the FilePV of privValKeyFile, decrypted with unlocker if
+// it's encrypted, or generates a new one.
+func resetFilePV(privValKeyFile, privValStateFile string, unlocker armor.Unlocker, logger log.Logger) {
 	if _, err := os.Stat(privValKeyFile); err == nil {
-		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
+		pv := privval.LoadFilePVEmptyStateWithUnlocker(privValKeyFile, privValStateFile, unlocker)
 		pv.Reset()
 		logger.Info(
 			"Reset private validator file to genesis state",
@@ -48,11 +48,12 @@ func Test_ResetAll(t *testing.T) {
 	pv.LastSignState.Height = 10
 	pv.Save()
 	require.NoError(t, resetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
-		config.PrivValidatorStateFile(), logger))
+		config.PrivValidatorStateFile(), nil, logger))
 	require.DirExists(t, config.DBDir())
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "block.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "state.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "evidence.db"))
+	require.NoFileExists(t, filepath.Join(config.DBDir(), "keyrotation.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "tx_index.db"))
 	require.FileExists(t, config.PrivValidatorStateFile())
 	pv = privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
@@ -73,6 +74,7 @@ func Test_ResetState(t *testing.T) {
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "block.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "state.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "evidence.db"))
+	require.NoFileExists(t, filepath.Join(config.DBDir(), "keyrotation.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "tx_index.db"))
 	require.FileExists(t, config.PrivValidatorStateFile())
 	pv = privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
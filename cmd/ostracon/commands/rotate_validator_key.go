@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/privval"
+	rpchttp "github.com/Finschia/ostracon/rpc/client/http"
+)
+
+// the activation height of a key rotation is this many blocks after the latest
+// one by default, to leave the time to commit the key rotation
+const defaultKeyRotationDelay = 10
+
+// RotateValidatorKeyCmd rotates the key of the validator of the private
+// validator key file, and broadcasts the key rotation to the node.
+var RotateValidatorKeyCmd = &cobra.Command{
+	Use:   "rotate-validator-key",
+	Short: "Rotate the key of the validator to a new key, active from the activation height",
+	Long: `Rotate the key of the validator to a new key, active from the activation height.
+
+The new key is saved as the next key of the private validator key file, signing
+from the activation height on once the key rotation is committed, and the key
+rotation is broadcast to the node. The node, which may be running, switches to
+the new key at the activation height.`,
+	RunE: rotateValidatorKey,
+}
+
+var (
+	rotateNodeAddr         string
+	rotateActivationHeight int64
+	rotateKeyType          string
+)
+
+func init() {
+	RotateValidatorKeyCmd.Flags().StringVar(&rotateNodeAddr, "node", "tcp://localhost:26657",
+		"the RPC address of the node (<host>:<port>)")
+	RotateValidatorKeyCmd.Flags().Int64Var(&rotateActivationHeight, "activation-height", 0,
+		fmt.Sprintf("the height the new key signs from (default: %d blocks after the latest one)",
+			defaultKeyRotationDelay))
+	RotateValidatorKeyCmd.Flags().StringVar(&rotateKeyType, "key-type", ed25519.KeyType,
+		fmt.Sprintf("the type of the new key (%s or %s)", ed25519.KeyType, bls12381.KeyType))
+}
+
+func rotateValidatorKey(cmd *cobra.Command, args []string) error {
+	if config.PrivValidatorListenAddr != "" || config.PrivValidatorCosigners != "" ||
+		config.PrivValidatorPKCS11Module != "" {
+		return fmt.Errorf("only the keys of private validator key files can be rotated")
+	}
+	var newPrivKey crypto.PrivKey
+	switch rotateKeyType {
+	case ed25519.KeyType:
+		newPrivKey = ed25519.GenPrivKey()
+	case bls12381.KeyType:
+		newPrivKey = bls12381.GenPrivKey()
+	default:
+		return fmt.Errorf("unsupported key type %q", rotateKeyType)
+	}
+
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !tmos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+	unlocker, err := keyUnlocker(false)
+	if err != nil {
+		return err
+	}
+	pv := privval.LoadFilePVEmptyStateWithUnlocker(keyFilePath, config.PrivValidatorStateFile(), unlocker)
+
+	c, err := rpchttp.New(rotateNodeAddr, "/websocket")
+	if err != nil {
+		return err
+	}
+	status, err := c.Status(context.Background())
+	if err != nil {
+		return fmt.Errorf("can't get the status of the node: %w", err)
+	}
+	activationHeight := rotateActivationHeight
+	if activationHeight == 0 {
+		activationHeight = status.SyncInfo.LatestBlockHeight + defaultKeyRotationDelay
+	}
+
+	// the new key is saved before the key rotation is broadcast, not to lose it
+	kr, err := pv.RotateKey(status.NodeInfo.Network, newPrivKey, activationHeight,
+		status.SyncInfo.LatestBlockHeight, status.SyncInfo.LatestBlockTime)
+	if err != nil {
+		return err
+	}
+	logger.Info("Saved the next private validator key", "path", keyFilePath, "pubKey", kr.NewPubKey,
+		"activationHeight", activationHeight)
+
+	res, err := c.BroadcastKeyRotation(context.Background(), kr)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast the key rotation: %w", err)
+	}
+	logger.Info("Broadcast the key rotation", "hash", fmt.Sprintf("%X", res.Hash))
+
+	bz, err := tmjson.Marshal(kr)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bz))
+	return nil
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/p2p"
+	"github.com/Finschia/ostracon/privval"
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestRotateValidatorKey(t *testing.T) {
+	dir := setupEnv(t)
+	cfg.EnsureRoot(dir)
+
+	original := config
+	defer func() {
+		config = original
+	}()
+
+	config = cfg.DefaultConfig()
+	config.SetRoot(dir)
+	err := RootCmd.PersistentPreRunE(RootCmd, nil)
+	require.NoError(t, err)
+	init := NewInitCmd()
+	err = init.RunE(init, nil)
+	require.NoError(t, err)
+	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+
+	// a node at height 5 receiving the key rotation
+	blockTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var broadcast *types.KeyRotation
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"status": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
+			return &ctypes.ResultStatus{
+				NodeInfo: p2p.DefaultNodeInfo{Network: "test-chain"},
+				SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 5, LatestBlockTime: blockTime},
+			}, nil
+		}, ""),
+		"broadcast_key_rotation": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context,
+			kr *types.KeyRotation) (*ctypes.ResultBroadcastKeyRotation, error) {
+			broadcast = kr
+			return &ctypes.ResultBroadcastKeyRotation{Hash: kr.Hash()}, nil
+		}, "key_rotation"),
+	}, log.TestingLogger())
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	originalNodeAddr := rotateNodeAddr
+	defer func() {
+		rotateNodeAddr = originalNodeAddr
+	}()
+	rotateNodeAddr = server.URL
+	_, err = captureStdout(func() {
+		err := RotateValidatorKeyCmd.RunE(RotateValidatorKeyCmd, nil)
+		require.NoError(t, err)
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, broadcast)
+	assert.Equal(t, pv.GetAddress(), broadcast.ValidatorAddress)
+	assert.EqualValues(t, 5+defaultKeyRotationDelay, broadcast.ActivationHeight)
+	assert.EqualValues(t, 5, broadcast.BlockHeight)
+	assert.NoError(t, broadcast.Verify("test-chain", pv.Key.PubKey))
+
+	// the new key is saved to be switched to at the activation height
+	loaded := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NotNil(t, loaded.Key.NextPrivKey)
+	assert.Equal(t, broadcast.NewPubKey, loaded.Key.NextPrivKey.PubKey())
+	assert.Equal(t, pv.Key.PrivKey, loaded.Key.PrivKey)
+
+	rotateKeyType = "secp256k1"
+	defer func() {
+		rotateKeyType = ed25519.KeyType
+	}()
+	err = RotateValidatorKeyCmd.RunE(RotateValidatorKeyCmd, nil)
+	assert.Error(t, err)
+}
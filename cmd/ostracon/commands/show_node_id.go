@@ -18,7 +18,11 @@ var ShowNodeIDCmd = &cobra.Command{
 }
 
 func showNodeID(cmd *cobra.Command, args []string) error {
-	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	unlocker, err := keyUnlocker(false)
+	if err != nil {
+		return err
+	}
+	nodeKey, err := p2p.LoadNodeKeyWithUnlocker(config.NodeKeyFile(), unlocker)
 	if err != nil {
 		return err
 	}
@@ -10,6 +10,7 @@ import (
 	cfg "github.com/Finschia/ostracon/config"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/p2p"
 	"github.com/Finschia/ostracon/privval"
 )
 
@@ -26,7 +27,8 @@ var ShowValidatorCmd = &cobra.Command{
 
 func showValidator(cmd *cobra.Command, args []string, config *cfg.Config) error {
 	var pv types.PrivValidator
-	if config.PrivValidatorListenAddr != "" {
+	switch {
+	case config.PrivValidatorListenAddr != "":
 		chainID, err := loadChainID(config)
 		if err != nil {
 			return err
@@ -35,12 +37,36 @@ func showValidator(cmd *cobra.Command, args []string, config *cfg.Config) error
 		if err != nil {
 			return err
 		}
-	} else {
+	case config.PrivValidatorCosigners != "":
+		unlocker, err := keyUnlocker(false)
+		if err != nil {
+			return err
+		}
+		nodeKey, err := p2p.LoadNodeKeyWithUnlocker(config.NodeKeyFile(), unlocker)
+		if err != nil {
+			return err
+		}
+		pv, err = node.CreateThresholdPrivValidator(config, nodeKey, logger)
+		if err != nil {
+			return err
+		}
+	case config.PrivValidatorPKCS11Module != "":
+		pkcs11PV, err := privval.LoadPKCS11PV(node.PKCS11Config(config), config.PrivValidatorStateFile())
+		if err != nil {
+			return err
+		}
+		defer pkcs11PV.Close()
+		pv = pkcs11PV
+	default:
 		keyFilePath := config.PrivValidatorKeyFile()
 		if !tmos.FileExists(keyFilePath) {
 			return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 		}
-		pv = privval.LoadFilePV(keyFilePath, config.PrivValidatorStateFile())
+		unlocker, err := keyUnlocker(false)
+		if err != nil {
+			return err
+		}
+		pv = privval.LoadFilePVWithUnlocker(keyFilePath, config.PrivValidatorStateFile(), unlocker)
 	}
 
 	pubKey, err := pv.GetPubKey()
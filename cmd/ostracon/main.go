@@ -22,7 +22,11 @@ func main() {
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ResetStateCmd,
+		cmd.RotateValidatorKeyCmd,
 		cmd.ShowValidatorCmd,
+		cmd.SplitPrivValidatorKeyCmd,
+		cmd.EncryptKeysCmd,
+		cmd.StartCosignerCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
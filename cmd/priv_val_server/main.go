@@ -2,15 +2,19 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/libs/log"
 	tmnet "github.com/Finschia/ostracon/libs/net"
 	tmos "github.com/Finschia/ostracon/libs/os"
+	"github.com/Finschia/ostracon/p2p"
 
 	"github.com/Finschia/ostracon/privval"
+	"github.com/Finschia/ostracon/types"
 )
 
 func main() {
@@ -19,6 +23,16 @@ func main() {
 		chainID          = flag.String("chain-id", "mychain", "chain id")
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
+		signStatePath    = flag.String("sign-state", "",
+			"last sign state file path shared with the other signers of the key, locked with a file lock")
+		signStateReplicas = flag.String("sign-state-replicas", "",
+			"comma separated list of the sign state replicas of the other signers, as node_id@tcp://host:port. "+
+				"If set, -sign-state is served as a replica and the last sign state is stored by a majority "+
+				"of the replicas instead of being locked with a file lock")
+		signStateLaddr = flag.String("sign-state-laddr", "tcp://127.0.0.1:26670",
+			"address to serve the sign state replica on")
+		nodeKeyPath = flag.String("node-key", "",
+			"node key file path, authenticating the signer with the sign state replicas")
 
 		logger = log.NewOCLogger(
 			log.NewSyncWriter(os.Stdout),
@@ -32,9 +46,23 @@ func main() {
 		"chainID", *chainID,
 		"privKeyPath", *privValKeyPath,
 		"privStatePath", *privValStatePath,
+		"signStatePath", *signStatePath,
 	)
 
-	pv := privval.LoadFilePV(*privValKeyPath, *privValStatePath)
+	filePV := privval.LoadFilePV(*privValKeyPath, *privValStatePath)
+	var pv types.PrivValidator = filePV
+	if *signStatePath != "" {
+		var lock privval.SignStateLock = privval.NewFileSignStateLock(*signStatePath)
+		if *signStateReplicas != "" {
+			var err error
+			lock, err = quorumSignStateLock(*signStatePath, *signStateReplicas, *signStateLaddr, *nodeKeyPath, logger)
+			if err != nil {
+				logger.Error("Failed to set up the sign state replicas", "err", err)
+				os.Exit(1)
+			}
+		}
+		pv = privval.NewSharedStateFilePV(filePV, lock)
+	}
 
 	var dialer privval.SocketDialer
 	protocol, address := tmnet.ProtocolAndAddress(*addr)
@@ -68,3 +96,42 @@ func main() {
 	// Run forever.
 	select {}
 }
+
+// quorumSignStateLock serves the replica of the last sign state in
+// signStatePath, and returns a lock storing the state with a majority of
+// this replica and the other signers' replicas.
+func quorumSignStateLock(
+	signStatePath, replicaAddrs, laddr, nodeKeyPath string,
+	logger log.Logger,
+) (privval.SignStateLock, error) {
+	nodeKey, err := p2p.LoadNodeKey(nodeKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	local, err := privval.LoadLocalSignStateReplica(signStatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := []privval.SignStateReplica{local}
+	var nodeIDs []string
+	for _, replica := range strings.Split(replicaAddrs, ",") {
+		idAndAddr := strings.SplitN(strings.TrimSpace(replica), "@", 2)
+		if len(idAndAddr) != 2 {
+			return nil, fmt.Errorf("invalid replica %q, expected node_id@tcp://host:port", replica)
+		}
+		remote, err := privval.NewRemoteSignStateReplica(idAndAddr[0], idAndAddr[1], nodeKey.PrivKey)
+		if err != nil {
+			return nil, err
+		}
+		replicas = append(replicas, remote)
+		nodeIDs = append(nodeIDs, idAndAddr[0])
+	}
+
+	// the other signers are allowed to use the replica of this one
+	server := privval.NewSignStateServer(laddr, local, nodeKey.PrivKey, nodeIDs, logger.With("module", "sign_state"))
+	if err := server.Start(); err != nil {
+		return nil, err
+	}
+	return privval.NewQuorumSignStateLock(replicas, privval.DefaultSignStateTimeout)
+}
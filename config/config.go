@@ -25,6 +25,13 @@ const (
 	DefaultLogLevel = "info"
 
 	DefaultDBBackend = "goleveldb"
+
+	// P2PTransportTCP is a transport of TCP connections upgraded to
+	// SecretConnection and MConnection
+	P2PTransportTCP = "tcp"
+	// P2PTransportQUIC is a transport of QUIC connections with a stream per
+	// channel
+	P2PTransportQUIC = "quic"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -44,6 +51,10 @@ var (
 	defaultPrivValKeyName   = "priv_validator_key.json"
 	defaultPrivValStateName = "priv_validator_state.json"
 
+	defaultPrivValKeyShareName      = "priv_validator_key_share.json"
+	defaultPrivValCosignerStateName = "priv_validator_cosigner_state.json"
+	defaultPrivValVRFKeyName        = "priv_validator_vrf_key.json"
+
 	defaultNodeKeyName  = "node_key.json"
 	defaultAddrBookName = "addrbook.json"
 
@@ -52,6 +63,10 @@ var (
 	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
 	defaultPrivValStatePath = filepath.Join(defaultDataDir, defaultPrivValStateName)
 
+	defaultPrivValKeySharePath      = filepath.Join(defaultConfigDir, defaultPrivValKeyShareName)
+	defaultPrivValCosignerStatePath = filepath.Join(defaultDataDir, defaultPrivValCosignerStateName)
+	defaultPrivValVRFKeyPath        = filepath.Join(defaultConfigDir, defaultPrivValVRFKeyName)
+
 	defaultNodeKeyPath  = filepath.Join(defaultConfigDir, defaultNodeKeyName)
 	defaultAddrBookPath = filepath.Join(defaultConfigDir, defaultAddrBookName)
 
@@ -230,15 +245,63 @@ type BaseConfig struct { //nolint: maligned
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
 	// TCP or UNIX socket address for Ostracon to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process.
+	// Comma separated addresses of several signers of the same key, sharing
+	// their last sign state, can be given for the node to fail over between
+	// them
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Path to the JSON file containing the share of the validator key held by
+	// this node when signing with a threshold of cosigners
+	PrivValidatorKeyShare string `mapstructure:"priv_validator_key_share_file"`
+
+	// Path to the JSON file containing the last sign state of the key share
+	PrivValidatorCosignerState string `mapstructure:"priv_validator_cosigner_state_file"`
+
+	// Comma separated list of the other cosigners holding shares of the
+	// validator key, as id@node_id@tcp://host:port, node_id being the ID of
+	// the node key the cosigner authenticates with. If set, the node signs
+	// with a threshold of cosigners instead of priv_validator_key_file
+	PrivValidatorCosigners string `mapstructure:"priv_validator_cosigners"`
+
+	// Path to the PKCS#11 module of the HSM holding the validator key. If set,
+	// the node signs through the HSM instead of priv_validator_key_file.
+	// Requires a build with the pkcs11 build tag
+	PrivValidatorPKCS11Module string `mapstructure:"priv_validator_pkcs11_module"`
+
+	// Label of the PKCS#11 token holding the validator key
+	PrivValidatorPKCS11Token string `mapstructure:"priv_validator_pkcs11_token"`
+
+	// PIN of the user of the PKCS#11 token
+	PrivValidatorPKCS11PIN string `mapstructure:"priv_validator_pkcs11_pin"`
+
+	// Label of the validator key in the PKCS#11 token
+	PrivValidatorPKCS11KeyLabel string `mapstructure:"priv_validator_pkcs11_key_label"`
+
+	// Path to the JSON file containing the VRF key of a validator key held in
+	// a PKCS#11 token, encrypted by a key of the token. The VRF key is a copy
+	// of the signing key, decrypted into the node on every proposal, so the
+	// token doesn't keep the signing key out of a compromised node
+	PrivValidatorVRFKey string `mapstructure:"priv_validator_vrf_key_file"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
+	// Path to a file containing the passphrase of encrypted validator and
+	// node key files
+	KeyPassphraseFile string `mapstructure:"key_passphrase_file"`
+
+	// Command of a KMS plugin decrypting the data keys of encrypted validator
+	// and node key files, run with an extra "encrypt" or "decrypt" argument
+	KeyKMSCommand string `mapstructure:"key_kms_command"`
+
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// If true, deliver all txs of a block to the ABCI app with a single
+	// DeliverTxBatch request instead of one DeliverTx request per tx
+	DeliverTxBatch bool `mapstructure:"deliver_tx_batch"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
@@ -247,23 +310,28 @@ type BaseConfig struct { //nolint: maligned
 // DefaultBaseConfig returns a default base configuration for an Ostracon node
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultPackageLogLevels(),
-		LogFormat:          LogFormatPlain,
-		LogPath:            "",
-		LogMaxAge:          0,
-		LogMaxSize:         100,
-		LogMaxBackups:      0,
-		FastSyncMode:       true,
-		FilterPeers:        false,
-		DBBackend:          DefaultDBBackend,
-		DBPath:             "data",
+		Genesis:                     defaultGenesisJSONPath,
+		PrivValidatorKey:            defaultPrivValKeyPath,
+		PrivValidatorState:          defaultPrivValStatePath,
+		PrivValidatorKeyShare:       defaultPrivValKeySharePath,
+		PrivValidatorCosignerState:  defaultPrivValCosignerStatePath,
+		PrivValidatorPKCS11KeyLabel: "priv_validator",
+		PrivValidatorVRFKey:         defaultPrivValVRFKeyPath,
+		NodeKey:                     defaultNodeKeyPath,
+		Moniker:                     defaultMoniker,
+		ProxyApp:                    "tcp://127.0.0.1:26658",
+		ABCI:                        "socket",
+		DeliverTxBatch:              false,
+		LogLevel:                    DefaultPackageLogLevels(),
+		LogFormat:                   LogFormatPlain,
+		LogPath:                     "",
+		LogMaxAge:                   0,
+		LogMaxSize:                  100,
+		LogMaxBackups:               0,
+		FastSyncMode:                true,
+		FilterPeers:                 false,
+		DBBackend:                   DefaultDBBackend,
+		DBPath:                      "data",
 	}
 }
 
@@ -296,11 +364,35 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorKeyShareFile returns the full path to the priv_validator_key_share.json file
+func (cfg BaseConfig) PrivValidatorKeyShareFile() string {
+	return rootify(cfg.PrivValidatorKeyShare, cfg.RootDir)
+}
+
+// PrivValidatorCosignerStateFile returns the full path to the priv_validator_cosigner_state.json file
+func (cfg BaseConfig) PrivValidatorCosignerStateFile() string {
+	return rootify(cfg.PrivValidatorCosignerState, cfg.RootDir)
+}
+
+// PrivValidatorVRFKeyFile returns the full path to the priv_validator_vrf_key.json file
+func (cfg BaseConfig) PrivValidatorVRFKeyFile() string {
+	return rootify(cfg.PrivValidatorVRFKey, cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// KeyPassphraseFilePath returns the full path to the key passphrase file, or
+// an empty string if it's not set
+func (cfg BaseConfig) KeyPassphraseFilePath() string {
+	if cfg.KeyPassphraseFile == "" {
+		return ""
+	}
+	return rootify(cfg.KeyPassphraseFile, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -314,6 +406,22 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.PrivValidatorCosigners != "" && cfg.PrivValidatorListenAddr != "" {
+		return errors.New("priv_validator_cosigners can't be used with priv_validator_laddr")
+	}
+	if cfg.PrivValidatorPKCS11Module != "" {
+		if cfg.PrivValidatorListenAddr != "" || cfg.PrivValidatorCosigners != "" {
+			return errors.New("priv_validator_pkcs11_module can't be used with priv_validator_laddr " +
+				"or priv_validator_cosigners")
+		}
+		if cfg.PrivValidatorPKCS11Token == "" || cfg.PrivValidatorPKCS11KeyLabel == "" {
+			return errors.New("priv_validator_pkcs11_token and priv_validator_pkcs11_key_label are required " +
+				"with priv_validator_pkcs11_module")
+		}
+	}
+	if cfg.KeyPassphraseFile != "" && cfg.KeyKMSCommand != "" {
+		return errors.New("key_passphrase_file can't be used with key_kms_command")
+	}
 	return nil
 }
 
@@ -584,6 +692,10 @@ type P2PConfig struct { //nolint: maligned
 	// Address to advertise to peers for them to dial
 	ExternalAddress string `mapstructure:"external_address"`
 
+	// Transport used to connect to peers: "tcp" or "quic". All the peers of a
+	// network must use the same transport.
+	Transport string `mapstructure:"transport"`
+
 	// Comma separated list of seed nodes to connect to
 	// We only use these if we can’t connect to peers in the addrbook
 	Seeds string `mapstructure:"seeds"`
@@ -625,6 +737,20 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Drop the messages of low priority channels (mempool, pex) instead of
+	// queueing them while the connection to a peer is saturated
+	DropLowPriority bool `mapstructure:"drop_low_priority"`
+
+	// Rates at which the mempool, fast sync and state sync chunk channels can
+	// send and receive, in bytes/second. Messages received over the receive
+	// rates are dropped. 0 means they are only limited by SendRate and RecvRate
+	MempoolSendRate   int64 `mapstructure:"mempool_send_rate"`
+	MempoolRecvRate   int64 `mapstructure:"mempool_recv_rate"`
+	FastSyncSendRate  int64 `mapstructure:"fastsync_send_rate"`
+	FastSyncRecvRate  int64 `mapstructure:"fastsync_recv_rate"`
+	StateSyncSendRate int64 `mapstructure:"statesync_send_rate"`
+	StateSyncRecvRate int64 `mapstructure:"statesync_recv_rate"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -669,6 +795,7 @@ func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                "tcp://0.0.0.0:26656",
 		ExternalAddress:              "",
+		Transport:                    P2PTransportTCP,
 		UPNP:                         false,
 		AddrBook:                     defaultAddrBookPath,
 		AddrBookStrict:               true,
@@ -714,6 +841,11 @@ func (cfg *P2PConfig) AddrBookFile() string {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case P2PTransportTCP, P2PTransportQUIC:
+	default:
+		return fmt.Errorf("unknown transport %q (must be 'tcp' or 'quic')", cfg.Transport)
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return errors.New("max_num_inbound_peers can't be negative")
 	}
@@ -735,6 +867,24 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if cfg.MempoolSendRate < 0 {
+		return errors.New("mempool_send_rate can't be negative")
+	}
+	if cfg.MempoolRecvRate < 0 {
+		return errors.New("mempool_recv_rate can't be negative")
+	}
+	if cfg.FastSyncSendRate < 0 {
+		return errors.New("fastsync_send_rate can't be negative")
+	}
+	if cfg.FastSyncRecvRate < 0 {
+		return errors.New("fastsync_recv_rate can't be negative")
+	}
+	if cfg.StateSyncSendRate < 0 {
+		return errors.New("statesync_send_rate can't be negative")
+	}
+	if cfg.StateSyncRecvRate < 0 {
+		return errors.New("statesync_recv_rate can't be negative")
+	}
 	return nil
 }
 
@@ -845,6 +995,7 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 type StateSyncConfig struct {
 	Enable              bool          `mapstructure:"enable"`
 	TempDir             string        `mapstructure:"temp_dir"`
+	UseP2P              bool          `mapstructure:"use_p2p"`
 	RPCServers          []string      `mapstructure:"rpc_servers"`
 	TrustPeriod         time.Duration `mapstructure:"trust_period"`
 	TrustHeight         int64         `mapstructure:"trust_height"`
@@ -852,6 +1003,7 @@ type StateSyncConfig struct {
 	DiscoveryTime       time.Duration `mapstructure:"discovery_time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
 	ChunkFetchers       int32         `mapstructure:"chunk_fetchers"`
+	NodeSnapshotBlocks  int64         `mapstructure:"node_snapshot_blocks"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -881,17 +1033,19 @@ func TestStateSyncConfig() *StateSyncConfig {
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
 	if cfg.Enable {
-		if len(cfg.RPCServers) == 0 {
-			return errors.New("rpc_servers is required")
-		}
+		if !cfg.UseP2P {
+			if len(cfg.RPCServers) == 0 {
+				return errors.New("rpc_servers is required")
+			}
 
-		if len(cfg.RPCServers) < 2 {
-			return errors.New("at least two rpc_servers entries is required")
-		}
+			if len(cfg.RPCServers) < 2 {
+				return errors.New("at least two rpc_servers entries is required")
+			}
 
-		for _, server := range cfg.RPCServers {
-			if len(server) == 0 {
-				return errors.New("found empty rpc_servers entry")
+			for _, server := range cfg.RPCServers {
+				if len(server) == 0 {
+					return errors.New("found empty rpc_servers entry")
+				}
 			}
 		}
 
@@ -925,6 +1079,10 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		}
 	}
 
+	if cfg.NodeSnapshotBlocks < 0 {
+		return errors.New("node_snapshot_blocks can't be negative")
+	}
+
 	return nil
 }
 
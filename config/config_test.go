@@ -62,6 +62,12 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// both a passphrase and a KMS for the keys
+	cfg = TestBaseConfig()
+	cfg.KeyPassphraseFile = "passphrase"
+	cfg.KeyKMSCommand = "kms-plugin"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -103,6 +109,11 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Transport = P2PTransportQUIC
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Transport = "udp"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -155,6 +166,10 @@ func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg.TrustHash = "00"
 	// Success with Enabled
 	require.NoError(t, cfg.ValidateBasic())
+	// Success with p2p, without rpc_servers
+	cfg.UseP2P = true
+	cfg.RPCServers = nil
+	require.NoError(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
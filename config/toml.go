@@ -155,15 +155,70 @@ priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
 # TCP or UNIX socket address for Ostracon to listen on for
-# connections from an external PrivValidator process
+# connections from an external PrivValidator process.
+# Comma separated addresses of several signers of the same key, sharing their last
+# sign state, can be given for the node to fail over between them
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# Path to the JSON file containing the share of the validator key held by this node
+# when signing with a threshold of cosigners
+priv_validator_key_share_file = "{{ js .BaseConfig.PrivValidatorKeyShare }}"
+
+# Path to the JSON file containing the last sign state of the key share
+priv_validator_cosigner_state_file = "{{ js .BaseConfig.PrivValidatorCosignerState }}"
+
+# Comma separated list of the other cosigners holding shares of the validator key,
+# as id@node_id@tcp://host:port, node_id being the ID of the node key of the
+# cosigner. If set, the node signs with a threshold of cosigners, each refusing to
+# double sign, instead of priv_validator_key_file. The cosigners must allow the
+# ID of this node's key (see start-cosigner --allowed-nodes)
+priv_validator_cosigners = "{{ .BaseConfig.PrivValidatorCosigners }}"
+
+# Path to the PKCS#11 module of the HSM holding the validator key (e.g. libsofthsm2.so).
+# If set, the node signs through the HSM instead of priv_validator_key_file.
+# Requires a build with the pkcs11 build tag (OSTRACON_BUILD_OPTIONS=pkcs11)
+priv_validator_pkcs11_module = "{{ js .BaseConfig.PrivValidatorPKCS11Module }}"
+
+# Label of the PKCS#11 token holding the validator key
+priv_validator_pkcs11_token = "{{ js .BaseConfig.PrivValidatorPKCS11Token }}"
+
+# PIN of the user of the PKCS#11 token.
+# It can be given with the OC_PRIV_VALIDATOR_PKCS11_PIN environment variable instead
+priv_validator_pkcs11_pin = "{{ js .BaseConfig.PrivValidatorPKCS11PIN }}"
+
+# Label of the validator key in the PKCS#11 token
+priv_validator_pkcs11_key_label = "{{ js .BaseConfig.PrivValidatorPKCS11KeyLabel }}"
+
+# Path to the JSON file containing the VRF key of a validator key held in a PKCS#11 token.
+# HSMs can't generate VRF proofs, and the VRF key is the signing key, so a copy of the
+# signing key is kept in this file, encrypted by a key of the token. It's decrypted into
+# the memory of the node to generate each proof, i.e. on every proposal: the token only
+# protects the key at rest, and the exposure of the signing key to a compromised node
+# is the same as with priv_validator_key_file
+priv_validator_vrf_key_file = "{{ js .BaseConfig.PrivValidatorVRFKey }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
+# Path to a file containing the passphrase of encrypted validator and node key files
+# (see "ostracon encrypt-keys"). The passphrase can be given with the
+# OC_KEY_PASSPHRASE environment variable instead
+key_passphrase_file = "{{ js .BaseConfig.KeyPassphraseFile }}"
+
+# Command of a KMS plugin to unlock encrypted validator and node key files with
+# instead of a passphrase. Each key is encrypted with a random data key, which the
+# command encrypts or decrypts when run with an extra "encrypt" or "decrypt"
+# argument, reading its input on stdin and writing its output on stdout
+key_kms_command = "{{ js .BaseConfig.KeyKMSCommand }}"
+
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# If true, deliver all txs of a block to the ABCI app with a single DeliverTxBatch
+# request, along with the dependency hints the app declared at CheckTx.
+# The app must implement DeliverTxBatch and return one result per tx.
+deliver_tx_batch = {{ .BaseConfig.DeliverTxBatch }}
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
@@ -322,6 +377,15 @@ laddr = "{{ .P2P.ListenAddress }}"
 # example: 159.89.10.97:26656
 external_address = "{{ .P2P.ExternalAddress }}"
 
+# Transport used to connect to peers:
+#   1) "tcp" - TCP connections encrypted with SecretConnection, multiplexing
+#      the channels over a single stream (default)
+#   2) "quic" - QUIC connections authenticated with the node key, with a
+#      stream per channel, so that a channel isn't blocked behind the others
+#      on lossy links. The laddr port is used over UDP.
+# All the peers of a network must use the same transport.
+transport = "{{ .P2P.Transport }}"
+
 # Comma separated list of seed nodes to connect to
 seeds = "{{ .P2P.Seeds }}"
 
@@ -362,6 +426,23 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Drop the messages of low priority channels (mempool, pex) instead of
+# queueing them while the connection to a peer is saturated, that is while
+# the send queues of the other channels are filling up or send_rate is reached
+drop_low_priority = {{ .P2P.DropLowPriority }}
+
+# Rates at which the mempool, fast sync and state sync chunk channels can send
+# and receive, in bytes/second, so that they don't take the whole connection.
+# Sends wait for the channel to get back under its rate, while messages received
+# over the rate are dropped, so the receive rates should not be lower than the
+# send rates of the peers. 0 means they are only limited by send_rate and recv_rate
+mempool_send_rate = {{ .P2P.MempoolSendRate }}
+mempool_recv_rate = {{ .P2P.MempoolRecvRate }}
+fastsync_send_rate = {{ .P2P.FastSyncSendRate }}
+fastsync_recv_rate = {{ .P2P.FastSyncRecvRate }}
+statesync_send_rate = {{ .P2P.StateSyncSendRate }}
+statesync_recv_rate = {{ .P2P.StateSyncRecvRate }}
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -443,6 +524,10 @@ enable = {{ .StateSync.Enable }}
 #
 # For Cosmos SDK-based chains, trust_period should usually be about 2/3 of the unbonding time (~2
 # weeks) during which they can be financially punished (slashed) for misbehavior.
+#
+# If use_p2p is true, light blocks and consensus params are fetched from connected peers instead,
+# and rpc_servers is not needed. At least two peers serving light blocks must be connected.
+use_p2p = {{ .StateSync.UseP2P }}
 rpc_servers = "{{ StringsJoin .StateSync.RPCServers "," }}"
 trust_height = {{ .StateSync.TrustHeight }}
 trust_hash = "{{ .StateSync.TrustHash }}"
@@ -451,17 +536,27 @@ trust_period = "{{ .StateSync.TrustPeriod }}"
 # Time to spend discovering snapshots before initiating a restore.
 discovery_time = "{{ .StateSync.DiscoveryTime }}"
 
-# Temporary directory for state sync snapshot chunks, defaults to the OS tempdir (typically /tmp).
-# Will create a new, randomly named directory within, and remove it when done.
+# Temporary directory for state sync snapshot chunks, defaults to the statesync directory in the
+# data dir. Chunks are stored in a directory named after the snapshot, which is removed when done.
+# A partially downloaded snapshot is resumed from it after a restart, keeping only the chunks that
+# can be verified against the chunk hashes in the snapshot metadata.
 temp_dir = "{{ .StateSync.TempDir }}"
 
 # The timeout duration before re-requesting a chunk, possibly from a different
-# peer (default: 1 minute).
+# peer (default: 1 minute). Once a peer has sent chunks, a shorter timeout based
+# on its observed latency is used for it, up to this value.
 chunk_request_timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 
 # The number of concurrent chunk fetchers to run (default: 1).
 chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 
+# The number of recent blocks, together with their commits, validator sets and consensus params,
+# exchanged as a node snapshot alongside the app snapshot. A state synced node fetches them after
+# restoring the app snapshot, so it can serve historical light blocks and verify evidence right
+# away. A node serves the node snapshot of these blocks up to its latest app snapshot, built in the
+# background whenever the app takes a new snapshot. 0 disables node snapshots (default: 0).
+node_snapshot_blocks = {{ .StateSync.NodeSnapshotBlocks }}
+
 #######################################################
 ###       Fast Sync Configuration Connections       ###
 #######################################################
@@ -118,6 +118,8 @@ type txNotifier interface {
 type evidencePool interface {
 	// reports conflicting votes to the evidence pool to be processed into evidence
 	ReportConflictingVotes(voteA, voteB *types.Vote)
+	// reports conflicting proposals of the proposer to the evidence pool to be processed into evidence
+	ReportConflictingProposals(proposalA, proposalB *types.Proposal, proposer types.Address)
 }
 
 // State handles execution of the consensus algorithm.
@@ -1144,7 +1146,7 @@ func (cs *State) enterPropose(height int64, round int32) {
 		return
 	}
 
-	address := cs.privValidatorPubKey.Address()
+	address := cs.privValidatorAddress()
 
 	// if not a validator, we're done
 	if !cs.Validators.HasAddress(address) {
@@ -1263,7 +1265,7 @@ func (cs *State) createProposalBlock(round int32) (block *types.Block, blockPart
 		return
 	}
 
-	proposerAddr := cs.privValidatorPubKey.Address()
+	proposerAddr := cs.privValidatorAddress()
 
 	message := cs.state.MakeHashMessage(round)
 
@@ -1812,7 +1814,7 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 				// Metrics won't be updated, but it's not critical.
 				cs.Logger.Error(fmt.Sprintf("recordMetrics: %v", errPubKeyIsNotSet))
 			} else {
-				address = cs.privValidatorPubKey.Address()
+				address = cs.privValidatorAddress()
 			}
 		}
 
@@ -1821,6 +1823,8 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 			if err != nil {
 				// Metrics won't be updated, but it's not critical.
 				cs.Logger.Error("Error on retrieval of pubkey", "err", err)
+			} else if _, val := cs.LastValidators.GetByPubKey(pubkey); val != nil {
+				address = val.Address
 			} else {
 				address = pubkey.Address()
 			}
@@ -1902,8 +1906,8 @@ func (cs *State) recordMetrics(height int64, block *types.Block) {
 
 func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	// Already have one
-	// TODO: possibly catch double proposals
 	if cs.Proposal != nil {
+		cs.checkDuplicateProposal(proposal)
 		return nil
 	}
 
@@ -1945,6 +1949,25 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	return nil
 }
 
+// checkDuplicateProposal reports the proposal to the evidence pool if it's signed by the proposer
+// and conflicts with the proposal we already have.
+func (cs *State) checkDuplicateProposal(proposal *types.Proposal) {
+	if proposal.Height != cs.Proposal.Height || proposal.Round != cs.Proposal.Round ||
+		proposal.BlockID.Equals(cs.Proposal.BlockID) {
+		return
+	}
+
+	proposer := cs.Validators.SelectProposer(cs.state.LastProofHash, proposal.Height, proposal.Round)
+	if !proposer.PubKey.VerifySignature(
+		types.ProposalSignBytes(cs.state.ChainID, proposal.ToProto()), proposal.Signature,
+	) {
+		return
+	}
+
+	cs.Logger.Info("found conflicting proposal", "proposal", cs.Proposal, "conflicting", proposal)
+	cs.evpool.ReportConflictingProposals(cs.Proposal, proposal, proposer.Address)
+}
+
 // NOTE: block is not necessarily valid.
 // Asynchronously triggers either enterPrevote (before we timeout of propose) or tryFinalizeCommit,
 // once we have the full block.
@@ -2057,7 +2080,7 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 				return false, errPubKeyIsNotSet
 			}
 
-			if bytes.Equal(vote.ValidatorAddress, cs.privValidatorPubKey.Address()) {
+			if bytes.Equal(vote.ValidatorAddress, cs.privValidatorAddress()) {
 				cs.Logger.Error(
 					"found conflicting vote from ourselves; did you unsafe_reset a validator?",
 					"height", vote.Height,
@@ -2284,7 +2307,7 @@ func (cs *State) signVote(
 		return nil, errPubKeyIsNotSet
 	}
 
-	addr := cs.privValidatorPubKey.Address()
+	addr := cs.privValidatorAddress()
 	valIdx, _ := cs.Validators.GetByAddress(addr)
 
 	vote := &types.Vote{
@@ -2337,7 +2360,7 @@ func (cs *State) signAddVote(msgType tmproto.SignedMsgType, hash []byte, header
 	}
 
 	// If the node not in the validator set, do nothing.
-	if !cs.Validators.HasAddress(cs.privValidatorPubKey.Address()) {
+	if !cs.Validators.HasAddress(cs.privValidatorAddress()) {
 		return nil
 	}
 
@@ -2354,8 +2377,9 @@ func (cs *State) signAddVote(msgType tmproto.SignedMsgType, hash []byte, header
 }
 
 // updatePrivValidatorPubKey get's the private validator public key and
-// memoizes it. This func returns an error if the private validator is not
-// responding or responds with an error.
+// memoizes it, switching to the rotated key of our validator once it's active.
+// This func returns an error if the private validator is not responding or
+// responds with an error.
 func (cs *State) updatePrivValidatorPubKey() error {
 	if cs.privValidator == nil {
 		return nil
@@ -2365,14 +2389,46 @@ func (cs *State) updatePrivValidatorPubKey() error {
 	if err != nil {
 		return err
 	}
+
+	// Our validator rotated its key => switch to the new one, if the private validator can.
+	if switcher, ok := cs.privValidator.(types.KeySwitcher); ok && cs.Validators != nil {
+		if _, val := cs.Validators.GetByPubKey(pubKey); val == nil {
+			for _, val := range cs.Validators.Validators {
+				if !val.HasPubKey(pubKey) {
+					continue
+				}
+				if err := switcher.SwitchKey(val.PubKey); err != nil {
+					return err
+				}
+				cs.Logger.Info("switched to the rotated private validator key", "height", cs.Height,
+					"address", val.Address, "pubKey", val.PubKey)
+				if pubKey, err = cs.privValidator.GetPubKey(); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
 	cs.privValidatorPubKey = pubKey
 	return nil
 }
 
+// privValidatorAddress returns the address of our validator, which isn't the one of our key if
+// the validator rotated its key.
+// CONTRACT: cs.privValidatorPubKey is not nil
+func (cs *State) privValidatorAddress() types.Address {
+	if cs.Validators != nil {
+		if _, val := cs.Validators.GetByPubKey(cs.privValidatorPubKey); val != nil {
+			return val.Address
+		}
+	}
+	return cs.privValidatorPubKey.Address()
+}
+
 // look back to check existence of the node's consensus votes before joining consensus
 func (cs *State) checkDoubleSigningRisk(height int64) error {
 	if cs.privValidator != nil && cs.privValidatorPubKey != nil && cs.config.DoubleSignCheckHeight > 0 && height > 0 {
-		valAddr := cs.privValidatorPubKey.Address()
+		valAddr := cs.privValidatorAddress()
 		doubleSignCheckHeight := cs.config.DoubleSignCheckHeight
 		if doubleSignCheckHeight > height {
 			doubleSignCheckHeight = height
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -17,12 +18,16 @@ import (
 	"github.com/Finschia/ostracon/abci/example/counter"
 	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/abci/types/mocks"
+	cfg "github.com/Finschia/ostracon/config"
 	cstypes "github.com/Finschia/ostracon/consensus/types"
+	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	"github.com/Finschia/ostracon/libs/log"
 	tmpubsub "github.com/Finschia/ostracon/libs/pubsub"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	p2pmock "github.com/Finschia/ostracon/p2p/mock"
+	"github.com/Finschia/ostracon/privval"
+	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
 )
 
@@ -241,6 +246,61 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+type conflictingProposalsRecorder struct {
+	sm.EmptyEvidencePool
+	proposals [][2]*types.Proposal
+	proposers []types.Address
+}
+
+func (r *conflictingProposalsRecorder) ReportConflictingProposals(proposalA, proposalB *types.Proposal,
+	proposer types.Address) {
+	r.proposals = append(r.proposals, [2]*types.Proposal{proposalA, proposalB})
+	r.proposers = append(r.proposers, proposer)
+}
+
+func TestStateReportsConflictingProposals(t *testing.T) {
+	cs1, vss := randState(2)
+	height, round := cs1.Height, cs1.Round
+	recorder := &conflictingProposalsRecorder{}
+	cs1.evpool = recorder
+
+	proposer := cs1.Validators.SelectProposer(cs1.state.LastProofHash, height, round)
+	var vs *validatorStub
+	for _, v := range vss {
+		pubKey, err := v.GetPubKey()
+		require.NoError(t, err)
+		if bytes.Equal(pubKey.Address(), proposer.Address) {
+			vs = v
+		}
+	}
+	require.NotNil(t, vs)
+
+	signProposal := func(hash []byte) *types.Proposal {
+		blockID := types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Total: 1, Hash: hash}}
+		proposal := types.NewProposal(height, round, -1, blockID)
+		p := proposal.ToProto()
+		require.NoError(t, vs.SignProposal(config.ChainID(), p))
+		proposal.Signature = p.Signature
+		return proposal
+	}
+	proposalA := signProposal(tmrand.Bytes(tmhash.Size))
+	proposalB := signProposal(tmrand.Bytes(tmhash.Size))
+
+	require.NoError(t, cs1.defaultSetProposal(proposalA))
+	require.NoError(t, cs1.defaultSetProposal(proposalA))
+	require.Empty(t, recorder.proposals)
+
+	// a conflicting proposal with a bad signature is ignored
+	unsigned := signProposal(tmrand.Bytes(tmhash.Size))
+	unsigned.Signature = tmrand.Bytes(len(unsigned.Signature))
+	require.NoError(t, cs1.defaultSetProposal(unsigned))
+	require.Empty(t, recorder.proposals)
+
+	require.NoError(t, cs1.defaultSetProposal(proposalB))
+	require.Len(t, recorder.proposals, 1)
+	assert.Equal(t, [2]*types.Proposal{proposalA, proposalB}, recorder.proposals[0])
+	assert.Equal(t, proposer.Address, recorder.proposers[0])
+}
 func TestStateOversizedBlock(t *testing.T) {
 	cs1, vss := randState(2)
 	cs1.state.ConsensusParams.Block.MaxBytes = 2000
@@ -2125,3 +2185,35 @@ func TestPruneBlocks(t *testing.T) {
 	// Wait for new round so next validator is set.
 	ensureNewRound(newRoundCh, height+1, 0)
 }
+
+func TestStateSwitchRotatedKey(t *testing.T) {
+	config := cfg.ResetTestRoot("consensus_switch_rotated_key_test")
+	defer os.RemoveAll(config.RootDir)
+	privVal := loadPrivValidator(config)
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	address := pubKey.Address()
+
+	state, _ := randGenesisState(1, false, 10)
+	state.Validators = types.NewValidatorSet([]*types.Validator{types.NewValidator(pubKey, 10)})
+	state.NextValidators = state.Validators.CopyIncrementProposerPriority(1)
+	cs := newState(state, privVal, counter.NewApplication(true))
+
+	// the key is rotated by another process, like the CLI
+	rotator := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	newPrivKey := ed25519.GenPrivKey()
+	_, err = rotator.RotateKey(state.ChainID, newPrivKey, 3, 1, state.LastBlockTime)
+	require.NoError(t, err)
+
+	// nothing changes until the rotation is active
+	require.NoError(t, cs.Validators.RotateKey(address, newPrivKey.PubKey(), 3))
+	require.NoError(t, cs.updatePrivValidatorPubKey())
+	assert.Equal(t, pubKey, cs.privValidatorPubKey)
+
+	cs.Validators.ActivateKeys(3)
+	require.NoError(t, cs.updatePrivValidatorPubKey())
+	assert.Equal(t, newPrivKey.PubKey(), cs.privValidatorPubKey)
+	assert.Equal(t, address, cs.privValidatorAddress())
+	assert.Equal(t, newPrivKey, privval.LoadFilePV(config.PrivValidatorKeyFile(),
+		config.PrivValidatorStateFile()).Key.PrivKey)
+}
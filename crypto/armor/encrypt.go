@@ -0,0 +1,211 @@
+package armor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/xsalsa20symmetric"
+)
+
+const (
+	headerKDF        = "kdf"
+	headerSalt       = "salt"
+	headerScryptN    = "n"
+	headerScryptR    = "r"
+	headerScryptP    = "p"
+	headerWrappedKey = "wrapped-key"
+
+	kdfScrypt = "scrypt"
+	kdfKMS    = "kms"
+
+	secretLen = 32
+	saltLen   = 16
+)
+
+// scrypt parameters of new secrets, recorded in the headers of the encrypted
+// data.
+var (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Bounds of the scrypt parameters read from the headers, so that a crafted
+// file can't make the node spend unbounded memory or CPU to derive a secret.
+// The cost of N and r is capped to 1 GiB of memory (128 * N * r bytes).
+const (
+	maxScryptN   = 1 << 20
+	maxScryptR   = 32
+	maxScryptP   = 16
+	maxScryptNxR = 1 << 23
+)
+
+// Unlocker provides the secret which armored data is encrypted with.
+type Unlocker interface {
+	// NewSecret returns a new secret to encrypt data with, and the headers
+	// to get it back from with Secret.
+	NewSecret() (secret []byte, headers map[string]string, err error)
+	// Secret returns the secret of the headers of encrypted data.
+	Secret(headers map[string]string) ([]byte, error)
+}
+
+// EncryptArmor encrypts data with a secret of unlocker, and encodes it in
+// ASCII armor.
+func EncryptArmor(blockType string, data []byte, unlocker Unlocker) (string, error) {
+	secret, headers, err := unlocker.NewSecret()
+	if err != nil {
+		return "", err
+	}
+	return EncodeArmor(blockType, headers, xsalsa20symmetric.EncryptSymmetric(data, secret)), nil
+}
+
+// DecryptArmor decodes data encrypted by EncryptArmor, and decrypts it with
+// the secret of unlocker.
+func DecryptArmor(armorStr string, unlocker Unlocker) (blockType string, data []byte, err error) {
+	blockType, headers, ciphertext, err := DecodeArmor(armorStr)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := unlocker.Secret(headers)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err = xsalsa20symmetric.DecryptSymmetric(ciphertext, secret)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decrypt %s, wrong passphrase or key? %w", blockType, err)
+	}
+	return blockType, data, nil
+}
+
+// IsArmored returns true if data looks like ASCII armor.
+func IsArmored(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN "))
+}
+
+//-----------------------------------------------------------------------------
+
+// Passphrase is an Unlocker deriving secrets from a passphrase with scrypt.
+type Passphrase []byte
+
+var _ Unlocker = Passphrase(nil)
+
+// NewSecret implements Unlocker.
+func (p Passphrase) NewSecret() ([]byte, map[string]string, error) {
+	salt := crypto.CRandBytes(saltLen)
+	secret, err := scrypt.Key(p, salt, scryptN, scryptR, scryptP, secretLen)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret, map[string]string{
+		headerKDF:     kdfScrypt,
+		headerSalt:    hex.EncodeToString(salt),
+		headerScryptN: strconv.Itoa(scryptN),
+		headerScryptR: strconv.Itoa(scryptR),
+		headerScryptP: strconv.Itoa(scryptP),
+	}, nil
+}
+
+// Secret implements Unlocker.
+func (p Passphrase) Secret(headers map[string]string) ([]byte, error) {
+	if headers[headerKDF] != kdfScrypt {
+		return nil, fmt.Errorf("unsupported kdf %q for a passphrase", headers[headerKDF])
+	}
+	salt, err := hex.DecodeString(headers[headerSalt])
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	var params [3]int
+	for i, header := range []string{headerScryptN, headerScryptR, headerScryptP} {
+		params[i], err = strconv.Atoi(headers[header])
+		if err != nil {
+			return nil, fmt.Errorf("invalid scrypt parameter %s: %w", header, err)
+		}
+	}
+	if err := checkScryptParams(params[0], params[1], params[2]); err != nil {
+		return nil, err
+	}
+	return scrypt.Key(p, salt, params[0], params[1], params[2], secretLen)
+}
+
+func checkScryptParams(n, r, p int) error {
+	switch {
+	case n < 2 || n > maxScryptN || n&(n-1) != 0:
+		return fmt.Errorf("invalid scrypt parameter %s: %d, must be a power of 2 up to %d", headerScryptN, n, maxScryptN)
+	case r < 1 || r > maxScryptR:
+		return fmt.Errorf("invalid scrypt parameter %s: %d, must be in [1, %d]", headerScryptR, r, maxScryptR)
+	case p < 1 || p > maxScryptP:
+		return fmt.Errorf("invalid scrypt parameter %s: %d, must be in [1, %d]", headerScryptP, p, maxScryptP)
+	case n*r > maxScryptNxR:
+		return fmt.Errorf("scrypt parameters %s=%d and %s=%d need more than %d MiB of memory",
+			headerScryptN, n, headerScryptR, r, 128*maxScryptNxR>>20)
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// KMSCommand is an Unlocker using envelope encryption with a KMS plugin
+// command: data is encrypted with a random data key, which is encrypted by
+// the KMS and stored in the headers.
+//
+// The command is run with an extra "encrypt" or "decrypt" argument, reads the
+// data key or the encrypted data key on its standard input, and writes the
+// result on its standard output.
+type KMSCommand []string
+
+var _ Unlocker = KMSCommand(nil)
+
+// NewSecret implements Unlocker.
+func (c KMSCommand) NewSecret() ([]byte, map[string]string, error) {
+	secret := crypto.CRandBytes(secretLen)
+	wrapped, err := c.run("encrypt", secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret, map[string]string{
+		headerKDF:        kdfKMS,
+		headerWrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+	}, nil
+}
+
+// Secret implements Unlocker.
+func (c KMSCommand) Secret(headers map[string]string) ([]byte, error) {
+	if headers[headerKDF] != kdfKMS {
+		return nil, fmt.Errorf("unsupported kdf %q for a KMS", headers[headerKDF])
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(headers[headerWrappedKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid wrapped key: %w", err)
+	}
+	secret, err := c.run("decrypt", wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) != secretLen {
+		return nil, fmt.Errorf("KMS returned a %d bytes data key, expected %d", len(secret), secretLen)
+	}
+	return secret, nil
+}
+
+func (c KMSCommand) run(op string, input []byte) ([]byte, error) {
+	if len(c) == 0 {
+		return nil, errors.New("empty KMS command")
+	}
+	cmd := exec.Command(c[0], append(c[1:len(c):len(c)], op)...) //nolint:gosec // the command is configured
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("KMS command failed to %s: %w: %s", op, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return output, nil
+}
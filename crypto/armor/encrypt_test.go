@@ -0,0 +1,88 @@
+package armor
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptArmorPassphrase(t *testing.T) {
+	data := []byte("somedata")
+	armorStr, err := EncryptArmor("MINT TEST", data, Passphrase("passphrase"))
+	require.NoError(t, err)
+	assert.True(t, IsArmored([]byte(armorStr)))
+	assert.NotContains(t, armorStr, "somedata")
+
+	blockType, decrypted, err := DecryptArmor(armorStr, Passphrase("passphrase"))
+	require.NoError(t, err)
+	assert.Equal(t, "MINT TEST", blockType)
+	assert.Equal(t, data, decrypted)
+
+	_, _, err = DecryptArmor(armorStr, Passphrase("wrong"))
+	assert.Error(t, err)
+	_, _, err = DecryptArmor(armorStr, KMSCommand{"cat"})
+	assert.Error(t, err)
+}
+
+func TestPassphraseScryptParamsBounds(t *testing.T) {
+	_, headers, err := Passphrase("passphrase").NewSecret()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		n, r, p int
+		ok      bool
+	}{
+		{1 << 15, 8, 1, true},
+		{maxScryptN, 8, 1, true},
+		{maxScryptN << 1, 8, 1, false},
+		{1000, 8, 1, false},
+		{1, 8, 1, false},
+		{1 << 15, 0, 1, false},
+		{1 << 15, maxScryptR + 1, 1, false},
+		{1 << 15, 8, 0, false},
+		{1 << 15, 8, maxScryptP + 1, false},
+		{maxScryptN, 16, 1, false},
+	}
+	for _, tc := range testCases {
+		err := checkScryptParams(tc.n, tc.r, tc.p)
+		if tc.ok {
+			assert.NoError(t, err, "n=%d r=%d p=%d", tc.n, tc.r, tc.p)
+			continue
+		}
+		assert.Error(t, err, "n=%d r=%d p=%d", tc.n, tc.r, tc.p)
+
+		headers[headerScryptN] = strconv.Itoa(tc.n)
+		headers[headerScryptR] = strconv.Itoa(tc.r)
+		headers[headerScryptP] = strconv.Itoa(tc.p)
+		_, err = Passphrase("passphrase").Secret(headers)
+		assert.Error(t, err, "n=%d r=%d p=%d", tc.n, tc.r, tc.p)
+	}
+}
+
+func TestEncryptArmorKMSCommand(t *testing.T) {
+	// a KMS plugin xoring data keys with a key of its own
+	plugin := filepath.Join(t.TempDir(), "kms")
+	require.NoError(t, os.WriteFile(plugin, []byte(`#!/bin/sh
+exec tr '\000-\377' '\200-\377\000-\177'
+`), 0700))
+
+	data := []byte("somedata")
+	armorStr, err := EncryptArmor("MINT TEST", data, KMSCommand{plugin})
+	require.NoError(t, err)
+
+	_, decrypted, err := DecryptArmor(armorStr, KMSCommand{plugin})
+	require.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	// the wrapped key isn't the data key
+	_, _, err = DecryptArmor(armorStr, KMSCommand{"cat"})
+	assert.Error(t, err)
+	_, _, err = DecryptArmor(armorStr, KMSCommand{"false"})
+	assert.Error(t, err)
+	_, _, err = DecryptArmor(armorStr, Passphrase("passphrase"))
+	assert.Error(t, err)
+}
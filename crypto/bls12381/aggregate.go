@@ -0,0 +1,68 @@
+package bls12381
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/ecc/bls12381"
+)
+
+// AggregateSignatures aggregates signatures, of any messages and by any keys, into one signature
+// of SignatureSize bytes.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	var agg bls12381.G2
+	agg.SetIdentity()
+	for i, sig := range sigs {
+		if len(sig) != SignatureSize {
+			return nil, fmt.Errorf("invalid size for signature #%d. Got %d, expected %d", i, len(sig), SignatureSize)
+		}
+		var s bls12381.G2
+		if err := s.SetBytes(sig); err != nil {
+			return nil, fmt.Errorf("invalid signature #%d: %w", i, err)
+		}
+		agg.Add(&agg, &s)
+	}
+	return agg.BytesCompressed(), nil
+}
+
+// VerifyAggregatedSignature verifies a signature aggregated from the signatures of msgs[i] by
+// pubKeys[i]. The messages need not be distinct.
+//
+// The whole aggregate is checked with a single product of pairings, which is much cheaper than
+// verifying the signatures one by one.
+func VerifyAggregatedSignature(pubKeys []PubKey, msgs [][]byte, sig []byte) bool {
+	return verify(pubKeys, msgs, sig, dstSignature)
+}
+
+// verify checks that e(G1, sig) equals the product of e(pubKeys[i], H(pubKeys[i] || msgs[i])).
+func verify(pubKeys []PubKey, msgs [][]byte, sig []byte, dst []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) || len(sig) != SignatureSize {
+		return false
+	}
+	s := new(bls12381.G2)
+	if err := s.SetBytes(sig); err != nil {
+		return false
+	}
+
+	points := make([]*bls12381.G1, 0, len(pubKeys)+1)
+	hashes := make([]*bls12381.G2, 0, len(pubKeys)+1)
+	signs := make([]int, 0, len(pubKeys)+1)
+	points = append(points, bls12381.G1Generator())
+	hashes = append(hashes, s)
+	signs = append(signs, 1)
+	for i, pubKey := range pubKeys {
+		pk, err := pubKey.point()
+		if err != nil {
+			return false
+		}
+		h := new(bls12381.G2)
+		h.Hash(augment(pubKey, msgs[i]), dst)
+		points = append(points, pk)
+		hashes = append(hashes, h)
+		signs = append(signs, -1)
+	}
+	return bls12381.ProdPairFrac(points, hashes, signs).IsIdentity()
+}
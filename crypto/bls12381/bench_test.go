@@ -0,0 +1,52 @@
+package bls12381
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/internal/benchmarking"
+)
+
+func BenchmarkKeyGeneration(b *testing.B) {
+	benchmarkKeygenWrapper := func(reader io.Reader) crypto.PrivKey {
+		return genPrivKey(reader)
+	}
+	benchmarking.BenchmarkKeyGeneration(b, benchmarkKeygenWrapper)
+}
+
+func BenchmarkSigning(b *testing.B) {
+	priv := GenPrivKey()
+	benchmarking.BenchmarkSigning(b, priv)
+}
+
+func BenchmarkVerification(b *testing.B) {
+	priv := GenPrivKey()
+	benchmarking.BenchmarkVerification(b, priv)
+}
+
+func BenchmarkVerifyAggregatedSignature(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 200} {
+		pubKeys := make([]PubKey, n)
+		msgs := make([][]byte, n)
+		sigs := make([][]byte, n)
+		for i := 0; i < n; i++ {
+			priv := GenPrivKey()
+			pubKeys[i] = priv.PubKey().(PubKey)
+			msgs[i] = crypto.CRandBytes(128)
+			sigs[i], _ = priv.Sign(msgs[i])
+		}
+		agg, err := AggregateSignatures(sigs)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !VerifyAggregatedSignature(pubKeys, msgs, agg) {
+					b.Fatal("aggregated signature did not verify")
+				}
+			}
+		})
+	}
+}
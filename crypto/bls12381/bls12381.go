@@ -0,0 +1,257 @@
+// Package bls12381 implements BLS signatures on the BLS12-381 curve with public keys in G1 and
+// signatures in G2, using the message augmentation scheme so that signatures of the same message
+// can be aggregated safely. The keys also implement the VRF of crypto.PrivKey with BLS signatures
+// under a separate domain, whose output is the hash of the unique signature.
+//
+// The public keys are carried by ostracon's own protobuf messages, and by the private validator
+// protocol in ostracon's PubKeyResponse. ABCI uses Tendermint's PublicKey, which can't hold a
+// BLS12-381 key: the validators passed to InitChain and returned by EndBlock can't have BLS12-381
+// keys, so the genesis file can't contain them and applications can't add them.
+package bls12381
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/ecc/bls12381"
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+)
+
+//-------------------------------------
+
+var _ crypto.PrivKey = PrivKey{}
+
+const (
+	PrivKeyName = "ostracon/PrivKeyBLS12381"
+	PubKeyName  = "ostracon/PubKeyBLS12381"
+	// PubKeySize is the size, in bytes, of public keys as used in this package: compressed G1 points.
+	PubKeySize = 48
+	// PrivKeySize is the size, in bytes, of private keys as used in this package: big-endian scalars.
+	PrivKeySize = 32
+	// SignatureSize is the size, in bytes, of signatures as used in this package: compressed G2 points.
+	SignatureSize = 96
+	// ProofSize is the size, in bytes, of VRF proofs, which are signatures too.
+	ProofSize = SignatureSize
+	// OutputSize is the size, in bytes, of VRF outputs.
+	OutputSize = sha512.Size
+
+	KeyType = "bls12381"
+)
+
+var (
+	// dstSignature is the domain separation tag of the message augmentation scheme of the BLS
+	// signature draft. Every message is signed together with the public key of its signer, so the
+	// signatures of equal messages can be aggregated without proofs of possession of the keys.
+	dstSignature = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+
+	// dstVRF separates VRF proofs from signatures. BLS signatures are unique, so a signature on
+	// the VRF message is a proof whose hash is the random output.
+	dstVRF = []byte("OSTRACON_VRF_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+)
+
+func init() {
+	tmjson.RegisterType(PubKey{}, PubKeyName)
+	tmjson.RegisterType(PrivKey{}, PrivKeyName)
+}
+
+// PrivKey implements crypto.PrivKey.
+type PrivKey []byte
+
+// Bytes returns the privkey byte format.
+func (privKey PrivKey) Bytes() []byte {
+	return []byte(privKey)
+}
+
+// Sign produces a signature on the provided message.
+func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
+	return privKey.sign(msg, dstSignature)
+}
+
+// VRFProve generates a VRF Proof for given message to generate a verifiable random.
+func (privKey PrivKey) VRFProve(message []byte) (crypto.Proof, error) {
+	proof, err := privKey.sign(message, dstVRF)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Proof(proof), nil
+}
+
+func (privKey PrivKey) sign(msg, dst []byte) ([]byte, error) {
+	sk, err := privKey.scalar()
+	if err != nil {
+		return nil, err
+	}
+	var sig bls12381.G2
+	sig.Hash(augment(publicKey(sk), msg), dst)
+	sig.ScalarMult(sk, &sig)
+	return sig.BytesCompressed(), nil
+}
+
+// PubKey gets the corresponding public key from the private key.
+//
+// Panics if the private key is not a valid scalar.
+func (privKey PrivKey) PubKey() crypto.PubKey {
+	sk, err := privKey.scalar()
+	if err != nil {
+		panic(err)
+	}
+	return publicKey(sk)
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
+	if otherBLS, ok := other.(PrivKey); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherBLS[:]) == 1
+	}
+	return false
+}
+
+func (privKey PrivKey) Type() string {
+	return KeyType
+}
+
+func (privKey PrivKey) scalar() (*bls12381.Scalar, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, fmt.Errorf("invalid size for PrivKeyBLS12381. Got %d, expected %d", len(privKey), PrivKeySize)
+	}
+	sk := new(bls12381.Scalar)
+	if err := sk.UnmarshalBinary(privKey); err != nil {
+		return nil, fmt.Errorf("invalid PrivKeyBLS12381: %w", err)
+	}
+	if sk.IsZero() == 1 {
+		return nil, errors.New("invalid PrivKeyBLS12381: zero scalar")
+	}
+	return sk, nil
+}
+
+func publicKey(sk *bls12381.Scalar) PubKey {
+	var pk bls12381.G1
+	pk.ScalarMult(sk, bls12381.G1Generator())
+	return PubKey(pk.BytesCompressed())
+}
+
+// GenPrivKey generates a new BLS12-381 private key.
+// It uses OS randomness in conjunction with the current global random seed
+// in ostracon/libs/common to generate the private key.
+func GenPrivKey() PrivKey {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new BLS12-381 private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKey {
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(rand, ikm); err != nil {
+		panic(err)
+	}
+	return privKeyFromIKM(ikm)
+}
+
+// GenPrivKeyFromSecret hashes the secret with SHA2, and uses
+// that 32 byte output to create the private key.
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKey {
+	return privKeyFromIKM(crypto.Sha256(secret))
+}
+
+// privKeyFromIKM derives a private key from the keying material with the KeyGen of the BLS
+// signature draft.
+func privKeyFromIKM(ikm []byte) PrivKey {
+	sk, err := bls.KeyGen[bls.KeyG1SigG2](ikm, nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(bz)
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKey{}
+
+// PubKey implements crypto.PubKey for the BLS12-381 signature scheme.
+type PubKey []byte
+
+// Address is the SHA256-20 of the raw pubkey bytes.
+func (pubKey PubKey) Address() crypto.Address {
+	if len(pubKey) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.Address(tmhash.SumTruncated(pubKey))
+}
+
+// Bytes returns the PubKey byte format.
+func (pubKey PubKey) Bytes() []byte {
+	return []byte(pubKey)
+}
+
+func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	return VerifyAggregatedSignature([]PubKey{pubKey}, [][]byte{msg}, sig)
+}
+
+// VRFVerify verifies that the given VRF Proof was generated from the message by the owner of this public key.
+func (pubKey PubKey) VRFVerify(proof crypto.Proof, message []byte) (crypto.Output, error) {
+	if !verify([]PubKey{pubKey}, [][]byte{message}, proof, dstVRF) {
+		return nil, fmt.Errorf("the specified Proof is not generated with this pair-key: %X", []byte(proof))
+	}
+	return ProofToHash(proof)
+}
+
+// ProofToHash returns the VRF output of a proof, the hash of the unique signature. It doesn't
+// verify the proof.
+func ProofToHash(proof crypto.Proof) (crypto.Output, error) {
+	if len(proof) != ProofSize {
+		return nil, fmt.Errorf("invalid size for VRF proof. Got %d, expected %d", len(proof), ProofSize)
+	}
+	output := sha512.Sum512(proof)
+	return crypto.Output(output[:]), nil
+}
+
+func (pubKey PubKey) String() string {
+	return fmt.Sprintf("PubKeyBLS12381{%X}", []byte(pubKey))
+}
+
+func (pubKey PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PubKey) Equals(other crypto.PubKey) bool {
+	if otherBLS, ok := other.(PubKey); ok {
+		return bytes.Equal(pubKey[:], otherBLS[:])
+	}
+	return false
+}
+
+// point decodes the public key, rejecting the identity, which would verify any signature.
+func (pubKey PubKey) point() (*bls12381.G1, error) {
+	if len(pubKey) != PubKeySize {
+		return nil, fmt.Errorf("invalid size for PubKeyBLS12381. Got %d, expected %d", len(pubKey), PubKeySize)
+	}
+	pk := new(bls12381.G1)
+	if err := pk.SetBytes(pubKey); err != nil {
+		return nil, err
+	}
+	if pk.IsIdentity() {
+		return nil, errors.New("identity PubKeyBLS12381")
+	}
+	return pk, nil
+}
+
+// augment prefixes the message with the public key of its signer.
+func augment(pubKey PubKey, msg []byte) []byte {
+	augmented := make([]byte, 0, len(pubKey)+len(msg))
+	augmented = append(augmented, pubKey...)
+	return append(augmented, msg...)
+}
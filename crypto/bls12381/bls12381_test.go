@@ -0,0 +1,114 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+)
+
+func TestSignAndValidateBLS12381(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, privKey.Bytes(), bls12381.PrivKeySize)
+	require.Len(t, pubKey.Bytes(), bls12381.PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, bls12381.SignatureSize)
+
+	// Test the signature
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+	assert.False(t, pubKey.VerifySignature(crypto.CRandBytes(128), sig))
+	assert.False(t, bls12381.GenPrivKey().PubKey().VerifySignature(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[7] ^= byte(0x01)
+	assert.False(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := bls12381.GenPrivKeyFromSecret([]byte("secret"))
+	assert.Equal(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("secret")))
+	assert.NotEqual(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("another secret")))
+}
+
+func TestVRFProveAndVRFVerify(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	message := []byte("seed")
+	proof, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	require.Len(t, proof, bls12381.ProofSize)
+
+	output, err := pubKey.VRFVerify(proof, message)
+	require.NoError(t, err)
+	assert.Len(t, output, 64)
+
+	// the proof is unique
+	proof2, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	assert.Equal(t, proof, proof2)
+
+	_, err = pubKey.VRFVerify(proof, []byte("another seed"))
+	assert.Error(t, err)
+
+	// a VRF proof is not a signature and vice versa
+	assert.False(t, pubKey.VerifySignature(message, proof))
+	sig, err := privKey.Sign(message)
+	require.NoError(t, err)
+	_, err = pubKey.VRFVerify(sig, message)
+	assert.Error(t, err)
+}
+
+func TestAggregatedSignature(t *testing.T) {
+	const n = 10
+	pubKeys := make([]bls12381.PubKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey := bls12381.GenPrivKey()
+		pubKeys[i] = privKey.PubKey().(bls12381.PubKey)
+		msgs[i] = crypto.CRandBytes(32)
+		if i%2 == 0 {
+			// equal messages are fine
+			msgs[i] = []byte("message")
+		}
+		sig, err := privKey.Sign(msgs[i])
+		require.NoError(t, err)
+		sigs[i] = sig
+	}
+
+	agg, err := bls12381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	require.Len(t, agg, bls12381.SignatureSize)
+	assert.True(t, bls12381.VerifyAggregatedSignature(pubKeys, msgs, agg))
+
+	// a missing signer
+	assert.False(t, bls12381.VerifyAggregatedSignature(pubKeys[1:], msgs[1:], agg))
+	// swapped messages
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	assert.False(t, bls12381.VerifyAggregatedSignature(pubKeys, msgs, agg))
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	// mismatched lengths
+	assert.False(t, bls12381.VerifyAggregatedSignature(pubKeys, msgs[1:], agg))
+
+	_, err = bls12381.AggregateSignatures(nil)
+	assert.Error(t, err)
+	_, err = bls12381.AggregateSignatures([][]byte{sigs[0], sigs[1][1:]})
+	assert.Error(t, err)
+}
+
+func TestIdentityPubKey(t *testing.T) {
+	// the compressed point at infinity
+	identity := make(bls12381.PubKey, bls12381.PubKeySize)
+	identity[0] = 0xc0
+	sig := make([]byte, bls12381.SignatureSize)
+	sig[0] = 0xc0
+	assert.False(t, identity.VerifySignature([]byte("message"), sig))
+}
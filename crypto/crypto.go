@@ -42,6 +42,17 @@ type PrivKey interface {
 	Type() string
 }
 
+// BatchVerifier verifies a batch of signatures at once, which is cheaper than verifying them
+// one by one.
+type BatchVerifier interface {
+	// Add adds a signature to the batch. It returns an error if the key isn't of the key type of
+	// the verifier or the signature is malformed.
+	Add(key PubKey, message, signature []byte) error
+	// Verify returns true if all the signatures of the batch are valid. It doesn't tell which
+	// signature is invalid.
+	Verify() bool
+}
+
 type Symmetric interface {
 	Keygen() []byte
 	Encrypt(plaintext []byte, secret []byte) (ciphertext []byte)
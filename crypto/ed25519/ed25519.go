@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"crypto/subtle"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 
+	"filippo.io/edwards25519"
+	"github.com/hdevalence/ed25519consensus"
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/Finschia/ostracon/crypto"
@@ -197,3 +200,94 @@ func (pubKey PubKey) Equals(other crypto.PubKey) bool {
 
 	return false
 }
+
+//-------------------------------------
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for ed25519.
+//
+// The batch is verified with the rules of ZIP 215, which accept some signatures that
+// VerifySignature rejects. Add refuses the signatures where the rules may differ, i.e. those
+// with non-canonical encodings or whose key or R has a torsion component, so that the caller
+// verifies them with VerifySignature instead. The batch then accepts exactly the signatures
+// VerifySignature accepts.
+type BatchVerifier struct {
+	ed25519consensus.BatchVerifier
+}
+
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{ed25519consensus.NewBatchVerifier()}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pkEd, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not Ed25519")
+	}
+	if l := len(pkEd); l != PubKeySize {
+		return fmt.Errorf("pubkey size is incorrect; expected: %d, got %d", PubKeySize, l)
+	}
+	// check that the signature is the correct length
+	if l := len(signature); l != SignatureSize {
+		return fmt.Errorf("signature size is incorrect; expected: %d, got %d", SignatureSize, l)
+	}
+	// refuse what VerifySignature may reject while ZIP 215 accepts it
+	a, err := decodeCanonicalPoint(pkEd)
+	if err != nil {
+		return fmt.Errorf("pubkey is invalid: %w", err)
+	}
+	if new(edwards25519.Point).MultByCofactor(a).Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return errors.New("pubkey is of small order")
+	}
+	if !isTorsionFree(a) {
+		return errors.New("pubkey has a torsion component")
+	}
+	r, err := decodeCanonicalPoint(signature[:32])
+	if err != nil {
+		return fmt.Errorf("signature is invalid: %w", err)
+	}
+	if !isTorsionFree(r) {
+		return errors.New("signature R has a torsion component")
+	}
+
+	b.BatchVerifier.Add(ed25519.PublicKey(pkEd), msg, signature)
+	return nil
+}
+
+func (b *BatchVerifier) Verify() bool {
+	return b.BatchVerifier.Verify()
+}
+
+// scalarMinusOne is L-1, L being the order of the prime-order subgroup.
+var scalarMinusOne = edwards25519.NewScalar().Subtract(edwards25519.NewScalar(), scalarOne())
+
+func scalarOne() *edwards25519.Scalar {
+	one := make([]byte, 32)
+	one[0] = 1
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(one)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// isTorsionFree returns whether p is in the prime-order subgroup, i.e. [L]p is the identity,
+// where the cofactored equation of ZIP 215 and the cofactorless one of VerifySignature agree.
+func isTorsionFree(p *edwards25519.Point) bool {
+	// [L]p = [L-1]p + p, since the scalar L itself can't be represented
+	lp := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(scalarMinusOne, p, edwards25519.NewScalar())
+	return lp.Add(lp, p).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// decodeCanonicalPoint decodes a point, which must be canonically encoded.
+func decodeCanonicalPoint(bz []byte) (*edwards25519.Point, error) {
+	p, err := new(edwards25519.Point).SetBytes(bz)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(p.Bytes(), bz) {
+		return nil, errors.New("non-canonical point encoding")
+	}
+	return p, nil
+}
@@ -1,16 +1,22 @@
 package ed25519_test
 
 import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"crypto/sha512"
 	"encoding/hex"
 	"testing"
 
+	"filippo.io/edwards25519"
 	coniks "github.com/coniks-sys/coniks-go/crypto/vrf"
 
+	"github.com/hdevalence/ed25519consensus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/secp256k1"
 )
 
 func TestSignAndValidateEd25519(t *testing.T) {
@@ -69,3 +75,116 @@ func TestVRFProveAndVRFVerify(t *testing.T) {
 		assert.Nil(t, output)
 	}
 }
+
+func TestBatchSafe(t *testing.T) {
+	v := ed25519.NewBatchVerifier()
+
+	for i := 0; i <= 38; i++ {
+		priv := ed25519.GenPrivKey()
+		pub := priv.PubKey()
+
+		var msg []byte
+		if i%2 == 0 {
+			msg = []byte("easter")
+		} else {
+			msg = []byte("egg")
+		}
+
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+
+		err = v.Add(pub, msg, sig)
+		require.NoError(t, err)
+	}
+
+	require.True(t, v.Verify())
+}
+
+func TestBatchInvalidSignature(t *testing.T) {
+	v := ed25519.NewBatchVerifier()
+	require.False(t, v.Verify(), "an empty batch is rejected")
+
+	for i := 0; i < 10; i++ {
+		priv := ed25519.GenPrivKey()
+		msg := crypto.CRandBytes(32)
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		if i == 7 {
+			msg = []byte("another message")
+		}
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+	}
+	assert.False(t, v.Verify())
+
+	priv := ed25519.GenPrivKey()
+	sig, err := priv.Sign([]byte("message"))
+	require.NoError(t, err)
+	assert.Error(t, v.Add(priv.PubKey(), []byte("message"), sig[1:]))
+	assert.Error(t, v.Add(secp256k1.GenPrivKey().PubKey(), []byte("message"), sig))
+}
+
+func TestBatchRefusesNonStrictSignatures(t *testing.T) {
+	// the identity point as the key, and non-canonically encoded as R, with a zero S: the
+	// signature is valid under ZIP 215 but not for VerifySignature
+	identity := make([]byte, ed25519.PubKeySize)
+	identity[0] = 1
+	nonCanonicalR := bytes.Repeat([]byte{0xff}, 32)
+	nonCanonicalR[0], nonCanonicalR[31] = 0xee, 0x7f
+	sig := append(nonCanonicalR, make([]byte, 32)...)
+	msg := []byte("message")
+
+	require.True(t, ed25519consensus.Verify(identity, msg, sig))
+	assert.False(t, ed25519.PubKey(identity).VerifySignature(msg, sig))
+	assert.Error(t, ed25519.NewBatchVerifier().Add(ed25519.PubKey(identity), msg, sig))
+
+	// a canonical R doesn't make a key of small order acceptable
+	copy(sig, identity)
+	assert.Error(t, ed25519.NewBatchVerifier().Add(ed25519.PubKey(identity), msg, sig))
+}
+
+// signWithTorsion signs msg with a nonce point R having an order 2 component, which the
+// cofactored equation of ZIP 215 accepts but the cofactorless one doesn't.
+func signWithTorsion(t *testing.T, privKey ed25519.PrivKey, msg []byte) []byte {
+	h := sha512.Sum512(privKey[:32])
+	a, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	require.NoError(t, err)
+	r, err := edwards25519.NewScalar().SetUniformBytes(crypto.CRandBytes(64))
+	require.NoError(t, err)
+	// (0, -1), the point of order 2
+	torsion, err := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	require.NoError(t, err)
+	t2, err := new(edwards25519.Point).SetBytes(torsion)
+	require.NoError(t, err)
+	R := new(edwards25519.Point).ScalarBaseMult(r)
+	R.Add(R, t2)
+
+	kh := sha512.New()
+	kh.Write(R.Bytes())
+	kh.Write(privKey[32:])
+	kh.Write(msg)
+	k, err := edwards25519.NewScalar().SetUniformBytes(kh.Sum(nil))
+	require.NoError(t, err)
+	S := edwards25519.NewScalar().MultiplyAdd(k, a, r)
+	return append(R.Bytes(), S.Bytes()...)
+}
+
+func TestBatchRefusesTorsionSignatures(t *testing.T) {
+	priv := ed25519.GenPrivKey()
+	pub := priv.PubKey().(ed25519.PubKey)
+	msg := []byte("message")
+	sig := signWithTorsion(t, priv, msg)
+
+	require.True(t, ed25519consensus.Verify(stded25519.PublicKey(pub), msg, sig), "valid under ZIP 215")
+	assert.False(t, pub.VerifySignature(msg, sig))
+
+	v := ed25519.NewBatchVerifier()
+	assert.Error(t, v.Add(pub, msg, sig))
+	// the batch agrees with VerifySignature on the other signatures
+	for i := 0; i < 3; i++ {
+		priv := ed25519.GenPrivKey()
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+	}
+	assert.True(t, v.Verify())
+}
@@ -6,9 +6,12 @@ import (
 	pc "github.com/tendermint/tendermint/proto/tendermint/crypto"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/secp256k1"
+	"github.com/Finschia/ostracon/crypto/sr25519"
 	"github.com/Finschia/ostracon/libs/json"
+	occrypto "github.com/Finschia/ostracon/proto/ostracon/crypto"
 )
 
 func init() {
@@ -39,6 +42,41 @@ func PubKeyToProto(k crypto.PubKey) (pc.PublicKey, error) {
 	return kp, nil
 }
 
+// PubKeyToOCProto takes crypto.PubKey and transforms it to an Ostracon protobuf Pubkey, which
+// also carries the key types that the Tendermint one doesn't, such as BLS12-381 and sr25519.
+func PubKeyToOCProto(k crypto.PubKey) (occrypto.PublicKey, error) {
+	var kp occrypto.PublicKey
+	switch k := k.(type) {
+	case ed25519.PubKey:
+		kp = occrypto.PublicKey{
+			Sum: &occrypto.PublicKey_Ed25519{
+				Ed25519: k,
+			},
+		}
+	case secp256k1.PubKey:
+		kp = occrypto.PublicKey{
+			Sum: &occrypto.PublicKey_Secp256K1{
+				Secp256K1: k,
+			},
+		}
+	case bls12381.PubKey:
+		kp = occrypto.PublicKey{
+			Sum: &occrypto.PublicKey_Bls12381{
+				Bls12381: k,
+			},
+		}
+	case sr25519.PubKey:
+		kp = occrypto.PublicKey{
+			Sum: &occrypto.PublicKey_Sr25519{
+				Sr25519: k,
+			},
+		}
+	default:
+		return kp, fmt.Errorf("toproto: key type %v is not supported", k)
+	}
+	return kp, nil
+}
+
 // PubKeyFromProto takes a protobuf Pubkey and transforms it to a crypto.Pubkey
 func PubKeyFromProto(k *pc.PublicKey) (crypto.PubKey, error) {
 	switch k := k.Sum.(type) {
@@ -62,3 +100,31 @@ func PubKeyFromProto(k *pc.PublicKey) (crypto.PubKey, error) {
 		return nil, fmt.Errorf("fromproto: key type %v is not supported", k)
 	}
 }
+
+// PubKeyFromOCProto takes an Ostracon protobuf Pubkey and transforms it to a crypto.Pubkey
+func PubKeyFromOCProto(k *occrypto.PublicKey) (crypto.PubKey, error) {
+	switch k := k.Sum.(type) {
+	case *occrypto.PublicKey_Ed25519:
+		return PubKeyFromProto(&pc.PublicKey{Sum: &pc.PublicKey_Ed25519{Ed25519: k.Ed25519}})
+	case *occrypto.PublicKey_Secp256K1:
+		return PubKeyFromProto(&pc.PublicKey{Sum: &pc.PublicKey_Secp256K1{Secp256K1: k.Secp256K1}})
+	case *occrypto.PublicKey_Bls12381:
+		if len(k.Bls12381) != bls12381.PubKeySize {
+			return nil, fmt.Errorf("invalid size for PubKeyBLS12381. Got %d, expected %d",
+				len(k.Bls12381), bls12381.PubKeySize)
+		}
+		pk := make(bls12381.PubKey, bls12381.PubKeySize)
+		copy(pk, k.Bls12381)
+		return pk, nil
+	case *occrypto.PublicKey_Sr25519:
+		if len(k.Sr25519) != sr25519.PubKeySize {
+			return nil, fmt.Errorf("invalid size for PubKeySr25519. Got %d, expected %d",
+				len(k.Sr25519), sr25519.PubKeySize)
+		}
+		pk := make(sr25519.PubKey, sr25519.PubKeySize)
+		copy(pk, k.Sr25519)
+		return pk, nil
+	default:
+		return nil, fmt.Errorf("fromproto: key type %v is not supported", k)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/secp256k1"
+	"github.com/Finschia/ostracon/crypto/sr25519"
 )
 
 func testPubKeyFromToProto(t *testing.T, sk crypto.PrivKey) {
@@ -29,3 +34,24 @@ func testPubKeyFromToProto(t *testing.T, sk crypto.PrivKey) {
 func TestPubKeyFromToProto(t *testing.T) {
 	testPubKeyFromToProto(t, ed25519.GenPrivKey())
 }
+
+func testPubKeyFromToOCProto(t *testing.T, sk crypto.PrivKey) {
+	pk := sk.PubKey()
+	pbPubKey, err := PubKeyToOCProto(pk)
+	require.NoError(t, err)
+	pk2, err := PubKeyFromOCProto(&pbPubKey)
+	require.NoError(t, err)
+	require.IsType(t, pk, pk2)
+	require.True(t, pk2.Equals(pk))
+}
+
+func TestPubKeyFromToOCProto(t *testing.T) {
+	testPubKeyFromToOCProto(t, ed25519.GenPrivKey())
+	testPubKeyFromToOCProto(t, secp256k1.GenPrivKey())
+	testPubKeyFromToOCProto(t, bls12381.GenPrivKey())
+	testPubKeyFromToOCProto(t, sr25519.GenPrivKey())
+
+	// the Tendermint PublicKey can't carry BLS12-381 keys
+	_, err := PubKeyToProto(bls12381.GenPrivKey().PubKey())
+	require.Error(t, err)
+}
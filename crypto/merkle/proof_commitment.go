@@ -0,0 +1,121 @@
+package merkle
+
+import (
+	"fmt"
+
+	ics23 "github.com/confio/ics23/go"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+const (
+	// ProofOpIAVLCommitment is the type of ICS23 proofs of IAVL trees, e.g. the stores of Cosmos SDK
+	// applications.
+	ProofOpIAVLCommitment = "ics23:iavl"
+	// ProofOpSimpleMerkleCommitment is the type of ICS23 proofs of simple Merkle trees, e.g. the
+	// multistore root of Cosmos SDK applications.
+	ProofOpSimpleMerkleCommitment = "ics23:simple"
+)
+
+// CommitmentOp verifies an ICS23 existence or non-existence proof of a key and produces the root
+// hash of the tree. The ProofSpec is given by the op type, and the proof is encoded in ProofOp.Data
+// as an ics23.CommitmentProof.
+//
+// Run with a single value as argument, it verifies that the key maps to the value. Run without
+// arguments, it verifies that the key is absent.
+type CommitmentOp struct {
+	Type  string
+	Spec  *ics23.ProofSpec
+	Key   []byte
+	Proof *ics23.CommitmentProof
+}
+
+var _ ProofOperator = CommitmentOp{}
+
+// NewIAVLCommitmentOp returns a CommitmentOp for a proof of an IAVL tree.
+func NewIAVLCommitmentOp(key []byte, proof *ics23.CommitmentProof) CommitmentOp {
+	return CommitmentOp{
+		Type:  ProofOpIAVLCommitment,
+		Spec:  ics23.IavlSpec,
+		Key:   key,
+		Proof: proof,
+	}
+}
+
+// NewSimpleMerkleCommitmentOp returns a CommitmentOp for a proof of a simple Merkle tree.
+func NewSimpleMerkleCommitmentOp(key []byte, proof *ics23.CommitmentProof) CommitmentOp {
+	return CommitmentOp{
+		Type:  ProofOpSimpleMerkleCommitment,
+		Spec:  ics23.TendermintSpec,
+		Key:   key,
+		Proof: proof,
+	}
+}
+
+// CommitmentOpDecoder decodes a ProofOp of one of the ICS23 types into a CommitmentOp.
+func CommitmentOpDecoder(pop tmcrypto.ProofOp) (ProofOperator, error) {
+	var spec *ics23.ProofSpec
+	switch pop.Type {
+	case ProofOpIAVLCommitment:
+		spec = ics23.IavlSpec
+	case ProofOpSimpleMerkleCommitment:
+		spec = ics23.TendermintSpec
+	default:
+		return nil, fmt.Errorf("unexpected ProofOp.Type; got %v, want %v or %v", pop.Type,
+			ProofOpIAVLCommitment, ProofOpSimpleMerkleCommitment)
+	}
+
+	proof := &ics23.CommitmentProof{}
+	if err := proof.Unmarshal(pop.Data); err != nil {
+		return nil, fmt.Errorf("decoding ProofOp.Data into CommitmentProof: %w", err)
+	}
+	return CommitmentOp{
+		Type:  pop.Type,
+		Spec:  spec,
+		Key:   pop.Key,
+		Proof: proof,
+	}, nil
+}
+
+func (op CommitmentOp) GetKey() []byte {
+	return op.Key
+}
+
+// Run computes the root hash of the proof, and verifies the existence of the single argument at
+// the key, or the absence of the key if there are no arguments.
+func (op CommitmentOp) Run(args [][]byte) ([][]byte, error) {
+	root, err := op.Proof.Calculate()
+	if err != nil {
+		return nil, fmt.Errorf("root calculation: %w", err)
+	}
+
+	switch len(args) {
+	case 0:
+		if !ics23.VerifyNonMembership(op.Spec, root, op.Proof, op.Key) {
+			return nil, fmt.Errorf("proof did not verify absence of key %X", op.Key)
+		}
+	case 1:
+		if !ics23.VerifyMembership(op.Spec, root, op.Proof, op.Key, args[0]) {
+			return nil, fmt.Errorf("proof did not verify existence of key %X with given value %X",
+				op.Key, args[0])
+		}
+	default:
+		return nil, fmt.Errorf("expected 0 or 1 args, got %v", len(args))
+	}
+	return [][]byte{root}, nil
+}
+
+func (op CommitmentOp) ProofOp() tmcrypto.ProofOp {
+	bz, err := op.Proof.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return tmcrypto.ProofOp{
+		Type: op.Type,
+		Key:  op.Key,
+		Data: bz,
+	}
+}
+
+func (op CommitmentOp) String() string {
+	return fmt.Sprintf("CommitmentOp{%v %X}", op.Type, op.Key)
+}
@@ -0,0 +1,130 @@
+package merkle
+
+import (
+	"testing"
+
+	ics23 "github.com/confio/ics23/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// simpleTreeProofs returns the existence proofs of the two leaves of a simple Merkle tree, and its
+// root hash.
+func simpleTreeProofs(t *testing.T, keys, values [2][]byte) (left, right *ics23.ExistenceProof, root []byte) {
+	leaf := ics23.TendermintSpec.LeafSpec
+	leftHash, err := leaf.Apply(keys[0], values[0])
+	require.NoError(t, err)
+	rightHash, err := leaf.Apply(keys[1], values[1])
+	require.NoError(t, err)
+
+	left = &ics23.ExistenceProof{Key: keys[0], Value: values[0], Leaf: leaf, Path: []*ics23.InnerOp{
+		{Hash: ics23.HashOp_SHA256, Prefix: []byte{1}, Suffix: rightHash},
+	}}
+	right = &ics23.ExistenceProof{Key: keys[1], Value: values[1], Leaf: leaf, Path: []*ics23.InnerOp{
+		{Hash: ics23.HashOp_SHA256, Prefix: append([]byte{1}, leftHash...)},
+	}}
+	root, err = left.Calculate()
+	require.NoError(t, err)
+	return left, right, root
+}
+
+func existenceCommitmentProof(p *ics23.ExistenceProof) *ics23.CommitmentProof {
+	return &ics23.CommitmentProof{Proof: &ics23.CommitmentProof_Exist{Exist: p}}
+}
+
+func TestCommitmentOp(t *testing.T) {
+	left, right, root := simpleTreeProofs(t, [2][]byte{[]byte("a"), []byte("c")},
+		[2][]byte{[]byte("A"), []byte("C")})
+
+	// Existence.
+	op := NewSimpleMerkleCommitmentOp([]byte("c"), existenceCommitmentProof(right))
+	out, err := op.Run([][]byte{[]byte("C")})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{root}, out)
+	_, err = op.Run([][]byte{[]byte("X")})
+	assert.Error(t, err)
+	_, err = op.Run(nil)
+	assert.Error(t, err)
+	_, err = op.Run([][]byte{[]byte("C"), []byte("C")})
+	assert.Error(t, err)
+
+	// Absence.
+	op = NewSimpleMerkleCommitmentOp([]byte("b"), &ics23.CommitmentProof{
+		Proof: &ics23.CommitmentProof_Nonexist{Nonexist: &ics23.NonExistenceProof{
+			Key: []byte("b"), Left: left, Right: right,
+		}},
+	})
+	out, err = op.Run(nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{root}, out)
+	_, err = op.Run([][]byte{[]byte("B")})
+	assert.Error(t, err)
+
+	// The IAVL spec rejects simple Merkle proofs.
+	op = NewIAVLCommitmentOp([]byte("a"), existenceCommitmentProof(left))
+	_, err = op.Run([][]byte{[]byte("A")})
+	assert.Error(t, err)
+}
+
+func TestCommitmentOpDecoder(t *testing.T) {
+	_, right, _ := simpleTreeProofs(t, [2][]byte{[]byte("a"), []byte("c")},
+		[2][]byte{[]byte("A"), []byte("C")})
+	op := NewSimpleMerkleCommitmentOp([]byte("c"), existenceCommitmentProof(right))
+
+	decoded, err := CommitmentOpDecoder(op.ProofOp())
+	require.NoError(t, err)
+	assert.Equal(t, op.ProofOp(), decoded.ProofOp())
+	assert.Equal(t, ics23.TendermintSpec, decoded.(CommitmentOp).Spec)
+
+	pop := op.ProofOp()
+	pop.Type = ProofOpIAVLCommitment
+	decoded, err = CommitmentOpDecoder(pop)
+	require.NoError(t, err)
+	assert.Equal(t, ics23.IavlSpec, decoded.(CommitmentOp).Spec)
+
+	pop.Type = ProofOpValue
+	_, err = CommitmentOpDecoder(pop)
+	assert.Error(t, err)
+
+	pop = op.ProofOp()
+	pop.Data = []byte("invalid")
+	_, err = CommitmentOpDecoder(pop)
+	assert.Error(t, err)
+}
+
+// TestCommitmentOp_ProofRuntime verifies a proof chained like the ones returned by Cosmos SDK
+// applications: an IAVL proof of the key in its store, and a simple Merkle proof of the store in
+// the multistore.
+func TestCommitmentOp_ProofRuntime(t *testing.T) {
+	// A single leaf IAVL tree, with height 0, size 1 and version 1.
+	iavlLeaf := &ics23.LeafOp{
+		Hash:         ics23.HashOp_SHA256,
+		PrehashValue: ics23.HashOp_SHA256,
+		Length:       ics23.LengthOp_VAR_PROTO,
+		Prefix:       []byte{0, 2, 2},
+	}
+	iavlProof := &ics23.ExistenceProof{Key: []byte("key"), Value: []byte("value"), Leaf: iavlLeaf}
+	storeRoot, err := iavlProof.Calculate()
+	require.NoError(t, err)
+
+	_, storeProof, appHash := simpleTreeProofs(t, [2][]byte{[]byte("acc"), []byte("bank")},
+		[2][]byte{[]byte("root"), storeRoot})
+
+	proofOps := &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{
+		NewIAVLCommitmentOp([]byte("key"), existenceCommitmentProof(iavlProof)).ProofOp(),
+		NewSimpleMerkleCommitmentOp([]byte("bank"), existenceCommitmentProof(storeProof)).ProofOp(),
+	}}
+	prt := DefaultProofRuntime()
+	prt.RegisterOpDecoder(ProofOpIAVLCommitment, CommitmentOpDecoder)
+	prt.RegisterOpDecoder(ProofOpSimpleMerkleCommitment, CommitmentOpDecoder)
+
+	keyPath := KeyPath{}.AppendKey([]byte("bank"), KeyEncodingURL).AppendKey([]byte("key"), KeyEncodingURL)
+	require.NoError(t, prt.VerifyValue(proofOps, appHash, keyPath.String(), []byte("value")))
+	assert.Error(t, prt.VerifyValue(proofOps, appHash, keyPath.String(), []byte("other")))
+	assert.Error(t, prt.VerifyValue(proofOps, storeRoot, keyPath.String(), []byte("value")))
+
+	otherPath := KeyPath{}.AppendKey([]byte("acc"), KeyEncodingURL).AppendKey([]byte("key"), KeyEncodingURL)
+	assert.Error(t, prt.VerifyValue(proofOps, appHash, otherPath.String(), []byte("value")))
+}
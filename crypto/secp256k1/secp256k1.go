@@ -39,9 +39,9 @@ func (privKey PrivKey) Bytes() []byte {
 	return []byte(privKey)
 }
 
-// VRFProve is not supported in Secp256k1.
-func (privKey PrivKey) VRFProve(seed []byte) (crypto.Proof, error) {
-	return nil, fmt.Errorf("VRF prove is not supported by the secp256k1")
+// VRFProve generates a VRF Proof for given message to generate a verifiable random.
+func (privKey PrivKey) VRFProve(message []byte) (crypto.Proof, error) {
+	return vrfProve(privKey, message)
 }
 
 // PubKey performs the point-scalar multiplication from the privKey on the
@@ -186,9 +186,9 @@ func (pubKey PubKey) String() string {
 	return fmt.Sprintf("PubKeySecp256k1{%X}", []byte(pubKey))
 }
 
-// VRFVerify is not supported in Secp256k1.
-func (pubKey PubKey) VRFVerify(proof crypto.Proof, seed []byte) (crypto.Output, error) {
-	return nil, fmt.Errorf("VRF verify is not supported by the secp256k1")
+// VRFVerify verifies that the given VRF Proof was generated from the message by the owner of this public key.
+func (pubKey PubKey) VRFVerify(proof crypto.Proof, message []byte) (crypto.Output, error) {
+	return vrfVerify(pubKey, proof, message)
 }
 
 func (pubKey PubKey) Equals(other crypto.PubKey) bool {
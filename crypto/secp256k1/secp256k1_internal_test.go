@@ -2,6 +2,8 @@ package secp256k1
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"math/big"
 	"testing"
 
@@ -72,3 +74,10 @@ func TestSignatureVerificationAndRejectUpperS(t *testing.T) {
 		)
 	}
 }
+
+func TestNonceRFC6979(t *testing.T) {
+	// the test vector of python-ecdsa and bitcoin libraries for secp256k1 and SHA-256
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	nonce := nonceRFC6979(big.NewInt(1), hash[:])
+	require.Equal(t, "8f8a276c19f4149656b280621e358cce24f5f52542772691ee69063b74f15d15", hex.EncodeToString(nonce.Bytes()))
+}
@@ -114,3 +114,38 @@ func TestGenPrivKeySecp256k1(t *testing.T) {
 		})
 	}
 }
+
+func TestVRFProveAndVRFVerify(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	message := []byte("seed")
+	proof, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	require.Len(t, proof, secp256k1.ProofSize)
+
+	output, err := pubKey.VRFVerify(proof, message)
+	require.NoError(t, err)
+	require.Len(t, output, secp256k1.OutputSize)
+	hash, err := secp256k1.ProofToHash(proof)
+	require.NoError(t, err)
+	assert.Equal(t, output, hash)
+
+	// the proof is deterministic
+	proof2, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	assert.Equal(t, proof, proof2)
+
+	_, err = pubKey.VRFVerify(proof, []byte("another seed"))
+	assert.Error(t, err)
+	_, err = secp256k1.GenPrivKey().PubKey().VRFVerify(proof, message)
+	assert.Error(t, err)
+	for i := range proof {
+		mutated := append(crypto.Proof{}, proof...)
+		mutated[i] ^= 0x01
+		_, err = pubKey.VRFVerify(mutated, message)
+		assert.Error(t, err, "mutated byte %d", i)
+	}
+	_, err = pubKey.VRFVerify(proof[1:], message)
+	assert.Error(t, err)
+}
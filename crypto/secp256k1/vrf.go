@@ -0,0 +1,224 @@
+package secp256k1
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	secp256k1 "github.com/btcsuite/btcd/btcec"
+
+	"github.com/Finschia/ostracon/crypto"
+)
+
+// The VRF is ECVRF-SECP256K1-SHA256-TAI: the ECVRF of RFC 9381 on secp256k1 with SHA-256, the
+// try-and-increment hash to curve, and the deterministic nonces of RFC 6979.
+const (
+	// ProofSize is the size, in bytes, of a VRF proof: a compressed point, the 16-byte challenge
+	// and a scalar.
+	ProofSize = PubKeySize + vrfChallengeSize + vrfScalarSize
+	// OutputSize is the size, in bytes, of a VRF output.
+	OutputSize = sha256.Size
+
+	vrfSuite         = 0xfe
+	vrfChallengeSize = 16
+	vrfScalarSize    = 32
+)
+
+var errInvalidVRFProof = errors.New("invalid VRF proof")
+
+// vrfPoint is an affine point of secp256k1.
+type vrfPoint struct {
+	x, y *big.Int
+}
+
+func (p vrfPoint) bytes() []byte {
+	return (&secp256k1.PublicKey{Curve: secp256k1.S256(), X: p.x, Y: p.y}).SerializeCompressed()
+}
+
+func (p vrfPoint) mul(k *big.Int) vrfPoint {
+	x, y := secp256k1.S256().ScalarMult(p.x, p.y, k.Bytes())
+	return vrfPoint{x, y}
+}
+
+func (p vrfPoint) add(q vrfPoint) vrfPoint {
+	x, y := secp256k1.S256().Add(p.x, p.y, q.x, q.y)
+	return vrfPoint{x, y}
+}
+
+func vrfBaseMul(k *big.Int) vrfPoint {
+	x, y := secp256k1.S256().ScalarBaseMult(k.Bytes())
+	return vrfPoint{x, y}
+}
+
+func vrfPointFromBytes(bz []byte) (vrfPoint, error) {
+	if len(bz) != PubKeySize {
+		return vrfPoint{}, fmt.Errorf("invalid size for point. Got %d, expected %d", len(bz), PubKeySize)
+	}
+	pub, err := secp256k1.ParsePubKey(bz, secp256k1.S256())
+	if err != nil {
+		return vrfPoint{}, err
+	}
+	return vrfPoint{pub.X, pub.Y}, nil
+}
+
+// vrfProve generates the proof that the message was hashed to a point by the owner of the key.
+func vrfProve(privKey PrivKey, message []byte) (crypto.Proof, error) {
+	x := new(big.Int).SetBytes(privKey)
+	if len(privKey) != PrivKeySize || x.Sign() == 0 || x.Cmp(secp256k1.S256().N) >= 0 {
+		return nil, errors.New("invalid private key")
+	}
+	pubKey := vrfBaseMul(x).bytes()
+	h, err := vrfHashToCurve(pubKey, message)
+	if err != nil {
+		return nil, err
+	}
+	hBytes := h.bytes()
+	gamma := h.mul(x)
+
+	hash := sha256.Sum256(hBytes)
+	k := nonceRFC6979(x, hash[:])
+	c := vrfChallenge(pubKey, hBytes, gamma.bytes(), vrfBaseMul(k).bytes(), h.mul(k).bytes())
+
+	// s = k + c * x mod N
+	s := new(big.Int).Mul(c, x)
+	s.Add(s, k)
+	s.Mod(s, secp256k1.S256().N)
+
+	proof := make([]byte, 0, ProofSize)
+	proof = append(proof, gamma.bytes()...)
+	proof = append(proof, leftPad(c.Bytes(), vrfChallengeSize)...)
+	proof = append(proof, leftPad(s.Bytes(), vrfScalarSize)...)
+	return proof, nil
+}
+
+// vrfVerify checks the proof and returns the VRF output.
+func vrfVerify(pubKey PubKey, proof crypto.Proof, message []byte) (crypto.Output, error) {
+	y, err := vrfPointFromBytes(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	gamma, c, s, err := vrfDecodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	h, err := vrfHashToCurve(pubKey, message)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s * B - c * Y, V = s * H - c * Gamma
+	negC := new(big.Int).Sub(secp256k1.S256().N, c)
+	u := vrfBaseMul(s).add(y.mul(negC))
+	v := h.mul(s).add(gamma.mul(negC))
+	expected := vrfChallenge(pubKey, h.bytes(), gamma.bytes(), u.bytes(), v.bytes())
+	if expected.Cmp(c) != 0 {
+		return nil, errInvalidVRFProof
+	}
+	return vrfGammaToHash(gamma), nil
+}
+
+// ProofToHash returns the VRF output of a proof. It doesn't verify the proof.
+func ProofToHash(proof crypto.Proof) (crypto.Output, error) {
+	gamma, _, _, err := vrfDecodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	return vrfGammaToHash(gamma), nil
+}
+
+func vrfDecodeProof(proof crypto.Proof) (gamma vrfPoint, c, s *big.Int, err error) {
+	if len(proof) != ProofSize {
+		return vrfPoint{}, nil, nil, fmt.Errorf("invalid size for VRF proof. Got %d, expected %d",
+			len(proof), ProofSize)
+	}
+	gamma, err = vrfPointFromBytes(proof[:PubKeySize])
+	if err != nil {
+		return vrfPoint{}, nil, nil, errInvalidVRFProof
+	}
+	c = new(big.Int).SetBytes(proof[PubKeySize : PubKeySize+vrfChallengeSize])
+	s = new(big.Int).SetBytes(proof[PubKeySize+vrfChallengeSize:])
+	if s.Cmp(secp256k1.S256().N) >= 0 {
+		return vrfPoint{}, nil, nil, errInvalidVRFProof
+	}
+	return gamma, c, s, nil
+}
+
+func vrfGammaToHash(gamma vrfPoint) crypto.Output {
+	hasher := sha256.New()
+	hasher.Write([]byte{vrfSuite, 0x03}) // nolint: errcheck
+	hasher.Write(gamma.bytes())          // nolint: errcheck
+	hasher.Write([]byte{0x00})           // nolint: errcheck
+	return hasher.Sum(nil)
+}
+
+// vrfHashToCurve hashes the message to a point with the try-and-increment method.
+func vrfHashToCurve(pubKey, message []byte) (vrfPoint, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		hasher := sha256.New()
+		hasher.Write([]byte{vrfSuite, 0x01})  // nolint: errcheck
+		hasher.Write(pubKey)                  // nolint: errcheck
+		hasher.Write(message)                 // nolint: errcheck
+		hasher.Write([]byte{byte(ctr), 0x00}) // nolint: errcheck
+		if h, err := vrfPointFromBytes(hasher.Sum([]byte{0x02})); err == nil {
+			return h, nil
+		}
+	}
+	return vrfPoint{}, errors.New("failed to hash the message to a point")
+}
+
+func vrfChallenge(points ...[]byte) *big.Int {
+	hasher := sha256.New()
+	hasher.Write([]byte{vrfSuite, 0x02}) // nolint: errcheck
+	for _, p := range points {
+		hasher.Write(p) // nolint: errcheck
+	}
+	hasher.Write([]byte{0x00}) // nolint: errcheck
+	return new(big.Int).SetBytes(hasher.Sum(nil)[:vrfChallengeSize])
+}
+
+// nonceRFC6979 generates the nonce of RFC 6979, section 3.2, with SHA-256.
+func nonceRFC6979(x *big.Int, hash []byte) *big.Int {
+	q := secp256k1.S256().N
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, d := range data {
+			m.Write(d) // nolint: errcheck
+		}
+		return m.Sum(nil)
+	}
+
+	// bits2octets(h1) is h1 mod q, since q is as long as the hash
+	h := new(big.Int).SetBytes(hash)
+	if h.Cmp(q) >= 0 {
+		h.Sub(h, q)
+	}
+	bx := append(leftPad(x.Bytes(), vrfScalarSize), leftPad(h.Bytes(), vrfScalarSize)...)
+
+	v := bytes.Repeat([]byte{0x01}, sha256.Size)
+	k := make([]byte, sha256.Size)
+	k = mac(k, v, []byte{0x00}, bx)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, bx)
+	v = mac(k, v)
+	for {
+		v = mac(k, v)
+		nonce := new(big.Int).SetBytes(v)
+		if nonce.Sign() > 0 && nonce.Cmp(q) < 0 {
+			return nonce
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
+
+func leftPad(bz []byte, size int) []byte {
+	if len(bz) >= size {
+		return bz
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(bz):], bz)
+	return padded
+}
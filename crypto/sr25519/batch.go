@@ -0,0 +1,86 @@
+package sr25519
+
+import (
+	"fmt"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	r255 "github.com/gtank/ristretto255"
+
+	"github.com/Finschia/ostracon/crypto"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for sr25519.
+//
+// Each signature (R, s) of a message by the key A is valid if s * B == R + k * A, where k is the
+// challenge of the signing transcript. The batch checks the sum of these equations scaled by
+// random 128-bit scalars z:
+//
+//	sum(z * s) * B - sum(z * R) - sum(z * k * A) == 0
+//
+// Ristretto255 has a prime order, so the batch is valid if and only if every signature is.
+type BatchVerifier struct {
+	scalars []*r255.Scalar
+	points  []*r255.Element
+	sumZS   *r255.Scalar
+}
+
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{sumZS: r255.NewScalar()}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pk, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not Sr25519")
+	}
+	if l := len(pk); l != PubKeySize {
+		return fmt.Errorf("pubkey size is incorrect; expected: %d, got %d", PubKeySize, l)
+	}
+	if l := len(signature); l != SignatureSize {
+		return fmt.Errorf("signature size is incorrect; expected: %d, got %d", SignatureSize, l)
+	}
+
+	a := r255.NewElement()
+	if err := a.Decode(pk); err != nil {
+		return err
+	}
+	var sig64 [SignatureSize]byte
+	copy(sig64[:], signature)
+	sig := &(schnorrkel.Signature{})
+	if err := sig.Decode(sig64); err != nil {
+		return err
+	}
+
+	// the challenge of schnorrkel.PublicKey.Verify
+	t := schnorrkel.NewSigningContext([]byte{}, msg)
+	t.AppendMessage([]byte("proto-name"), []byte("Schnorr-sig"))
+	t.AppendMessage([]byte("sign:pk"), pk)
+	t.AppendMessage([]byte("sign:R"), sig.R.Encode([]byte{}))
+	k := r255.NewScalar().FromUniformBytes(t.ExtractBytes([]byte("sign:c"), 64))
+
+	var zBytes [32]byte
+	copy(zBytes[:16], crypto.CRandBytes(16))
+	z := r255.NewScalar()
+	if err := z.Decode(zBytes[:]); err != nil {
+		return err
+	}
+
+	b.sumZS.Add(b.sumZS, r255.NewScalar().Multiply(z, sig.S))
+	zk := r255.NewScalar().Multiply(z, k)
+	b.scalars = append(b.scalars, r255.NewScalar().Negate(z), zk.Negate(zk))
+	b.points = append(b.points, sig.R, a)
+	return nil
+}
+
+// Verify returns false on an empty batch, which probably indicates a bug.
+func (b *BatchVerifier) Verify() bool {
+	if len(b.points) == 0 {
+		return false
+	}
+	scalars := append([]*r255.Scalar{b.sumZS}, b.scalars...)
+	points := append([]*r255.Element{r255.NewElement().Base()}, b.points...)
+	sum := r255.NewElement().VarTimeMultiScalarMult(scalars, points)
+	return sum.Equal(r255.NewElement().Zero()) == 1
+}
@@ -42,11 +42,6 @@ func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
 	return sigBytes[:], nil
 }
 
-// VRFProve is not supported in Sr25519.
-func (privKey PrivKey) VRFProve(seed []byte) (crypto.Proof, error) {
-	return nil, fmt.Errorf("VRF prove is not supported by the sr25519")
-}
-
 // PubKey gets the corresponding public key from the private key.
 func (privKey PrivKey) PubKey() crypto.PubKey {
 	var p [PrivKeySize]byte
@@ -75,7 +70,7 @@ func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
 }
 
 func (privKey PrivKey) Type() string {
-	return keyType
+	return KeyType
 }
 
 // GenPrivKey generates a new sr25519 private key.
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/secp256k1"
 	"github.com/Finschia/ostracon/crypto/sr25519"
 )
 
@@ -29,3 +30,87 @@ func TestSignAndValidateSr25519(t *testing.T) {
 
 	assert.False(t, pubKey.VerifySignature(msg, sig))
 }
+
+func TestVRFProveAndVRFVerify(t *testing.T) {
+	privKey := sr25519.GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	message := []byte("seed")
+	proof, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	require.Len(t, proof, sr25519.ProofSize)
+
+	output, err := pubKey.VRFVerify(proof, message)
+	require.NoError(t, err)
+	require.Len(t, output, sr25519.OutputSize)
+	hash, err := sr25519.ProofToHash(proof)
+	require.NoError(t, err)
+	assert.Equal(t, output, hash)
+
+	// the output is unique even though the proof isn't
+	proof2, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	output2, err := pubKey.VRFVerify(proof2, message)
+	require.NoError(t, err)
+	assert.Equal(t, output, output2)
+
+	_, err = pubKey.VRFVerify(proof, []byte("another seed"))
+	assert.Error(t, err)
+	_, err = sr25519.GenPrivKey().PubKey().VRFVerify(proof, message)
+	assert.Error(t, err)
+	for i := range proof {
+		mutated := append(crypto.Proof{}, proof...)
+		mutated[i] ^= 0x01
+		_, err = pubKey.VRFVerify(mutated, message)
+		assert.Error(t, err, "mutated byte %d", i)
+	}
+	_, err = pubKey.VRFVerify(proof[1:], message)
+	assert.Error(t, err)
+}
+
+func TestBatchSafe(t *testing.T) {
+	v := sr25519.NewBatchVerifier()
+
+	for i := 0; i <= 38; i++ {
+		priv := sr25519.GenPrivKey()
+		pub := priv.PubKey()
+
+		var msg []byte
+		if i%2 == 0 {
+			msg = []byte("easter")
+		} else {
+			msg = []byte("egg")
+		}
+
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+
+		err = v.Add(pub, msg, sig)
+		require.NoError(t, err)
+	}
+
+	require.True(t, v.Verify())
+}
+
+func TestBatchInvalidSignature(t *testing.T) {
+	v := sr25519.NewBatchVerifier()
+	require.False(t, v.Verify(), "an empty batch is rejected")
+
+	for i := 0; i < 10; i++ {
+		priv := sr25519.GenPrivKey()
+		msg := crypto.CRandBytes(32)
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		if i == 7 {
+			msg = []byte("another message")
+		}
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+	}
+	assert.False(t, v.Verify())
+
+	priv := sr25519.GenPrivKey()
+	sig, err := priv.Sign([]byte("message"))
+	require.NoError(t, err)
+	assert.Error(t, v.Add(priv.PubKey(), []byte("message"), sig[1:]))
+	assert.Error(t, v.Add(secp256k1.GenPrivKey().PubKey(), []byte("message"), sig))
+}
@@ -0,0 +1,94 @@
+package sr25519
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+
+	"github.com/Finschia/ostracon/crypto"
+)
+
+const (
+	// ProofSize is the size, in bytes, of a VRF proof: the VRF output point followed by the
+	// schnorrkel DLEQ proof.
+	ProofSize = vrfOutputSize + vrfDLEQProofSize
+	// OutputSize is the size, in bytes, of a VRF output.
+	OutputSize = sha512.Size
+
+	vrfOutputSize    = 32
+	vrfDLEQProofSize = 64
+)
+
+// vrfContext separates the transcripts of VRF proofs from the ones of signatures.
+var vrfContext = []byte("ostracon-vrf")
+
+// VRFProve generates a VRF Proof for given message to generate a verifiable random.
+// The proof isn't deterministic, but its output is.
+func (privKey PrivKey) VRFProve(message []byte) (crypto.Proof, error) {
+	var p [PrivKeySize]byte
+	copy(p[:], privKey)
+	miniSecretKey, err := schnorrkel.NewMiniSecretKeyFromRaw(p)
+	if err != nil {
+		return nil, err
+	}
+	secretKey := miniSecretKey.ExpandEd25519()
+
+	inout, dleqProof, err := secretKey.VrfSign(schnorrkel.NewSigningContext(vrfContext, message))
+	if err != nil {
+		return nil, err
+	}
+	output := inout.Output().Encode()
+	proof := dleqProof.Encode()
+	return append(output[:], proof[:]...), nil
+}
+
+// VRFVerify verifies that the given VRF Proof was generated from the message by the owner of this public key.
+func (pubKey PubKey) VRFVerify(proof crypto.Proof, message []byte) (crypto.Output, error) {
+	if len(proof) != ProofSize {
+		return nil, fmt.Errorf("invalid size for VRF proof. Got %d, expected %d", len(proof), ProofSize)
+	}
+	if len(pubKey) != PubKeySize {
+		return nil, fmt.Errorf("invalid size for public key. Got %d, expected %d", len(pubKey), PubKeySize)
+	}
+	var p [PubKeySize]byte
+	copy(p[:], pubKey)
+	publicKey := &(schnorrkel.PublicKey{})
+	if err := publicKey.Decode(p); err != nil {
+		return nil, err
+	}
+
+	var outputBytes [vrfOutputSize]byte
+	copy(outputBytes[:], proof[:vrfOutputSize])
+	output := &(schnorrkel.VrfOutput{})
+	if err := output.Decode(outputBytes); err != nil {
+		return nil, err
+	}
+	var proofBytes [vrfDLEQProofSize]byte
+	copy(proofBytes[:], proof[vrfOutputSize:])
+	dleqProof := &(schnorrkel.VrfProof{})
+	if err := dleqProof.Decode(proofBytes); err != nil {
+		return nil, err
+	}
+
+	transcript := schnorrkel.NewSigningContext(vrfContext, message)
+	ok, err := publicKey.VrfVerify(transcript, output.AttachInput(publicKey, transcript), dleqProof)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("invalid VRF proof")
+	}
+	return ProofToHash(proof)
+}
+
+// ProofToHash returns the VRF output of a proof, the hash of its output point. It doesn't verify
+// the proof.
+func ProofToHash(proof crypto.Proof) (crypto.Output, error) {
+	if len(proof) != ProofSize {
+		return nil, fmt.Errorf("invalid size for VRF proof. Got %d, expected %d", len(proof), ProofSize)
+	}
+	hash := sha512.Sum512(proof[:vrfOutputSize])
+	return hash[:], nil
+}
@@ -0,0 +1,629 @@
+// Package threshold implements t-of-n threshold signing with ed25519 keys.
+//
+// The private key is split into shares with Shamir's secret sharing by a
+// trusted dealer. Any threshold of share holders (cosigners) can then produce
+// ed25519 signatures and ECVRF proofs for the key in two rounds, without the
+// key ever being reconstructed:
+//
+//  1. every cosigner commits to fresh nonces (Commit)
+//  2. given the commitments of all the participating cosigners, every cosigner
+//     computes its share of the signature or proof (SignShare, VRFShare)
+//
+// The shares are then combined into a regular signature or proof by
+// AggregateSignature and AggregateVRFProof. Nonces are bound to the session
+// like in FROST so commitments can't be used to forge signatures.
+//
+// The VRF proofs are compatible with the default (r2ishiguro) implementation
+// of crypto/vrf.
+package threshold
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"filippo.io/edwards25519"
+	r2ishiguro "github.com/r2ishiguro/vrf/go/vrf_ed25519"
+
+	"github.com/Finschia/ostracon/crypto/ed25519"
+)
+
+const (
+	// vrfChallengeSize is the size of the ECVRF challenge, in bytes
+	vrfChallengeSize = 16
+
+	domainBinding    = "ostracon-threshold-binding"
+	domainVRFBinding = "ostracon-threshold-vrf-binding"
+)
+
+var (
+	// ErrNotEnoughShares is returned when less than threshold cosigners take
+	// part in a session.
+	ErrNotEnoughShares = errors.New("not enough shares")
+)
+
+// ErrInvalidShare is returned when the share of a cosigner doesn't verify.
+type ErrInvalidShare struct {
+	ID int
+}
+
+func (e ErrInvalidShare) Error() string {
+	return fmt.Sprintf("invalid share from cosigner %d", e.ID)
+}
+
+//-------------------------------------------------------------------------------
+
+// Group is the public part of a key split into shares.
+type Group struct {
+	Threshold int            `json:"threshold"`
+	PubKey    ed25519.PubKey `json:"pub_key"`
+	// PubShares are the public keys of the shares, by share ID - 1.
+	PubShares [][]byte `json:"pub_shares"`
+}
+
+// ValidateBasic performs basic validation.
+func (g Group) ValidateBasic() error {
+	if len(g.PubKey) != ed25519.PubKeySize {
+		return fmt.Errorf("invalid pub key size %d", len(g.PubKey))
+	}
+	if g.Threshold < 1 || g.Threshold > len(g.PubShares) {
+		return fmt.Errorf("invalid threshold %d of %d shares", g.Threshold, len(g.PubShares))
+	}
+	for i, pub := range g.PubShares {
+		if _, err := new(edwards25519.Point).SetBytes(pub); err != nil {
+			return fmt.Errorf("invalid pub share %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// KeyShare is the share of an ed25519 private key held by a cosigner.
+type KeyShare struct {
+	Group `json:"group"`
+	// ID of the share, from 1 to the number of shares.
+	ID     int    `json:"id"`
+	Secret []byte `json:"secret"`
+}
+
+// ValidateBasic performs basic validation.
+func (ks KeyShare) ValidateBasic() error {
+	if err := ks.Group.ValidateBasic(); err != nil {
+		return err
+	}
+	if ks.ID < 1 || ks.ID > len(ks.PubShares) {
+		return fmt.Errorf("invalid share ID %d", ks.ID)
+	}
+	x, err := new(edwards25519.Scalar).SetCanonicalBytes(ks.Secret)
+	if err != nil {
+		return fmt.Errorf("invalid secret: %w", err)
+	}
+	if new(edwards25519.Point).ScalarBaseMult(x).Equal(mustPoint(ks.PubShares[ks.ID-1])) != 1 {
+		return errors.New("secret doesn't match the pub share")
+	}
+	return nil
+}
+
+// SplitKey splits privKey into total shares, any threshold of which can sign.
+func SplitKey(privKey ed25519.PrivKey, threshold, total int) ([]KeyShare, error) {
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key size %d", len(privKey))
+	}
+	if threshold < 1 || threshold > total {
+		return nil, fmt.Errorf("invalid threshold %d of %d shares", threshold, total)
+	}
+
+	// ed25519 signs with the clamped hash of the seed
+	digest := sha512.Sum512(privKey[:32])
+	x, err := new(edwards25519.Scalar).SetBytesWithClamping(digest[:32])
+	if err != nil {
+		return nil, err
+	}
+
+	// f(0) = x
+	coefficients := make([]*edwards25519.Scalar, threshold)
+	coefficients[0] = x
+	for i := 1; i < threshold; i++ {
+		coefficients[i] = randomScalar()
+	}
+
+	group := Group{
+		Threshold: threshold,
+		PubKey:    privKey.PubKey().(ed25519.PubKey),
+		PubShares: make([][]byte, total),
+	}
+	secrets := make([]*edwards25519.Scalar, total)
+	for i := range secrets {
+		id := scalarFromInt(i + 1)
+		// Horner's method
+		secret := edwards25519.NewScalar()
+		for j := threshold - 1; j >= 0; j-- {
+			secret.MultiplyAdd(secret, id, coefficients[j])
+		}
+		secrets[i] = secret
+		group.PubShares[i] = new(edwards25519.Point).ScalarBaseMult(secret).Bytes()
+	}
+
+	shares := make([]KeyShare, total)
+	for i, secret := range secrets {
+		shares[i] = KeyShare{Group: group, ID: i + 1, Secret: secret.Bytes()}
+	}
+	return shares, nil
+}
+
+//-------------------------------------------------------------------------------
+
+// Nonces are the secret nonces a cosigner committed to for a session. They
+// must be used for a single share.
+type Nonces struct {
+	hiding  *edwards25519.Scalar
+	binding *edwards25519.Scalar
+}
+
+// Commitment is the commitment of a cosigner to its nonces for a session.
+type Commitment struct {
+	ID      int    `json:"id"`
+	Hiding  []byte `json:"hiding"`
+	Binding []byte `json:"binding"`
+
+	// Set for VRF proofs only: the nonces and key share multiplied by the
+	// point the message hashes to.
+	VRFHiding  []byte `json:"vrf_hiding,omitempty"`
+	VRFBinding []byte `json:"vrf_binding,omitempty"`
+	VRFGamma   []byte `json:"vrf_gamma,omitempty"`
+}
+
+// Commit generates the nonces of the cosigner for a signature session.
+func (ks KeyShare) Commit() (*Nonces, Commitment) {
+	nonces := &Nonces{hiding: randomScalar(), binding: randomScalar()}
+	return nonces, Commitment{
+		ID:      ks.ID,
+		Hiding:  new(edwards25519.Point).ScalarBaseMult(nonces.hiding).Bytes(),
+		Binding: new(edwards25519.Point).ScalarBaseMult(nonces.binding).Bytes(),
+	}
+}
+
+// VRFCommit generates the nonces of the cosigner for a VRF proof session of
+// message.
+func (ks KeyShare) VRFCommit(message []byte) (*Nonces, Commitment, error) {
+	x, err := new(edwards25519.Scalar).SetCanonicalBytes(ks.Secret)
+	if err != nil {
+		return nil, Commitment{}, err
+	}
+	h, err := hashToCurve(ks.PubKey, message)
+	if err != nil {
+		return nil, Commitment{}, err
+	}
+
+	nonces, commitment := ks.Commit()
+	commitment.VRFHiding = new(edwards25519.Point).ScalarMult(nonces.hiding, h).Bytes()
+	commitment.VRFBinding = new(edwards25519.Point).ScalarMult(nonces.binding, h).Bytes()
+	commitment.VRFGamma = new(edwards25519.Point).ScalarMult(x, h).Bytes()
+	return nonces, commitment, nil
+}
+
+// SignShare returns the share of the cosigner of the signature of message.
+// commitments are the commitments of all the cosigners taking part in the
+// session.
+func (ks KeyShare) SignShare(message []byte, nonces *Nonces, commitments []Commitment) ([]byte, error) {
+	x, err := new(edwards25519.Scalar).SetCanonicalBytes(ks.Secret)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSession(ks.Group, domainBinding, message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.index(ks.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCommitment(i, nonces, nil); err != nil {
+		return nil, err
+	}
+	c := signatureChallenge(s.nonce, ks.PubKey, message)
+
+	// z = d + ρ * e + λ * c * x
+	z := edwards25519.NewScalar().MultiplyAdd(s.bindingFactors[i], nonces.binding, nonces.hiding)
+	lc := edwards25519.NewScalar().Multiply(s.lagrange[i], c)
+	z.MultiplyAdd(lc, x, z)
+	return z.Bytes(), nil
+}
+
+// VRFShare returns the share of the cosigner of the VRF proof of message.
+// commitments are the commitments of all the cosigners taking part in the
+// session.
+func (ks KeyShare) VRFShare(message []byte, nonces *Nonces, commitments []Commitment) ([]byte, error) {
+	x, err := new(edwards25519.Scalar).SetCanonicalBytes(ks.Secret)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newVRFSession(ks.Group, message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.index(ks.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCommitment(i, nonces, x); err != nil {
+		return nil, err
+	}
+	c, _ := s.vrfChallenge()
+
+	// z = d + ρ * e - λ * c * x
+	z := edwards25519.NewScalar().MultiplyAdd(s.bindingFactors[i], nonces.binding, nonces.hiding)
+	lc := edwards25519.NewScalar().Multiply(s.lagrange[i], c)
+	z.Subtract(z, lc.Multiply(lc, x))
+	return z.Bytes(), nil
+}
+
+// AggregateSignature combines the signature shares, in the order of the
+// commitments, into the signature of message.
+func (g Group) AggregateSignature(message []byte, commitments []Commitment, shares [][]byte) ([]byte, error) {
+	s, err := newSession(g, domainBinding, message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	zs, err := s.shareScalars(commitments, shares)
+	if err != nil {
+		return nil, err
+	}
+	c := signatureChallenge(s.nonce, g.PubKey, message)
+
+	sum := edwards25519.NewScalar()
+	for i, z := range zs {
+		// z * B == D + ρ * E + λ * c * X
+		lc := edwards25519.NewScalar().Multiply(s.lagrange[i], c)
+		expected := new(edwards25519.Point).ScalarMult(lc, mustPoint(g.PubShares[s.commitments[i].ID-1]))
+		expected.Add(expected, s.nonces[i])
+		if new(edwards25519.Point).ScalarBaseMult(z).Equal(expected) != 1 {
+			return nil, ErrInvalidShare{ID: s.commitments[i].ID}
+		}
+		sum.Add(sum, z)
+	}
+
+	sig := append(s.nonce.Bytes(), sum.Bytes()...)
+	if !g.PubKey.VerifySignature(message, sig) {
+		return nil, errors.New("aggregated signature doesn't verify")
+	}
+	return sig, nil
+}
+
+// AggregateVRFProof combines the VRF shares, in the order of the commitments,
+// into the VRF proof of message.
+func (g Group) AggregateVRFProof(message []byte, commitments []Commitment, shares [][]byte) ([]byte, error) {
+	s, err := newVRFSession(g, message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	zs, err := s.shareScalars(commitments, shares)
+	if err != nil {
+		return nil, err
+	}
+	c, cInt := s.vrfChallenge()
+
+	sum := edwards25519.NewScalar()
+	for i, z := range zs {
+		// z * B == K - λ * c * X and z * H == V - λ * c * Γ
+		lc := edwards25519.NewScalar().Multiply(s.lagrange[i], c)
+		lc.Negate(lc)
+		expected := new(edwards25519.Point).ScalarMult(lc, mustPoint(g.PubShares[s.commitments[i].ID-1]))
+		expected.Add(expected, s.nonces[i])
+		expectedH := new(edwards25519.Point).ScalarMult(lc, s.gammas[i])
+		expectedH.Add(expectedH, s.vrfNonces[i])
+		if new(edwards25519.Point).ScalarBaseMult(z).Equal(expected) != 1 ||
+			new(edwards25519.Point).ScalarMult(z, s.h).Equal(expectedH) != 1 {
+			return nil, ErrInvalidShare{ID: s.commitments[i].ID}
+		}
+		sum.Add(sum, z)
+	}
+
+	// pi = gamma || I2OSP(c, N) || I2OSP(s, 2N)
+	proof := r2ishiguro.S2OS(s.gamma.Bytes())
+	proof = append(proof, r2ishiguro.I2OSP(cInt, vrfChallengeSize)...)
+	proof = append(proof, reverse(sum.Bytes())...)
+	if _, err := g.PubKey.VRFVerify(proof, message); err != nil {
+		return nil, fmt.Errorf("aggregated VRF proof doesn't verify: %w", err)
+	}
+	return proof, nil
+}
+
+//-------------------------------------------------------------------------------
+
+// session holds what the cosigners of a session compute from their
+// commitments.
+type session struct {
+	group       Group
+	commitments []Commitment // sorted by ID
+
+	lagrange       []*edwards25519.Scalar
+	bindingFactors []*edwards25519.Scalar
+	nonces         []*edwards25519.Point // D + ρ * E
+	nonce          *edwards25519.Point   // sum of the nonces
+
+	// VRF only
+	h         *edwards25519.Point
+	vrfNonces []*edwards25519.Point // (D + ρ * E) * H
+	vrfNonce  *edwards25519.Point
+	gammas    []*edwards25519.Point
+	gamma     *edwards25519.Point
+}
+
+func newSession(g Group, domain string, message []byte, commitments []Commitment) (*session, error) {
+	if err := g.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if len(commitments) < g.Threshold {
+		return nil, ErrNotEnoughShares
+	}
+	s := &session{group: g, commitments: make([]Commitment, len(commitments))}
+	copy(s.commitments, commitments)
+	sort.Slice(s.commitments, func(i, j int) bool { return s.commitments[i].ID < s.commitments[j].ID })
+
+	ids := make([]*edwards25519.Scalar, len(s.commitments))
+	for i, c := range s.commitments {
+		if c.ID < 1 || c.ID > len(g.PubShares) {
+			return nil, fmt.Errorf("invalid cosigner ID %d", c.ID)
+		}
+		if i > 0 && s.commitments[i-1].ID == c.ID {
+			return nil, fmt.Errorf("duplicate commitment from cosigner %d", c.ID)
+		}
+		ids[i] = scalarFromInt(c.ID)
+	}
+
+	// binding factors depend on all the commitments and the message
+	encoded := encodeCommitments(domain, message, s.commitments)
+	s.nonce = edwards25519.NewIdentityPoint()
+	for i, c := range s.commitments {
+		hiding, err := new(edwards25519.Point).SetBytes(c.Hiding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commitment from cosigner %d: %w", c.ID, err)
+		}
+		binding, err := new(edwards25519.Point).SetBytes(c.Binding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commitment from cosigner %d: %w", c.ID, err)
+		}
+
+		rho := hashToScalar(encoded, uint32(c.ID))
+		nonce := new(edwards25519.Point).ScalarMult(rho, binding)
+		nonce.Add(nonce, hiding)
+
+		s.lagrange = append(s.lagrange, lagrangeCoefficient(ids, i))
+		s.bindingFactors = append(s.bindingFactors, rho)
+		s.nonces = append(s.nonces, nonce)
+		s.nonce.Add(s.nonce, nonce)
+	}
+	return s, nil
+}
+
+func newVRFSession(g Group, message []byte, commitments []Commitment) (*session, error) {
+	s, err := newSession(g, domainVRFBinding, message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	if s.h, err = hashToCurve(g.PubKey, message); err != nil {
+		return nil, err
+	}
+
+	s.vrfNonce = edwards25519.NewIdentityPoint()
+	s.gamma = edwards25519.NewIdentityPoint()
+	for i, c := range s.commitments {
+		var points [3]*edwards25519.Point
+		for j, bz := range [][]byte{c.VRFHiding, c.VRFBinding, c.VRFGamma} {
+			if points[j], err = new(edwards25519.Point).SetBytes(bz); err != nil {
+				return nil, fmt.Errorf("invalid VRF commitment from cosigner %d: %w", c.ID, err)
+			}
+		}
+		nonce := new(edwards25519.Point).ScalarMult(s.bindingFactors[i], points[1])
+		nonce.Add(nonce, points[0])
+		s.vrfNonces = append(s.vrfNonces, nonce)
+		s.vrfNonce.Add(s.vrfNonce, nonce)
+
+		s.gammas = append(s.gammas, points[2])
+		s.gamma.Add(s.gamma, new(edwards25519.Point).ScalarMult(s.lagrange[i], points[2]))
+	}
+	return s, nil
+}
+
+// index returns the position of the cosigner in the session.
+func (s *session) index(id int) (int, error) {
+	for i, c := range s.commitments {
+		if c.ID == id {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("cosigner %d isn't part of the session", id)
+}
+
+// checkCommitment checks that the commitment of the cosigner at position i in
+// the session is the one of its nonces, and for VRF proofs of its key share x,
+// as RFC 9591 section 5.2 requires: the cosigner would otherwise produce a
+// share for nonces it didn't commit to.
+func (s *session) checkCommitment(i int, nonces *Nonces, x *edwards25519.Scalar) error {
+	c := s.commitments[i]
+	invalid := fmt.Errorf("commitment of cosigner %d doesn't match its nonces", c.ID)
+	if nonces == nil {
+		return invalid
+	}
+	type check struct {
+		scalar *edwards25519.Scalar
+		base   *edwards25519.Point
+		point  []byte
+	}
+	g := edwards25519.NewGeneratorPoint()
+	checks := []check{{nonces.hiding, g, c.Hiding}, {nonces.binding, g, c.Binding}}
+	if s.h != nil {
+		checks = append(checks, check{nonces.hiding, s.h, c.VRFHiding}, check{nonces.binding, s.h, c.VRFBinding},
+			check{x, s.h, c.VRFGamma})
+	}
+	for _, check := range checks {
+		point, err := new(edwards25519.Point).SetBytes(check.point)
+		if err != nil || new(edwards25519.Point).ScalarMult(check.scalar, check.base).Equal(point) != 1 {
+			return invalid
+		}
+	}
+	return nil
+}
+
+// shareScalars decodes the shares given in the order of commitments, and
+// returns them in the order of the session.
+func (s *session) shareScalars(commitments []Commitment, shares [][]byte) ([]*edwards25519.Scalar, error) {
+	if len(shares) != len(commitments) {
+		return nil, fmt.Errorf("got %d shares for %d commitments", len(shares), len(commitments))
+	}
+	zs := make([]*edwards25519.Scalar, len(shares))
+	for i, share := range shares {
+		j, err := s.index(commitments[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		if zs[j], err = new(edwards25519.Scalar).SetCanonicalBytes(share); err != nil {
+			return nil, ErrInvalidShare{ID: commitments[i].ID}
+		}
+	}
+	return zs, nil
+}
+
+// vrfChallenge returns the ECVRF challenge of the session as a scalar and as
+// an integer.
+func (s *session) vrfChallenge() (*edwards25519.Scalar, *big.Int) {
+	base := edwards25519.NewGeneratorPoint()
+	c := r2ishiguro.ECVRF_hash_points(
+		r2ishiguro.S2OS(base.Bytes()),
+		r2ishiguro.S2OS(s.h.Bytes()),
+		r2ishiguro.S2OS(s.group.PubKey),
+		r2ishiguro.S2OS(s.gamma.Bytes()),
+		r2ishiguro.S2OS(s.nonce.Bytes()),
+		r2ishiguro.S2OS(s.vrfNonce.Bytes()),
+	)
+	var buf [32]byte
+	c.FillBytes(buf[:])
+	scalar, err := new(edwards25519.Scalar).SetCanonicalBytes(reverse(buf[:]))
+	if err != nil {
+		panic(err) // c < 2^128
+	}
+	return scalar, c
+}
+
+//-------------------------------------------------------------------------------
+
+func signatureChallenge(r *edwards25519.Point, pubKey ed25519.PubKey, message []byte) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write(r.Bytes())
+	h.Write(pubKey)
+	h.Write(message)
+	c, err := new(edwards25519.Scalar).SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// hashToCurve hashes message to a point like the ECVRF implementation.
+func hashToCurve(pubKey ed25519.PubKey, message []byte) (*edwards25519.Point, error) {
+	// ECP2OS prepends a sign octet to the encoded point
+	return new(edwards25519.Point).SetBytes(r2ishiguro.ECP2OS(r2ishiguro.ECVRF_hash_to_curve(message, pubKey))[1:])
+}
+
+func encodeCommitments(domain string, message []byte, commitments []Commitment) []byte {
+	h := sha512.New()
+	h.Write([]byte(domain))
+	h.Write(message)
+	var id [4]byte
+	for _, c := range commitments {
+		binary.BigEndian.PutUint32(id[:], uint32(c.ID))
+		h.Write(id[:])
+		h.Write(c.Hiding)
+		h.Write(c.Binding)
+		h.Write(c.VRFHiding)
+		h.Write(c.VRFBinding)
+		h.Write(c.VRFGamma)
+	}
+	return h.Sum(nil)
+}
+
+func hashToScalar(encoded []byte, id uint32) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write(encoded)
+	var bz [4]byte
+	binary.BigEndian.PutUint32(bz[:], id)
+	h.Write(bz[:])
+	s, err := new(edwards25519.Scalar).SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// lagrangeCoefficient returns the coefficient of ids[i] to interpolate the
+// secret at 0.
+func lagrangeCoefficient(ids []*edwards25519.Scalar, i int) *edwards25519.Scalar {
+	num := scalarFromInt(1)
+	den := scalarFromInt(1)
+	for j, id := range ids {
+		if j == i {
+			continue
+		}
+		num.Multiply(num, id)
+		den.Multiply(den, edwards25519.NewScalar().Subtract(id, ids[i]))
+	}
+	return num.Multiply(num, invert(den))
+}
+
+// invert returns 1/s, computed as s^(l-2) mod l.
+func invert(s *edwards25519.Scalar) *edwards25519.Scalar {
+	l, _ := new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+	exp := new(big.Int).Sub(l, big.NewInt(2))
+	inv := new(big.Int).Exp(new(big.Int).SetBytes(reverse(s.Bytes())), exp, l)
+	var buf [32]byte
+	inv.FillBytes(buf[:])
+	scalar, err := new(edwards25519.Scalar).SetCanonicalBytes(reverse(buf[:]))
+	if err != nil {
+		panic(err)
+	}
+	return scalar
+}
+
+func scalarFromInt(i int) *edwards25519.Scalar {
+	var buf [32]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(i))
+	s, err := new(edwards25519.Scalar).SetCanonicalBytes(buf[:])
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func randomScalar() *edwards25519.Scalar {
+	var buf [64]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(err)
+	}
+	s, err := new(edwards25519.Scalar).SetUniformBytes(buf[:])
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func mustPoint(bz []byte) *edwards25519.Point {
+	p, err := new(edwards25519.Point).SetBytes(bz)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// reverse returns a reversed copy of bz, to convert between little and big
+// endian.
+func reverse(bz []byte) []byte {
+	r := make([]byte, len(bz))
+	for i, b := range bz {
+		r[len(bz)-1-i] = b
+	}
+	return r
+}
@@ -0,0 +1,133 @@
+package threshold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+)
+
+func TestSplitKey(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	_, err := SplitKey(privKey, 0, 3)
+	assert.Error(t, err)
+	_, err = SplitKey(privKey, 4, 3)
+	assert.Error(t, err)
+
+	shares, err := SplitKey(privKey, 2, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 3)
+	for i, share := range shares {
+		assert.Equal(t, i+1, share.ID)
+		assert.Equal(t, privKey.PubKey(), share.PubKey)
+		assert.NoError(t, share.ValidateBasic())
+	}
+
+	bz, err := tmjson.Marshal(shares[0])
+	require.NoError(t, err)
+	var share KeyShare
+	require.NoError(t, tmjson.Unmarshal(bz, &share))
+	assert.Equal(t, shares[0], share)
+
+	share.ID = 2
+	assert.Error(t, share.ValidateBasic())
+}
+
+func TestThresholdSignature(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	shares, err := SplitKey(privKey, 2, 3)
+	require.NoError(t, err)
+	msg := []byte("vote")
+
+	for _, signers := range [][]KeyShare{
+		{shares[0], shares[1]},
+		{shares[2], shares[0]},
+		{shares[1], shares[2]},
+		shares,
+	} {
+		nonces := make([]*Nonces, len(signers))
+		commitments := make([]Commitment, len(signers))
+		for i, signer := range signers {
+			nonces[i], commitments[i] = signer.Commit()
+		}
+		sigShares := make([][]byte, len(signers))
+		for i, signer := range signers {
+			sigShares[i], err = signer.SignShare(msg, nonces[i], commitments)
+			require.NoError(t, err)
+		}
+
+		sig, err := shares[0].Group.AggregateSignature(msg, commitments, sigShares)
+		require.NoError(t, err)
+		assert.True(t, privKey.PubKey().VerifySignature(msg, sig))
+
+		// a cosigner doesn't sign when its commitment was tampered with
+		tampered := make([]Commitment, len(commitments))
+		copy(tampered, commitments)
+		tampered[0].Hiding, tampered[0].Binding = commitments[0].Binding, commitments[0].Hiding
+		_, err = signers[0].SignShare(msg, nonces[0], tampered)
+		assert.Error(t, err)
+
+		// a bad share is pinned on its cosigner
+		sigShares[1] = sigShares[0]
+		_, err = shares[0].Group.AggregateSignature(msg, commitments, sigShares)
+		assert.Equal(t, ErrInvalidShare{ID: signers[1].ID}, err)
+	}
+
+	nonces, commitment := shares[0].Commit()
+	_, err = shares[0].SignShare(msg, nonces, []Commitment{commitment})
+	assert.Equal(t, ErrNotEnoughShares, err)
+	_, err = shares[0].SignShare(msg, nonces, []Commitment{commitment, commitment})
+	assert.Error(t, err)
+}
+
+func TestThresholdVRFProof(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	shares, err := SplitKey(privKey, 2, 3)
+	require.NoError(t, err)
+	msg := []byte("seed")
+
+	proof, err := privKey.VRFProve(msg)
+	require.NoError(t, err)
+	output, err := privKey.PubKey().VRFVerify(proof, msg)
+	require.NoError(t, err)
+
+	signers := []KeyShare{shares[2], shares[0]}
+	nonces := make([]*Nonces, len(signers))
+	commitments := make([]Commitment, len(signers))
+	for i, signer := range signers {
+		nonces[i], commitments[i], err = signer.VRFCommit(msg)
+		require.NoError(t, err)
+	}
+	vrfShares := make([][]byte, len(signers))
+	for i, signer := range signers {
+		vrfShares[i], err = signer.VRFShare(msg, nonces[i], commitments)
+		require.NoError(t, err)
+	}
+
+	thresholdProof, err := shares[1].Group.AggregateVRFProof(msg, commitments, vrfShares)
+	require.NoError(t, err)
+	thresholdOutput, err := privKey.PubKey().VRFVerify(thresholdProof, msg)
+	require.NoError(t, err)
+	assert.Equal(t, output, thresholdOutput)
+
+	// a cosigner doesn't sign when its commitment was tampered with
+	for _, tamper := range []func(c *Commitment){
+		func(c *Commitment) { c.Hiding = commitments[1].Hiding },
+		func(c *Commitment) { c.VRFBinding = commitments[1].VRFBinding },
+		func(c *Commitment) { c.VRFGamma = commitments[1].VRFGamma },
+	} {
+		tampered := make([]Commitment, len(commitments))
+		copy(tampered, commitments)
+		tamper(&tampered[0])
+		_, err = signers[0].VRFShare(msg, nonces[0], tampered)
+		assert.Error(t, err)
+	}
+
+	// signature commitments can't be used for VRF proofs
+	_, commitments[0] = signers[0].Commit()
+	_, err = signers[1].VRFShare(msg, nonces[1], commitments)
+	assert.Error(t, err)
+}
@@ -49,5 +49,15 @@ and hence pruned. Currently, only committed evidence in which a marker to the he
 and hence very small is saved. All updates are made from the `Update(block, state)` function which should be called
 when a new block is committed.
 
+Inspection
+
+`ListPendingEvidence(filter)` and `ListCommittedEvidence(filter)` list the evidence of either bucket, filtered by
+the address of an accused validator, the evidence type and the heights of the misbehavior, and paginated while
+iterating over the bucket. Committed evidence is loaded from the block at the height of its marker. Markers written
+by earlier versions hold the height of the evidence instead; the block of such evidence is searched for among the
+following blocks with evidence, and the marker is updated. They back the `pending_evidence` and `evidence_search`
+RPCs.
+Evidence is published on the event bus with `EventPendingEvidence` when it's first added to the pending bucket.
+
 */
 package evidence
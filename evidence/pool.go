@@ -10,11 +10,11 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	gogotypes "github.com/gogo/protobuf/types"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/Finschia/ostracon/libs/clist"
 	"github.com/Finschia/ostracon/libs/log"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
 )
@@ -37,6 +37,9 @@ type Pool struct {
 	// needed to load headers and commits to verify evidence
 	blockStore BlockStore
 
+	// publishes evidence when it's first added to the pool
+	eventBus types.EvidenceEventPublisher
+
 	mtx sync.Mutex
 	// latest state
 	state sm.State
@@ -44,6 +47,7 @@ type Pool struct {
 	// before being flushed to the pool. This prevents broadcasting and proposing of
 	// evidence before the height with which the evidence happened is finished.
 	consensusBuffer []duplicateVoteSet
+	proposalBuffer  []duplicateProposalSet
 
 	pruningHeight int64
 	pruningTime   time.Time
@@ -61,11 +65,13 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 	pool := &Pool{
 		stateDB:         stateDB,
 		blockStore:      blockStore,
+		eventBus:        types.NopEventBus{},
 		state:           state,
 		logger:          log.NewNopLogger(),
 		evidenceStore:   evidenceDB,
 		evidenceList:    clist.New(),
 		consensusBuffer: make([]duplicateVoteSet, 0),
+		proposalBuffer:  make([]duplicateProposalSet, 0),
 	}
 
 	// if pending evidence already in db, in event of prior failure, then check for expiration,
@@ -97,8 +103,8 @@ func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 
 // Update takes both the new state and the evidence committed at that height and performs
 // the following operations:
-// 1. Take any conflicting votes from consensus and use the state's LastBlockTime to form
-//    DuplicateVoteEvidence and add it to the pool.
+// 1. Take any conflicting votes and proposals from consensus and use the state's LastBlockTime
+//    to form DuplicateVoteEvidence and DuplicateProposalEvidence and add it to the pool.
 // 2. Update the pool's state which contains evidence params relating to expiry.
 // 3. Moves pending evidence that has now been committed into the committed pool.
 // 4. Removes any expired evidence based on both height and time.
@@ -114,14 +120,14 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	evpool.logger.Debug("Updating evidence pool", "last_block_height", state.LastBlockHeight,
 		"last_block_time", state.LastBlockTime)
 
-	// flush conflicting vote pairs from the buffer, producing DuplicateVoteEvidence and
-	// adding it to the pool
+	// flush conflicting vote and proposal pairs from the buffers, producing DuplicateVoteEvidence
+	// and DuplicateProposalEvidence and adding it to the pool
 	evpool.processConsensusBuffer(state)
 	// update state
 	evpool.updateState(state)
 
 	// move committed evidence out from the pending pool and into the committed pool
-	evpool.markEvidenceAsCommitted(ev)
+	evpool.markEvidenceAsCommitted(ev, state.LastBlockHeight)
 
 	// prune pending evidence when it has expired. This also updates when the next evidence will expire
 	if evpool.Size() > 0 && state.LastBlockHeight > evpool.pruningHeight &&
@@ -164,6 +170,9 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 
 	evpool.logger.Info("Verified new evidence of byzantine behavior", "evidence", ev)
 
+	// 4) Notify subscribers of the new pending evidence.
+	evpool.publishPendingEvidence(ev)
+
 	return nil
 }
 
@@ -185,6 +194,21 @@ func (evpool *Pool) ReportConflictingVotes(voteA, voteB *types.Vote) {
 	})
 }
 
+// ReportConflictingProposals takes two conflicting proposals of the proposer and forms
+// duplicate proposal evidence, adding it eventually to the evidence pool. Like conflicting
+// votes, the proposals are held in a buffer until consensus at that height has been reached.
+//
+// Proposals are not verified.
+func (evpool *Pool) ReportConflictingProposals(proposalA, proposalB *types.Proposal, proposer types.Address) {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	evpool.proposalBuffer = append(evpool.proposalBuffer, duplicateProposalSet{
+		ProposalA: proposalA,
+		ProposalB: proposalB,
+		Proposer:  proposer,
+	})
+}
+
 // CheckEvidence takes an array of evidence from a block and verifies all the evidence there.
 // If it has already verified the evidence then it jumps to the next one. It ensures that no
 // evidence has already been committed or is being proposed twice. It also adds any
@@ -239,6 +263,11 @@ func (evpool *Pool) EvidenceWaitChan() <-chan struct{} {
 	return evpool.evidenceList.WaitChan()
 }
 
+// SetEventBus sets the event bus on which evidence is published when it's first added to the pool.
+func (evpool *Pool) SetEventBus(eventBus types.EvidenceEventPublisher) {
+	evpool.eventBus = eventBus
+}
+
 // SetLogger sets the Logger.
 func (evpool *Pool) SetLogger(l log.Logger) {
 	evpool.logger = l
@@ -323,9 +352,9 @@ func (evpool *Pool) removePendingEvidence(evidence types.Evidence) {
 	}
 }
 
-// markEvidenceAsCommitted processes all the evidence in the block, marking it as
+// markEvidenceAsCommitted processes all the evidence in the block at height, marking it as
 // committed and removing it from the pending database.
-func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
+func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList, height int64) {
 	blockEvidenceMap := make(map[string]struct{}, len(evidence))
 	for _, ev := range evidence {
 		if evpool.isPending(ev) {
@@ -334,10 +363,10 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 		}
 
 		// Add evidence to the committed list. As the evidence is stored in the block store
-		// we only need to record the height that it was saved at.
+		// we only need to record the height of the block that it was saved at.
 		key := keyCommitted(ev)
 
-		h := gogotypes.Int64Value{Value: ev.Height()}
+		h := gogotypes.Int64Value{Value: height}
 		evBytes, err := proto.Marshal(&h)
 		if err != nil {
 			evpool.logger.Error("failed to marshal committed evidence", "err", err, "key(height/hash)", key)
@@ -362,7 +391,7 @@ func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Eviden
 		evSize    int64
 		totalSize int64
 		evidence  []types.Evidence
-		evList    tmproto.EvidenceList // used for calculating the bytes size
+		evList    ocproto.EvidenceList // used for calculating the bytes size
 	)
 
 	iter, err := dbm.IteratePrefix(evpool.evidenceStore, []byte{prefixKey})
@@ -371,7 +400,7 @@ func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Eviden
 	}
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
-		var evpb tmproto.Evidence
+		var evpb ocproto.Evidence
 		err := evpb.Unmarshal(iter.Value())
 		if err != nil {
 			return evidence, totalSize, err
@@ -452,80 +481,110 @@ func (evpool *Pool) updateState(state sm.State) {
 	evpool.state = state
 }
 
-// processConsensusBuffer converts all the duplicate votes witnessed from consensus
-// into DuplicateVoteEvidence. It sets the evidence timestamp to the block height
-// from the most recently committed block.
+// processConsensusBuffer converts all the duplicate votes and proposals witnessed from consensus
+// into DuplicateVoteEvidence and DuplicateProposalEvidence. It sets the evidence timestamp to the
+// block height from the most recently committed block.
 // Evidence is then added to the pool so as to be ready to be broadcasted and proposed.
 func (evpool *Pool) processConsensusBuffer(state sm.State) {
 	evpool.mtx.Lock()
 	defer evpool.mtx.Unlock()
 	for _, voteSet := range evpool.consensusBuffer {
-
 		// Check the height of the conflicting votes and fetch the corresponding time and validator set
 		// to produce the valid evidence
-		var dve *types.DuplicateVoteEvidence
-		switch {
-		case voteSet.VoteA.Height == state.LastBlockHeight:
-			dve = types.NewDuplicateVoteEvidence(
-				voteSet.VoteA,
-				voteSet.VoteB,
-				state.LastBlockTime,
-				state.LastValidators,
-			)
-
-		case voteSet.VoteA.Height < state.LastBlockHeight:
-			valSet, err := evpool.stateDB.LoadValidators(voteSet.VoteA.Height)
-			if err != nil {
-				evpool.logger.Error("failed to load validator set for conflicting votes", "height",
-					voteSet.VoteA.Height, "err", err,
-				)
-				continue
-			}
-			blockMeta := evpool.blockStore.LoadBlockMeta(voteSet.VoteA.Height)
-			if blockMeta == nil {
-				evpool.logger.Error("failed to load block time for conflicting votes", "height", voteSet.VoteA.Height)
-				continue
-			}
-			dve = types.NewDuplicateVoteEvidence(
-				voteSet.VoteA,
-				voteSet.VoteB,
-				blockMeta.Header.Time,
-				valSet,
-			)
-
-		default:
-			// evidence pool shouldn't expect to get votes from consensus of a height that is above the current
-			// state. If this error is seen then perhaps consider keeping the votes in the buffer and retry
-			// in following heights
-			evpool.logger.Error("inbound duplicate votes from consensus are of a greater height than current state",
-				"duplicate vote height", voteSet.VoteA.Height,
-				"state.LastBlockHeight", state.LastBlockHeight)
+		blockTime, valSet, ok := evpool.consensusEvidenceContext(state, voteSet.VoteA.Height, "votes")
+		if !ok {
 			continue
 		}
-
-		// check if we already have this evidence
-		if evpool.isPending(dve) {
-			evpool.logger.Debug("evidence already pending; ignoring", "evidence", dve)
+		dve := types.NewDuplicateVoteEvidence(voteSet.VoteA, voteSet.VoteB, blockTime, valSet)
+		if dve == nil {
+			evpool.logger.Error("failed to form evidence from conflicting votes", "vote", voteSet.VoteA)
 			continue
 		}
-
-		// check that the evidence is not already committed on chain
-		if evpool.isCommitted(dve) {
-			evpool.logger.Debug("evidence already committed; ignoring", "evidence", dve)
+		evpool.addConsensusEvidence(dve)
+	}
+	for _, proposalSet := range evpool.proposalBuffer {
+		blockTime, valSet, ok := evpool.consensusEvidenceContext(state, proposalSet.ProposalA.Height, "proposals")
+		if !ok {
 			continue
 		}
-
-		if err := evpool.addPendingEvidence(dve); err != nil {
-			evpool.logger.Error("failed to flush evidence from consensus buffer to pending list: %w", err)
+		dpe := types.NewDuplicateProposalEvidence(proposalSet.ProposalA, proposalSet.ProposalB,
+			proposalSet.Proposer, blockTime, valSet)
+		if dpe == nil {
+			evpool.logger.Error("failed to form evidence from conflicting proposals", "proposal",
+				proposalSet.ProposalA, "proposer", proposalSet.Proposer)
 			continue
 		}
+		evpool.addConsensusEvidence(dpe)
+	}
+	// reset consensus buffers
+	evpool.consensusBuffer = make([]duplicateVoteSet, 0)
+	evpool.proposalBuffer = make([]duplicateProposalSet, 0)
+}
 
-		evpool.evidenceList.PushBack(dve)
+// consensusEvidenceContext returns the block time and the validator set of the given height to
+// form evidence from the conflicting messages of consensus with.
+func (evpool *Pool) consensusEvidenceContext(state sm.State, height int64, msgs string) (
+	time.Time, *types.ValidatorSet, bool) {
+	switch {
+	case height == state.LastBlockHeight:
+		return state.LastBlockTime, state.LastValidators, true
 
-		evpool.logger.Info("verified new evidence of byzantine behavior", "evidence", dve)
+	case height < state.LastBlockHeight:
+		valSet, err := evpool.stateDB.LoadValidators(height)
+		if err != nil {
+			evpool.logger.Error("failed to load validator set for conflicting "+msgs, "height",
+				height, "err", err,
+			)
+			return time.Time{}, nil, false
+		}
+		blockMeta := evpool.blockStore.LoadBlockMeta(height)
+		if blockMeta == nil {
+			evpool.logger.Error("failed to load block time for conflicting "+msgs, "height", height)
+			return time.Time{}, nil, false
+		}
+		return blockMeta.Header.Time, valSet, true
+
+	default:
+		// evidence pool shouldn't expect to get messages from consensus of a height that is above the current
+		// state. If this error is seen then perhaps consider keeping the messages in the buffer and retry
+		// in following heights
+		evpool.logger.Error("inbound duplicate "+msgs+" from consensus are of a greater height than current state",
+			"duplicate height", height,
+			"state.LastBlockHeight", state.LastBlockHeight)
+		return time.Time{}, nil, false
+	}
+}
+
+// addConsensusEvidence adds evidence formed from the conflicting messages of consensus to the pool.
+func (evpool *Pool) addConsensusEvidence(ev types.Evidence) {
+	// check if we already have this evidence
+	if evpool.isPending(ev) {
+		evpool.logger.Debug("evidence already pending; ignoring", "evidence", ev)
+		return
+	}
+
+	// check that the evidence is not already committed on chain
+	if evpool.isCommitted(ev) {
+		evpool.logger.Debug("evidence already committed; ignoring", "evidence", ev)
+		return
+	}
+
+	if err := evpool.addPendingEvidence(ev); err != nil {
+		evpool.logger.Error("failed to flush evidence from consensus buffer to pending list: %w", err)
+		return
+	}
+
+	evpool.evidenceList.PushBack(ev)
+
+	evpool.logger.Info("verified new evidence of byzantine behavior", "evidence", ev)
+
+	evpool.publishPendingEvidence(ev)
+}
+
+func (evpool *Pool) publishPendingEvidence(ev types.Evidence) {
+	if err := evpool.eventBus.PublishEventPendingEvidence(types.EventDataPendingEvidence{Evidence: ev}); err != nil {
+		evpool.logger.Error("failed publishing pending evidence", "err", err)
 	}
-	// reset consensus buffer
-	evpool.consensusBuffer = make([]duplicateVoteSet, 0)
 }
 
 type duplicateVoteSet struct {
@@ -533,8 +592,14 @@ type duplicateVoteSet struct {
 	VoteB *types.Vote
 }
 
+type duplicateProposalSet struct {
+	ProposalA *types.Proposal
+	ProposalB *types.Proposal
+	Proposer  types.Address
+}
+
 func bytesToEv(evBytes []byte) (types.Evidence, error) {
-	var evpb tmproto.Evidence
+	var evpb ocproto.Evidence
 	err := evpb.Unmarshal(evBytes)
 	if err != nil {
 		return &types.DuplicateVoteEvidence{}, err
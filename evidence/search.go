@@ -0,0 +1,272 @@
+package evidence
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/types"
+)
+
+// Names of the evidence types selected by Filter.
+const (
+	TypeDuplicateVote     = "DUPLICATE_VOTE"
+	TypeLightClientAttack = "LIGHT_CLIENT_ATTACK"
+	TypeDuplicateProposal = "DUPLICATE_PROPOSAL"
+)
+
+// Filter selects evidence by the address of a validator it accuses, its type and the range of
+// heights at which the misbehavior happened. Fields with zero values match any evidence.
+//
+// Type is the name of the evidence type rather than its ABCI type, as ABCI passes duplicate
+// proposals as duplicate votes.
+type Filter struct {
+	Address   types.Address
+	Type      string
+	MinHeight int64
+	MaxHeight int64
+}
+
+// CommittedEvidence is evidence with the height of the block that committed it.
+type CommittedEvidence struct {
+	Evidence types.Evidence
+	Height   int64
+}
+
+// Page selects a page of the evidence that matches a filter: the evidence after the first Skip of
+// it, up to Limit of it if Limit is positive. Evidence is listed from the oldest misbehavior to the
+// newest, or from the newest to the oldest if Desc is true.
+type Page struct {
+	Skip  int
+	Limit int
+	Desc  bool
+}
+
+func (p Page) contains(i int) bool {
+	return i >= p.Skip && (p.Limit <= 0 || i < p.Skip+p.Limit)
+}
+
+// ListPendingEvidence returns the page of the pending evidence that matches the filter, and the
+// count of the matching evidence. Only the evidence of the page is kept, and if the filter
+// selects evidence by heights only, only the evidence of the page is decoded.
+func (evpool *Pool) ListPendingEvidence(filter Filter, page Page) ([]types.Evidence, int, error) {
+	iter, err := evpool.filterIterator(baseKeyPending, filter, page.Desc)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer iter.Close()
+
+	evidence := make([]types.Evidence, 0)
+	total := 0
+	for ; iter.Valid(); iter.Next() {
+		inPage := page.contains(total)
+		if !inPage && !filter.needsEvidence() {
+			total++
+			continue
+		}
+		ev, err := bytesToEv(iter.Value())
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode pending evidence: %w", err)
+		}
+		if !filter.matches(ev) {
+			continue
+		}
+		if inPage {
+			evidence = append(evidence, ev)
+		}
+		total++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, 0, err
+	}
+	return evidence, total, nil
+}
+
+// ListCommittedEvidence returns the page of the committed evidence that matches the filter, and
+// the count of the matching evidence. The evidence is loaded from the blocks that committed it,
+// and if the filter selects evidence by heights only, only the blocks of the evidence of the page
+// are loaded. Evidence whose block is no longer in the block store is left out.
+func (evpool *Pool) ListCommittedEvidence(filter Filter, page Page) ([]CommittedEvidence, int, error) {
+	iter, err := evpool.filterIterator(baseKeyCommitted, filter, page.Desc)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer iter.Close()
+
+	evidence := make([]CommittedEvidence, 0)
+	total := 0
+	// consecutive evidence is often committed by the same block
+	var block *types.Block
+	for ; iter.Valid(); iter.Next() {
+		inPage := page.contains(total)
+		if !inPage && !filter.needsEvidence() {
+			total++
+			continue
+		}
+		evHeight, hash, err := parseKey(iter.Key())
+		if err != nil {
+			return nil, 0, err
+		}
+		height, err := evpool.committedHeight(iter.Key(), iter.Value(), evHeight, hash)
+		if err != nil {
+			return nil, 0, err
+		}
+		if block == nil || block.Height != height {
+			block = evpool.blockStore.LoadBlock(height)
+		}
+		ev := findEvidence(block, hash)
+		if ev == nil {
+			evpool.logger.Debug("Committed evidence not found in block", "hash", hash, "height", height)
+			continue
+		}
+		if !filter.matches(ev) {
+			continue
+		}
+		if inPage {
+			evidence = append(evidence, CommittedEvidence{Evidence: ev, Height: height})
+		}
+		total++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, 0, err
+	}
+	return evidence, total, nil
+}
+
+// committedHeight returns the height of the block that committed the evidence of the committed
+// key, from the value of the key.
+//
+// The value used to be the height of the evidence, which is lower than the height of the block
+// that committed it. In that case, the block is searched for among the blocks following the
+// evidence until it expires, and the value is updated once the block is found. Only the blocks
+// with evidence are loaded.
+func (evpool *Pool) committedHeight(key, value []byte, evHeight int64, hash []byte) (int64, error) {
+	var height gogotypes.Int64Value
+	if err := proto.Unmarshal(value, &height); err != nil {
+		return 0, fmt.Errorf("failed to decode committed evidence height: %w", err)
+	}
+	if height.Value > evHeight {
+		return height.Value, nil
+	}
+
+	evMeta := evpool.blockStore.LoadBlockMeta(evHeight)
+	if evMeta == nil {
+		return 0, nil
+	}
+	params := evpool.State().ConsensusParams.Evidence
+	emptyHash := types.EvidenceList{}.Hash()
+	for h := evHeight + 1; h <= evpool.blockStore.Height(); h++ {
+		meta := evpool.blockStore.LoadBlockMeta(h)
+		if meta == nil {
+			break
+		}
+		if h-evHeight > params.MaxAgeNumBlocks && meta.Header.Time.Sub(evMeta.Header.Time) > params.MaxAgeDuration {
+			break
+		}
+		if bytes.Equal(meta.Header.EvidenceHash, emptyHash) || findEvidence(evpool.blockStore.LoadBlock(h), hash) == nil {
+			continue
+		}
+		bz, err := proto.Marshal(&gogotypes.Int64Value{Value: h})
+		if err != nil {
+			return 0, err
+		}
+		if err := evpool.evidenceStore.Set(key, bz); err != nil {
+			evpool.logger.Error("Unable to update committed evidence", "err", err, "key(height/hash)", key)
+		}
+		return h, nil
+	}
+	return 0, nil
+}
+
+// filterIterator iterates over the evidence under prefixKey in the height range of the filter.
+func (evpool *Pool) filterIterator(prefixKey byte, filter Filter, desc bool) (dbm.Iterator, error) {
+	start := []byte{prefixKey}
+	if filter.MinHeight > 0 {
+		start = append(start, bE(filter.MinHeight)...)
+	}
+	end := []byte{prefixKey + 1}
+	if filter.MaxHeight > 0 {
+		end = append([]byte{prefixKey}, bE(filter.MaxHeight+1)...)
+	}
+	var (
+		iter dbm.Iterator
+		err  error
+	)
+	if desc {
+		iter, err = evpool.evidenceStore.ReverseIterator(start, end)
+	} else {
+		iter, err = evpool.evidenceStore.Iterator(start, end)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+	return iter, nil
+}
+
+// needsEvidence returns true if the filter selects evidence by more than its height, which is in
+// the key of the evidence.
+func (f Filter) needsEvidence() bool {
+	return len(f.Address) > 0 || f.Type != ""
+}
+
+func (f Filter) matches(ev types.Evidence) bool {
+	if f.Type != "" && f.Type != evidenceType(ev) {
+		return false
+	}
+	if len(f.Address) == 0 {
+		return true
+	}
+	for _, abciEv := range ev.ABCI() {
+		if bytes.Equal(abciEv.Validator.Address, f.Address) {
+			return true
+		}
+	}
+	return false
+}
+
+func evidenceType(ev types.Evidence) string {
+	switch ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		return TypeDuplicateVote
+	case *types.LightClientAttackEvidence:
+		return TypeLightClientAttack
+	case *types.DuplicateProposalEvidence:
+		return TypeDuplicateProposal
+	default:
+		return ""
+	}
+}
+
+func findEvidence(block *types.Block, hash []byte) types.Evidence {
+	if block == nil {
+		return nil
+	}
+	for _, ev := range block.Evidence.Evidence {
+		if bytes.Equal(ev.Hash(), hash) {
+			return ev
+		}
+	}
+	return nil
+}
+
+// parseKey returns the height and the hash of the evidence of a key made by keySuffix.
+func parseKey(key []byte) (int64, []byte, error) {
+	parts := bytes.Split(key[1:], []byte("/"))
+	if len(parts) != 2 {
+		return 0, nil, fmt.Errorf("malformed evidence key %X", key)
+	}
+	height, err := strconv.ParseInt(string(parts[0]), 16, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed evidence key %X: %w", key, err)
+	}
+	hash, err := hex.DecodeString(string(parts[1]))
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed evidence key %X: %w", key, err)
+	}
+	return height, hash, nil
+}
@@ -0,0 +1,164 @@
+package evidence_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	"github.com/Finschia/ostracon/evidence"
+	"github.com/Finschia/ostracon/libs/log"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/types"
+	"github.com/Finschia/ostracon/version"
+)
+
+func TestListEvidence(t *testing.T) {
+	height := int64(21)
+	val := types.NewMockPV()
+	stateStore := initializeValidatorState(val, height)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore := initializeBlockStore(dbm.NewMemDB(), state, val.PrivKey)
+	evidenceDB := dbm.NewMemDB()
+	pool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryPendingEvidence, 2)
+	require.NoError(t, err)
+	pool.SetEventBus(eventBus)
+
+	ev1 := types.NewMockDuplicateVoteEvidenceWithValidator(5, defaultEvidenceTime.Add(5*time.Minute),
+		val, evidenceChainID)
+	ev2 := types.NewMockDuplicateVoteEvidenceWithValidator(10, defaultEvidenceTime.Add(10*time.Minute),
+		val, evidenceChainID)
+	for _, ev := range []types.Evidence{ev1, ev2} {
+		require.NoError(t, pool.AddEvidence(ev))
+		select {
+		case msg := <-sub.Out():
+			assert.Equal(t, ev, msg.Data().(types.EventDataPendingEvidence).Evidence)
+		case <-time.After(time.Second):
+			t.Fatal("did not receive pending evidence after 1 sec.")
+		}
+	}
+	// evidence already in the pool isn't published again
+	require.NoError(t, pool.AddEvidence(ev1))
+	select {
+	case <-sub.Out():
+		t.Fatal("pending evidence published twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	valAddr := val.PrivKey.PubKey().Address()
+	testCases := []struct {
+		name     string
+		filter   evidence.Filter
+		expected []types.Evidence
+	}{
+		{"all", evidence.Filter{}, []types.Evidence{ev1, ev2}},
+		{"min height", evidence.Filter{MinHeight: 6}, []types.Evidence{ev2}},
+		{"max height", evidence.Filter{MaxHeight: 5}, []types.Evidence{ev1}},
+		{"height range", evidence.Filter{MinHeight: 5, MaxHeight: 10}, []types.Evidence{ev1, ev2}},
+		{"empty height range", evidence.Filter{MinHeight: 6, MaxHeight: 9}, []types.Evidence{}},
+		{"address", evidence.Filter{Address: valAddr}, []types.Evidence{ev1, ev2}},
+		{"other address", evidence.Filter{Address: tmrand.Bytes(crypto.AddressSize)}, []types.Evidence{}},
+		{"type", evidence.Filter{Type: evidence.TypeDuplicateVote}, []types.Evidence{ev1, ev2}},
+		{"other type", evidence.Filter{Type: evidence.TypeLightClientAttack}, []types.Evidence{}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pending, total, err := pool.ListPendingEvidence(tc.filter, evidence.Page{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, pending)
+			assert.Equal(t, len(tc.expected), total)
+		})
+	}
+
+	pageCases := []struct {
+		name     string
+		filter   evidence.Filter
+		page     evidence.Page
+		expected []types.Evidence
+	}{
+		{"limit", evidence.Filter{}, evidence.Page{Limit: 1}, []types.Evidence{ev1}},
+		{"skip", evidence.Filter{}, evidence.Page{Skip: 1, Limit: 1}, []types.Evidence{ev2}},
+		{"skip all", evidence.Filter{}, evidence.Page{Skip: 2, Limit: 1}, []types.Evidence{}},
+		{"desc", evidence.Filter{}, evidence.Page{Desc: true}, []types.Evidence{ev2, ev1}},
+		{"desc limit", evidence.Filter{}, evidence.Page{Limit: 1, Desc: true}, []types.Evidence{ev2}},
+		{"filtered", evidence.Filter{Address: valAddr}, evidence.Page{Skip: 1}, []types.Evidence{ev2}},
+	}
+	for _, tc := range pageCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pending, total, err := pool.ListPendingEvidence(tc.filter, tc.page)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, pending)
+			assert.Equal(t, 2, total)
+		})
+	}
+
+	// commit the first evidence in the next block
+	round := int32(0)
+	proof, err := val.PrivKey.VRFProve(state.MakeHashMessage(round))
+	require.NoError(t, err)
+	lastBlockID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)}}
+	lastCommit := types.NewCommit(height, 0, lastBlockID, makeCommit(height, valAddr).Signatures)
+	block, _ := state.MakeBlock(height+1, []types.Tx{}, lastCommit, []types.Evidence{ev1},
+		state.Validators.SelectProposer(proof, height+1, round).Address, round, proof)
+	block.Header.Version = tmversion.Consensus{Block: version.BlockProtocol, App: version.AppProtocol}
+	blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), makeCommit(height+1, valAddr))
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(22 * time.Minute)
+	pool.Update(state, block.Evidence.Evidence)
+
+	pending, _, err := pool.ListPendingEvidence(evidence.Filter{}, evidence.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.Evidence{ev2}, pending)
+
+	committed, total, err := pool.ListCommittedEvidence(evidence.Filter{Address: valAddr}, evidence.Page{})
+	require.NoError(t, err)
+	require.Len(t, committed, 1)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, ev1.Hash(), committed[0].Evidence.Hash())
+	assert.Equal(t, height+1, committed[0].Height)
+
+	committed, total, err = pool.ListCommittedEvidence(evidence.Filter{MinHeight: 6}, evidence.Page{})
+	require.NoError(t, err)
+	assert.Empty(t, committed)
+	assert.Zero(t, total)
+
+	// the committed evidence used to be marked with its own height
+	key := append([]byte{0x00}, []byte(fmt.Sprintf("%0.16X/%X", ev1.Height(), ev1.Hash()))...)
+	legacy, err := proto.Marshal(&gogotypes.Int64Value{Value: ev1.Height()})
+	require.NoError(t, err)
+	require.NoError(t, evidenceDB.Set(key, legacy))
+	committed, _, err = pool.ListCommittedEvidence(evidence.Filter{}, evidence.Page{})
+	require.NoError(t, err)
+	require.Len(t, committed, 1)
+	assert.Equal(t, height+1, committed[0].Height)
+	// and is marked with the height of its block once found
+	value, err := evidenceDB.Get(key)
+	require.NoError(t, err)
+	var marker gogotypes.Int64Value
+	require.NoError(t, proto.Unmarshal(value, &marker))
+	assert.Equal(t, height+1, marker.Value)
+}
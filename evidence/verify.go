@@ -56,6 +56,17 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		}
 		return VerifyDuplicateVote(ev, state.ChainID, valSet)
 
+	case *types.DuplicateProposalEvidence:
+		valSet, err := evpool.stateDB.LoadValidators(evidence.Height())
+		if err != nil {
+			return err
+		}
+		proofHash, err := evpool.stateDB.LoadProofHash(evidence.Height())
+		if err != nil {
+			return err
+		}
+		return VerifyDuplicateProposal(ev, state.ChainID, valSet, proofHash)
+
 	case *types.LightClientAttackEvidence:
 		commonHeader, err := getSignedHeader(evpool.blockStore, evidence.Height())
 		if err != nil {
@@ -114,7 +125,8 @@ func VerifyLightClientAttack(e *types.LightClientAttackEvidence, commonHeader, t
 	// In the case of lunatic attack there will be a different commonHeader height. Therefore the node perform a single
 	// verification jump between the common header and the conflicting one
 	if commonHeader.Height != e.ConflictingBlock.Height {
-		err := commonVals.VerifyCommitLightTrusting(trustedHeader.ChainID, e.ConflictingBlock.Commit, light.DefaultTrustLevel)
+		err := commonVals.VerifyCommitLightTrustingRotated(trustedHeader.ChainID, e.ConflictingBlock.Commit,
+			light.DefaultTrustLevel, e.ConflictingBlock.ValidatorSet)
 		if err != nil {
 			return fmt.Errorf("skipping verification of conflicting block failed: %w", err)
 		}
@@ -190,13 +202,6 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 		)
 	}
 
-	// pubkey must match address (this should already be true, sanity check)
-	addr := e.VoteA.ValidatorAddress
-	if !bytes.Equal(pubKey.Address(), addr) {
-		return fmt.Errorf("address (%X) doesn't match pubkey (%v - %X)",
-			addr, pubKey, pubKey.Address())
-	}
-
 	// validator voting power and total voting power must match
 	if val.VotingPower != e.ValidatorPower {
 		return fmt.Errorf("validator power from evidence and our validator set does not match (%d != %d)",
@@ -220,6 +225,67 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 	return nil
 }
 
+// VerifyDuplicateProposal verifies DuplicateProposalEvidence against the state of full node. This
+// involves the following checks:
+//      - the validator is the proposer elected, with the proof hash of the height, for the
+//        height and round of the proposals
+//      - the height and round of the proposals must be the same
+//      - the block ID's must be different
+//      - The signatures must both be valid
+func VerifyDuplicateProposal(e *types.DuplicateProposalEvidence, chainID string, valSet *types.ValidatorSet,
+	proofHash []byte) error {
+	_, val := valSet.GetByAddress(e.ProposerAddress)
+	if val == nil {
+		return fmt.Errorf("address %X was not a validator at height %d", e.ProposerAddress, e.Height())
+	}
+	pubKey := val.PubKey
+
+	// H/R must be the same
+	if e.ProposalA.Height != e.ProposalB.Height ||
+		e.ProposalA.Round != e.ProposalB.Round {
+		return fmt.Errorf("h/r does not match: %d/%d vs %d/%d",
+			e.ProposalA.Height, e.ProposalA.Round,
+			e.ProposalB.Height, e.ProposalB.Round)
+	}
+
+	// the validator must be the proposer of the round
+	proposer := valSet.SelectProposer(proofHash, e.ProposalA.Height, e.ProposalA.Round)
+	if !bytes.Equal(proposer.Address, e.ProposerAddress) {
+		return fmt.Errorf("address %X was not the proposer of height %d round %d, expected %X",
+			e.ProposerAddress, e.ProposalA.Height, e.ProposalA.Round, proposer.Address)
+	}
+
+	// BlockIDs must be different
+	if e.ProposalA.BlockID.Equals(e.ProposalB.BlockID) {
+		return fmt.Errorf(
+			"block IDs are the same (%v) - not a real duplicate proposal",
+			e.ProposalA.BlockID,
+		)
+	}
+
+	// validator voting power and total voting power must match
+	if val.VotingPower != e.ValidatorPower {
+		return fmt.Errorf("validator power from evidence and our validator set does not match (%d != %d)",
+			e.ValidatorPower, val.VotingPower)
+	}
+	if valSet.TotalVotingPower() != e.TotalVotingPower {
+		return fmt.Errorf("total voting power from the evidence and our validator set does not match (%d != %d)",
+			e.TotalVotingPower, valSet.TotalVotingPower())
+	}
+
+	pa := e.ProposalA.ToProto()
+	pb := e.ProposalB.ToProto()
+	// Signatures must be valid
+	if !pubKey.VerifySignature(types.ProposalSignBytes(chainID, pa), e.ProposalA.Signature) {
+		return fmt.Errorf("verifying ProposalA: %w", types.ErrInvalidProposalSignature)
+	}
+	if !pubKey.VerifySignature(types.ProposalSignBytes(chainID, pb), e.ProposalB.Signature) {
+		return fmt.Errorf("verifying ProposalB: %w", types.ErrInvalidProposalSignature)
+	}
+
+	return nil
+}
+
 // validateABCIEvidence validates the ABCI component of the light client attack
 // evidence i.e voting power and byzantine validators
 func validateABCIEvidence(
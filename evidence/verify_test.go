@@ -508,6 +508,94 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyDuplicateProposalEvidence(t *testing.T) {
+	val := types.NewMockPV()
+	val2 := types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1), val2.ExtractIntoValidator(1)})
+	proofHash := []byte("proofhash")
+	proposer, other := val, val2
+	if !bytes.Equal(valSet.SelectProposer(proofHash, 10, 2).Address, val.PrivKey.PubKey().Address()) {
+		proposer, other = val2, val
+	}
+
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+
+	const chainID = "mychain"
+
+	proposal1 := makeProposal(t, proposer, chainID, 10, 2, blockID)
+	badProposal := makeProposal(t, other, chainID, 10, 2, blockID2)
+
+	cases := []struct {
+		proposal1 *types.Proposal
+		proposal2 *types.Proposal
+		signer    types.MockPV
+		valid     bool
+	}{
+		{proposal1, makeProposal(t, proposer, chainID, 10, 2, blockID2), proposer, true},     // different block ids
+		{proposal1, makeProposal(t, proposer, chainID, 10, 2, blockID), proposer, false},     // wrong block id
+		{proposal1, makeProposal(t, proposer, "mychain2", 10, 2, blockID2), proposer, false}, // wrong chain id
+		{proposal1, makeProposal(t, proposer, chainID, 11, 2, blockID2), proposer, false},    // wrong height
+		{proposal1, makeProposal(t, proposer, chainID, 10, 3, blockID2), proposer, false},    // wrong round
+		{proposal1, badProposal, proposer, false},                                            // signed by wrong key
+		// signed by a validator who isn't the proposer
+		{makeProposal(t, other, chainID, 10, 2, blockID), badProposal, other, false},
+	}
+
+	for _, c := range cases {
+		ev := &types.DuplicateProposalEvidence{
+			ProposalA:        c.proposal1,
+			ProposalB:        c.proposal2,
+			ProposerAddress:  c.signer.PrivKey.PubKey().Address(),
+			ValidatorPower:   1,
+			TotalVotingPower: 2,
+			Timestamp:        defaultEvidenceTime,
+		}
+		if c.valid {
+			assert.Nil(t, evidence.VerifyDuplicateProposal(ev, chainID, valSet, proofHash), "evidence should be valid")
+		} else {
+			assert.NotNil(t, evidence.VerifyDuplicateProposal(ev, chainID, valSet, proofHash),
+				"evidence should be invalid")
+		}
+	}
+
+	// evidence checked against the state of the pool
+	goodEv := types.NewDuplicateProposalEvidence(proposal1, makeProposal(t, proposer, chainID, 10, 2, blockID2),
+		proposer.PrivKey.PubKey().Address(), defaultEvidenceTime, valSet)
+	badEv := types.NewDuplicateProposalEvidence(proposal1, makeProposal(t, proposer, chainID, 10, 2, blockID2),
+		proposer.PrivKey.PubKey().Address(), defaultEvidenceTime, valSet)
+	badEv.ValidatorPower = 2
+	state := sm.State{
+		ChainID:         chainID,
+		LastBlockTime:   defaultEvidenceTime.Add(1 * time.Minute),
+		LastBlockHeight: 11,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", int64(10)).Return(valSet, nil)
+	stateStore.On("LoadProofHash", int64(10)).Return(proofHash, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}})
+
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+
+	assert.NoError(t, pool.CheckEvidence(types.EvidenceList{goodEv}))
+	// evidence with a different validator power should fail
+	assert.Error(t, pool.CheckEvidence(types.EvidenceList{badEv}))
+}
+
+func makeProposal(t *testing.T, val types.PrivValidator, chainID string, height int64, round int32,
+	blockID types.BlockID) *types.Proposal {
+	proposal := types.NewProposal(height, round, -1, blockID)
+	proposal.Timestamp = defaultEvidenceTime
+	p := proposal.ToProto()
+	require.NoError(t, val.SignProposal(chainID, p))
+	proposal.Signature = p.Signature
+	return proposal
+}
+
 func makeLunaticEvidence(
 	t *testing.T,
 	height, commonHeight int64,
@@ -0,0 +1,18 @@
+/*
+Package keyrotation handles the gossiping of key rotations, with which validators replace their
+consensus key, from their announcement to block proposal. For the key rotation itself refer to the
+`key_rotation.go` file in the types package.
+
+Key rotations aren't evidence: they're gossiped on their own channel and committed in the
+KeyRotations of a block, which are applied to the validator set after the next one.
+
+The pool keeps the valid key rotations that aren't committed yet in the keyrotation database, from
+which the ones still valid are loaded back on restart. A key rotation is valid against the state of
+the pool (see state/validation.go#VerifyKeyRotation), so the pool verifies its key rotations again
+when a block is committed, and drops the ones the new state makes invalid, like the committed ones. Only one key rotation of a validator or to a key can be pending, since
+they couldn't be committed together.
+
+The reactor broadcasts the pending key rotations to the peers that have committed the block the
+key rotation refers to.
+*/
+package keyrotation
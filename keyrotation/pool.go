@@ -0,0 +1,233 @@
+package keyrotation
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/libs/clist"
+	"github.com/Finschia/ostracon/libs/log"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/types"
+)
+
+const (
+	baseKeyPending = byte(0x01)
+)
+
+// Pool maintains a pool of valid key rotations to be broadcasted and committed
+type Pool struct {
+	logger log.Logger
+
+	keyRotationStore dbm.DB
+	keyRotationList  *clist.CList // concurrent linked-list of key rotations
+
+	mtx sync.Mutex
+	// latest state
+	state sm.State
+	// key rotation hash -> element of keyRotationList
+	keyRotationMap map[string]*clist.CElement
+}
+
+var _ sm.KeyRotationPool = (*Pool)(nil)
+
+// NewPool creates a key rotation pool with the state of stateDB. The pending key rotations are
+// persisted to keyRotationDB, and those of an existing store that are still valid are loaded back
+// to the pool.
+func NewPool(keyRotationDB dbm.DB, stateDB sm.Store) (*Pool, error) {
+	state, err := stateDB.Load()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load state: %w", err)
+	}
+
+	pool := &Pool{
+		logger:           log.NewNopLogger(),
+		keyRotationStore: keyRotationDB,
+		keyRotationList:  clist.New(),
+		state:            state,
+		keyRotationMap:   make(map[string]*clist.CElement),
+	}
+	if err := pool.loadPendingKeyRotations(); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// SetLogger sets the Logger.
+func (pool *Pool) SetLogger(l log.Logger) {
+	pool.logger = l
+}
+
+// State returns the current state of the pool.
+func (pool *Pool) State() sm.State {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	return pool.state
+}
+
+// Size returns the number of pending key rotations.
+func (pool *Pool) Size() int {
+	return pool.keyRotationList.Len()
+}
+
+// PendingKeyRotations is used primarily as part of block proposal and returns the pending key
+// rotations, as long as they add up to maxBytes in the block.
+func (pool *Pool) PendingKeyRotations(maxBytes int64) (types.KeyRotationList, int64) {
+	var (
+		krs  types.KeyRotationList
+		size int64
+	)
+	for e := pool.keyRotationList.Front(); e != nil; e = e.Next() {
+		next := append(krs[:len(krs):len(krs)], e.Value.(*types.KeyRotation))
+		nextSize := next.ByteSize()
+		if nextSize > maxBytes {
+			break
+		}
+		krs, size = next, nextSize
+	}
+	return krs, size
+}
+
+// Update takes the new state, and drops the pending key rotations that aren't valid anymore,
+// including the committed ones: a key rotation is signed by the last key the validator announced
+// and has to be activated after the next validator set.
+func (pool *Pool) Update(state sm.State, committed types.KeyRotationList) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	// sanity check
+	if state.LastBlockHeight <= pool.state.LastBlockHeight {
+		panic(fmt.Sprintf(
+			"failed KeyRotationPool.Update new state height is less than or equal to previous state height: %d <= %d",
+			state.LastBlockHeight,
+			pool.state.LastBlockHeight,
+		))
+	}
+	pool.state = state
+
+	for e := pool.keyRotationList.Front(); e != nil; e = e.Next() {
+		kr := e.Value.(*types.KeyRotation)
+		if committed.Has(kr) {
+			pool.logger.Debug("Removing committed key rotation", "kr", kr)
+		} else if err := sm.VerifyKeyRotation(state, kr); err != nil {
+			pool.logger.Info("Removing invalid key rotation", "kr", kr, "err", err)
+		} else {
+			continue
+		}
+		pool.removePendingKeyRotation(e)
+	}
+}
+
+// AddKeyRotation checks the key rotation is valid and adds it to the pool. A key rotation of a
+// validator or to a key of a pending one is ignored.
+func (pool *Pool) AddKeyRotation(kr *types.KeyRotation) error {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if pool.isPending(kr) {
+		return nil
+	}
+	if err := sm.VerifyKeyRotation(pool.state, kr); err != nil {
+		return fmt.Errorf("invalid key rotation: %w", err)
+	}
+
+	krpb, err := kr.ToProto()
+	if err != nil {
+		return fmt.Errorf("unable to convert to proto, err: %w", err)
+	}
+	krBytes, err := krpb.Marshal()
+	if err != nil {
+		return fmt.Errorf("unable to marshal key rotation: %w", err)
+	}
+	if err := pool.keyRotationStore.Set(keyPending(kr), krBytes); err != nil {
+		return fmt.Errorf("can't persist key rotation: %w", err)
+	}
+
+	pool.keyRotationMap[string(kr.Hash())] = pool.keyRotationList.PushBack(kr)
+	pool.logger.Info("Verified new key rotation", "kr", kr)
+	return nil
+}
+
+// isPending returns true if kr, or a conflicting key rotation, is already pending.
+func (pool *Pool) isPending(kr *types.KeyRotation) bool {
+	if _, ok := pool.keyRotationMap[string(kr.Hash())]; ok {
+		pool.logger.Debug("Key rotation already pending, ignoring this one", "kr", kr)
+		return true
+	}
+	for e := pool.keyRotationList.Front(); e != nil; e = e.Next() {
+		pending := e.Value.(*types.KeyRotation)
+		if bytes.Equal(pending.ValidatorAddress, kr.ValidatorAddress) || pending.NewPubKey.Equals(kr.NewPubKey) {
+			pool.logger.Debug("Conflicting key rotation already pending, ignoring this one", "kr", kr)
+			return true
+		}
+	}
+	return false
+}
+
+func (pool *Pool) removePendingKeyRotation(e *clist.CElement) {
+	kr := e.Value.(*types.KeyRotation)
+	if err := pool.keyRotationStore.Delete(keyPending(kr)); err != nil {
+		pool.logger.Error("Unable to delete pending key rotation", "err", err)
+	}
+	pool.keyRotationList.Remove(e)
+	e.DetachPrev()
+	delete(pool.keyRotationMap, string(kr.Hash()))
+}
+
+// loadPendingKeyRotations adds the key rotations of the store, in the event of a restart, to the
+// pool, and deletes the ones that aren't valid anymore.
+func (pool *Pool) loadPendingKeyRotations() error {
+	iter, err := dbm.IteratePrefix(pool.keyRotationStore, []byte{baseKeyPending})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	var stale [][]byte
+	for ; iter.Valid(); iter.Next() {
+		kr, err := bytesToKeyRotation(iter.Value())
+		if err == nil && !pool.isPending(kr) {
+			err = sm.VerifyKeyRotation(pool.state, kr)
+			if err == nil {
+				pool.keyRotationMap[string(kr.Hash())] = pool.keyRotationList.PushBack(kr)
+				continue
+			}
+		}
+		pool.logger.Info("Removing stale key rotation", "err", err)
+		stale = append(stale, append([]byte{}, iter.Key()...))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	for _, key := range stale {
+		if err := pool.keyRotationStore.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KeyRotationFront goes to the first key rotation in the clist
+func (pool *Pool) KeyRotationFront() *clist.CElement {
+	return pool.keyRotationList.Front()
+}
+
+// KeyRotationWaitChan is a channel that closes once the first key rotation in the list is there.
+// i.e the front is not nil
+func (pool *Pool) KeyRotationWaitChan() <-chan struct{} {
+	return pool.keyRotationList.WaitChan()
+}
+
+func bytesToKeyRotation(krBytes []byte) (*types.KeyRotation, error) {
+	var krpb ocproto.KeyRotation
+	if err := krpb.Unmarshal(krBytes); err != nil {
+		return nil, err
+	}
+	return types.KeyRotationFromProto(&krpb)
+}
+
+func keyPending(kr *types.KeyRotation) []byte {
+	return append([]byte{baseKeyPending}, kr.Hash()...)
+}
@@ -0,0 +1,150 @@
+package keyrotation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/keyrotation"
+	"github.com/Finschia/ostracon/libs/log"
+	sm "github.com/Finschia/ostracon/state"
+	smmocks "github.com/Finschia/ostracon/state/mocks"
+	"github.com/Finschia/ostracon/types"
+)
+
+const chainID = "test_chain"
+
+var defaultBlockTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func makeState(height int64, valSet *types.ValidatorSet) sm.State {
+	return sm.State{
+		ChainID:         chainID,
+		LastBlockHeight: height,
+		LastBlockTime:   defaultBlockTime,
+		Validators:      valSet,
+		NextValidators:  valSet.CopyIncrementProposerPriority(1),
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+}
+
+func makePool(t *testing.T, state sm.State) *keyrotation.Pool {
+	return makePoolWithDB(t, dbm.NewMemDB(), state)
+}
+
+func makePoolWithDB(t *testing.T, db dbm.DB, state sm.State) *keyrotation.Pool {
+	stateStore := &smmocks.Store{}
+	stateStore.On("Load").Return(state, nil)
+	pool, err := keyrotation.NewPool(db, stateStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+	return pool
+}
+
+func makeKeyRotation(t *testing.T, val types.MockPV, newPrivKey crypto.PrivKey, activationHeight,
+	blockHeight int64) *types.KeyRotation {
+	kr, err := types.NewKeyRotation(chainID, val.PrivKey.PubKey().Address(), val.PrivKey, newPrivKey,
+		activationHeight, blockHeight, defaultBlockTime)
+	require.NoError(t, err)
+	return kr
+}
+
+func TestKeyRotationPoolBasic(t *testing.T) {
+	val, val2 := types.NewMockPV(), types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1), val2.ExtractIntoValidator(1)})
+	pool := makePool(t, makeState(10, valSet))
+
+	krs, size := pool.PendingKeyRotations(1000)
+	assert.Empty(t, krs)
+	assert.Zero(t, size)
+
+	kr := makeKeyRotation(t, val, ed25519.GenPrivKey(), 13, 10)
+	require.NoError(t, pool.AddKeyRotation(kr))
+	assert.Equal(t, 1, pool.Size())
+
+	krs, size = pool.PendingKeyRotations(1000)
+	assert.Equal(t, types.KeyRotationList{kr}, krs)
+	assert.Equal(t, krs.ByteSize(), size)
+
+	// not enough room in the block
+	krs, size = pool.PendingKeyRotations(size - 1)
+	assert.Empty(t, krs)
+	assert.Zero(t, size)
+
+	// a duplicate, another rotation of the validator and one to the same key are ignored
+	require.NoError(t, pool.AddKeyRotation(kr))
+	require.NoError(t, pool.AddKeyRotation(makeKeyRotation(t, val, ed25519.GenPrivKey(), 14, 10)))
+	newKr2, err := types.NewKeyRotation(chainID, val2.PrivKey.PubKey().Address(), val2.PrivKey,
+		ed25519.GenPrivKey(), 13, 10, defaultBlockTime)
+	require.NoError(t, err)
+	newKr2.NewPubKey = kr.NewPubKey
+	require.NoError(t, pool.AddKeyRotation(newKr2))
+	assert.Equal(t, 1, pool.Size())
+
+	// invalid rotations are refused
+	assert.Error(t, pool.AddKeyRotation(makeKeyRotation(t, val2, ed25519.GenPrivKey(), 12, 10)))
+	assert.Error(t, pool.AddKeyRotation(makeKeyRotation(t, types.NewMockPV(), ed25519.GenPrivKey(), 13, 10)))
+	assert.Equal(t, 1, pool.Size())
+
+	kr2 := makeKeyRotation(t, val2, ed25519.GenPrivKey(), 13, 10)
+	require.NoError(t, pool.AddKeyRotation(kr2))
+	assert.Equal(t, 2, pool.Size())
+	krs, _ = pool.PendingKeyRotations(1000)
+	assert.Equal(t, types.KeyRotationList{kr, kr2}, krs)
+}
+
+func TestKeyRotationPoolUpdate(t *testing.T) {
+	val, val2 := types.NewMockPV(), types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1), val2.ExtractIntoValidator(1)})
+	pool := makePool(t, makeState(10, valSet))
+
+	kr := makeKeyRotation(t, val, ed25519.GenPrivKey(), 13, 10)
+	kr2 := makeKeyRotation(t, val2, ed25519.GenPrivKey(), 14, 10)
+	require.NoError(t, pool.AddKeyRotation(kr))
+	require.NoError(t, pool.AddKeyRotation(kr2))
+
+	// the committed rotation is dropped
+	pool.Update(makeState(11, valSet), types.KeyRotationList{kr})
+	krs, _ := pool.PendingKeyRotations(1000)
+	assert.Equal(t, types.KeyRotationList{kr2}, krs)
+
+	// the rotation can't be committed before its activation height anymore
+	pool.Update(makeState(12, valSet), nil)
+	assert.Zero(t, pool.Size())
+	assert.EqualValues(t, 12, pool.State().LastBlockHeight)
+
+	assert.Panics(t, func() { pool.Update(makeState(12, valSet), nil) })
+}
+
+func TestKeyRotationPoolRestart(t *testing.T) {
+	val, val2 := types.NewMockPV(), types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1), val2.ExtractIntoValidator(1)})
+	db := dbm.NewMemDB()
+	pool := makePoolWithDB(t, db, makeState(10, valSet))
+
+	kr := makeKeyRotation(t, val, ed25519.GenPrivKey(), 13, 10)
+	kr2 := makeKeyRotation(t, val2, ed25519.GenPrivKey(), 14, 10)
+	require.NoError(t, pool.AddKeyRotation(kr))
+	require.NoError(t, pool.AddKeyRotation(kr2))
+
+	// the pending rotations are loaded back
+	pool = makePoolWithDB(t, db, makeState(10, valSet))
+	krs, _ := pool.PendingKeyRotations(1000)
+	assert.ElementsMatch(t, types.KeyRotationList{kr, kr2}, krs)
+
+	// the committed rotation isn't
+	pool.Update(makeState(11, valSet), types.KeyRotationList{kr})
+	pool = makePoolWithDB(t, db, makeState(11, valSet))
+	krs, _ = pool.PendingKeyRotations(1000)
+	assert.Equal(t, types.KeyRotationList{kr2}, krs)
+
+	// nor the one the state of the restart makes invalid, which is deleted
+	pool = makePoolWithDB(t, db, makeState(13, valSet))
+	assert.Zero(t, pool.Size())
+	pool = makePoolWithDB(t, db, makeState(11, valSet))
+	assert.Zero(t, pool.Size())
+}
@@ -0,0 +1,173 @@
+package keyrotation
+
+import (
+	"fmt"
+	"time"
+
+	clist "github.com/Finschia/ostracon/libs/clist"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/p2p"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
+	"github.com/Finschia/ostracon/types"
+)
+
+const (
+	KeyRotationChannel = byte(0x39)
+
+	maxMsgSize = 65536 // a few key rotations
+
+	// broadcast all uncommitted key rotations this often. Like evidence, they should be committed
+	// in the very next block.
+	broadcastKeyRotationIntervalS = 10
+	// If a message fails wait this much before sending it again
+	peerRetryMessageIntervalMS = 100
+)
+
+// Reactor handles key rotation broadcasting amongst peers.
+type Reactor struct {
+	p2p.BaseReactor
+	pool *Pool
+}
+
+// NewReactor returns a new Reactor with the given pool.
+func NewReactor(pool *Pool, async bool, recvBufSize int) *Reactor {
+	krR := &Reactor{
+		pool: pool,
+	}
+	krR.BaseReactor = *p2p.NewBaseReactor("KeyRotation", krR, async, recvBufSize)
+	return krR
+}
+
+// SetLogger sets the Logger on the reactor and the underlying pool.
+func (krR *Reactor) SetLogger(l log.Logger) {
+	krR.Logger = l
+	krR.pool.SetLogger(l)
+}
+
+// GetChannels implements Reactor.
+// It returns the list of channels for this reactor.
+func (krR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  KeyRotationChannel,
+			Priority:            6,
+			RecvMessageCapacity: maxMsgSize,
+		},
+	}
+}
+
+// AddPeer implements Reactor.
+func (krR *Reactor) AddPeer(peer p2p.Peer) {
+	go krR.broadcastKeyRotationRoutine(peer)
+}
+
+// Receive implements Reactor.
+// It adds any received key rotation to the pool.
+func (krR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	krs, err := decodeMsg(msgBytes)
+	if err != nil {
+		krR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		krR.Switch.MarkPeerAsBad(src, err)
+		return
+	}
+
+	for _, kr := range krs {
+		// a key rotation valid for the peer may not be valid for us anymore, if we're ahead of
+		// it, so the peer isn't punished for it
+		if err := krR.pool.AddKeyRotation(kr); err != nil {
+			krR.Logger.Info("Key rotation has not been added", "kr", kr, "err", err)
+		}
+	}
+}
+
+// Modeled after the evidence routine.
+func (krR *Reactor) broadcastKeyRotationRoutine(peer p2p.Peer) {
+	var next *clist.CElement
+	for {
+		// This happens because the CElement we were looking at got garbage
+		// collected (removed). That is, .NextWait() returned nil. Go ahead and
+		// start from the beginning.
+		if next == nil {
+			select {
+			case <-krR.pool.KeyRotationWaitChan(): // Wait until a key rotation is available
+				if next = krR.pool.KeyRotationFront(); next == nil {
+					continue
+				}
+			case <-peer.Quit():
+				return
+			case <-krR.Quit():
+				return
+			}
+		} else if !peer.IsRunning() || !krR.IsRunning() {
+			return
+		}
+
+		kr := next.Value.(*types.KeyRotation)
+		if krR.peerCanVerify(peer, kr) {
+			krR.Logger.Debug("Gossiping key rotation to peer", "kr", kr, "peer", peer)
+			msgBytes, err := encodeMsg(types.KeyRotationList{kr})
+			if err != nil {
+				panic(err)
+			}
+			success := peer.Send(KeyRotationChannel, msgBytes)
+			if !success {
+				time.Sleep(peerRetryMessageIntervalMS * time.Millisecond)
+				continue
+			}
+		}
+
+		afterCh := time.After(time.Second * broadcastKeyRotationIntervalS)
+		select {
+		case <-afterCh:
+			// start from the beginning every tick.
+			next = nil
+		case <-next.NextWaitChan():
+			// see the start of the for loop for nil check
+			next = next.Next()
+		case <-peer.Quit():
+			return
+		case <-krR.Quit():
+			return
+		}
+	}
+}
+
+// peerCanVerify returns true if the peer committed the block the key rotation refers to.
+func (krR *Reactor) peerCanVerify(peer p2p.Peer, kr *types.KeyRotation) bool {
+	peerState, ok := peer.Get(types.PeerStateKey).(PeerState)
+	if !ok {
+		// Peer does not have a state yet. We set it in the consensus reactor.
+		return false
+	}
+	return peerState.GetHeight() > kr.BlockHeight
+}
+
+// PeerState describes the state of a peer.
+type PeerState interface {
+	GetHeight() int64
+}
+
+// encodeMsg returns the byte encoding of the key rotations.
+func encodeMsg(krs types.KeyRotationList) ([]byte, error) {
+	pb, err := krs.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return pb.Marshal()
+}
+
+// decodeMsg returns the key rotations of the bytes.
+func decodeMsg(bz []byte) (types.KeyRotationList, error) {
+	pb := ocproto.KeyRotationList{}
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	krs, err := types.KeyRotationListFromProto(&pb)
+	if err != nil {
+		return nil, err
+	}
+	if err := krs.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid key rotations: %w", err)
+	}
+	return krs, nil
+}
@@ -0,0 +1,95 @@
+package keyrotation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/keyrotation"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/p2p"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/types"
+)
+
+func makeAndConnectReactorsAndPools(t *testing.T, config *cfg.Config, states []sm.State) ([]*keyrotation.Reactor,
+	[]*keyrotation.Pool) {
+	N := len(states)
+
+	reactors := make([]*keyrotation.Reactor, N)
+	pools := make([]*keyrotation.Pool, N)
+	for i := 0; i < N; i++ {
+		pools[i] = makePool(t, states[i])
+		reactors[i] = keyrotation.NewReactor(pools[i], config.P2P.RecvAsync, config.P2P.EvidenceRecvBufSize)
+		reactors[i].SetLogger(log.TestingLogger().With("validator", i))
+	}
+
+	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch, config *cfg.P2PConfig) *p2p.Switch {
+		s.AddReactor("KEYROTATION", reactors[i])
+		return s
+	}, p2p.Connect2Switches)
+	t.Cleanup(func() {
+		for _, r := range reactors {
+			if err := r.Switch.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	return reactors, pools
+}
+
+type peerState struct {
+	height int64
+}
+
+func (ps peerState) GetHeight() int64 {
+	return ps.height
+}
+
+func TestReactorBroadcastKeyRotation(t *testing.T) {
+	config := cfg.TestConfig()
+
+	val := types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1)})
+	reactors, pools := makeAndConnectReactorsAndPools(t, config,
+		[]sm.State{makeState(10, valSet), makeState(10, valSet), makeState(10, valSet)})
+
+	// the second peer of the first reactor hasn't committed the block of the key rotation yet
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{11})
+		}
+	}
+	var lagging p2p.Peer
+	for _, peer := range reactors[0].Switch.Peers().List() {
+		if peer.ID() == reactors[2].Switch.NodeInfo().ID() {
+			lagging = peer
+		}
+	}
+	require.NotNil(t, lagging)
+	lagging.Set(types.PeerStateKey, peerState{10})
+	for _, peer := range reactors[1].Switch.Peers().List() {
+		if peer.ID() == reactors[2].Switch.NodeInfo().ID() {
+			peer.Set(types.PeerStateKey, peerState{10})
+		}
+	}
+
+	kr := makeKeyRotation(t, val, ed25519.GenPrivKey(), 13, 10)
+	require.NoError(t, pools[0].AddKeyRotation(kr))
+
+	require.Eventually(t, func() bool {
+		krs, _ := pools[1].PendingKeyRotations(1000)
+		return len(krs) == 1 && assert.ObjectsAreEqual(kr.Hash(), krs[0].Hash())
+	}, 10*time.Second, 10*time.Millisecond)
+
+	time.Sleep(500 * time.Millisecond)
+	assert.Zero(t, pools[2].Size())
+
+	// peers should still be connected
+	assert.Equal(t, 2, reactors[1].Switch.Peers().Size())
+}
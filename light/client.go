@@ -125,6 +125,29 @@ func MaxBlockLag(d time.Duration) Option {
 	}
 }
 
+// EvidencePool is the part of a node's evidence pool the light client needs to broadcast evidence
+// over the p2p network.
+type EvidencePool interface {
+	AddEvidence(ev types.Evidence) error
+}
+
+// BroadcastEvidence adds the evidence of detected attacks to the given evidence pool, in addition
+// to reporting it to the primary and witnesses. When the light client runs in-process with a
+// node, passing the node's evidence pool gossips the evidence to its peers.
+func BroadcastEvidence(pool EvidencePool) Option {
+	return func(c *Client) {
+		c.evidencePool = pool
+	}
+}
+
+// RecordDivergences saves a record of every divergence detected between the primary and a
+// witness to the given store, for later inspection.
+func RecordDivergences(s *DivergenceStore) Option {
+	return func(c *Client) {
+		c.divergenceStore = s
+	}
+}
+
 // Client represents a light client, connected to a single chain, which gets
 // light blocks from a primary provider, verifies them either sequentially or by
 // skipping some and stores them in a trusted store (usually, a local FS).
@@ -155,6 +178,10 @@ type Client struct {
 	pruningSize uint16
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
+	// See BroadcastEvidence option
+	evidencePool EvidencePool
+	// See RecordDivergences option
+	divergenceStore *DivergenceStore
 
 	quit chan struct{}
 
@@ -867,6 +894,35 @@ func (c *Client) Witnesses() []provider.Provider {
 	return c.witnesses
 }
 
+// AddWitness adds a witness provider, e.g. to replace a witness that was
+// removed for misbehaving or to rotate witnesses.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) AddWitness(witness provider.Provider) error {
+	if witness.ChainID() != c.chainID {
+		return fmt.Errorf("witness %v is on another chain %s, expected %s", witness, witness.ChainID(), c.chainID)
+	}
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	c.witnesses = append(c.witnesses, witness)
+	return nil
+}
+
+// RemoveWitness removes the given witness provider, as returned by
+// Witnesses(). It returns ErrNoWitnesses if it is the last witness.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) RemoveWitness(witness provider.Provider) error {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	for i, w := range c.witnesses {
+		if w == witness {
+			return c.removeWitnesses([]int{i})
+		}
+	}
+	return fmt.Errorf("witness %v not found", witness)
+}
+
 // Cleanup removes all the data (headers and validator sets) stored. Note: the
 // client must be stopped at this point.
 func (c *Client) Cleanup() error {
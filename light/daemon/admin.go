@@ -0,0 +1,148 @@
+package daemon
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Finschia/ostracon/libs/log"
+	tmmath "github.com/Finschia/ostracon/libs/math"
+	"github.com/Finschia/ostracon/light"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+)
+
+// ResultChains is the result of the chains admin method.
+type ResultChains struct {
+	Chains []*ChainStatus `json:"chains"`
+}
+
+// ResultEmpty is the result of the admin methods that only return an error, if any.
+type ResultEmpty struct{}
+
+// AdminRoutes returns the routes of the admin API, which manages the chains tracked by the
+// daemon. They should only be served through AdminHandler.
+func AdminRoutes(d *Daemon) map[string]*rpcserver.RPCFunc {
+	return map[string]*rpcserver.RPCFunc{
+		"chains":       rpcserver.NewRPCFunc(makeChainsFunc(d), ""),
+		"chain_status": rpcserver.NewRPCFunc(makeChainStatusFunc(d), "chain_id"),
+		"add_chain": rpcserver.NewRPCFunc(makeAddChainFunc(d),
+			"chain_id,primary,witnesses,trusting_period,height,hash,trust_level,sequential"),
+		"remove_chain":   rpcserver.NewRPCFunc(makeRemoveChainFunc(d), "chain_id"),
+		"add_witness":    rpcserver.NewRPCFunc(makeAddWitnessFunc(d), "chain_id,address"),
+		"remove_witness": rpcserver.NewRPCFunc(makeRemoveWitnessFunc(d), "chain_id,address"),
+	}
+}
+
+// AdminHandler returns the handler serving the admin API over JSON-RPC. Only POST requests
+// carrying the given bearer token are served, and requests made by browsers, i.e. with an Origin
+// or Sec-Fetch-Site header, are rejected, so that web pages can't forge admin requests. The GET
+// endpoints of the other RPC servers are not provided.
+func AdminHandler(d *Daemon, token string, logger log.Logger) (http.Handler, error) {
+	if token == "" {
+		return nil, errors.New("the admin API requires a token")
+	}
+	mux := http.NewServeMux()
+	rpcserver.RegisterJSONRPCFuncs(mux, AdminRoutes(d), logger)
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method != http.MethodPost:
+			http.Error(w, "only POST requests are allowed", http.StatusMethodNotAllowed)
+		case r.Header.Get("Origin") != "" || r.Header.Get("Sec-Fetch-Site") != "":
+			http.Error(w, "requests from browsers are not allowed", http.StatusForbidden)
+		case subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1:
+			http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+		default:
+			mux.ServeHTTP(w, r)
+		}
+	}), nil
+}
+
+type rpcChainsFunc func(ctx *rpctypes.Context) (*ResultChains, error)
+
+func makeChainsFunc(d *Daemon) rpcChainsFunc {
+	return func(ctx *rpctypes.Context) (*ResultChains, error) {
+		return &ResultChains{Chains: d.Chains()}, nil
+	}
+}
+
+type rpcChainStatusFunc func(ctx *rpctypes.Context, chainID string) (*ChainStatus, error)
+
+func makeChainStatusFunc(d *Daemon) rpcChainStatusFunc {
+	return func(ctx *rpctypes.Context, chainID string) (*ChainStatus, error) {
+		return d.Status(chainID)
+	}
+}
+
+type rpcAddChainFunc func(ctx *rpctypes.Context, chainID, primary, witnesses, trustingPeriod string,
+	height int64, hash, trustLevel string, sequential bool) (*ChainStatus, error)
+
+// makeAddChainFunc returns the add_chain method. Witnesses are comma separated, the trusting
+// period is a duration like 168h, the hash is hex encoded and the trust level is a fraction like
+// 1/3, which defaults to light.DefaultTrustLevel.
+func makeAddChainFunc(d *Daemon) rpcAddChainFunc {
+	return func(ctx *rpctypes.Context, chainID, primary, witnesses, trustingPeriod string,
+		height int64, hash, trustLevel string, sequential bool) (*ChainStatus, error) {
+
+		cfg := ChainConfig{
+			ChainID:       chainID,
+			Primary:       primary,
+			TrustedHeight: height,
+			TrustLevel:    light.DefaultTrustLevel,
+			Sequential:    sequential,
+		}
+		if witnesses != "" {
+			cfg.Witnesses = strings.Split(witnesses, ",")
+		}
+		var err error
+		if cfg.TrustingPeriod, err = time.ParseDuration(trustingPeriod); err != nil {
+			return nil, fmt.Errorf("can't parse trusting period: %w", err)
+		}
+		if cfg.TrustedHash, err = hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("can't parse hash: %w", err)
+		}
+		if trustLevel != "" {
+			if cfg.TrustLevel, err = tmmath.ParseFraction(trustLevel); err != nil {
+				return nil, fmt.Errorf("can't parse trust level: %w", err)
+			}
+		}
+
+		if err := d.AddChain(ctx.Context(), cfg); err != nil {
+			return nil, err
+		}
+		return d.Status(chainID)
+	}
+}
+
+type rpcRemoveChainFunc func(ctx *rpctypes.Context, chainID string) (*ResultEmpty, error)
+
+func makeRemoveChainFunc(d *Daemon) rpcRemoveChainFunc {
+	return func(ctx *rpctypes.Context, chainID string) (*ResultEmpty, error) {
+		return &ResultEmpty{}, d.RemoveChain(chainID)
+	}
+}
+
+type rpcWitnessFunc func(ctx *rpctypes.Context, chainID, address string) (*ChainStatus, error)
+
+func makeAddWitnessFunc(d *Daemon) rpcWitnessFunc {
+	return func(ctx *rpctypes.Context, chainID, address string) (*ChainStatus, error) {
+		if err := d.AddWitness(chainID, address); err != nil {
+			return nil, err
+		}
+		return d.Status(chainID)
+	}
+}
+
+func makeRemoveWitnessFunc(d *Daemon) rpcWitnessFunc {
+	return func(ctx *rpctypes.Context, chainID, address string) (*ChainStatus, error) {
+		if err := d.RemoveWitness(chainID, address); err != nil {
+			return nil, err
+		}
+		return d.Status(chainID)
+	}
+}
@@ -0,0 +1,524 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmbytes "github.com/Finschia/ostracon/libs/bytes"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	"github.com/Finschia/ostracon/libs/log"
+	tmmath "github.com/Finschia/ostracon/libs/math"
+	"github.com/Finschia/ostracon/libs/service"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/light"
+	"github.com/Finschia/ostracon/light/provider"
+	lhttp "github.com/Finschia/ostracon/light/provider/http"
+	lproxy "github.com/Finschia/ostracon/light/proxy"
+	lrpc "github.com/Finschia/ostracon/light/rpc"
+	dbs "github.com/Finschia/ostracon/light/store/db"
+	rpcclient "github.com/Finschia/ostracon/rpc/client"
+	rpchttp "github.com/Finschia/ostracon/rpc/client/http"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	"github.com/Finschia/ostracon/types"
+)
+
+const (
+	// defaultUpdatePeriod is how often each chain's light client is updated to the latest header.
+	defaultUpdatePeriod = 5 * time.Second
+	// initTimeout is how long to wait for a new chain's light client to be initialized.
+	initTimeout = time.Minute
+)
+
+var (
+	// ErrChainNotFound is returned if the daemon doesn't track the chain.
+	ErrChainNotFound = errors.New("chain not found")
+	// ErrChainExists is returned when adding a chain the daemon already tracks.
+	ErrChainExists = errors.New("chain already exists")
+)
+
+// ChainConfig is the configuration of a chain tracked by the daemon.
+type ChainConfig struct {
+	ChainID   string   `json:"chain_id"`
+	Primary   string   `json:"primary"`
+	Witnesses []string `json:"witnesses"`
+
+	// Trust options. The trusted height and hash are only required when the chain has no trusted
+	// light blocks yet.
+	TrustingPeriod time.Duration    `json:"trusting_period"`
+	TrustedHeight  int64            `json:"trusted_height"`
+	TrustedHash    tmbytes.HexBytes `json:"trusted_hash"`
+	TrustLevel     tmmath.Fraction  `json:"trust_level"`
+	Sequential     bool             `json:"sequential"`
+}
+
+// ValidateBasic performs basic validation.
+func (cfg ChainConfig) ValidateBasic() error {
+	if cfg.ChainID == "" {
+		return errors.New("empty chain ID")
+	}
+	if len(cfg.ChainID) > types.MaxChainIDLen {
+		return fmt.Errorf("chain ID is too long; got: %d, max: %d", len(cfg.ChainID), types.MaxChainIDLen)
+	}
+	if strings.Contains(cfg.ChainID, "/") {
+		return errors.New("chain ID can't contain /")
+	}
+	if cfg.Primary == "" {
+		return errors.New("empty primary")
+	}
+	if len(cfg.Witnesses) == 0 {
+		return errors.New("at least one witness is required")
+	}
+	if cfg.TrustingPeriod <= 0 {
+		return errors.New("trusting period must be positive")
+	}
+	if cfg.TrustedHeight < 0 {
+		return errors.New("negative trusted height")
+	}
+	if !cfg.Sequential {
+		if err := light.ValidateTrustLevel(cfg.TrustLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChainStatus is the verification status of a chain tracked by the daemon.
+type ChainStatus struct {
+	ChainID   string   `json:"chain_id"`
+	Primary   string   `json:"primary"`
+	Witnesses []string `json:"witnesses"`
+
+	LatestTrustedHeight int64            `json:"latest_trusted_height"`
+	LatestTrustedHash   tmbytes.HexBytes `json:"latest_trusted_hash"`
+	LatestTrustedTime   time.Time        `json:"latest_trusted_time"`
+
+	// LastUpdate is the time of the last attempt to update the light client, and LastError the
+	// error it failed with, if any.
+	LastUpdate time.Time `json:"last_update"`
+	LastError  string    `json:"last_error,omitempty"`
+}
+
+// Daemon tracks several chains in one process, each with its own light client, and serves a
+// verifying proxy for each of them under the /<chain ID>/ path. Chains are managed through the
+// admin API (see AdminRoutes), and persisted in the database so that they are tracked again after
+// a restart.
+type Daemon struct {
+	service.BaseService
+
+	db           dbm.DB
+	updatePeriod time.Duration
+	rpcConfig    *rpcserver.Config
+
+	// for tests
+	newProvider  func(chainID, address string) (provider.Provider, error)
+	newRPCClient func(address string) (rpcclient.Client, error)
+
+	mtx    tmsync.RWMutex
+	chains map[string]*chain
+}
+
+// Option sets a parameter for the daemon.
+type Option func(*Daemon)
+
+// UpdatePeriod sets how often each chain's light client is updated to the latest header.
+// Default: 5s.
+func UpdatePeriod(period time.Duration) Option {
+	return func(d *Daemon) {
+		d.updatePeriod = period
+	}
+}
+
+// NewDaemon creates a daemon storing its chains and their light blocks in the given database.
+// The RPC config is used for the proxied chains.
+func NewDaemon(db dbm.DB, rpcConfig *rpcserver.Config, options ...Option) *Daemon {
+	d := &Daemon{
+		db:           db,
+		updatePeriod: defaultUpdatePeriod,
+		rpcConfig:    rpcConfig,
+		newProvider:  lhttp.New,
+		newRPCClient: func(address string) (rpcclient.Client, error) {
+			return rpchttp.NewWithTimeout(address, "/websocket", uint(rpcConfig.WriteTimeout.Seconds()))
+		},
+		chains: make(map[string]*chain),
+	}
+	d.BaseService = *service.NewBaseService(nil, "LightDaemon", d)
+	for _, option := range options {
+		option(d)
+	}
+	return d
+}
+
+// OnStart implements service.Service. It starts tracking the chains saved in the database. Chains
+// that fail to start are logged and skipped, so that they can be fixed through the admin API.
+func (d *Daemon) OnStart() error {
+	configs, err := d.loadChainConfigs()
+	if err != nil {
+		return err
+	}
+	for _, cfg := range configs {
+		ctx, cancel := context.WithTimeout(context.Background(), initTimeout)
+		c, err := d.startChain(ctx, cfg)
+		cancel()
+		if err != nil {
+			d.Logger.Error("Failed to start chain", "chainID", cfg.ChainID, "err", err)
+			continue
+		}
+		d.mtx.Lock()
+		d.chains[cfg.ChainID] = c
+		d.mtx.Unlock()
+	}
+	return nil
+}
+
+// OnStop implements service.Service.
+func (d *Daemon) OnStop() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for _, c := range d.chains {
+		c.stop()
+	}
+}
+
+// AddChain starts tracking a new chain and saves it, so that it is tracked again after a restart.
+func (d *Daemon) AddChain(ctx context.Context, cfg ChainConfig) error {
+	if err := cfg.ValidateBasic(); err != nil {
+		return err
+	}
+	d.mtx.RLock()
+	_, ok := d.chains[cfg.ChainID]
+	d.mtx.RUnlock()
+	if ok {
+		return ErrChainExists
+	}
+
+	// Initializing the light client takes a few requests, so don't block the other chains.
+	c, err := d.startChain(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if _, ok := d.chains[cfg.ChainID]; ok {
+		c.stop()
+		return ErrChainExists
+	}
+	if err := d.saveChainConfig(cfg); err != nil {
+		c.stop()
+		return err
+	}
+	d.chains[cfg.ChainID] = c
+	d.Logger.Info("Added chain", "chainID", cfg.ChainID)
+	return nil
+}
+
+// RemoveChain stops tracking the chain, and deletes its configuration and light blocks.
+func (d *Daemon) RemoveChain(chainID string) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	c, ok := d.chains[chainID]
+	if !ok {
+		return ErrChainNotFound
+	}
+	c.stop()
+	delete(d.chains, chainID)
+
+	if err := d.db.DeleteSync(chainConfigKey(chainID)); err != nil {
+		return fmt.Errorf("failed to delete chain config: %w", err)
+	}
+	if err := c.client.Cleanup(); err != nil {
+		return fmt.Errorf("failed to delete light blocks: %w", err)
+	}
+	d.Logger.Info("Removed chain", "chainID", chainID)
+	return nil
+}
+
+// AddWitness adds a witness to the chain.
+func (d *Daemon) AddWitness(chainID, address string) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	c, ok := d.chains[chainID]
+	if !ok {
+		return ErrChainNotFound
+	}
+	for _, w := range c.config.Witnesses {
+		if w == address {
+			return fmt.Errorf("witness %s already exists", address)
+		}
+	}
+
+	witness, err := d.newProvider(chainID, address)
+	if err != nil {
+		return err
+	}
+	if err := c.client.AddWitness(witness); err != nil {
+		return err
+	}
+	c.witnesses[address] = witness
+	c.config.Witnesses = append(c.config.Witnesses, address)
+	return d.saveChainConfig(c.config)
+}
+
+// RemoveWitness removes a witness from the chain. The last witness can't be removed.
+func (d *Daemon) RemoveWitness(chainID, address string) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	c, ok := d.chains[chainID]
+	if !ok {
+		return ErrChainNotFound
+	}
+	witness, ok := c.witnesses[address]
+	if !ok {
+		return fmt.Errorf("witness %s not found", address)
+	}
+
+	// The light client may have already removed the witness for misbehaving.
+	for _, w := range c.client.Witnesses() {
+		if w == witness {
+			if err := c.client.RemoveWitness(witness); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	delete(c.witnesses, address)
+	for i, w := range c.config.Witnesses {
+		if w == address {
+			c.config.Witnesses = append(c.config.Witnesses[:i:i], c.config.Witnesses[i+1:]...)
+			break
+		}
+	}
+	return d.saveChainConfig(c.config)
+}
+
+// Status returns the verification status of the chain.
+func (d *Daemon) Status(chainID string) (*ChainStatus, error) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	c, ok := d.chains[chainID]
+	if !ok {
+		return nil, ErrChainNotFound
+	}
+	return c.status(), nil
+}
+
+// Chains returns the verification status of all tracked chains.
+func (d *Daemon) Chains() []*ChainStatus {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	statuses := make([]*ChainStatus, 0, len(d.chains))
+	for _, c := range d.chains {
+		statuses = append(statuses, c.status())
+	}
+	return statuses
+}
+
+// ServeHTTP serves the proxy of the chain given by the first element of the path, e.g.
+// /chain-1/status.
+func (d *Daemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	chainID := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0]
+	d.mtx.RLock()
+	c, ok := d.chains[chainID]
+	d.mtx.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	http.StripPrefix("/"+chainID, c.handler).ServeHTTP(w, r)
+}
+
+// startChain creates the light client and proxy of the chain, and starts updating it.
+func (d *Daemon) startChain(ctx context.Context, cfg ChainConfig) (*chain, error) {
+	logger := d.Logger.With("chainID", cfg.ChainID)
+	primary, err := d.newProvider(cfg.ChainID, cfg.Primary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create primary: %w", err)
+	}
+	witnesses := make(map[string]provider.Provider, len(cfg.Witnesses))
+	witnessList := make([]provider.Provider, 0, len(cfg.Witnesses))
+	for _, address := range cfg.Witnesses {
+		witness, err := d.newProvider(cfg.ChainID, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create witness %s: %w", address, err)
+		}
+		witnesses[address] = witness
+		witnessList = append(witnessList, witness)
+	}
+
+	options := []light.Option{
+		light.Logger(logger),
+		light.RecordDivergences(light.NewDivergenceStore(d.db, cfg.ChainID)),
+	}
+	if cfg.Sequential {
+		options = append(options, light.SequentialVerification())
+	} else {
+		options = append(options, light.SkippingVerification(cfg.TrustLevel))
+	}
+
+	trustedStore := dbs.New(d.db, cfg.ChainID)
+	lastHeight, err := trustedStore.LastLightBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	var client *light.Client
+	if lastHeight > 0 { // continue from latest state
+		client, err = light.NewClientFromTrustedStore(cfg.ChainID, cfg.TrustingPeriod, primary, witnessList,
+			trustedStore, options...)
+	} else {
+		client, err = light.NewClient(ctx, cfg.ChainID, light.TrustOptions{
+			Period: cfg.TrustingPeriod,
+			Height: cfg.TrustedHeight,
+			Hash:   cfg.TrustedHash,
+		}, primary, witnessList, trustedStore, options...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create light client: %w", err)
+	}
+
+	rpcClient, err := d.newRPCClient(cfg.Primary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client for %s: %w", cfg.Primary, err)
+	}
+	proxy := &lproxy.Proxy{
+		Config: d.rpcConfig,
+		Client: lrpc.NewClient(rpcClient, client, lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn())),
+		Logger: logger,
+	}
+	proxy.Client.SetLogger(logger)
+	handler, err := proxy.Handler()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &chain{
+		config:    cfg,
+		client:    client,
+		proxy:     proxy,
+		handler:   handler,
+		witnesses: witnesses,
+		logger:    logger,
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go c.updateRoutine(d.updatePeriod)
+	return c, nil
+}
+
+func (d *Daemon) saveChainConfig(cfg ChainConfig) error {
+	bz, err := tmjson.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := d.db.SetSync(chainConfigKey(cfg.ChainID), bz); err != nil {
+		return fmt.Errorf("failed to save chain config: %w", err)
+	}
+	return nil
+}
+
+func (d *Daemon) loadChainConfigs() ([]ChainConfig, error) {
+	itr, err := dbm.IteratePrefix(d.db, []byte(chainConfigPrefix))
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var configs []ChainConfig
+	for ; itr.Valid(); itr.Next() {
+		var cfg ChainConfig
+		if err := tmjson.Unmarshal(itr.Value(), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chain config %s: %w", itr.Key(), err)
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, itr.Error()
+}
+
+const chainConfigPrefix = "daemon/chain/"
+
+func chainConfigKey(chainID string) []byte {
+	return []byte(chainConfigPrefix + chainID)
+}
+
+// chain is a chain tracked by the daemon.
+type chain struct {
+	config    ChainConfig // guarded by the daemon mutex
+	client    *light.Client
+	proxy     *lproxy.Proxy
+	handler   http.Handler
+	witnesses map[string]provider.Provider // by address, guarded by the daemon mutex
+	logger    log.Logger
+	quit      chan struct{}
+	done      chan struct{}
+
+	mtx        tmsync.Mutex
+	lastUpdate time.Time
+	lastErr    error
+}
+
+// updateRoutine periodically updates the light client to the latest header of the primary.
+func (c *chain) updateRoutine(period time.Duration) {
+	defer close(c.done)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-c.quit
+		cancel()
+	}()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		_, err := c.client.Update(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			c.logger.Error("Failed to update light client", "err", err)
+		}
+		c.mtx.Lock()
+		c.lastUpdate, c.lastErr = time.Now(), err
+		c.mtx.Unlock()
+
+		select {
+		case <-ticker.C:
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// stop stops updating the light client, and waits for the current update to return.
+func (c *chain) stop() {
+	close(c.quit)
+	<-c.done
+	if err := c.proxy.Client.Stop(); err != nil {
+		c.logger.Error("Failed to stop proxy client", "err", err)
+	}
+}
+
+func (c *chain) status() *ChainStatus {
+	status := &ChainStatus{
+		ChainID:             c.config.ChainID,
+		Primary:             fmt.Sprint(c.client.Primary()),
+		LatestTrustedHeight: -1,
+	}
+	for _, w := range c.client.Witnesses() {
+		status.Witnesses = append(status.Witnesses, fmt.Sprint(w))
+	}
+	if height, err := c.client.LastTrustedHeight(); err == nil && height > 0 {
+		if lb, err := c.client.TrustedLightBlock(height); err == nil {
+			status.LatestTrustedHeight = lb.Height
+			status.LatestTrustedHash = lb.Hash()
+			status.LatestTrustedTime = lb.Time
+		}
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	status.LastUpdate = c.lastUpdate
+	if c.lastErr != nil {
+		status.LastError = c.lastErr.Error()
+	}
+	return status
+}
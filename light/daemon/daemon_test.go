@@ -0,0 +1,204 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/light/provider"
+	"github.com/Finschia/ostracon/light/provider/local"
+	rpcclient "github.com/Finschia/ostracon/rpc/client"
+	rpcmock "github.com/Finschia/ostracon/rpc/client/mocks"
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/store"
+	"github.com/Finschia/ostracon/types"
+	tmtime "github.com/Finschia/ostracon/types/time"
+)
+
+const chainID = "test-chain"
+
+// makeChain generates a chain of the given height, returning a provider factory serving it.
+func makeChain(t *testing.T, height int64) func(string, string) (provider.Provider, error) {
+	privVal := types.NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:     chainID,
+		GenesisTime: tmtime.Now(),
+		Validators:  []types.GenesisValidator{{PubKey: pubKey, Power: 10}},
+	})
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, stateStore.Save(state))
+
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for h := int64(1); h <= height; h++ {
+		proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+		require.NoError(t, err)
+		block, parts := state.MakeBlock(h, nil, commit, nil, state.Validators.Validators[0].Address, 0, proof)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		voteSet := types.NewVoteSet(state.ChainID, h, 0, tmproto.PrecommitType, state.Validators)
+		commit, err = types.MakeCommit(blockID, h, 0, voteSet, []types.PrivValidator{privVal}, tmtime.Now())
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, parts, commit)
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators.Copy()
+		require.NoError(t, stateStore.Save(state))
+	}
+	return func(chainID, address string) (provider.Provider, error) {
+		return local.New(chainID, blockStore, stateStore), nil
+	}
+}
+
+func newTestDaemon(t *testing.T, db dbm.DB, newProvider func(string, string) (provider.Provider, error)) *Daemon {
+	d := NewDaemon(db, rpcserver.DefaultConfig(), UpdatePeriod(10*time.Millisecond))
+	d.SetLogger(log.TestingLogger())
+	d.newProvider = newProvider
+	d.newRPCClient = func(string) (rpcclient.Client, error) {
+		next := &rpcmock.Client{}
+		next.On("IsRunning").Return(true).Maybe()
+		next.On("Stop").Return(nil).Maybe()
+		next.On("Health", mock.Anything).Return(&ctypes.ResultHealth{}, nil).Maybe()
+		return next, nil
+	}
+	require.NoError(t, d.Start())
+	return d
+}
+
+func TestDaemon(t *testing.T) {
+	newProvider := makeChain(t, 5)
+	genesis, err := newProvider(chainID, "")
+	require.NoError(t, err)
+	lb, err := genesis.LightBlock(context.Background(), 1)
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	d := newTestDaemon(t, db, newProvider)
+	ctx := &rpctypes.Context{}
+	routes := AdminRoutes(d)
+	require.Contains(t, routes, "add_chain")
+
+	// Add a chain through the admin API, and wait for it to be updated to the latest header.
+	addChain := makeAddChainFunc(d)
+	_, err = addChain(ctx, chainID, "primary", "w1", "1h", 1, lb.Hash().String(), "", false)
+	require.NoError(t, err)
+	_, err = addChain(ctx, chainID, "primary", "w1", "1h", 1, lb.Hash().String(), "", false)
+	assert.Equal(t, ErrChainExists, err)
+	_, err = addChain(ctx, "other-chain", "primary", "", "1h", 1, lb.Hash().String(), "", false)
+	assert.Error(t, err)
+
+	assert.Eventually(t, func() bool {
+		status, err := d.Status(chainID)
+		require.NoError(t, err)
+		return status.LatestTrustedHeight == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	status, err := d.Status(chainID)
+	require.NoError(t, err)
+	assert.False(t, status.LastUpdate.IsZero())
+	assert.Empty(t, status.LastError)
+	chains, err := makeChainsFunc(d)(ctx)
+	require.NoError(t, err)
+	assert.Len(t, chains.Chains, 1)
+
+	// Rotate the witness.
+	status, err = makeAddWitnessFunc(d)(ctx, chainID, "w2")
+	require.NoError(t, err)
+	assert.Len(t, status.Witnesses, 2)
+	status, err = makeRemoveWitnessFunc(d)(ctx, chainID, "w1")
+	require.NoError(t, err)
+	assert.Len(t, status.Witnesses, 1)
+	_, err = makeRemoveWitnessFunc(d)(ctx, chainID, "w2")
+	assert.Error(t, err)
+	_, err = makeRemoveWitnessFunc(d)(ctx, chainID, "w3")
+	assert.Error(t, err)
+
+	// The chain's proxy is served under its chain ID.
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+chainID+"/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = httptest.NewRecorder()
+	d.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/other-chain/health", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// The chain is restored after a restart, with its trusted light blocks and witnesses.
+	require.NoError(t, d.Stop())
+	d = newTestDaemon(t, db, newProvider)
+	status, err = d.Status(chainID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, status.LatestTrustedHeight)
+	assert.Len(t, status.Witnesses, 1)
+
+	_, err = makeRemoveChainFunc(d)(ctx, chainID)
+	require.NoError(t, err)
+	assert.Empty(t, d.Chains())
+	_, err = d.Status(chainID)
+	assert.Equal(t, ErrChainNotFound, err)
+	assert.Equal(t, ErrChainNotFound, d.RemoveChain(chainID))
+	require.NoError(t, d.Stop())
+
+	configs, err := NewDaemon(db, rpcserver.DefaultConfig()).loadChainConfigs()
+	require.NoError(t, err)
+	assert.Empty(t, configs)
+}
+
+func TestAdminHandler(t *testing.T) {
+	d := newTestDaemon(t, dbm.NewMemDB(), makeChain(t, 1))
+	_, err := AdminHandler(d, "", log.TestingLogger())
+	require.Error(t, err)
+	handler, err := AdminHandler(d, "secret", log.TestingLogger())
+	require.NoError(t, err)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"chains","params":{}}`
+	auth := map[string]string{"Authorization": "Bearer secret"}
+	testcases := map[string]struct {
+		method     string
+		path       string
+		header     map[string]string
+		expectCode int
+	}{
+		"valid request": {http.MethodPost, "/", auth, http.StatusOK},
+		"GET request":   {http.MethodGet, "/chains", auth, http.StatusMethodNotAllowed},
+		"URI endpoint":  {http.MethodPost, "/chains", auth, http.StatusNotFound},
+		"missing token": {http.MethodPost, "/", nil, http.StatusUnauthorized},
+		"wrong token": {http.MethodPost, "/", map[string]string{"Authorization": "Bearer other"},
+			http.StatusUnauthorized},
+		"browser origin": {http.MethodPost, "/", map[string]string{"Authorization": "Bearer secret",
+			"Origin": "https://example.com"}, http.StatusForbidden},
+		"browser fetch": {http.MethodPost, "/", map[string]string{"Authorization": "Bearer secret",
+			"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(body))
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tc.expectCode, rec.Code)
+			if tc.expectCode == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"result"`)
+			}
+		})
+	}
+}
@@ -210,6 +210,27 @@ func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEv
 	}
 }
 
+// broadcastEvidence adds evidence to the evidence pool, if any, so that it is gossiped over the
+// p2p network. Evidence against an honest provider is rejected by the pool.
+func (c *Client) broadcastEvidence(ev *types.LightClientAttackEvidence) {
+	if c.evidencePool == nil {
+		return
+	}
+	if err := c.evidencePool.AddEvidence(ev); err != nil {
+		c.logger.Info("Evidence pool rejected evidence", "ev", ev, "err", err)
+	}
+}
+
+// recordDivergence saves the divergence record to the divergence store, if any.
+func (c *Client) recordDivergence(d *Divergence) {
+	if c.divergenceStore == nil {
+		return
+	}
+	if err := c.divergenceStore.Save(d); err != nil {
+		c.logger.Error("Failed to record divergence", "err", err)
+	}
+}
+
 // handleConflictingHeaders handles the primary style of attack, which is where a primary and witness have
 // two headers of the same height but with different hashes
 func (c *Client) handleConflictingHeaders(
@@ -220,6 +241,15 @@ func (c *Client) handleConflictingHeaders(
 	now time.Time,
 ) error {
 	supportingWitness := c.witnesses[witnessIndex]
+	divergence := &Divergence{
+		Time:             now,
+		Primary:          fmt.Sprint(c.primary),
+		Witness:          fmt.Sprint(supportingWitness),
+		PrimaryTrace:     primaryTrace,
+		ConflictingBlock: challendingBlock,
+	}
+	defer c.recordDivergence(divergence)
+
 	witnessTrace, primaryBlock, err := c.examineConflictingHeaderAgainstTrace(
 		ctx,
 		primaryTrace,
@@ -229,8 +259,10 @@ func (c *Client) handleConflictingHeaders(
 	)
 	if err != nil {
 		c.logger.Info("error validating witness's divergent header", "witness", supportingWitness, "err", err)
+		divergence.Error = fmt.Sprintf("failed to validate witness's divergent header: %v", err)
 		return nil
 	}
+	divergence.WitnessTrace = witnessTrace
 
 	// We are suspecting that the primary is faulty, hence we hold the witness as the source of truth
 	// and generate evidence against the primary that we can send to the witness
@@ -238,7 +270,9 @@ func (c *Client) handleConflictingHeaders(
 	evidenceAgainstPrimary := newLightClientAttackEvidence(primaryBlock, trustedBlock, commonBlock)
 	c.logger.Error("ATTEMPTED ATTACK DETECTED. Sending evidence againt primary by witness", "ev", evidenceAgainstPrimary,
 		"primary", c.primary, "witness", supportingWitness)
+	divergence.EvidenceAgainstPrimary = evidenceAgainstPrimary
 	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
+	c.broadcastEvidence(evidenceAgainstPrimary)
 
 	if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
 		c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
@@ -258,6 +292,7 @@ func (c *Client) handleConflictingHeaders(
 	)
 	if err != nil {
 		c.logger.Info("Error validating primary's divergent header", "primary", c.primary, "err", err)
+		divergence.Error = fmt.Sprintf("failed to validate primary's divergent header: %v", err)
 		return ErrLightClientAttack
 	}
 
@@ -266,7 +301,9 @@ func (c *Client) handleConflictingHeaders(
 	evidenceAgainstWitness := newLightClientAttackEvidence(witnessBlock, trustedBlock, commonBlock)
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
 		"primary", c.primary, "witness", supportingWitness)
+	divergence.EvidenceAgainstWitness = evidenceAgainstWitness
 	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
+	c.broadcastEvidence(evidenceAgainstWitness)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
 }
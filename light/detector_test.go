@@ -59,6 +59,8 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		primaryValidators[height] = vals
 	}
 	primary := mockp.New(chainID, primaryHeaders, primaryValidators)
+	evpool := &evidencePool{}
+	divergenceStore := light.NewDivergenceStore(dbm.NewMemDB(), chainID)
 
 	c, err := light.NewClient(
 		ctx,
@@ -73,6 +75,8 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		dbs.New(dbm.NewMemDB(), chainID),
 		light.Logger(log.TestingLogger()),
 		light.MaxRetryAttempts(1),
+		light.BroadcastEvidence(evpool),
+		light.RecordDivergences(divergenceStore),
 	)
 	require.NoError(t, err)
 
@@ -103,6 +107,35 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		CommonHeight: 4,
 	}
 	assert.True(t, primary.HasEvidence(evAgainstWitness))
+
+	// Check evidence was broadcast and the divergence was recorded.
+	require.Len(t, evpool.evidence, 2)
+	assert.Equal(t, evAgainstPrimary.ConflictingBlock.Hash(),
+		evpool.evidence[0].(*types.LightClientAttackEvidence).ConflictingBlock.Hash())
+	assert.Equal(t, evAgainstWitness.ConflictingBlock.Hash(),
+		evpool.evidence[1].(*types.LightClientAttackEvidence).ConflictingBlock.Hash())
+
+	divergences, err := divergenceStore.List()
+	require.NoError(t, err)
+	require.Len(t, divergences, 1)
+	d := divergences[0]
+	assert.NotEmpty(t, d.Primary)
+	assert.NotEmpty(t, d.Witness)
+	assert.Equal(t, primaryHeaders[10].Hash(), d.PrimaryTrace[len(d.PrimaryTrace)-1].Hash())
+	assert.Equal(t, witnessHeaders[10].Hash(), d.ConflictingBlock.Hash())
+	assert.NotEmpty(t, d.WitnessTrace)
+	assert.Equal(t, evAgainstPrimary.ConflictingBlock.Hash(), d.EvidenceAgainstPrimary.ConflictingBlock.Hash())
+	assert.Equal(t, evAgainstWitness.ConflictingBlock.Hash(), d.EvidenceAgainstWitness.ConflictingBlock.Hash())
+	assert.Empty(t, d.Error)
+}
+
+type evidencePool struct {
+	evidence []types.Evidence
+}
+
+func (p *evidencePool) AddEvidence(ev types.Evidence) error {
+	p.evidence = append(p.evidence, ev)
+	return nil
 }
 
 func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
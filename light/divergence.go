@@ -0,0 +1,137 @@
+package light
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/types"
+)
+
+// Divergence is a record of a conflicting header returned by a witness, kept for forensics. It
+// contains the conflicting light blocks, the traces that were verified while examining them and
+// the evidence that was created, if any.
+type Divergence struct {
+	Time    time.Time `json:"time"`
+	Primary string    `json:"primary"`
+	Witness string    `json:"witness"`
+
+	// PrimaryTrace is the trace verified from the primary, ending at the header the witness
+	// conflicts with.
+	PrimaryTrace []*types.LightBlock `json:"primary_trace"`
+	// ConflictingBlock is the light block returned by the witness.
+	ConflictingBlock *types.LightBlock `json:"conflicting_block"`
+	// WitnessTrace is the trace verified from the witness up to the bifurcation point, if the
+	// witness' header could be verified.
+	WitnessTrace []*types.LightBlock `json:"witness_trace,omitempty"`
+
+	EvidenceAgainstPrimary *types.LightClientAttackEvidence `json:"evidence_against_primary,omitempty"`
+	EvidenceAgainstWitness *types.LightClientAttackEvidence `json:"evidence_against_witness,omitempty"`
+
+	// Error explains why the divergence couldn't be fully examined, if it couldn't.
+	Error string `json:"error,omitempty"`
+}
+
+// DivergenceStore persists divergence records in a database, in the order they were saved.
+type DivergenceStore struct {
+	db     dbm.DB
+	prefix string
+
+	mtx  tmsync.Mutex
+	next int64
+}
+
+// NewDivergenceStore returns a DivergenceStore that wraps any DB, with an optional prefix in case
+// the DB is shared, e.g. with a light block store.
+func NewDivergenceStore(db dbm.DB, prefix string) *DivergenceStore {
+	return &DivergenceStore{db: db, prefix: prefix, next: -1}
+}
+
+// Save saves the divergence record after the previously saved ones.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *DivergenceStore) Save(d *Divergence) error {
+	bz, err := tmjson.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal divergence: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.next < 0 {
+		s.next, err = s.lastSeq()
+		if err != nil {
+			return err
+		}
+		s.next++
+	}
+	if err := s.db.SetSync(s.key(s.next), bz); err != nil {
+		return fmt.Errorf("failed to save divergence: %w", err)
+	}
+	s.next++
+	return nil
+}
+
+// List returns all saved divergence records, oldest first.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *DivergenceStore) List() ([]*Divergence, error) {
+	itr, err := s.db.Iterator(s.key(1), append(s.key(1<<63-1), byte(0x00)))
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	divergences := make([]*Divergence, 0)
+	for ; itr.Valid(); itr.Next() {
+		if _, ok := s.parseKey(itr.Key()); !ok {
+			continue
+		}
+		d := new(Divergence)
+		if err := tmjson.Unmarshal(itr.Value(), d); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal divergence: %w", err)
+		}
+		divergences = append(divergences, d)
+	}
+	return divergences, itr.Error()
+}
+
+// lastSeq returns the sequence number of the last saved record, or 0 if there are none.
+func (s *DivergenceStore) lastSeq() (int64, error) {
+	itr, err := s.db.ReverseIterator(s.key(1), append(s.key(1<<63-1), byte(0x00)))
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		if seq, ok := s.parseKey(itr.Key()); ok {
+			return seq, nil
+		}
+	}
+	return 0, itr.Error()
+}
+
+func (s *DivergenceStore) key(seq int64) []byte {
+	return []byte(fmt.Sprintf("divergence/%s/%020d", s.prefix, seq))
+}
+
+var divergenceKeyPattern = regexp.MustCompile(`^divergence/([^/]*)/([0-9]+)$`)
+
+func (s *DivergenceStore) parseKey(key []byte) (int64, bool) {
+	submatch := divergenceKeyPattern.FindSubmatch(key)
+	if submatch == nil || string(submatch[1]) != s.prefix {
+		return 0, false
+	}
+	seq, err := strconv.ParseInt(string(submatch[2]), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
@@ -0,0 +1,57 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/light"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestDivergenceStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	s := light.NewDivergenceStore(db, chainID)
+
+	divergences, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, divergences)
+
+	headers, vals, _ := genMockNodeWithKeys(chainID, 2, 3, 1, bTime)
+	lb := func(height int64) *types.LightBlock {
+		return &types.LightBlock{SignedHeader: headers[height], ValidatorSet: vals[height]}
+	}
+	require.NoError(t, s.Save(&light.Divergence{
+		Time:             bTime,
+		Primary:          "primary",
+		Witness:          "witness",
+		PrimaryTrace:     []*types.LightBlock{lb(1), lb(2)},
+		ConflictingBlock: lb(2),
+		Error:            "error",
+	}))
+	require.NoError(t, s.Save(&light.Divergence{Time: bTime.Add(time.Minute)}))
+
+	// Records are appended after the existing ones when the store is reopened, and stores with
+	// other prefixes don't see them.
+	s = light.NewDivergenceStore(db, chainID)
+	require.NoError(t, s.Save(&light.Divergence{Time: bTime.Add(2 * time.Minute)}))
+	require.NoError(t, light.NewDivergenceStore(db, "other").Save(&light.Divergence{}))
+
+	divergences, err = s.List()
+	require.NoError(t, err)
+	require.Len(t, divergences, 3)
+	for i, d := range divergences {
+		assert.True(t, bTime.Add(time.Duration(i)*time.Minute).Equal(d.Time))
+	}
+	d := divergences[0]
+	assert.Equal(t, "primary", d.Primary)
+	assert.Equal(t, "witness", d.Witness)
+	require.Len(t, d.PrimaryTrace, 2)
+	assert.Equal(t, headers[1].Hash(), d.PrimaryTrace[0].Hash())
+	assert.Equal(t, headers[2].Hash(), d.ConflictingBlock.Hash())
+	assert.Equal(t, "error", d.Error)
+}
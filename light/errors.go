@@ -10,7 +10,7 @@ import (
 
 // ErrOldHeaderExpired means the old (trusted) header has expired according to
 // the given trustingPeriod and current time. If so, the light client must be
-// reset subjectively.
+// reset subjectively, e.g. with Client.Reanchor.
 type ErrOldHeaderExpired struct {
 	At  time.Time
 	Now time.Time
@@ -11,6 +11,7 @@ import (
 	"github.com/Finschia/ostracon/light/provider"
 	rpcclient "github.com/Finschia/ostracon/rpc/client"
 	rpchttp "github.com/Finschia/ostracon/rpc/client/http"
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
 	"github.com/Finschia/ostracon/types"
 )
 
@@ -24,7 +25,14 @@ var (
 	timeout          uint = 5 // sec.
 )
 
+// aggregatedCommitClient is implemented by the RPC clients which can fetch commits with aggregated
+// signatures, like rpchttp.HTTP.
+type aggregatedCommitClient interface {
+	AggregatedCommit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+}
+
 // http provider uses an RPC client to obtain the necessary information.
+// Commits are fetched with aggregated signatures if the client can.
 type http struct {
 	chainID string
 	client  rpcclient.RemoteClient
@@ -175,9 +183,16 @@ OUTER_LOOP:
 	return valSet, nil
 }
 
+func (p *http) commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	if c, ok := p.client.(aggregatedCommitClient); ok {
+		return c.AggregatedCommit(ctx, height)
+	}
+	return p.client.Commit(ctx, height)
+}
+
 func (p *http) signedHeader(ctx context.Context, height *int64) (*types.SignedHeader, error) {
 	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
-		commit, err := p.client.Commit(ctx, height)
+		commit, err := p.commit(ctx, height)
 		switch {
 		case err == nil:
 			return &commit.SignedHeader, nil
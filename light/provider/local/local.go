@@ -0,0 +1,105 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Finschia/ostracon/light/provider"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/types"
+)
+
+// Local is a light client provider reading light blocks directly from the block store and state
+// store of a full node, without going through its RPC server.
+type Local struct {
+	chainID    string
+	blockStore sm.BlockStore
+	stateStore sm.Store
+}
+
+var _ provider.Provider = (*Local)(nil)
+
+// New creates a light client provider reading from the given stores of a full node running in the
+// same process.
+//
+// The stores of a node running in another process can't be shared: goleveldb locks its databases
+// for the process holding them, even for read-only access, and has no secondary mode following
+// the node's writes. Components running next to a node in their own process should use the http
+// provider against the node's RPC server instead.
+func New(chainID string, blockStore sm.BlockStore, stateStore sm.Store) *Local {
+	return &Local{
+		chainID:    chainID,
+		blockStore: blockStore,
+		stateStore: stateStore,
+	}
+}
+
+// ChainID returns the chain ID the light blocks are validated against.
+func (p *Local) ChainID() string {
+	return p.chainID
+}
+
+func (p *Local) String() string {
+	return fmt.Sprintf("Local{%s}", p.chainID)
+}
+
+// LightBlock returns the light block at the given height, or the latest one if 0.
+//
+// The latest height has no canonical commit yet, so the commit the node has seen for it is used
+// instead.
+func (p *Local) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: errors.New("negative height")}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	latest := p.blockStore.Height()
+	if height == 0 {
+		height = latest
+	}
+	if height > latest {
+		return nil, provider.ErrHeightTooHigh
+	}
+	if height < p.blockStore.Base() {
+		return nil, provider.ErrLightBlockNotFound
+	}
+
+	meta := p.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, provider.ErrLightBlockNotFound
+	}
+	commit := p.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		commit = p.blockStore.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, provider.ErrLightBlockNotFound
+	}
+	vals, err := p.stateStore.LoadValidators(height)
+	if err != nil {
+		if errors.As(err, &sm.ErrNoValSetForHeight{}) {
+			return nil, provider.ErrLightBlockNotFound
+		}
+		return nil, err
+	}
+
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &meta.Header,
+			Commit: commit,
+		},
+		ValidatorSet: vals,
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence is not supported, since the stores don't give access to the node's evidence pool.
+func (p *Local) ReportEvidence(context.Context, types.Evidence) error {
+	return errors.New("reporting evidence is not supported by the local provider")
+}
@@ -0,0 +1,88 @@
+package local_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/light/provider"
+	"github.com/Finschia/ostracon/light/provider/local"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/store"
+	"github.com/Finschia/ostracon/types"
+	tmtime "github.com/Finschia/ostracon/types/time"
+)
+
+const chainID = "test-chain"
+
+// makeChain saves a chain of the given height to the given databases.
+func makeChain(t *testing.T, height int64, blockStoreDB, stateDB dbm.DB) {
+	privVal := types.NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:     chainID,
+		GenesisTime: tmtime.Now(),
+		Validators:  []types.GenesisValidator{{PubKey: pubKey, Power: 10}},
+	})
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(blockStoreDB)
+	require.NoError(t, stateStore.Save(state))
+
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for h := int64(1); h <= height; h++ {
+		proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+		require.NoError(t, err)
+		block, parts := state.MakeBlock(h, []types.Tx{types.Tx(time.Now().String())}, commit, nil,
+			state.Validators.Validators[0].Address, 0, proof)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		voteSet := types.NewVoteSet(state.ChainID, h, 0, tmproto.PrecommitType, state.Validators)
+		commit, err = types.MakeCommit(blockID, h, 0, voteSet, []types.PrivValidator{privVal}, tmtime.Now())
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, parts, commit)
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators.Copy()
+		require.NoError(t, stateStore.Save(state))
+	}
+}
+
+func TestLocal(t *testing.T) {
+	blockStoreDB, stateDB := dbm.NewMemDB(), dbm.NewMemDB()
+	makeChain(t, 5, blockStoreDB, stateDB)
+	p := local.New(chainID, store.NewBlockStore(blockStoreDB), sm.NewStore(stateDB))
+	assert.Equal(t, chainID, p.ChainID())
+	ctx := context.Background()
+
+	lb, err := p.LightBlock(ctx, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lb.Height)
+	require.NoError(t, lb.ValidatorSet.VerifyCommitLight(chainID, lb.Commit.BlockID, lb.Height, lb.Commit))
+
+	// The latest light block uses the seen commit.
+	lb, err = p.LightBlock(ctx, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, lb.Height)
+	require.NoError(t, lb.ValidatorSet.VerifyCommitLight(chainID, lb.Commit.BlockID, lb.Height, lb.Commit))
+
+	_, err = p.LightBlock(ctx, 6)
+	assert.Equal(t, provider.ErrHeightTooHigh, err)
+
+	_, err = p.LightBlock(ctx, -1)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+
+	_, err = local.New("other-chain", store.NewBlockStore(blockStoreDB), sm.NewStore(stateDB)).LightBlock(ctx, 3)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+
+	assert.Error(t, p.ReportEvidence(ctx, nil))
+}
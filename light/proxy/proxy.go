@@ -85,6 +85,24 @@ func (p *Proxy) ListenAndServeTLS(certFile, keyFile string) error {
 }
 
 func (p *Proxy) listen() (net.Listener, *http.ServeMux, error) {
+	mux, err := p.Handler()
+	if err != nil {
+		return nil, mux, err
+	}
+
+	// Start listening for new connections.
+	listener, err := rpcserver.Listen(p.Addr, p.Config)
+	if err != nil {
+		return nil, mux, err
+	}
+
+	return listener, mux, nil
+}
+
+// Handler sets up the RPC routes and websocket connections to proxy via
+// Client, and starts the client. It allows serving the proxy from another
+// HTTP server, e.g. under a path prefix.
+func (p *Proxy) Handler() (*http.ServeMux, error) {
 	mux := http.NewServeMux()
 
 	// 1) Register regular routes.
@@ -108,15 +126,9 @@ func (p *Proxy) listen() (net.Listener, *http.ServeMux, error) {
 	// 3) Start a client.
 	if !p.Client.IsRunning() {
 		if err := p.Client.Start(); err != nil {
-			return nil, mux, fmt.Errorf("can't start client: %w", err)
+			return mux, fmt.Errorf("can't start client: %w", err)
 		}
 	}
 
-	// 4) Start listening for new connections.
-	listener, err := rpcserver.Listen(p.Addr, p.Config)
-	if err != nil {
-		return nil, mux, err
-	}
-
-	return listener, mux, nil
+	return mux, nil
 }
@@ -49,6 +49,13 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence"),
+		"pending_evidence": rpcserver.NewRPCFunc(makePendingEvidenceFunc(c),
+			"address,type,min_height,max_height,page,per_page,order_by"),
+		"evidence_search": rpcserver.NewRPCFunc(makeEvidenceSearchFunc(c),
+			"address,type,min_height,max_height,page,per_page,order_by"),
+
+		// key rotation API
+		"broadcast_key_rotation": rpcserver.NewRPCFunc(makeBroadcastKeyRotationFunc(c), "key_rotation"),
 	}
 }
 
@@ -284,3 +291,52 @@ func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
 		return c.BroadcastEvidence(ctx.Context(), ev)
 	}
 }
+
+type rpcBroadcastKeyRotationFunc func(
+	ctx *rpctypes.Context,
+	kr *types.KeyRotation,
+) (*ctypes.ResultBroadcastKeyRotation, error)
+
+// nolint: interfacer
+func makeBroadcastKeyRotationFunc(c *lrpc.Client) rpcBroadcastKeyRotationFunc {
+	return func(ctx *rpctypes.Context, kr *types.KeyRotation) (*ctypes.ResultBroadcastKeyRotation, error) {
+		return c.BroadcastKeyRotation(ctx.Context(), kr)
+	}
+}
+
+type rpcEvidenceSearchFunc func(
+	ctx *rpctypes.Context,
+	address []byte,
+	evType string,
+	minHeight, maxHeight int64,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error)
+
+func makePendingEvidenceFunc(c *lrpc.Client) rpcEvidenceSearchFunc {
+	return func(
+		ctx *rpctypes.Context,
+		address []byte,
+		evType string,
+		minHeight, maxHeight int64,
+		page, perPage *int,
+		orderBy string,
+	) (*ctypes.ResultEvidenceSearch, error) {
+		filter := rpcclient.EvidenceFilter{Address: address, Type: evType, MinHeight: minHeight, MaxHeight: maxHeight}
+		return c.PendingEvidence(ctx.Context(), filter, page, perPage, orderBy)
+	}
+}
+
+func makeEvidenceSearchFunc(c *lrpc.Client) rpcEvidenceSearchFunc {
+	return func(
+		ctx *rpctypes.Context,
+		address []byte,
+		evType string,
+		minHeight, maxHeight int64,
+		page, perPage *int,
+		orderBy string,
+	) (*ctypes.ResultEvidenceSearch, error) {
+		filter := rpcclient.EvidenceFilter{Address: address, Type: evType, MinHeight: minHeight, MaxHeight: maxHeight}
+		return c.EvidenceSearch(ctx.Context(), filter, page, perPage, orderBy)
+	}
+}
@@ -0,0 +1,148 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Finschia/ostracon/light/provider"
+	"github.com/Finschia/ostracon/types"
+)
+
+// Reanchor resets the trust of a light client whose latest trusted header has expired to the
+// header given by options, without removing the stored light blocks. The trusting period of the
+// client is replaced by options.Period.
+//
+// Since the new header can't be verified against the expired one, it is trusted subjectively:
+//
+//  1. The primary must return a valid light block with the given hash, signed by +2/3 of its
+//     validator set.
+//  2. A quorum (more than half) of the witnesses must return the same light block. Any witness
+//     returning a different one aborts the re-anchoring.
+//  3. The user must confirm the new header through the ConfirmationFunction, which is shown the
+//     overlap of its validator set with the one of the latest trusted header.
+//
+// It returns an error if the latest trusted header hasn't expired, in which case the client should
+// be updated with Update or VerifyLightBlockAtHeight instead, or if the new header is at or below
+// the latest trusted one.
+func (c *Client) Reanchor(ctx context.Context, options TrustOptions, now time.Time) error {
+	if err := options.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid TrustOptions: %w", err)
+	}
+	if c.latestTrustedBlock == nil {
+		return errors.New("no trusted light block to re-anchor from. Use NewClient with the TrustOptions instead")
+	}
+	if !HeaderExpired(c.latestTrustedBlock.SignedHeader, c.trustingPeriod, now) {
+		return fmt.Errorf("latest trusted header at height %d has not expired yet", c.latestTrustedBlock.Height)
+	}
+	if options.Height <= c.latestTrustedBlock.Height {
+		return fmt.Errorf("new trusted height %d must be greater than the latest trusted height %d",
+			options.Height, c.latestTrustedBlock.Height)
+	}
+
+	// 1) Fetch and verify the light block.
+	l, err := c.lightBlockFromPrimary(ctx, options.Height)
+	if err != nil {
+		return err
+	}
+	if err := l.ValidateBasic(c.chainID); err != nil {
+		return err
+	}
+	if !bytes.Equal(l.Hash(), options.Hash) {
+		return fmt.Errorf("expected header's hash %X, but got %X", options.Hash, l.Hash())
+	}
+	if HeaderExpired(l.SignedHeader, options.Period, now) {
+		return fmt.Errorf("new trusted header at height %d has already expired at %v (now: %v)",
+			l.Height, l.Time.Add(options.Period), now)
+	}
+	if err := l.ValidatorSet.VerifyCommitLight(c.chainID, l.Commit.BlockID, l.Height, l.Commit); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+
+	// 2) Cross-check with a quorum of witnesses.
+	if err := c.compareReanchorHeaderWithWitnesses(ctx, l.SignedHeader); err != nil {
+		return err
+	}
+
+	// 3) Ask for confirmation, showing how much of the last trusted validator set is still there.
+	count, power := validatorSetOverlap(c.latestTrustedBlock.ValidatorSet, l.ValidatorSet)
+	total := c.latestTrustedBlock.ValidatorSet.TotalVotingPower()
+	action := fmt.Sprintf(
+		"Re-anchor to %d (%X)? The latest trusted header %d (%X) expired at %v. "+
+			"%d of its %d validators, with %d of %d voting power (%.2f%%), are in the new validator set",
+		l.Height, l.Hash(),
+		c.latestTrustedBlock.Height, c.latestTrustedBlock.Hash(),
+		c.latestTrustedBlock.Time.Add(c.trustingPeriod),
+		count, c.latestTrustedBlock.ValidatorSet.Size(), power, total, float64(power)*100/float64(total))
+	if !c.confirmationFn(action) {
+		return errors.New("refused to re-anchor the light client")
+	}
+
+	// 4) Persist the new light block. The older ones are kept.
+	if err := c.updateTrustedLightBlock(l); err != nil {
+		return err
+	}
+	c.trustingPeriod = options.Period
+
+	c.logger.Info("Re-anchored light client", "height", l.Height, "hash", l.Hash(),
+		"overlap", fmt.Sprintf("%d/%d", power, total))
+	return nil
+}
+
+// compareReanchorHeaderWithWitnesses compares h with all witnesses. Unlike
+// compareFirstHeaderWithWitnesses, more than half of the witnesses must have the same header, since
+// it can't be verified against a trusted one.
+func (c *Client) compareReanchorHeaderWithWitnesses(ctx context.Context, h *types.SignedHeader) error {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	if len(c.witnesses) == 0 {
+		return ErrNoWitnesses
+	}
+
+	respc := make(chan witnessResponse, len(c.witnesses))
+	for i, witness := range c.witnesses {
+		go func(witnessIndex int, witness provider.Provider) {
+			lb, err := witness.LightBlock(ctx, h.Height)
+			respc <- witnessResponse{lb, witnessIndex, err}
+		}(i, witness)
+	}
+
+	matching := 0
+	for i := 0; i < cap(respc); i++ {
+		resp := <-respc
+		switch {
+		case resp.err == nil && bytes.Equal(resp.lb.Hash(), h.Hash()):
+			matching++
+		case resp.err == nil:
+			c.logger.Error("Witness has a different header. Please check primary is correct",
+				"witness", c.witnesses[resp.witnessIndex], "hash", resp.lb.Hash())
+			return errConflictingHeaders{Block: resp.lb, WitnessIndex: resp.witnessIndex}
+		case errors.Is(resp.err, context.Canceled) || errors.Is(resp.err, context.DeadlineExceeded):
+			return resp.err
+		default:
+			c.logger.Info("error comparing re-anchor header with witness",
+				"witness", c.witnesses[resp.witnessIndex], "err", resp.err)
+		}
+	}
+
+	if matching*2 <= len(c.witnesses) {
+		return fmt.Errorf("only %d of %d witnesses confirmed header %X, need more than half",
+			matching, len(c.witnesses), h.Hash())
+	}
+	return nil
+}
+
+// validatorSetOverlap returns the number and the voting power of the validators of old that are
+// also in new.
+func validatorSetOverlap(old, new *types.ValidatorSet) (count int, power int64) {
+	for _, val := range old.Validators {
+		if new.HasAddress(val.Address) {
+			count++
+			power += val.VotingPower
+		}
+	}
+	return count, power
+}
@@ -0,0 +1,112 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/light"
+	"github.com/Finschia/ostracon/light/provider"
+	mockp "github.com/Finschia/ostracon/light/provider/mock"
+	dbs "github.com/Finschia/ostracon/light/store/db"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestClient_Reanchor(t *testing.T) {
+	// h1 expires at bTime+4h, h3 at bTime+5h with the initial trusting period.
+	now := bTime.Add(6 * time.Hour)
+	reanchorOptions := light.TrustOptions{
+		Period: 8 * time.Hour,
+		Height: 3,
+		Hash:   h3.Hash(),
+	}
+
+	forkedH3 := keys.GenSignedHeaderLastBlockID(chainID, 3, bTime.Add(1*time.Hour), nil, vals, vals,
+		hash("app_hash2"), hash("cons_hash"), hash("results_hash"), 0, len(keys), types.BlockID{Hash: h2.Hash()})
+	forkedNode := mockp.New(
+		chainID,
+		map[int64]*types.SignedHeader{1: h1, 2: h2, 3: forkedH3},
+		valSet,
+	)
+
+	testCases := []struct {
+		name      string
+		witnesses []provider.Provider
+		options   light.TrustOptions
+		now       time.Time
+		confirm   bool
+		expErr    bool
+	}{
+		{"success", []provider.Provider{fullNode, fullNode}, reanchorOptions, now, true, false},
+		{"quorum of witnesses", []provider.Provider{fullNode, fullNode, deadNode}, reanchorOptions, now, true, false},
+		{"no quorum of witnesses", []provider.Provider{fullNode, deadNode}, reanchorOptions, now, true, true},
+		{"conflicting witness", []provider.Provider{fullNode, fullNode, forkedNode}, reanchorOptions, now, true, true},
+		{"not confirmed", []provider.Provider{fullNode}, reanchorOptions, now, false, true},
+		{"not expired", []provider.Provider{fullNode}, reanchorOptions, bTime.Add(time.Hour), true, true},
+		{"wrong hash", []provider.Provider{fullNode},
+			light.TrustOptions{Period: 8 * time.Hour, Height: 3, Hash: h2.Hash()}, now, true, true},
+		{"new header expired", []provider.Provider{fullNode},
+			light.TrustOptions{Period: 4 * time.Hour, Height: 3, Hash: h3.Hash()}, now, true, true},
+		{"not above trusted height", []provider.Provider{fullNode},
+			light.TrustOptions{Period: 8 * time.Hour, Height: 1, Hash: h1.Hash()}, now, true, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			trustedStore := dbs.New(dbm.NewMemDB(), chainID)
+			require.NoError(t, trustedStore.SaveLightBlock(l1))
+
+			var action string
+			c, err := light.NewClientFromTrustedStore(
+				chainID,
+				trustPeriod,
+				fullNode,
+				tc.witnesses,
+				trustedStore,
+				light.Logger(log.TestingLogger()),
+				light.ConfirmationFunction(func(a string) bool {
+					action = a
+					return tc.confirm
+				}),
+			)
+			require.NoError(t, err)
+
+			if tc.now.After(bTime.Add(trustPeriod)) {
+				// the client can't verify new headers anymore
+				_, err = c.VerifyLightBlockAtHeight(ctx, 3, tc.now)
+				require.Error(t, err)
+			}
+
+			err = c.Reanchor(ctx, tc.options, tc.now)
+			if tc.expErr {
+				assert.Error(t, err)
+				height, err := c.LastTrustedHeight()
+				require.NoError(t, err)
+				assert.EqualValues(t, 1, height)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, action, "10 of its 10 validators")
+
+			height, err := c.LastTrustedHeight()
+			require.NoError(t, err)
+			assert.EqualValues(t, 3, height)
+
+			// the stored history is kept
+			l, err := c.TrustedLightBlock(1)
+			require.NoError(t, err)
+			assert.Equal(t, l1.Hash(), l.Hash())
+
+			// the new trusting period is used
+			l, err = c.VerifyLightBlockAtHeight(ctx, 2, tc.now)
+			require.NoError(t, err)
+			assert.Equal(t, h2.Hash(), l.Hash())
+		})
+	}
+}
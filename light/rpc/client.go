@@ -40,8 +40,8 @@ type LightClient interface {
 var _ rpcclient.Client = (*Client)(nil)
 
 // Client is an RPC client, which uses light#Client to verify data (if it can
-// be proved). Note, merkle.DefaultProofRuntime is used to verify values
-// returned by ABCI#Query.
+// be proved). Note, merkle.DefaultProofRuntime, extended with the ICS23
+// commitment proof decoders, is used to verify values returned by ABCI#Query.
 type Client struct {
 	service.BaseService
 
@@ -95,6 +95,8 @@ func NewClient(next rpcclient.Client, lc LightClient, opts ...Option) *Client {
 		lc:   lc,
 		prt:  merkle.DefaultProofRuntime(),
 	}
+	c.prt.RegisterOpDecoder(merkle.ProofOpIAVLCommitment, merkle.CommitmentOpDecoder)
+	c.prt.RegisterOpDecoder(merkle.ProofOpSimpleMerkleCommitment, merkle.CommitmentOpDecoder)
 	c.BaseService = *service.NewBaseService(nil, "Client", c)
 	for _, o := range opts {
 		o(c)
@@ -165,25 +167,23 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 		return nil, err
 	}
 
+	// Build a Merkle key path from path and resp.Key.
+	if c.keyPathFn == nil {
+		return nil, errors.New("please configure Client with KeyPathFn option")
+	}
+	kp, err := c.keyPathFn(path, resp.Key)
+	if err != nil {
+		return nil, fmt.Errorf("can't build merkle key path: %w", err)
+	}
+
 	// Validate the value proof against the trusted header.
 	if resp.Value != nil {
-		// 1) build a Merkle key path from path and resp.Key
-		if c.keyPathFn == nil {
-			return nil, errors.New("please configure Client with KeyPathFn option")
-		}
-
-		kp, err := c.keyPathFn(path, resp.Key)
-		if err != nil {
-			return nil, fmt.Errorf("can't build merkle key path: %w", err)
-		}
-
-		// 2) verify value
 		err = c.prt.VerifyValue(resp.ProofOps, l.AppHash, kp.String(), resp.Value)
 		if err != nil {
 			return nil, fmt.Errorf("verify value proof: %w", err)
 		}
 	} else { // OR validate the absence proof against the trusted header.
-		err = c.prt.VerifyAbsence(resp.ProofOps, l.AppHash, string(resp.Key))
+		err = c.prt.VerifyAbsence(resp.ProofOps, l.AppHash, kp.String())
 		if err != nil {
 			return nil, fmt.Errorf("verify absence proof: %w", err)
 		}
@@ -535,6 +535,23 @@ func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*cty
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+func (c *Client) BroadcastKeyRotation(
+	ctx context.Context,
+	kr *types.KeyRotation,
+) (*ctypes.ResultBroadcastKeyRotation, error) {
+	return c.next.BroadcastKeyRotation(ctx, kr)
+}
+
+func (c *Client) PendingEvidence(ctx context.Context, filter rpcclient.EvidenceFilter, page, perPage *int,
+	orderBy string) (*ctypes.ResultEvidenceSearch, error) {
+	return c.next.PendingEvidence(ctx, filter, page, perPage, orderBy)
+}
+
+func (c *Client) EvidenceSearch(ctx context.Context, filter rpcclient.EvidenceFilter, page, perPage *int,
+	orderBy string) (*ctypes.ResultEvidenceSearch, error) {
+	return c.next.EvidenceSearch(ctx, filter, page, perPage, orderBy)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...)
@@ -569,28 +586,63 @@ func (c *Client) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
 }
 
 // SubscribeWS subscribes for events using the given query and remote address as
-// a subscriber, but does not verify responses (UNSAFE)!
-// TODO: verify data
+// a subscriber. NewBlock, NewBlockHeader and Tx events are verified before
+// being relayed, see eventVerifier#verify. Events are verified concurrently and
+// relayed in order. Events which fail verification are dropped and an error is
+// sent instead.
 func (c *Client) SubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
 	out, err := c.next.Subscribe(context.Background(), ctx.RemoteAddr(), query)
 	if err != nil {
 		return nil, err
 	}
 
+	verifier := newEventVerifier(c)
+	id := rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID))
+	// Each event gets a channel for its verification result, queued in the order the events were
+	// received. The capacity of the queue bounds the number of concurrent verifications.
+	queue := make(chan chan verifiedEvent, maxConcurrentEventVerifications)
+
 	go func() {
+		defer close(queue)
 		for {
 			select {
-			case resultEvent := <-out:
-				// We should have a switch here that performs a validation
-				// depending on the event's type.
-				ctx.WSConn.TryWriteRPCResponse(
-					rpctypes.NewRPCSuccessResponse(
-						rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID)),
-						resultEvent,
-					))
+			case resultEvent, ok := <-out:
+				if !ok {
+					return
+				}
+				result := make(chan verifiedEvent, 1)
+				select {
+				case queue <- result:
+				case <-c.Quit():
+					return
+				}
+				go func() {
+					vctx, cancel := context.WithTimeout(context.Background(), eventVerificationTimeout)
+					defer cancel()
+					event, err := verifier.verify(vctx, resultEvent)
+					result <- verifiedEvent{event: event, err: err}
+				}()
+			case <-c.Quit():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		for result := range queue {
+			var verified verifiedEvent
+			select {
+			case verified = <-result:
 			case <-c.Quit():
 				return
 			}
+			if verified.err != nil {
+				c.Logger.Error("Dropping unverifiable event", "query", verified.event.Query, "err", verified.err)
+				ctx.WSConn.TryWriteRPCResponse(
+					rpctypes.RPCInternalError(id, fmt.Errorf("failed to verify event: %w", verified.err)))
+				continue
+			}
+			ctx.WSConn.TryWriteRPCResponse(rpctypes.NewRPCSuccessResponse(id, verified.event))
 		}
 	}()
 
@@ -0,0 +1,228 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
+	"github.com/Finschia/ostracon/types"
+)
+
+const (
+	// eventVerificationTimeout is how long to try verifying an event before dropping it. Tx results
+	// can only be verified once the next block is committed, so this covers a few blocks.
+	eventVerificationTimeout = time.Minute
+	// nextHeaderRetryInterval is how often to retry verifying the header that contains the
+	// results hash of a tx event, while the primary doesn't have it yet.
+	nextHeaderRetryInterval = 500 * time.Millisecond
+	// maxConcurrentEventVerifications is the number of events of a subscription verified at once.
+	maxConcurrentEventVerifications = 16
+)
+
+// eventVerifier verifies the events of a single subscription. Events may be verified concurrently.
+// The block and results of the last heights are kept, since a block usually emits many tx events.
+type eventVerifier struct {
+	c *Client
+
+	mtx      tmsync.Mutex
+	txBlocks map[int64]*txBlock
+}
+
+// txBlock is the verified block and results of a height, shared by the tx events of the block.
+type txBlock struct {
+	tmsync.Mutex
+	block   *types.Block
+	results types.ABCIResults
+	// resultsHash is the verified LastResultsHash of the header following the block.
+	resultsHash []byte
+}
+
+// verifiedEvent is the result of verifying an event.
+type verifiedEvent struct {
+	event ctypes.ResultEvent
+	err   error
+}
+
+func newEventVerifier(c *Client) *eventVerifier {
+	return &eventVerifier{c: c, txBlocks: make(map[int64]*txBlock)}
+}
+
+// verify verifies the event payload against the headers verified by the light client, and returns
+// the event without the parts that can't be verified:
+//
+//   - NewBlock: the block must match the verified header at its height.
+//   - NewBlockHeader: the header must match the verified header at its height.
+//   - Tx: the tx is proven against the data hash of the verified header at its height with a
+//     TxProof, and the result is proven against the results hash of the verified header at the
+//     next height, waiting for it to be committed.
+//
+// The ABCI events, logs and infos, and the BeginBlock and EndBlock responses, aren't covered by any
+// hash and are removed, as are the event attributes other than the reserved ones. Other event types
+// are relayed unverified.
+func (v *eventVerifier) verify(ctx context.Context, event ctypes.ResultEvent) (ctypes.ResultEvent, error) {
+	switch data := event.Data.(type) {
+	case types.EventDataNewBlock:
+		if data.Block == nil {
+			return event, errors.New("missing block")
+		}
+		if err := v.verifyBlock(ctx, data.Block); err != nil {
+			return event, err
+		}
+		event.Data = types.EventDataNewBlock{Block: data.Block}
+		event.Events = reservedEvents(event.Events, types.BlockHeightKey)
+	case types.EventDataNewBlockHeader:
+		l, err := v.c.updateLightClientIfNeededTo(ctx, &data.Header.Height)
+		if err != nil {
+			return event, err
+		}
+		if !bytes.Equal(data.Header.Hash(), l.Hash()) {
+			return event, fmt.Errorf("header %X does not match with trusted header %X", data.Header.Hash(), l.Hash())
+		}
+		event.Data = types.EventDataNewBlockHeader{Header: data.Header, NumTxs: data.NumTxs}
+		event.Events = reservedEvents(event.Events, types.BlockHeightKey)
+	case types.EventDataTx:
+		if err := v.verifyTx(ctx, data.TxResult); err != nil {
+			return event, err
+		}
+		data.Result = abci.ResponseDeliverTx{
+			Code:      data.Result.Code,
+			Data:      data.Result.Data,
+			GasWanted: data.Result.GasWanted,
+			GasUsed:   data.Result.GasUsed,
+		}
+		event.Data = data
+		event.Events = reservedEvents(event.Events, types.TxHashKey, types.TxHeightKey)
+	}
+	return event, nil
+}
+
+// reservedEvents returns the event type and the given reserved keys of the events, which are derived
+// from the verified data rather than from the ABCI events.
+func reservedEvents(events map[string][]string, keys ...string) map[string][]string {
+	reserved := make(map[string][]string, len(keys)+1)
+	for _, key := range append(keys, types.EventTypeKey) {
+		if values, ok := events[key]; ok {
+			reserved[key] = values
+		}
+	}
+	return reserved
+}
+
+// txBlock returns the tx block of the given height, creating it if needed. Tx events arrive in
+// height order, so the blocks of older heights are dropped.
+func (v *eventVerifier) txBlock(height int64) *txBlock {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	b, ok := v.txBlocks[height]
+	if !ok {
+		b = &txBlock{}
+		v.txBlocks[height] = b
+		for h := range v.txBlocks {
+			if h < height-1 {
+				delete(v.txBlocks, h)
+			}
+		}
+	}
+	return b
+}
+
+func (v *eventVerifier) verifyBlock(ctx context.Context, block *types.Block) error {
+	l, err := v.c.updateLightClientIfNeededTo(ctx, &block.Height)
+	if err != nil {
+		return err
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid block: %w", err)
+	}
+	if !bytes.Equal(block.Hash(), l.Hash()) {
+		return fmt.Errorf("block header %X does not match with trusted header %X", block.Hash(), l.Hash())
+	}
+	return nil
+}
+
+func (v *eventVerifier) verifyTx(ctx context.Context, txResult abci.TxResult) error {
+	if txResult.Height <= 0 {
+		return errNegOrZeroHeight
+	}
+	b := v.txBlock(txResult.Height)
+	b.Lock()
+	defer b.Unlock()
+
+	// Prove the tx against the data hash of its block.
+	if b.block == nil {
+		res, err := v.c.next.Block(ctx, &txResult.Height)
+		if err != nil {
+			return fmt.Errorf("failed to fetch block at height %d: %w", txResult.Height, err)
+		}
+		if res.Block == nil || res.Block.Height != txResult.Height {
+			return fmt.Errorf("primary returned no block at height %d", txResult.Height)
+		}
+		if err := v.verifyBlock(ctx, res.Block); err != nil {
+			return err
+		}
+		b.block = res.Block
+	}
+	if int(txResult.Index) >= len(b.block.Txs) {
+		return fmt.Errorf("tx index %d out of range, block has %d txs", txResult.Index, len(b.block.Txs))
+	}
+	proof := b.block.Txs.Proof(int(txResult.Index))
+	if err := proof.Validate(b.block.DataHash); err != nil {
+		return fmt.Errorf("invalid tx proof: %w", err)
+	}
+	if !bytes.Equal(proof.Data, txResult.Tx) {
+		return fmt.Errorf("tx %X is not at index %d of block %d", types.Tx(txResult.Tx).Hash(),
+			txResult.Index, txResult.Height)
+	}
+
+	// Prove the result against the results hash of the next header.
+	if b.resultsHash == nil {
+		next, err := v.nextLightBlock(ctx, txResult.Height+1)
+		if err != nil {
+			return err
+		}
+		res, err := v.c.next.BlockResults(ctx, &txResult.Height)
+		if err != nil {
+			return fmt.Errorf("failed to fetch block results at height %d: %w", txResult.Height, err)
+		}
+		results := types.NewResults(res.TxsResults)
+		if !bytes.Equal(results.Hash(), next.LastResultsHash) {
+			return fmt.Errorf("last results %X does not match with trusted last results %X",
+				results.Hash(), next.LastResultsHash)
+		}
+		b.results, b.resultsHash = results, next.LastResultsHash
+	}
+	if int(txResult.Index) >= len(b.results) {
+		return fmt.Errorf("tx index %d out of range, block has %d results", txResult.Index, len(b.results))
+	}
+	bz, err := types.NewResults([]*abci.ResponseDeliverTx{&txResult.Result})[0].Marshal()
+	if err != nil {
+		return err
+	}
+	resultProof := b.results.ProveResult(int(txResult.Index))
+	if err := resultProof.Verify(b.resultsHash, bz); err != nil {
+		return fmt.Errorf("invalid tx result: %w", err)
+	}
+	return nil
+}
+
+// nextLightBlock verifies the light block at the given height, retrying until the primary has it
+// or the context is done.
+func (v *eventVerifier) nextLightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	for {
+		l, err := v.c.updateLightClientIfNeededTo(ctx, &height)
+		if err == nil {
+			return l, nil
+		}
+		select {
+		case <-time.After(nextHeaderRetryInterval):
+		case <-ctx.Done():
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	lcmock "github.com/Finschia/ostracon/light/rpc/mocks"
+	rpcmock "github.com/Finschia/ostracon/rpc/client/mocks"
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
+	"github.com/Finschia/ostracon/types"
+	"github.com/Finschia/ostracon/version"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+)
+
+func TestEventVerifier(t *testing.T) {
+	txs := []types.Tx{types.Tx("a"), types.Tx("b")}
+	results := []*abci.ResponseDeliverTx{{Code: 0, Data: []byte("x")}, {Code: 1, Data: []byte("y")}}
+	block := types.MakeBlock(1, txs, types.NewCommit(0, 0, types.BlockID{}, nil), nil,
+		tmversion.Consensus{Block: version.BlockProtocol})
+	block.ChainID = "test-chain"
+	block.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+	block.ValidatorsHash = tmrand.Bytes(tmhash.Size)
+	nextHeader := &types.Header{Height: 2, LastResultsHash: types.NewResults(results).Hash()}
+
+	next := &rpcmock.Client{}
+	next.On("Block", mock.Anything, mock.Anything).Return(&ctypes.ResultBlock{Block: block}, nil)
+	next.On("BlockResults", mock.Anything, mock.Anything).Return(
+		&ctypes.ResultBlockResults{Height: 1, TxsResults: results}, nil)
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(1), mock.Anything).Return(
+		&types.LightBlock{SignedHeader: &types.SignedHeader{Header: &block.Header}}, nil)
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(2), mock.Anything).Return(
+		&types.LightBlock{SignedHeader: &types.SignedHeader{Header: nextHeader}}, nil)
+	v := newEventVerifier(NewClient(next, lc))
+	ctx := context.Background()
+
+	otherHeader := block.Header
+	otherHeader.AppHash = []byte("other")
+	otherResult := *results[0]
+	otherResult.Code = 1
+	// The events aren't part of the results hash.
+	withEvents := *results[1]
+	withEvents.Events = []abci.Event{{Type: "transfer"}}
+
+	testCases := []struct {
+		name  string
+		data  interface{}
+		valid bool
+	}{
+		{"block", types.EventDataNewBlock{Block: block}, true},
+		{"header", types.EventDataNewBlockHeader{Header: block.Header}, true},
+		{"other header", types.EventDataNewBlockHeader{Header: otherHeader}, false},
+		{"tx", types.EventDataTx{TxResult: abci.TxResult{Height: 1, Index: 0, Tx: txs[0],
+			Result: *results[0]}}, true},
+		{"tx with events", types.EventDataTx{TxResult: abci.TxResult{Height: 1, Index: 1, Tx: txs[1],
+			Result: withEvents}}, true},
+		{"wrong tx", types.EventDataTx{TxResult: abci.TxResult{Height: 1, Index: 1, Tx: txs[0],
+			Result: *results[1]}}, false},
+		{"wrong result", types.EventDataTx{TxResult: abci.TxResult{Height: 1, Index: 0, Tx: txs[0],
+			Result: otherResult}}, false},
+		{"out of range", types.EventDataTx{TxResult: abci.TxResult{Height: 1, Index: 2, Tx: txs[0],
+			Result: *results[0]}}, false},
+		{"unverified", types.EventDataString("string"), true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.verify(ctx, ctypes.ResultEvent{Data: tc.data})
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	// The block and the results are only fetched once.
+	next.AssertNumberOfCalls(t, "Block", 1)
+	next.AssertNumberOfCalls(t, "BlockResults", 1)
+
+	// The parts of the events that aren't covered by any hash are removed.
+	withEvents.Log = "log"
+	event, err := v.verify(ctx, ctypes.ResultEvent{
+		Data: types.EventDataTx{TxResult: abci.TxResult{Height: 1, Index: 1, Tx: txs[1], Result: withEvents}},
+		Events: map[string][]string{
+			types.EventTypeKey: {types.EventTx},
+			types.TxHeightKey:  {"1"},
+			"transfer.amount":  {"100"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, *results[1], event.Data.(types.EventDataTx).Result)
+	assert.Equal(t, map[string][]string{types.EventTypeKey: {types.EventTx}, types.TxHeightKey: {"1"}},
+		event.Events)
+
+	event, err = v.verify(ctx, ctypes.ResultEvent{Data: types.EventDataNewBlock{
+		Block:          block,
+		ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{{Type: "transfer"}}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, types.EventDataNewBlock{Block: block}, event.Data)
+}
+
+func TestEventVerifier_Concurrent(t *testing.T) {
+	txs := []types.Tx{types.Tx("a"), types.Tx("b"), types.Tx("c"), types.Tx("d")}
+	results := make([]*abci.ResponseDeliverTx, len(txs))
+	for i := range results {
+		results[i] = &abci.ResponseDeliverTx{Data: txs[i]}
+	}
+	block := types.MakeBlock(1, txs, types.NewCommit(0, 0, types.BlockID{}, nil), nil,
+		tmversion.Consensus{Block: version.BlockProtocol})
+	block.ChainID = "test-chain"
+	block.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+	block.ValidatorsHash = tmrand.Bytes(tmhash.Size)
+	nextHeader := &types.Header{Height: 2, LastResultsHash: types.NewResults(results).Hash()}
+
+	next := &rpcmock.Client{}
+	next.On("Block", mock.Anything, mock.Anything).Return(&ctypes.ResultBlock{Block: block}, nil)
+	next.On("BlockResults", mock.Anything, mock.Anything).Return(
+		&ctypes.ResultBlockResults{Height: 1, TxsResults: results}, nil)
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(1), mock.Anything).Return(
+		&types.LightBlock{SignedHeader: &types.SignedHeader{Header: &block.Header}}, nil)
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(2), mock.Anything).Return(
+		&types.LightBlock{SignedHeader: &types.SignedHeader{Header: nextHeader}}, nil)
+	v := newEventVerifier(NewClient(next, lc))
+
+	// The tx events of a block share its block and results, which are still only fetched once.
+	var wg sync.WaitGroup
+	for i := range txs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := v.verify(context.Background(), ctypes.ResultEvent{Data: types.EventDataTx{
+				TxResult: abci.TxResult{Height: 1, Index: uint32(i), Tx: txs[i], Result: *results[i]}}})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	next.AssertNumberOfCalls(t, "Block", 1)
+	next.AssertNumberOfCalls(t, "BlockResults", 1)
+}
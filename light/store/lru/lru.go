@@ -0,0 +1,174 @@
+package lru
+
+import (
+	"container/list"
+	"sort"
+
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/light/store"
+	"github.com/Finschia/ostracon/types"
+)
+
+// lru is an in-memory Store holding up to a fixed number of light blocks. When it is full, the
+// least recently saved or read light block is evicted.
+type lru struct {
+	mtx      tmsync.Mutex
+	capacity int
+	blocks   map[int64]*list.Element // values are *types.LightBlock
+	list     *list.List              // from least to most recently used
+	heights  []int64                 // sorted, for the height based lookups
+}
+
+// New returns a Store that keeps up to capacity light blocks in memory, for short-lived light
+// clients that shouldn't write to disk. It panics if capacity isn't positive.
+func New(capacity int) store.Store {
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+	return &lru{
+		capacity: capacity,
+		blocks:   make(map[int64]*list.Element, capacity),
+		list:     list.New(),
+	}
+}
+
+// SaveLightBlock saves the LightBlock, evicting the least recently used one if the store is full.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) SaveLightBlock(lb *types.LightBlock) error {
+	if lb.Height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if e, ok := s.blocks[lb.Height]; ok {
+		e.Value = lb
+		s.list.MoveToBack(e)
+		return nil
+	}
+	if s.list.Len() >= s.capacity {
+		if e := s.list.Front(); e != nil {
+			s.remove(e.Value.(*types.LightBlock).Height)
+		}
+	}
+	s.blocks[lb.Height] = s.list.PushBack(lb)
+	i := sort.Search(len(s.heights), func(i int) bool { return s.heights[i] > lb.Height })
+	s.heights = append(s.heights, 0)
+	copy(s.heights[i+1:], s.heights[i:])
+	s.heights[i] = lb.Height
+	return nil
+}
+
+// DeleteLightBlock deletes the LightBlock.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) DeleteLightBlock(height int64) error {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.remove(height)
+	return nil
+}
+
+// remove removes the light block at the given height, if any. The caller must hold the mutex lock.
+func (s *lru) remove(height int64) {
+	e, ok := s.blocks[height]
+	if !ok {
+		return
+	}
+	s.list.Remove(e)
+	delete(s.blocks, height)
+	i := sort.Search(len(s.heights), func(i int) bool { return s.heights[i] >= height })
+	s.heights = append(s.heights[:i], s.heights[i+1:]...)
+}
+
+// LightBlock retrieves the LightBlock at the given height, marking it as recently used.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) LightBlock(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.lightBlock(height)
+}
+
+// lightBlock returns the light block at the given height. The caller must hold the mutex lock.
+func (s *lru) lightBlock(height int64) (*types.LightBlock, error) {
+	e, ok := s.blocks[height]
+	if !ok {
+		return nil, store.ErrLightBlockNotFound
+	}
+	s.list.MoveToBack(e)
+	return e.Value.(*types.LightBlock), nil
+}
+
+// LastLightBlockHeight returns the last LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) LastLightBlockHeight() (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.heights) == 0 {
+		return -1, nil
+	}
+	return s.heights[len(s.heights)-1], nil
+}
+
+// FirstLightBlockHeight returns the first LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) FirstLightBlockHeight() (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.heights) == 0 {
+		return -1, nil
+	}
+	return s.heights[0], nil
+}
+
+// LightBlockBefore returns the LightBlock with the greatest height below the given height. It
+// returns ErrLightBlockNotFound if no such block exists.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) LightBlockBefore(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i := sort.Search(len(s.heights), func(i int) bool { return s.heights[i] >= height })
+	if i == 0 {
+		return nil, store.ErrLightBlockNotFound
+	}
+	return s.lightBlock(s.heights[i-1])
+}
+
+// Prune removes the light blocks with the lowest heights until there are only size left.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) Prune(size uint16) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for len(s.heights) > int(size) {
+		s.remove(s.heights[0])
+	}
+	return nil
+}
+
+// Size returns the number of light blocks stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *lru) Size() uint16 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return uint16(s.list.Len())
+}
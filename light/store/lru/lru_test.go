@@ -0,0 +1,124 @@
+package lru
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Finschia/ostracon/light/store"
+	"github.com/Finschia/ostracon/types"
+)
+
+func lightBlock(height int64) *types.LightBlock {
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &types.Header{Height: height},
+			Commit: &types.Commit{},
+		},
+	}
+}
+
+func TestLRU_SaveLightBlock(t *testing.T) {
+	s := New(3)
+
+	// Empty store
+	_, err := s.LightBlock(1)
+	require.Equal(t, store.ErrLightBlockNotFound, err)
+	height, err := s.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+	height, err = s.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+
+	for _, h := range []int64{5, 1, 3} {
+		require.NoError(t, s.SaveLightBlock(lightBlock(h)))
+	}
+	assert.EqualValues(t, 3, s.Size())
+	lb, err := s.LightBlock(3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lb.Height)
+
+	// Saving an existing height doesn't grow the store.
+	require.NoError(t, s.SaveLightBlock(lightBlock(3)))
+	assert.EqualValues(t, 3, s.Size())
+
+	height, err = s.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+	height, err = s.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, height)
+
+	require.NoError(t, s.DeleteLightBlock(1))
+	_, err = s.LightBlock(1)
+	require.Equal(t, store.ErrLightBlockNotFound, err)
+	assert.EqualValues(t, 2, s.Size())
+}
+
+func TestLRU_Evict(t *testing.T) {
+	s := New(2)
+	require.NoError(t, s.SaveLightBlock(lightBlock(1)))
+	require.NoError(t, s.SaveLightBlock(lightBlock(2)))
+
+	// Reading height 1 makes height 2 the least recently used.
+	_, err := s.LightBlock(1)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveLightBlock(lightBlock(3)))
+
+	assert.EqualValues(t, 2, s.Size())
+	_, err = s.LightBlock(2)
+	require.Equal(t, store.ErrLightBlockNotFound, err)
+	_, err = s.LightBlock(1)
+	require.NoError(t, err)
+	_, err = s.LightBlock(3)
+	require.NoError(t, err)
+}
+
+func TestLRU_LightBlockBefore(t *testing.T) {
+	s := New(10)
+	for _, h := range []int64{2, 4, 6} {
+		require.NoError(t, s.SaveLightBlock(lightBlock(h)))
+	}
+
+	testcases := []struct {
+		height int64
+		expect int64
+	}{
+		{1, 0},
+		{2, 0},
+		{3, 2},
+		{4, 2},
+		{6, 4},
+		{100, 6},
+	}
+	for _, tc := range testcases {
+		lb, err := s.LightBlockBefore(tc.height)
+		if tc.expect == 0 {
+			require.Equal(t, store.ErrLightBlockNotFound, err, "height %v", tc.height)
+			continue
+		}
+		require.NoError(t, err, "height %v", tc.height)
+		assert.Equal(t, tc.expect, lb.Height, "height %v", tc.height)
+	}
+}
+
+func TestLRU_Prune(t *testing.T) {
+	s := New(10)
+	for h := int64(1); h <= 5; h++ {
+		require.NoError(t, s.SaveLightBlock(lightBlock(h)))
+	}
+
+	require.NoError(t, s.Prune(10))
+	assert.EqualValues(t, 5, s.Size())
+
+	require.NoError(t, s.Prune(2))
+	assert.EqualValues(t, 2, s.Size())
+	height, err := s.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, height)
+	height, err = s.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+}
@@ -55,7 +55,9 @@ func VerifyNonAdjacent(
 	}
 
 	// Ensure that +`trustLevel` (default 1/3) or more of last trusted validators signed correctly.
-	err := trustedVals.VerifyCommitLightTrusting(trustedHeader.ChainID, untrustedHeader.Commit, trustLevel)
+	// The validators that rotated their key since the trusted height are skipped.
+	err := trustedVals.VerifyCommitLightTrustingRotated(trustedHeader.ChainID, untrustedHeader.Commit, trustLevel,
+		untrustedVals)
 	if err != nil {
 		switch e := err.(type) {
 		case types.ErrNotEnoughVotingPowerSigned:
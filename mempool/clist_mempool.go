@@ -518,6 +518,7 @@ func (mem *CListMempool) resCbFirstTime(
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
+				hint:      r.CheckTx.Hint,
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
@@ -689,6 +690,19 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	return txs
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) TxHints(txs types.Txs) []ocabci.TxHint {
+	hints := make([]ocabci.TxHint, len(txs))
+	for i, tx := range txs {
+		if e, ok := mem.txsMap.Load(TxKey(tx)); ok {
+			if hint := e.(*clist.CElement).Value.(*mempoolTx).hint; hint != nil {
+				hints[i] = *hint
+			}
+		}
+	}
+	return hints
+}
+
 // Lock() must be held by the caller during execution.
 func (mem *CListMempool) Update(
 	block *types.Block,
@@ -796,9 +810,10 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64          // height that this tx had been validated in
+	gasWanted int64          // amount of gas this tx states it will require
+	tx        types.Tx       //
+	hint      *ocabci.TxHint // dependencies declared by the app at CheckTx, may be nil
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
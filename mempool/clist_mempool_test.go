@@ -757,3 +757,33 @@ func TestTxMempoolPostCheckError(t *testing.T) {
 		})
 	}
 }
+
+type txHintApp struct {
+	ocabci.BaseApplication
+}
+
+func (app *txHintApp) CheckTxSync(req abci.RequestCheckTx) ocabci.ResponseCheckTx {
+	return ocabci.ResponseCheckTx{
+		Code: ocabci.CodeTypeOK,
+		Hint: &ocabci.TxHint{ReadKeys: [][]byte{req.Tx}, WriteKeys: [][]byte{req.Tx}},
+	}
+}
+
+func (app *txHintApp) CheckTxAsync(req abci.RequestCheckTx, callback ocabci.CheckTxCallback) {
+	callback(app.CheckTxSync(req))
+}
+
+func TestMempoolTxHints(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(&txHintApp{})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	_, err := mempool.CheckTxSync(types.Tx{0x01}, TxInfo{})
+	require.NoError(t, err)
+
+	hints := mempool.TxHints(types.Txs{{0x01}, {0x02}})
+	require.Len(t, hints, 2)
+	assert.Equal(t, [][]byte{{0x01}}, hints[0].WriteKeys)
+	assert.Empty(t, hints[1].ReadKeys)
+	assert.Empty(t, hints[1].WriteKeys)
+}
@@ -35,6 +35,10 @@ type Mempool interface {
 	// transactions (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// TxHints returns the dependency hints the app declared at CheckTx for
+	// each of the given txs. Txs that are not in the mempool get an empty hint.
+	TxHints(txs types.Txs) []ocabci.TxHint
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely update.
 	Lock()
 
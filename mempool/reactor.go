@@ -152,6 +152,7 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 		{
 			ID:                  MempoolChannel,
 			Priority:            5,
+			LowPriority:         true,
 			RecvMessageCapacity: batchMsg.Size(),
 		},
 	}
@@ -177,7 +178,7 @@ func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := memR.decodeMsg(msgBytes)
 	if err != nil {
 		memR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		memR.Switch.StopPeerForError(src, err)
+		memR.Switch.MarkPeerAsBad(src, err)
 		return
 	}
 	memR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
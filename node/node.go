@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,7 +25,9 @@ import (
 	cfg "github.com/Finschia/ostracon/config"
 	cs "github.com/Finschia/ostracon/consensus"
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/armor"
 	"github.com/Finschia/ostracon/evidence"
+	"github.com/Finschia/ostracon/keyrotation"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	"github.com/Finschia/ostracon/libs/log"
 	tmpubsub "github.com/Finschia/ostracon/libs/pubsub"
@@ -31,6 +36,7 @@ import (
 	mempl "github.com/Finschia/ostracon/mempool"
 	"github.com/Finschia/ostracon/p2p"
 	"github.com/Finschia/ostracon/p2p/pex"
+	"github.com/Finschia/ostracon/p2p/trust"
 	"github.com/Finschia/ostracon/privval"
 	"github.com/Finschia/ostracon/proxy"
 	rpccore "github.com/Finschia/ostracon/rpc/core"
@@ -93,12 +99,17 @@ type Provider func(*cfg.Config, log.Logger) (*Node, error)
 // PrivValidator, ClientCreator, GenesisDoc, and DBProvider.
 // It implements NodeProvider.
 func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
-	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	unlocker, err := KeyUnlocker(config)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeKey, err := p2p.LoadOrGenNodeKeyWithUnlocker(config.NodeKeyFile(), unlocker)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
-	pv := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pv := privval.LoadOrGenFilePVWithUnlocker(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), unlocker)
 	return NewNode(config,
 		pv,
 		nodeKey,
@@ -113,16 +124,23 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 // NewOstraconNode returns an Ostracon node for more safe production environments that don't automatically generate
 // critical files. This function doesn't reference local key pair in configurations using KMS.
 func NewOstraconNode(config *cfg.Config, logger log.Logger) (*Node, error) {
-	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	unlocker, err := KeyUnlocker(config)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeKey, err := p2p.LoadNodeKeyWithUnlocker(config.NodeKeyFile(), unlocker)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load node key %s: %w", config.NodeKeyFile(), err)
 	}
 
 	var privKey types.PrivValidator
-	if config.PrivValidatorListenAddr == "" {
-		privKey = privval.LoadFilePV(
+	if config.PrivValidatorListenAddr == "" && config.PrivValidatorCosigners == "" &&
+		config.PrivValidatorPKCS11Module == "" {
+		privKey = privval.LoadFilePVWithUnlocker(
 			config.PrivValidatorKeyFile(),
-			config.PrivValidatorStateFile())
+			config.PrivValidatorStateFile(),
+			unlocker)
 	}
 	return NewNode(
 		config,
@@ -218,7 +236,7 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport   *p2p.MultiplexTransport
+	transport   p2pTransport
 	sw          *p2p.Switch  // p2p connections
 	addrBook    pex.AddrBook // known peers
 	nodeInfo    p2p.NodeInfo
@@ -240,6 +258,7 @@ type Node struct {
 	consensusReactor  *cs.Reactor             // for participating in the consensus
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
 	evidencePool      *evidence.Pool          // tracking evidence
+	keyRotationPool   *keyrotation.Pool       // tracking key rotations
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
 	txIndexer         txindex.TxIndexer
@@ -367,12 +386,11 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 		)
 	}
 
-	addr := pubKey.Address()
 	// Log whether this node is a validator or an observer
-	if state.Validators.HasAddress(addr) {
-		consensusLogger.Info("This node is a validator", "addr", addr, "pubKey", pubKey)
+	if _, val := state.Validators.GetByPubKey(pubKey); val != nil {
+		consensusLogger.Info("This node is a validator", "addr", val.Address, "pubKey", pubKey)
 	} else {
-		consensusLogger.Info("This node is not a validator", "addr", addr, "pubKey", pubKey)
+		consensusLogger.Info("This node is not a validator", "addr", pubKey.Address(), "pubKey", pubKey)
 	}
 }
 
@@ -380,8 +398,9 @@ func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 	if state.Validators.Size() > 1 {
 		return false
 	}
-	addr, _ := state.Validators.GetByIndex(0)
-	return bytes.Equal(pubKey.Address(), addr)
+	// the validator may have rotated its key, so its address may not be the one of pubKey
+	_, val := state.Validators.GetByPubKey(pubKey)
+	return val != nil
 }
 
 func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
@@ -406,7 +425,8 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 }
 
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
-	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger) (*evidence.Reactor, *evidence.Pool, error) {
+	stateDB dbm.DB, blockStore *store.BlockStore, eventBus *types.EventBus,
+	logger log.Logger) (*evidence.Reactor, *evidence.Pool, error) {
 
 	evidenceDB, err := dbProvider(&DBContext{"evidence", config})
 	if err != nil {
@@ -417,11 +437,30 @@ func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
 	if err != nil {
 		return nil, nil, err
 	}
+	evidencePool.SetEventBus(eventBus)
 	evidenceReactor := evidence.NewReactor(evidencePool, config.P2P.RecvAsync, config.P2P.EvidenceRecvBufSize)
 	evidenceReactor.SetLogger(evidenceLogger)
 	return evidenceReactor, evidencePool, nil
 }
 
+func createKeyRotationReactor(config *cfg.Config, dbProvider DBProvider, stateDB dbm.DB,
+	logger log.Logger) (*keyrotation.Reactor, *keyrotation.Pool, error) {
+
+	keyRotationDB, err := dbProvider(&DBContext{"keyrotation", config})
+	if err != nil {
+		return nil, nil, err
+	}
+	keyRotationPool, err := keyrotation.NewPool(keyRotationDB, sm.NewStore(stateDB))
+	if err != nil {
+		return nil, nil, err
+	}
+	// key rotations are rare, like evidence
+	keyRotationReactor := keyrotation.NewReactor(keyRotationPool, config.P2P.RecvAsync,
+		config.P2P.EvidenceRecvBufSize)
+	keyRotationReactor.SetLogger(logger.With("module", "keyrotation"))
+	return keyRotationReactor, keyRotationPool, nil
+}
+
 func createBlockchainReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
@@ -480,18 +519,28 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// p2pTransport is the transport of a node, either a p2p.MultiplexTransport or
+// a p2p.QUICTransport.
+type p2pTransport interface {
+	p2p.Transport
+	Listen(p2p.NetAddress) error
+	Close() error
+	AddChannel(chID byte) error
+}
+
 func createTransport(
 	config *cfg.Config,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
+	banList *p2p.BanList,
 ) (
-	*p2p.MultiplexTransport,
+	p2pTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
 		mConnConfig = p2p.MConnConfig(config.P2P)
-		transport   = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
 		connFilters = []p2p.ConnFilterFunc{}
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
@@ -540,13 +589,27 @@ func createTransport(
 		)
 	}
 
-	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
-
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
-	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
 
-	return transport, peerFilters
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		transport, err := p2p.NewQUICTransport(nodeInfo, *nodeKey, mConnConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		p2p.QUICTransportConnFilters(connFilters...)(transport)
+		p2p.QUICTransportMaxIncomingConnections(max)(transport)
+		p2p.QUICTransportBanList(banList)(transport)
+		return transport, peerFilters, nil
+	}
+
+	transport := p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig,
+		p2p.MultiplexTransportConnFilters(connFilters...),
+		p2p.MultiplexTransportMaxIncomingConnections(max),
+		p2p.MultiplexTransportBanList(banList),
+	)
+
+	return transport, peerFilters, nil
 }
 
 func createSwitch(config *cfg.Config,
@@ -558,6 +621,9 @@ func createSwitch(config *cfg.Config,
 	stateSyncReactor *statesync.Reactor,
 	consensusReactor *cs.Reactor,
 	evidenceReactor *evidence.Reactor,
+	keyRotationReactor *keyrotation.Reactor,
+	trustMetricStore *trust.MetricStore,
+	banList *p2p.BanList,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	p2pLogger log.Logger) *p2p.Switch {
@@ -567,12 +633,16 @@ func createSwitch(config *cfg.Config,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.WithTrustMetricStore(trustMetricStore),
+		p2p.WithBanList(banList),
+		p2p.WithChannelRates(channelRates(config.P2P)),
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
 	sw.AddReactor("BLOCKCHAIN", bcReactor)
 	sw.AddReactor("CONSENSUS", consensusReactor)
 	sw.AddReactor("EVIDENCE", evidenceReactor)
+	sw.AddReactor("KEYROTATION", keyRotationReactor)
 	sw.AddReactor("STATESYNC", stateSyncReactor)
 
 	sw.SetNodeInfo(nodeInfo)
@@ -582,6 +652,39 @@ func createSwitch(config *cfg.Config,
 	return sw
 }
 
+// channelRates returns the configured rates of the channels carrying bulk data,
+// so that they don't starve the others.
+func channelRates(config *cfg.P2PConfig) map[byte]p2p.ChannelRate {
+	stateSyncRate := p2p.ChannelRate{SendRate: config.StateSyncSendRate, RecvRate: config.StateSyncRecvRate}
+	return map[byte]p2p.ChannelRate{
+		mempl.MempoolChannel: {SendRate: config.MempoolSendRate, RecvRate: config.MempoolRecvRate},
+		// all the fast sync versions use the same channel
+		bcv0.BlockchainChannel:     {SendRate: config.FastSyncSendRate, RecvRate: config.FastSyncRecvRate},
+		statesync.ChunkChannel:     stateSyncRate,
+		statesync.NodeChunkChannel: stateSyncRate,
+	}
+}
+
+func createBanList(config *cfg.Config, dbProvider DBProvider) (*p2p.BanList, error) {
+	banListDB, err := dbProvider(&DBContext{"banlist", config})
+	if err != nil {
+		return nil, err
+	}
+	return p2p.NewBanList(banListDB)
+}
+
+func createTrustMetricStore(config *cfg.Config, dbProvider DBProvider,
+	p2pLogger log.Logger) (*trust.MetricStore, error) {
+
+	trustHistoryDB, err := dbProvider(&DBContext{"trusthistory", config})
+	if err != nil {
+		return nil, err
+	}
+	trustMetricStore := trust.NewTrustMetricStore(trustHistoryDB, trust.DefaultConfig())
+	trustMetricStore.SetLogger(p2pLogger)
+	return trustMetricStore, nil
+}
+
 func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 	p2pLogger log.Logger, nodeKey *p2p.NodeKey) (pex.AddrBook, error) {
 
@@ -635,27 +738,46 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 // startStateSync starts an asynchronous state sync process, then switches to fast sync mode.
 func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reactor,
 	stateProvider statesync.StateProvider, config *cfg.StateSyncConfig, fastSync bool,
-	stateStore sm.Store, blockStore *store.BlockStore, state sm.State) error {
+	stateStore sm.Store, blockStore *store.BlockStore, evidencePool *evidence.Pool, state sm.State) error {
 	ssR.Logger.Info("Starting state sync")
 
-	if stateProvider == nil {
+	// Evidence of attacks detected by the light client is gossiped to our peers.
+	broadcastEvidence := light.BroadcastEvidence(evidencePool)
+
+	trustOptions := light.TrustOptions{
+		Period: config.TrustPeriod,
+		Height: config.TrustHeight,
+		Hash:   config.TrustHashBytes(),
+	}
+	if stateProvider == nil && !config.UseP2P {
 		var err error
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		stateProvider, err = statesync.NewLightClientStateProvider(
 			ctx,
 			state.ChainID, state.Version, state.InitialHeight,
-			config.RPCServers, light.TrustOptions{
-				Period: config.TrustPeriod,
-				Height: config.TrustHeight,
-				Hash:   config.TrustHashBytes(),
-			}, ssR.Logger.With("module", "light"))
+			config.RPCServers, trustOptions, ssR.Logger.With("module", "light"), broadcastEvidence)
 		if err != nil {
 			return fmt.Errorf("failed to set up light client state provider: %w", err)
 		}
 	}
 
 	go func() {
+		if stateProvider == nil {
+			// The peers are still being dialed, so give them time to connect.
+			var err error
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			stateProvider, err = ssR.NewP2PStateProvider(
+				ctx,
+				state.ChainID, state.Version, state.InitialHeight,
+				trustOptions, ssR.Logger.With("module", "light"), broadcastEvidence)
+			cancel()
+			if err != nil {
+				ssR.Logger.Error("Failed to set up p2p state provider", "err", err)
+				return
+			}
+		}
+
 		state, previousState, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
 		if err != nil {
 			ssR.Logger.Error("State sync failed", "err", err)
@@ -678,6 +800,16 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 			ssR.Logger.Error("Failed to store last seen commit", "err", err)
 			return
 		}
+		// The node snapshot only provides history, so carry on without it if it can't be restored.
+		err = ssR.RestoreNodeSnapshot(state, commit, config.DiscoveryTime)
+		if err != nil {
+			ssR.Logger.Error("Failed to restore node snapshot", "err", err)
+		}
+		// Without the backfilled headers evidence can't be verified, but the node still works.
+		err = ssR.Backfill(state)
+		if err != nil {
+			ssR.Logger.Error("Backfill failed, proceeding without the earlier headers", "err", err)
+		}
 
 		if fastSync {
 			// FIXME Very ugly to have these metrics bleed through here.
@@ -740,7 +872,7 @@ func NewNode(config *cfg.Config,
 	}
 
 	// If an address is provided, listen on the socket for a connection from an
-	// external signing process.
+	// external signing process. If several are, fail over between them.
 	if config.PrivValidatorListenAddr != "" {
 		// FIXME: we should start services inside OnStart
 		privValidator, err = CreateAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
@@ -749,6 +881,22 @@ func NewNode(config *cfg.Config,
 		}
 	}
 
+	// If cosigners are provided, sign with a threshold of them.
+	if config.PrivValidatorCosigners != "" {
+		privValidator, err = CreateThresholdPrivValidator(config, nodeKey, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with threshold private validator: %w", err)
+		}
+	}
+
+	// If a PKCS#11 module is provided, sign with the key held in the HSM.
+	if config.PrivValidatorPKCS11Module != "" {
+		privValidator, err = privval.LoadPKCS11PV(PKCS11Config(config), config.PrivValidatorStateFile())
+		if err != nil {
+			return nil, fmt.Errorf("error with PKCS#11 private validator: %w", err)
+		}
+	}
+
 	pubKey, err := privValidator.GetPubKey()
 	if err != nil {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
@@ -790,7 +938,13 @@ func NewNode(config *cfg.Config,
 	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
 
 	// Make Evidence Reactor
-	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, logger)
+	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, eventBus, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make KeyRotation Reactor
+	keyRotationReactor, keyRotationPool, err := createKeyRotationReactor(config, dbProvider, stateDB, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -803,6 +957,8 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evidencePool,
 		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithDeliverTxBatch(config.DeliverTxBatch),
+		sm.BlockExecutorWithKeyRotationPool(keyRotationPool),
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
@@ -827,10 +983,18 @@ func NewNode(config *cfg.Config,
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
 	// https://github.com/tendermint/tendermint/issues/4644
+	stateSyncConfig := *config.StateSync
+	if stateSyncConfig.TempDir == "" {
+		// Keep the chunks in the data dir by default, so that an interrupted state sync is resumed
+		// after a restart.
+		stateSyncConfig.TempDir = filepath.Join(config.DBDir(), "statesync")
+	}
 	stateSyncReactor := statesync.NewReactor(
-		*config.StateSync,
+		stateSyncConfig,
 		proxyApp.Snapshot(),
 		proxyApp.Query(),
+		stateStore,
+		blockStore,
 		config.P2P.RecvAsync,
 		config.P2P.StatesyncRecvBufSize)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
@@ -841,13 +1005,25 @@ func NewNode(config *cfg.Config,
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	banList, err := createBanList(config, dbProvider)
+	if err != nil {
+		return nil, fmt.Errorf("could not create ban list: %w", err)
+	}
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp, banList)
+	if err != nil {
+		return nil, fmt.Errorf("could not create transport: %w", err)
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
+	trustMetricStore, err := createTrustMetricStore(config, dbProvider, p2pLogger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create trust metric store: %w", err)
+	}
 	sw := createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		stateSyncReactor, consensusReactor, evidenceReactor, keyRotationReactor, trustMetricStore, banList, nodeInfo,
+		nodeKey, p2pLogger,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -912,6 +1088,7 @@ func NewNode(config *cfg.Config,
 		stateSyncGenesis: state, // Shouldn't be necessary, but need a way to pass the genesis state
 		pexReactor:       pexReactor,
 		evidencePool:     evidencePool,
+		keyRotationPool:  keyRotationPool,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
@@ -991,7 +1168,7 @@ func (n *Node) OnStart() error {
 			return fmt.Errorf("this blockchain reactor does not support switching from state sync")
 		}
 		err := startStateSync(n.stateSyncReactor, bcR, n.consensusReactor, n.stateSyncProvider,
-			n.config.StateSync, n.config.FastSyncMode, n.stateStore, n.blockStore, n.stateSyncGenesis)
+			n.config.StateSync, n.config.FastSyncMode, n.stateStore, n.blockStore, n.evidencePool, n.stateSyncGenesis)
 		if err != nil {
 			return fmt.Errorf("failed to start state sync: %w", err)
 		}
@@ -1072,12 +1249,14 @@ func (n *Node) ConfigureRPC() error {
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
 
-		StateStore:     n.stateStore,
-		BlockStore:     n.blockStore,
-		EvidencePool:   n.evidencePool,
-		ConsensusState: n.consensusState,
-		P2PPeers:       n.sw,
-		P2PTransport:   n,
+		StateStore:      n.stateStore,
+		BlockStore:      n.blockStore,
+		EvidencePool:    n.evidencePool,
+		EvidenceLister:  n.evidencePool,
+		KeyRotationPool: n.keyRotationPool,
+		ConsensusState:  n.consensusState,
+		P2PPeers:        n.sw,
+		P2PTransport:    n,
 
 		PubKey:           pubKey,
 		GenDoc:           n.genesisDoc,
@@ -1281,6 +1460,11 @@ func (n *Node) EvidencePool() *evidence.Pool {
 	return n.evidencePool
 }
 
+// KeyRotationPool returns the Node's KeyRotationPool.
+func (n *Node) KeyRotationPool() *keyrotation.Pool {
+	return n.keyRotationPool
+}
+
 // EventBus returns the Node's EventBus.
 func (n *Node) EventBus() *types.EventBus {
 	return n.eventBus
@@ -1362,6 +1546,7 @@ func makeNodeInfo(
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
 			evidence.EvidenceChannel,
+			keyrotation.KeyRotationChannel,
 			statesync.SnapshotChannel, statesync.ChunkChannel,
 		},
 		Moniker: config.Moniker,
@@ -1486,6 +1671,42 @@ func CreateAndStartPrivValidatorSocketClient(
 	chainID string,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
+	const (
+		retries = 50 // 50 * 100ms = 5s total
+		timeout = 100 * time.Millisecond
+	)
+
+	// If several addresses are provided, fail over between the signers
+	listenAddrs := splitAndTrimEmpty(listenAddr, ",", " ")
+	if len(listenAddrs) > 1 {
+		signers := make([]*privval.SignerClient, len(listenAddrs))
+		for i, addr := range listenAddrs {
+			pve, err := privval.NewSignerListener(addr, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start private validator: %w", err)
+			}
+			signers[i], err = privval.NewSignerClient(pve, chainID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start private validator: %w", err)
+			}
+		}
+		pvsc, err := privval.NewFailoverSignerClient(signers, retries, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+
+		// try to get a pubkey from any private validator first time
+		_, err = pvsc.GetPubKey()
+		if err != nil {
+			return nil, fmt.Errorf("can't get pubkey: %w", err)
+		}
+		// and refuse signers of another key
+		if err := pvsc.CheckPubKeys(); err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		return pvsc, nil
+	}
+
 	pve, err := privval.NewSignerListener(listenAddr, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
@@ -1502,15 +1723,91 @@ func CreateAndStartPrivValidatorSocketClient(
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
 
-	const (
-		retries = 50 // 50 * 100ms = 5s total
-		timeout = 100 * time.Millisecond
-	)
 	pvscWithRetries := privval.NewRetrySignerClient(pvsc, retries, timeout)
 
 	return pvscWithRetries, nil
 }
 
+// CreateThresholdPrivValidator returns a ThresholdSigner signing with the key
+// share of the node and the cosigners of the config, which the node connects
+// to with its node key.
+func CreateThresholdPrivValidator(
+	config *cfg.Config,
+	nodeKey *p2p.NodeKey,
+	logger log.Logger,
+) (*privval.ThresholdSigner, error) {
+	local, err := privval.LoadLocalCosigner(config.PrivValidatorKeyShareFile(), config.PrivValidatorCosignerStateFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key share: %w", err)
+	}
+
+	cosigners := []privval.Cosigner{local}
+	for _, cosigner := range splitAndTrimEmpty(config.PrivValidatorCosigners, ",", " ") {
+		parts := strings.SplitN(cosigner, "@", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid cosigner %q, expected id@node_id@tcp://host:port", cosigner)
+		}
+		id, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cosigner ID %q: %w", parts[0], err)
+		}
+		remote, err := privval.NewRemoteCosigner(id, parts[1], parts[2], nodeKey.PrivKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cosigner client: %w", err)
+		}
+		cosigners = append(cosigners, remote)
+	}
+
+	ts, err := privval.NewThresholdSigner(local.Group(), cosigners,
+		config.PrivValidatorStateFile(), privval.DefaultCosignerTimeout)
+	if err != nil {
+		return nil, err
+	}
+	ts.SetLogger(logger.With("module", "privval"))
+	return ts, nil
+}
+
+// PKCS11Config returns the PKCS#11 token holding the validator key in the
+// config.
+func PKCS11Config(config *cfg.Config) privval.PKCS11Config {
+	return privval.PKCS11Config{
+		ModulePath:     config.PrivValidatorPKCS11Module,
+		TokenLabel:     config.PrivValidatorPKCS11Token,
+		PIN:            config.PrivValidatorPKCS11PIN,
+		KeyLabel:       config.PrivValidatorPKCS11KeyLabel,
+		VRFKeyFilePath: config.PrivValidatorVRFKeyFile(),
+	}
+}
+
+// KeyPassphraseEnvVar is the environment variable the passphrase of encrypted
+// key files is read from when neither key_passphrase_file nor key_kms_command
+// is set.
+const KeyPassphraseEnvVar = "OC_KEY_PASSPHRASE"
+
+// KeyUnlocker returns the unlocker of encrypted key files in the config: the
+// KMS command, the passphrase file or the passphrase of KeyPassphraseEnvVar,
+// in this order. It returns nil if none of them is set.
+func KeyUnlocker(config *cfg.Config) (armor.Unlocker, error) {
+	if config.KeyKMSCommand != "" {
+		return armor.KMSCommand(strings.Fields(config.KeyKMSCommand)), nil
+	}
+	if path := config.KeyPassphraseFilePath(); path != "" {
+		passphrase, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key passphrase file: %w", err)
+		}
+		passphrase = bytes.TrimRight(passphrase, "\r\n")
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("key passphrase file %s is empty", path)
+		}
+		return armor.Passphrase(passphrase), nil
+	}
+	if passphrase := os.Getenv(KeyPassphraseEnvVar); passphrase != "" {
+		return armor.Passphrase(passphrase), nil
+	}
+	return nil, nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
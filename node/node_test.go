@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"testing"
@@ -20,7 +21,9 @@ import (
 
 	"github.com/Finschia/ostracon/abci/example/kvstore"
 	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/armor"
 	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/threshold"
 	"github.com/Finschia/ostracon/evidence"
 	"github.com/Finschia/ostracon/libs/log"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
@@ -118,6 +121,34 @@ func TestSplitAndTrimEmpty(t *testing.T) {
 	}
 }
 
+func TestKeyUnlocker(t *testing.T) {
+	config := cfg.ResetTestRoot("key_unlocker_test")
+	defer os.RemoveAll(config.RootDir)
+	t.Setenv(KeyPassphraseEnvVar, "")
+
+	unlocker, err := KeyUnlocker(config)
+	require.NoError(t, err)
+	assert.Nil(t, unlocker)
+
+	t.Setenv(KeyPassphraseEnvVar, "env passphrase")
+	unlocker, err = KeyUnlocker(config)
+	require.NoError(t, err)
+	assert.Equal(t, armor.Passphrase("env passphrase"), unlocker)
+
+	config.KeyPassphraseFile = "passphrase"
+	_, err = KeyUnlocker(config)
+	assert.Error(t, err)
+	require.NoError(t, os.WriteFile(config.KeyPassphraseFilePath(), []byte("file passphrase\n"), 0600))
+	unlocker, err = KeyUnlocker(config)
+	require.NoError(t, err)
+	assert.Equal(t, armor.Passphrase("file passphrase"), unlocker)
+
+	config.KeyKMSCommand = "kms-plugin --key validator"
+	unlocker, err = KeyUnlocker(config)
+	require.NoError(t, err)
+	assert.Equal(t, armor.KMSCommand{"kms-plugin", "--key", "validator"}, unlocker)
+}
+
 func TestNodeDelayedStart(t *testing.T) {
 	config := cfg.ResetTestRoot("node_delayed_start_test")
 	defer os.RemoveAll(config.RootDir)
@@ -189,6 +220,41 @@ func TestNodeSetPrivValTCP(t *testing.T) {
 	assert.IsType(t, &privval.RetrySignerClient{}, n.PrivValidator())
 }
 
+func TestNodeSetPrivValFailover(t *testing.T) {
+	addrs := []string{"tcp://" + testFreeAddr(t), "tcp://" + testFreeAddr(t)}
+
+	config := cfg.ResetTestRoot("node_priv_val_failover_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorListenAddr = strings.Join(addrs, ",")
+
+	// only the second signer is up, and waits for the node to give up on the
+	// first one before being accepted
+	dialer := privval.DialTCPFn(addrs[1], 5*time.Second, ed25519.GenPrivKey())
+	dialerEndpoint := privval.NewSignerDialerEndpoint(
+		log.TestingLogger(),
+		dialer,
+	)
+
+	signerServer := privval.NewSignerServer(
+		dialerEndpoint,
+		config.ChainID(),
+		types.NewMockPV(),
+	)
+
+	go func() {
+		err := signerServer.Start()
+		if err != nil {
+			panic(err)
+		}
+	}()
+	defer signerServer.Stop() //nolint:errcheck // ignore for tests
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.IsType(t, &privval.FailoverSignerClient{}, n.PrivValidator())
+	assert.Equal(t, 1, n.PrivValidator().(*privval.FailoverSignerClient).Active())
+}
+
 // address without a protocol must result in error
 func TestPrivValidatorListenAddrNoProtocol(t *testing.T) {
 	addrNoPrefix := testFreeAddr(t)
@@ -233,6 +299,34 @@ func TestNodeSetPrivValIPC(t *testing.T) {
 	assert.IsType(t, &privval.RetrySignerClient{}, n.PrivValidator())
 }
 
+func TestNodeSetPrivValThreshold(t *testing.T) {
+	config := cfg.ResetTestRoot("node_priv_val_threshold_test")
+	defer os.RemoveAll(config.RootDir)
+
+	privKey := ed25519.GenPrivKey()
+	shares, err := threshold.SplitKey(privKey, 2, 2)
+	require.NoError(t, err)
+	require.NoError(t, privval.SaveKeyShare(shares[0], config.PrivValidatorKeyShareFile()))
+	remote, err := privval.NewLocalCosigner(shares[1], filepath.Join(config.RootDir, "cosigner_state.json"))
+	require.NoError(t, err)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	cosignerKey := p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	server := privval.NewCosignerServer("tcp://127.0.0.1:0", remote, cosignerKey.PrivKey,
+		[]string{string(nodeKey.ID())}, log.TestingLogger())
+	require.NoError(t, server.Start())
+	defer server.Stop() //nolint:errcheck // ignore for tests
+	config.BaseConfig.PrivValidatorCosigners = fmt.Sprintf("2@%s@tcp://%s", cosignerKey.ID(), server.Addr())
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.IsType(t, &privval.ThresholdSigner{}, n.PrivValidator())
+	pubKey, err := n.PrivValidator().GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+}
+
 // testFreeAddr claims a free port so we don't block on listener being ready.
 func testFreeAddr(t *testing.T) string {
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
@@ -649,7 +743,10 @@ func NewInvalidNode(config *cfg.Config,
 		return nil, err
 	}
 
-	transport, _ := createTransport(config, &p2pmocks.NodeInfo{}, nodeKey, n.proxyApp)
+	transport, _, err := createTransport(config, &p2pmocks.NodeInfo{}, nodeKey, n.proxyApp, nil)
+	if err != nil {
+		return nil, err
+	}
 	n.transport = transport
 
 	for _, option := range options {
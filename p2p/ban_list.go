@@ -0,0 +1,262 @@
+package p2p
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+)
+
+var banKeyPrefix = []byte("ban/")
+
+// ErrBanNotFound is returned when unbanning a target that isn't in the BanList.
+var ErrBanNotFound = errors.New("ban not found")
+
+var errNoBanList = errors.New("ban list is not enabled")
+
+// Ban is an entry of the BanList. It bans, or allows if Allowed is set, either
+// a node ID or an IP range.
+type Ban struct {
+	// Target is a node ID, or an IP range in CIDR notation.
+	Target  string    `json:"target"`
+	Reason  string    `json:"reason"`
+	Allowed bool      `json:"allowed"`
+	Created time.Time `json:"created"`
+	// Expires is the time the entry expires at, or zero if it never does.
+	Expires time.Time `json:"expires"`
+
+	id    ID
+	ipNet *net.IPNet
+}
+
+func (b *Ban) expired(now time.Time) bool {
+	return !b.Expires.IsZero() && !b.Expires.After(now)
+}
+
+func (b *Ban) matches(id ID, ip net.IP) bool {
+	if b.ipNet != nil {
+		return ip != nil && b.ipNet.Contains(ip)
+	}
+	return id != "" && b.id == id
+}
+
+// BanList is a list of banned and allowed node IDs and IP ranges, persisted
+// in a database. Allowed entries take precedence over banned ones, so that
+// specific nodes of a banned IP range can still connect, and allowed nodes are
+// never banned automatically.
+//
+// Safe for concurrent use by multiple goroutines.
+type BanList struct {
+	db dbm.DB
+
+	mtx  tmsync.RWMutex
+	bans map[string]*Ban // by target
+}
+
+// NewBanList returns a BanList, loading the entries saved in the db.
+func NewBanList(db dbm.DB) (*BanList, error) {
+	bl := &BanList{db: db, bans: make(map[string]*Ban)}
+
+	itr, err := dbm.IteratePrefix(db, banKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		b := new(Ban)
+		if err := json.Unmarshal(itr.Value(), b); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ban: %w", err)
+		}
+		if b.Target, b.id, b.ipNet, err = parseBanTarget(b.Target); err != nil {
+			return nil, err
+		}
+		bl.bans[b.Target] = b
+	}
+	return bl, itr.Error()
+}
+
+// Ban bans the target, which is a node ID, an IP address or an IP range in
+// CIDR notation, for the given duration, or forever if it's zero. It replaces
+// any entry of the target.
+func (bl *BanList) Ban(target string, duration time.Duration, reason string) (*Ban, error) {
+	return bl.add(target, duration, reason, false)
+}
+
+// Allow allows the target, which is a node ID, an IP address or an IP range in
+// CIDR notation, regardless of the bans. It replaces any entry of the target.
+func (bl *BanList) Allow(target string, reason string) (*Ban, error) {
+	return bl.add(target, 0, reason, true)
+}
+
+func (bl *BanList) add(target string, duration time.Duration, reason string, allowed bool) (*Ban, error) {
+	if duration < 0 {
+		return nil, fmt.Errorf("negative duration %v", duration)
+	}
+	target, id, ipNet, err := parseBanTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	b := &Ban{
+		Target:  target,
+		Reason:  reason,
+		Allowed: allowed,
+		Created: now,
+		id:      id,
+		ipNet:   ipNet,
+	}
+	if duration > 0 {
+		b.Expires = now.Add(duration)
+	}
+	bz, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	if err := bl.pruneExpired(now); err != nil {
+		return nil, err
+	}
+	if err := bl.db.SetSync(banKey(target), bz); err != nil {
+		return nil, fmt.Errorf("failed to save ban: %w", err)
+	}
+	bl.bans[target] = b
+	return b, nil
+}
+
+// Unban removes the entry of the target, banned or allowed. It returns
+// ErrBanNotFound if there is none.
+func (bl *BanList) Unban(target string) error {
+	target, _, _, err := parseBanTarget(target)
+	if err != nil {
+		return err
+	}
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	if _, ok := bl.bans[target]; !ok {
+		return ErrBanNotFound
+	}
+	if err := bl.db.DeleteSync(banKey(target)); err != nil {
+		return fmt.Errorf("failed to delete ban: %w", err)
+	}
+	delete(bl.bans, target)
+	return nil
+}
+
+// List returns the entries which haven't expired, sorted by target.
+func (bl *BanList) List() []*Ban {
+	now := time.Now()
+
+	bl.mtx.RLock()
+	defer bl.mtx.RUnlock()
+
+	bans := make([]*Ban, 0, len(bl.bans))
+	for _, b := range bl.bans {
+		if !b.expired(now) {
+			bans = append(bans, b)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].Target < bans[j].Target })
+	return bans
+}
+
+// IsAllowed returns true if the node ID or the IP, either of which can be
+// empty, is allowed.
+func (bl *BanList) IsAllowed(id ID, ip net.IP) bool {
+	return bl.match(id, ip, true) != nil
+}
+
+// IsBanned returns the ban of the node ID or the IP, either of which can be
+// empty, or nil if they aren't banned or are allowed.
+func (bl *BanList) IsBanned(id ID, ip net.IP) *Ban {
+	if bl.IsAllowed(id, ip) {
+		return nil
+	}
+	return bl.match(id, ip, false)
+}
+
+func (bl *BanList) match(id ID, ip net.IP, allowed bool) *Ban {
+	now := time.Now()
+
+	bl.mtx.RLock()
+	defer bl.mtx.RUnlock()
+
+	for _, b := range bl.bans {
+		if b.Allowed == allowed && !b.expired(now) && b.matches(id, ip) {
+			return b
+		}
+	}
+	return nil
+}
+
+// hasAllowedIDs returns true if any node ID is allowed.
+func (bl *BanList) hasAllowedIDs() bool {
+	now := time.Now()
+
+	bl.mtx.RLock()
+	defer bl.mtx.RUnlock()
+
+	for _, b := range bl.bans {
+		if b.Allowed && b.ipNet == nil && !b.expired(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneExpired deletes the expired entries. It requires a lock.
+func (bl *BanList) pruneExpired(now time.Time) error {
+	for target, b := range bl.bans {
+		if !b.expired(now) {
+			continue
+		}
+		if err := bl.db.DeleteSync(banKey(target)); err != nil {
+			return fmt.Errorf("failed to delete ban: %w", err)
+		}
+		delete(bl.bans, target)
+	}
+	return nil
+}
+
+func banKey(target string) []byte {
+	return append(append([]byte{}, banKeyPrefix...), target...)
+}
+
+// parseBanTarget parses a node ID, an IP address or an IP range in CIDR
+// notation, returning its canonical form.
+func parseBanTarget(target string) (string, ID, *net.IPNet, error) {
+	target = strings.TrimSpace(target)
+	if strings.Contains(target, "/") {
+		_, ipNet, err := net.ParseCIDR(target)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("invalid IP range %q: %w", target, err)
+		}
+		return ipNet.String(), "", ipNet, nil
+	}
+	if ip := net.ParseIP(target); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		ipNet := &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		return ipNet.String(), "", ipNet, nil
+	}
+	id := ID(strings.ToLower(target))
+	if err := validateID(id); err != nil {
+		return "", "", nil, fmt.Errorf("%q is neither a node ID nor an IP range: %w", target, err)
+	}
+	return string(id), id, nil, nil
+}
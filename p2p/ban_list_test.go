@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/crypto/ed25519"
+)
+
+func TestBanList(t *testing.T) {
+	db := dbm.NewMemDB()
+	bl, err := NewBanList(db)
+	require.NoError(t, err)
+
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	allowedID := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	ip := net.ParseIP("10.1.2.3")
+
+	assert.Nil(t, bl.IsBanned(id, ip))
+
+	// invalid targets
+	for _, target := range []string{"", "foo", "10.0.0.0/33", "10.0.0.256"} {
+		_, err := bl.Ban(target, 0, "")
+		assert.Error(t, err, target)
+	}
+	_, err = bl.Ban("10.0.0.0/8", -time.Second, "")
+	assert.Error(t, err)
+
+	// ban by ID
+	ban, err := bl.Ban(string(id), 0, "bad blocks")
+	require.NoError(t, err)
+	assert.Equal(t, string(id), ban.Target)
+	assert.True(t, ban.Expires.IsZero())
+	assert.Equal(t, ban, bl.IsBanned(id, nil))
+	assert.Nil(t, bl.IsBanned(allowedID, nil))
+
+	// ban by IP range
+	ban, err = bl.Ban("10.1.0.0/16", time.Hour, "spam")
+	require.NoError(t, err)
+	assert.Equal(t, "10.1.0.0/16", ban.Target)
+	assert.Equal(t, ban, bl.IsBanned("", ip))
+	assert.Equal(t, ban, bl.IsBanned(allowedID, ip))
+	assert.Nil(t, bl.IsBanned("", net.ParseIP("10.2.0.1")))
+
+	// ban by IP, which expires
+	ban, err = bl.Ban("192.168.0.1", time.Millisecond, "spam")
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.1/32", ban.Target)
+	time.Sleep(2 * time.Millisecond)
+	assert.Nil(t, bl.IsBanned("", net.ParseIP("192.168.0.1")))
+
+	// allowances take precedence
+	assert.False(t, bl.hasAllowedIDs())
+	_, err = bl.Allow(string(allowedID), "validator")
+	require.NoError(t, err)
+	assert.True(t, bl.hasAllowedIDs())
+	assert.True(t, bl.IsAllowed(allowedID, ip))
+	assert.Nil(t, bl.IsBanned(allowedID, ip))
+
+	bans := bl.List()
+	require.Len(t, bans, 3)
+	targets := make(map[string]*Ban, len(bans))
+	for _, b := range bans {
+		targets[b.Target] = b
+	}
+	if assert.Contains(t, targets, "10.1.0.0/16") {
+		assert.Equal(t, "spam", targets["10.1.0.0/16"].Reason)
+	}
+
+	// the entries are persisted
+	bl2, err := NewBanList(db)
+	require.NoError(t, err)
+	assert.Equal(t, "spam", bl2.IsBanned("", ip).Reason)
+	assert.NotNil(t, bl2.IsBanned(id, nil))
+	assert.Nil(t, bl2.IsBanned(allowedID, ip))
+
+	// unban
+	require.NoError(t, bl.Unban("10.1.0.0/16"))
+	assert.Nil(t, bl.IsBanned("", ip))
+	assert.Equal(t, ErrBanNotFound, bl.Unban("10.1.0.0/16"))
+	require.NoError(t, bl.Unban(string(allowedID)))
+	assert.False(t, bl.IsAllowed(allowedID, ip))
+
+	// expired entries are pruned on the next ban
+	_, err = bl.Ban("10.2.0.0/16", 0, "")
+	require.NoError(t, err)
+	bl3, err := NewBanList(db)
+	require.NoError(t, err)
+	assert.Len(t, bl3.bans, 2)
+}
@@ -33,6 +33,17 @@ const (
 	minWriteBufferSize = 65536
 	updateStats        = 2 * time.Second
 
+	// wait before retrying to send on channels throttled by their send rate
+	throttleWait = 100 * time.Millisecond
+
+	// share of the recently sent bytes up to which strict priority channels
+	// are sent before the others, so that they can't starve them
+	maxStrictPriorityShare = 0.8
+
+	// a channel whose send queue is filled beyond this ratio saturates the
+	// connection
+	saturatedQueueRatio = 0.5
+
 	// some of these defaults are written in the user config
 	// flushThrottle, sendRate, recvRate
 	// TODO: remove values present in config
@@ -106,8 +117,9 @@ type MConnection struct {
 	// are safe to call concurrently.
 	stopMtx tmsync.Mutex
 
-	flushTimer *timer.ThrottleTimer // flush writes as necessary but throttled.
-	pingTimer  *time.Ticker         // send pings periodically
+	flushTimer    *timer.ThrottleTimer // flush writes as necessary but throttled.
+	throttleTimer *timer.ThrottleTimer // wake up the sendRoutine for throttled channels.
+	pingTimer     *time.Ticker         // send pings periodically
 
 	// close conn if pong is not received in pongTimeout
 	pongTimer     *time.Timer
@@ -139,6 +151,10 @@ type MConnConfig struct {
 
 	// Action method of reactor's receive function
 	RecvAsync bool `mapstructure:"recv_async"`
+
+	// Drop messages of low priority channels instead of queueing them while
+	// the connection is saturated
+	DropLowPriority bool `mapstructure:"drop_low_priority"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -228,6 +244,7 @@ func (c *MConnection) OnStart() error {
 		return err
 	}
 	c.flushTimer = timer.NewThrottleTimer("flush", c.config.FlushThrottle)
+	c.throttleTimer = timer.NewThrottleTimer("throttle", throttleWait)
 	c.pingTimer = time.NewTicker(c.config.PingInterval)
 	c.pongTimeoutCh = make(chan bool, 1)
 	c.chStatsTimer = time.NewTicker(updateStats)
@@ -262,6 +279,7 @@ func (c *MConnection) stopServices() (alreadyStopped bool) {
 
 	c.BaseService.OnStop()
 	c.flushTimer.Stop()
+	c.throttleTimer.Stop()
 	c.pingTimer.Stop()
 	c.chStatsTimer.Stop()
 
@@ -368,6 +386,12 @@ func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
 		return false
 	}
 
+	if channel.shouldDrop() {
+		channel.dropMsg()
+		c.Logger.Debug("Send dropped, connection saturated", "channel", chID, "conn", c)
+		return false
+	}
+
 	success := channel.sendBytes(msgBytes)
 	if success {
 		// Wake up sendRoutine if necessary
@@ -397,6 +421,12 @@ func (c *MConnection) TrySend(chID byte, msgBytes []byte) bool {
 		return false
 	}
 
+	if channel.shouldDrop() {
+		channel.dropMsg()
+		c.Logger.Debug("TrySend dropped, connection saturated", "channel", chID, "conn", c)
+		return false
+	}
+
 	ok = channel.trySendBytes(msgBytes)
 	if ok {
 		// Wake up sendRoutine if necessary
@@ -440,6 +470,12 @@ FOR_LOOP:
 			// NOTE: flushTimer.Set() must be called every time
 			// something is written to .bufConnWriter.
 			c.flush()
+		case <-c.throttleTimer.Ch:
+			// Retry the channels throttled by their send rate.
+			select {
+			case c.send <- struct{}{}:
+			default:
+			}
 		case <-c.chStatsTimer.C:
 			for _, channel := range c.channels {
 				channel.updateStats()
@@ -524,25 +560,14 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 
 // Returns true if messages from channels were exhausted.
 func (c *MConnection) sendPacketMsg() bool {
-	// Choose a channel to create a PacketMsg from.
-	// The chosen channel will be the one whose recentlySent/priority is the least.
-	var leastRatio float32 = math.MaxFloat32
-	var leastChannel *Channel
-	for _, channel := range c.channels {
-		// If nothing to send, skip this channel
-		if !channel.isSendPending() {
-			continue
-		}
-		// Get ratio, and keep track of lowest ratio.
-		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
-		if ratio < leastRatio {
-			leastRatio = ratio
-			leastChannel = channel
-		}
-	}
+	leastChannel, throttled := c.selectChannel()
 
 	// Nothing to send?
 	if leastChannel == nil {
+		if throttled {
+			// Come back once the throttled channels may send again.
+			c.throttleTimer.Set()
+		}
 		return true
 	}
 	// c.Logger.Info("Found a msgPacket to send")
@@ -559,6 +584,65 @@ func (c *MConnection) sendPacketMsg() bool {
 	return false
 }
 
+// selectChannel chooses a channel to create a PacketMsg from.
+// Strict priority channels are chosen first, the one with the highest priority
+// winning, as long as they sent less than maxStrictPriorityShare of the bytes
+// recently sent. Otherwise the chosen channel will be the non strict one whose
+// recentlySent/priority is the least. Channels which exceeded their send rate
+// are skipped, in which case throttled is true.
+func (c *MConnection) selectChannel() (leastChannel *Channel, throttled bool) {
+	var leastRatio float32 = math.MaxFloat32
+	var strictChannel *Channel
+	var strictSent, totalSent int64
+	for _, channel := range c.channels {
+		recentlySent := atomic.LoadInt64(&channel.recentlySent)
+		totalSent += recentlySent
+		if channel.desc.StrictPriority {
+			strictSent += recentlySent
+		}
+
+		// If nothing to send, skip this channel
+		if !channel.isSendPending() {
+			continue
+		}
+		if channel.isSendThrottled() {
+			throttled = true
+			continue
+		}
+		if channel.desc.StrictPriority {
+			if strictChannel == nil || channel.desc.Priority > strictChannel.desc.Priority {
+				strictChannel = channel
+			}
+			continue
+		}
+		// Get ratio, and keep track of lowest ratio.
+		ratio := float32(recentlySent) / float32(channel.desc.Priority)
+		if ratio < leastRatio {
+			leastRatio = ratio
+			leastChannel = channel
+		}
+	}
+
+	if strictChannel != nil &&
+		(leastChannel == nil || float64(strictSent) <= maxStrictPriorityShare*float64(totalSent)) {
+		return strictChannel, throttled
+	}
+	return leastChannel, throttled
+}
+
+// isSaturated returns true if the connection can't keep up with the messages
+// queued to it, that is if the send queue of a channel which isn't low
+// priority is filling up or the send rate is exhausted.
+// Goroutine-safe
+func (c *MConnection) isSaturated() bool {
+	for _, channel := range c.channels {
+		if !channel.desc.LowPriority && channel.isSendQueueFilling() {
+			return true
+		}
+	}
+	return c.sendMonitor.Limit(c._maxPacketMsgSize, atomic.LoadInt64(&c.config.SendRate), false) == 0
+}
+
 // recvRoutine reads PacketMsgs and reconstructs the message using the channels' "recving" buffer.
 // After a whole message has been assembled, it's pushed to onReceive().
 // Blocks depending on how the connection is throttled.
@@ -640,6 +724,7 @@ FOR_LOOP:
 				break FOR_LOOP
 			}
 
+			channel.recvMonitor.Update(_n)
 			msgBytes, err := channel.recvPacketMsg(*pkt.PacketMsg)
 			if err != nil {
 				if c.IsRunning() {
@@ -648,7 +733,14 @@ FOR_LOOP:
 				}
 				break FOR_LOOP
 			}
-			if msgBytes != nil {
+			if msgBytes != nil && channel.isRecvThrottled() {
+				// This routine reads for all channels, so it can't wait for a channel to get
+				// back under its receive rate without stalling the others: the message is
+				// dropped instead.
+				channel.dropRecvMsg()
+				c.Logger.Debug("Received message dropped, channel exceeded its receive rate",
+					"chID", channelID, "conn", c)
+			} else if msgBytes != nil {
 				c.Logger.Debug("Received bytes", "chID", channelID, "msgBytes", msgBytes)
 				// NOTE: This means the reactor.Receive runs in the same thread as the p2p recv routine
 				c.onReceive(channelID, msgBytes)
@@ -701,7 +793,12 @@ type ChannelStatus struct {
 	SendQueueCapacity int
 	SendQueueSize     int
 	Priority          int
+	StrictPriority    bool
 	RecentlySent      int64
+	DroppedMsgs       int64
+	RecvDroppedMsgs   int64
+	SendMonitor       flow.Status
+	RecvMonitor       flow.Status
 }
 
 func (c *MConnection) Status() ConnectionStatus {
@@ -716,7 +813,12 @@ func (c *MConnection) Status() ConnectionStatus {
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
+			StrictPriority:    channel.desc.StrictPriority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
+			DroppedMsgs:       atomic.LoadInt64(&channel.droppedMsgs),
+			RecvDroppedMsgs:   atomic.LoadInt64(&channel.recvDroppedMsgs),
+			SendMonitor:       channel.sendMonitor.Status(),
+			RecvMonitor:       channel.recvMonitor.Status(),
 		}
 	}
 	return status
@@ -730,6 +832,21 @@ type ChannelDescriptor struct {
 	SendQueueCapacity   int
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
+
+	// StrictPriority channels are always sent before the other channels,
+	// which share the rest of the connection by Priority.
+	StrictPriority bool
+	// LowPriority channels drop their messages while the connection is
+	// saturated if MConnConfig.DropLowPriority is set.
+	LowPriority bool
+
+	// Rates at which the channel can send and receive, in bytes/second.
+	// 0 means the channel is only limited by the connection's rates. Sends
+	// wait for the channel to get back under its send rate, while messages
+	// received over the receive rate are dropped, since the connection
+	// reads for all channels at once.
+	SendRate int64
+	RecvRate int64
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -749,13 +866,17 @@ func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
 // TODO: lowercase.
 // NOTE: not goroutine-safe.
 type Channel struct {
-	conn          *MConnection
-	desc          ChannelDescriptor
-	sendQueue     chan []byte
-	sendQueueSize int32 // atomic.
-	recving       []byte
-	sending       []byte
-	recentlySent  int64 // exponential moving average
+	conn            *MConnection
+	desc            ChannelDescriptor
+	sendQueue       chan []byte
+	sendQueueSize   int32 // atomic.
+	recving         []byte
+	sending         []byte
+	recentlySent    int64 // exponential moving average
+	droppedMsgs     int64 // atomic.
+	recvDroppedMsgs int64 // atomic.
+	sendMonitor     *flow.Monitor
+	recvMonitor     *flow.Monitor
 
 	maxPacketMsgPayloadSize int
 
@@ -772,6 +893,8 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
+		sendMonitor:             flow.New(0, 0),
+		recvMonitor:             flow.New(0, 0),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
 }
@@ -817,6 +940,51 @@ func (ch *Channel) canSend() bool {
 	return ch.loadSendQueueSize() < defaultSendQueueCapacity
 }
 
+// Returns true if a message should be dropped rather than queued because
+// the channel is low priority and the connection is saturated.
+// Goroutine-safe
+func (ch *Channel) shouldDrop() bool {
+	if !ch.desc.LowPriority || !ch.conn.config.DropLowPriority {
+		return false
+	}
+	return len(ch.sendQueue) == cap(ch.sendQueue) || ch.conn.isSaturated()
+}
+
+// Goroutine-safe
+func (ch *Channel) dropMsg() {
+	atomic.AddInt64(&ch.droppedMsgs, 1)
+}
+
+// Goroutine-safe
+func (ch *Channel) dropRecvMsg() {
+	atomic.AddInt64(&ch.recvDroppedMsgs, 1)
+}
+
+// Returns true if the channel exceeded its receive rate.
+// Not goroutine-safe
+func (ch *Channel) isRecvThrottled() bool {
+	if ch.desc.RecvRate <= 0 {
+		return false
+	}
+	return ch.recvMonitor.Limit(ch.conn._maxPacketMsgSize, ch.desc.RecvRate, false) == 0
+}
+
+// Returns true if the send queue is filled beyond saturatedQueueRatio.
+// Goroutine-safe
+func (ch *Channel) isSendQueueFilling() bool {
+	size := ch.loadSendQueueSize()
+	return size > 0 && float64(size) >= saturatedQueueRatio*float64(cap(ch.sendQueue))
+}
+
+// Returns true if the channel exceeded its send rate.
+// Goroutine-safe
+func (ch *Channel) isSendThrottled() bool {
+	if ch.desc.SendRate <= 0 {
+		return false
+	}
+	return ch.sendMonitor.Limit(ch.conn._maxPacketMsgSize, ch.desc.SendRate, false) == 0
+}
+
 // Returns true if any PacketMsgs are pending to be sent.
 // Call before calling nextPacketMsg()
 // Goroutine-safe
@@ -853,6 +1021,7 @@ func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 	packet := ch.nextPacketMsg()
 	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	ch.sendMonitor.Update(n)
 	return
 }
 
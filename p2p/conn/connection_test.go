@@ -565,6 +565,130 @@ func TestMConnectionTrySend(t *testing.T) {
 	assert.Equal(t, "TrySend", <-resultCh)
 }
 
+func TestMConnectionSelectChannel(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 100, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10, StrictPriority: true},
+		{ID: 0x03, Priority: 5, SendQueueCapacity: 10, StrictPriority: true, SendRate: 1},
+	}
+	mconn := NewMConnection(client, chDescs, func(byte, []byte) {}, func(interface{}) {})
+	for _, ch := range mconn.channels {
+		require.True(t, ch.trySendBytes([]byte("Jubilee")))
+	}
+
+	// the strict priority channel with the highest priority comes first
+	ch, throttled := mconn.selectChannel()
+	assert.EqualValues(t, 0x03, ch.desc.ID)
+	assert.False(t, throttled)
+
+	// unless it exceeded its send rate
+	mconn.channelsIdx[0x03].sendMonitor.Update(mconn._maxPacketMsgSize)
+	ch, throttled = mconn.selectChannel()
+	assert.EqualValues(t, 0x02, ch.desc.ID)
+	assert.True(t, throttled)
+
+	// but it can't take more than its share of the connection
+	mconn.channelsIdx[0x02].recentlySent = 1000
+	ch, _ = mconn.selectChannel()
+	assert.EqualValues(t, 0x01, ch.desc.ID)
+	mconn.channelsIdx[0x01].recentlySent = 1000
+	ch, _ = mconn.selectChannel()
+	assert.EqualValues(t, 0x02, ch.desc.ID)
+
+	// strict priority channels are drained before the others
+	mconn.channelsIdx[0x02].nextPacketMsg()
+	ch, _ = mconn.selectChannel()
+	assert.EqualValues(t, 0x01, ch.desc.ID)
+}
+
+func TestMConnectionDropLowPriority(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	cfg := DefaultMConnConfig()
+	cfg.DropLowPriority = true
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10, StrictPriority: true},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10, LowPriority: true},
+	}
+	mconn := NewMConnectionWithConfig(client, chDescs, func(byte, []byte) {}, func(interface{}) {}, cfg)
+	mconn.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn.Start())
+	defer mconn.Stop() // nolint:errcheck // ignore for tests
+
+	msg := []byte("Doop")
+	assert.True(t, mconn.TrySend(0x02, msg))
+	_, err := server.Read(make([]byte, len(msg)))
+	require.NoError(t, err)
+
+	// a pending consensus message doesn't saturate the connection
+	require.True(t, mconn.channelsIdx[0x01].trySendBytes(msg))
+	assert.False(t, mconn.channelsIdx[0x02].shouldDrop())
+
+	// but a consensus send queue filling up does, even if the connection
+	// picks one of the messages
+	for i := 0; i < 5; i++ {
+		require.True(t, mconn.channelsIdx[0x01].trySendBytes(msg))
+	}
+	assert.False(t, mconn.TrySend(0x02, msg))
+	assert.False(t, mconn.Send(0x02, msg))
+	assert.EqualValues(t, 2, mconn.Status().Channels[1].DroppedMsgs)
+
+	// without DropLowPriority messages are queued
+	mconn.config.DropLowPriority = false
+	assert.True(t, mconn.TrySend(0x02, msg))
+}
+
+func TestMConnectionRecvRate(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10, RecvRate: 1},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10},
+	}
+	receivedCh := make(chan byte, 10)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- chID
+	}
+	mconn1 := NewMConnectionWithConfig(client, chDescs, onReceive, func(interface{}) {}, DefaultMConnConfig())
+	mconn1.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn1.Start())
+	defer mconn1.Stop() // nolint:errcheck // ignore for tests
+
+	mconn2 := NewMConnectionWithConfig(server, chDescs, func(byte, []byte) {}, func(interface{}) {},
+		DefaultMConnConfig())
+	mconn2.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn2.Start())
+	defer mconn2.Stop() // nolint:errcheck // ignore for tests
+
+	// the messages over the receive rate of a channel are dropped, without
+	// delaying the other channels
+	msg := []byte("Cyclops")
+	for i := 0; i < 5; i++ {
+		assert.True(t, mconn2.Send(0x01, msg))
+	}
+	assert.True(t, mconn2.Send(0x02, msg))
+	timeout := time.After(500 * time.Millisecond)
+	received := 0
+	for chID := byte(0); chID != 0x02; {
+		select {
+		case chID = <-receivedCh:
+			received++
+		case <-timeout:
+			t.Fatal("Did not receive the message of the unlimited channel in 500ms")
+		}
+	}
+	assert.Less(t, received, 6)
+	assert.EqualValues(t, 6-received, mconn1.Status().Channels[0].RecvDroppedMsgs)
+}
+
 // nolint:lll //ignore line length for tests
 func TestConnVectors(t *testing.T) {
 
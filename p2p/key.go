@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/armor"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmos "github.com/Finschia/ostracon/libs/os"
@@ -21,7 +22,9 @@ const IDByteLength = crypto.AddressSize
 
 //------------------------------------------------------------------------------
 // Persistent peer ID
-// TODO: encrypt on disk
+
+// nodeKeyBlockType is the armor block type of encrypted NodeKey files.
+const nodeKeyBlockType = "OSTRACON NODE KEY"
 
 // NodeKey is the persistent peer key.
 // It contains the nodes private key for authentication.
@@ -48,8 +51,15 @@ func PubKeyToID(pubKey crypto.PubKey) ID {
 // LoadOrGenNodeKey attempts to load the NodeKey from the given filePath. If
 // the file does not exist, it generates and saves a new NodeKey.
 func LoadOrGenNodeKey(filePath string) (*NodeKey, error) {
+	return LoadOrGenNodeKeyWithUnlocker(filePath, nil)
+}
+
+// LoadOrGenNodeKeyWithUnlocker is like LoadOrGenNodeKey, but decrypts the
+// NodeKey with unlocker if it's encrypted, and encrypts a new NodeKey with
+// unlocker if unlocker isn't nil.
+func LoadOrGenNodeKeyWithUnlocker(filePath string, unlocker armor.Unlocker) (*NodeKey, error) {
 	if tmos.FileExists(filePath) {
-		nodeKey, err := LoadNodeKey(filePath)
+		nodeKey, err := LoadNodeKeyWithUnlocker(filePath, unlocker)
 		if err != nil {
 			return nil, err
 		}
@@ -61,7 +71,7 @@ func LoadOrGenNodeKey(filePath string) (*NodeKey, error) {
 		PrivKey: privKey,
 	}
 
-	if err := nodeKey.SaveAs(filePath); err != nil {
+	if err := nodeKey.SaveAsEncrypted(filePath, unlocker); err != nil {
 		return nil, err
 	}
 
@@ -70,10 +80,25 @@ func LoadOrGenNodeKey(filePath string) (*NodeKey, error) {
 
 // LoadNodeKey loads NodeKey located in filePath.
 func LoadNodeKey(filePath string) (*NodeKey, error) {
+	return LoadNodeKeyWithUnlocker(filePath, nil)
+}
+
+// LoadNodeKeyWithUnlocker loads NodeKey located in filePath, decrypting it
+// with unlocker if it's encrypted.
+func LoadNodeKeyWithUnlocker(filePath string, unlocker armor.Unlocker) (*NodeKey, error) {
 	jsonBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
+	if armor.IsArmored(jsonBytes) {
+		if unlocker == nil {
+			return nil, fmt.Errorf("node key %v is encrypted, but no passphrase or KMS command is set", filePath)
+		}
+		_, jsonBytes, err = armor.DecryptArmor(string(jsonBytes), unlocker)
+		if err != nil {
+			return nil, err
+		}
+	}
 	nodeKey := new(NodeKey)
 	err = tmjson.Unmarshal(jsonBytes, nodeKey)
 	if err != nil {
@@ -84,10 +109,23 @@ func LoadNodeKey(filePath string) (*NodeKey, error) {
 
 // SaveAs persists the NodeKey to filePath.
 func (nodeKey *NodeKey) SaveAs(filePath string) error {
+	return nodeKey.SaveAsEncrypted(filePath, nil)
+}
+
+// SaveAsEncrypted persists the NodeKey to filePath, encrypted with unlocker.
+// If unlocker is nil, the NodeKey is saved in plaintext.
+func (nodeKey *NodeKey) SaveAsEncrypted(filePath string, unlocker armor.Unlocker) error {
 	jsonBytes, err := tmjson.Marshal(nodeKey)
 	if err != nil {
 		return err
 	}
+	if unlocker != nil {
+		armorStr, err := armor.EncryptArmor(nodeKeyBlockType, jsonBytes, unlocker)
+		if err != nil {
+			return err
+		}
+		jsonBytes = []byte(armorStr)
+	}
 	err = os.WriteFile(filePath, jsonBytes, 0600)
 	if err != nil {
 		return err
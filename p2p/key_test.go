@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Finschia/ostracon/crypto/armor"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 )
@@ -53,6 +54,25 @@ func TestNodeKeySaveAs(t *testing.T) {
 	assert.FileExists(t, filePath)
 }
 
+func TestNodeKeySaveAsEncrypted(t *testing.T) {
+	filePath := filepath.Join(os.TempDir(), tmrand.Str(12)+"_peer_id.json")
+
+	nodeKey := &NodeKey{
+		PrivKey: ed25519.GenPrivKey(),
+	}
+	err := nodeKey.SaveAsEncrypted(filePath, armor.Passphrase("passphrase"))
+	require.NoError(t, err)
+
+	_, err = LoadNodeKey(filePath)
+	assert.Error(t, err)
+	_, err = LoadNodeKeyWithUnlocker(filePath, armor.Passphrase("wrong"))
+	assert.Error(t, err)
+
+	loaded, err := LoadNodeKeyWithUnlocker(filePath, armor.Passphrase("passphrase"))
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey, loaded)
+}
+
 //----------------------------------------------------------
 
 func padBytes(bz []byte, targetBytes int) []byte {
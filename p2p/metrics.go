@@ -29,6 +29,18 @@ type Metrics struct {
 	NumAbandonedPeerMsgs metrics.Counter
 	// Number of pooled peer messages
 	NumPooledPeerMsgs metrics.Gauge
+	// Number of messages queued to be sent on a channel to a given peer.
+	PeerChannelSendQueueSize metrics.Gauge
+	// Current rate at which bytes are sent on a channel to a given peer.
+	PeerChannelSendRate metrics.Gauge
+	// Current rate at which bytes are received on a channel from a given peer.
+	PeerChannelReceiveRate metrics.Gauge
+	// Number of low priority messages dropped because the connection to a
+	// given peer was saturated.
+	PeerChannelDroppedMsgs metrics.Counter
+	// Number of messages received on a channel from a given peer dropped
+	// because the channel exceeded its receive rate.
+	PeerChannelRecvDroppedMsgs metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -82,6 +94,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "num_pooled_peer_msgs",
 			Help:      "Number of peer messages pooled currently",
 		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerChannelSendQueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_send_queue_size",
+			Help:      "Number of messages queued to be sent on a channel to a given peer.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerChannelSendRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_send_rate",
+			Help:      "Current rate at which bytes are sent on a channel to a given peer, in bytes/second.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerChannelReceiveRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_receive_rate",
+			Help:      "Current rate at which bytes are received on a channel from a given peer, in bytes/second.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerChannelDroppedMsgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_dropped_msgs",
+			Help:      "Number of low priority messages dropped because the connection to a given peer was saturated.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerChannelRecvDroppedMsgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_recv_dropped_msgs",
+			Help:      "Number of messages received on a channel from a given peer dropped because the channel exceeded its receive rate.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
 	}
 }
 
@@ -95,5 +137,11 @@ func NopMetrics() *Metrics {
 		NumTxs:                discard.NewGauge(),
 		NumAbandonedPeerMsgs:  discard.NewCounter(),
 		NumPooledPeerMsgs:     discard.NewGauge(),
+
+		PeerChannelSendQueueSize:   discard.NewGauge(),
+		PeerChannelSendRate:        discard.NewGauge(),
+		PeerChannelReceiveRate:     discard.NewGauge(),
+		PeerChannelDroppedMsgs:     discard.NewCounter(),
+		PeerChannelRecvDroppedMsgs: discard.NewCounter(),
 	}
 }
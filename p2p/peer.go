@@ -352,22 +352,46 @@ func PeerMetrics(metrics *Metrics) PeerOption {
 }
 
 func (p *peer) metricsReporter() {
+	// channel statuses of the previous report, by channel
+	reported := make(map[byte]tmconn.ChannelStatus)
 	for {
 		select {
 		case <-p.metricsTicker.C:
-			status := p.mconn.Status()
-			var sendQueueSize float64
-			for _, chStatus := range status.Channels {
-				sendQueueSize += float64(chStatus.SendQueueSize)
-			}
-
-			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			reportConnectionMetrics(p.metrics, p.ID(), p.mconn.Status(), reported)
 		case <-p.Quit():
 			return
 		}
 	}
 }
 
+// reportConnectionMetrics reports the metrics of the channels of a peer
+// connection. reported holds the channel statuses of the previous report, by
+// channel, to report the messages dropped since then, and is updated.
+func reportConnectionMetrics(metrics *Metrics, id ID, status tmconn.ConnectionStatus,
+	reported map[byte]tmconn.ChannelStatus) {
+	var sendQueueSize float64
+	for _, chStatus := range status.Channels {
+		sendQueueSize += float64(chStatus.SendQueueSize)
+
+		labels := []string{
+			"peer_id", string(id),
+			"chID", fmt.Sprintf("%#x", chStatus.ID),
+		}
+		metrics.PeerChannelSendQueueSize.With(labels...).Set(float64(chStatus.SendQueueSize))
+		metrics.PeerChannelSendRate.With(labels...).Set(float64(chStatus.SendMonitor.CurRate))
+		metrics.PeerChannelReceiveRate.With(labels...).Set(float64(chStatus.RecvMonitor.CurRate))
+		if dropped := chStatus.DroppedMsgs - reported[chStatus.ID].DroppedMsgs; dropped > 0 {
+			metrics.PeerChannelDroppedMsgs.With(labels...).Add(float64(dropped))
+		}
+		if dropped := chStatus.RecvDroppedMsgs - reported[chStatus.ID].RecvDroppedMsgs; dropped > 0 {
+			metrics.PeerChannelRecvDroppedMsgs.With(labels...).Add(float64(dropped))
+		}
+		reported[chStatus.ID] = chStatus
+	}
+
+	metrics.PeerPendingSendBytes.With("peer_id", string(id)).Set(sendQueueSize)
+}
+
 //------------------------------------------------------------------
 // helper funcs
 
@@ -381,29 +405,7 @@ func createMConnection(
 ) *tmconn.MConnection {
 
 	onReceive := func(chID byte, msgBytes []byte) {
-		reactor := reactorsByCh[chID]
-		if reactor == nil {
-			// Note that its ok to panic here as it's caught in the conn._recover,
-			// which does onPeerError.
-			panic(fmt.Sprintf("Unknown channel %X", chID))
-		}
-		labels := []string{
-			"peer_id", string(p.ID()),
-			"chID", fmt.Sprintf("%#x", chID),
-		}
-		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
-		if config.RecvAsync {
-			ch := reactor.GetRecvChan()
-			p.metrics.NumPooledPeerMsgs.With(labels...).Set(float64(len(ch)))
-			// we must use copied msgBytes
-			// because msgBytes is on socket receive buffer yet so reactor can read it concurrently
-			copied := make([]byte, len(msgBytes))
-			copy(copied, msgBytes)
-			// if the channel is full, we are blocking a message until it can send into the channel
-			ch <- &BufferedMsg{ChID: chID, Peer: p, Msg: copied}
-		} else {
-			reactor.Receive(chID, p, msgBytes)
-		}
+		receiveMsg(p, p.metrics, reactorsByCh, config.RecvAsync, chID, msgBytes)
 	}
 
 	onError := func(r interface{}) {
@@ -418,3 +420,38 @@ func createMConnection(
 		config,
 	)
 }
+
+// receiveMsg delivers a message received from the peer to the reactor of the
+// channel, synchronously or through its receive channel if recvAsync is set.
+func receiveMsg(
+	p Peer,
+	metrics *Metrics,
+	reactorsByCh map[byte]Reactor,
+	recvAsync bool,
+	chID byte,
+	msgBytes []byte,
+) {
+	reactor := reactorsByCh[chID]
+	if reactor == nil {
+		// Note that its ok to panic here as it's caught in the conn._recover,
+		// which does onPeerError.
+		panic(fmt.Sprintf("Unknown channel %X", chID))
+	}
+	labels := []string{
+		"peer_id", string(p.ID()),
+		"chID", fmt.Sprintf("%#x", chID),
+	}
+	metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+	if recvAsync {
+		ch := reactor.GetRecvChan()
+		metrics.NumPooledPeerMsgs.With(labels...).Set(float64(len(ch)))
+		// we must use copied msgBytes
+		// because msgBytes is on socket receive buffer yet so reactor can read it concurrently
+		copied := make([]byte, len(msgBytes))
+		copy(copied, msgBytes)
+		// if the channel is full, we are blocking a message until it can send into the channel
+		ch <- &BufferedMsg{ChID: chID, Peer: p, Msg: copied}
+	} else {
+		reactor.Receive(chID, p, msgBytes)
+	}
+}
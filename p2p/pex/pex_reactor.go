@@ -193,6 +193,7 @@ func (r *Reactor) GetChannels() []*conn.ChannelDescriptor {
 		{
 			ID:                  PexChannel,
 			Priority:            1,
+			LowPriority:         true,
 			SendQueueCapacity:   10,
 			RecvMessageCapacity: maxMsgSize,
 		},
@@ -251,7 +252,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		r.Switch.StopPeerForError(src, err)
+		r.Switch.MarkPeerAsBad(src, err)
 		return
 	}
 	r.Logger.Debug("Received message", "src", src, "chId", chID, "msg", msg)
@@ -287,7 +288,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 		} else {
 			// Check we're not receiving requests too frequently.
 			if err := r.receiveRequest(src); err != nil {
-				r.Switch.StopPeerForError(src, err)
+				r.Switch.MarkPeerAsBad(src, err)
 				r.book.MarkBad(src.SocketAddr(), defaultBanTime)
 				return
 			}
@@ -298,7 +299,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 		// If we asked for addresses, add them to the book
 		addrs, err := p2p.NetAddressesFromProto(msg.Addrs)
 		if err != nil {
-			r.Switch.StopPeerForError(src, err)
+			r.Switch.MarkPeerAsBad(src, err)
 			r.book.MarkBad(src.SocketAddr(), defaultBanTime)
 			return
 		}
@@ -488,7 +489,7 @@ func (r *Reactor) ensurePeers() {
 	maxAttempts := numToDial * 3
 
 	for i := 0; i < maxAttempts && len(toDial) < numToDial; i++ {
-		try := r.book.PickAddress(newBias)
+		try := r.pickTrustedAddress(newBias)
 		if try == nil {
 			continue
 		}
@@ -545,6 +546,28 @@ func (r *Reactor) ensurePeers() {
 	}
 }
 
+// pickTrustedAddress picks two addresses from the book and returns the one
+// whose peer has the higher trust score. Peers without a trust metric are
+// preferred, as they haven't misbehaved yet.
+func (r *Reactor) pickTrustedAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
+	try := r.book.PickAddress(biasTowardsNewAddrs)
+	if try == nil {
+		return nil
+	}
+	score, ok := r.Switch.PeerTrustScore(try.ID)
+	if !ok {
+		return try
+	}
+	alt := r.book.PickAddress(biasTowardsNewAddrs)
+	if alt == nil {
+		return try
+	}
+	if altScore, ok := r.Switch.PeerTrustScore(alt.ID); !ok || altScore > score {
+		return alt
+	}
+	return try
+}
+
 func (r *Reactor) dialAttemptsInfo(addr *p2p.NetAddress) (attempts int, lastDialed time.Time) {
 	_attempts, ok := r.attemptsToDial.Load(addr.DialString())
 	if !ok {
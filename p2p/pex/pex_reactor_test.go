@@ -14,11 +14,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/libs/log"
 	"github.com/Finschia/ostracon/p2p"
 	"github.com/Finschia/ostracon/p2p/mock"
+	"github.com/Finschia/ostracon/p2p/trust"
 )
 
 var (
@@ -534,6 +536,36 @@ func TestPEXReactorDialPeer(t *testing.T) {
 	}
 }
 
+func TestPEXReactorPrefersTrustedAddresses(t *testing.T) {
+	pexR, book := createReactor(&ReactorConfig{})
+	defer teardownReactor(book)
+
+	trustStore := trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig())
+	sw := p2p.MakeSwitch(cfg, 0, "127.0.0.1", "123.123.123",
+		func(i int, sw *p2p.Switch, config *config.P2PConfig) *p2p.Switch { return sw },
+		p2p.WithTrustMetricStore(trustStore))
+	sw.SetLogger(log.TestingLogger())
+	sw.AddReactor(pexR.String(), pexR)
+	pexR.SetSwitch(sw)
+	sw.SetAddrBook(book)
+
+	untrusted, err := p2p.NewNetAddressString("6b9f9b9e4c3d2d5f2e54e2d3fd0e3c6b10c10a7a@1.2.3.4:26656")
+	require.NoError(t, err)
+	unknown, err := p2p.NewNetAddressString("0a9f9b9e4c3d2d5f2e54e2d3fd0e3c6b10c10a7a@5.6.7.8:26656")
+	require.NoError(t, err)
+	require.NoError(t, book.AddAddress(untrusted, untrusted))
+	require.NoError(t, book.AddAddress(unknown, unknown))
+	trustStore.GetPeerTrustMetric(string(untrusted.ID)).BadEvents(1)
+
+	// the untrusted address is only picked if it's picked twice
+	picks := make(map[p2p.ID]int)
+	for i := 0; i < 200; i++ {
+		picks[pexR.pickTrustedAddress(100).ID]++
+	}
+	assert.Greater(t, picks[unknown.ID], 120)
+	assert.Greater(t, picks[untrusted.ID], 0)
+}
+
 func assertPeersWithTimeout(
 	t *testing.T,
 	switches []*p2p.Switch,
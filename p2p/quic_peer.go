@@ -0,0 +1,667 @@
+package p2p
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/Finschia/ostracon/libs/cmap"
+	flow "github.com/Finschia/ostracon/libs/flowrate"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/libs/service"
+	tmconn "github.com/Finschia/ostracon/p2p/conn"
+)
+
+const (
+	quicSendTimeout = 10 * time.Second
+
+	// quicThrottleWait is how long the send routine waits before trying the
+	// channels which exceeded their send rate again.
+	quicThrottleWait = 100 * time.Millisecond
+
+	// share of the recently sent bytes up to which strict priority channels
+	// are sent before the others, and ratio beyond which a filling send queue
+	// saturates the connection, as for MConnection
+	quicMaxStrictPriorityShare = 0.8
+	quicSaturatedQueueRatio    = 0.5
+
+	// quicFlushTimeout is the time FlushStop waits for the sent messages to be
+	// delivered before closing the connection.
+	quicFlushTimeout = time.Second
+)
+
+// quicChannel is a channel of a quicPeer, whose messages are sent in order on
+// a unidirectional stream of its own.
+type quicChannel struct {
+	desc          tmconn.ChannelDescriptor
+	sendQueue     chan []byte
+	sendQueueSize int32 // atomic.
+	recentlySent  int64 // exponential moving average, atomic.
+	droppedMsgs   int64 // atomic.
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
+
+	// only accessed by the send routine
+	stream  quic.SendStream
+	sending []byte // rest of the message frame being sent
+}
+
+// quicPeer implements Peer over a QUIC connection.
+//
+// Each channel sends its messages on a unidirectional stream, which is opened
+// on the first message and starts with the channel ID. Each message is
+// prefixed with its length as an uvarint. As the streams are independent, a
+// lost packet of a channel doesn't delay the messages of the others, and the
+// messages of different channels are received concurrently.
+//
+// The messages are written by a single send routine, in pieces of at most
+// MaxPacketMsgPayloadSize bytes, choosing the channel to write next as
+// MConnection does, so that the channel priorities and send rates apply.
+type quicPeer struct {
+	service.BaseService
+
+	// raw peerConn, of the handshake stream, and the QUIC connection
+	peerConn
+	qconn  quic.Connection
+	config tmconn.MConnConfig
+
+	// peer's node info and the channel it knows about
+	// channels = nodeInfo.Channels
+	// cached to avoid copying nodeInfo in hasChannel
+	nodeInfo NodeInfo
+	channels []byte
+
+	chDescs      []*tmconn.ChannelDescriptor
+	channelsByID map[byte]*quicChannel
+	reactorsByCh map[byte]Reactor
+	onPeerError  func(Peer, interface{})
+	errored      uint32
+
+	created     time.Time
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+	sendc       chan struct{} // wakes up the send routine when a message is queued
+	flushc      chan struct{}
+	sendWg      sync.WaitGroup
+
+	// User data
+	Data *cmap.CMap
+
+	metrics       *Metrics
+	metricsTicker *time.Ticker
+}
+
+var _ Peer = (*quicPeer)(nil)
+
+func newQUICPeer(
+	pc peerConn,
+	qconn quic.Connection,
+	config tmconn.MConnConfig,
+	nodeInfo NodeInfo,
+	reactorsByCh map[byte]Reactor,
+	chDescs []*tmconn.ChannelDescriptor,
+	onPeerError func(Peer, interface{}),
+	metrics *Metrics,
+) *quicPeer {
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	p := &quicPeer{
+		peerConn:      pc,
+		qconn:         qconn,
+		config:        config,
+		nodeInfo:      nodeInfo,
+		channels:      nodeInfo.(DefaultNodeInfo).Channels,
+		chDescs:       chDescs,
+		channelsByID:  make(map[byte]*quicChannel, len(chDescs)),
+		reactorsByCh:  reactorsByCh,
+		onPeerError:   onPeerError,
+		created:       time.Now(),
+		sendMonitor:   flow.New(0, 0),
+		recvMonitor:   flow.New(0, 0),
+		sendc:         make(chan struct{}, 1),
+		flushc:        make(chan struct{}),
+		Data:          cmap.NewCMap(),
+		metrics:       metrics,
+		metricsTicker: time.NewTicker(metricsTickerDuration),
+	}
+	for _, desc := range chDescs {
+		desc := desc.FillDefaults()
+		p.channelsByID[desc.ID] = &quicChannel{
+			desc:        desc,
+			sendQueue:   make(chan []byte, desc.SendQueueCapacity),
+			sendMonitor: flow.New(0, 0),
+			recvMonitor: flow.New(0, 0),
+		}
+	}
+	p.BaseService = *service.NewBaseService(nil, "Peer", p)
+
+	return p
+}
+
+// String representation.
+func (p *quicPeer) String() string {
+	if p.outbound {
+		return fmt.Sprintf("Peer{QUIC{%v} %v out}", p.RemoteAddr(), p.ID())
+	}
+
+	return fmt.Sprintf("Peer{QUIC{%v} %v in}", p.RemoteAddr(), p.ID())
+}
+
+//---------------------------------------------------
+// Implements service.Service
+
+// SetLogger implements BaseService.
+func (p *quicPeer) SetLogger(l log.Logger) {
+	p.Logger = l
+}
+
+// OnStart implements BaseService.
+func (p *quicPeer) OnStart() error {
+	if err := p.BaseService.OnStart(); err != nil {
+		return err
+	}
+
+	p.sendWg.Add(1)
+	go p.sendRoutine()
+	go p.acceptRoutine()
+	go p.metricsReporter()
+
+	return nil
+}
+
+// FlushStop mimics OnStop but additionally ensures that all successful
+// .Send() calls will get flushed before closing the connection.
+// NOTE: it is not safe to call this method more than once.
+func (p *quicPeer) FlushStop() {
+	p.metricsTicker.Stop()
+	p.BaseService.OnStop()
+
+	// Send the queued messages and close the streams.
+	close(p.flushc)
+	p.sendWg.Wait()
+
+	// Closing the connection discards the data which hasn't been delivered
+	// yet, so give it some time.
+	go func() {
+		select {
+		case <-p.qconn.Context().Done():
+		case <-time.After(quicFlushTimeout):
+		}
+		p.closeConn()
+	}()
+}
+
+// OnStop implements BaseService.
+func (p *quicPeer) OnStop() {
+	p.metricsTicker.Stop()
+	p.BaseService.OnStop()
+	p.closeConn()
+}
+
+//---------------------------------------------------
+// Implements Peer
+
+// ID returns the peer's ID - the hex encoded hash of its pubkey.
+func (p *quicPeer) ID() ID {
+	return p.nodeInfo.ID()
+}
+
+// IsOutbound returns true if the connection is outbound, false otherwise.
+func (p *quicPeer) IsOutbound() bool {
+	return p.peerConn.outbound
+}
+
+// IsPersistent returns true if the peer is persitent, false otherwise.
+func (p *quicPeer) IsPersistent() bool {
+	return p.peerConn.persistent
+}
+
+// NodeInfo returns a copy of the peer's NodeInfo.
+func (p *quicPeer) NodeInfo() NodeInfo {
+	return p.nodeInfo
+}
+
+// SocketAddr returns the address of the socket.
+// For outbound peers, it's the address dialed (after DNS resolution).
+// For inbound peers, it's the address returned by the underlying connection
+// (not what's reported in the peer's NodeInfo).
+func (p *quicPeer) SocketAddr() *NetAddress {
+	return p.peerConn.socketAddr
+}
+
+// RemoteAddr returns peer's remote network address.
+func (p *quicPeer) RemoteAddr() net.Addr {
+	return p.peerConn.conn.RemoteAddr()
+}
+
+// Status returns the peer's ConnectionStatus.
+func (p *quicPeer) Status() tmconn.ConnectionStatus {
+	status := tmconn.ConnectionStatus{
+		Duration:    time.Since(p.created),
+		SendMonitor: p.sendMonitor.Status(),
+		RecvMonitor: p.recvMonitor.Status(),
+		Channels:    make([]tmconn.ChannelStatus, 0, len(p.chDescs)),
+	}
+	for _, desc := range p.chDescs {
+		ch := p.channelsByID[desc.ID]
+		status.Channels = append(status.Channels, tmconn.ChannelStatus{
+			ID:                ch.desc.ID,
+			SendQueueCapacity: cap(ch.sendQueue),
+			SendQueueSize:     int(atomic.LoadInt32(&ch.sendQueueSize)),
+			Priority:          ch.desc.Priority,
+			StrictPriority:    ch.desc.StrictPriority,
+			RecentlySent:      atomic.LoadInt64(&ch.recentlySent),
+			DroppedMsgs:       atomic.LoadInt64(&ch.droppedMsgs),
+			SendMonitor:       ch.sendMonitor.Status(),
+			RecvMonitor:       ch.recvMonitor.Status(),
+		})
+	}
+	return status
+}
+
+// Send msg bytes to the channel identified by chID byte. Returns false if the
+// send queue is full after timeout.
+func (p *quicPeer) Send(chID byte, msgBytes []byte) bool {
+	ch, ok := p.sendChannel(chID)
+	if !ok {
+		return false
+	}
+	if p.shouldDrop(ch) {
+		atomic.AddInt64(&ch.droppedMsgs, 1)
+		p.Logger.Debug("Send dropped, connection saturated", "channel", chID, "peer", p)
+		return false
+	}
+
+	select {
+	case ch.sendQueue <- msgBytes:
+	case <-time.After(quicSendTimeout):
+		return false
+	}
+	p.queued(ch, msgBytes)
+	return true
+}
+
+// TrySend msg bytes to the channel identified by chID byte. Immediately returns
+// false if the send queue is full.
+func (p *quicPeer) TrySend(chID byte, msgBytes []byte) bool {
+	ch, ok := p.sendChannel(chID)
+	if !ok {
+		return false
+	}
+	if p.shouldDrop(ch) {
+		atomic.AddInt64(&ch.droppedMsgs, 1)
+		p.Logger.Debug("TrySend dropped, connection saturated", "channel", chID, "peer", p)
+		return false
+	}
+
+	select {
+	case ch.sendQueue <- msgBytes:
+	default:
+		return false
+	}
+	p.queued(ch, msgBytes)
+	return true
+}
+
+// Get the data for a given key.
+func (p *quicPeer) Get(key string) interface{} {
+	return p.Data.Get(key)
+}
+
+// Set sets the data for the given key.
+func (p *quicPeer) Set(key string, data interface{}) {
+	p.Data.Set(key, data)
+}
+
+// CloseConn closes the QUIC connection. Used for cleaning up in cases where
+// the peer had not been started at all.
+func (p *quicPeer) CloseConn() error {
+	return p.peerConn.conn.Close()
+}
+
+//---------------------------------------------------
+
+// sendChannel returns the channel to send a message on, if the peer is running
+// and both ends know about it.
+func (p *quicPeer) sendChannel(chID byte) (*quicChannel, bool) {
+	if !p.IsRunning() {
+		// see Switch#Broadcast, where we fetch the list of peers and loop over
+		// them - while we're looping, one peer may be removed and stopped.
+		return nil, false
+	}
+	for _, id := range p.channels {
+		if id == chID {
+			ch, ok := p.channelsByID[chID]
+			if !ok {
+				p.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+			}
+			return ch, ok
+		}
+	}
+	p.Logger.Debug("Unknown channel for peer", "channel", chID, "channels", p.channels)
+	return nil, false
+}
+
+// queued records a message queued on the channel, and wakes up the send
+// routine.
+func (p *quicPeer) queued(ch *quicChannel, msgBytes []byte) {
+	atomic.AddInt32(&ch.sendQueueSize, 1)
+	select {
+	case p.sendc <- struct{}{}:
+	default:
+	}
+
+	labels := []string{
+		"peer_id", string(p.ID()),
+		"chID", fmt.Sprintf("%#x", ch.desc.ID),
+	}
+	p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+}
+
+// shouldDrop returns true if a message should be dropped rather than queued
+// because the channel is low priority and the connection is saturated.
+func (p *quicPeer) shouldDrop(ch *quicChannel) bool {
+	if !ch.desc.LowPriority || !p.config.DropLowPriority {
+		return false
+	}
+	return len(ch.sendQueue) == cap(ch.sendQueue) || p.isSaturated()
+}
+
+// isSaturated returns true if the connection can't keep up with the messages
+// queued to it, that is if the send queue of a channel which isn't low
+// priority is filling up or the send rate is exhausted.
+func (p *quicPeer) isSaturated() bool {
+	for _, ch := range p.channelsByID {
+		size := atomic.LoadInt32(&ch.sendQueueSize)
+		if !ch.desc.LowPriority && size > 0 &&
+			float64(size) >= quicSaturatedQueueRatio*float64(cap(ch.sendQueue)) {
+			return true
+		}
+	}
+	return p.sendMonitor.Limit(p.config.MaxPacketMsgPayloadSize, p.config.SendRate, false) == 0
+}
+
+// sendRoutine writes the queued messages on the streams of their channels,
+// until the peer is stopped or flushed.
+func (p *quicPeer) sendRoutine() {
+	defer p.sendWg.Done()
+	defer p._recover()
+
+	flushing := false
+	for {
+		// The throttled channels are ignored when flushing, so that the queued
+		// messages are all sent.
+		ch, throttled := p.selectChannel(flushing)
+		if ch != nil {
+			if err := p.sendPiece(ch); err != nil {
+				if flushing {
+					p.Logger.Debug("Failed to flush message", "err", err)
+				} else {
+					p.stopForError(err)
+				}
+				return
+			}
+			continue
+		}
+		if flushing {
+			for _, ch := range p.channelsByID {
+				if ch.stream != nil {
+					_ = ch.stream.Close()
+				}
+			}
+			return
+		}
+
+		var throttlec <-chan time.Time
+		if throttled {
+			// Come back once the throttled channels may send again.
+			throttlec = time.After(quicThrottleWait)
+		}
+		select {
+		case <-p.sendc:
+		case <-throttlec:
+		case <-p.flushc:
+			flushing = true
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// selectChannel chooses the channel to write next, as MConnection does.
+// Strict priority channels are chosen first, the one with the highest priority
+// winning, as long as they sent less than quicMaxStrictPriorityShare of the
+// bytes recently sent. Otherwise the chosen channel will be the non strict one
+// whose recentlySent/priority is the least. Channels which exceeded their send
+// rate are skipped unless ignoreRates is set, in which case throttled is true.
+func (p *quicPeer) selectChannel(ignoreRates bool) (leastChannel *quicChannel, throttled bool) {
+	var leastRatio float32 = math.MaxFloat32
+	var strictChannel *quicChannel
+	var strictSent, totalSent int64
+	for _, ch := range p.channelsByID {
+		recentlySent := atomic.LoadInt64(&ch.recentlySent)
+		totalSent += recentlySent
+		if ch.desc.StrictPriority {
+			strictSent += recentlySent
+		}
+
+		if !ch.isSendPending() {
+			continue
+		}
+		if !ignoreRates && ch.isSendThrottled(p.config.MaxPacketMsgPayloadSize) {
+			throttled = true
+			continue
+		}
+		if ch.desc.StrictPriority {
+			if strictChannel == nil || ch.desc.Priority > strictChannel.desc.Priority {
+				strictChannel = ch
+			}
+			continue
+		}
+		ratio := float32(recentlySent) / float32(ch.desc.Priority)
+		if ratio < leastRatio {
+			leastRatio = ratio
+			leastChannel = ch
+		}
+	}
+
+	if strictChannel != nil &&
+		(leastChannel == nil || float64(strictSent) <= quicMaxStrictPriorityShare*float64(totalSent)) {
+		return strictChannel, throttled
+	}
+	return leastChannel, throttled
+}
+
+// sendPiece writes the next piece of the message frame being sent on the
+// channel's stream, opening it if needed.
+func (p *quicPeer) sendPiece(ch *quicChannel) error {
+	if ch.stream == nil {
+		s, err := p.qconn.OpenUniStreamSync(p.qconn.Context())
+		if err != nil {
+			return err
+		}
+		if _, err := s.Write([]byte{ch.desc.ID}); err != nil {
+			return err
+		}
+		ch.stream = s
+	}
+
+	n := len(ch.sending)
+	if n > p.config.MaxPacketMsgPayloadSize {
+		n = p.config.MaxPacketMsgPayloadSize
+	}
+	if err := p.writeLimited(ch.stream, ch.sending[:n]); err != nil {
+		return err
+	}
+	ch.sending = ch.sending[n:]
+	ch.sendMonitor.Update(n)
+	atomic.AddInt64(&ch.recentlySent, int64(n))
+	return nil
+}
+
+// isSendPending returns true if the channel has a message frame to send,
+// taking the next one from the queue if needed.
+func (ch *quicChannel) isSendPending() bool {
+	if len(ch.sending) > 0 {
+		return true
+	}
+	select {
+	case msg := <-ch.sendQueue:
+		atomic.AddInt32(&ch.sendQueueSize, -1)
+		frame := make([]byte, binary.MaxVarintLen64+len(msg))
+		n := binary.PutUvarint(frame, uint64(len(msg)))
+		n += copy(frame[n:], msg)
+		ch.sending = frame[:n]
+		return true
+	default:
+		return false
+	}
+}
+
+// isSendThrottled returns true if the channel exceeded its send rate.
+func (ch *quicChannel) isSendThrottled(pieceSize int) bool {
+	if ch.desc.SendRate <= 0 {
+		return false
+	}
+	return ch.sendMonitor.Limit(pieceSize, ch.desc.SendRate, false) == 0
+}
+
+// writeLimited writes b on the stream at the send rate.
+func (p *quicPeer) writeLimited(stream quic.SendStream, b []byte) error {
+	for len(b) > 0 {
+		n := p.sendMonitor.Limit(len(b), p.config.SendRate, true)
+		n, err := stream.Write(b[:n])
+		p.sendMonitor.Update(n)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// acceptRoutine accepts the streams opened by the peer, until the connection
+// is closed.
+func (p *quicPeer) acceptRoutine() {
+	for {
+		stream, err := p.qconn.AcceptUniStream(p.qconn.Context())
+		if err != nil {
+			p.stopForError(err)
+			return
+		}
+		go p.recvRoutine(stream)
+	}
+}
+
+// recvRoutine reads the messages of a channel from its stream, and delivers
+// them to the reactor, until the stream is closed.
+func (p *quicPeer) recvRoutine(stream quic.ReceiveStream) {
+	defer p._recover()
+
+	r := bufio.NewReader(stream)
+	chID, err := r.ReadByte()
+	if err != nil {
+		p.stopForError(err)
+		return
+	}
+	ch, ok := p.channelsByID[chID]
+	if !ok {
+		p.stopForError(fmt.Errorf("unknown channel %X", chID))
+		return
+	}
+
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			// The peer closed the stream between messages.
+			return
+		} else if err != nil {
+			p.stopForError(err)
+			return
+		}
+		if size > uint64(ch.desc.RecvMessageCapacity) {
+			p.stopForError(fmt.Errorf("received message exceeds available capacity: %v < %v",
+				ch.desc.RecvMessageCapacity, size))
+			return
+		}
+
+		msgBytes := make([]byte, size)
+		if err := p.readLimited(r, msgBytes); err != nil {
+			p.stopForError(err)
+			return
+		}
+		ch.recvMonitor.Update(len(msgBytes))
+		receiveMsg(p, p.metrics, p.reactorsByCh, p.config.RecvAsync, chID, msgBytes)
+
+		// Block until the channel is back under its receive rate. This stops
+		// reading from the stream, pushing back on the sender.
+		ch.recvMonitor.Limit(p.config.MaxPacketMsgPayloadSize, ch.desc.RecvRate, true)
+	}
+}
+
+// readLimited reads len(b) bytes from r at the receive rate.
+func (p *quicPeer) readLimited(r io.Reader, b []byte) error {
+	for len(b) > 0 {
+		n := p.recvMonitor.Limit(len(b), p.config.RecvRate, true)
+		n, err := io.ReadFull(r, b[:n])
+		p.recvMonitor.Update(n)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// stopForError closes the connection and reports the error, once. It is
+// ignored once the peer is stopped.
+func (p *quicPeer) stopForError(r interface{}) {
+	if !atomic.CompareAndSwapUint32(&p.errored, 0, 1) {
+		return
+	}
+	_ = p.qconn.CloseWithError(quicErrCodeError, fmt.Sprintf("%v", r))
+	if p.onPeerError != nil {
+		p.onPeerError(p, r)
+	}
+}
+
+// closeConn closes the connection without reporting an error.
+func (p *quicPeer) closeConn() {
+	atomic.StoreUint32(&p.errored, 1)
+	_ = p.qconn.CloseWithError(quicErrCodeNone, "peer stopped")
+}
+
+// _recover catches the panics of the reactors, and stops the peer for them.
+func (p *quicPeer) _recover() {
+	if r := recover(); r != nil {
+		p.Logger.Error("Peer failed", "err", r)
+		p.stopForError(errors.New(fmt.Sprint(r)))
+	}
+}
+
+func (p *quicPeer) metricsReporter() {
+	// channel statuses of the previous report, by channel
+	reported := make(map[byte]tmconn.ChannelStatus)
+	for {
+		select {
+		case <-p.metricsTicker.C:
+			for _, ch := range p.channelsByID {
+				// Exponential decay of the bytes recently sent.
+				atomic.StoreInt64(&ch.recentlySent, int64(float64(atomic.LoadInt64(&ch.recentlySent))*0.8))
+			}
+			reportConnectionMetrics(p.metrics, p.ID(), p.Status(), reported)
+		case <-p.Quit():
+			return
+		}
+	}
+}
@@ -0,0 +1,521 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/p2p/conn"
+)
+
+const (
+	// quicALPN is the application protocol negotiated in the TLS handshake.
+	quicALPN = "ostracon-p2p"
+
+	// quicMaxIncomingUniStreams bounds the streams a peer can open, one per
+	// channel.
+	quicMaxIncomingUniStreams = 256
+
+	quicCertValidity = 100 * 365 * 24 * time.Hour
+)
+
+// Application error codes sent when closing a QUIC connection.
+const (
+	quicErrCodeNone    quic.ApplicationErrorCode = 0
+	quicErrCodeRefused quic.ApplicationErrorCode = 1
+	quicErrCodeError   quic.ApplicationErrorCode = 2
+)
+
+// QUICTransportOption sets an optional parameter on the QUICTransport.
+type QUICTransportOption func(*QUICTransport)
+
+// QUICTransportConnFilters sets the filters for rejection new connections.
+func QUICTransportConnFilters(filters ...ConnFilterFunc) QUICTransportOption {
+	return func(mt *QUICTransport) { mt.connFilters = filters }
+}
+
+// QUICTransportFilterTimeout sets the timeout waited for filter calls to
+// return.
+func QUICTransportFilterTimeout(timeout time.Duration) QUICTransportOption {
+	return func(mt *QUICTransport) { mt.filterTimeout = timeout }
+}
+
+// QUICTransportResolver sets the Resolver used for ip lookups, defaults to
+// net.DefaultResolver.
+func QUICTransportResolver(resolver IPResolver) QUICTransportOption {
+	return func(mt *QUICTransport) { mt.resolver = resolver }
+}
+
+// QUICTransportBanList sets the BanList used to reject banned nodes on dial
+// and accept.
+func QUICTransportBanList(banList *BanList) QUICTransportOption {
+	return func(mt *QUICTransport) { mt.banList = banList }
+}
+
+// QUICTransportMaxIncomingConnections sets the maximum number of simultaneous
+// connections (incoming). Default: 0 (unlimited)
+func QUICTransportMaxIncomingConnections(n int) QUICTransportOption {
+	return func(mt *QUICTransport) { mt.maxIncomingConnections = int32(n) }
+}
+
+// QUICTransport accepts and dials QUIC connections and wraps them in peers
+// which send the messages of each channel on a stream of their own, so that a
+// channel is never blocked behind the others, unlike with an MConnection.
+//
+// The connections are authenticated with TLS 1.3, using a self-signed
+// certificate of the node key, which must be ed25519. The node ID of a peer is
+// derived from the key of its certificate.
+type QUICTransport struct {
+	netAddr                NetAddress
+	udpConn                net.PacketConn
+	transport              *quic.Transport
+	listener               *quic.Listener
+	maxIncomingConnections int32 // see MaxIncomingConnections
+	numIncomingConnections int32 // atomic
+
+	acceptc chan accept
+	closec  chan struct{}
+
+	connFilter
+
+	dialTimeout      time.Duration
+	handshakeTimeout time.Duration
+	nodeInfo         NodeInfo
+	nodeKey          NodeKey
+	tlsConfig        *tls.Config
+
+	mConfig conn.MConnConfig
+}
+
+// Test QUICTransport for interface completeness.
+var _ Transport = (*QUICTransport)(nil)
+var _ transportLifecycle = (*QUICTransport)(nil)
+
+// NewQUICTransport returns a QUIC transport. It returns an error if the node
+// key isn't an ed25519 key.
+func NewQUICTransport(
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	mConfig conn.MConnConfig,
+) (*QUICTransport, error) {
+	tlsConfig, err := quicTLSConfig(nodeKey.PrivKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QUICTransport{
+		acceptc:          make(chan accept),
+		closec:           make(chan struct{}),
+		connFilter:       newConnFilter(),
+		dialTimeout:      defaultDialTimeout,
+		handshakeTimeout: defaultHandshakeTimeout,
+		mConfig:          mConfig,
+		nodeInfo:         nodeInfo,
+		nodeKey:          nodeKey,
+		tlsConfig:        tlsConfig,
+	}, nil
+}
+
+// NetAddress implements Transport.
+func (mt *QUICTransport) NetAddress() NetAddress {
+	return mt.netAddr
+}
+
+// Accept implements Transport.
+func (mt *QUICTransport) Accept(cfg peerConfig) (Peer, error) {
+	select {
+	case a := <-mt.acceptc:
+		if a.err != nil {
+			return nil, a.err
+		}
+
+		cfg.outbound = false
+
+		return mt.wrapPeer(a.conn.(*quicStreamConn), a.nodeInfo, cfg, a.netAddr), nil
+	case <-mt.closec:
+		return nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements Transport.
+func (mt *QUICTransport) Dial(
+	addr NetAddress,
+	cfg peerConfig,
+) (Peer, error) {
+	if err := mt.checkBanned(addr.ID, addr.IP); err != nil {
+		return nil, ErrRejected{addr: addr, id: addr.ID, err: err, isFiltered: true}
+	}
+
+	// The QUIC handshake includes the TLS one.
+	ctx, cancel := context.WithTimeout(context.Background(), mt.dialTimeout+mt.handshakeTimeout)
+	defer cancel()
+
+	udpAddr := &net.UDPAddr{IP: addr.IP, Port: int(addr.Port)}
+	var (
+		qc  quic.Connection
+		err error
+	)
+	if mt.transport != nil {
+		// Dial from the listening port, so that the peer sees our address.
+		qc, err = mt.transport.Dial(ctx, udpAddr, mt.tlsConfig, mt.quicConfig())
+	} else {
+		qc, err = quic.DialAddr(ctx, udpAddr.String(), mt.tlsConfig, mt.quicConfig())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := qc.OpenStreamSync(ctx)
+	if err != nil {
+		_ = qc.CloseWithError(quicErrCodeError, err.Error())
+		return nil, err
+	}
+	c := newQUICStreamConn(qc, stream)
+
+	// TODO(xla): Evaluate if we should apply filters if we explicitly dial.
+	if err := mt.filterConn(c); err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := mt.upgrade(c, &addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return mt.wrapPeer(c, nodeInfo, cfg, &addr), nil
+}
+
+// Close implements transportLifecycle.
+func (mt *QUICTransport) Close() error {
+	close(mt.closec)
+
+	if mt.listener != nil {
+		if err := mt.listener.Close(); err != nil {
+			return err
+		}
+	}
+	if mt.transport != nil {
+		if err := mt.transport.Close(); err != nil {
+			return err
+		}
+		return mt.udpConn.Close()
+	}
+
+	return nil
+}
+
+// Listen implements transportLifecycle. It listens on the UDP port of addr.
+func (mt *QUICTransport) Listen(addr NetAddress) error {
+	udpConn, err := net.ListenPacket("udp", addr.DialString())
+	if err != nil {
+		return err
+	}
+
+	transport := &quic.Transport{Conn: udpConn}
+	ln, err := transport.Listen(mt.tlsConfig, mt.quicConfig())
+	if err != nil {
+		_ = transport.Close()
+		_ = udpConn.Close()
+		return err
+	}
+
+	mt.netAddr = addr
+	mt.udpConn = udpConn
+	mt.transport = transport
+	mt.listener = ln
+
+	go mt.acceptPeers()
+
+	return nil
+}
+
+// AddChannel registers a channel to nodeInfo.
+// NOTE: NodeInfo must be of type DefaultNodeInfo else channels won't be updated
+func (mt *QUICTransport) AddChannel(chID byte) error {
+	ni, ok := mt.nodeInfo.(DefaultNodeInfo)
+	if !ok {
+		return fmt.Errorf("nodeInfo type: %T is not supported", mt.nodeInfo)
+	}
+	if !ni.HasChannel(chID) {
+		ni.Channels = append(ni.Channels, chID)
+	}
+	mt.nodeInfo = ni
+	return nil
+}
+
+func (mt *QUICTransport) acceptPeers() {
+	for {
+		qc, err := mt.listener.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case _, ok := <-mt.closec:
+				if !ok {
+					return
+				}
+			default:
+				// Transport is not closed
+			}
+
+			mt.acceptc <- accept{err: err}
+			return
+		}
+
+		if max := mt.maxIncomingConnections; max > 0 &&
+			atomic.LoadInt32(&mt.numIncomingConnections) >= max {
+			_ = qc.CloseWithError(quicErrCodeRefused, "too many connections")
+			continue
+		}
+		atomic.AddInt32(&mt.numIncomingConnections, 1)
+		go func(qc quic.Connection) {
+			<-qc.Context().Done()
+			atomic.AddInt32(&mt.numIncomingConnections, -1)
+		}(qc)
+
+		// Connection upgrade and filtering should be asynchronous to avoid
+		// Head-of-line blocking.
+		go func(qc quic.Connection) {
+			defer func() {
+				if r := recover(); r != nil {
+					err := ErrRejected{
+						err:           fmt.Errorf("recovered from panic: %v", r),
+						isAuthFailure: true,
+					}
+					_ = qc.CloseWithError(quicErrCodeError, err.Error())
+					select {
+					case mt.acceptc <- accept{err: err}:
+					case <-mt.closec:
+					}
+				}
+			}()
+
+			var (
+				nodeInfo NodeInfo
+				c        *quicStreamConn
+				netAddr  *NetAddress
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), mt.handshakeTimeout)
+			stream, err := qc.AcceptStream(ctx)
+			cancel()
+			if err != nil {
+				_ = qc.CloseWithError(quicErrCodeError, err.Error())
+			} else {
+				c = newQUICStreamConn(qc, stream)
+				err = mt.filterConn(c)
+				if err == nil {
+					nodeInfo, err = mt.upgrade(c, nil)
+					if err == nil {
+						netAddr = remoteNetAddress(nodeInfo.ID(), qc.RemoteAddr())
+					}
+				}
+			}
+
+			select {
+			case mt.acceptc <- accept{netAddr, c, nodeInfo, err}:
+				// Make the upgraded peer available.
+			case <-mt.closec:
+				// Give up if the transport was closed.
+				_ = qc.CloseWithError(quicErrCodeNone, "transport closed")
+				return
+			}
+		}(qc)
+	}
+}
+
+// Cleanup removes the given address from the connections set and
+// closes the connection.
+func (mt *QUICTransport) Cleanup(p Peer) {
+	mt.conns.RemoveAddr(p.RemoteAddr())
+	_ = p.CloseConn()
+}
+
+func (mt *QUICTransport) cleanup(c net.Conn) error {
+	mt.conns.Remove(c)
+
+	return c.Close()
+}
+
+func (mt *QUICTransport) upgrade(
+	c *quicStreamConn,
+	dialedAddr *NetAddress,
+) (nodeInfo NodeInfo, err error) {
+	defer func() {
+		if err != nil {
+			_ = mt.cleanup(c)
+		}
+	}()
+
+	connID, err := quicRemoteID(c.conn)
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           err,
+			isAuthFailure: true,
+		}
+	}
+	var remoteIP net.IP
+	if udpAddr, ok := c.RemoteAddr().(*net.UDPAddr); ok {
+		remoteIP = udpAddr.IP
+	}
+	if err := mt.checkConnID(c, connID, remoteIP, dialedAddr); err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err = handshake(c, mt.handshakeTimeout, mt.nodeInfo)
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	if err := checkNodeInfo(c, connID, mt.nodeInfo, nodeInfo); err != nil {
+		return nil, err
+	}
+
+	return nodeInfo, nil
+}
+
+func (mt *QUICTransport) wrapPeer(
+	c *quicStreamConn,
+	ni NodeInfo,
+	cfg peerConfig,
+	socketAddr *NetAddress,
+) Peer {
+	peerConn := newPeerConn(
+		cfg.outbound,
+		isPersistentPeer(cfg, ni, socketAddr),
+		c,
+		socketAddr,
+	)
+
+	return newQUICPeer(
+		peerConn,
+		c.conn,
+		mt.mConfig,
+		ni,
+		cfg.reactorsByCh,
+		cfg.chDescs,
+		cfg.onPeerError,
+		cfg.metrics,
+	)
+}
+
+func (mt *QUICTransport) quicConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout:  mt.handshakeTimeout,
+		MaxIdleTimeout:        mt.mConfig.PingInterval + mt.mConfig.PongTimeout,
+		KeepAlivePeriod:       mt.mConfig.PingInterval,
+		MaxIncomingStreams:    1, // the handshake stream
+		MaxIncomingUniStreams: quicMaxIncomingUniStreams,
+	}
+}
+
+// quicStreamConn is the bidirectional stream of a QUIC connection used for the
+// handshake, as a net.Conn. Closing it closes the connection.
+type quicStreamConn struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+var _ net.Conn = (*quicStreamConn)(nil)
+
+func newQUICStreamConn(qc quic.Connection, stream quic.Stream) *quicStreamConn {
+	return &quicStreamConn{Stream: stream, conn: qc}
+}
+
+// LocalAddr implements net.Conn.
+func (c *quicStreamConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// RemoteAddr implements net.Conn.
+func (c *quicStreamConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close implements net.Conn by closing the QUIC connection.
+func (c *quicStreamConn) Close() error {
+	return c.conn.CloseWithError(quicErrCodeNone, "")
+}
+
+// quicTLSConfig returns the TLS config of a node, with a self-signed
+// certificate of its key. Peers must present such a certificate too.
+func quicTLSConfig(privKey crypto.PrivKey) (*tls.Config, error) {
+	edKey, ok := privKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("QUIC transport requires an ed25519 node key, got %s", privKey.Type())
+	}
+	key := stded25519.PrivateKey(edKey)
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(quicCertValidity),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+		ClientAuth:   tls.RequireAnyClientCert,
+		// The certificates are self-signed: verifyQUICPeerCertificate checks
+		// them instead, and the node ID is checked after the handshake.
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: verifyQUICPeerCertificate,
+		NextProtos:            []string{quicALPN},
+		MinVersion:            tls.VersionTLS13,
+	}, nil
+}
+
+// verifyQUICPeerCertificate checks that the peer presented a single ed25519
+// certificate signed by itself. TLS checks that the peer has its private key.
+func verifyQUICPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected 1 certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := cert.PublicKey.(stded25519.PublicKey); !ok {
+		return fmt.Errorf("expected an ed25519 certificate, got %T", cert.PublicKey)
+	}
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+}
+
+// quicRemoteID returns the node ID of the key of the peer's certificate.
+func quicRemoteID(qc quic.Connection) (ID, error) {
+	certs := qc.ConnectionState().TLS.PeerCertificates
+	if len(certs) != 1 {
+		return "", errors.New("no peer certificate")
+	}
+	pubKey, ok := certs[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an ed25519 certificate, got %T", certs[0].PublicKey)
+	}
+	return PubKeyToID(ed25519.PubKey(pubKey)), nil
+}
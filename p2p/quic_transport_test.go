@@ -0,0 +1,226 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/secp256k1"
+	"github.com/Finschia/ostracon/p2p/conn"
+)
+
+func testSetupQUICTransport(t *testing.T, channels ...byte) (*QUICTransport, ID) {
+	pv := ed25519.GenPrivKey()
+	id := PubKeyToID(pv.PubKey())
+	ni := testNodeInfo(id, "transport").(DefaultNodeInfo)
+	if len(channels) > 0 {
+		ni.Channels = channels
+	}
+
+	mConfig := conn.DefaultMConnConfig()
+	mConfig.RecvAsync = false
+	mt, err := NewQUICTransport(ni, NodeKey{PrivKey: pv}, mConfig)
+	require.NoError(t, err)
+	return mt, id
+}
+
+func testListenQUICTransport(t *testing.T, mt *QUICTransport, id ID) NetAddress {
+	laddr, err := NewNetAddressString(IDAddressString(id, "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, mt.Listen(*laddr))
+	t.Cleanup(func() {
+		if err := mt.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return *remoteNetAddress(id, mt.listener.Addr())
+}
+
+func TestQUICTransportRequiresEd25519(t *testing.T) {
+	pv := secp256k1.GenPrivKey()
+	_, err := NewQUICTransport(
+		testNodeInfo(PubKeyToID(pv.PubKey()), "transport"),
+		NodeKey{PrivKey: pv},
+		conn.DefaultMConnConfig(),
+	)
+	assert.Error(t, err)
+}
+
+func TestQUICTransportSendReceive(t *testing.T) {
+	const ch1, ch2 = byte(0x01), byte(0x02)
+	chDescs := []*conn.ChannelDescriptor{
+		{ID: ch1, Priority: 1, SendQueueCapacity: 10},
+		{ID: ch2, Priority: 1, SendQueueCapacity: 10},
+	}
+
+	lt, lid := testSetupQUICTransport(t, ch1, ch2)
+	listenAddr := testListenQUICTransport(t, lt, lid)
+	dt, _ := testSetupQUICTransport(t, ch1, ch2)
+
+	lreactor := NewTestReactor(chDescs, false, 0, true)
+	dreactor := NewTestReactor(chDescs, false, 0, true)
+	peerCfg := func(r *TestReactor) peerConfig {
+		return peerConfig{
+			chDescs:      chDescs,
+			onPeerError:  func(p Peer, err interface{}) { t.Errorf("peer %v errored: %v", p, err) },
+			reactorsByCh: map[byte]Reactor{ch1: r, ch2: r},
+		}
+	}
+
+	acceptc := make(chan Peer)
+	go func() {
+		p, err := lt.Accept(peerCfg(lreactor))
+		require.NoError(t, err)
+		acceptc <- p
+	}()
+
+	dp, err := dt.Dial(listenAddr, peerCfg(dreactor))
+	require.NoError(t, err)
+	lp := <-acceptc
+
+	assert.True(t, dp.IsOutbound())
+	assert.False(t, lp.IsOutbound())
+	assert.Equal(t, lt.nodeInfo.ID(), dp.ID())
+	assert.Equal(t, dt.nodeInfo.ID(), lp.ID())
+	assert.Equal(t, "127.0.0.1", lp.RemoteIP().String())
+
+	require.NoError(t, dp.Start())
+	require.NoError(t, lp.Start())
+	t.Cleanup(func() {
+		_ = dp.Stop()
+		_ = lp.Stop()
+	})
+
+	big := bytes.Repeat([]byte{0xab}, 1<<20)
+	require.True(t, dp.Send(ch1, big))
+	require.True(t, dp.Send(ch2, []byte("vote")))
+	require.True(t, dp.Send(ch2, []byte("vote2")))
+	require.True(t, lp.TrySend(ch1, []byte("pong")))
+	assert.False(t, lp.Send(0x03, []byte("unknown channel")))
+
+	require.Eventually(t, func() bool {
+		return len(lreactor.getMsgs(ch1)) == 1 && len(lreactor.getMsgs(ch2)) == 2 &&
+			len(dreactor.getMsgs(ch1)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, big, lreactor.getMsgs(ch1)[0].Bytes)
+	assert.Equal(t, []byte("vote"), lreactor.getMsgs(ch2)[0].Bytes)
+	assert.Equal(t, []byte("vote2"), lreactor.getMsgs(ch2)[1].Bytes)
+	assert.Equal(t, []byte("pong"), dreactor.getMsgs(ch1)[0].Bytes)
+
+	status := dp.Status()
+	require.Len(t, status.Channels, 2)
+	assert.EqualValues(t, ch1, status.Channels[0].ID)
+	assert.Positive(t, status.SendMonitor.Bytes)
+}
+
+func TestQUICPeerSelectChannel(t *testing.T) {
+	chDescs := []*conn.ChannelDescriptor{
+		{ID: 0x01, Priority: 100, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10, StrictPriority: true},
+		{ID: 0x03, Priority: 5, SendQueueCapacity: 10, StrictPriority: true, SendRate: 1},
+	}
+	ni := testNodeInfo(PubKeyToID(ed25519.GenPrivKey().PubKey()), "peer")
+	p := newQUICPeer(peerConn{}, nil, conn.DefaultMConnConfig(), ni, nil, chDescs, nil, nil)
+	p.metricsTicker.Stop()
+	for _, ch := range p.channelsByID {
+		ch.sendQueue <- []byte("Jubilee")
+		p.queued(ch, []byte("Jubilee"))
+	}
+
+	// the strict priority channel with the highest priority comes first
+	ch, throttled := p.selectChannel(false)
+	assert.EqualValues(t, 0x03, ch.desc.ID)
+	assert.False(t, throttled)
+
+	// unless it exceeded its send rate
+	p.channelsByID[0x03].sendMonitor.Update(p.config.MaxPacketMsgPayloadSize)
+	ch, throttled = p.selectChannel(false)
+	assert.EqualValues(t, 0x02, ch.desc.ID)
+	assert.True(t, throttled)
+
+	// which is ignored when flushing
+	ch, _ = p.selectChannel(true)
+	assert.EqualValues(t, 0x03, ch.desc.ID)
+
+	// but it can't take more than its share of the connection
+	p.channelsByID[0x02].recentlySent = 1000
+	ch, _ = p.selectChannel(false)
+	assert.EqualValues(t, 0x01, ch.desc.ID)
+	p.channelsByID[0x01].recentlySent = 1000
+	ch, _ = p.selectChannel(false)
+	assert.EqualValues(t, 0x02, ch.desc.ID)
+
+	// strict priority channels are drained before the others
+	p.channelsByID[0x02].sending = nil
+	p.channelsByID[0x03].sending = nil
+	ch, _ = p.selectChannel(false)
+	assert.EqualValues(t, 0x01, ch.desc.ID)
+}
+
+func TestQUICPeerDropLowPriority(t *testing.T) {
+	cfg := conn.DefaultMConnConfig()
+	cfg.DropLowPriority = true
+	chDescs := []*conn.ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10, StrictPriority: true},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10, LowPriority: true},
+	}
+	ni := testNodeInfo(PubKeyToID(ed25519.GenPrivKey().PubKey()), "peer")
+	p := newQUICPeer(peerConn{}, nil, cfg, ni, nil, chDescs, nil, nil)
+	p.metricsTicker.Stop()
+
+	lowCh := p.channelsByID[0x02]
+	assert.False(t, p.shouldDrop(lowCh))
+
+	// a pending consensus message doesn't saturate the connection
+	strictCh := p.channelsByID[0x01]
+	strictCh.sendQueue <- []byte("Doop")
+	p.queued(strictCh, []byte("Doop"))
+	assert.False(t, p.shouldDrop(lowCh))
+
+	// but a consensus send queue filling up does
+	for i := 0; i < 4; i++ {
+		strictCh.sendQueue <- []byte("Doop")
+		p.queued(strictCh, []byte("Doop"))
+	}
+	assert.True(t, p.shouldDrop(lowCh))
+	assert.False(t, p.shouldDrop(strictCh))
+
+	// without DropLowPriority messages are queued
+	p.config.DropLowPriority = false
+	assert.False(t, p.shouldDrop(lowCh))
+}
+
+func TestQUICTransportRejects(t *testing.T) {
+	lt, lid := testSetupQUICTransport(t)
+	banList, err := NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+	QUICTransportBanList(banList)(lt)
+	listenAddr := testListenQUICTransport(t, lt, lid)
+
+	// dialing the wrong ID
+	dt, did := testSetupQUICTransport(t)
+	wrongAddr := listenAddr
+	wrongAddr.ID = did
+	_, err = dt.Dial(wrongAddr, peerConfig{})
+	if assert.Error(t, err) {
+		assert.True(t, err.(ErrRejected).IsAuthFailure(), err)
+	}
+	_, err = lt.Accept(peerConfig{})
+	assert.Error(t, err)
+
+	// a banned node
+	_, err = banList.Ban(string(did), 0, "spam")
+	require.NoError(t, err)
+	go func() {
+		_, _ = dt.Dial(listenAddr, peerConfig{})
+	}()
+	_, err = lt.Accept(peerConfig{})
+	if assert.Error(t, err) {
+		assert.True(t, err.(ErrRejected).IsFiltered(), err)
+	}
+}
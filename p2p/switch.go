@@ -1,8 +1,10 @@
 package p2p
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"net"
 	"sync"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/Finschia/ostracon/libs/rand"
 	"github.com/Finschia/ostracon/libs/service"
 	"github.com/Finschia/ostracon/p2p/conn"
+	"github.com/Finschia/ostracon/p2p/trust"
 )
 
 const (
@@ -27,6 +30,18 @@ const (
 	// ie. 3**10 = 16hrs
 	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
+
+	// peers whose trust score falls below this after misbehaving are banned
+	trustScoreBanThreshold = 20
+	// peers are only banned once they misbehaved at least this many times, so
+	// that a single violation, which a new peer's score can't absorb, doesn't
+	// ban it
+	trustScoreBanMinBadEvents = 3
+	// inbound peers whose trust score is below this can be evicted for
+	// a more trusted peer when there are too many inbound peers
+	trustScoreEvictThreshold = 50
+	// how long a peer banned for its trust score is banned for
+	trustScoreBanTime = 24 * time.Hour
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -38,6 +53,7 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.RecvRate = cfg.RecvRate
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
 	mConfig.RecvAsync = cfg.RecvAsync
+	mConfig.DropLowPriority = cfg.DropLowPriority
 	return mConfig
 }
 
@@ -51,6 +67,8 @@ type AddrBook interface {
 	AddOurAddress(*NetAddress)
 	OurAddress(*NetAddress) bool
 	MarkGood(ID)
+	MarkBad(*NetAddress, time.Duration)
+	IsBanned(*NetAddress) bool
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
 	Save()
@@ -73,12 +91,15 @@ type Switch struct {
 	reactors     map[string]Reactor
 	chDescs      []*conn.ChannelDescriptor
 	reactorsByCh map[byte]Reactor
+	channelRates map[byte]ChannelRate // optional, see WithChannelRates
 	peers        *PeerSet
 	dialing      *cmap.CMap
 	reconnecting *cmap.CMap
 	nodeInfo     NodeInfo // our node info
 	nodeKey      *NodeKey // our node privkey
 	addrBook     AddrBook
+	trustStore   *trust.MetricStore // optional, see WithTrustMetricStore
+	banList      *BanList           // optional, see WithBanList
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
@@ -150,6 +171,32 @@ func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
 }
 
+// WithBanList sets the list of banned and allowed peers, to which peers with a
+// low trust score are added. It should be the same as the transport's.
+func WithBanList(banList *BanList) SwitchOption {
+	return func(sw *Switch) { sw.banList = banList }
+}
+
+// ChannelRate is the rate at which a channel can send and receive, in
+// bytes/second. 0 means the channel is only limited by the connection's rates.
+type ChannelRate struct {
+	SendRate int64
+	RecvRate int64
+}
+
+// WithChannelRates sets the rates of the given channels, overriding the ones
+// of their reactors' descriptors.
+func WithChannelRates(rates map[byte]ChannelRate) SwitchOption {
+	return func(sw *Switch) { sw.channelRates = rates }
+}
+
+// WithTrustMetricStore sets the store of the peers' trust metrics, which
+// are used to ban misbehaving peers and to evict untrusted inbound peers.
+// The Switch starts and stops the store.
+func WithTrustMetricStore(store *trust.MetricStore) SwitchOption {
+	return func(sw *Switch) { sw.trustStore = store }
+}
+
 //---------------------------------------------------------------------
 // Switch setup
 
@@ -162,6 +209,16 @@ func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 		if sw.reactorsByCh[chID] != nil {
 			panic(fmt.Sprintf("Channel %X has multiple reactors %v & %v", chID, sw.reactorsByCh[chID], reactor))
 		}
+		if rate, ok := sw.channelRates[chID]; ok {
+			desc := *chDesc
+			if rate.SendRate > 0 {
+				desc.SendRate = rate.SendRate
+			}
+			if rate.RecvRate > 0 {
+				desc.RecvRate = rate.RecvRate
+			}
+			chDesc = &desc
+		}
 		sw.chDescs = append(sw.chDescs, chDesc)
 		sw.reactorsByCh[chID] = reactor
 	}
@@ -222,6 +279,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 
 // OnStart implements BaseService. It starts all the reactors and peers.
 func (sw *Switch) OnStart() error {
+	if sw.trustStore != nil {
+		if err := sw.trustStore.Start(); err != nil {
+			return fmt.Errorf("failed to start trust metric store: %w", err)
+		}
+	}
+
 	// Start reactors
 	for _, reactor := range sw.reactors {
 		err := reactor.Start()
@@ -250,6 +313,12 @@ func (sw *Switch) OnStop() {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
 		}
 	}
+
+	if sw.trustStore != nil {
+		if err := sw.trustStore.Stop(); err != nil {
+			sw.Logger.Error("error while stopping trust metric store", "error", err)
+		}
+	}
 }
 
 //---------------------------------------------------------------------
@@ -319,9 +388,11 @@ func (sw *Switch) Peers() IPeerSet {
 	return sw.peers
 }
 
-// StopPeerForError disconnects from a peer due to external error.
+// StopPeerForError disconnects from a peer due to external error, e.g. a
+// connection error or a timeout, which honest peers run into too. The error
+// isn't recorded as misbehaviour, protocol violations are reported with
+// MarkPeerAsBad instead.
 // If the peer is persistent, it will attempt to reconnect.
-// TODO: make record depending on reason.
 func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	if !peer.IsRunning() {
 		return
@@ -371,6 +442,10 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	if sw.peers.Remove(peer) {
 		sw.metrics.Peers.Add(float64(-1))
 	}
+
+	if sw.trustStore != nil {
+		sw.trustStore.PeerDisconnected(string(peer.ID()))
+	}
 }
 
 // reconnectToPeer tries to reconnect to the addr, first repeatedly
@@ -441,6 +516,189 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	if sw.addrBook != nil {
 		sw.addrBook.MarkGood(peer.ID())
 	}
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).GoodEvents(1)
+	}
+}
+
+// MarkPeerAsBad records the misbehaviour of the given peer, e.g. a protocol
+// violation, and disconnects from it. If its trust score falls below
+// trustScoreBanThreshold after at least trustScoreBanMinBadEvents misbehaviours,
+// the peer is also banned, so it's neither redialed nor accepted until the ban
+// expires.
+// Persistent and unconditional peers are never banned. Peers that are already
+// stopped are ignored, so that an error reported by several reactors is only
+// recorded once.
+func (sw *Switch) MarkPeerAsBad(peer Peer, reason interface{}) {
+	if !peer.IsRunning() {
+		return
+	}
+	if sw.trustStore == nil {
+		sw.StopPeerForError(peer, reason)
+		return
+	}
+
+	tm := sw.trustStore.GetPeerTrustMetric(string(peer.ID()))
+	tm.BadEvents(1)
+	score := tm.TrustScore()
+	if score >= trustScoreBanThreshold || tm.BadEventCount() < trustScoreBanMinBadEvents ||
+		peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID()) ||
+		(sw.banList != nil && sw.banList.IsAllowed(peer.ID(), peerIP(peer))) {
+		sw.StopPeerForError(peer, reason)
+		return
+	}
+
+	sw.Logger.Info("Banning peer with a low trust score", "peer", peer, "score", score, "reason", reason)
+	sw.banPeer(peer, fmt.Sprintf("trust score %d after: %v", score, reason))
+	sw.stopAndRemovePeer(peer, reason)
+}
+
+// peerIP returns the IP of the peer's socket address, or nil if it has none.
+func peerIP(peer Peer) net.IP {
+	if addr := peer.SocketAddr(); addr != nil {
+		return addr.IP
+	}
+	return nil
+}
+
+// PeerTrustScore returns the trust score of the peer between 0 and 100, and
+// false if the peer has no trust metric.
+func (sw *Switch) PeerTrustScore(id ID) (int, bool) {
+	if sw.trustStore == nil || !sw.trustStore.HasPeerTrustMetric(string(id)) {
+		return 0, false
+	}
+	return sw.trustStore.GetPeerTrustMetric(string(id)).TrustScore(), true
+}
+
+// banPeer bans the peer in the ban list, and its address in the address book.
+func (sw *Switch) banPeer(peer Peer, reason string) {
+	if sw.banList != nil {
+		if _, err := sw.banList.Ban(string(peer.ID()), trustScoreBanTime, reason); err != nil {
+			sw.Logger.Error("Failed to ban peer", "peer", peer, "err", err)
+		}
+	}
+	if sw.addrBook == nil {
+		return
+	}
+
+	addr := peer.SocketAddr()
+	if !peer.IsOutbound() { // self-reported address for inbound peers
+		var err error
+		addr, err = peer.NodeInfo().NetAddress()
+		if err != nil {
+			sw.Logger.Error("Wanted to ban inbound peer, but self-reported address is wrong",
+				"peer", peer, "err", err)
+			return
+		}
+	}
+	if addr == nil {
+		return
+	}
+	// Only addresses in the book can be banned.
+	if !sw.addrBook.HasAddress(addr) {
+		if err := sw.addrBook.AddAddress(addr, addr); err != nil {
+			sw.Logger.Info("Failed to add address of peer to ban", "peer", peer, "err", err)
+		}
+	}
+	sw.addrBook.MarkBad(addr, trustScoreBanTime)
+}
+
+// isBanned returns true if the peer is banned in the ban list or in the
+// address book. Persistent and unconditional peers are only banned by the ban
+// list, since the address book bans peers automatically. Peers without a
+// socket address are only checked against the node ID bans.
+func (sw *Switch) isBanned(p Peer) bool {
+	if sw.banList != nil && sw.banList.IsBanned(p.ID(), peerIP(p)) != nil {
+		return true
+	}
+	addr := p.SocketAddr()
+	if addr == nil || sw.addrBook == nil || p.IsPersistent() || sw.IsPeerUnconditional(p.ID()) {
+		return false
+	}
+	return sw.addrBook.IsBanned(addr)
+}
+
+// BanPeers bans the target, which is a node ID, an IP address or an IP range
+// in CIDR notation, for the given duration, or forever if it's zero, and
+// disconnects from the peers it matches.
+func (sw *Switch) BanPeers(target string, duration time.Duration, reason string) (*Ban, error) {
+	if sw.banList == nil {
+		return nil, errNoBanList
+	}
+	ban, err := sw.banList.Ban(target, duration, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range sw.peers.List() {
+		if sw.banList.IsBanned(p.ID(), peerIP(p)) != nil {
+			sw.Logger.Info("Stopping banned peer", "peer", p, "ban", ban.Target)
+			sw.stopAndRemovePeer(p, fmt.Errorf("banned: %s", reason))
+		}
+	}
+	return ban, nil
+}
+
+// AllowPeers allows the target, which is a node ID, an IP address or an IP
+// range in CIDR notation, regardless of the bans.
+func (sw *Switch) AllowPeers(target string, reason string) (*Ban, error) {
+	if sw.banList == nil {
+		return nil, errNoBanList
+	}
+	return sw.banList.Allow(target, reason)
+}
+
+// UnbanPeers removes the ban or allowance of the target.
+func (sw *Switch) UnbanPeers(target string) error {
+	if sw.banList == nil {
+		return errNoBanList
+	}
+	return sw.banList.Unban(target)
+}
+
+// Bans returns the bans and allowances which haven't expired.
+func (sw *Switch) Bans() ([]*Ban, error) {
+	if sw.banList == nil {
+		return nil, errNoBanList
+	}
+	return sw.banList.List(), nil
+}
+
+// evictUntrustedInboundPeer makes room for the new inbound peer by stopping
+// the least trusted inbound peer, if its trust score is below
+// trustScoreEvictThreshold and the new peer's, which is the maximum score if
+// it has no trust metric yet. It returns true if a peer was evicted.
+func (sw *Switch) evictUntrustedInboundPeer(p Peer) bool {
+	if sw.trustStore == nil {
+		return false
+	}
+
+	newScore, ok := sw.PeerTrustScore(p.ID())
+	if !ok {
+		newScore = 100
+	}
+
+	var (
+		evict    Peer
+		minScore = trustScoreEvictThreshold
+	)
+	for _, peer := range sw.peers.List() {
+		if peer.IsOutbound() || peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID()) {
+			continue
+		}
+		score, ok := sw.PeerTrustScore(peer.ID())
+		if ok && score < minScore && score < newScore {
+			evict, minScore = peer, score
+		}
+	}
+	if evict == nil {
+		return false
+	}
+
+	sw.Logger.Info("Evicting inbound peer with a low trust score", "peer", evict, "score", minScore,
+		"newPeer", p.ID())
+	sw.StopPeerGracefully(evict)
+	return true
 }
 
 //---------------------------------------------------------------------
@@ -673,9 +931,10 @@ func (sw *Switch) acceptRoutine() {
 		}
 
 		if !sw.IsPeerUnconditional(p.NodeInfo().ID()) {
-			// Ignore connection if we already have enough peers.
+			// Ignore connection if we already have enough peers, unless an
+			// untrusted one can be evicted.
 			_, in, _ := sw.NumPeers()
-			if in >= sw.config.MaxNumInboundPeers {
+			if in >= sw.config.MaxNumInboundPeers && !sw.evictUntrustedInboundPeer(p) {
 				sw.Logger.Info(
 					"Ignoring inbound connection: already have enough inbound peers",
 					"address", p.SocketAddr(),
@@ -766,6 +1025,10 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if sw.isBanned(p) {
+		return ErrRejected{id: p.ID(), err: errors.New("peer is banned"), isFiltered: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -828,6 +1091,11 @@ func (sw *Switch) addPeer(p Peer) error {
 	}
 	sw.metrics.Peers.Add(float64(1))
 
+	if sw.trustStore != nil {
+		// Create the trust metric of the peer, or resume it.
+		sw.trustStore.GetPeerTrustMetric(string(p.ID())).GoodEvents(0)
+	}
+
 	// Start all the reactor protocols on the peer.
 	for _, reactor := range sw.reactors {
 		reactor.AddPeer(p)
@@ -16,13 +16,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/libs/log"
 	net2 "github.com/Finschia/ostracon/libs/net"
+	"github.com/Finschia/ostracon/libs/service"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
 	"github.com/Finschia/ostracon/p2p/conn"
+	"github.com/Finschia/ostracon/p2p/trust"
 )
 
 var (
@@ -670,6 +673,234 @@ func TestSwitchAcceptRoutine(t *testing.T) {
 	}
 }
 
+func TestSwitchMarkPeerAsBad(t *testing.T) {
+	trustStore := trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig())
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, WithTrustMetricStore(trustStore))
+	book := &addrBookMock{
+		addrs:    make(map[string]struct{}),
+		ourAddrs: make(map[string]struct{}),
+	}
+	sw.SetAddrBook(book)
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	addPeer := func(rp *remotePeer) (Peer, error) {
+		p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+			chDescs:      sw.chDescs,
+			onPeerError:  sw.StopPeerForError,
+			isPersistent: sw.IsPeerPersistent,
+			reactorsByCh: sw.reactorsByCh,
+		})
+		require.NoError(t, err)
+		if err := sw.addPeer(p); err != nil {
+			sw.transport.Cleanup(p)
+			return nil, err
+		}
+		return p, nil
+	}
+
+	// a peer that contributed a lot is only disconnected
+	rp1 := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp1.Start()
+	defer rp1.Stop()
+	p1, err := addPeer(rp1)
+	require.NoError(t, err)
+	score, ok := sw.PeerTrustScore(rp1.ID())
+	require.True(t, ok)
+	assert.Equal(t, 100, score)
+
+	for i := 0; i < 9; i++ {
+		sw.MarkPeerAsGood(p1)
+	}
+	sw.MarkPeerAsBad(p1, "bad message")
+	assert.False(t, p1.IsRunning())
+	assert.False(t, book.IsBanned(rp1.Addr()))
+	score, ok = sw.PeerTrustScore(rp1.ID())
+	require.True(t, ok)
+	assert.Equal(t, 86, score)
+
+	// a new peer that keeps misbehaving is banned and not accepted anymore
+	rp2 := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp2.Start()
+	defer rp2.Stop()
+	for i := 0; i < trustScoreBanMinBadEvents; i++ {
+		assert.False(t, book.IsBanned(rp2.Addr()))
+		p2, err := addPeer(rp2)
+		require.NoError(t, err)
+		sw.MarkPeerAsBad(p2, "bad message")
+		assert.False(t, p2.IsRunning())
+	}
+	assert.True(t, book.IsBanned(rp2.Addr()))
+	score, ok = sw.PeerTrustScore(rp2.ID())
+	require.True(t, ok)
+	assert.Less(t, score, trustScoreBanThreshold)
+
+	_, err = addPeer(rp2)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ErrRejected).IsFiltered())
+	}
+	_, err = addPeer(rp1)
+	assert.NoError(t, err)
+}
+
+func TestSwitchStopPeerForErrorDoesNotBan(t *testing.T) {
+	trustStore := trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig())
+	banList, err := NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc,
+		WithTrustMetricStore(trustStore), WithBanList(banList))
+	err = sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mp := newMockPeer(net.IP{127, 0, 0, 1})
+	startPeer := func() Peer {
+		p := &transientMockPeer{&mockPeer{ip: mp.ip, id: mp.id}}
+		p.BaseService = *service.NewBaseService(nil, "MockPeer", p)
+		require.NoError(t, p.Start())
+		return p
+	}
+
+	// errors like timeouts only disconnect the peer
+	p := startPeer()
+	sw.StopPeerForError(p, "timed out")
+	assert.False(t, p.IsRunning())
+	assert.Nil(t, banList.IsBanned(p.ID(), nil))
+	_, ok := sw.PeerTrustScore(p.ID())
+	assert.False(t, ok)
+
+	// a single misbehaviour doesn't ban a new peer either, even though its
+	// score drops below the threshold, but misbehaving again does, even for
+	// peers without a socket address
+	for i := 0; i < trustScoreBanMinBadEvents; i++ {
+		assert.Nil(t, banList.IsBanned(p.ID(), nil))
+		p = startPeer()
+		sw.MarkPeerAsBad(p, "bad message")
+		assert.False(t, p.IsRunning())
+		score, ok := sw.PeerTrustScore(p.ID())
+		require.True(t, ok)
+		assert.Less(t, score, trustScoreBanThreshold)
+	}
+	assert.NotNil(t, banList.IsBanned(p.ID(), nil))
+	assert.True(t, sw.isBanned(p))
+
+	// reports for stopped peers are ignored
+	sw.MarkPeerAsBad(p, "bad message")
+	assert.Equal(t, trustScoreBanMinBadEvents, trustStore.GetPeerTrustMetric(string(p.ID())).BadEventCount())
+}
+
+// transientMockPeer is a mock peer that isn't persistent, so it can be banned.
+type transientMockPeer struct {
+	*mockPeer
+}
+
+func (mp *transientMockPeer) IsPersistent() bool { return false }
+
+func TestSwitchBanPeers(t *testing.T) {
+	banList, err := NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, WithBanList(banList))
+	MultiplexTransportBanList(banList)(sw.transport.(*MultiplexTransport))
+	err = sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	require.NotNil(t, sw.Peers().Get(rp.ID()))
+
+	// banning the peer disconnects it, and it can't be dialed anymore
+	ban, err := sw.BanPeers(string(rp.ID()), 0, "spam")
+	require.NoError(t, err)
+	assert.Equal(t, "spam", ban.Reason)
+	assert.Nil(t, sw.Peers().Get(rp.ID()))
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	if assert.Error(t, err) {
+		assert.True(t, err.(ErrRejected).IsFiltered())
+	}
+
+	bans, err := sw.Bans()
+	require.NoError(t, err)
+	assert.Len(t, bans, 1)
+
+	// allowing it overrides the ban
+	_, err = sw.AllowPeers(rp.Addr().IP.String(), "trusted")
+	require.NoError(t, err)
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+
+	require.NoError(t, sw.UnbanPeers(string(rp.ID())))
+	assert.Equal(t, ErrBanNotFound, sw.UnbanPeers(string(rp.ID())))
+}
+
+func TestSwitchEvictsUntrustedInboundPeer(t *testing.T) {
+	defer func(max int) { cfg.MaxNumInboundPeers = max }(cfg.MaxNumInboundPeers)
+	cfg.MaxNumInboundPeers = 1
+
+	trustStore := trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig())
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, WithTrustMetricStore(trustStore))
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	dial := func(rp *remotePeer) {
+		c, err := rp.Dial(sw.NetAddress())
+		require.NoError(t, err)
+		// spawn a reading routine to prevent connection from closing
+		go func(c net.Conn) {
+			for {
+				one := make([]byte, 1)
+				_, err := c.Read(one)
+				if err != nil {
+					return
+				}
+			}
+		}(c)
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	rp1 := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp1.Start()
+	defer rp1.Stop()
+	dial(rp1)
+	require.True(t, sw.Peers().Has(rp1.ID()))
+
+	// a trusted peer is not evicted
+	rp2 := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp2.Start()
+	defer rp2.Stop()
+	dial(rp2)
+	assert.True(t, sw.Peers().Has(rp1.ID()))
+	assert.False(t, sw.Peers().Has(rp2.ID()))
+
+	// an untrusted peer is evicted for a new one
+	trustStore.GetPeerTrustMetric(string(rp1.ID())).BadEvents(1)
+	dial(rp2)
+	assert.False(t, sw.Peers().Has(rp1.ID()))
+	assert.True(t, sw.Peers().Has(rp2.ID()))
+}
+
 type errorTransport struct {
 	acceptErr error
 }
@@ -715,6 +946,19 @@ func TestSwitchAcceptRoutineErrorCases(t *testing.T) {
 	})
 }
 
+func TestSwitchChannelRates(t *testing.T) {
+	chDesc := &conn.ChannelDescriptor{ID: 0x01, SendRate: 100, RecvRate: 100}
+	sw := NewSwitch(cfg, errorTransport{ErrTransportClosed{}},
+		WithChannelRates(map[byte]ChannelRate{0x01: {SendRate: 200}}))
+	sw.AddReactor("test", NewTestReactor([]*conn.ChannelDescriptor{chDesc}, false, 0, false))
+
+	require.Len(t, sw.chDescs, 1)
+	assert.EqualValues(t, 200, sw.chDescs[0].SendRate)
+	assert.EqualValues(t, 100, sw.chDescs[0].RecvRate)
+	// the reactor's descriptor is left as it is
+	assert.EqualValues(t, 100, chDesc.SendRate)
+}
+
 // mockReactor checks that InitPeer never called before RemovePeer. If that's
 // not true, InitCalledBeforeRemoveFinished will return true.
 type mockReactor struct {
@@ -840,6 +1084,7 @@ func BenchmarkSwitchBroadcast(b *testing.B) {
 type addrBookMock struct {
 	addrs    map[string]struct{}
 	ourAddrs map[string]struct{}
+	banned   map[ID]struct{}
 }
 
 var _ AddrBook = (*addrBookMock)(nil)
@@ -857,6 +1102,16 @@ func (book *addrBookMock) OurAddress(addr *NetAddress) bool {
 	return ok
 }
 func (book *addrBookMock) MarkGood(ID) {}
+func (book *addrBookMock) MarkBad(addr *NetAddress, banTime time.Duration) {
+	if book.banned == nil {
+		book.banned = make(map[ID]struct{})
+	}
+	book.banned[addr.ID] = struct{}{}
+}
+func (book *addrBookMock) IsBanned(addr *NetAddress) bool {
+	_, ok := book.banned[addr.ID]
+	return ok
+}
 func (book *addrBookMock) RemoveAddress(addr *NetAddress) {
 	delete(book.addrs, addr.String())
 }
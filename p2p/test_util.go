@@ -306,7 +306,9 @@ func (book *AddrBookMock) OurAddress(addr *NetAddress) bool {
 	_, ok := book.OurAddrs[addr.String()]
 	return ok
 }
-func (book *AddrBookMock) MarkGood(ID) {}
+func (book *AddrBookMock) MarkGood(ID)                        {}
+func (book *AddrBookMock) MarkBad(*NetAddress, time.Duration) {}
+func (book *AddrBookMock) IsBanned(*NetAddress) bool          { return false }
 func (book *AddrBookMock) HasAddress(addr *NetAddress) bool {
 	_, ok := book.Addrs[addr.String()]
 	return ok
@@ -125,6 +125,12 @@ func MultiplexTransportResolver(resolver IPResolver) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.resolver = resolver }
 }
 
+// MultiplexTransportBanList sets the BanList used to reject banned nodes on
+// dial and accept.
+func MultiplexTransportBanList(banList *BanList) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.banList = banList }
+}
+
 // MultiplexTransportMaxIncomingConnections sets the maximum number of
 // simultaneous connections (incoming). Default: 0 (unlimited)
 func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
@@ -141,16 +147,12 @@ type MultiplexTransport struct {
 	acceptc chan accept
 	closec  chan struct{}
 
-	// Lookup table for duplicate ip and id checks.
-	conns       ConnSet
-	connFilters []ConnFilterFunc
+	connFilter
 
 	dialTimeout      time.Duration
-	filterTimeout    time.Duration
 	handshakeTimeout time.Duration
 	nodeInfo         NodeInfo
 	nodeKey          NodeKey
-	resolver         IPResolver
 
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
@@ -167,19 +169,22 @@ func NewMultiplexTransport(
 	nodeInfo NodeInfo,
 	nodeKey NodeKey,
 	mConfig conn.MConnConfig,
+	options ...MultiplexTransportOption,
 ) *MultiplexTransport {
-	return &MultiplexTransport{
+	mt := &MultiplexTransport{
 		acceptc:          make(chan accept),
 		closec:           make(chan struct{}),
+		connFilter:       newConnFilter(),
 		dialTimeout:      defaultDialTimeout,
-		filterTimeout:    defaultFilterTimeout,
 		handshakeTimeout: defaultHandshakeTimeout,
 		mConfig:          mConfig,
 		nodeInfo:         nodeInfo,
 		nodeKey:          nodeKey,
-		conns:            NewConnSet(),
-		resolver:         net.DefaultResolver,
 	}
+	for _, option := range options {
+		option(mt)
+	}
+	return mt
 }
 
 // NetAddress implements Transport.
@@ -210,6 +215,10 @@ func (mt *MultiplexTransport) Dial(
 	addr NetAddress,
 	cfg peerConfig,
 ) (Peer, error) {
+	if err := mt.checkBanned(addr.ID, addr.IP); err != nil {
+		return nil, ErrRejected{addr: addr, id: addr.ID, err: err, isFiltered: true}
+	}
+
 	c, err := addr.DialTimeout(mt.dialTimeout)
 	if err != nil {
 		return nil, err
@@ -360,7 +369,25 @@ func (mt *MultiplexTransport) cleanup(c net.Conn) error {
 	return c.Close()
 }
 
-func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
+// connFilter is the lookup table for duplicate ip and id checks, and the
+// filters applied to new connections, shared by the transports.
+type connFilter struct {
+	conns         ConnSet
+	connFilters   []ConnFilterFunc
+	banList       *BanList // optional
+	filterTimeout time.Duration
+	resolver      IPResolver
+}
+
+func newConnFilter() connFilter {
+	return connFilter{
+		conns:         NewConnSet(),
+		filterTimeout: defaultFilterTimeout,
+		resolver:      net.DefaultResolver,
+	}
+}
+
+func (cf *connFilter) filterConn(c net.Conn) (err error) {
 	defer func() {
 		if err != nil {
 			_ = c.Close()
@@ -368,21 +395,31 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 	}()
 
 	// Reject if connection is already present.
-	if mt.conns.Has(c) {
+	if cf.conns.Has(c) {
 		return ErrRejected{conn: c, isDuplicate: true}
 	}
 
 	// Resolve ips for incoming conn.
-	ips, err := resolveIPs(mt.resolver, c)
+	ips, err := resolveIPs(cf.resolver, c)
 	if err != nil {
 		return err
 	}
 
-	errc := make(chan error, len(mt.connFilters))
+	// Reject banned ips before the handshake, unless a node ID is allowed,
+	// which is only known after it.
+	if cf.banList != nil && !cf.banList.hasAllowedIDs() {
+		for _, ip := range ips {
+			if err := cf.checkBanned("", ip); err != nil {
+				return ErrRejected{conn: c, err: err, isFiltered: true}
+			}
+		}
+	}
+
+	errc := make(chan error, len(cf.connFilters))
 
-	for _, f := range mt.connFilters {
+	for _, f := range cf.connFilters {
 		go func(f ConnFilterFunc, c net.Conn, ips []net.IP, errc chan<- error) {
-			errc <- f(mt.conns, c, ips)
+			errc <- f(cf.conns, c, ips)
 		}(f, c, ips, errc)
 	}
 
@@ -392,17 +429,28 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 			if err != nil {
 				return ErrRejected{conn: c, err: err, isFiltered: true}
 			}
-		case <-time.After(mt.filterTimeout):
+		case <-time.After(cf.filterTimeout):
 			return ErrFilterTimeout{}
 		}
 
 	}
 
-	mt.conns.Set(c, ips)
+	cf.conns.Set(c, ips)
 
 	return nil
 }
 
+// checkBanned returns an error if the node ID or the IP is banned.
+func (cf *connFilter) checkBanned(id ID, ip net.IP) error {
+	if cf.banList == nil {
+		return nil
+	}
+	if ban := cf.banList.IsBanned(id, ip); ban != nil {
+		return fmt.Errorf("%s is banned: %s", ban.Target, ban.Reason)
+	}
+	return nil
+}
+
 func (mt *MultiplexTransport) upgrade(
 	c net.Conn,
 	dialedAddr *NetAddress,
@@ -422,11 +470,75 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	// For outgoing conns, ensure connection key matches dialed key.
 	connID := PubKeyToID(secretConn.RemotePubKey())
+	var remoteIP net.IP
+	if tcpAddr, ok := c.RemoteAddr().(*net.TCPAddr); ok {
+		remoteIP = tcpAddr.IP
+	}
+	if err := mt.checkConnID(c, connID, remoteIP, dialedAddr); err != nil {
+		return nil, nil, err
+	}
+
+	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	if err := checkNodeInfo(c, connID, mt.nodeInfo, nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	return secretConn, nodeInfo, nil
+}
+
+func (mt *MultiplexTransport) wrapPeer(
+	c net.Conn,
+	ni NodeInfo,
+	cfg peerConfig,
+	socketAddr *NetAddress,
+) Peer {
+
+	peerConn := newPeerConn(
+		cfg.outbound,
+		isPersistentPeer(cfg, ni, socketAddr),
+		c,
+		socketAddr,
+	)
+
+	p := newPeer(
+		peerConn,
+		mt.mConfig,
+		ni,
+		cfg.reactorsByCh,
+		cfg.chDescs,
+		cfg.onPeerError,
+		PeerMetrics(cfg.metrics),
+	)
+
+	return p
+}
+
+// checkConnID rejects the connection if the node ID it's authenticated with,
+// or its IP, is banned, or for outgoing connections, if the ID doesn't match
+// the dialed one.
+func (cf *connFilter) checkConnID(
+	c net.Conn,
+	connID ID,
+	remoteIP net.IP,
+	dialedAddr *NetAddress,
+) error {
+	if err := cf.checkBanned(connID, remoteIP); err != nil {
+		return ErrRejected{conn: c, id: connID, err: err, isFiltered: true}
+	}
+
+	// For outgoing conns, ensure connection key matches dialed key.
 	if dialedAddr != nil {
 		if dialedID := dialedAddr.ID; connID != dialedID {
-			return nil, nil, ErrRejected{
+			return ErrRejected{
 				conn: c,
 				id:   connID,
 				err: fmt.Errorf(
@@ -439,17 +551,19 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
-	if err != nil {
-		return nil, nil, ErrRejected{
-			conn:          c,
-			err:           fmt.Errorf("handshake failed: %v", err),
-			isAuthFailure: true,
-		}
-	}
+	return nil
+}
 
+// checkNodeInfo rejects the NodeInfo received in the handshake on a connection
+// authenticated with connID if it's invalid, not ours, self or incompatible.
+func checkNodeInfo(
+	c net.Conn,
+	connID ID,
+	ourNodeInfo NodeInfo,
+	nodeInfo NodeInfo,
+) error {
 	if err := nodeInfo.Validate(); err != nil {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn:              c,
 			err:               err,
 			isNodeInfoInvalid: true,
@@ -458,7 +572,7 @@ func (mt *MultiplexTransport) upgrade(
 
 	// Ensure connection key matches self reported key.
 	if connID != nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn: c,
 			id:   connID,
 			err: fmt.Errorf(
@@ -471,17 +585,17 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	// Reject self.
-	if mt.nodeInfo.ID() == nodeInfo.ID() {
-		return nil, nil, ErrRejected{
-			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
+	if ourNodeInfo.ID() == nodeInfo.ID() {
+		return ErrRejected{
+			addr:   *remoteNetAddress(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
 			id:     nodeInfo.ID(),
 			isSelf: true,
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
-		return nil, nil, ErrRejected{
+	if err := ourNodeInfo.CompatibleWith(nodeInfo); err != nil {
+		return ErrRejected{
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
@@ -489,46 +603,34 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	return secretConn, nodeInfo, nil
+	return nil
 }
 
-func (mt *MultiplexTransport) wrapPeer(
-	c net.Conn,
-	ni NodeInfo,
-	cfg peerConfig,
-	socketAddr *NetAddress,
-) Peer {
-
-	persistent := false
-	if cfg.isPersistent != nil {
-		if cfg.outbound {
-			persistent = cfg.isPersistent(socketAddr)
-		} else {
-			selfReportedAddr, err := ni.NetAddress()
-			if err == nil {
-				persistent = cfg.isPersistent(selfReportedAddr)
-			}
-		}
+// remoteNetAddress returns the NetAddress of the node at the remote address of
+// a TCP or QUIC connection.
+func remoteNetAddress(id ID, addr net.Addr) *NetAddress {
+	if udpAddr, ok := addr.(*net.UDPAddr); ok {
+		na := NewNetAddressIPPort(udpAddr.IP, uint16(udpAddr.Port))
+		na.ID = id
+		return na
 	}
+	return NewNetAddress(id, addr)
+}
 
-	peerConn := newPeerConn(
-		cfg.outbound,
-		persistent,
-		c,
-		socketAddr,
-	)
-
-	p := newPeer(
-		peerConn,
-		mt.mConfig,
-		ni,
-		cfg.reactorsByCh,
-		cfg.chDescs,
-		cfg.onPeerError,
-		PeerMetrics(cfg.metrics),
-	)
-
-	return p
+// isPersistentPeer tells if the peer is persistent, from the dialed address
+// for outbound peers, or from the self-reported one for inbound peers.
+func isPersistentPeer(cfg peerConfig, ni NodeInfo, socketAddr *NetAddress) bool {
+	if cfg.isPersistent == nil {
+		return false
+	}
+	if cfg.outbound {
+		return cfg.isPersistent(socketAddr)
+	}
+	selfReportedAddr, err := ni.NetAddress()
+	if err != nil {
+		return false
+	}
+	return cfg.isPersistent(selfReportedAddr)
 }
 
 func handshake(
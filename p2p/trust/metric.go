@@ -74,6 +74,9 @@ type Metric struct {
 	// The number of recorded good and bad events for the current time interval
 	bad, good float64
 
+	// The number of bad events recorded since the metric was created
+	badCount int
+
 	// While true, history data is not modified
 	paused bool
 
@@ -179,6 +182,16 @@ func (tm *Metric) BadEvents(num int) {
 
 	tm.unpause()
 	tm.bad += float64(num)
+	tm.badCount += num
+}
+
+// BadEventCount returns the number of bad events recorded since the metric
+// was created
+func (tm *Metric) BadEventCount() int {
+	tm.mtx.Lock()
+	defer tm.mtx.Unlock()
+
+	return tm.badCount
 }
 
 // GoodEvents indicates that a desirable event(s) took place
@@ -277,6 +290,7 @@ func (tm *Metric) Copy() *Metric {
 		historyValue:       tm.historyValue,
 		good:               tm.good,
 		bad:                tm.bad,
+		badCount:           tm.badCount,
 		paused:             tm.paused,
 	}
 
@@ -22,6 +22,7 @@ func TestTrustMetricScores(t *testing.T) {
 	tm.BadEvents(10)
 	score = tm.TrustScore()
 	assert.NotEqual(t, 100, score)
+	assert.Equal(t, 10, tm.BadEventCount())
 	err = tm.Stop()
 	require.NoError(t, err)
 }
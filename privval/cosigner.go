@@ -0,0 +1,298 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/threshold"
+	tmbytes "github.com/Finschia/ostracon/libs/bytes"
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/libs/tempfile"
+	"github.com/Finschia/ostracon/types"
+)
+
+// maxCosignerSessions is the number of sessions a cosigner keeps the nonces
+// of. Older sessions are dropped.
+const maxCosignerSessions = 64
+
+// Cosigner holds a share of the validator key and takes part in the threshold
+// signing sessions of a ThresholdSigner.
+type Cosigner interface {
+	// ID returns the ID of the key share held by the cosigner.
+	ID() int
+	// Commit starts a signing session by committing to fresh nonces.
+	Commit(ctx context.Context, req CosignerCommitRequest) (*CosignerCommitResponse, error)
+	// Sign returns the share of the cosigner of the signature or VRF proof of
+	// a session.
+	Sign(ctx context.Context, req CosignerSignRequest) (*CosignerSignResponse, error)
+}
+
+// CosignerCommitRequest starts a signing session.
+type CosignerCommitRequest struct {
+	// VRFMessage is set for VRF proof sessions.
+	VRFMessage []byte `json:"vrf_message,omitempty"`
+}
+
+// CosignerCommitResponse contains the commitment of a cosigner for a session.
+type CosignerCommitResponse struct {
+	SessionID  tmbytes.HexBytes     `json:"session_id"`
+	Commitment threshold.Commitment `json:"commitment"`
+}
+
+// CosignerSignRequest requests the share of a cosigner for a session. Exactly
+// one of Vote, Proposal and VRFMessage must be set.
+type CosignerSignRequest struct {
+	SessionID tmbytes.HexBytes `json:"session_id"`
+	ChainID   string           `json:"chain_id"`
+	// Vote and Proposal are protobuf encoded.
+	Vote       []byte `json:"vote,omitempty"`
+	Proposal   []byte `json:"proposal,omitempty"`
+	VRFMessage []byte `json:"vrf_message,omitempty"`
+	// Commitments of all the cosigners taking part in the session.
+	Commitments []threshold.Commitment `json:"commitments"`
+}
+
+// CosignerSignResponse contains the share of a cosigner for a session.
+type CosignerSignResponse struct {
+	Share []byte `json:"share"`
+}
+
+//-------------------------------------------------------------------------------
+
+// LoadKeyShare loads a key share from a JSON file.
+func LoadKeyShare(filePath string) (threshold.KeyShare, error) {
+	var keyShare threshold.KeyShare
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return keyShare, err
+	}
+	if err := tmjson.Unmarshal(bz, &keyShare); err != nil {
+		return keyShare, fmt.Errorf("error reading key share from %v: %w", filePath, err)
+	}
+	if err := keyShare.ValidateBasic(); err != nil {
+		return keyShare, fmt.Errorf("invalid key share in %v: %w", filePath, err)
+	}
+	return keyShare, nil
+}
+
+// SaveKeyShare persists a key share to a JSON file.
+func SaveKeyShare(keyShare threshold.KeyShare, filePath string) error {
+	bz, err := tmjson.MarshalIndent(keyShare, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filePath, bz, 0600)
+}
+
+// loadOrGenLastSignState loads the last sign state from filePath, or returns
+// an empty state to be persisted to filePath if the file doesn't exist.
+func loadOrGenLastSignState(filePath string) (FilePVLastSignState, error) {
+	lss := FilePVLastSignState{Step: stepNone, filePath: filePath}
+	if !tmos.FileExists(filePath) {
+		return lss, nil
+	}
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return lss, err
+	}
+	if err := tmjson.Unmarshal(bz, &lss); err != nil {
+		return lss, fmt.Errorf("error reading last sign state from %v: %w", filePath, err)
+	}
+	lss.filePath = filePath
+	return lss, nil
+}
+
+//-------------------------------------------------------------------------------
+
+// LocalCosigner is a Cosigner holding its key share in memory.
+//
+// It persists the last vote or proposal it signed a share for, and refuses to
+// sign anything which would be a double sign, so the validator can't double
+// sign unless threshold cosigners are compromised.
+type LocalCosigner struct {
+	mtx tmsync.Mutex
+
+	keyShare      threshold.KeyShare
+	lastSignState FilePVLastSignState
+
+	sessions     map[string]cosignerSession
+	sessionOrder []string
+}
+
+type cosignerSession struct {
+	nonces     *threshold.Nonces
+	vrfMessage []byte
+}
+
+var _ Cosigner = (*LocalCosigner)(nil)
+
+// NewLocalCosigner returns a LocalCosigner signing with keyShare, which
+// persists its last sign state to stateFilePath.
+func NewLocalCosigner(keyShare threshold.KeyShare, stateFilePath string) (*LocalCosigner, error) {
+	if err := keyShare.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalCosigner{
+		keyShare:      keyShare,
+		lastSignState: lss,
+		sessions:      make(map[string]cosignerSession),
+	}, nil
+}
+
+// LoadLocalCosigner loads a LocalCosigner from the key share file and the
+// state file, which is created if it doesn't exist.
+func LoadLocalCosigner(keyShareFilePath, stateFilePath string) (*LocalCosigner, error) {
+	keyShare, err := LoadKeyShare(keyShareFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalCosigner(keyShare, stateFilePath)
+}
+
+// ID implements Cosigner.
+func (lc *LocalCosigner) ID() int {
+	return lc.keyShare.ID
+}
+
+// Group returns the public part of the key the share is part of.
+func (lc *LocalCosigner) Group() threshold.Group {
+	return lc.keyShare.Group
+}
+
+// Commit implements Cosigner.
+func (lc *LocalCosigner) Commit(_ context.Context, req CosignerCommitRequest) (*CosignerCommitResponse, error) {
+	var (
+		nonces     *threshold.Nonces
+		commitment threshold.Commitment
+		err        error
+	)
+	if req.VRFMessage != nil {
+		nonces, commitment, err = lc.keyShare.VRFCommit(req.VRFMessage)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		nonces, commitment = lc.keyShare.Commit()
+	}
+
+	lc.mtx.Lock()
+	defer lc.mtx.Unlock()
+
+	sessionID := crypto.CRandBytes(16)
+	lc.sessions[string(sessionID)] = cosignerSession{nonces: nonces, vrfMessage: req.VRFMessage}
+	lc.sessionOrder = append(lc.sessionOrder, string(sessionID))
+	if len(lc.sessionOrder) > maxCosignerSessions {
+		delete(lc.sessions, lc.sessionOrder[0])
+		lc.sessionOrder = lc.sessionOrder[1:]
+	}
+
+	return &CosignerCommitResponse{SessionID: sessionID, Commitment: commitment}, nil
+}
+
+// Sign implements Cosigner.
+func (lc *LocalCosigner) Sign(_ context.Context, req CosignerSignRequest) (*CosignerSignResponse, error) {
+	lc.mtx.Lock()
+	defer lc.mtx.Unlock()
+
+	// nonces must never be used twice
+	session, ok := lc.sessions[string(req.SessionID)]
+	if !ok {
+		return nil, fmt.Errorf("unknown session %v", req.SessionID)
+	}
+	delete(lc.sessions, string(req.SessionID))
+	for i, id := range lc.sessionOrder {
+		if id == string(req.SessionID) {
+			lc.sessionOrder = append(lc.sessionOrder[:i], lc.sessionOrder[i+1:]...)
+			break
+		}
+	}
+
+	signShare := func(signBytes []byte) ([]byte, error) {
+		if session.vrfMessage != nil {
+			return nil, errors.New("session is for a VRF proof")
+		}
+		return lc.keyShare.SignShare(signBytes, session.nonces, req.Commitments)
+	}
+
+	var (
+		share []byte
+		err   error
+	)
+	switch {
+	case req.Vote != nil:
+		var vote tmproto.Vote
+		if err := proto.Unmarshal(req.Vote, &vote); err != nil {
+			return nil, fmt.Errorf("invalid vote: %w", err)
+		}
+		if !types.IsVoteTypeValid(vote.Type) {
+			return nil, fmt.Errorf("invalid vote type %v", vote.Type)
+		}
+		share, err = lc.signShare(vote.Height, vote.Round, voteToStep(&vote),
+			types.VoteSignBytes(req.ChainID, &vote), checkVotesOnlyDifferByTimestamp, signShare)
+	case req.Proposal != nil:
+		var proposal tmproto.Proposal
+		if err := proto.Unmarshal(req.Proposal, &proposal); err != nil {
+			return nil, fmt.Errorf("invalid proposal: %w", err)
+		}
+		share, err = lc.signShare(proposal.Height, proposal.Round, stepPropose,
+			types.ProposalSignBytes(req.ChainID, &proposal), checkProposalsOnlyDifferByTimestamp, signShare)
+	case req.VRFMessage != nil:
+		if !bytes.Equal(req.VRFMessage, session.vrfMessage) {
+			return nil, errors.New("VRF message doesn't match the session")
+		}
+		share, err = lc.keyShare.VRFShare(req.VRFMessage, session.nonces, req.Commitments)
+	default:
+		return nil, errors.New("nothing to sign")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &CosignerSignResponse{Share: share}, nil
+}
+
+// signShare checks the height, round and step against the last sign state, and
+// signs a share of signBytes.
+//
+// Unlike FilePV, which returns its last signature again, a share can't be
+// reused as its nonces are only valid for one session. So a share is signed
+// again for the last sign bytes, or for sign bytes which only differ from them
+// by their timestamp, e.g. if the ThresholdSigner lost its state or another
+// node of the validator signs. This isn't a double sign, and the last sign
+// state is kept as it is.
+func (lc *LocalCosigner) signShare(height int64, round int32, step int8, signBytes []byte,
+	onlyDifferByTimestamp func(lastSignBytes, newSignBytes []byte) (time.Time, bool),
+	sign func(signBytes []byte) ([]byte, error)) ([]byte, error) {
+	lss := &lc.lastSignState
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil {
+		return nil, err
+	}
+	if sameHRS && !bytes.Equal(signBytes, lss.SignBytes) {
+		if _, ok := onlyDifferByTimestamp(lss.SignBytes, signBytes); !ok {
+			return nil, errors.New("conflicting data")
+		}
+	}
+
+	share, err := sign(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !sameHRS {
+		lss.saveSigned(height, round, step, signBytes, share)
+	}
+	return share, nil
+}
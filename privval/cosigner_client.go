@@ -0,0 +1,53 @@
+package privval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Finschia/ostracon/crypto"
+	rpcclient "github.com/Finschia/ostracon/rpc/jsonrpc/client"
+)
+
+// RemoteCosigner is a Cosigner served by a CosignerServer.
+type RemoteCosigner struct {
+	id     int
+	client *rpcclient.Client
+}
+
+var _ Cosigner = (*RemoteCosigner)(nil)
+
+// NewRemoteCosigner returns the cosigner holding the share id, served at
+// remoteAddr by the node nodeID. The client authenticates with connKey, which
+// must be allowed by the cosigner.
+func NewRemoteCosigner(id int, nodeID, remoteAddr string, connKey crypto.PrivKey) (*RemoteCosigner, error) {
+	client, err := newSecretConnRPCClient(remoteAddr, nodeID, connKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cosigner address %v: %w", remoteAddr, err)
+	}
+	return &RemoteCosigner{id: id, client: client}, nil
+}
+
+// ID implements Cosigner.
+func (rc *RemoteCosigner) ID() int {
+	return rc.id
+}
+
+// Commit implements Cosigner.
+func (rc *RemoteCosigner) Commit(ctx context.Context, req CosignerCommitRequest) (*CosignerCommitResponse, error) {
+	resp := new(CosignerCommitResponse)
+	_, err := rc.client.Call(ctx, cosignerCommitMethod, map[string]interface{}{"request": req}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Sign implements Cosigner.
+func (rc *RemoteCosigner) Sign(ctx context.Context, req CosignerSignRequest) (*CosignerSignResponse, error) {
+	resp := new(CosignerSignResponse)
+	_, err := rc.client.Call(ctx, cosignerSignMethod, map[string]interface{}{"request": req}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+package privval
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/libs/service"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+)
+
+const (
+	cosignerCommitMethod = "cosigner_commit"
+	cosignerSignMethod   = "cosigner_sign"
+)
+
+// CosignerServer serves a LocalCosigner over JSON-RPC to the ThresholdSigners
+// of the nodes of a validator.
+//
+// Requests are served over authenticated encrypted connections, like the ones
+// of remote signers, and only to the nodes whose node IDs are allowed.
+type CosignerServer struct {
+	service.BaseService
+
+	listenAddr   string
+	cosigner     *LocalCosigner
+	connKey      crypto.PrivKey
+	allowedNodes []string
+	listener     net.Listener
+}
+
+// NewCosignerServer returns a CosignerServer for cosigner listening on
+// listenAddr, e.g. tcp://127.0.0.1:26660. It authenticates with connKey and
+// only accepts connections from the nodes in allowedNodes.
+func NewCosignerServer(
+	listenAddr string,
+	cosigner *LocalCosigner,
+	connKey crypto.PrivKey,
+	allowedNodes []string,
+	logger log.Logger,
+) *CosignerServer {
+	cs := &CosignerServer{
+		listenAddr:   listenAddr,
+		cosigner:     cosigner,
+		connKey:      connKey,
+		allowedNodes: allowedNodes,
+	}
+	cs.BaseService = *service.NewBaseService(logger, "CosignerServer", cs)
+	return cs
+}
+
+// OnStart implements service.Service.
+func (cs *CosignerServer) OnStart() error {
+	routes := map[string]*rpcserver.RPCFunc{
+		cosignerCommitMethod: rpcserver.NewRPCFunc(cs.commit, "request"),
+		cosignerSignMethod:   rpcserver.NewRPCFunc(cs.sign, "request"),
+	}
+	mux := http.NewServeMux()
+	rpcLogger := cs.Logger.With("module", "rpc-server")
+	rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+
+	config := rpcserver.DefaultConfig()
+	listener, err := rpcserver.Listen(cs.listenAddr, config)
+	if err != nil {
+		return err
+	}
+	cs.listener = newSecretConnListener(listener, cs.connKey, cs.allowedNodes, cs.Logger)
+
+	go func() {
+		if err := rpcserver.Serve(cs.listener, mux, rpcLogger, config); err != nil && cs.IsRunning() {
+			cs.Logger.Error("Cosigner server stopped", "err", err)
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (cs *CosignerServer) OnStop() {
+	if err := cs.listener.Close(); err != nil {
+		cs.Logger.Error("Error closing listener", "err", err)
+	}
+}
+
+// Addr returns the address the server listens on.
+func (cs *CosignerServer) Addr() net.Addr {
+	return cs.listener.Addr()
+}
+
+func (cs *CosignerServer) commit(ctx *rpctypes.Context, request CosignerCommitRequest) (*CosignerCommitResponse, error) {
+	return cs.cosigner.Commit(ctx.Context(), request)
+}
+
+func (cs *CosignerServer) sign(ctx *rpctypes.Context, request CosignerSignRequest) (*CosignerSignResponse, error) {
+	return cs.cosigner.Sign(ctx.Context(), request)
+}
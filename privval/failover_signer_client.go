@@ -0,0 +1,198 @@
+package privval
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/types"
+)
+
+// FailoverSignerClient wraps several SignerClients of the same validator key,
+// sending each operation to the active signer and failing over to the next
+// signer when it's unavailable.
+//
+// The signers must share their last sign state (see SharedStateFilePV), so
+// that a signer taking over never signs a message conflicting with one signed
+// by another signer. They must also have the same key: the key of each signer
+// is checked before it's first used, and a signer with another key than the
+// first one is never used.
+type FailoverSignerClient struct {
+	mtx     tmsync.Mutex
+	signers []*SignerClient
+	active  int
+	retries int
+	timeout time.Duration
+
+	pubKey     crypto.PubKey   // of the first signer whose key was checked
+	signerKeys []crypto.PubKey // of the signers, once checked
+}
+
+// NewFailoverSignerClient returns FailoverSignerClient. Each operation is
+// tried against every signer up to +retries+ times, waiting +timeout+ between
+// attempts. If +retries+ is 0, the client will be retrying each operation
+// indefinitely.
+func NewFailoverSignerClient(signers []*SignerClient, retries int, timeout time.Duration) (*FailoverSignerClient, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers")
+	}
+	return &FailoverSignerClient{
+		signers:    signers,
+		retries:    retries,
+		timeout:    timeout,
+		signerKeys: make([]crypto.PubKey, len(signers)),
+	}, nil
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+
+// Close closes the connections to all the signers.
+func (sc *FailoverSignerClient) Close() error {
+	var err error
+	for _, signer := range sc.signers {
+		if closeErr := signer.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// IsConnected indicates whether any signer is connected.
+func (sc *FailoverSignerClient) IsConnected() bool {
+	for _, signer := range sc.signers {
+		if signer.IsConnected() {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPubKeys checks the key of every signer which wasn't checked yet, and
+// returns an error if a signer has another key than the others. It should be
+// called at start-up, once the signers are connected. The signers which are
+// unavailable are checked before they are first used.
+func (sc *FailoverSignerClient) CheckPubKeys() error {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	var mismatched error
+	for i := range sc.signers {
+		err := sc.checkPubKey(i)
+		if errors.As(err, &errMismatchedPubKey{}) {
+			mismatched = err
+		}
+	}
+	return mismatched
+}
+
+// errMismatchedPubKey is returned by checkPubKey for a signer with another key
+// than the other signers.
+type errMismatchedPubKey struct {
+	signer         int
+	pubKey, expKey crypto.PubKey
+}
+
+func (e errMismatchedPubKey) Error() string {
+	return fmt.Sprintf("signer %d has key %v, expected %v", e.signer, e.pubKey.Address(), e.expKey.Address())
+}
+
+// checkPubKey checks the key of the ith signer, unless it was already checked.
+// The first key checked is the key of the validator.
+func (sc *FailoverSignerClient) checkPubKey(i int) error {
+	if sc.signerKeys[i] == nil {
+		pubKey, err := sc.signers[i].GetPubKey()
+		if err != nil {
+			return err
+		}
+		sc.signerKeys[i] = pubKey
+		if sc.pubKey == nil {
+			sc.pubKey = pubKey
+		}
+	}
+	if !sc.signerKeys[i].Equals(sc.pubKey) {
+		return errMismatchedPubKey{signer: i, pubKey: sc.signerKeys[i], expKey: sc.pubKey}
+	}
+	return nil
+}
+
+// Active returns the index of the signer operations are sent to first.
+func (sc *FailoverSignerClient) Active() int {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.active
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+func (sc *FailoverSignerClient) GetPubKey() (crypto.PubKey, error) {
+	// the key of the signer is fetched when it's checked
+	var pk crypto.PubKey
+	err := sc.failover("get pubkey", func(*SignerClient) error {
+		pk = sc.pubKey
+		return nil
+	})
+	return pk, err
+}
+
+func (sc *FailoverSignerClient) SignVote(chainID string, vote *tmproto.Vote) error {
+	return sc.failover("sign vote", func(signer *SignerClient) error {
+		return signer.SignVote(chainID, vote)
+	})
+}
+
+func (sc *FailoverSignerClient) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	return sc.failover("sign proposal", func(signer *SignerClient) error {
+		return signer.SignProposal(chainID, proposal)
+	})
+}
+
+func (sc *FailoverSignerClient) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	var proof crypto.Proof
+	err := sc.failover("generate vrf proof", func(signer *SignerClient) (err error) {
+		proof, err = signer.GenerateVRFProof(message)
+		return err
+	})
+	return proof, err
+}
+
+// failover runs op against the active signer, failing over to the next signers
+// until one succeeds. If all the signers refuse op with a RemoteSignerError,
+// the error is returned without retrying. Otherwise, a refusal is returned in
+// preference to a connection error once all the attempts are exhausted.
+func (sc *FailoverSignerClient) failover(op string, f func(signer *SignerClient) error) error {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	var err, refusal error
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		refused := 0
+		for range sc.signers {
+			err = sc.checkPubKey(sc.active)
+			if err == nil {
+				err = f(sc.signers[sc.active])
+			}
+			if err == nil {
+				return nil
+			}
+			if _, ok := err.(*RemoteSignerError); ok {
+				refusal = err
+				refused++
+			}
+			sc.active = (sc.active + 1) % len(sc.signers)
+		}
+		// If all remote signers error, we don't retry.
+		if refused == len(sc.signers) {
+			return err
+		}
+		time.Sleep(sc.timeout)
+	}
+	if refusal != nil {
+		err = refusal
+	}
+	return fmt.Errorf("exhausted all attempts to %s: %w", op, err)
+}
@@ -0,0 +1,106 @@
+package privval
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/types"
+)
+
+func TestFailoverSignerClient(t *testing.T) {
+	chainID := tmrand.Str(12)
+	pvs := newSharedStateFilePVs(t, 2)
+
+	signerClients := make([]*SignerClient, len(pvs))
+	signerServers := make([]*SignerServer, len(pvs))
+	for i, pv := range pvs {
+		addr := GetFreeLocalhostAddrPort()
+		sl, sd := getMockEndpoints(t, addr, DialTCPFn(addr, testTimeoutReadWrite, ed25519.GenPrivKey()))
+		var err error
+		signerClients[i], err = NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+		signerServers[i] = NewSignerServer(sd, chainID, pv)
+		require.NoError(t, signerServers[i].Start())
+	}
+	t.Cleanup(func() {
+		for _, ss := range signerServers {
+			if ss.IsRunning() {
+				if err := ss.Stop(); err != nil {
+					t.Error(err)
+				}
+			}
+		}
+	})
+
+	sc, err := NewFailoverSignerClient(signerClients, 1, 100*time.Millisecond)
+	require.NoError(t, err)
+
+	pubKey, err := sc.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, pvs[0].Key.PubKey, pubKey)
+
+	vote := newVote(pubKey.Address(), 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	require.NoError(t, sc.SignVote(chainID, vote))
+	assert.Equal(t, 0, sc.Active())
+
+	// fail over to the standby signer
+	require.NoError(t, signerServers[0].Stop())
+	proposal := newProposal(11, 0, testBlockID()).ToProto()
+	require.NoError(t, sc.SignProposal(chainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+	assert.Equal(t, 1, sc.Active())
+
+	// which refuses to double sign
+	conflicting := newProposal(11, 0, testBlockID()).ToProto()
+	err = sc.SignProposal(chainID, conflicting)
+	var remoteErr *RemoteSignerError
+	assert.True(t, errors.As(err, &remoteErr), err)
+}
+
+func TestFailoverSignerClientMismatchedPubKey(t *testing.T) {
+	chainID := tmrand.Str(12)
+	pvs := []types.PrivValidator{types.NewMockPV(), types.NewMockPV()}
+
+	signerClients := make([]*SignerClient, len(pvs))
+	signerServers := make([]*SignerServer, len(pvs))
+	for i, pv := range pvs {
+		addr := GetFreeLocalhostAddrPort()
+		sl, sd := getMockEndpoints(t, addr, DialTCPFn(addr, testTimeoutReadWrite, ed25519.GenPrivKey()))
+		var err error
+		signerClients[i], err = NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+		signerServers[i] = NewSignerServer(sd, chainID, pv)
+		require.NoError(t, signerServers[i].Start())
+	}
+	t.Cleanup(func() {
+		for _, ss := range signerServers {
+			if ss.IsRunning() {
+				if err := ss.Stop(); err != nil {
+					t.Error(err)
+				}
+			}
+		}
+	})
+
+	sc, err := NewFailoverSignerClient(signerClients, 1, 100*time.Millisecond)
+	require.NoError(t, err)
+	pubKey, err := sc.GetPubKey()
+	require.NoError(t, err)
+	expKey, err := pvs[0].GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, expKey, pubKey)
+	assert.Error(t, sc.CheckPubKeys())
+
+	// the signer of the other key is never used
+	require.NoError(t, signerServers[0].Stop())
+	vote := newVote(pubKey.Address(), 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	assert.Error(t, sc.SignVote(chainID, vote))
+	assert.Nil(t, vote.Signature)
+}
@@ -11,6 +11,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/armor"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmbytes "github.com/Finschia/ostracon/libs/bytes"
 	tmjson "github.com/Finschia/ostracon/libs/json"
@@ -29,6 +30,9 @@ const (
 	stepPrecommit int8 = 3
 )
 
+// keyBlockType is the armor block type of encrypted FilePVKey files.
+const keyBlockType = "OSTRACON PRIVATE VALIDATOR KEY"
+
 // A vote is either stepPrevote or stepPrecommit.
 func voteToStep(vote *tmproto.Vote) int8 {
 	switch vote.Type {
@@ -44,22 +48,50 @@ func voteToStep(vote *tmproto.Vote) int8 {
 //-------------------------------------------------------------------------------
 
 // FilePVKey stores the immutable part of PrivValidator.
+// NextPrivKey is the key the validator rotates to, which the FilePV switches to
+// once the rotation is active. The Address stays the one of the validator.
 type FilePVKey struct {
-	Address types.Address  `json:"address"`
-	PubKey  crypto.PubKey  `json:"pub_key"`
-	PrivKey crypto.PrivKey `json:"priv_key"`
+	Address     types.Address  `json:"address"`
+	PubKey      crypto.PubKey  `json:"pub_key"`
+	PrivKey     crypto.PrivKey `json:"priv_key"`
+	NextPrivKey crypto.PrivKey `json:"next_priv_key,omitempty"`
 
 	filePath string
+	unlocker armor.Unlocker
+}
+
+// SetUnlocker sets the unlocker the FilePVKey is encrypted with when saved.
+// If unlocker is nil, the FilePVKey is saved in plaintext.
+func (pvKey *FilePVKey) SetUnlocker(unlocker armor.Unlocker) {
+	pvKey.unlocker = unlocker
+}
+
+// Bytes returns the contents of the FilePVKey file: the FilePVKey in JSON,
+// encrypted if it has an unlocker.
+func (pvKey FilePVKey) Bytes() ([]byte, error) {
+	jsonBytes, err := tmjson.MarshalIndent(pvKey, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if pvKey.unlocker == nil {
+		return jsonBytes, nil
+	}
+	armorStr, err := armor.EncryptArmor(keyBlockType, jsonBytes, pvKey.unlocker)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(armorStr), nil
 }
 
-// Save persists the FilePVKey to its filePath.
+// Save persists the FilePVKey to its filePath, encrypted if it has an
+// unlocker.
 func (pvKey FilePVKey) Save() {
 	outFile := pvKey.filePath
 	if outFile == "" {
 		panic("cannot save PrivValidator key: filePath not set")
 	}
 
-	jsonBytes, err := tmjson.MarshalIndent(pvKey, "", "  ")
+	jsonBytes, err := pvKey.Bytes()
 	if err != nil {
 		panic(err)
 	}
@@ -123,6 +155,18 @@ func (lss *FilePVLastSignState) CheckHRS(height int64, round int32, step int8) (
 	return false, nil
 }
 
+// Persist height/round/step and signature
+func (lss *FilePVLastSignState) saveSigned(height int64, round int32, step int8,
+	signBytes []byte, sig []byte) {
+
+	lss.Height = height
+	lss.Round = round
+	lss.Step = step
+	lss.Signature = sig
+	lss.SignBytes = signBytes
+	lss.Save()
+}
+
 // Save persists the FilePvLastSignState to its filePath.
 func (lss *FilePVLastSignState) Save() {
 	outFile := lss.filePath
@@ -177,31 +221,35 @@ func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
 func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+	return loadFilePV(keyFilePath, stateFilePath, true, nil)
+}
+
+// LoadFilePVWithUnlocker loads a FilePV like LoadFilePV, decrypting the key
+// file with unlocker if it's encrypted. The key stays encrypted with unlocker
+// when saved.
+func LoadFilePVWithUnlocker(keyFilePath, stateFilePath string, unlocker armor.Unlocker) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, unlocker)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+	return loadFilePV(keyFilePath, stateFilePath, false, nil)
+}
+
+// LoadFilePVEmptyStateWithUnlocker loads a FilePV like LoadFilePVEmptyState,
+// decrypting the key file with unlocker if it's encrypted.
+func LoadFilePVEmptyStateWithUnlocker(keyFilePath, stateFilePath string, unlocker armor.Unlocker) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, unlocker)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
-	keyJSONBytes, err := os.ReadFile(keyFilePath)
+// An encrypted key file is decrypted with unlocker.
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, unlocker armor.Unlocker) *FilePV {
+	pvKey, err := loadFilePVKey(keyFilePath, unlocker)
 	if err != nil {
 		tmos.Exit(err.Error())
 	}
-	pvKey := FilePVKey{}
-	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
-	if err != nil {
-		tmos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
-	}
-
-	// overwrite pubkey and address for convenience
-	pvKey.PubKey = pvKey.PrivKey.PubKey()
-	pvKey.Address = pvKey.PubKey.Address()
-	pvKey.filePath = keyFilePath
 
 	pvState := FilePVLastSignState{}
 
@@ -224,14 +272,55 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	}
 }
 
+// loadFilePVKey loads a FilePVKey from keyFilePath, decrypting it with unlocker
+// if it's encrypted.
+func loadFilePVKey(keyFilePath string, unlocker armor.Unlocker) (FilePVKey, error) {
+	keyJSONBytes, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return FilePVKey{}, err
+	}
+	if armor.IsArmored(keyJSONBytes) {
+		if unlocker == nil {
+			return FilePVKey{}, fmt.Errorf("PrivValidator key %v is encrypted, but no passphrase or KMS command is set",
+				keyFilePath)
+		}
+		_, keyJSONBytes, err = armor.DecryptArmor(string(keyJSONBytes), unlocker)
+		if err != nil {
+			return FilePVKey{}, fmt.Errorf("error decrypting PrivValidator key from %v: %w", keyFilePath, err)
+		}
+	}
+	pvKey := FilePVKey{}
+	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+	}
+
+	// overwrite pubkey and address for convenience. The address isn't the one of the key anymore
+	// once the validator rotated its key.
+	pvKey.PubKey = pvKey.PrivKey.PubKey()
+	if len(pvKey.Address) == 0 {
+		pvKey.Address = pvKey.PubKey.Address()
+	}
+	pvKey.filePath = keyFilePath
+	pvKey.unlocker = unlocker
+	return pvKey, nil
+}
+
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
+	return LoadOrGenFilePVWithUnlocker(keyFilePath, stateFilePath, nil)
+}
+
+// LoadOrGenFilePVWithUnlocker loads a FilePV like LoadOrGenFilePV, generating
+// a key encrypted with unlocker if unlocker isn't nil.
+func LoadOrGenFilePVWithUnlocker(keyFilePath, stateFilePath string, unlocker armor.Unlocker) *FilePV {
 	var pv *FilePV
 	if tmos.FileExists(keyFilePath) {
-		pv = LoadFilePV(keyFilePath, stateFilePath)
+		pv = LoadFilePVWithUnlocker(keyFilePath, stateFilePath, unlocker)
 	} else {
 		pv = GenFilePV(keyFilePath, stateFilePath)
+		pv.Key.SetUnlocker(unlocker)
 		pv.Save()
 	}
 	return pv
@@ -272,6 +361,49 @@ func (pv *FilePV) GenerateVRFProof(message []byte) (crypto.Proof, error) {
 	return pv.Key.PrivKey.VRFProve(message)
 }
 
+// RotateKey returns the rotation of the key of the validator to newPrivKey from
+// activationHeight on, signed by the current key and newPrivKey. blockHeight and
+// blockTime are the ones of the last committed block. newPrivKey is saved as the
+// next key, replacing the one of a rotation that wasn't committed, so it must be
+// persisted before the rotation is broadcast.
+func (pv *FilePV) RotateKey(chainID string, newPrivKey crypto.PrivKey, activationHeight, blockHeight int64,
+	blockTime time.Time) (*types.KeyRotation, error) {
+	kr, err := types.NewKeyRotation(chainID, pv.Key.Address, pv.Key.PrivKey, newPrivKey, activationHeight,
+		blockHeight, blockTime)
+	if err != nil {
+		return nil, err
+	}
+	pv.Key.NextPrivKey = newPrivKey
+	pv.Key.Save()
+	return kr, nil
+}
+
+// SwitchKey switches to the next key once the rotation of the validator to
+// pubKey is active. The key file is loaded again if the rotation was made by
+// another process, like the CLI, since the FilePV was loaded.
+// Implements types.KeySwitcher.
+func (pv *FilePV) SwitchKey(pubKey crypto.PubKey) error {
+	if pv.Key.PubKey.Equals(pubKey) {
+		return nil
+	}
+	if pv.Key.NextPrivKey == nil || !pv.Key.NextPrivKey.PubKey().Equals(pubKey) {
+		pvKey, err := loadFilePVKey(pv.Key.filePath, pv.Key.unlocker)
+		if err != nil {
+			return err
+		}
+		pv.Key.NextPrivKey = pvKey.NextPrivKey
+	}
+	if pv.Key.NextPrivKey == nil || !pv.Key.NextPrivKey.PubKey().Equals(pubKey) {
+		return fmt.Errorf("no private key for the rotated key %X of validator %v", pubKey.Bytes(), pv.Key.Address)
+	}
+
+	pv.Key.PrivKey = pv.Key.NextPrivKey
+	pv.Key.PubKey = pv.Key.PrivKey.PubKey()
+	pv.Key.NextPrivKey = nil
+	pv.Key.Save()
+	return nil
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
@@ -307,9 +439,21 @@ func (pv *FilePV) String() string {
 // It may need to set the timestamp as well if the vote is otherwise the same as
 // a previously signed vote (ie. we crashed after signing but before the vote hit the WAL).
 func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
-	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	return signVote(&pv.LastSignState, chainID, vote, pv.Key.PrivKey.Sign)
+}
 
-	lss := pv.LastSignState
+// signProposal checks if the proposal is good to sign and sets the proposal signature.
+// It may need to set the timestamp as well if the proposal is otherwise the same as
+// a previously signed proposal ie. we crashed after signing but before the proposal hit the WAL).
+func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error {
+	return signProposal(&pv.LastSignState, chainID, proposal, pv.Key.PrivKey.Sign)
+}
+
+// signVote checks the vote against the last sign state, signs it with sign and
+// persists the new state.
+func signVote(lss *FilePVLastSignState, chainID string, vote *tmproto.Vote,
+	sign func(signBytes []byte) ([]byte, error)) error {
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
@@ -336,23 +480,21 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 	}
 
 	// It passed the checks. Sign the vote
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := sign(signBytes)
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	lss.saveSigned(height, round, step, signBytes, sig)
 	vote.Signature = sig
 	return nil
 }
 
-// signProposal checks if the proposal is good to sign and sets the proposal signature.
-// It may need to set the timestamp as well if the proposal is otherwise the same as
-// a previously signed proposal ie. we crashed after signing but before the proposal hit the WAL).
-func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error {
+// signProposal checks the proposal against the last sign state, signs it with
+// sign and persists the new state.
+func signProposal(lss *FilePVLastSignState, chainID string, proposal *tmproto.Proposal,
+	sign func(signBytes []byte) ([]byte, error)) error {
 	height, round, step := proposal.Height, proposal.Round, stepPropose
 
-	lss := pv.LastSignState
-
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
 		return err
@@ -378,27 +520,15 @@ func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error
 	}
 
 	// It passed the checks. Sign the proposal
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := sign(signBytes)
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	lss.saveSigned(height, round, step, signBytes, sig)
 	proposal.Signature = sig
 	return nil
 }
 
-// Persist height/round/step and signature
-func (pv *FilePV) saveSigned(height int64, round int32, step int8,
-	signBytes []byte, sig []byte) {
-
-	pv.LastSignState.Height = height
-	pv.LastSignState.Round = round
-	pv.LastSignState.Step = step
-	pv.LastSignState.Signature = sig
-	pv.LastSignState.SignBytes = signBytes
-	pv.LastSignState.Save()
-}
-
 //-----------------------------------------------------------------------------------------
 
 // returns the timestamp from the lastSignBytes.
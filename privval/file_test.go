@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Finschia/ostracon/crypto/armor"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	tmjson "github.com/Finschia/ostracon/libs/json"
@@ -53,6 +54,71 @@ func TestGenLoadValidator(t *testing.T) {
 	assert.Equal(height, privVal.LastSignState.Height, "expected privval.LastHeight to have been saved")
 }
 
+func TestGenLoadEncryptedValidator(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	privVal.Key.SetUnlocker(armor.Passphrase("passphrase"))
+	privVal.Save()
+
+	keyBytes, err := os.ReadFile(tempKeyFile.Name())
+	require.NoError(t, err)
+	assert.True(t, armor.IsArmored(keyBytes))
+
+	loaded := LoadFilePVWithUnlocker(tempKeyFile.Name(), tempStateFile.Name(), armor.Passphrase("passphrase"))
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+
+	// stays encrypted when saved again
+	loaded.Save()
+	keyBytes, err = os.ReadFile(tempKeyFile.Name())
+	require.NoError(t, err)
+	assert.True(t, armor.IsArmored(keyBytes))
+}
+
+func TestRotateAndSwitchKey(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	privVal.Key.SetUnlocker(armor.Passphrase("passphrase"))
+	privVal.Save()
+	addr := privVal.GetAddress()
+	oldPubKey := privVal.Key.PubKey
+
+	// the key is rotated by another process, like the CLI
+	rotator := LoadFilePVWithUnlocker(tempKeyFile.Name(), tempStateFile.Name(), armor.Passphrase("passphrase"))
+	newPrivKey := ed25519.GenPrivKey()
+	blockTime := tmtime.Now()
+	kr, err := rotator.RotateKey("mychainid", newPrivKey, 10, 5, blockTime)
+	require.NoError(t, err)
+	assert.Equal(t, addr, kr.ValidatorAddress)
+	assert.Equal(t, newPrivKey.PubKey(), kr.NewPubKey)
+	assert.NoError(t, kr.Verify("mychainid", oldPubKey))
+
+	// nothing changes until the rotation is active
+	require.NoError(t, privVal.SwitchKey(oldPubKey))
+	assert.Equal(t, oldPubKey, privVal.Key.PubKey)
+	assert.Error(t, privVal.SwitchKey(ed25519.GenPrivKey().PubKey()))
+
+	require.NoError(t, privVal.SwitchKey(newPrivKey.PubKey()))
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, newPrivKey.PubKey(), pubKey)
+	assert.Equal(t, addr, privVal.GetAddress(), "the validator address should stay the same")
+	assert.Nil(t, privVal.Key.NextPrivKey)
+
+	// the switch is persisted
+	loaded := LoadFilePVWithUnlocker(tempKeyFile.Name(), tempStateFile.Name(), armor.Passphrase("passphrase"))
+	assert.Equal(t, newPrivKey, loaded.Key.PrivKey)
+	assert.Nil(t, loaded.Key.NextPrivKey)
+	assert.Equal(t, addr, loaded.GetAddress())
+}
+
 func TestResetValidator(t *testing.T) {
 	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
 	require.Nil(t, err)
@@ -0,0 +1,14 @@
+//go:build unix
+
+package privval
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFileExclusive blocks until it acquires an exclusive lock on file, which
+// is released when file is closed.
+func lockFileExclusive(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
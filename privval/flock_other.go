@@ -0,0 +1,13 @@
+//go:build !unix
+
+package privval
+
+import (
+	"errors"
+	"os"
+)
+
+// lockFileExclusive is not supported on this platform.
+func lockFileExclusive(file *os.File) error {
+	return errors.New("file locks are not supported on this platform")
+}
@@ -22,6 +22,8 @@ func mustWrapMsg(pb proto.Message) ocprivvalproto.Message {
 		msg.Sum = &ocprivvalproto.Message_PubKeyRequest{PubKeyRequest: pb}
 	case *privvalproto.PubKeyResponse:
 		msg.Sum = &ocprivvalproto.Message_PubKeyResponse{PubKeyResponse: pb}
+	case *ocprivvalproto.PubKeyResponse:
+		msg.Sum = &ocprivvalproto.Message_OcPubKeyResponse{OcPubKeyResponse: pb}
 	case *privvalproto.SignVoteRequest:
 		msg.Sum = &ocprivvalproto.Message_SignVoteRequest{SignVoteRequest: pb}
 	case *privvalproto.SignedVoteResponse:
@@ -0,0 +1,51 @@
+package privval
+
+import (
+	"fmt"
+	"os"
+
+	tmjson "github.com/Finschia/ostracon/libs/json"
+	"github.com/Finschia/ostracon/libs/tempfile"
+)
+
+// PKCS11Config locates a validator key held in a PKCS#11 token.
+type PKCS11Config struct {
+	// ModulePath is the path to the PKCS#11 module of the HSM.
+	ModulePath string
+	// TokenLabel is the label of the token holding the key.
+	TokenLabel string
+	// PIN is the PIN of the user of the token.
+	PIN string
+	// KeyLabel is the label of the key in the token.
+	KeyLabel string
+	// VRFKeyFilePath is the path to the encrypted VRF key.
+	VRFKeyFilePath string
+}
+
+// pkcs11VRFKey is a copy of the validator signing key to generate VRF proofs
+// with, encrypted with AES-GCM by a key of the token: either its seed, or its
+// PKCS#8 encoding if it was generated in the token and wrapped.
+type pkcs11VRFKey struct {
+	IV         []byte `json:"iv"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func loadPKCS11VRFKey(filePath string) (pkcs11VRFKey, error) {
+	var key pkcs11VRFKey
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return key, err
+	}
+	if err := tmjson.Unmarshal(bz, &key); err != nil {
+		return key, fmt.Errorf("error reading VRF key from %v: %w", filePath, err)
+	}
+	return key, nil
+}
+
+func (key pkcs11VRFKey) save(filePath string) error {
+	bz, err := tmjson.MarshalIndent(key, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filePath, bz, 0600)
+}
@@ -0,0 +1,509 @@
+//go:build pkcs11
+
+package privval
+
+import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/miekg/pkcs11"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	tmos "github.com/Finschia/ostracon/libs/os"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/types"
+)
+
+// PKCS#11 3.0 EdDSA constants, which github.com/miekg/pkcs11 doesn't define.
+const (
+	ckkECEdwards           = 0x00000040
+	ckmECEdwardsKeyPairGen = 0x00001055
+	ckmEdDSA               = 0x00001057
+)
+
+const (
+	// vrfKeyLabelSuffix is appended to the label of the validator key to label
+	// the AES key encrypting the VRF key.
+	vrfKeyLabelSuffix = "-vrf"
+	gcmIVSize         = 12
+	gcmTagBits        = 128
+)
+
+// ed25519ECParams is the DER encoding of the id-Ed25519 OID (RFC 8410).
+var ed25519ECParams = []byte{0x06, 0x03, 0x2b, 0x65, 0x70}
+
+// PKCS11PV implements PrivValidator with an ed25519 key held in a PKCS#11
+// token, which can be tested with SoftHSM.
+//
+// HSMs can't generate VRF proofs, and the VRF key of a validator is its
+// signing key, so a copy of the signing key is kept in the VRF key file,
+// encrypted by an AES key of the token. The token decrypts it into the memory
+// of the process to generate each proof, i.e. on every proposal, and it's wiped
+// right after. The token only protects the key at rest: anyone who can read
+// the memory of the process, or use the token, can recover the signing key, as
+// with FilePV.
+//
+// Like FilePV, it persists the last signed vote or proposal to a state file to
+// prevent double signing.
+type PKCS11PV struct {
+	mtx tmsync.Mutex
+
+	ctx     *pkcs11.Ctx
+	slot    uint
+	session pkcs11.SessionHandle
+
+	pubKey        ed25519.PubKey
+	privKey       pkcs11.ObjectHandle
+	vrfKeyWrapper pkcs11.ObjectHandle
+	vrfKey        pkcs11VRFKey
+
+	lastSignState FilePVLastSignState
+}
+
+var _ types.PrivValidator = (*PKCS11PV)(nil)
+
+// LoadPKCS11PV returns a PKCS11PV signing with the key of cfg, which persists
+// its last sign state to stateFilePath.
+func LoadPKCS11PV(cfg PKCS11Config, stateFilePath string) (*PKCS11PV, error) {
+	pv, err := openPKCS11PV(cfg, stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := pv.loadKeys(cfg); err != nil {
+		pv.Close()
+		return nil, err
+	}
+	return pv, nil
+}
+
+// GenPKCS11PV generates a new key and saves its encrypted copy, the VRF key,
+// to cfg.VRFKeyFilePath. The key is generated in the token if it supports
+// CKM_EC_EDWARDS_KEY_PAIR_GEN, extractable only wrapped by the AES key of the
+// token. Otherwise it's generated in the process, imported into the token and
+// wiped. Either way, it's still decrypted into the process to generate VRF
+// proofs (see PKCS11PV).
+func GenPKCS11PV(cfg PKCS11Config, stateFilePath string) (*PKCS11PV, error) {
+	pv, err := openPKCS11PV(cfg, stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := pv.genKeys(cfg); err != nil {
+		pv.Close()
+		return nil, err
+	}
+	if err := pv.loadKeys(cfg); err != nil {
+		pv.Close()
+		return nil, err
+	}
+	return pv, nil
+}
+
+func openPKCS11PV(cfg PKCS11Config, stateFilePath string) (*PKCS11PV, error) {
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := pkcs11.New(cfg.ModulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %v", cfg.ModulePath)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+	pv := &PKCS11PV{ctx: ctx, lastSignState: lss}
+
+	pv.slot, err = pv.findSlot(cfg.TokenLabel)
+	if err != nil {
+		pv.finalize()
+		return nil, err
+	}
+	pv.session, err = ctx.OpenSession(pv.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		pv.finalize()
+		return nil, fmt.Errorf("failed to open PKCS#11 session: %w", err)
+	}
+	err = ctx.Login(pv.session, pkcs11.CKU_USER, cfg.PIN)
+	if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		ctx.CloseSession(pv.session) //nolint:errcheck
+		pv.finalize()
+		return nil, fmt.Errorf("failed to log in to PKCS#11 token: %w", err)
+	}
+	return pv, nil
+}
+
+func (pv *PKCS11PV) findSlot(tokenLabel string) (uint, error) {
+	slots, err := pv.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := pv.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get PKCS#11 token info: %w", err)
+		}
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11 token %q not found", tokenLabel)
+}
+
+// supportsMechanism returns whether the token supports the mechanism.
+func (pv *PKCS11PV) supportsMechanism(mechanism uint) (bool, error) {
+	mechanisms, err := pv.ctx.GetMechanismList(pv.slot)
+	if err != nil {
+		return false, fmt.Errorf("failed to list PKCS#11 mechanisms: %w", err)
+	}
+	for _, m := range mechanisms {
+		if m.Mechanism == mechanism {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findObject returns the only object of the token of class and label.
+func (pv *PKCS11PV) findObject(class uint, label string) (pkcs11.ObjectHandle, bool, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := pv.ctx.FindObjectsInit(pv.session, template); err != nil {
+		return 0, false, err
+	}
+	objects, _, err := pv.ctx.FindObjects(pv.session, 2)
+	if finalErr := pv.ctx.FindObjectsFinal(pv.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	switch len(objects) {
+	case 0:
+		return 0, false, nil
+	case 1:
+		return objects[0], true, nil
+	default:
+		return 0, false, fmt.Errorf("several PKCS#11 objects labeled %q", label)
+	}
+}
+
+func (pv *PKCS11PV) loadKeys(cfg PKCS11Config) error {
+	var err error
+	pv.privKey, err = pv.mustFindObject(pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	pubKey, err := pv.mustFindObject(pkcs11.CKO_PUBLIC_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	attrs, err := pv.ctx.GetAttributeValue(pv.session, pubKey,
+		[]*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+	pv.pubKey, err = parseEd25519ECPoint(attrs[0].Value)
+	if err != nil {
+		return err
+	}
+
+	pv.vrfKeyWrapper, err = pv.mustFindObject(pkcs11.CKO_SECRET_KEY, cfg.KeyLabel+vrfKeyLabelSuffix)
+	if err != nil {
+		return err
+	}
+	pv.vrfKey, err = loadPKCS11VRFKey(cfg.VRFKeyFilePath)
+	if err != nil {
+		return err
+	}
+	// check the VRF key is a copy of the validator key
+	vrfPrivKey, err := pv.decryptVRFKey()
+	if err != nil {
+		return err
+	}
+	defer wipe(vrfPrivKey)
+	if !vrfPrivKey.PubKey().Equals(pv.pubKey) {
+		return errors.New("VRF key doesn't match the validator key")
+	}
+	return nil
+}
+
+func (pv *PKCS11PV) mustFindObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	object, ok, err := pv.findObject(class, label)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find PKCS#11 object %q: %w", label, err)
+	}
+	if !ok {
+		return 0, fmt.Errorf("PKCS#11 object %q not found", label)
+	}
+	return object, nil
+}
+
+func (pv *PKCS11PV) genKeys(cfg PKCS11Config) error {
+	if _, ok, err := pv.findObject(pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel); err != nil || ok {
+		if err == nil {
+			err = fmt.Errorf("PKCS#11 key %q already exists", cfg.KeyLabel)
+		}
+		return err
+	}
+	if tmos.FileExists(cfg.VRFKeyFilePath) {
+		return fmt.Errorf("VRF key file %v already exists", cfg.VRFKeyFilePath)
+	}
+	onToken, err := pv.supportsMechanism(ckmECEdwardsKeyPairGen)
+	if err != nil {
+		return err
+	}
+
+	pv.vrfKeyWrapper, err = pv.ctx.GenerateKey(pv.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+			pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, 32),
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+			pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+			pkcs11.NewAttribute(pkcs11.CKA_WRAP, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel+vrfKeyLabelSuffix),
+		})
+	if err != nil {
+		return fmt.Errorf("failed to generate VRF key wrapping key: %w", err)
+	}
+
+	var vrfKey pkcs11VRFKey
+	if onToken {
+		vrfKey, err = pv.genTokenKeys(cfg)
+	} else {
+		vrfKey, err = pv.importKeys(cfg)
+	}
+	if err != nil {
+		return err
+	}
+	return vrfKey.save(cfg.VRFKeyFilePath)
+}
+
+// genTokenKeys generates the key pair in the token and returns the private
+// key wrapped by the VRF key wrapping key, the only way it can leave the token.
+func (pv *PKCS11PV) genTokenKeys(cfg PKCS11Config) (pkcs11VRFKey, error) {
+	pubKey, privKey, err := pv.ctx.GenerateKeyPair(pv.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(ckmECEdwardsKeyPairGen, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, ed25519ECParams),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		})
+	if err != nil {
+		return pkcs11VRFKey{}, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	attrs, err := pv.ctx.GetAttributeValue(pv.session, pubKey,
+		[]*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		return pkcs11VRFKey{}, fmt.Errorf("failed to get public key: %w", err)
+	}
+	edPubKey, err := parseEd25519ECPoint(attrs[0].Value)
+	if err != nil {
+		return pkcs11VRFKey{}, err
+	}
+
+	params := pkcs11.NewGCMParams(crypto.CRandBytes(gcmIVSize), edPubKey, gcmTagBits)
+	defer params.Free()
+	ciphertext, err := pv.ctx.WrapKey(pv.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, pv.vrfKeyWrapper, privKey)
+	if err != nil {
+		return pkcs11VRFKey{}, fmt.Errorf("failed to wrap VRF key: %w", err)
+	}
+	return pkcs11VRFKey{IV: params.IV(), Ciphertext: ciphertext}, nil
+}
+
+// importKeys generates the key pair in the process, for tokens which can't
+// generate ed25519 keys, imports it into the token and returns its seed
+// encrypted by the VRF key wrapping key.
+func (pv *PKCS11PV) importKeys(cfg PKCS11Config) (pkcs11VRFKey, error) {
+	privKey := ed25519.GenPrivKey()
+	defer wipe(privKey)
+	seed := privKey[:32]
+	pubKey := privKey.PubKey().Bytes()
+
+	_, err := pv.ctx.CreateObject(pv.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, ckkECEdwards),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, ed25519ECParams),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, seed),
+	})
+	if err != nil {
+		return pkcs11VRFKey{}, fmt.Errorf("failed to import private key: %w", err)
+	}
+	_, err = pv.ctx.CreateObject(pv.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, ckkECEdwards),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, ed25519ECParams),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, append([]byte{0x04, byte(len(pubKey))}, pubKey...)),
+	})
+	if err != nil {
+		return pkcs11VRFKey{}, fmt.Errorf("failed to import public key: %w", err)
+	}
+
+	params := pkcs11.NewGCMParams(crypto.CRandBytes(gcmIVSize), pubKey, gcmTagBits)
+	defer params.Free()
+	err = pv.ctx.EncryptInit(pv.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, pv.vrfKeyWrapper)
+	if err != nil {
+		return pkcs11VRFKey{}, fmt.Errorf("failed to encrypt VRF key: %w", err)
+	}
+	ciphertext, err := pv.ctx.Encrypt(pv.session, seed)
+	if err != nil {
+		return pkcs11VRFKey{}, fmt.Errorf("failed to encrypt VRF key: %w", err)
+	}
+	return pkcs11VRFKey{IV: params.IV(), Ciphertext: ciphertext}, nil
+}
+
+// decryptVRFKey decrypts the VRF key, which must be wiped after use.
+func (pv *PKCS11PV) decryptVRFKey() (ed25519.PrivKey, error) {
+	params := pkcs11.NewGCMParams(pv.vrfKey.IV, pv.pubKey, gcmTagBits)
+	defer params.Free()
+	err := pv.ctx.DecryptInit(pv.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, pv.vrfKeyWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt VRF key: %w", err)
+	}
+	plaintext, err := pv.ctx.Decrypt(pv.session, pv.vrfKey.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt VRF key: %w", err)
+	}
+	defer wipe(plaintext)
+	if len(plaintext) == stded25519.SeedSize {
+		// imported key
+		return ed25519.PrivKey(stded25519.NewKeyFromSeed(plaintext)), nil
+	}
+	// key generated in the token, wrapped as a PKCS#8 PrivateKeyInfo
+	key, err := x509.ParsePKCS8PrivateKey(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VRF key: %w", err)
+	}
+	privKey, ok := key.(stded25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid VRF key type %T", key)
+	}
+	return ed25519.PrivKey(privKey), nil
+}
+
+// GetPubKey returns the public key of the validator.
+// Implements PrivValidator.
+func (pv *PKCS11PV) GetPubKey() (crypto.PubKey, error) {
+	return pv.pubKey, nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *PKCS11PV) SignVote(chainID string, vote *tmproto.Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	if err := signVote(&pv.lastSignState, chainID, vote, pv.sign); err != nil {
+		return fmt.Errorf("error signing vote: %v", err)
+	}
+	return nil
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (pv *PKCS11PV) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	if err := signProposal(&pv.lastSignState, chainID, proposal, pv.sign); err != nil {
+		return fmt.Errorf("error signing proposal: %v", err)
+	}
+	return nil
+}
+
+// GenerateVRFProof generates a proof for specified message with the VRF key,
+// i.e. the signing key, decrypted into the process and wiped right after.
+// Implements PrivValidator.
+func (pv *PKCS11PV) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	privKey, err := pv.decryptVRFKey()
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(privKey)
+	return privKey.VRFProve(message)
+}
+
+func (pv *PKCS11PV) sign(signBytes []byte) ([]byte, error) {
+	err := pv.ctx.SignInit(pv.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(ckmEdDSA, nil)}, pv.privKey)
+	if err != nil {
+		return nil, err
+	}
+	return pv.ctx.Sign(pv.session, signBytes)
+}
+
+// Close logs out of the token and unloads the PKCS#11 module.
+func (pv *PKCS11PV) Close() error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	pv.ctx.Logout(pv.session)       //nolint:errcheck
+	pv.ctx.CloseSession(pv.session) //nolint:errcheck
+	return pv.finalize()
+}
+
+func (pv *PKCS11PV) finalize() error {
+	err := pv.ctx.Finalize()
+	pv.ctx.Destroy()
+	return err
+}
+
+// String returns a string representation of the PKCS11PV.
+func (pv *PKCS11PV) String() string {
+	return fmt.Sprintf("PKCS11PV{%v LH:%v, LR:%v, LS:%v}", pv.pubKey.Address(),
+		pv.lastSignState.Height, pv.lastSignState.Round, pv.lastSignState.Step)
+}
+
+// parseEd25519ECPoint parses an ed25519 public key from a CKA_EC_POINT,
+// which is DER encoded as an octet string, or raw by some modules.
+func parseEd25519ECPoint(point []byte) (ed25519.PubKey, error) {
+	if len(point) == ed25519.PubKeySize+2 && point[0] == 0x04 && point[1] == ed25519.PubKeySize {
+		point = point[2:]
+	}
+	if len(point) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key %X", point)
+	}
+	return ed25519.PubKey(bytes.Clone(point)), nil
+}
+
+func wipe(bz []byte) {
+	for i := range bz {
+		bz[i] = 0
+	}
+}
@@ -0,0 +1,84 @@
+//go:build pkcs11
+
+package privval
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/types"
+)
+
+// newTestPKCS11Config returns the config of a token to test with, e.g. a
+// SoftHSM token initialized with:
+//
+//	softhsm2-util --init-token --free --label ostracon --pin 1234 --so-pin 1234
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so PKCS11_TOKEN=ostracon PKCS11_PIN=1234 \
+//		go test -tags pkcs11 ./privval
+func newTestPKCS11Config(t *testing.T) PKCS11Config {
+	modulePath := os.Getenv("PKCS11_MODULE")
+	if modulePath == "" {
+		t.Skip("PKCS11_MODULE not set")
+	}
+	return PKCS11Config{
+		ModulePath:     modulePath,
+		TokenLabel:     os.Getenv("PKCS11_TOKEN"),
+		PIN:            os.Getenv("PKCS11_PIN"),
+		KeyLabel:       fmt.Sprintf("ostracon-test-%d", time.Now().UnixNano()),
+		VRFKeyFilePath: filepath.Join(t.TempDir(), "priv_validator_vrf_key.json"),
+	}
+}
+
+func TestPKCS11PV(t *testing.T) {
+	cfg := newTestPKCS11Config(t)
+	stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+
+	pv, err := GenPKCS11PV(cfg, stateFile)
+	require.NoError(t, err)
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	_, err = GenPKCS11PV(cfg, stateFile)
+	assert.Error(t, err, "the key already exists")
+
+	chainID := "mychainid"
+	vote := newVote(pubKey.Address(), 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	require.NoError(t, pv.SignVote(chainID, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+
+	proof, err := pv.GenerateVRFProof([]byte("seed"))
+	require.NoError(t, err)
+	_, err = pubKey.VRFVerify(proof, []byte("seed"))
+	assert.NoError(t, err)
+	require.NoError(t, pv.Close())
+
+	// the key and the last sign state are loaded again
+	pv, err = LoadPKCS11PV(cfg, stateFile)
+	require.NoError(t, err)
+	defer pv.Close()
+	loadedPubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, loadedPubKey)
+	conflicting := newVote(pubKey.Address(), 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	assert.Error(t, pv.SignVote(chainID, conflicting))
+}
+
+func TestParseEd25519ECPoint(t *testing.T) {
+	raw := make([]byte, 32)
+	raw[0] = 1
+	pubKey, err := parseEd25519ECPoint(append([]byte{0x04, 32}, raw...))
+	require.NoError(t, err)
+	assert.EqualValues(t, raw, pubKey)
+	pubKey, err = parseEd25519ECPoint(raw)
+	require.NoError(t, err)
+	assert.EqualValues(t, raw, pubKey)
+	_, err = parseEd25519ECPoint(raw[1:])
+	assert.Error(t, err)
+}
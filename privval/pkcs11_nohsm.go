@@ -0,0 +1,57 @@
+//go:build !pkcs11
+
+package privval
+
+import (
+	"errors"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/types"
+)
+
+// ErrPKCS11NotSupported is returned when using a PKCS#11 token with a build
+// without the pkcs11 build tag.
+var ErrPKCS11NotSupported = errors.New("PKCS#11 is not supported by this build, rebuild with the pkcs11 build tag")
+
+// PKCS11PV implements PrivValidator with a key held in a PKCS#11 token. It
+// requires a build with the pkcs11 build tag.
+type PKCS11PV struct{}
+
+var _ types.PrivValidator = (*PKCS11PV)(nil)
+
+// LoadPKCS11PV returns ErrPKCS11NotSupported.
+func LoadPKCS11PV(PKCS11Config, string) (*PKCS11PV, error) {
+	return nil, ErrPKCS11NotSupported
+}
+
+// GenPKCS11PV returns ErrPKCS11NotSupported.
+func GenPKCS11PV(PKCS11Config, string) (*PKCS11PV, error) {
+	return nil, ErrPKCS11NotSupported
+}
+
+// GetPubKey implements PrivValidator.
+func (pv *PKCS11PV) GetPubKey() (crypto.PubKey, error) {
+	return nil, ErrPKCS11NotSupported
+}
+
+// SignVote implements PrivValidator.
+func (pv *PKCS11PV) SignVote(string, *tmproto.Vote) error {
+	return ErrPKCS11NotSupported
+}
+
+// SignProposal implements PrivValidator.
+func (pv *PKCS11PV) SignProposal(string, *tmproto.Proposal) error {
+	return ErrPKCS11NotSupported
+}
+
+// GenerateVRFProof implements PrivValidator.
+func (pv *PKCS11PV) GenerateVRFProof([]byte) (crypto.Proof, error) {
+	return nil, ErrPKCS11NotSupported
+}
+
+// Close does nothing.
+func (pv *PKCS11PV) Close() error {
+	return nil
+}
@@ -0,0 +1,151 @@
+package privval
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/libs/log"
+	tmnet "github.com/Finschia/ostracon/libs/net"
+	p2pconn "github.com/Finschia/ostracon/p2p/conn"
+	rpcclient "github.com/Finschia/ostracon/rpc/jsonrpc/client"
+)
+
+// secretConnHandshakeTimeout is the time to establish a secret connection
+// with a cosigner, a sign state replica or their clients.
+const secretConnHandshakeTimeout = time.Second * defaultTimeoutReadWriteSeconds
+
+// secretConnListener accepts the secret connections of the allowed nodes, to
+// serve JSON-RPC to them. Closing it closes the accepted connections too, so
+// that clients don't keep being served over kept-alive connections.
+type secretConnListener struct {
+	net.Listener
+
+	connKey      crypto.PrivKey
+	allowedNodes []string
+	logger       log.Logger
+
+	mtx   sync.Mutex
+	conns map[*secretConnListenerConn]struct{}
+}
+
+var _ net.Listener = (*secretConnListener)(nil)
+
+func newSecretConnListener(ln net.Listener, connKey crypto.PrivKey, allowedNodes []string,
+	logger log.Logger) *secretConnListener {
+	return &secretConnListener{
+		Listener:     ln,
+		connKey:      connKey,
+		allowedNodes: allowedNodes,
+		logger:       logger,
+		conns:        make(map[*secretConnListenerConn]struct{}),
+	}
+}
+
+// Accept implements net.Listener. Connections which fail the handshake or
+// come from nodes which aren't allowed are closed, and the next one is
+// accepted.
+func (ln *secretConnListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := ln.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		sc, err := makeSecretConn(conn, ln.connKey, ln.allowedNodes...)
+		if err != nil {
+			ln.logger.Info("Rejected connection", "remote", conn.RemoteAddr(), "err", err)
+			_ = conn.Close()
+			continue
+		}
+		tc := &secretConnListenerConn{Conn: sc, ln: ln}
+		ln.mtx.Lock()
+		ln.conns[tc] = struct{}{}
+		ln.mtx.Unlock()
+		return tc, nil
+	}
+}
+
+// Close implements net.Listener.
+func (ln *secretConnListener) Close() error {
+	err := ln.Listener.Close()
+	ln.mtx.Lock()
+	conns := ln.conns
+	ln.conns = make(map[*secretConnListenerConn]struct{})
+	ln.mtx.Unlock()
+	for conn := range conns {
+		_ = conn.Conn.Close()
+	}
+	return err
+}
+
+// secretConnListenerConn is a connection accepted by a secretConnListener.
+type secretConnListenerConn struct {
+	net.Conn
+	ln *secretConnListener
+}
+
+// Close implements net.Conn.
+func (c *secretConnListenerConn) Close() error {
+	c.ln.mtx.Lock()
+	delete(c.ln.conns, c)
+	c.ln.mtx.Unlock()
+	return c.Conn.Close()
+}
+
+// newSecretConnRPCClient returns a JSON-RPC client of the node nodeID serving
+// at remoteAddr, authenticating with connKey.
+func newSecretConnRPCClient(remoteAddr, nodeID string, connKey crypto.PrivKey) (*rpcclient.Client, error) {
+	protocol, address := tmnet.ProtocolAndAddress(remoteAddr)
+	dialer := &net.Dialer{Timeout: secretConnHandshakeTimeout}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			// Set to true to prevent GZIP-bomb DoS attacks
+			DisableCompression: true,
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				conn, err := dialer.DialContext(ctx, protocol, address)
+				if err != nil {
+					return nil, err
+				}
+				sc, err := makeSecretConn(conn, connKey, nodeID)
+				if err != nil {
+					_ = conn.Close()
+					return nil, err
+				}
+				return sc, nil
+			},
+		},
+	}
+	return rpcclient.NewWithHTTPClient(remoteAddr, httpClient)
+}
+
+// makeSecretConn establishes a secret connection over conn, authenticating
+// with connKey, and checks that the remote node is one of allowedNodes.
+func makeSecretConn(conn net.Conn, connKey crypto.PrivKey, allowedNodes ...string) (net.Conn, error) {
+	if err := conn.SetDeadline(time.Now().Add(secretConnHandshakeTimeout)); err != nil {
+		return nil, err
+	}
+	sc, err := p2pconn.MakeSecretConnection(conn, connKey)
+	if err != nil {
+		return nil, err
+	}
+	nodeID := hex.EncodeToString(sc.RemotePubKey().Address())
+	allowed := false
+	for _, id := range allowedNodes {
+		if id == nodeID {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("node %v is not allowed", nodeID)
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
@@ -0,0 +1,197 @@
+package privval
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/types"
+)
+
+// SignStateLock coordinates the last sign state of the signers of a validator
+// key, so that two signers never sign conflicting messages.
+//
+// Implementations don't need consensus between the signers. They may keep the
+// state in a locked file, see FileSignStateLock, or in replicas run by the
+// signers, see QuorumSignStateLock.
+type SignStateLock interface {
+	// Lock blocks until the lock is acquired and returns the last sign state
+	// shared by the signers.
+	Lock() (FilePVLastSignState, error)
+	// Unlock stores lss as the last sign state, unless it's nil, and releases
+	// the lock. The lock is released even if storing lss fails.
+	Unlock(lss *FilePVLastSignState) error
+}
+
+//-------------------------------------------------------------------------------
+
+// FileSignStateLock is a SignStateLock keeping the last sign state in a file,
+// locked with a file lock. The signers must run on the same host, or share a
+// file system supporting file locks.
+type FileSignStateLock struct {
+	mtx tmsync.Mutex
+
+	filePath string
+	lockFile *os.File
+}
+
+var _ SignStateLock = (*FileSignStateLock)(nil)
+
+// NewFileSignStateLock returns a FileSignStateLock keeping the last sign state
+// in filePath, locked with filePath.lock.
+func NewFileSignStateLock(filePath string) *FileSignStateLock {
+	return &FileSignStateLock{filePath: filePath}
+}
+
+// Lock implements SignStateLock.
+func (l *FileSignStateLock) Lock() (FilePVLastSignState, error) {
+	// the file lock is per process, so the mutex locks out the other
+	// goroutines
+	l.mtx.Lock()
+
+	lockFile, err := os.OpenFile(l.filePath+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		l.mtx.Unlock()
+		return FilePVLastSignState{}, err
+	}
+	if err := lockFileExclusive(lockFile); err != nil {
+		lockFile.Close()
+		l.mtx.Unlock()
+		return FilePVLastSignState{}, fmt.Errorf("error locking %v: %w", lockFile.Name(), err)
+	}
+	l.lockFile = lockFile
+
+	lss, err := loadOrGenLastSignState(l.filePath)
+	if err != nil {
+		l.release()
+		return FilePVLastSignState{}, err
+	}
+	return lss, nil
+}
+
+// Unlock implements SignStateLock.
+func (l *FileSignStateLock) Unlock(lss *FilePVLastSignState) (err error) {
+	defer l.release()
+
+	if lss == nil {
+		return nil
+	}
+	// Save panics on errors, which must not leave the lock held
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("error saving last sign state: %v", r)
+		}
+	}()
+	shared := *lss
+	shared.filePath = l.filePath
+	shared.Save()
+	return nil
+}
+
+// release closes the lock file, which releases the file lock, and unlocks the
+// mutex.
+func (l *FileSignStateLock) release() {
+	l.lockFile.Close()
+	l.lockFile = nil
+	l.mtx.Unlock()
+}
+
+//-------------------------------------------------------------------------------
+
+// SharedStateFilePV is a FilePV sharing its last sign state with the other
+// signers of the key through a SignStateLock, so that several signers can be
+// run for a validator, and the node can fail over between them.
+//
+// Each signer also persists the last sign state locally, like FilePV.
+type SharedStateFilePV struct {
+	*FilePV
+
+	mtx  tmsync.Mutex
+	lock SignStateLock
+}
+
+var _ types.PrivValidator = (*SharedStateFilePV)(nil)
+
+// NewSharedStateFilePV returns a SharedStateFilePV signing with pv, and
+// coordinating with the other signers through lock.
+func NewSharedStateFilePV(pv *FilePV, lock SignStateLock) *SharedStateFilePV {
+	return &SharedStateFilePV{FilePV: pv, lock: lock}
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID, after checking it against the shared last sign state.
+// Implements PrivValidator.
+func (pv *SharedStateFilePV) SignVote(chainID string, vote *tmproto.Vote) error {
+	err := pv.withSharedState(func() error {
+		return pv.FilePV.SignVote(chainID, vote)
+	})
+	if err != nil {
+		vote.Signature = nil
+	}
+	return err
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID, after checking it against the shared last sign state.
+// Implements PrivValidator.
+func (pv *SharedStateFilePV) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	err := pv.withSharedState(func() error {
+		return pv.FilePV.SignProposal(chainID, proposal)
+	})
+	if err != nil {
+		proposal.Signature = nil
+	}
+	return err
+}
+
+// withSharedState runs sign holding the lock, with the local last sign state
+// caught up with the shared one, and shares the new last sign state if sign
+// succeeds. The signature must not be used if sharing the state fails.
+func (pv *SharedStateFilePV) withSharedState(sign func() error) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	shared, err := pv.lock.Lock()
+	if err != nil {
+		return fmt.Errorf("error locking last sign state: %w", err)
+	}
+	pv.catchUp(shared)
+
+	if err := sign(); err != nil {
+		if unlockErr := pv.lock.Unlock(nil); unlockErr != nil {
+			return fmt.Errorf("%v; error unlocking last sign state: %w", err, unlockErr)
+		}
+		return err
+	}
+
+	lss := pv.LastSignState
+	if err := pv.lock.Unlock(&lss); err != nil {
+		return fmt.Errorf("error sharing last sign state: %w", err)
+	}
+	return nil
+}
+
+// catchUp replaces the local last sign state with the shared one, unless the
+// shared state is behind. A local state ahead of the shared one was never
+// shared, so its signature was never used.
+func (pv *SharedStateFilePV) catchUp(shared FilePVLastSignState) {
+	local := &pv.LastSignState
+	if shared.Height < local.Height ||
+		(shared.Height == local.Height && shared.Round < local.Round) ||
+		(shared.Height == local.Height && shared.Round == local.Round && shared.Step < local.Step) {
+		return
+	}
+	if shared.Height == local.Height && shared.Round == local.Round && shared.Step == local.Step &&
+		bytes.Equal(shared.SignBytes, local.SignBytes) {
+		return
+	}
+	local.Height = shared.Height
+	local.Round = shared.Round
+	local.Step = shared.Step
+	local.Signature = shared.Signature
+	local.SignBytes = shared.SignBytes
+	local.Save()
+}
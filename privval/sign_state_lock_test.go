@@ -0,0 +1,155 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/types"
+)
+
+func newSharedStateFilePVs(t *testing.T, n int) []*SharedStateFilePV {
+	dir := t.TempDir()
+	key := GenFilePV("", "").Key
+	signStateFile := filepath.Join(dir, "sign_state.json")
+	pvs := make([]*SharedStateFilePV, n)
+	for i := range pvs {
+		pv := GenFilePV("", filepath.Join(dir, fmt.Sprintf("priv_validator_state_%d.json", i)))
+		pv.Key = key
+		pvs[i] = NewSharedStateFilePV(pv, NewFileSignStateLock(signStateFile))
+	}
+	return pvs
+}
+
+func TestSharedStateFilePV(t *testing.T) {
+	pvs := newSharedStateFilePVs(t, 2)
+	chainID := "mychainid"
+	addr := pvs[0].GetAddress()
+
+	vote := newVote(addr, 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	require.NoError(t, pvs[0].SignVote(chainID, vote))
+	sig := vote.Signature
+
+	// the other signer re-signs the same vote with the same signature
+	vote.Signature = nil
+	require.NoError(t, pvs[1].SignVote(chainID, vote))
+	assert.Equal(t, sig, vote.Signature)
+
+	// but refuses to sign a conflicting vote or regress
+	conflicting := newVote(addr, 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	assert.Error(t, pvs[1].SignVote(chainID, conflicting))
+	assert.Nil(t, conflicting.Signature)
+	proposal := newProposal(9, 0, testBlockID()).ToProto()
+	assert.Error(t, pvs[1].SignProposal(chainID, proposal))
+
+	proposal = newProposal(11, 0, testBlockID()).ToProto()
+	require.NoError(t, pvs[1].SignProposal(chainID, proposal))
+	assert.True(t, pvs[1].Key.PubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	// the first signer catches up with the shared state
+	conflictingProposal := newProposal(11, 0, testBlockID()).ToProto()
+	assert.Error(t, pvs[0].SignProposal(chainID, conflictingProposal))
+	assert.EqualValues(t, 11, pvs[0].LastSignState.Height)
+
+	// the shared state survives restarts
+	restarted := NewSharedStateFilePV(GenFilePV("", filepath.Join(t.TempDir(), "priv_validator_state.json")),
+		pvs[0].lock)
+	restarted.Key = pvs[0].Key
+	assert.Error(t, restarted.SignProposal(chainID, conflictingProposal))
+}
+
+// unavailableReplica is a SignStateReplica which may be unavailable.
+type unavailableReplica struct {
+	SignStateReplica
+	unavailable *atomic.Bool
+}
+
+func (r unavailableReplica) LastSignState(ctx context.Context) (FilePVLastSignState, error) {
+	if r.unavailable.Load() {
+		return FilePVLastSignState{}, errors.New("unavailable")
+	}
+	return r.SignStateReplica.LastSignState(ctx)
+}
+
+func (r unavailableReplica) Update(ctx context.Context, lss FilePVLastSignState) error {
+	if r.unavailable.Load() {
+		return errors.New("unavailable")
+	}
+	return r.SignStateReplica.Update(ctx, lss)
+}
+
+func TestQuorumSignStateLock(t *testing.T) {
+	dir := t.TempDir()
+	replicas := make([]*LocalSignStateReplica, 3)
+	for i := range replicas {
+		var err error
+		replicas[i], err = LoadLocalSignStateReplica(filepath.Join(dir, fmt.Sprintf("sign_state_%d.json", i)))
+		require.NoError(t, err)
+	}
+
+	// the third replica is served to the signers remotely
+	serverKey, clientKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	server := NewSignStateServer("tcp://127.0.0.1:0", replicas[2], serverKey,
+		[]string{testNodeID(clientKey)}, log.TestingLogger())
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if server.IsRunning() {
+			if err := server.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	remote, err := NewRemoteSignStateReplica(testNodeID(serverKey), "tcp://"+server.Addr().String(), clientKey)
+	require.NoError(t, err)
+
+	unavailable := new(atomic.Bool)
+	key := GenFilePV("", "").Key
+	pvs := make([]*SharedStateFilePV, 2)
+	for i := range pvs {
+		pv := GenFilePV("", filepath.Join(dir, fmt.Sprintf("priv_validator_state_%d.json", i)))
+		pv.Key = key
+		lock, err := NewQuorumSignStateLock(
+			[]SignStateReplica{replicas[0], unavailableReplica{replicas[1], unavailable}, remote},
+			DefaultSignStateTimeout)
+		require.NoError(t, err)
+		pvs[i] = NewSharedStateFilePV(pv, lock)
+	}
+	chainID := "mychainid"
+
+	vote := newVote(key.Address, 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	require.NoError(t, pvs[0].SignVote(chainID, vote))
+	lss, err := remote.LastSignState(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, lss.Height)
+
+	// the other signer refuses to sign a conflicting vote
+	conflicting := newVote(key.Address, 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	assert.Error(t, pvs[1].SignVote(chainID, conflicting))
+
+	// even if it didn't catch up, as the replicas refuse the conflicting state
+	pvs[1].LastSignState.Height = 0
+	require.NoError(t, pvs[1].FilePV.SignVote(chainID, conflicting))
+	_, err = pvs[1].lock.Lock()
+	require.NoError(t, err)
+	assert.Error(t, pvs[1].lock.Unlock(&pvs[1].LastSignState))
+
+	// a minority of the replicas can fail
+	require.NoError(t, server.Stop())
+	proposal := newProposal(11, 0, testBlockID()).ToProto()
+	require.NoError(t, pvs[1].SignProposal(chainID, proposal))
+
+	// but not a majority
+	unavailable.Store(true)
+	vote = newVote(key.Address, 0, 12, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	assert.Error(t, pvs[0].SignVote(chainID, vote))
+	assert.Nil(t, vote.Signature)
+}
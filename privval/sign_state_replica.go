@@ -0,0 +1,369 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/libs/service"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	rpcclient "github.com/Finschia/ostracon/rpc/jsonrpc/client"
+	rpcserver "github.com/Finschia/ostracon/rpc/jsonrpc/server"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+)
+
+const (
+	signStateGetMethod    = "sign_state_get"
+	signStateUpdateMethod = "sign_state_update"
+
+	// DefaultSignStateTimeout is the default time to wait for the replicas of
+	// the last sign state.
+	DefaultSignStateTimeout = time.Second
+)
+
+// SignStateReplica stores a copy of the last sign state shared by the signers
+// of a validator key, see QuorumSignStateLock.
+type SignStateReplica interface {
+	// LastSignState returns the last sign state stored by the replica.
+	LastSignState(ctx context.Context) (FilePVLastSignState, error)
+	// Update stores lss if it's ahead of the stored state. It fails if lss is
+	// behind the stored state, or at the same height, round and step with
+	// different sign bytes.
+	Update(ctx context.Context, lss FilePVLastSignState) error
+}
+
+// compareHRS compares the height, round and step of a and b, returning -1,
+// 0 or 1 if a is behind, at the same step or ahead of b.
+func compareHRS(a, b FilePVLastSignState) int {
+	switch {
+	case a.Height != b.Height:
+		if a.Height < b.Height {
+			return -1
+		}
+		return 1
+	case a.Round != b.Round:
+		if a.Round < b.Round {
+			return -1
+		}
+		return 1
+	case a.Step != b.Step:
+		if a.Step < b.Step {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+//-------------------------------------------------------------------------------
+
+// QuorumSignStateLock is a SignStateLock keeping the last sign state in
+// replicas, e.g. one run by each signer, so that the signers can run on
+// different hosts and tolerate the failure of a minority of the replicas.
+//
+// A state is stored once a quorum (a majority) of the replicas stored it.
+// Replicas only accept a state ahead of theirs, and two quorums always share a
+// replica, so two signers can't both store conflicting states for the same
+// height, round and step. Since a signature is only used once its state is
+// stored (see SharedStateFilePV), the signers never sign conflicting messages,
+// without having to lock each other out.
+type QuorumSignStateLock struct {
+	mtx tmsync.Mutex
+
+	replicas []SignStateReplica
+	timeout  time.Duration
+}
+
+var _ SignStateLock = (*QuorumSignStateLock)(nil)
+
+// NewQuorumSignStateLock returns a QuorumSignStateLock keeping the last sign
+// state in replicas, waiting timeout for them.
+func NewQuorumSignStateLock(replicas []SignStateReplica, timeout time.Duration) (*QuorumSignStateLock, error) {
+	if len(replicas) == 0 {
+		return nil, errors.New("no replicas")
+	}
+	return &QuorumSignStateLock{replicas: replicas, timeout: timeout}, nil
+}
+
+// quorum returns the number of replicas needed to read or store the state.
+func (l *QuorumSignStateLock) quorum() int {
+	return len(l.replicas)/2 + 1
+}
+
+// Lock implements SignStateLock. It returns the latest state of a quorum of
+// replicas.
+func (l *QuorumSignStateLock) Lock() (FilePVLastSignState, error) {
+	l.mtx.Lock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	type result struct {
+		lss FilePVLastSignState
+		err error
+	}
+	resultc := make(chan result, len(l.replicas))
+	for _, replica := range l.replicas {
+		go func(replica SignStateReplica) {
+			lss, err := replica.LastSignState(ctx)
+			resultc <- result{lss, err}
+		}(replica)
+	}
+
+	var (
+		states []FilePVLastSignState
+		err    error
+	)
+	for range l.replicas {
+		res := <-resultc
+		if res.err != nil {
+			err = res.err
+			continue
+		}
+		states = append(states, res.lss)
+		if len(states) == l.quorum() {
+			return latestSignState(states), nil
+		}
+	}
+	l.mtx.Unlock()
+	return FilePVLastSignState{}, fmt.Errorf("last sign state read from %d of %d replicas: %w",
+		len(states), len(l.replicas), err)
+}
+
+// latestSignState returns the state ahead of the others. If several states
+// are at the same step, the one returned by the most replicas is preferred,
+// since the others may have been stored by less than a quorum.
+func latestSignState(states []FilePVLastSignState) FilePVLastSignState {
+	latest, count := states[0], 0
+	for _, lss := range states {
+		if cmp := compareHRS(lss, latest); cmp > 0 {
+			latest, count = lss, 0
+		} else if cmp < 0 {
+			continue
+		}
+		same := 0
+		for _, other := range states {
+			if compareHRS(other, lss) == 0 && bytes.Equal(other.SignBytes, lss.SignBytes) {
+				same++
+			}
+		}
+		if same > count {
+			latest, count = lss, same
+		}
+	}
+	return latest
+}
+
+// Unlock implements SignStateLock. It fails unless a quorum of replicas
+// stored lss.
+func (l *QuorumSignStateLock) Unlock(lss *FilePVLastSignState) error {
+	defer l.mtx.Unlock()
+
+	if lss == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	errc := make(chan error, len(l.replicas))
+	for _, replica := range l.replicas {
+		go func(replica SignStateReplica) {
+			errc <- replica.Update(ctx, *lss)
+		}(replica)
+	}
+
+	// wait for all the replicas, so that they don't fall behind needlessly
+	stored := 0
+	var err error
+	for range l.replicas {
+		if updateErr := <-errc; updateErr != nil {
+			err = updateErr
+			continue
+		}
+		stored++
+	}
+	if stored >= l.quorum() {
+		return nil
+	}
+	return fmt.Errorf("last sign state stored by %d of %d replicas: %w", stored, len(l.replicas), err)
+}
+
+//-------------------------------------------------------------------------------
+
+// LocalSignStateReplica is a SignStateReplica persisting the last sign state
+// to a file.
+type LocalSignStateReplica struct {
+	mtx tmsync.Mutex
+	lss FilePVLastSignState
+}
+
+var _ SignStateReplica = (*LocalSignStateReplica)(nil)
+
+// LoadLocalSignStateReplica returns a LocalSignStateReplica persisting the
+// last sign state to filePath, which is created if it doesn't exist.
+func LoadLocalSignStateReplica(filePath string) (*LocalSignStateReplica, error) {
+	lss, err := loadOrGenLastSignState(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalSignStateReplica{lss: lss}, nil
+}
+
+// LastSignState implements SignStateReplica.
+func (r *LocalSignStateReplica) LastSignState(context.Context) (FilePVLastSignState, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.lss, nil
+}
+
+// Update implements SignStateReplica.
+func (r *LocalSignStateReplica) Update(_ context.Context, lss FilePVLastSignState) (err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	switch compareHRS(lss, r.lss) {
+	case -1:
+		return fmt.Errorf("sign state regression: %v/%v/%v is behind %v/%v/%v",
+			lss.Height, lss.Round, lss.Step, r.lss.Height, r.lss.Round, r.lss.Step)
+	case 0:
+		if !bytes.Equal(lss.SignBytes, r.lss.SignBytes) {
+			return fmt.Errorf("conflicting sign state at %v/%v/%v", lss.Height, lss.Round, lss.Step)
+		}
+		return nil
+	}
+
+	// Save panics on errors
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("error saving last sign state: %v", r)
+		}
+	}()
+	lss.filePath = r.lss.filePath
+	lss.Save()
+	r.lss = lss
+	return nil
+}
+
+//-------------------------------------------------------------------------------
+
+// SignStateServer serves a LocalSignStateReplica over JSON-RPC to the other
+// signers of the validator key.
+//
+// Like CosignerServer, requests are served over authenticated encrypted
+// connections, and only to the signers whose node IDs are allowed.
+type SignStateServer struct {
+	service.BaseService
+
+	listenAddr   string
+	replica      *LocalSignStateReplica
+	connKey      crypto.PrivKey
+	allowedNodes []string
+	listener     net.Listener
+}
+
+// NewSignStateServer returns a SignStateServer for replica listening on
+// listenAddr. It authenticates with connKey and only accepts connections from
+// the nodes in allowedNodes.
+func NewSignStateServer(
+	listenAddr string,
+	replica *LocalSignStateReplica,
+	connKey crypto.PrivKey,
+	allowedNodes []string,
+	logger log.Logger,
+) *SignStateServer {
+	ss := &SignStateServer{
+		listenAddr:   listenAddr,
+		replica:      replica,
+		connKey:      connKey,
+		allowedNodes: allowedNodes,
+	}
+	ss.BaseService = *service.NewBaseService(logger, "SignStateServer", ss)
+	return ss
+}
+
+// OnStart implements service.Service.
+func (ss *SignStateServer) OnStart() error {
+	routes := map[string]*rpcserver.RPCFunc{
+		signStateGetMethod:    rpcserver.NewRPCFunc(ss.get, ""),
+		signStateUpdateMethod: rpcserver.NewRPCFunc(ss.update, "state"),
+	}
+	mux := http.NewServeMux()
+	rpcLogger := ss.Logger.With("module", "rpc-server")
+	rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+
+	config := rpcserver.DefaultConfig()
+	listener, err := rpcserver.Listen(ss.listenAddr, config)
+	if err != nil {
+		return err
+	}
+	ss.listener = newSecretConnListener(listener, ss.connKey, ss.allowedNodes, ss.Logger)
+
+	go func() {
+		if err := rpcserver.Serve(ss.listener, mux, rpcLogger, config); err != nil && ss.IsRunning() {
+			ss.Logger.Error("Sign state server stopped", "err", err)
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (ss *SignStateServer) OnStop() {
+	if err := ss.listener.Close(); err != nil {
+		ss.Logger.Error("Error closing listener", "err", err)
+	}
+}
+
+// Addr returns the address the server listens on.
+func (ss *SignStateServer) Addr() net.Addr {
+	return ss.listener.Addr()
+}
+
+func (ss *SignStateServer) get(ctx *rpctypes.Context) (*FilePVLastSignState, error) {
+	lss, err := ss.replica.LastSignState(ctx.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &lss, nil
+}
+
+func (ss *SignStateServer) update(ctx *rpctypes.Context, state FilePVLastSignState) (*struct{}, error) {
+	if err := ss.replica.Update(ctx.Context(), state); err != nil {
+		return nil, err
+	}
+	return &struct{}{}, nil
+}
+
+// RemoteSignStateReplica is a SignStateReplica served by a SignStateServer.
+type RemoteSignStateReplica struct {
+	client *rpcclient.Client
+}
+
+var _ SignStateReplica = (*RemoteSignStateReplica)(nil)
+
+// NewRemoteSignStateReplica returns the replica served at remoteAddr by the
+// node nodeID. The client authenticates with connKey, which must be allowed
+// by the replica.
+func NewRemoteSignStateReplica(nodeID, remoteAddr string, connKey crypto.PrivKey) (*RemoteSignStateReplica, error) {
+	client, err := newSecretConnRPCClient(remoteAddr, nodeID, connKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sign state replica address %v: %w", remoteAddr, err)
+	}
+	return &RemoteSignStateReplica{client: client}, nil
+}
+
+// LastSignState implements SignStateReplica.
+func (r *RemoteSignStateReplica) LastSignState(ctx context.Context) (FilePVLastSignState, error) {
+	var lss FilePVLastSignState
+	_, err := r.client.Call(ctx, signStateGetMethod, map[string]interface{}{}, &lss)
+	return lss, err
+}
+
+// Update implements SignStateReplica.
+func (r *RemoteSignStateReplica) Update(ctx context.Context, lss FilePVLastSignState) error {
+	_, err := r.client.Call(ctx, signStateUpdateMethod, map[string]interface{}{"state": lss}, new(struct{}))
+	return err
+}
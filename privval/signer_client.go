@@ -60,6 +60,13 @@ func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
 		return nil, fmt.Errorf("send: %w", err)
 	}
 
+	if resp := response.GetOcPubKeyResponse(); resp != nil {
+		if resp.Error != nil {
+			return nil, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+		}
+		return cryptoenc.PubKeyFromOCProto(&resp.PubKey)
+	}
+
 	resp := response.GetPubKeyResponse()
 	if resp == nil {
 		return nil, ErrUnexpectedResponse
@@ -13,6 +13,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	"github.com/Finschia/ostracon/crypto/vrf"
@@ -104,6 +105,27 @@ func TestSignerGetPubKey(t *testing.T) {
 	}
 }
 
+func TestSignerGetPubKeyBLS12381(t *testing.T) {
+	mockPV := types.NewMockPVWithParams(bls12381.GenPrivKey(), false, false)
+	for _, tc := range getSignerTestCases(t, mockPV, true) {
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		pubKey, err := tc.signerClient.GetPubKey()
+		require.NoError(t, err)
+		assert.Equal(t, mockPV.PrivKey.PubKey(), pubKey)
+	}
+}
+
 func TestSignerProposal(t *testing.T) {
 	for _, tc := range getSignerTestCases(t, nil, true) {
 		ts := time.Now()
@@ -39,7 +39,13 @@ func DefaultValidationRequestHandler(
 		}
 		pk, err := cryptoenc.PubKeyToProto(pubKey)
 		if err != nil {
-			return res, err
+			// the key types of Ostracon, like BLS12-381, are sent in Ostracon's response
+			ocpk, err := cryptoenc.PubKeyToOCProto(pubKey)
+			if err != nil {
+				return res, err
+			}
+			res = mustWrapMsg(&ocprivvalproto.PubKeyResponse{PubKey: ocpk, Error: nil})
+			break
 		}
 
 		if err != nil {
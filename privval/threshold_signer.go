@@ -0,0 +1,253 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/threshold"
+	"github.com/Finschia/ostracon/libs/log"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/types"
+)
+
+// DefaultCosignerTimeout is the default time to wait for each round of a
+// threshold signing session.
+const DefaultCosignerTimeout = 1500 * time.Millisecond
+
+// ThresholdSigner implements PrivValidator.
+// It signs votes, proposals and VRF proofs with a key split between cosigners,
+// any threshold of which are needed to sign.
+//
+// Like FilePV, it persists the last signed vote or proposal to re-sign it if
+// the node crashes before the consensus message is processed. Each cosigner
+// also keeps its own last sign state to refuse double signing.
+type ThresholdSigner struct {
+	mtx tmsync.Mutex
+
+	group         threshold.Group
+	cosigners     []Cosigner
+	lastSignState FilePVLastSignState
+	timeout       time.Duration
+
+	logger log.Logger
+}
+
+var _ types.PrivValidator = (*ThresholdSigner)(nil)
+
+// NewThresholdSigner returns a ThresholdSigner for the key of group, signing
+// with cosigners, which persists its last sign state to stateFilePath.
+func NewThresholdSigner(
+	group threshold.Group,
+	cosigners []Cosigner,
+	stateFilePath string,
+	timeout time.Duration,
+) (*ThresholdSigner, error) {
+	if err := group.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if len(cosigners) < group.Threshold {
+		return nil, fmt.Errorf("%d cosigners for a threshold of %d", len(cosigners), group.Threshold)
+	}
+	ids := make(map[int]bool, len(cosigners))
+	for _, cosigner := range cosigners {
+		if cosigner.ID() < 1 || cosigner.ID() > len(group.PubShares) || ids[cosigner.ID()] {
+			return nil, fmt.Errorf("invalid or duplicate cosigner ID %d", cosigner.ID())
+		}
+		ids[cosigner.ID()] = true
+	}
+	lss, err := loadOrGenLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &ThresholdSigner{
+		group:         group,
+		cosigners:     cosigners,
+		lastSignState: lss,
+		timeout:       timeout,
+		logger:        log.NewNopLogger(),
+	}, nil
+}
+
+// SetLogger sets the logger.
+func (ts *ThresholdSigner) SetLogger(l log.Logger) {
+	ts.logger = l
+}
+
+// GetPubKey implements PrivValidator.
+func (ts *ThresholdSigner) GetPubKey() (crypto.PubKey, error) {
+	return ts.group.PubKey, nil
+}
+
+// SignVote implements PrivValidator.
+func (ts *ThresholdSigner) SignVote(chainID string, vote *tmproto.Vote) error {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	err := signVote(&ts.lastSignState, chainID, vote, func(signBytes []byte) ([]byte, error) {
+		voteBz, err := proto.Marshal(vote)
+		if err != nil {
+			return nil, err
+		}
+		return ts.sign(signBytes, CosignerSignRequest{ChainID: chainID, Vote: voteBz})
+	})
+	if err != nil {
+		return fmt.Errorf("error signing vote: %v", err)
+	}
+	return nil
+}
+
+// SignProposal implements PrivValidator.
+func (ts *ThresholdSigner) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	err := signProposal(&ts.lastSignState, chainID, proposal, func(signBytes []byte) ([]byte, error) {
+		proposalBz, err := proto.Marshal(proposal)
+		if err != nil {
+			return nil, err
+		}
+		return ts.sign(signBytes, CosignerSignRequest{ChainID: chainID, Proposal: proposalBz})
+	})
+	if err != nil {
+		return fmt.Errorf("error signing proposal: %v", err)
+	}
+	return nil
+}
+
+// GenerateVRFProof implements PrivValidator.
+func (ts *ThresholdSigner) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	return ts.sign(message, CosignerSignRequest{VRFMessage: message})
+}
+
+// sign runs signing sessions for message until threshold cosigners return
+// valid shares. Cosigners which fail are left out of the next sessions.
+func (ts *ThresholdSigner) sign(message []byte, req CosignerSignRequest) ([]byte, error) {
+	available := ts.cosigners
+	for len(available) >= ts.group.Threshold {
+		sig, failed, err := ts.signSession(message, req, available)
+		if err == nil {
+			return sig, nil
+		}
+		if len(failed) == 0 {
+			return nil, err
+		}
+		ts.logger.Error("Threshold signing session failed", "failed", failed, "err", err)
+
+		remaining := make([]Cosigner, 0, len(available))
+		for _, cosigner := range available {
+			if !failed[cosigner.ID()] {
+				remaining = append(remaining, cosigner)
+			}
+		}
+		available = remaining
+	}
+	return nil, fmt.Errorf("less than %d cosigners available: %w", ts.group.Threshold, threshold.ErrNotEnoughShares)
+}
+
+// signSession runs a signing session with the first threshold cosigners to
+// commit. It returns the cosigners which failed if the session failed.
+func (ts *ThresholdSigner) signSession(
+	message []byte,
+	req CosignerSignRequest,
+	cosigners []Cosigner,
+) (sig []byte, failed map[int]bool, err error) {
+	type result struct {
+		cosigner Cosigner
+		commit   *CosignerCommitResponse
+		sign     *CosignerSignResponse
+		err      error
+	}
+	failed = make(map[int]bool)
+
+	// Round 1: commit to nonces
+	commitCtx, cancelCommit := context.WithTimeout(context.Background(), ts.timeout)
+	defer cancelCommit()
+	commitc := make(chan result, len(cosigners))
+	for _, cosigner := range cosigners {
+		go func(cosigner Cosigner) {
+			resp, err := cosigner.Commit(commitCtx, CosignerCommitRequest{VRFMessage: req.VRFMessage})
+			commitc <- result{cosigner: cosigner, commit: resp, err: err}
+		}(cosigner)
+	}
+	var signers []result
+	for range cosigners {
+		res := <-commitc
+		if res.err != nil {
+			failed[res.cosigner.ID()] = true
+			continue
+		}
+		signers = append(signers, res)
+		if len(signers) == ts.group.Threshold {
+			break
+		}
+	}
+	if len(signers) < ts.group.Threshold {
+		return nil, failed, threshold.ErrNotEnoughShares
+	}
+	commitments := make([]threshold.Commitment, len(signers))
+	for i, signer := range signers {
+		commitments[i] = signer.commit.Commitment
+	}
+
+	// Round 2: sign shares
+	signCtx, cancelSign := context.WithTimeout(context.Background(), ts.timeout)
+	defer cancelSign()
+	signc := make(chan result, len(signers))
+	for _, signer := range signers {
+		go func(signer result) {
+			signReq := req
+			signReq.SessionID = signer.commit.SessionID
+			signReq.Commitments = commitments
+			resp, err := signer.cosigner.Sign(signCtx, signReq)
+			signer.sign, signer.err = resp, err
+			signc <- signer
+		}(signer)
+	}
+	shares := make(map[int][]byte, len(signers))
+	for range signers {
+		res := <-signc
+		if res.err != nil {
+			failed[res.cosigner.ID()] = true
+			err = fmt.Errorf("cosigner %d: %w", res.cosigner.ID(), res.err)
+			continue
+		}
+		shares[res.cosigner.ID()] = res.sign.Share
+	}
+	if err != nil {
+		return nil, failed, err
+	}
+
+	orderedShares := make([][]byte, len(commitments))
+	for i, commitment := range commitments {
+		orderedShares[i] = shares[commitment.ID]
+	}
+	if req.VRFMessage != nil {
+		sig, err = ts.group.AggregateVRFProof(message, commitments, orderedShares)
+	} else {
+		sig, err = ts.group.AggregateSignature(message, commitments, orderedShares)
+	}
+	var errInvalidShare threshold.ErrInvalidShare
+	if errors.As(err, &errInvalidShare) {
+		failed[errInvalidShare.ID] = true
+	}
+	return sig, failed, err
+}
+
+// String returns a string representation of the ThresholdSigner.
+func (ts *ThresholdSigner) String() string {
+	return fmt.Sprintf(
+		"ThresholdSigner{%v %d/%d LH:%v, LR:%v, LS:%v}",
+		ts.group.PubKey.Address(),
+		ts.group.Threshold,
+		len(ts.group.PubShares),
+		ts.lastSignState.Height,
+		ts.lastSignState.Round,
+		ts.lastSignState.Step,
+	)
+}
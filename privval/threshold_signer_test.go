@@ -0,0 +1,252 @@
+package privval
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/threshold"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	"github.com/Finschia/ostracon/libs/log"
+	tmrand "github.com/Finschia/ostracon/libs/rand"
+	"github.com/Finschia/ostracon/types"
+)
+
+// faultyCosigner fails to sign, or signs invalid shares.
+type faultyCosigner struct {
+	*LocalCosigner
+	invalidShares bool
+}
+
+func (fc faultyCosigner) Sign(ctx context.Context, req CosignerSignRequest) (*CosignerSignResponse, error) {
+	if !fc.invalidShares {
+		return nil, errors.New("unavailable")
+	}
+	resp, err := fc.LocalCosigner.Sign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Share[0]++
+	return resp, nil
+}
+
+func testBlockID() types.BlockID {
+	return types.BlockID{Hash: tmrand.Bytes(tmhash.Size), PartSetHeader: types.PartSetHeader{}}
+}
+
+func newTestCosigners(t *testing.T, privKey ed25519.PrivKey, thresh, total int) []*LocalCosigner {
+	shares, err := threshold.SplitKey(privKey, thresh, total)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	cosigners := make([]*LocalCosigner, total)
+	for i, share := range shares {
+		keyFile := filepath.Join(dir, fmt.Sprintf("key_share_%d.json", share.ID))
+		require.NoError(t, SaveKeyShare(share, keyFile))
+		cosigners[i], err = LoadLocalCosigner(keyFile, filepath.Join(dir, fmt.Sprintf("state_%d.json", share.ID)))
+		require.NoError(t, err)
+	}
+	return cosigners
+}
+
+func TestThresholdSigner(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	cosigners := newTestCosigners(t, privKey, 2, 3)
+
+	// serve the second cosigner remotely
+	serverKey, clientKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	server := NewCosignerServer("tcp://127.0.0.1:0", cosigners[1], serverKey,
+		[]string{testNodeID(clientKey)}, log.TestingLogger())
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	remote, err := NewRemoteCosigner(2, testNodeID(serverKey), "tcp://"+server.Addr().String(), clientKey)
+	require.NoError(t, err)
+
+	stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+	ts, err := NewThresholdSigner(cosigners[0].Group(),
+		[]Cosigner{cosigners[0], remote, faultyCosigner{LocalCosigner: cosigners[2]}},
+		stateFile, DefaultCosignerTimeout)
+	require.NoError(t, err)
+	ts.SetLogger(log.TestingLogger())
+
+	pubKey, err := ts.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+
+	chainID := "mychainid"
+	vote := newVote(pubKey.Address(), 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	require.NoError(t, ts.SignVote(chainID, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+	assert.FileExists(t, stateFile)
+
+	// re-signing the same vote reuses the signature
+	sig := vote.Signature
+	vote.Signature = nil
+	require.NoError(t, ts.SignVote(chainID, vote))
+	assert.Equal(t, sig, vote.Signature)
+
+	// double signing is refused
+	conflicting := newVote(pubKey.Address(), 0, 10, 1, tmproto.PrevoteType, testBlockID()).ToProto()
+	assert.Error(t, ts.SignVote(chainID, conflicting))
+
+	proposal := newProposal(11, 0, testBlockID()).ToProto()
+	require.NoError(t, ts.SignProposal(chainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	message := []byte("seed")
+	proof, err := ts.GenerateVRFProof(message)
+	require.NoError(t, err)
+	output, err := pubKey.VRFVerify(proof, message)
+	require.NoError(t, err)
+	expectedProof, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	expectedOutput, err := pubKey.VRFVerify(expectedProof, message)
+	require.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+
+	// not enough cosigners left
+	ts, err = NewThresholdSigner(cosigners[0].Group(),
+		[]Cosigner{cosigners[0], faultyCosigner{LocalCosigner: cosigners[2], invalidShares: true}},
+		filepath.Join(t.TempDir(), "priv_validator_state.json"), DefaultCosignerTimeout)
+	require.NoError(t, err)
+	_, err = ts.GenerateVRFProof(message)
+	assert.True(t, errors.Is(err, threshold.ErrNotEnoughShares), err)
+}
+
+func testNodeID(privKey crypto.PrivKey) string {
+	return hex.EncodeToString(privKey.PubKey().Address())
+}
+
+func TestCosignerServerAuthentication(t *testing.T) {
+	cosigners := newTestCosigners(t, ed25519.GenPrivKey(), 2, 2)
+	serverKey, clientKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	server := NewCosignerServer("tcp://127.0.0.1:0", cosigners[1], serverKey,
+		[]string{testNodeID(clientKey)}, log.TestingLogger())
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	addr := "tcp://" + server.Addr().String()
+	ctx := context.Background()
+
+	remote, err := NewRemoteCosigner(2, testNodeID(serverKey), addr, clientKey)
+	require.NoError(t, err)
+	_, err = remote.Commit(ctx, CosignerCommitRequest{})
+	assert.NoError(t, err)
+
+	// the client isn't allowed by the cosigner
+	otherKey := ed25519.GenPrivKey()
+	remote, err = NewRemoteCosigner(2, testNodeID(serverKey), addr, otherKey)
+	require.NoError(t, err)
+	_, err = remote.Commit(ctx, CosignerCommitRequest{})
+	assert.Error(t, err)
+
+	// the cosigner isn't the expected one
+	remote, err = NewRemoteCosigner(2, testNodeID(otherKey), addr, clientKey)
+	require.NoError(t, err)
+	_, err = remote.Commit(ctx, CosignerCommitRequest{})
+	assert.Error(t, err)
+}
+
+func TestLocalCosignerDoubleSign(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	cosigners := newTestCosigners(t, privKey, 2, 2)
+	chainID := "mychainid"
+	ctx := context.Background()
+
+	sign := func(vote *tmproto.Vote) error {
+		voteBz, err := proto.Marshal(vote)
+		require.NoError(t, err)
+		var commitments []threshold.Commitment
+		var sessionIDs [][]byte
+		for _, cosigner := range cosigners {
+			resp, err := cosigner.Commit(ctx, CosignerCommitRequest{})
+			require.NoError(t, err)
+			commitments = append(commitments, resp.Commitment)
+			sessionIDs = append(sessionIDs, resp.SessionID)
+		}
+		req := CosignerSignRequest{SessionID: sessionIDs[0], ChainID: chainID, Vote: voteBz, Commitments: commitments}
+		_, err = cosigners[0].Sign(ctx, req)
+		if err == nil {
+			// sessions can't be reused
+			_, reuseErr := cosigners[0].Sign(ctx, req)
+			assert.Error(t, reuseErr)
+		}
+		return err
+	}
+
+	vote := newVote(privKey.PubKey().Address(), 0, 10, 1, tmproto.PrecommitType, testBlockID()).ToProto()
+	require.NoError(t, sign(vote))
+	require.NoError(t, sign(vote))
+
+	// a vote which only differs by its timestamp isn't a double sign
+	later := *vote
+	later.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, sign(&later))
+	assert.EqualValues(t, types.VoteSignBytes(chainID, vote), cosigners[0].lastSignState.SignBytes.Bytes())
+
+	blockID := testBlockID()
+	conflicting := *vote
+	conflicting.BlockID = blockID.ToProto()
+	assert.Error(t, sign(&conflicting))
+
+	vote.Height = 9
+	assert.Error(t, sign(vote))
+
+	// the state survives restarts
+	restarted, err := NewLocalCosigner(cosigners[0].keyShare, cosigners[0].lastSignState.filePath)
+	require.NoError(t, err)
+	cosigners[0] = restarted
+	assert.Error(t, sign(&conflicting))
+	vote.Height = 10
+	assert.NoError(t, sign(vote))
+}
+
+func TestLocalCosignerTamperedCommitment(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	cosigners := newTestCosigners(t, privKey, 2, 2)
+	ctx := context.Background()
+	vote := newVote(privKey.PubKey().Address(), 0, 10, 1, tmproto.PrecommitType, testBlockID()).ToProto()
+	voteBz, err := proto.Marshal(vote)
+	require.NoError(t, err)
+
+	sign := func(tamper func(commitments []threshold.Commitment)) error {
+		var commitments []threshold.Commitment
+		var sessionIDs [][]byte
+		for _, cosigner := range cosigners {
+			resp, err := cosigner.Commit(ctx, CosignerCommitRequest{})
+			require.NoError(t, err)
+			commitments = append(commitments, resp.Commitment)
+			sessionIDs = append(sessionIDs, resp.SessionID)
+		}
+		tamper(commitments)
+		_, err := cosigners[0].Sign(ctx, CosignerSignRequest{SessionID: sessionIDs[0], ChainID: "mychainid",
+			Vote: voteBz, Commitments: commitments})
+		return err
+	}
+
+	// the cosigner refuses to sign for nonces other than the ones it committed to
+	assert.Error(t, sign(func(commitments []threshold.Commitment) {
+		commitments[0].Hiding = commitments[1].Hiding
+	}))
+	assert.Error(t, sign(func(commitments []threshold.Commitment) {
+		commitments[0].Binding = commitments[1].Binding
+	}))
+	assert.NoError(t, sign(func([]threshold.Commitment) {}))
+}
@@ -5,6 +5,7 @@ package privval
 
 import (
 	fmt "fmt"
+	crypto "github.com/Finschia/ostracon/proto/ostracon/crypto"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/tendermint/tendermint/proto/tendermint/crypto"
@@ -124,6 +125,60 @@ func (m *VRFProofResponse) GetError() *privval.RemoteSignerError {
 	return nil
 }
 
+// PubKeyResponse is a response message containing the public key, for the key types which
+// tendermint.privval.PubKeyResponse can't carry, such as BLS12-381 and sr25519.
+type PubKeyResponse struct {
+	PubKey crypto.PublicKey           `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"`
+	Error  *privval.RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *PubKeyResponse) Reset()         { *m = PubKeyResponse{} }
+func (m *PubKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*PubKeyResponse) ProtoMessage()    {}
+func (*PubKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abbbbe5131a55005, []int{2}
+}
+func (m *PubKeyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PubKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PubKeyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PubKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubKeyResponse.Merge(m, src)
+}
+func (m *PubKeyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PubKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubKeyResponse proto.InternalMessageInfo
+
+func (m *PubKeyResponse) GetPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.PubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *PubKeyResponse) GetError() *privval.RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_PubKeyRequest
@@ -136,6 +191,7 @@ type Message struct {
 	//	*Message_PingResponse
 	//	*Message_VrfProofRequest
 	//	*Message_VrfProofResponse
+	//	*Message_OcPubKeyResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -143,7 +199,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_abbbbe5131a55005, []int{2}
+	return fileDescriptor_abbbbe5131a55005, []int{3}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -208,6 +264,9 @@ type Message_VrfProofRequest struct {
 type Message_VrfProofResponse struct {
 	VrfProofResponse *VRFProofResponse `protobuf:"bytes,1001,opt,name=vrf_proof_response,json=vrfProofResponse,proto3,oneof" json:"vrf_proof_response,omitempty"`
 }
+type Message_OcPubKeyResponse struct {
+	OcPubKeyResponse *PubKeyResponse `protobuf:"bytes,1002,opt,name=oc_pub_key_response,json=ocPubKeyResponse,proto3,oneof" json:"oc_pub_key_response,omitempty"`
+}
 
 func (*Message_PubKeyRequest) isMessage_Sum()          {}
 func (*Message_PubKeyResponse) isMessage_Sum()         {}
@@ -219,6 +278,7 @@ func (*Message_PingRequest) isMessage_Sum()            {}
 func (*Message_PingResponse) isMessage_Sum()           {}
 func (*Message_VrfProofRequest) isMessage_Sum()        {}
 func (*Message_VrfProofResponse) isMessage_Sum()       {}
+func (*Message_OcPubKeyResponse) isMessage_Sum()       {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -297,6 +357,13 @@ func (m *Message) GetVrfProofResponse() *VRFProofResponse {
 	return nil
 }
 
+func (m *Message) GetOcPubKeyResponse() *PubKeyResponse {
+	if x, ok := m.GetSum().(*Message_OcPubKeyResponse); ok {
+		return x.OcPubKeyResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -310,53 +377,60 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_PingResponse)(nil),
 		(*Message_VrfProofRequest)(nil),
 		(*Message_VrfProofResponse)(nil),
+		(*Message_OcPubKeyResponse)(nil),
 	}
 }
 
 func init() {
 	proto.RegisterType((*VRFProofRequest)(nil), "ostracon.privval.VRFProofRequest")
 	proto.RegisterType((*VRFProofResponse)(nil), "ostracon.privval.VRFProofResponse")
+	proto.RegisterType((*PubKeyResponse)(nil), "ostracon.privval.PubKeyResponse")
 	proto.RegisterType((*Message)(nil), "ostracon.privval.Message")
 }
 
 func init() { proto.RegisterFile("ostracon/privval/types.proto", fileDescriptor_abbbbe5131a55005) }
 
 var fileDescriptor_abbbbe5131a55005 = []byte{
-	// 538 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0xc1, 0x6e, 0xd3, 0x30,
-	0x18, 0xc7, 0x13, 0x46, 0x1b, 0xe4, 0x76, 0xb4, 0x33, 0x05, 0x55, 0xd5, 0x14, 0x4a, 0x11, 0x30,
-	0x81, 0x94, 0x48, 0xdb, 0x91, 0xdb, 0x04, 0x63, 0xd2, 0x34, 0x54, 0x32, 0x69, 0x87, 0x49, 0xa8,
-	0x4a, 0xdb, 0xaf, 0x59, 0xb4, 0x25, 0x36, 0xb6, 0x13, 0x29, 0x6f, 0xc1, 0x63, 0x71, 0xdc, 0x11,
-	0x6e, 0xa8, 0xbd, 0xc0, 0x5b, 0x4c, 0x71, 0xdc, 0x24, 0xed, 0xd2, 0xde, 0xe2, 0xbf, 0x3f, 0xff,
-	0xbe, 0x9f, 0x15, 0xdb, 0x68, 0x9f, 0x70, 0xc1, 0xdc, 0x09, 0x09, 0x6d, 0xca, 0xfc, 0x38, 0x76,
-	0x6f, 0x6d, 0x91, 0x50, 0xe0, 0x16, 0x65, 0x44, 0x10, 0xdc, 0x5e, 0xce, 0x5a, 0x6a, 0xb6, 0xb7,
-	0x2f, 0x20, 0x9c, 0x02, 0x0b, 0xfc, 0x50, 0xd8, 0x13, 0x96, 0x50, 0x41, 0xec, 0x1b, 0x48, 0x54,
-	0x7d, 0xaf, 0xe3, 0x11, 0x8f, 0xc8, 0x4f, 0x3b, 0xfd, 0x52, 0x69, 0x79, 0x8d, 0xa4, 0x97, 0x7b,
-	0xf4, 0xcc, 0xd2, 0x6c, 0x85, 0xc3, 0xe0, 0x03, 0x6a, 0x5d, 0x3a, 0x27, 0x43, 0x46, 0xc8, 0xcc,
-	0x81, 0x1f, 0x11, 0x70, 0x81, 0xbb, 0xc8, 0x08, 0x80, 0x73, 0xd7, 0x83, 0xae, 0xde, 0xd7, 0x0f,
-	0x9a, 0xce, 0x72, 0x38, 0x00, 0xd4, 0x2e, 0x8a, 0x39, 0x25, 0x21, 0x07, 0xdc, 0x41, 0x35, 0x9a,
-	0x06, 0xaa, 0x36, 0x1b, 0xe0, 0x8f, 0xa8, 0x06, 0x8c, 0x11, 0xd6, 0x7d, 0xd4, 0xd7, 0x0f, 0x1a,
-	0x87, 0x6f, 0xac, 0x42, 0x63, 0xb9, 0x59, 0xcb, 0x81, 0x80, 0x08, 0xb8, 0xf0, 0xbd, 0x10, 0xd8,
-	0xe7, 0xb4, 0xd8, 0xc9, 0xd6, 0x0c, 0xfe, 0xd4, 0x91, 0x71, 0x9e, 0xb5, 0xc4, 0x67, 0xa8, 0x45,
-	0xa3, 0xf1, 0xe8, 0x06, 0x92, 0x11, 0xcb, 0xfc, 0x64, 0xa3, 0xc6, 0xe1, 0xab, 0x2a, 0xe4, 0x30,
-	0x1a, 0x9f, 0x41, 0xa2, 0x36, 0x72, 0xaa, 0x39, 0xbb, 0xb4, 0x1c, 0xe0, 0xaf, 0xa8, 0x5d, 0xc0,
-	0x32, 0x7f, 0x25, 0x38, 0xd8, 0x46, 0xcb, 0x2a, 0x4f, 0x35, 0xe7, 0x29, 0x5d, 0x49, 0xf0, 0x37,
-	0xb4, 0xc7, 0x7d, 0x2f, 0x1c, 0xc5, 0x44, 0x40, 0xae, 0xb7, 0x23, 0x81, 0xaf, 0xab, 0x80, 0xe9,
-	0x5e, 0x2f, 0x89, 0x80, 0x42, 0xb0, 0xc5, 0x57, 0x23, 0x7c, 0x85, 0x3a, 0x69, 0x04, 0xd3, 0x25,
-	0x54, 0x69, 0x3e, 0x96, 0xd4, 0xb7, 0x9b, 0xa8, 0x30, 0xcd, 0x20, 0xb9, 0x2a, 0xe6, 0x0f, 0x52,
-	0xfc, 0x1d, 0x3d, 0x97, 0xba, 0x94, 0x11, 0x4a, 0xb8, 0x7b, 0x9b, 0x2b, 0xd7, 0x24, 0xfc, 0xdd,
-	0x26, 0xf8, 0x50, 0xd5, 0x17, 0xda, 0xcf, 0xf8, 0xc3, 0x18, 0xcf, 0x50, 0x57, 0xa9, 0x97, 0x1a,
-	0x28, 0xfd, 0xba, 0xec, 0xf0, 0x7e, 0xb3, 0x7e, 0x01, 0xcb, 0xb7, 0xf0, 0x82, 0x57, 0xce, 0xe0,
-	0x4f, 0xa8, 0x49, 0xfd, 0xd0, 0xcb, 0xed, 0x0d, 0xc9, 0x7e, 0x59, 0xf9, 0x07, 0xfd, 0xd0, 0x2b,
-	0xac, 0x1b, 0xb4, 0x18, 0xe2, 0x2f, 0x68, 0x57, 0x51, 0x94, 0xe2, 0x13, 0x89, 0xe9, 0x6f, 0xc6,
-	0xe4, 0x62, 0x4d, 0x5a, 0x1a, 0xe3, 0x21, 0xda, 0x8b, 0xd9, 0x6c, 0x24, 0xcf, 0x7d, 0xee, 0xf4,
-	0xcf, 0x50, 0x87, 0x74, 0xfd, 0x8a, 0x5b, 0x6b, 0xb7, 0x2d, 0x3d, 0x03, 0x31, 0x9b, 0xad, 0x5c,
-	0xc0, 0x0b, 0x84, 0xcb, 0x44, 0xe5, 0xf7, 0xdf, 0x50, 0x27, 0x75, 0x0b, 0x32, 0x57, 0x6c, 0x17,
-	0xcc, 0x2c, 0x3b, 0xae, 0xa1, 0x1d, 0x1e, 0x05, 0xc7, 0xe7, 0xbf, 0xe6, 0xa6, 0x7e, 0x37, 0x37,
-	0xf5, 0xbf, 0x73, 0x53, 0xff, 0xb9, 0x30, 0xb5, 0xbb, 0x85, 0xa9, 0xfd, 0x5e, 0x98, 0xda, 0xd5,
-	0x91, 0xe7, 0x8b, 0xeb, 0x68, 0x6c, 0x4d, 0x48, 0x60, 0x9f, 0xf8, 0x21, 0x9f, 0x5c, 0xfb, 0xae,
-	0x5d, 0x7a, 0xbf, 0xd2, 0x67, 0x67, 0xfd, 0x39, 0x1b, 0xd7, 0x65, 0x7e, 0x74, 0x1f, 0x00, 0x00,
-	0xff, 0xff, 0x04, 0x18, 0x8f, 0xf9, 0xe9, 0x04, 0x00, 0x00,
+	// 609 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x41, 0x6f, 0xd3, 0x3e,
+	0x14, 0xc0, 0x93, 0xff, 0xd6, 0xe5, 0x2f, 0xb7, 0x5b, 0x3b, 0xaf, 0xa0, 0x2a, 0x9a, 0xb2, 0x52,
+	0x04, 0x4c, 0x20, 0x25, 0xd2, 0x76, 0x42, 0xdc, 0x2a, 0x18, 0x93, 0xaa, 0xa1, 0x92, 0x49, 0x3b,
+	0x4c, 0x42, 0x55, 0x9b, 0xba, 0x59, 0xb4, 0x36, 0x36, 0xb6, 0x53, 0x29, 0xdf, 0x80, 0x23, 0x5f,
+	0x84, 0xef, 0xb1, 0xe3, 0x8e, 0x9c, 0x10, 0x6a, 0x2f, 0xc0, 0xa7, 0x40, 0x71, 0x5c, 0x27, 0x6d,
+	0xda, 0x5d, 0xb8, 0xc5, 0xcf, 0x2f, 0xbf, 0xf7, 0x7b, 0xce, 0x8b, 0xc1, 0x21, 0x66, 0x9c, 0xf6,
+	0x3d, 0x1c, 0x3a, 0x84, 0x06, 0xd3, 0x69, 0x7f, 0xec, 0xf0, 0x98, 0x20, 0x66, 0x13, 0x8a, 0x39,
+	0x86, 0xb5, 0xc5, 0xae, 0x2d, 0x77, 0xcd, 0x43, 0x8e, 0xc2, 0x21, 0xa2, 0x93, 0x20, 0xe4, 0x8e,
+	0x47, 0x63, 0xc2, 0xb1, 0x73, 0x8b, 0x62, 0x99, 0x6f, 0xd6, 0x7d, 0xec, 0x63, 0xf1, 0xe8, 0x24,
+	0x4f, 0x32, 0x9a, 0x7f, 0x47, 0xd0, 0xf3, 0x35, 0x4c, 0x2b, 0xb7, 0xbb, 0xc6, 0xc1, 0x34, 0x95,
+	0x61, 0xa1, 0x5e, 0xeb, 0x15, 0xa8, 0x5e, 0xb9, 0x67, 0x5d, 0x8a, 0xf1, 0xc8, 0x45, 0x9f, 0x23,
+	0xc4, 0x38, 0x6c, 0x00, 0x63, 0x82, 0x18, 0xeb, 0xfb, 0xa8, 0xa1, 0x37, 0xf5, 0xe3, 0x8a, 0xbb,
+	0x58, 0xb6, 0x10, 0xa8, 0x65, 0xc9, 0x8c, 0xe0, 0x90, 0x21, 0x58, 0x07, 0x25, 0x92, 0x04, 0x64,
+	0x6e, 0xba, 0x80, 0x6f, 0x40, 0x09, 0x51, 0x8a, 0x69, 0xe3, 0xbf, 0xa6, 0x7e, 0x5c, 0x3e, 0x79,
+	0x66, 0x67, 0x8a, 0x8b, 0x83, 0xb0, 0x5d, 0x34, 0xc1, 0x1c, 0x5d, 0x06, 0x7e, 0x88, 0xe8, 0xbb,
+	0x24, 0xd9, 0x4d, 0xdf, 0x69, 0x7d, 0xd1, 0xc1, 0x5e, 0x37, 0x1a, 0x74, 0x50, 0xac, 0xaa, 0xbc,
+	0x06, 0x06, 0x89, 0x06, 0xbd, 0x5b, 0x14, 0x8b, 0x3a, 0xe5, 0x13, 0xd3, 0x56, 0x07, 0x9b, 0x36,
+	0x65, 0x77, 0xa3, 0xc1, 0x38, 0xf0, 0x3a, 0x28, 0x6e, 0x6f, 0xdf, 0xfd, 0x38, 0xd2, 0xdc, 0x1d,
+	0x22, 0x10, 0xff, 0xa6, 0xf2, 0xcd, 0x00, 0xc6, 0x45, 0xda, 0x3d, 0xec, 0x80, 0xaa, 0x74, 0xe8,
+	0xd1, 0xf4, 0xa8, 0xa4, 0xcb, 0x93, 0x75, 0xc8, 0x45, 0x03, 0x22, 0xf1, 0x5c, 0x73, 0x77, 0x49,
+	0x3e, 0x00, 0x3f, 0x80, 0x5a, 0x06, 0x4b, 0x9b, 0x94, 0x82, 0xad, 0x87, 0x68, 0x69, 0xe6, 0xb9,
+	0xe6, 0xee, 0x91, 0xe5, 0x03, 0xfa, 0x08, 0xf6, 0x59, 0xe0, 0x87, 0xbd, 0x29, 0xe6, 0x48, 0xe9,
+	0x6d, 0x09, 0xe0, 0xd3, 0x75, 0xc0, 0xa4, 0xd7, 0x2b, 0xcc, 0x51, 0x26, 0x58, 0x65, 0xcb, 0x21,
+	0x78, 0x0d, 0xea, 0x49, 0x08, 0x0d, 0x17, 0x50, 0xa9, 0xb9, 0x2d, 0xa8, 0xcf, 0x37, 0x51, 0xd1,
+	0x30, 0x85, 0x28, 0x55, 0xc8, 0x0a, 0x51, 0xf8, 0x09, 0x3c, 0x12, 0xba, 0x84, 0x62, 0x82, 0x59,
+	0x7f, 0xac, 0x94, 0x4b, 0x02, 0xfe, 0x62, 0x13, 0xbc, 0x2b, 0xf3, 0x33, 0xed, 0x03, 0x56, 0x0c,
+	0xc3, 0x11, 0x68, 0x48, 0xf5, 0x5c, 0x01, 0xa9, 0xbf, 0x23, 0x2a, 0xbc, 0xdc, 0xac, 0x9f, 0xc1,
+	0x54, 0x0b, 0x8f, 0xd9, 0xda, 0x1d, 0xf8, 0x16, 0x54, 0x48, 0x10, 0xfa, 0xca, 0xde, 0x10, 0xec,
+	0xa3, 0xb5, 0x5f, 0x30, 0x08, 0xfd, 0xcc, 0xba, 0x4c, 0xb2, 0x25, 0x7c, 0x0f, 0x76, 0x25, 0x45,
+	0x2a, 0xfe, 0x2f, 0x30, 0xcd, 0xcd, 0x18, 0x25, 0x56, 0x21, 0xb9, 0x35, 0xec, 0x82, 0xfd, 0x29,
+	0x1d, 0xf5, 0xc4, 0x2f, 0xa8, 0x9c, 0x7e, 0x19, 0x72, 0x48, 0x57, 0x6f, 0x22, 0x7b, 0xe5, 0xc7,
+	0x4f, 0x66, 0x60, 0x4a, 0x47, 0xf9, 0x10, 0xbc, 0x04, 0x30, 0x4f, 0x94, 0x7e, 0xbf, 0x0d, 0x39,
+	0xa9, 0x0f, 0x20, 0x95, 0x62, 0x2d, 0x63, 0x4a, 0x4d, 0x17, 0x1c, 0x60, 0xaf, 0x57, 0x18, 0xff,
+	0x3f, 0x86, 0x6c, 0xbb, 0x40, 0x2d, 0x4c, 0x7f, 0x0d, 0x7b, 0xcb, 0xb1, 0x76, 0x09, 0x6c, 0xb1,
+	0x68, 0xd2, 0xbe, 0xb8, 0x9b, 0x59, 0xfa, 0xfd, 0xcc, 0xd2, 0x7f, 0xce, 0x2c, 0xfd, 0xeb, 0xdc,
+	0xd2, 0xee, 0xe7, 0x96, 0xf6, 0x7d, 0x6e, 0x69, 0xd7, 0xa7, 0x7e, 0xc0, 0x6f, 0xa2, 0x81, 0xed,
+	0xe1, 0x89, 0x73, 0x16, 0x84, 0xcc, 0xbb, 0x09, 0xfa, 0x4e, 0xee, 0xea, 0x4e, 0x6e, 0xdc, 0xd5,
+	0x9b, 0x7c, 0xb0, 0x23, 0xe2, 0xa7, 0x7f, 0x07, 0x00, 0xa6, 0x60, 0x08, 0x97, 0xe4, 0x05, 0x00,
+	0x00,
 }
 
 func (m *VRFProofRequest) Marshal() (dAtA []byte, err error) {
@@ -431,6 +505,51 @@ func (m *VRFProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *PubKeyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.PubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *Message) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -677,6 +796,29 @@ func (m *Message_VrfProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_OcPubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_OcPubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.OcPubKeyResponse != nil {
+		{
+			size, err := m.OcPubKeyResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xd2
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -718,6 +860,21 @@ func (m *VRFProofResponse) Size() (n int) {
 	return n
 }
 
+func (m *PubKeyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
@@ -850,6 +1007,18 @@ func (m *Message_VrfProofResponse) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_OcPubKeyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OcPubKeyResponse != nil {
+		l = m.OcPubKeyResponse.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -1061,6 +1230,125 @@ func (m *VRFProofResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PubKeyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubKeyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubKeyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &privval.RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1440,6 +1728,41 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_VrfProofResponse{v}
 			iNdEx = postIndex
+		case 1002:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OcPubKeyResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PubKeyResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_OcPubKeyResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
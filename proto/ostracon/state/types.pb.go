@@ -6,14 +6,14 @@ package state
 import (
 	fmt "fmt"
 	_ "github.com/Finschia/ostracon/abci/types"
-	_ "github.com/Finschia/ostracon/proto/ostracon/types"
+	types1 "github.com/Finschia/ostracon/proto/ostracon/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	types "github.com/tendermint/tendermint/abci/types"
 	state "github.com/tendermint/tendermint/proto/tendermint/state"
-	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
+	types2 "github.com/tendermint/tendermint/proto/tendermint/types"
 	_ "github.com/tendermint/tendermint/proto/tendermint/version"
 	io "io"
 	math "math"
@@ -96,6 +96,60 @@ func (m *ABCIResponses) GetBeginBlock() *types.ResponseBeginBlock {
 	return nil
 }
 
+// ValidatorsInfo represents the latest validator set, or the last height it changed.
+// It is wire-compatible with tendermint.state.ValidatorsInfo, and carries ostracon.types.ValidatorSet instead.
+type ValidatorsInfo struct {
+	ValidatorSet      *types1.ValidatorSet `protobuf:"bytes,1,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set,omitempty"`
+	LastHeightChanged int64                `protobuf:"varint,2,opt,name=last_height_changed,json=lastHeightChanged,proto3" json:"last_height_changed,omitempty"`
+}
+
+func (m *ValidatorsInfo) Reset()         { *m = ValidatorsInfo{} }
+func (m *ValidatorsInfo) String() string { return proto.CompactTextString(m) }
+func (*ValidatorsInfo) ProtoMessage()    {}
+func (*ValidatorsInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_898987a4421067cd, []int{1}
+}
+func (m *ValidatorsInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorsInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorsInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidatorsInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorsInfo.Merge(m, src)
+}
+func (m *ValidatorsInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorsInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorsInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorsInfo proto.InternalMessageInfo
+
+func (m *ValidatorsInfo) GetValidatorSet() *types1.ValidatorSet {
+	if m != nil {
+		return m.ValidatorSet
+	}
+	return nil
+}
+
+func (m *ValidatorsInfo) GetLastHeightChanged() int64 {
+	if m != nil {
+		return m.LastHeightChanged
+	}
+	return 0
+}
+
 type State struct {
 	Version state.Version `protobuf:"bytes,1,opt,name=version,proto3" json:"version"`
 	// immutable
@@ -103,7 +157,7 @@ type State struct {
 	InitialHeight int64  `protobuf:"varint,14,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
 	// LastBlockHeight=0 at genesis (ie. block(H=0) does not exist)
 	LastBlockHeight int64          `protobuf:"varint,3,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
-	LastBlockID     types1.BlockID `protobuf:"bytes,4,opt,name=last_block_id,json=lastBlockId,proto3" json:"last_block_id"`
+	LastBlockID     types2.BlockID `protobuf:"bytes,4,opt,name=last_block_id,json=lastBlockId,proto3" json:"last_block_id"`
 	LastBlockTime   time.Time      `protobuf:"bytes,5,opt,name=last_block_time,json=lastBlockTime,proto3,stdtime" json:"last_block_time"`
 	// LastValidators is used to validate block.LastCommit.
 	// Validators are persisted to the database separately every time they change,
@@ -117,7 +171,7 @@ type State struct {
 	LastHeightValidatorsChanged int64                `protobuf:"varint,9,opt,name=last_height_validators_changed,json=lastHeightValidatorsChanged,proto3" json:"last_height_validators_changed,omitempty"`
 	// Consensus parameters used for validating blocks.
 	// Changes returned by EndBlock and updated after Commit.
-	ConsensusParams                  types1.ConsensusParams `protobuf:"bytes,10,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params"`
+	ConsensusParams                  types2.ConsensusParams `protobuf:"bytes,10,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params"`
 	LastHeightConsensusParamsChanged int64                  `protobuf:"varint,11,opt,name=last_height_consensus_params_changed,json=lastHeightConsensusParamsChanged,proto3" json:"last_height_consensus_params_changed,omitempty"`
 	// Merkle root of the results from executing prev block
 	LastResultsHash []byte `protobuf:"bytes,12,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
@@ -131,7 +185,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_898987a4421067cd, []int{1}
+	return fileDescriptor_898987a4421067cd, []int{2}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -188,11 +242,11 @@ func (m *State) GetLastBlockHeight() int64 {
 	return 0
 }
 
-func (m *State) GetLastBlockID() types1.BlockID {
+func (m *State) GetLastBlockID() types2.BlockID {
 	if m != nil {
 		return m.LastBlockID
 	}
-	return types1.BlockID{}
+	return types2.BlockID{}
 }
 
 func (m *State) GetLastBlockTime() time.Time {
@@ -230,11 +284,11 @@ func (m *State) GetLastHeightValidatorsChanged() int64 {
 	return 0
 }
 
-func (m *State) GetConsensusParams() types1.ConsensusParams {
+func (m *State) GetConsensusParams() types2.ConsensusParams {
 	if m != nil {
 		return m.ConsensusParams
 	}
-	return types1.ConsensusParams{}
+	return types2.ConsensusParams{}
 }
 
 func (m *State) GetLastHeightConsensusParamsChanged() int64 {
@@ -267,58 +321,61 @@ func (m *State) GetLastProofHash() []byte {
 
 func init() {
 	proto.RegisterType((*ABCIResponses)(nil), "ostracon.state.ABCIResponses")
+	proto.RegisterType((*ValidatorsInfo)(nil), "ostracon.state.ValidatorsInfo")
 	proto.RegisterType((*State)(nil), "ostracon.state.State")
 }
 
 func init() { proto.RegisterFile("ostracon/state/types.proto", fileDescriptor_898987a4421067cd) }
 
 var fileDescriptor_898987a4421067cd = []byte{
-	// 708 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x4f, 0x6f, 0xd3, 0x3e,
-	0x18, 0xc7, 0x9b, 0x5f, 0xb7, 0xb5, 0x73, 0xd6, 0xf6, 0x47, 0xe0, 0x90, 0x75, 0x90, 0x96, 0xf1,
-	0xaf, 0xe2, 0x90, 0x8a, 0x71, 0xe2, 0x82, 0x44, 0x5a, 0x60, 0x15, 0x13, 0x9a, 0xb2, 0x69, 0x07,
-	0x2e, 0x91, 0x93, 0x78, 0x89, 0x45, 0x1a, 0x47, 0xb1, 0x3b, 0x8d, 0xb7, 0xc0, 0x69, 0x2f, 0x6b,
-	0xc7, 0x1d, 0x11, 0x87, 0x81, 0xba, 0x0b, 0x2f, 0x03, 0xd9, 0x8e, 0xd3, 0x6c, 0x65, 0xd2, 0x6e,
-	0xce, 0xf3, 0xfd, 0x3e, 0x1f, 0x7d, 0x6d, 0x3f, 0x31, 0xe8, 0x12, 0xca, 0x72, 0x18, 0x90, 0x74,
-	0x48, 0x19, 0x64, 0x68, 0xc8, 0xbe, 0x65, 0x88, 0xda, 0x59, 0x4e, 0x18, 0x31, 0xda, 0x4a, 0xb3,
-	0x85, 0xd6, 0x7d, 0x10, 0x91, 0x88, 0x08, 0x69, 0xc8, 0x57, 0xd2, 0xd5, 0xdd, 0x2c, 0x09, 0xd0,
-	0x0f, 0x70, 0x15, 0xd0, 0x5d, 0xc0, 0x45, 0xf5, 0x9a, 0xd6, 0x67, 0x28, 0x0d, 0x51, 0x3e, 0xc5,
-	0x29, 0x2b, 0xd4, 0x13, 0x98, 0xe0, 0x10, 0x32, 0x92, 0x17, 0x8e, 0x47, 0x4b, 0x8e, 0x0c, 0xe6,
-	0x70, 0xaa, 0x00, 0x0f, 0x97, 0xe4, 0x2a, 0xde, 0xaa, 0xa8, 0x27, 0x28, 0xa7, 0x58, 0x85, 0x28,
-	0xf4, 0x5e, 0x44, 0x48, 0x94, 0xa0, 0xa1, 0xf8, 0xf2, 0x67, 0xc7, 0x43, 0x86, 0xa7, 0x88, 0x32,
-	0x38, 0xcd, 0xfe, 0x81, 0x5f, 0x3a, 0x9a, 0xee, 0x56, 0x45, 0xbd, 0xb9, 0xed, 0xed, 0x9f, 0x1a,
-	0x68, 0xbd, 0x73, 0x46, 0x13, 0x17, 0xd1, 0x8c, 0xa4, 0x14, 0x51, 0x63, 0x04, 0xf4, 0x10, 0x25,
-	0xf8, 0x04, 0xe5, 0x1e, 0x3b, 0xa5, 0xa6, 0xd6, 0xaf, 0x0f, 0xf4, 0x9d, 0x6d, 0x7b, 0x01, 0xb1,
-	0x39, 0xc4, 0x56, 0x0d, 0x63, 0xe9, 0x3d, 0x3c, 0x75, 0x41, 0xa8, 0x96, 0xd4, 0x78, 0x0b, 0xd6,
-	0x51, 0x1a, 0x7a, 0x7e, 0x42, 0x82, 0xaf, 0xe6, 0x7f, 0x7d, 0x6d, 0xa0, 0xef, 0x3c, 0xbe, 0x15,
-	0xf1, 0x3e, 0x0d, 0x1d, 0x6e, 0x74, 0x9b, 0xa8, 0x58, 0x19, 0x63, 0xa0, 0xfb, 0x28, 0xc2, 0x69,
-	0x41, 0xa8, 0x0b, 0xc2, 0x93, 0x5b, 0x09, 0x0e, 0xf7, 0x4a, 0x06, 0xf0, 0xcb, 0xf5, 0xf6, 0xf7,
-	0x06, 0x58, 0x3d, 0xe0, 0xe7, 0x61, 0xbc, 0x01, 0x8d, 0xe2, 0x64, 0x4d, 0x4d, 0xb0, 0x36, 0xab,
-	0x2c, 0x71, 0x66, 0xf6, 0x91, 0x34, 0x38, 0x2b, 0xe7, 0x97, 0xbd, 0x9a, 0xab, 0xfc, 0xc6, 0x73,
-	0xd0, 0x0c, 0x62, 0x88, 0x53, 0x0f, 0x87, 0x62, 0x27, 0xeb, 0x8e, 0x3e, 0xbf, 0xec, 0x35, 0x46,
-	0xbc, 0x36, 0x19, 0xbb, 0x0d, 0x21, 0x4e, 0x42, 0xe3, 0x19, 0x68, 0xe3, 0x14, 0x33, 0x0c, 0x13,
-	0x2f, 0x46, 0x38, 0x8a, 0x99, 0xd9, 0xee, 0x6b, 0x83, 0xba, 0xdb, 0x2a, 0xaa, 0xbb, 0xa2, 0x68,
-	0xbc, 0x04, 0xf7, 0x12, 0x48, 0x99, 0xdc, 0x98, 0x72, 0xd6, 0x85, 0xb3, 0xc3, 0x05, 0x91, 0xbc,
-	0xf0, 0xba, 0xa0, 0x55, 0xf1, 0xe2, 0xd0, 0x5c, 0x59, 0xce, 0x2e, 0x2f, 0x53, 0x74, 0x4d, 0xc6,
-	0xce, 0x7d, 0x9e, 0x7d, 0x7e, 0xd9, 0xd3, 0xf7, 0x14, 0x6a, 0x32, 0x76, 0xf5, 0x92, 0x3b, 0x09,
-	0x8d, 0x3d, 0xd0, 0xa9, 0x30, 0xf9, 0x24, 0x99, 0xab, 0x82, 0xda, 0xb5, 0xe5, 0x98, 0xd9, 0x6a,
-	0xcc, 0xec, 0x43, 0x35, 0x66, 0x4e, 0x93, 0x63, 0xcf, 0x7e, 0xf5, 0x34, 0xb7, 0x55, 0xb2, 0xb8,
-	0x6a, 0x7c, 0x04, 0x9d, 0x14, 0x9d, 0x32, 0xaf, 0xfc, 0x1f, 0xa8, 0xb9, 0x26, 0x68, 0xd6, 0x72,
-	0xc6, 0x23, 0xe5, 0x39, 0x40, 0xcc, 0x6d, 0xf3, 0xb6, 0xb2, 0xc2, 0x07, 0x06, 0x54, 0x18, 0x8d,
-	0x3b, 0x31, 0x2a, 0x1d, 0x3c, 0x88, 0xd8, 0x56, 0x05, 0xd2, 0xbc, 0x5b, 0x10, 0xde, 0x56, 0x09,
-	0x32, 0x02, 0x96, 0x00, 0xc9, 0x9b, 0xa9, 0xf0, 0xbc, 0x20, 0x86, 0x69, 0x84, 0x42, 0x73, 0x5d,
-	0x5c, 0xd6, 0x16, 0x77, 0xc9, 0x7b, 0x5a, 0x74, 0x8f, 0xa4, 0xc5, 0x70, 0xc1, 0xff, 0x01, 0x9f,
-	0xcb, 0x94, 0xce, 0xa8, 0x27, 0x5f, 0x02, 0x13, 0x2c, 0xff, 0x05, 0x32, 0xce, 0x48, 0x39, 0xf7,
-	0x85, 0xb1, 0x98, 0xbf, 0x4e, 0x70, 0xbd, 0x6c, 0x7c, 0x06, 0x4f, 0xab, 0xc1, 0x6e, 0xf2, 0xcb,
-	0x78, 0xba, 0x88, 0xd7, 0x5f, 0xc4, 0xbb, 0xc1, 0x57, 0x19, 0xd5, 0x20, 0xe6, 0x88, 0xce, 0x12,
-	0x46, 0xbd, 0x18, 0xd2, 0xd8, 0xdc, 0xe8, 0x6b, 0x83, 0x0d, 0x39, 0x88, 0xae, 0xac, 0xef, 0x42,
-	0x1a, 0x1b, 0x9b, 0xa0, 0x09, 0xb3, 0x4c, 0x5a, 0x5a, 0xc2, 0xd2, 0x80, 0x59, 0x26, 0xa4, 0x17,
-	0xc5, 0xc1, 0x67, 0x39, 0x21, 0xc7, 0xd2, 0xf1, 0xa7, 0x21, 0x2c, 0x62, 0x54, 0xf6, 0x79, 0x99,
-	0x1b, 0x9d, 0x4f, 0xe7, 0x73, 0x4b, 0xbb, 0x98, 0x5b, 0xda, 0xef, 0xb9, 0xa5, 0x9d, 0x5d, 0x59,
-	0xb5, 0x8b, 0x2b, 0xab, 0xf6, 0xe3, 0xca, 0xaa, 0x7d, 0x79, 0x15, 0x61, 0x16, 0xcf, 0x7c, 0x3b,
-	0x20, 0xd3, 0xe1, 0x07, 0x9c, 0xd2, 0x20, 0xc6, 0x70, 0x58, 0x3e, 0xc7, 0xf2, 0x11, 0xbf, 0xfe,
-	0xf4, 0xfb, 0x6b, 0xa2, 0xfa, 0xfa, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x37, 0xe8, 0x57, 0xa5,
-	0x13, 0x06, 0x00, 0x00,
+	// 749 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xcd, 0x4e, 0xdb, 0x4a,
+	0x18, 0x8d, 0x6f, 0x80, 0x84, 0x31, 0x49, 0xee, 0x1d, 0xee, 0xc2, 0x04, 0xea, 0xa4, 0xe9, 0x1f,
+	0xea, 0xc2, 0x51, 0xe9, 0xaa, 0x52, 0x55, 0x09, 0x27, 0x54, 0x44, 0x45, 0x15, 0x32, 0x88, 0x45,
+	0x37, 0xd6, 0xc4, 0x1e, 0xec, 0x51, 0x13, 0x8f, 0xe5, 0x99, 0x44, 0x74, 0xdd, 0x3e, 0x00, 0x8f,
+	0xc5, 0x92, 0x65, 0xd5, 0x05, 0xad, 0xc2, 0xa6, 0x8f, 0x51, 0xcd, 0x8c, 0xed, 0x38, 0xa4, 0x48,
+	0xec, 0xc6, 0xdf, 0x39, 0xdf, 0xf1, 0x99, 0xf9, 0xce, 0xd8, 0xa0, 0x49, 0x19, 0x4f, 0x90, 0x47,
+	0xa3, 0x2e, 0xe3, 0x88, 0xe3, 0x2e, 0xff, 0x12, 0x63, 0x66, 0xc5, 0x09, 0xe5, 0x14, 0xd6, 0x33,
+	0xcc, 0x92, 0x58, 0xf3, 0xff, 0x80, 0x06, 0x54, 0x42, 0x5d, 0xb1, 0x52, 0xac, 0xe6, 0x56, 0xae,
+	0x80, 0x86, 0x1e, 0x29, 0x0a, 0x34, 0xe7, 0xe2, 0xb2, 0xba, 0x80, 0x99, 0x77, 0xb0, 0x29, 0x1a,
+	0x11, 0x1f, 0x71, 0x9a, 0xa4, 0xf8, 0x23, 0x8e, 0x23, 0x1f, 0x27, 0x63, 0x12, 0xf1, 0x94, 0x11,
+	0xa3, 0x04, 0x8d, 0xb3, 0xf6, 0x9d, 0x25, 0x78, 0x41, 0xbc, 0x80, 0x4e, 0x71, 0xc2, 0x08, 0x8d,
+	0x16, 0xf0, 0x56, 0x40, 0x69, 0x30, 0xc2, 0x5d, 0xf9, 0x34, 0x9c, 0x9c, 0x77, 0x39, 0x19, 0x63,
+	0xc6, 0xd1, 0x38, 0xfe, 0x8b, 0xfc, 0xd2, 0xc1, 0x34, 0xb7, 0x0b, 0xe8, 0xdd, 0x4d, 0x77, 0x7e,
+	0x68, 0xa0, 0xb6, 0x6f, 0xf7, 0x06, 0x0e, 0x66, 0x31, 0x8d, 0x18, 0x66, 0xb0, 0x07, 0x74, 0x1f,
+	0x8f, 0xc8, 0x14, 0x27, 0x2e, 0xbf, 0x60, 0x86, 0xd6, 0x2e, 0xef, 0xea, 0x7b, 0x1d, 0x6b, 0x2e,
+	0x62, 0x09, 0x11, 0x2b, 0x6b, 0xe8, 0x2b, 0xee, 0xe9, 0x85, 0x03, 0xfc, 0x6c, 0xc9, 0xe0, 0x3b,
+	0xb0, 0x8e, 0x23, 0xdf, 0x1d, 0x8e, 0xa8, 0xf7, 0xd9, 0xf8, 0xa7, 0xad, 0xed, 0xea, 0x7b, 0x8f,
+	0xef, 0x95, 0x38, 0x88, 0x7c, 0x5b, 0x10, 0x9d, 0x2a, 0x4e, 0x57, 0xb0, 0x0f, 0xf4, 0x21, 0x0e,
+	0x48, 0x94, 0x2a, 0x94, 0xa5, 0xc2, 0x93, 0x7b, 0x15, 0x6c, 0xc1, 0x55, 0x1a, 0x60, 0x98, 0xaf,
+	0x3b, 0x5f, 0x35, 0x50, 0x3f, 0xcb, 0x26, 0xc5, 0x06, 0xd1, 0x39, 0x85, 0xfb, 0xa0, 0x96, 0xcf,
+	0xce, 0x65, 0x98, 0x1b, 0x9a, 0x94, 0xde, 0xb1, 0xf2, 0xf4, 0xa8, 0xd3, 0xc9, 0xdb, 0x4e, 0x30,
+	0x77, 0x36, 0xa6, 0x85, 0x27, 0x68, 0x81, 0xcd, 0x11, 0x62, 0xdc, 0x0d, 0x31, 0x09, 0x42, 0xee,
+	0x7a, 0x21, 0x8a, 0x02, 0xec, 0xcb, 0x5d, 0x96, 0x9d, 0xff, 0x04, 0x74, 0x28, 0x91, 0x9e, 0x02,
+	0x3a, 0xdf, 0x2a, 0x60, 0xf5, 0x44, 0x4c, 0x05, 0xbe, 0x01, 0x95, 0x74, 0xbe, 0xe9, 0x6b, 0xb7,
+	0x8a, 0x3b, 0x92, 0x93, 0xb3, 0xce, 0x14, 0xc1, 0x5e, 0xb9, 0xba, 0x69, 0x95, 0x9c, 0x8c, 0x0f,
+	0x9f, 0x83, 0xaa, 0x17, 0x22, 0x12, 0xb9, 0x44, 0xbd, 0x69, 0xdd, 0xd6, 0x67, 0x37, 0xad, 0x4a,
+	0x4f, 0xd4, 0x06, 0x7d, 0xa7, 0x22, 0xc1, 0x81, 0x0f, 0x9f, 0x81, 0x3a, 0x89, 0x08, 0x27, 0x68,
+	0x94, 0xfa, 0x33, 0xea, 0xd2, 0x57, 0x2d, 0xad, 0x2a, 0x6b, 0xf0, 0x25, 0x90, 0x46, 0xd5, 0xf1,
+	0x66, 0xcc, 0xb2, 0x64, 0x36, 0x04, 0x20, 0xcf, 0x2f, 0xe5, 0x3a, 0xa0, 0x56, 0xe0, 0x12, 0xdf,
+	0x58, 0x59, 0xf6, 0xae, 0x0e, 0x4d, 0x76, 0x0d, 0xfa, 0xf6, 0xa6, 0xf0, 0x3e, 0xbb, 0x69, 0xe9,
+	0x47, 0x99, 0xd4, 0xa0, 0xef, 0xe8, 0xb9, 0xee, 0xc0, 0x87, 0x47, 0xa0, 0x51, 0xd0, 0x14, 0x79,
+	0x36, 0x56, 0xa5, 0x6a, 0xd3, 0x52, 0x61, 0xb7, 0xb2, 0xb0, 0x5b, 0xa7, 0x59, 0xd8, 0xed, 0xaa,
+	0x90, 0xbd, 0xfc, 0xd9, 0xd2, 0x9c, 0x5a, 0xae, 0x25, 0x50, 0x78, 0x00, 0x1a, 0x11, 0xbe, 0xe0,
+	0x6e, 0x3e, 0x26, 0x66, 0xac, 0x3d, 0x60, 0xac, 0x75, 0xd1, 0x94, 0x57, 0x18, 0x7c, 0x0b, 0x40,
+	0x41, 0xa1, 0xf2, 0x00, 0x85, 0x02, 0x5f, 0x98, 0x90, 0x5b, 0x2a, 0x48, 0x54, 0x1f, 0x62, 0x42,
+	0x34, 0x15, 0x4c, 0xf4, 0x80, 0x59, 0x4c, 0xd7, 0x5c, 0x2d, 0x0f, 0xda, 0xba, 0x1c, 0xd3, 0xf6,
+	0x3c, 0x68, 0xf3, 0xee, 0x34, 0x72, 0xd0, 0x01, 0xff, 0x7a, 0xe2, 0x5e, 0x44, 0x6c, 0xc2, 0x5c,
+	0xf5, 0x25, 0x32, 0xc0, 0xf2, 0x2d, 0x54, 0x76, 0x7a, 0x19, 0xf3, 0x58, 0x12, 0xd3, 0xe4, 0x35,
+	0xbc, 0xc5, 0x32, 0xfc, 0x08, 0x9e, 0x2e, 0xc4, 0xfe, 0x8e, 0x7e, 0x6e, 0x4f, 0x97, 0xf6, 0xda,
+	0x85, 0x7b, 0xb0, 0x28, 0x94, 0x79, 0xcc, 0x22, 0x98, 0x60, 0x36, 0x19, 0x71, 0xe6, 0x86, 0x88,
+	0x85, 0xc6, 0x46, 0x5b, 0xdb, 0xdd, 0x50, 0x11, 0x74, 0x54, 0xfd, 0x10, 0xb1, 0x10, 0x6e, 0x81,
+	0x2a, 0x8a, 0x63, 0x45, 0xa9, 0x49, 0x4a, 0x05, 0xc5, 0xb1, 0x84, 0x5e, 0xa4, 0xc7, 0x1e, 0x27,
+	0x94, 0x9e, 0x2b, 0xc6, 0xef, 0x8a, 0xa4, 0xc8, 0x90, 0x1c, 0x8b, 0xb2, 0x20, 0xda, 0x1f, 0xae,
+	0x66, 0xa6, 0x76, 0x3d, 0x33, 0xb5, 0x5f, 0x33, 0x53, 0xbb, 0xbc, 0x35, 0x4b, 0xd7, 0xb7, 0x66,
+	0xe9, 0xfb, 0xad, 0x59, 0xfa, 0xf4, 0x2a, 0x20, 0x3c, 0x9c, 0x0c, 0x2d, 0x8f, 0x8e, 0xbb, 0xef,
+	0x49, 0xc4, 0xbc, 0x90, 0xa0, 0x6e, 0xfe, 0xc1, 0x57, 0xbf, 0x90, 0xc5, 0x1f, 0xcf, 0x70, 0x4d,
+	0x56, 0x5f, 0xff, 0x19, 0x00, 0xd1, 0xd3, 0x59, 0x57, 0x91, 0x06, 0x00, 0x00,
 }
 
 func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
@@ -382,6 +439,46 @@ func (m *ABCIResponses) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ValidatorsInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorsInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidatorsInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastHeightChanged != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastHeightChanged))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ValidatorSet != nil {
+		{
+			size, err := m.ValidatorSet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *State) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -486,12 +583,12 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x32
 	}
-	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
-	if err7 != nil {
-		return 0, err7
+	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
+	if err8 != nil {
+		return 0, err8
 	}
-	i -= n7
-	i = encodeVarintTypes(dAtA, i, uint64(n7))
+	i -= n8
+	i = encodeVarintTypes(dAtA, i, uint64(n8))
 	i--
 	dAtA[i] = 0x2a
 	{
@@ -563,6 +660,22 @@ func (m *ABCIResponses) Size() (n int) {
 	return n
 }
 
+func (m *ValidatorsInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ValidatorSet != nil {
+		l = m.ValidatorSet.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.LastHeightChanged != 0 {
+		n += 1 + sovTypes(uint64(m.LastHeightChanged))
+	}
+	return n
+}
+
 func (m *State) Size() (n int) {
 	if m == nil {
 		return 0
@@ -782,6 +895,111 @@ func (m *ABCIResponses) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ValidatorsInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorsInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorsInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValidatorSet == nil {
+				m.ValidatorSet = &types1.ValidatorSet{}
+			}
+			if err := m.ValidatorSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHeightChanged", wireType)
+			}
+			m.LastHeightChanged = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastHeightChanged |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *State) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
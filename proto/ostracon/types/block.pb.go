@@ -25,12 +25,13 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Block struct {
-	Header     types.Header       `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Data       types.Data         `protobuf:"bytes,2,opt,name=data,proto3" json:"data"`
-	Evidence   types.EvidenceList `protobuf:"bytes,3,opt,name=evidence,proto3" json:"evidence"`
-	LastCommit *types.Commit      `protobuf:"bytes,4,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
+	Header     types.Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Data       types.Data   `protobuf:"bytes,2,opt,name=data,proto3" json:"data"`
+	Evidence   EvidenceList `protobuf:"bytes,3,opt,name=evidence,proto3" json:"evidence"`
+	LastCommit *Commit      `protobuf:"bytes,4,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
 	// *** Ostracon Extended Fields ***
-	Entropy Entropy `protobuf:"bytes,1000,opt,name=entropy,proto3" json:"entropy"`
+	Entropy      Entropy          `protobuf:"bytes,1000,opt,name=entropy,proto3" json:"entropy"`
+	KeyRotations *KeyRotationList `protobuf:"bytes,1001,opt,name=key_rotations,json=keyRotations,proto3" json:"key_rotations,omitempty"`
 }
 
 func (m *Block) Reset()         { *m = Block{} }
@@ -80,14 +81,14 @@ func (m *Block) GetData() types.Data {
 	return types.Data{}
 }
 
-func (m *Block) GetEvidence() types.EvidenceList {
+func (m *Block) GetEvidence() EvidenceList {
 	if m != nil {
 		return m.Evidence
 	}
-	return types.EvidenceList{}
+	return EvidenceList{}
 }
 
-func (m *Block) GetLastCommit() *types.Commit {
+func (m *Block) GetLastCommit() *Commit {
 	if m != nil {
 		return m.LastCommit
 	}
@@ -101,6 +102,13 @@ func (m *Block) GetEntropy() Entropy {
 	return Entropy{}
 }
 
+func (m *Block) GetKeyRotations() *KeyRotationList {
+	if m != nil {
+		return m.KeyRotations
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Block)(nil), "ostracon.types.Block")
 }
@@ -108,27 +116,29 @@ func init() {
 func init() { proto.RegisterFile("ostracon/types/block.proto", fileDescriptor_69510200dee501a6) }
 
 var fileDescriptor_69510200dee501a6 = []byte{
-	// 315 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xcd, 0x4a, 0xf3, 0x40,
-	0x14, 0x40, 0x93, 0x7e, 0xfd, 0x5a, 0x99, 0x82, 0x8b, 0x41, 0x74, 0x28, 0x32, 0x15, 0x57, 0xae,
-	0x26, 0xfe, 0x80, 0xe8, 0x4e, 0xaa, 0x15, 0x41, 0x57, 0x5d, 0xba, 0x91, 0xe9, 0x64, 0x68, 0x06,
-	0x9b, 0x99, 0x90, 0x5c, 0x85, 0xbe, 0x85, 0x8f, 0xe1, 0xa3, 0x74, 0xd9, 0xa5, 0x2b, 0x91, 0x64,
-	0xe3, 0x63, 0x48, 0x26, 0x93, 0x6a, 0x0d, 0x6e, 0xc2, 0x25, 0xe7, 0x9c, 0xe4, 0xc2, 0x45, 0x7d,
-	0x93, 0x41, 0xca, 0x85, 0xd1, 0x01, 0xcc, 0x13, 0x99, 0x05, 0x93, 0x99, 0x11, 0x8f, 0x2c, 0x49,
-	0x0d, 0x18, 0xbc, 0x59, 0x33, 0x66, 0x59, 0x7f, 0x6b, 0x6a, 0xa6, 0xc6, 0xa2, 0xa0, 0x9c, 0x2a,
-	0xab, 0xff, 0xfb, 0x0b, 0xf6, 0xe9, 0xd8, 0x00, 0xa4, 0x0e, 0x65, 0x1a, 0x2b, 0x0d, 0x8e, 0xca,
-	0x67, 0x15, 0x4a, 0x2d, 0xa4, 0x13, 0x76, 0x1b, 0xc2, 0x8f, 0x7c, 0xff, 0xb5, 0x85, 0xfe, 0x0f,
-	0xcb, 0x85, 0xf0, 0x29, 0xea, 0x44, 0x92, 0x87, 0x32, 0x25, 0xfe, 0x9e, 0x7f, 0xd0, 0x3b, 0x26,
-	0xec, 0x3b, 0xac, 0xb6, 0x63, 0x37, 0x96, 0x0f, 0xdb, 0x8b, 0xf7, 0x81, 0x37, 0x76, 0x36, 0x3e,
-	0x44, 0xed, 0x90, 0x03, 0x27, 0x2d, 0x5b, 0x6d, 0x37, 0xab, 0x2b, 0x0e, 0xdc, 0x35, 0xd6, 0xc4,
-	0x17, 0x68, 0xa3, 0xde, 0x91, 0xfc, 0xb3, 0x15, 0x6d, 0x56, 0x23, 0x67, 0xdc, 0xa9, 0x0c, 0x5c,
-	0xbd, 0xaa, 0xf0, 0x39, 0xea, 0xcd, 0x78, 0x06, 0x0f, 0xc2, 0xc4, 0xb1, 0x02, 0xd2, 0xfe, 0x6b,
-	0xe1, 0x4b, 0xcb, 0xc7, 0xa8, 0x94, 0xab, 0x19, 0x9f, 0xa1, 0xae, 0xd4, 0x90, 0x9a, 0x64, 0x4e,
-	0x3e, 0xbb, 0xb6, 0xdb, 0x61, 0xeb, 0x47, 0x60, 0xa3, 0x8a, 0xbb, 0xbf, 0xd6, 0xfa, 0xf0, 0x76,
-	0x91, 0x53, 0x7f, 0x99, 0x53, 0xff, 0x23, 0xa7, 0xfe, 0x4b, 0x41, 0xbd, 0x65, 0x41, 0xbd, 0xb7,
-	0x82, 0x7a, 0xf7, 0x47, 0x53, 0x05, 0xd1, 0xd3, 0x84, 0x09, 0x13, 0x07, 0xd7, 0x4a, 0x67, 0x22,
-	0x52, 0x3c, 0x58, 0xdd, 0xac, 0x3a, 0xe7, 0xfa, 0x09, 0x27, 0x1d, 0xfb, 0xf6, 0xe4, 0x2b, 0x00,
-	0x00, 0xff, 0xff, 0x34, 0x90, 0xd1, 0x5a, 0x1d, 0x02, 0x00, 0x00,
+	// 351 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0x4f, 0x4f, 0xfa, 0x30,
+	0x18, 0xc7, 0x37, 0x7e, 0xfc, 0xc0, 0x14, 0xf5, 0xd0, 0x18, 0x6d, 0x08, 0x0e, 0xf5, 0xe4, 0xa9,
+	0xf3, 0x4f, 0xa2, 0x9e, 0x3c, 0xa0, 0x18, 0x13, 0x3c, 0xed, 0xe8, 0x85, 0x94, 0xad, 0x81, 0x06,
+	0xd6, 0x92, 0xf5, 0xd1, 0x64, 0xef, 0xc2, 0x97, 0xc5, 0x91, 0xa3, 0x27, 0x63, 0xe0, 0xa2, 0xf1,
+	0x4d, 0x98, 0x75, 0x05, 0x64, 0x5c, 0x96, 0xa6, 0x9f, 0xef, 0xa7, 0xcf, 0xb3, 0x3e, 0x45, 0x75,
+	0xa5, 0x21, 0x61, 0xa1, 0x92, 0x3e, 0xa4, 0x63, 0xae, 0xfd, 0xde, 0x48, 0x85, 0x43, 0x3a, 0x4e,
+	0x14, 0x28, 0xbc, 0xbb, 0x60, 0xd4, 0xb0, 0xfa, 0x5e, 0x5f, 0xf5, 0x95, 0x41, 0x7e, 0xb6, 0xca,
+	0x53, 0xf5, 0xc3, 0xc2, 0x09, 0xfc, 0x55, 0x44, 0x5c, 0x86, 0xdc, 0xe2, 0xe3, 0x02, 0x1e, 0xf2,
+	0xb4, 0x9b, 0x28, 0x60, 0x20, 0x94, 0xb4, 0x91, 0x62, 0x0f, 0xe6, 0x6b, 0x59, 0x03, 0xb8, 0x8c,
+	0x78, 0x12, 0x0b, 0x09, 0x9b, 0xf4, 0xe4, 0xa7, 0x84, 0xfe, 0xb7, 0xb2, 0x8e, 0xf1, 0x15, 0xaa,
+	0x0c, 0x38, 0x8b, 0x78, 0x42, 0xdc, 0x23, 0xf7, 0xb4, 0x76, 0x41, 0xe8, 0x4a, 0xcc, 0xdb, 0xa7,
+	0x8f, 0x86, 0xb7, 0xca, 0x93, 0x8f, 0xa6, 0x13, 0xd8, 0x34, 0x3e, 0x43, 0xe5, 0x88, 0x01, 0x23,
+	0x25, 0x63, 0xed, 0x6f, 0x5a, 0xf7, 0x0c, 0x98, 0x75, 0x4c, 0x12, 0xdf, 0xa2, 0xad, 0xc5, 0x2f,
+	0x92, 0x7f, 0xc6, 0x6a, 0xd0, 0xf5, 0x8b, 0xa2, 0x6d, 0xcb, 0x9f, 0x84, 0x06, 0xeb, 0x2e, 0x1d,
+	0x7c, 0x8d, 0x6a, 0x23, 0xa6, 0xa1, 0x1b, 0xaa, 0x38, 0x16, 0x40, 0xca, 0xb6, 0x70, 0xe1, 0x88,
+	0x3b, 0x43, 0x03, 0x94, 0x45, 0xf3, 0x35, 0xbe, 0x41, 0x55, 0x2e, 0x21, 0x51, 0xe3, 0x94, 0x7c,
+	0x55, 0x8d, 0x75, 0xb0, 0x51, 0x38, 0xe7, 0xb6, 0xe6, 0x22, 0x8e, 0xdb, 0x68, 0xe7, 0xef, 0xb5,
+	0x6b, 0xf2, 0x9d, 0xfb, 0xcd, 0xa2, 0xdf, 0xe1, 0x69, 0x60, 0x43, 0x59, 0xef, 0xc1, 0xf6, 0x70,
+	0xb5, 0xa1, 0x5b, 0x9d, 0xc9, 0xcc, 0x73, 0xa7, 0x33, 0xcf, 0xfd, 0x9c, 0x79, 0xee, 0xdb, 0xdc,
+	0x73, 0xa6, 0x73, 0xcf, 0x79, 0x9f, 0x7b, 0xce, 0xf3, 0x79, 0x5f, 0xc0, 0xe0, 0xa5, 0x47, 0x43,
+	0x15, 0xfb, 0x0f, 0x42, 0xea, 0x70, 0x20, 0x98, 0xbf, 0x9c, 0x6a, 0xfe, 0x64, 0xd6, 0x87, 0xdc,
+	0xab, 0x98, 0xdd, 0xcb, 0xdf, 0x01, 0x00, 0xbe, 0x7b, 0x71, 0x83, 0x81, 0x02, 0x00, 0x00,
 }
 
 func (m *Block) Marshal() (dAtA []byte, err error) {
@@ -151,6 +161,20 @@ func (m *Block) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.KeyRotations != nil {
+		{
+			size, err := m.KeyRotations.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xca
+	}
 	{
 		size, err := m.Entropy.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -237,6 +261,10 @@ func (m *Block) Size() (n int) {
 	}
 	l = m.Entropy.Size()
 	n += 2 + l + sovBlock(uint64(l))
+	if m.KeyRotations != nil {
+		l = m.KeyRotations.Size()
+		n += 2 + l + sovBlock(uint64(l))
+	}
 	return n
 }
 
@@ -404,7 +432,7 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.LastCommit == nil {
-				m.LastCommit = &types.Commit{}
+				m.LastCommit = &Commit{}
 			}
 			if err := m.LastCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -443,6 +471,42 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 1001:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyRotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.KeyRotations == nil {
+				m.KeyRotations = &KeyRotationList{}
+			}
+			if err := m.KeyRotations.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlock(dAtA[iNdEx:])
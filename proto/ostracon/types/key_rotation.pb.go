@@ -0,0 +1,1189 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ostracon/types/key_rotation.proto
+
+package types
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	crypto "github.com/Finschia/ostracon/proto/ostracon/crypto"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// KeyRotation is a message signed by a validator to replace its consensus key by new_pub_key
+// from activation_height on.
+type KeyRotation struct {
+	ValidatorAddress []byte           `protobuf:"bytes,1,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	NewPubKey        crypto.PublicKey `protobuf:"bytes,2,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key"`
+	ActivationHeight int64            `protobuf:"varint,3,opt,name=activation_height,json=activationHeight,proto3" json:"activation_height,omitempty"`
+	Height           int64            `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp        time.Time        `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature        []byte           `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	NewKeySignature  []byte           `protobuf:"bytes,7,opt,name=new_key_signature,json=newKeySignature,proto3" json:"new_key_signature,omitempty"`
+}
+
+func (m *KeyRotation) Reset()         { *m = KeyRotation{} }
+func (m *KeyRotation) String() string { return proto.CompactTextString(m) }
+func (*KeyRotation) ProtoMessage()    {}
+func (*KeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ea86471968c8c08b, []int{0}
+}
+func (m *KeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyRotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyRotation.Merge(m, src)
+}
+func (m *KeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyRotation proto.InternalMessageInfo
+
+func (m *KeyRotation) GetValidatorAddress() []byte {
+	if m != nil {
+		return m.ValidatorAddress
+	}
+	return nil
+}
+
+func (m *KeyRotation) GetNewPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *KeyRotation) GetActivationHeight() int64 {
+	if m != nil {
+		return m.ActivationHeight
+	}
+	return 0
+}
+
+func (m *KeyRotation) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *KeyRotation) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *KeyRotation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *KeyRotation) GetNewKeySignature() []byte {
+	if m != nil {
+		return m.NewKeySignature
+	}
+	return nil
+}
+
+// CanonicalKeyRotation is the message that both the current and the new key of a KeyRotation sign.
+type CanonicalKeyRotation struct {
+	ValidatorAddress []byte           `protobuf:"bytes,1,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	NewPubKey        crypto.PublicKey `protobuf:"bytes,2,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key"`
+	ActivationHeight int64            `protobuf:"fixed64,3,opt,name=activation_height,json=activationHeight,proto3" json:"activation_height,omitempty"`
+	Height           int64            `protobuf:"fixed64,4,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp        time.Time        `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	ChainID          string           `protobuf:"bytes,6,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *CanonicalKeyRotation) Reset()         { *m = CanonicalKeyRotation{} }
+func (m *CanonicalKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*CanonicalKeyRotation) ProtoMessage()    {}
+func (*CanonicalKeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ea86471968c8c08b, []int{1}
+}
+func (m *CanonicalKeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CanonicalKeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CanonicalKeyRotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CanonicalKeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanonicalKeyRotation.Merge(m, src)
+}
+func (m *CanonicalKeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *CanonicalKeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanonicalKeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanonicalKeyRotation proto.InternalMessageInfo
+
+func (m *CanonicalKeyRotation) GetValidatorAddress() []byte {
+	if m != nil {
+		return m.ValidatorAddress
+	}
+	return nil
+}
+
+func (m *CanonicalKeyRotation) GetNewPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *CanonicalKeyRotation) GetActivationHeight() int64 {
+	if m != nil {
+		return m.ActivationHeight
+	}
+	return 0
+}
+
+func (m *CanonicalKeyRotation) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CanonicalKeyRotation) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *CanonicalKeyRotation) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
+// KeyRotationList is the list of key rotations of a block, and the message of the key rotation
+// channel.
+type KeyRotationList struct {
+	KeyRotations []*KeyRotation `protobuf:"bytes,1,rep,name=key_rotations,json=keyRotations,proto3" json:"key_rotations,omitempty"`
+}
+
+func (m *KeyRotationList) Reset()         { *m = KeyRotationList{} }
+func (m *KeyRotationList) String() string { return proto.CompactTextString(m) }
+func (*KeyRotationList) ProtoMessage()    {}
+func (*KeyRotationList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ea86471968c8c08b, []int{2}
+}
+func (m *KeyRotationList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyRotationList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyRotationList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyRotationList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyRotationList.Merge(m, src)
+}
+func (m *KeyRotationList) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyRotationList) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyRotationList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyRotationList proto.InternalMessageInfo
+
+func (m *KeyRotationList) GetKeyRotations() []*KeyRotation {
+	if m != nil {
+		return m.KeyRotations
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*KeyRotation)(nil), "ostracon.types.KeyRotation")
+	proto.RegisterType((*CanonicalKeyRotation)(nil), "ostracon.types.CanonicalKeyRotation")
+	proto.RegisterType((*KeyRotationList)(nil), "ostracon.types.KeyRotationList")
+}
+
+func init() { proto.RegisterFile("ostracon/types/key_rotation.proto", fileDescriptor_ea86471968c8c08b) }
+
+var fileDescriptor_ea86471968c8c08b = []byte{
+	// 464 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x18, 0x8c, 0x93, 0x92, 0x34, 0xeb, 0x42, 0x53, 0xab, 0x42, 0x56, 0x40, 0x4e, 0xc8, 0x01, 0x45,
+	0x20, 0xad, 0x45, 0x79, 0x81, 0xe2, 0x22, 0x44, 0x65, 0x0e, 0x95, 0xcb, 0x89, 0x8b, 0xb5, 0xb6,
+	0x17, 0x7b, 0x95, 0x64, 0xd7, 0xf2, 0xae, 0x1b, 0xf9, 0x2d, 0xfa, 0x34, 0x3c, 0x43, 0x6f, 0xf4,
+	0xc8, 0xa9, 0xa0, 0xe4, 0x45, 0xd0, 0xae, 0xff, 0x92, 0x13, 0x17, 0x0e, 0xbd, 0xf9, 0x9b, 0x99,
+	0xd5, 0x7c, 0xdf, 0x58, 0x03, 0x5e, 0x31, 0x2e, 0x32, 0x14, 0x32, 0x6a, 0x8b, 0x22, 0xc5, 0xdc,
+	0x5e, 0xe0, 0xc2, 0xcf, 0x98, 0x40, 0x82, 0x30, 0x0a, 0xd3, 0x8c, 0x09, 0x66, 0x3c, 0xab, 0x25,
+	0x50, 0x49, 0xc6, 0xa7, 0x31, 0x8b, 0x99, 0xa2, 0x6c, 0xf9, 0x55, 0xaa, 0xc6, 0x93, 0x98, 0xb1,
+	0x78, 0x89, 0x6d, 0x35, 0x05, 0xf9, 0x77, 0x5b, 0x90, 0x15, 0xe6, 0x02, 0xad, 0xd2, 0x4a, 0x30,
+	0x6e, 0x9c, 0xc2, 0xac, 0x48, 0x05, 0x93, 0x56, 0xbc, 0xe4, 0x66, 0x3f, 0xbb, 0x40, 0x77, 0x71,
+	0xe1, 0x55, 0xc6, 0xc6, 0x5b, 0x70, 0x72, 0x83, 0x96, 0x24, 0x42, 0x82, 0x65, 0x3e, 0x8a, 0xa2,
+	0x0c, 0x73, 0x6e, 0x6a, 0x53, 0x6d, 0x7e, 0xe4, 0x8d, 0x1a, 0xe2, 0x43, 0x89, 0x1b, 0xe7, 0x40,
+	0xa7, 0x78, 0xed, 0xa7, 0x79, 0xe0, 0x2f, 0x70, 0x61, 0x76, 0xa7, 0xda, 0x5c, 0x3f, 0x1b, 0xc3,
+	0x66, 0xeb, 0xd2, 0x0e, 0x5e, 0xe5, 0xc1, 0x92, 0x84, 0x2e, 0x2e, 0x9c, 0x83, 0xbb, 0x87, 0x49,
+	0xc7, 0x1b, 0x52, 0xbc, 0xbe, 0xca, 0x03, 0x17, 0x17, 0xd2, 0x0e, 0x85, 0x82, 0xdc, 0x28, 0x73,
+	0x3f, 0xc1, 0x24, 0x4e, 0x84, 0xd9, 0x9b, 0x6a, 0xf3, 0x9e, 0x37, 0x6a, 0x89, 0xcf, 0x0a, 0x37,
+	0x9e, 0x83, 0x7e, 0xa5, 0x38, 0x50, 0x8a, 0x6a, 0x32, 0x1c, 0x30, 0x6c, 0x4e, 0x36, 0x9f, 0x54,
+	0x4b, 0x94, 0xa1, 0xc0, 0x3a, 0x14, 0xf8, 0xb5, 0x56, 0x38, 0x87, 0x72, 0x89, 0xdb, 0xdf, 0x13,
+	0xcd, 0x6b, 0x9f, 0x19, 0x2f, 0xc1, 0x90, 0x93, 0x98, 0x22, 0x91, 0x67, 0xd8, 0xec, 0xab, 0x7b,
+	0x5b, 0xc0, 0x78, 0x03, 0x4e, 0xe4, 0xa1, 0xf2, 0x17, 0xb5, 0xaa, 0x81, 0x52, 0x1d, 0x53, 0xbc,
+	0x76, 0x71, 0x71, 0x5d, 0xc3, 0xb3, 0x1f, 0x5d, 0x70, 0x7a, 0x81, 0x28, 0xa3, 0x24, 0x44, 0xcb,
+	0xc7, 0x18, 0xed, 0xe8, 0x9f, 0xd1, 0x8e, 0xfe, 0x6b, 0xb4, 0xaf, 0xc1, 0x61, 0x98, 0x20, 0x42,
+	0x7d, 0x12, 0xa9, 0x64, 0x87, 0x8e, 0xbe, 0x79, 0x98, 0x0c, 0x2e, 0x24, 0x76, 0xf9, 0xd1, 0x1b,
+	0x28, 0xf2, 0x32, 0x9a, 0x5d, 0x83, 0xe3, 0x9d, 0xb8, 0xbe, 0x10, 0x2e, 0x8c, 0x73, 0xf0, 0x74,
+	0xb7, 0x16, 0x32, 0xae, 0xde, 0x5c, 0x3f, 0x7b, 0x01, 0xf7, 0x8b, 0x01, 0x77, 0xde, 0x79, 0x47,
+	0x8b, 0x76, 0xe0, 0x8e, 0x7b, 0xb7, 0xb1, 0xb4, 0xfb, 0x8d, 0xa5, 0xfd, 0xd9, 0x58, 0xda, 0xed,
+	0xd6, 0xea, 0xdc, 0x6f, 0xad, 0xce, 0xaf, 0xad, 0xd5, 0xf9, 0xf6, 0x2e, 0x26, 0x22, 0xc9, 0x03,
+	0x18, 0xb2, 0x95, 0xfd, 0x89, 0x50, 0x1e, 0x26, 0x04, 0xd9, 0x4d, 0x53, 0xca, 0x96, 0xed, 0x57,
+	0x34, 0xe8, 0x2b, 0xf4, 0xfd, 0xdf, 0x01, 0x00, 0x48, 0x4e, 0x70, 0x3e, 0xbb, 0x03, 0x00, 0x00,
+}
+
+func (m *KeyRotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyRotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NewKeySignature) > 0 {
+		i -= len(m.NewKeySignature)
+		copy(dAtA[i:], m.NewKeySignature)
+		i = encodeVarintKeyRotation(dAtA, i, uint64(len(m.NewKeySignature)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintKeyRotation(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintKeyRotation(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x2a
+	if m.Height != 0 {
+		i = encodeVarintKeyRotation(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ActivationHeight != 0 {
+		i = encodeVarintKeyRotation(dAtA, i, uint64(m.ActivationHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	{
+		size, err := m.NewPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintKeyRotation(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.ValidatorAddress) > 0 {
+		i -= len(m.ValidatorAddress)
+		copy(dAtA[i:], m.ValidatorAddress)
+		i = encodeVarintKeyRotation(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CanonicalKeyRotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CanonicalKeyRotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CanonicalKeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintKeyRotation(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x32
+	}
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintKeyRotation(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x2a
+	if m.Height != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Height))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.ActivationHeight != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.ActivationHeight))
+		i--
+		dAtA[i] = 0x19
+	}
+	{
+		size, err := m.NewPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintKeyRotation(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.ValidatorAddress) > 0 {
+		i -= len(m.ValidatorAddress)
+		copy(dAtA[i:], m.ValidatorAddress)
+		i = encodeVarintKeyRotation(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeyRotationList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyRotationList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyRotationList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyRotations) > 0 {
+		for iNdEx := len(m.KeyRotations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.KeyRotations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintKeyRotation(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintKeyRotation(dAtA []byte, offset int, v uint64) int {
+	offset -= sovKeyRotation(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *KeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovKeyRotation(uint64(l))
+	}
+	l = m.NewPubKey.Size()
+	n += 1 + l + sovKeyRotation(uint64(l))
+	if m.ActivationHeight != 0 {
+		n += 1 + sovKeyRotation(uint64(m.ActivationHeight))
+	}
+	if m.Height != 0 {
+		n += 1 + sovKeyRotation(uint64(m.Height))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovKeyRotation(uint64(l))
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovKeyRotation(uint64(l))
+	}
+	l = len(m.NewKeySignature)
+	if l > 0 {
+		n += 1 + l + sovKeyRotation(uint64(l))
+	}
+	return n
+}
+
+func (m *CanonicalKeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovKeyRotation(uint64(l))
+	}
+	l = m.NewPubKey.Size()
+	n += 1 + l + sovKeyRotation(uint64(l))
+	if m.ActivationHeight != 0 {
+		n += 9
+	}
+	if m.Height != 0 {
+		n += 9
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovKeyRotation(uint64(l))
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovKeyRotation(uint64(l))
+	}
+	return n
+}
+
+func (m *KeyRotationList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.KeyRotations) > 0 {
+		for _, e := range m.KeyRotations {
+			l = e.Size()
+			n += 1 + l + sovKeyRotation(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovKeyRotation(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozKeyRotation(x uint64) (n int) {
+	return sovKeyRotation(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *KeyRotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeyRotation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyRotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddress = append(m.ValidatorAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidatorAddress == nil {
+				m.ValidatorAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NewPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationHeight", wireType)
+			}
+			m.ActivationHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActivationHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewKeySignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewKeySignature = append(m.NewKeySignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewKeySignature == nil {
+				m.NewKeySignature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeyRotation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CanonicalKeyRotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeyRotation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CanonicalKeyRotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CanonicalKeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddress = append(m.ValidatorAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidatorAddress == nil {
+				m.ValidatorAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NewPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationHeight", wireType)
+			}
+			m.ActivationHeight = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationHeight = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Height = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeyRotation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyRotationList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeyRotation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyRotationList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyRotationList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyRotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyRotations = append(m.KeyRotations, &KeyRotation{})
+			if err := m.KeyRotations[len(m.KeyRotations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeyRotation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthKeyRotation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipKeyRotation(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowKeyRotation
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowKeyRotation
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthKeyRotation
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupKeyRotation
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthKeyRotation
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthKeyRotation        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowKeyRotation          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupKeyRotation = fmt.Errorf("proto: unexpected end of group")
+)
@@ -21,6 +21,7 @@ type AppConnConsensus interface {
 
 	BeginBlockSync(ocabci.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	DeliverTxAsync(types.RequestDeliverTx, abcicli.ResponseCallback) *abcicli.ReqRes
+	DeliverTxBatchSync(ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error)
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
 	CommitSync() (*types.ResponseCommit, error)
 }
@@ -91,6 +92,11 @@ func (app *appConnConsensus) DeliverTxAsync(req types.RequestDeliverTx, cb abcic
 	return app.appConn.DeliverTxAsync(req, cb)
 }
 
+func (app *appConnConsensus) DeliverTxBatchSync(
+	req ocabci.RequestDeliverTxBatch) (*ocabci.ResponseDeliverTxBatch, error) {
+	return app.appConn.DeliverTxBatchSync(req)
+}
+
 func (app *appConnConsensus) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
 	return app.appConn.EndBlockSync(req)
 }
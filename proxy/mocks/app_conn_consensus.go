@@ -84,6 +84,32 @@ func (_m *AppConnConsensus) DeliverTxAsync(_a0 abcitypes.RequestDeliverTx, _a1 a
 	return r0
 }
 
+// DeliverTxBatchSync provides a mock function with given fields: _a0
+func (_m *AppConnConsensus) DeliverTxBatchSync(_a0 types.RequestDeliverTxBatch) (*types.ResponseDeliverTxBatch, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponseDeliverTxBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(types.RequestDeliverTxBatch) (*types.ResponseDeliverTxBatch, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(types.RequestDeliverTxBatch) *types.ResponseDeliverTxBatch); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseDeliverTxBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.RequestDeliverTxBatch) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EndBlockSync provides a mock function with given fields: _a0
 func (_m *AppConnConsensus) EndBlockSync(_a0 abcitypes.RequestEndBlock) (*abcitypes.ResponseEndBlock, error) {
 	ret := _m.Called(_a0)
@@ -140,6 +140,19 @@ func TestBroadcastEvidence_DuplicateVoteEvidence(t *testing.T) {
 		err = client.WaitForHeight(c, status.SyncInfo.LatestBlockHeight+2, nil)
 		require.NoError(t, err)
 
+		search, err := c.EvidenceSearch(context.Background(), client.EvidenceFilter{
+			Address: pv.Key.Address, Type: "DUPLICATE_VOTE", MinHeight: correct.Height(), MaxHeight: correct.Height(),
+		}, nil, nil, "")
+		require.NoError(t, err)
+		committed := false
+		for _, res := range search.Evidence {
+			if bytes.Equal(res.Hash, correct.Hash()) {
+				assert.Greater(t, res.Height, correct.Height())
+				committed = true
+			}
+		}
+		assert.True(t, committed, "expected evidence to be committed")
+
 		ed25519pub := pv.Key.PubKey.(ed25519.PubKey)
 		rawpub := ed25519pub.Bytes()
 		publicKey, err := cryptoenc.PubKeyToProto(pv.Key.PubKey)
@@ -457,6 +457,21 @@ func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.Resu
 	return result, nil
 }
 
+// AggregatedCommit is like Commit, but the signatures of the validators with BLS12-381 keys are
+// aggregated into one signature, which makes the commit smaller and faster to verify.
+func (c *baseRPCClient) AggregatedCommit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	result := new(ctypes.ResultCommit)
+	params := map[string]interface{}{"aggregate": true}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "commit", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	result := new(ctypes.ResultTx)
 	params := map[string]interface{}{
@@ -565,6 +580,68 @@ func (c *baseRPCClient) BroadcastEvidence(
 	return result, nil
 }
 
+func (c *baseRPCClient) BroadcastKeyRotation(
+	ctx context.Context,
+	kr *types.KeyRotation,
+) (*ctypes.ResultBroadcastKeyRotation, error) {
+	result := new(ctypes.ResultBroadcastKeyRotation)
+	_, err := c.caller.Call(ctx, "broadcast_key_rotation", map[string]interface{}{"key_rotation": kr}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) PendingEvidence(
+	ctx context.Context,
+	filter rpcclient.EvidenceFilter,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error) {
+	return c.listEvidence(ctx, "pending_evidence", filter, page, perPage, orderBy)
+}
+
+func (c *baseRPCClient) EvidenceSearch(
+	ctx context.Context,
+	filter rpcclient.EvidenceFilter,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error) {
+	return c.listEvidence(ctx, "evidence_search", filter, page, perPage, orderBy)
+}
+
+func (c *baseRPCClient) listEvidence(
+	ctx context.Context,
+	method string,
+	filter rpcclient.EvidenceFilter,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error) {
+
+	result := new(ctypes.ResultEvidenceSearch)
+	params := map[string]interface{}{
+		"address":    filter.Address,
+		"type":       filter.Type,
+		"min_height": filter.MinHeight,
+		"max_height": filter.MaxHeight,
+		"order_by":   orderBy,
+	}
+
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+
+	_, err := c.caller.Call(ctx, method, params, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 //-----------------------------------------------------------------------------
 // WSEvents
 
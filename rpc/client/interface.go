@@ -41,6 +41,7 @@ type Client interface {
 	SignClient
 	StatusClient
 	EvidenceClient
+	KeyRotationClient
 	MempoolClient
 }
 
@@ -139,9 +140,33 @@ type MempoolClient interface {
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
-// behaviour.
+// behaviour, and for inspecting the evidence known to the node.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+
+	// PendingEvidence defines a method to list a paginated set of the evidence
+	// that's not committed yet.
+	PendingEvidence(
+		ctx context.Context,
+		filter EvidenceFilter,
+		page, perPage *int,
+		orderBy string,
+	) (*ctypes.ResultEvidenceSearch, error)
+
+	// EvidenceSearch defines a method to search for a paginated set of the
+	// evidence committed in blocks.
+	EvidenceSearch(
+		ctx context.Context,
+		filter EvidenceFilter,
+		page, perPage *int,
+		orderBy string,
+	) (*ctypes.ResultEvidenceSearch, error)
+}
+
+// KeyRotationClient is used for submitting the rotation of the consensus key
+// of a validator.
+type KeyRotationClient interface {
+	BroadcastKeyRotation(context.Context, *types.KeyRotation) (*ctypes.ResultBroadcastKeyRotation, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
@@ -170,7 +170,7 @@ func (c *Local) BlockResults(ctx context.Context, height *int64) (*ctypes.Result
 }
 
 func (c *Local) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	return core.Commit(c.ctx, height)
+	return core.Commit(c.ctx, height, false)
 }
 
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
@@ -205,6 +205,33 @@ func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctyp
 	return core.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) BroadcastKeyRotation(
+	ctx context.Context,
+	kr *types.KeyRotation,
+) (*ctypes.ResultBroadcastKeyRotation, error) {
+	return core.BroadcastKeyRotation(c.ctx, kr)
+}
+
+func (c *Local) PendingEvidence(
+	_ context.Context,
+	filter rpcclient.EvidenceFilter,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error) {
+	return core.PendingEvidence(c.ctx, filter.Address, filter.Type, filter.MinHeight, filter.MaxHeight,
+		page, perPage, orderBy)
+}
+
+func (c *Local) EvidenceSearch(
+	_ context.Context,
+	filter rpcclient.EvidenceFilter,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error) {
+	return core.EvidenceSearch(c.ctx, filter.Address, filter.Type, filter.MinHeight, filter.MaxHeight,
+		page, perPage, orderBy)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
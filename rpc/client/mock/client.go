@@ -39,6 +39,7 @@ type Client struct {
 	client.StatusClient
 	client.EventsClient
 	client.EvidenceClient
+	client.KeyRotationClient
 	client.MempoolClient
 	service.Service
 }
@@ -167,7 +168,7 @@ func (c Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlo
 }
 
 func (c Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	return core.Commit(&rpctypes.Context{}, height)
+	return core.Commit(&rpctypes.Context{}, height, false)
 }
 
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
@@ -177,3 +178,22 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
+
+func (c Client) BroadcastKeyRotation(
+	ctx context.Context,
+	kr *types.KeyRotation,
+) (*ctypes.ResultBroadcastKeyRotation, error) {
+	return core.BroadcastKeyRotation(&rpctypes.Context{}, kr)
+}
+
+func (c Client) PendingEvidence(ctx context.Context, filter client.EvidenceFilter, page, perPage *int,
+	orderBy string) (*ctypes.ResultEvidenceSearch, error) {
+	return core.PendingEvidence(&rpctypes.Context{}, filter.Address, filter.Type, filter.MinHeight, filter.MaxHeight,
+		page, perPage, orderBy)
+}
+
+func (c Client) EvidenceSearch(ctx context.Context, filter client.EvidenceFilter, page, perPage *int,
+	orderBy string) (*ctypes.ResultEvidenceSearch, error) {
+	return core.EvidenceSearch(&rpctypes.Context{}, filter.Address, filter.Type, filter.MinHeight, filter.MaxHeight,
+		page, perPage, orderBy)
+}
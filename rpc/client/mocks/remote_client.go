@@ -256,6 +256,32 @@ func (_m *RemoteClient) BroadcastEvidence(_a0 context.Context, _a1 types.Evidenc
 	return r0, r1
 }
 
+// BroadcastKeyRotation provides a mock function with given fields: _a0, _a1
+func (_m *RemoteClient) BroadcastKeyRotation(_a0 context.Context, _a1 *types.KeyRotation) (*coretypes.ResultBroadcastKeyRotation, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *coretypes.ResultBroadcastKeyRotation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.KeyRotation) (*coretypes.ResultBroadcastKeyRotation, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.KeyRotation) *coretypes.ResultBroadcastKeyRotation); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastKeyRotation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.KeyRotation) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BroadcastTxAsync provides a mock function with given fields: _a0, _a1
 func (_m *RemoteClient) BroadcastTxAsync(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	ret := _m.Called(_a0, _a1)
@@ -464,6 +490,32 @@ func (_m *RemoteClient) DumpConsensusState(_a0 context.Context) (*coretypes.Resu
 	return r0, r1
 }
 
+// EvidenceSearch provides a mock function with given fields: ctx, filter, page, perPage, orderBy
+func (_m *RemoteClient) EvidenceSearch(ctx context.Context, filter client.EvidenceFilter, page *int, perPage *int, orderBy string) (*coretypes.ResultEvidenceSearch, error) {
+	ret := _m.Called(ctx, filter, page, perPage, orderBy)
+
+	var r0 *coretypes.ResultEvidenceSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.EvidenceFilter, *int, *int, string) (*coretypes.ResultEvidenceSearch, error)); ok {
+		return rf(ctx, filter, page, perPage, orderBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.EvidenceFilter, *int, *int, string) *coretypes.ResultEvidenceSearch); ok {
+		r0 = rf(ctx, filter, page, perPage, orderBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidenceSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.EvidenceFilter, *int, *int, string) error); ok {
+		r1 = rf(ctx, filter, page, perPage, orderBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *RemoteClient) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -641,6 +693,32 @@ func (_m *RemoteClient) OnStop() {
 	_m.Called()
 }
 
+// PendingEvidence provides a mock function with given fields: ctx, filter, page, perPage, orderBy
+func (_m *RemoteClient) PendingEvidence(ctx context.Context, filter client.EvidenceFilter, page *int, perPage *int, orderBy string) (*coretypes.ResultEvidenceSearch, error) {
+	ret := _m.Called(ctx, filter, page, perPage, orderBy)
+
+	var r0 *coretypes.ResultEvidenceSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.EvidenceFilter, *int, *int, string) (*coretypes.ResultEvidenceSearch, error)); ok {
+		return rf(ctx, filter, page, perPage, orderBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.EvidenceFilter, *int, *int, string) *coretypes.ResultEvidenceSearch); ok {
+		r0 = rf(ctx, filter, page, perPage, orderBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidenceSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.EvidenceFilter, *int, *int, string) error); ok {
+		r1 = rf(ctx, filter, page, perPage, orderBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Quit provides a mock function with given fields:
 func (_m *RemoteClient) Quit() <-chan struct{} {
 	ret := _m.Called()
@@ -9,3 +9,15 @@ type ABCIQueryOptions struct {
 
 // DefaultABCIQueryOptions are latest height (0) and prove false.
 var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
+
+// EvidenceFilter selects the evidence listed by PendingEvidence and
+// EvidenceSearch. Fields with zero values match any evidence.
+type EvidenceFilter struct {
+	// Address of a validator accused by the evidence
+	Address []byte
+	// Type is DUPLICATE_VOTE, LIGHT_CLIENT_ATTACK or DUPLICATE_PROPOSAL
+	Type string
+	// MinHeight and MaxHeight bound the heights of the misbehavior
+	MinHeight int64
+	MaxHeight int64
+}
@@ -106,8 +106,9 @@ func BlockByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlock, error
 
 // Commit gets block commit at a given height.
 // If no height is provided, it will fetch the commit for the latest block.
+// If aggregate is true, the signatures of the validators with BLS12-381 keys are aggregated.
 // More: https://docs.tendermint.com/master/rpc/#/Info/commit
-func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, error) {
+func Commit(ctx *rpctypes.Context, heightPtr *int64, aggregate bool) (*ctypes.ResultCommit, error) {
 	height, err := getHeight(env.BlockStore.Height(), heightPtr)
 	if err != nil {
 		return nil, err
@@ -123,14 +124,40 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 	// use a non-canonical commit
 	if height == env.BlockStore.Height() {
 		commit := env.BlockStore.LoadSeenCommit(height)
+		if aggregate {
+			commit = aggregateCommit(commit)
+		}
 		return ctypes.NewResultCommit(&header, commit, false), nil
 	}
 
 	// Return the canonical commit (comes from the block at height+1)
 	commit := env.BlockStore.LoadBlockCommit(height)
+	if aggregate {
+		commit = aggregateCommit(commit)
+	}
 	return ctypes.NewResultCommit(&header, commit, true), nil
 }
 
+// aggregateCommit aggregates the signatures of the validators with BLS12-381 keys in the commit,
+// which makes it smaller and faster to verify for light clients. The commit is returned as is if
+// its validators are unknown.
+func aggregateCommit(commit *types.Commit) *types.Commit {
+	if commit == nil {
+		return nil
+	}
+	vals, err := env.StateStore.LoadValidators(commit.Height)
+	if err != nil {
+		env.Logger.Debug("Commit not aggregated: failed to load validators", "height", commit.Height, "err", err)
+		return commit
+	}
+	aggregated, err := commit.AggregateSignatures(vals)
+	if err != nil {
+		env.Logger.Error("Failed to aggregate commit", "height", commit.Height, "err", err)
+		return commit
+	}
+	return aggregated
+}
+
 // BlockResults gets ABCIResults at a given height.
 // If no height is provided, it will fetch results for the latest block.
 //
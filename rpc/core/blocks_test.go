@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
@@ -11,10 +12,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
 	cfg "github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	"github.com/Finschia/ostracon/libs/log"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	tmstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	ctypes "github.com/Finschia/ostracon/rpc/core/types"
@@ -125,6 +130,44 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestCommitAggregate(t *testing.T) {
+	const height = int64(3)
+	privVals := make([]types.PrivValidator, 0, 4)
+	vals := make([]*types.Validator, 0, 4)
+	for i := 0; i < 4; i++ {
+		privVal := types.NewMockPVWithParams(bls12381.GenPrivKey(), false, false)
+		privVals = append(privVals, privVal)
+		vals = append(vals, privVal.ExtractIntoValidator(10))
+	}
+	sort.Sort(types.PrivValidatorsByAddress(privVals))
+	valSet := types.NewValidatorSet(vals)
+	blockID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)}}
+	voteSet := types.NewVoteSet("test_chain_id", height, 0, tmproto.PrecommitType, valSet)
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+
+	env = &Environment{Logger: log.TestingLogger()}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, env.StateStore.Bootstrap(sm.State{
+		LastBlockHeight: height - 1,
+		Validators:      valSet,
+		NextValidators:  valSet,
+		LastProofHash:   tmrand.Bytes(tmhash.Size),
+	}))
+	env.BlockStore = commitBlockStore{mockBlockStore: mockBlockStore{height: height + 1}, commit: commit}
+
+	h := height
+	res, err := Commit(&rpctypes.Context{}, &h, false)
+	require.NoError(t, err)
+	assert.Equal(t, commit, res.Commit)
+
+	res, err = Commit(&rpctypes.Context{}, &h, true)
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Commit.AggregatedSignature)
+	assert.NoError(t, valSet.VerifyCommit("test_chain_id", blockID, height, res.Commit))
+}
+
 func TestBlockSearchByBlockHeightQuery(t *testing.T) {
 	height := int64(1)
 	ctx := &rpctypes.Context{}
@@ -321,6 +364,18 @@ type mockBlockStore struct {
 	height int64
 }
 
+// commitBlockStore is a mockBlockStore with the same commit at every height.
+type commitBlockStore struct {
+	mockBlockStore
+	commit *types.Commit
+}
+
+func (store commitBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return &types.BlockMeta{Header: types.Header{Height: height}}
+}
+func (store commitBlockStore) LoadBlockCommit(height int64) *types.Commit { return store.commit }
+func (store commitBlockStore) LoadSeenCommit(height int64) *types.Commit  { return store.commit }
+
 func (mockBlockStore) Base() int64                                       { return 1 }
 func (store mockBlockStore) Height() int64                               { return store.height }
 func (store mockBlockStore) Size() int64                                 { return store.height }
@@ -334,3 +389,6 @@ func (mockBlockStore) LoadSeenCommit(height int64) *types.Commit         { retur
 func (mockBlockStore) PruneBlocks(height int64) (uint64, error)          { return 0, nil }
 func (mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
+func (mockBlockStore) SaveSignedHeader(sh *types.SignedHeader, blockID types.BlockID) error {
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	cfg "github.com/Finschia/ostracon/config"
 	"github.com/Finschia/ostracon/consensus"
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/evidence"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	"github.com/Finschia/ostracon/libs/log"
 	mempl "github.com/Finschia/ostracon/mempool"
@@ -70,6 +71,16 @@ type peers interface {
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
+	PeerTrustScore(p2p.ID) (int, bool)
+	BanPeers(target string, duration time.Duration, reason string) (*p2p.Ban, error)
+	AllowPeers(target string, reason string) (*p2p.Ban, error)
+	UnbanPeers(target string) error
+	Bans() ([]*p2p.Ban, error)
+}
+
+type evidenceLister interface {
+	ListPendingEvidence(filter evidence.Filter, page evidence.Page) ([]types.Evidence, int, error)
+	ListCommittedEvidence(filter evidence.Filter, page evidence.Page) ([]evidence.CommittedEvidence, int, error)
 }
 
 //----------------------------------------------
@@ -81,12 +92,14 @@ type Environment struct {
 	ProxyAppMempool proxy.AppConnMempool
 
 	// interfaces defined in types and above
-	StateStore     sm.Store
-	BlockStore     sm.BlockStore
-	EvidencePool   sm.EvidencePool
-	ConsensusState Consensus
-	P2PPeers       peers
-	P2PTransport   transport
+	StateStore      sm.Store
+	BlockStore      sm.BlockStore
+	EvidencePool    sm.EvidencePool
+	EvidenceLister  evidenceLister
+	KeyRotationPool sm.KeyRotationPool
+	ConsensusState  Consensus
+	P2PPeers        peers
+	P2PTransport    transport
 
 	// objects
 	PubKey           crypto.PubKey
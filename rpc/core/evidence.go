@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/Finschia/ostracon/evidence"
 	ctypes "github.com/Finschia/ostracon/rpc/core/types"
 	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
 	"github.com/Finschia/ostracon/types"
@@ -25,3 +26,119 @@ func BroadcastEvidence(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.Result
 	}
 	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
+
+// PendingEvidence returns the evidence in the evidence pool that's not committed yet, from the
+// oldest misbehavior to the newest, optionally filtered by the address of an accused validator,
+// the type of the evidence and the range of heights of the misbehavior.
+func PendingEvidence(
+	ctx *rpctypes.Context,
+	address []byte,
+	evType string,
+	minHeight, maxHeight int64,
+	pagePtr, perPagePtr *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error) {
+	filter, err := evidenceFilter(address, evType, minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	page, err := evidencePage(pagePtr, perPagePtr, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, totalCount, err := env.EvidenceLister.ListPendingEvidence(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validatePage(pagePtr, page.Limit, totalCount); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ctypes.ResultEvidence, 0, len(pending))
+	for _, ev := range pending {
+		results = append(results, &ctypes.ResultEvidence{Evidence: ev, Hash: ev.Hash()})
+	}
+	return &ctypes.ResultEvidenceSearch{Evidence: results, TotalCount: totalCount}, nil
+}
+
+// EvidenceSearch returns the evidence committed in blocks, from the oldest misbehavior to the
+// newest, optionally filtered by the address of an accused validator, the type of the evidence
+// and the range of heights of the misbehavior.
+func EvidenceSearch(
+	ctx *rpctypes.Context,
+	address []byte,
+	evType string,
+	minHeight, maxHeight int64,
+	pagePtr, perPagePtr *int,
+	orderBy string,
+) (*ctypes.ResultEvidenceSearch, error) {
+	filter, err := evidenceFilter(address, evType, minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	page, err := evidencePage(pagePtr, perPagePtr, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	committed, totalCount, err := env.EvidenceLister.ListCommittedEvidence(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validatePage(pagePtr, page.Limit, totalCount); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ctypes.ResultEvidence, 0, len(committed))
+	for _, c := range committed {
+		results = append(results, &ctypes.ResultEvidence{Evidence: c.Evidence, Hash: c.Evidence.Hash(), Height: c.Height})
+	}
+	return &ctypes.ResultEvidenceSearch{Evidence: results, TotalCount: totalCount}, nil
+}
+
+// evidenceTypes are the names of the evidence types accepted by the evidence filters.
+var evidenceTypes = map[string]bool{
+	evidence.TypeDuplicateVote:     true,
+	evidence.TypeLightClientAttack: true,
+	evidence.TypeDuplicateProposal: true,
+}
+
+func evidenceFilter(address []byte, evType string, minHeight, maxHeight int64) (evidence.Filter, error) {
+	filter := evidence.Filter{Address: address, MinHeight: minHeight, MaxHeight: maxHeight}
+	if evType != "" {
+		if !evidenceTypes[evType] {
+			return filter, fmt.Errorf("unknown evidence type %q, expected one of "+
+				"DUPLICATE_VOTE, LIGHT_CLIENT_ATTACK or DUPLICATE_PROPOSAL", evType)
+		}
+		filter.Type = evType
+	}
+	if minHeight < 0 || maxHeight < 0 {
+		return filter, errors.New("heights must be non negative")
+	}
+	if maxHeight > 0 && minHeight > maxHeight {
+		return filter, fmt.Errorf("min height %d can't be greater than max height %d", minHeight, maxHeight)
+	}
+	return filter, nil
+}
+
+// evidencePage returns the page of evidence to list. The page number is checked against the
+// count of the evidence once it's listed.
+func evidencePage(pagePtr, perPagePtr *int, orderBy string) (evidence.Page, error) {
+	page := evidence.Page{Limit: validatePerPage(perPagePtr)}
+	// evidence is listed in ascending order of the heights of the misbehavior by default
+	switch orderBy {
+	case "desc":
+		page.Desc = true
+	case "asc", "":
+	default:
+		return page, errors.New("expected order_by to be either `asc` or `desc` or empty")
+	}
+	if pagePtr != nil {
+		if *pagePtr <= 0 {
+			return page, fmt.Errorf("page should be greater than 0, given %d", *pagePtr)
+		}
+		page.Skip = validateSkipCount(*pagePtr, page.Limit)
+	}
+	return page, nil
+}
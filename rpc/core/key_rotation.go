@@ -0,0 +1,29 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	ctypes "github.com/Finschia/ostracon/rpc/core/types"
+	rpctypes "github.com/Finschia/ostracon/rpc/jsonrpc/types"
+	"github.com/Finschia/ostracon/types"
+)
+
+// BroadcastKeyRotation broadcasts the rotation of the consensus key of a validator.
+func BroadcastKeyRotation(
+	ctx *rpctypes.Context,
+	kr *types.KeyRotation,
+) (*ctypes.ResultBroadcastKeyRotation, error) {
+	if kr == nil {
+		return nil, errors.New("no key rotation was provided")
+	}
+
+	if err := kr.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("keyRotation.ValidateBasic failed: %w", err)
+	}
+
+	if err := env.KeyRotationPool.AddKeyRotation(kr); err != nil {
+		return nil, fmt.Errorf("failed to add key rotation: %w", err)
+	}
+	return &ctypes.ResultBroadcastKeyRotation{Hash: kr.Hash()}, nil
+}
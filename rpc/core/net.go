@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Finschia/ostracon/p2p"
 	ctypes "github.com/Finschia/ostracon/rpc/core/types"
@@ -20,12 +21,16 @@ func NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 		if !ok {
 			return nil, fmt.Errorf("peer.NodeInfo() is not DefaultNodeInfo")
 		}
-		peers = append(peers, ctypes.Peer{
+		p := ctypes.Peer{
 			NodeInfo:         nodeInfo,
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.Status(),
 			RemoteIP:         peer.RemoteIP().String(),
-		})
+		}
+		if score, ok := env.P2PPeers.PeerTrustScore(peer.ID()); ok {
+			p.TrustScore = &score
+		}
+		peers = append(peers, p)
 	}
 	// TODO: Should we include PersistentPeers and Seeds in here?
 	// PRO: useful info
@@ -91,6 +96,55 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, uncondit
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeBanPeer bans a node ID, an IP address or an IP range in CIDR notation
+// for the given duration (e.g. 24h), or forever if it's empty, and disconnects
+// from the peers it matches.
+func UnsafeBanPeer(ctx *rpctypes.Context, target, duration, reason string) (*ctypes.ResultBan, error) {
+	var d time.Duration
+	if duration != "" {
+		var err error
+		if d, err = time.ParseDuration(duration); err != nil {
+			return nil, fmt.Errorf("can't parse duration: %w", err)
+		}
+	}
+	env.Logger.Info("BanPeer", "target", target, "duration", d, "reason", reason)
+	ban, err := env.P2PPeers.BanPeers(target, d, reason)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBan{Ban: ban}, nil
+}
+
+// UnsafeAllowPeer allows a node ID, an IP address or an IP range in CIDR
+// notation regardless of the bans, and exempts it from automatic bans.
+func UnsafeAllowPeer(ctx *rpctypes.Context, target, reason string) (*ctypes.ResultBan, error) {
+	env.Logger.Info("AllowPeer", "target", target, "reason", reason)
+	ban, err := env.P2PPeers.AllowPeers(target, reason)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBan{Ban: ban}, nil
+}
+
+// UnsafeUnbanPeer removes the ban or allowance of a node ID, an IP address or
+// an IP range in CIDR notation.
+func UnsafeUnbanPeer(ctx *rpctypes.Context, target string) (*ctypes.ResultUnbanPeer, error) {
+	env.Logger.Info("UnbanPeer", "target", target)
+	if err := env.P2PPeers.UnbanPeers(target); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnbanPeer{}, nil
+}
+
+// UnsafeListBans returns the bans and allowances which haven't expired.
+func UnsafeListBans(ctx *rpctypes.Context) (*ctypes.ResultListBans, error) {
+	bans, err := env.P2PPeers.Bans()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultListBans{Bans: bans}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
@@ -23,7 +23,7 @@ var Routes = map[string]*rpc.RPCFunc{
 	"block":                rpc.NewRPCFunc(Block, "height"),
 	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash"),
 	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
-	"commit":               rpc.NewRPCFunc(Commit, "height"),
+	"commit":               rpc.NewRPCFunc(Commit, "height,aggregate"),
 	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
 	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
 	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by"),
@@ -46,6 +46,11 @@ var Routes = map[string]*rpc.RPCFunc{
 
 	// evidence API
 	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"pending_evidence":   rpc.NewRPCFunc(PendingEvidence, "address,type,min_height,max_height,page,per_page,order_by"),
+	"evidence_search":    rpc.NewRPCFunc(EvidenceSearch, "address,type,min_height,max_height,page,per_page,order_by"),
+
+	// key rotation API
+	"broadcast_key_rotation": rpc.NewRPCFunc(BroadcastKeyRotation, "key_rotation"),
 }
 
 // AddUnsafeRoutes adds unsafe routes.
@@ -54,4 +59,8 @@ func AddUnsafeRoutes() {
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["ban_peer"] = rpc.NewRPCFunc(UnsafeBanPeer, "target,duration,reason")
+	Routes["allow_peer"] = rpc.NewRPCFunc(UnsafeAllowPeer, "target,reason")
+	Routes["unban_peer"] = rpc.NewRPCFunc(UnsafeUnbanPeer, "target")
+	Routes["list_bans"] = rpc.NewRPCFunc(UnsafeListBans, "")
 }
@@ -126,12 +126,28 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Result of banning or allowing a peer
+type ResultBan struct {
+	Ban *p2p.Ban `json:"ban"`
+}
+
+// Result of unbanning a peer
+type ResultUnbanPeer struct{}
+
+// List of bans and allowances
+type ResultListBans struct {
+	Bans []*p2p.Ban `json:"bans"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	// TrustScore is the trust score of the peer between 0 and 100, if the node
+	// tracks the trust metrics of its peers.
+	TrustScore *int `json:"trust_score,omitempty"`
 }
 
 // ResultValidators for a height
@@ -237,6 +253,24 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of broadcasting a key rotation
+type ResultBroadcastKeyRotation struct {
+	Hash []byte `json:"hash"`
+}
+
+// Result of querying for evidence
+type ResultEvidence struct {
+	Evidence types.Evidence `json:"evidence"`
+	Hash     bytes.HexBytes `json:"hash"`
+	Height   int64          `json:"height"` // height of the block that committed the evidence, 0 if pending
+}
+
+// Result of listing pending evidence or searching for committed evidence
+type ResultEvidenceSearch struct {
+	Evidence   []*ResultEvidence `json:"evidence"`
+	TotalCount int               `json:"total_count"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
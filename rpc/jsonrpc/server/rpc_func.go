@@ -20,6 +20,12 @@ func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger lo
 	}
 
 	// JSONRPC endpoints
+	RegisterJSONRPCFuncs(mux, funcMap, logger)
+}
+
+// RegisterJSONRPCFuncs adds the general jsonrpc handler for all functions, without the
+// per-function HTTP endpoints, which also take their arguments from GET requests.
+func RegisterJSONRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
 	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
 }
 
@@ -1,6 +1,10 @@
 package state
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/Finschia/ostracon/types"
+)
 
 type (
 	ErrInvalidBlock error
@@ -41,6 +45,11 @@ type (
 		Height int64
 	}
 
+	ErrNoValidatorForHeight struct {
+		Height  int64
+		Address types.Address
+	}
+
 	ErrNoProofHashForHeight struct {
 		Height int64
 	}
@@ -96,6 +105,10 @@ func (e ErrNoValSetForHeight) Error() string {
 	return fmt.Sprintf("could not find validator set for height #%d", e.Height)
 }
 
+func (e ErrNoValidatorForHeight) Error() string {
+	return fmt.Sprintf("could not find validator %X for height #%d", e.Address, e.Height)
+}
+
 func (e ErrNoProofHashForHeight) Error() string {
 	return fmt.Sprintf("could not find proof hash for height #%d", e.Height)
 }
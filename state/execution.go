@@ -11,7 +11,6 @@ import (
 	ocabci "github.com/Finschia/ostracon/abci/types"
 	"github.com/Finschia/ostracon/crypto"
 	cryptoenc "github.com/Finschia/ostracon/crypto/encoding"
-	"github.com/Finschia/ostracon/crypto/vrf"
 	"github.com/Finschia/ostracon/libs/fail"
 	"github.com/Finschia/ostracon/libs/log"
 	mempl "github.com/Finschia/ostracon/mempool"
@@ -41,10 +40,14 @@ type BlockExecutor struct {
 	// and update both with block results after commit.
 	mempool mempl.Mempool
 	evpool  EvidencePool
+	krpool  KeyRotationPool
 
 	logger log.Logger
 
 	metrics *Metrics
+
+	// deliver the txs of a block with a single DeliverTxBatch request
+	deliverTxBatch bool
 }
 
 type CommitStepTimes struct {
@@ -79,6 +82,22 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithDeliverTxBatch makes the executor send all txs of a block to
+// the app at once, along with the tx hints kept in the mempool.
+func BlockExecutorWithDeliverTxBatch(enabled bool) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.deliverTxBatch = enabled
+	}
+}
+
+// BlockExecutorWithKeyRotationPool makes the executor propose the key rotations of krpool, and
+// update it with the committed ones. Without it, the executor proposes no key rotation.
+func BlockExecutorWithKeyRotationPool(krpool KeyRotationPool) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.krpool = krpool
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -95,6 +114,7 @@ func NewBlockExecutor(
 		eventBus: types.NopEventBus{},
 		mempool:  mempool,
 		evpool:   evpool,
+		krpool:   EmptyKeyRotationPool{},
 		logger:   logger,
 		metrics:  NopMetrics(),
 	}
@@ -119,6 +139,7 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
+// Key rotations from the krpool fill the space evidence leaves.
 // The rest is given to txs, up to the max gas.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
@@ -133,13 +154,20 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxGas := state.ConsensusParams.Block.MaxGas
 
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	keyRotations, krSize := blockExec.krpool.PendingKeyRotations(state.ConsensusParams.Evidence.MaxBytes - evSize)
 
 	// Fetch a limited amount of valid txs
-	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
+	maxDataBytes := types.MaxDataBytesForValidators(maxBytes, evSize+krSize, state.Validators)
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGasMaxTxs(maxDataBytes, maxGas, maxTxs)
 
-	return state.MakeBlock(height, txs, commit, evidence, proposerAddr, round, proof)
+	block, partSet := state.MakeBlock(height, txs, commit, evidence, proposerAddr, round, proof)
+	if len(keyRotations) > 0 {
+		// the key rotations are part of the block parts
+		block.KeyRotations = keyRotations
+		partSet = block.MakePartSet(types.BlockPartSizeBytes)
+	}
+	return block, partSet
 }
 
 // ValidateBlock validates the given block against the given state.
@@ -170,9 +198,14 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, 0, ErrInvalidBlock(err)
 	}
 
+	var txHints []ocabci.TxHint
+	if blockExec.deliverTxBatch {
+		txHints = blockExec.mempool.TxHints(block.Txs)
+	}
+
 	execStartTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight, txHints,
 	)
 	execEndTime := time.Now().UnixNano()
 
@@ -209,7 +242,8 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	}
 
 	// Update the state with the block and responses.
-	state, err = updateState(state, blockID, &block.Header, &block.Entropy, abciResponses, validatorUpdates)
+	state, err = updateState(state, blockID, &block.Header, &block.Entropy, block.KeyRotations, abciResponses,
+		validatorUpdates)
 	if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
@@ -233,6 +267,9 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	// Update evpool with the latest state.
 	blockExec.evpool.Update(state, block.Evidence.Evidence)
 
+	// Update krpool with the latest state.
+	blockExec.krpool.Update(state, block.KeyRotations)
+
 	fail.Fail() // XXX
 
 	// Update the app hash and save the state.
@@ -336,13 +373,16 @@ func (blockExec *BlockExecutor) Commit(
 // Helper functions for executing blocks and updating state
 
 // Executes block's transactions on proxyAppConn.
-// Returns a list of transaction results and updates to the validator set
+// Returns a list of transaction results and updates to the validator set.
+// If txHints is non-nil, the transactions are delivered with a single DeliverTxBatch
+// request and txHints must have one entry per transaction.
 func execBlockOnProxyApp(
 	logger log.Logger,
 	proxyAppConn proxy.AppConnConsensus,
 	block *types.Block,
 	store Store,
 	initialHeight int64,
+	txHints []ocabci.TxHint,
 ) (*tmstate.ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
@@ -351,22 +391,25 @@ func execBlockOnProxyApp(
 	dtxs := make([]*abci.ResponseDeliverTx, len(block.Txs))
 	abciResponses.DeliverTxs = dtxs
 
+	deliverTxCb := func(txRes *abci.ResponseDeliverTx) {
+		// TODO: make use of res.Log
+		// TODO: make use of this info
+		// Blocks may include invalid txs.
+		if txRes.Code == ocabci.CodeTypeOK {
+			validTxs++
+		} else {
+			logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
+			invalidTxs++
+		}
+
+		abciResponses.DeliverTxs[txIndex] = txRes
+		txIndex++
+	}
+
 	// Execute transactions and get hash.
 	proxyCb := func(req *ocabci.Request, res *ocabci.Response) {
 		if r, ok := res.Value.(*ocabci.Response_DeliverTx); ok {
-			// TODO: make use of res.Log
-			// TODO: make use of this info
-			// Blocks may include invalid txs.
-			txRes := r.DeliverTx
-			if txRes.Code == ocabci.CodeTypeOK {
-				validTxs++
-			} else {
-				logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
-				invalidTxs++
-			}
-
-			abciResponses.DeliverTxs[txIndex] = txRes
-			txIndex++
+			deliverTxCb(r.DeliverTx)
 		}
 	}
 	proxyAppConn.SetGlobalCallback(proxyCb)
@@ -404,11 +447,33 @@ func execBlockOnProxyApp(
 
 	startTime := time.Now()
 	// run txs of block
-	for _, tx := range block.Txs {
-		proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx}, nil)
-		if err := proxyAppConn.Error(); err != nil {
+	if txHints != nil {
+		reqs := make([]abci.RequestDeliverTx, len(block.Txs))
+		for i, tx := range block.Txs {
+			reqs[i] = abci.RequestDeliverTx{Tx: tx}
+		}
+		res, err := proxyAppConn.DeliverTxBatchSync(ocabci.RequestDeliverTxBatch{Txs: reqs, Hints: txHints})
+		if err != nil {
+			logger.Error("error in proxyAppConn.DeliverTxBatch", "err", err)
 			return nil, err
 		}
+		if len(res.DeliverTxs) != len(block.Txs) {
+			return nil, fmt.Errorf("expected %d results from DeliverTxBatch, got %d",
+				len(block.Txs), len(res.DeliverTxs))
+		}
+		for i, txRes := range res.DeliverTxs {
+			if txRes == nil {
+				return nil, fmt.Errorf("missing result for tx #%d in DeliverTxBatch", i)
+			}
+			deliverTxCb(txRes)
+		}
+	} else {
+		for _, tx := range block.Txs {
+			proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx}, nil)
+			if err := proxyAppConn.Error(); err != nil {
+				return nil, err
+			}
+		}
 	}
 	endTime := time.Now()
 	execTime := endTime.Sub(startTime)
@@ -500,6 +565,7 @@ func updateState(
 	blockID types.BlockID,
 	header *types.Header,
 	entropy *types.Entropy,
+	keyRotations types.KeyRotationList,
 	abciResponses *tmstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
 ) (State, error) {
@@ -508,8 +574,18 @@ func updateState(
 	// and update s.LastValidators and s.Validators.
 	nValSet := state.NextValidators.Copy()
 
-	// Update the validator set with the latest abciResponses.
+	// Record the key rotations of the block. They have been verified against the
+	// next validator set, which they may take effect from the height of at the earliest.
 	lastHeightValsChanged := state.LastHeightValidatorsChanged
+	for _, kr := range keyRotations {
+		err := nValSet.RotateKey(kr.ValidatorAddress, kr.NewPubKey, kr.ActivationHeight)
+		if err != nil {
+			return state, fmt.Errorf("error rotating validator key: %v", err)
+		}
+		lastHeightValsChanged = header.Height + 1 + 1
+	}
+
+	// Update the validator set with the latest abciResponses.
 	if len(validatorUpdates) > 0 {
 		err := nValSet.UpdateWithChangeSet(validatorUpdates)
 		if err != nil {
@@ -519,6 +595,16 @@ func updateState(
 		lastHeightValsChanged = header.Height + 1 + 1
 	}
 
+	// Switch to the keys that are valid from the next next height.
+	if nValSet.ActivateKeys(header.Height + 1 + 1) {
+		lastHeightValsChanged = header.Height + 1 + 1
+	}
+	// Forget the keys replaced before the evidence age, which the set would otherwise
+	// accumulate and carry in its hash, every light block and every stored set.
+	if nValSet.PruneKeyHistory(header.Height+1+1, state.ConsensusParams.Evidence.MaxAgeNumBlocks) {
+		lastHeightValsChanged = header.Height + 1 + 1
+	}
+
 	// Update validator proposer priority and set state variables.
 	nValSet.IncrementProposerPriority(1)
 
@@ -542,7 +628,11 @@ func updateState(
 	nextVersion := state.Version
 
 	// get proof hash from vrf proof
-	proofHash, err := vrf.ProofToHash(entropy.Proof.Bytes())
+	_, proposer := state.Validators.GetByAddress(header.ProposerAddress)
+	if proposer == nil {
+		return state, fmt.Errorf("proposer %X is not in the validator set", header.ProposerAddress)
+	}
+	proofHash, err := types.ProofToHash(proposer.PubKey, crypto.Proof(entropy.Proof))
 	if err != nil {
 		return state, fmt.Errorf("error get proof of hash: %v", err)
 	}
@@ -637,7 +727,7 @@ func ExecCommitBlock(
 	store Store,
 	initialHeight int64,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight, nil)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
@@ -32,7 +32,21 @@ func UpdateState(
 	abciResponses *tmstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
 ) (State, error) {
-	return updateState(state, blockID, header, entropy, abciResponses, validatorUpdates)
+	return updateState(state, blockID, header, entropy, nil, abciResponses, validatorUpdates)
+}
+
+// UpdateStateWithKeyRotations is an alias for updateState exported from execution.go,
+// exclusively and explicitly for testing.
+func UpdateStateWithKeyRotations(
+	state State,
+	blockID types.BlockID,
+	header *types.Header,
+	entropy *types.Entropy,
+	keyRotations types.KeyRotationList,
+	abciResponses *tmstate.ABCIResponses,
+	validatorUpdates []*types.Validator,
+) (State, error) {
+	return updateState(state, blockID, header, entropy, keyRotations, abciResponses, validatorUpdates)
 }
 
 // ValidateValidatorUpdates is an alias for validateValidatorUpdates exported
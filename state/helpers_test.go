@@ -141,8 +141,12 @@ func makeState(nVals, height int) (sm.State, dbm.DB, map[string]types.PrivValida
 	return s, stateDB, privVals
 }
 
+// makeBlock makes a block of the proposer of the state, whose key is unknown, so the VRF proof of
+// the block is made by another key.
 func makeBlock(state sm.State, height int64) *types.Block {
-	return makeBlockWithPrivVal(state, makePrivVal(), height)
+	block := makeBlockWithPrivVal(state, makePrivVal(), height)
+	block.ProposerAddress = state.Validators.SelectProposer(state.LastProofHash, height, 0).Address
+	return block
 }
 
 func makeBlockWithPrivVal(state sm.State, privVal types.PrivValidator, height int64) *types.Block {
@@ -255,6 +259,7 @@ type testApp struct {
 	CommitVotes         []abci.VoteInfo
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+	TxHints             []ocabci.TxHint
 }
 
 var _ ocabci.Application = (*testApp)(nil)
@@ -282,6 +287,16 @@ func (app *testApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx
 	return abci.ResponseDeliverTx{Events: []abci.Event{}}
 }
 
+func (app *testApp) DeliverTxBatch(req ocabci.RequestDeliverTxBatch) ocabci.ResponseDeliverTxBatch {
+	app.TxHints = req.Hints
+	res := ocabci.ResponseDeliverTxBatch{DeliverTxs: make([]*abci.ResponseDeliverTx, len(req.Txs))}
+	for i, tx := range req.Txs {
+		r := app.DeliverTx(tx)
+		res.DeliverTxs[i] = &r
+	}
+	return res
+}
+
 func (app *testApp) CheckTxSync(req abci.RequestCheckTx) ocabci.ResponseCheckTx {
 	return ocabci.ResponseCheckTx{}
 }
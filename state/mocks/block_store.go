@@ -182,6 +182,20 @@ func (_m *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	_m.Called(block, blockParts, seenCommit)
 }
 
+// SaveSignedHeader provides a mock function with given fields: sh, blockID
+func (_m *BlockStore) SaveSignedHeader(sh *types.SignedHeader, blockID types.BlockID) error {
+	ret := _m.Called(sh, blockID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*types.SignedHeader, types.BlockID) error); ok {
+		r0 = rf(sh, blockID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Size provides a mock function with given fields:
 func (_m *BlockStore) Size() int64 {
 	ret := _m.Called()
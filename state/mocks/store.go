@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	crypto "github.com/Finschia/ostracon/crypto"
+
 	ostraconstate "github.com/Finschia/ostracon/proto/ostracon/state"
 	ostracontypes "github.com/Finschia/ostracon/types"
 	mock "github.com/stretchr/testify/mock"
@@ -193,6 +195,32 @@ func (_m *Store) LoadProofHash(_a0 int64) ([]byte, error) {
 	return r0, r1
 }
 
+// LoadValidatorKey provides a mock function with given fields: _a0, _a1
+func (_m *Store) LoadValidatorKey(_a0 int64, _a1 ostracontypes.Address) (crypto.PubKey, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 crypto.PubKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, ostracontypes.Address) (crypto.PubKey, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(int64, ostracontypes.Address) crypto.PubKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(crypto.PubKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, ostracontypes.Address) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: _a0
 func (_m *Store) LoadValidators(_a0 int64) (*ostracontypes.ValidatorSet, error) {
 	ret := _m.Called(_a0)
@@ -261,6 +289,34 @@ func (_m *Store) SaveABCIResponses(_a0 int64, _a1 *ostraconstate.ABCIResponses)
 	return r0
 }
 
+// SaveConsensusParams provides a mock function with given fields: _a0, _a1
+func (_m *Store) SaveConsensusParams(_a0 int64, _a1 types.ConsensusParams) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, types.ConsensusParams) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveValidatorSets provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) SaveValidatorSets(_a0 int64, _a1 int64, _a2 *ostracontypes.ValidatorSet) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, *ostracontypes.ValidatorSet) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewStore interface {
 	mock.TestingT
 	Cleanup(func())
@@ -25,6 +25,7 @@ type BlockStore interface {
 	LoadBlock(height int64) *types.Block
 
 	SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit)
+	SaveSignedHeader(sh *types.SignedHeader, blockID types.BlockID) error
 
 	PruneBlocks(height int64) (uint64, error)
 
@@ -59,3 +60,24 @@ func (EmptyEvidencePool) AddEvidence(types.Evidence) error                { retu
 func (EmptyEvidencePool) Update(State, types.EvidenceList)                {}
 func (EmptyEvidencePool) CheckEvidence(evList types.EvidenceList) error   { return nil }
 func (EmptyEvidencePool) ReportConflictingVotes(voteA, voteB *types.Vote) {}
+func (EmptyEvidencePool) ReportConflictingProposals(proposalA, proposalB *types.Proposal, proposer types.Address) {
+}
+
+//------------------------------------------------------
+// keyrotation
+
+// KeyRotationPool defines the KeyRotationPool interface used by State.
+type KeyRotationPool interface {
+	PendingKeyRotations(maxBytes int64) (krs types.KeyRotationList, size int64)
+	AddKeyRotation(*types.KeyRotation) error
+	Update(State, types.KeyRotationList)
+}
+
+// EmptyKeyRotationPool is an empty implementation of KeyRotationPool, useful for testing.
+type EmptyKeyRotationPool struct{}
+
+func (EmptyKeyRotationPool) PendingKeyRotations(maxBytes int64) (krs types.KeyRotationList, size int64) {
+	return nil, 0
+}
+func (EmptyKeyRotationPool) AddKeyRotation(*types.KeyRotation) error { return nil }
+func (EmptyKeyRotationPool) Update(State, types.KeyRotationList)     {}
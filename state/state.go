@@ -156,20 +156,20 @@ func (state *State) ToProto() (*ocstate.State, error) {
 
 	sm.LastBlockID = state.LastBlockID.ToProto()
 	sm.LastBlockTime = state.LastBlockTime
-	vals, err := state.Validators.ToProto()
+	vals, err := state.Validators.ToOCProto()
 	if err != nil {
 		return nil, err
 	}
 	sm.Validators = vals
 
-	nVals, err := state.NextValidators.ToProto()
+	nVals, err := state.NextValidators.ToOCProto()
 	if err != nil {
 		return nil, err
 	}
 	sm.NextValidators = nVals
 
 	if state.LastBlockHeight >= 1 { // At Block 1 LastValidators is nil
-		lVals, err := state.LastValidators.ToProto()
+		lVals, err := state.LastValidators.ToOCProto()
 		if err != nil {
 			return nil, err
 		}
@@ -207,20 +207,20 @@ func FromProto(pb *ocstate.State) (*State, error) { //nolint:golint
 	state.LastBlockHeight = pb.LastBlockHeight
 	state.LastBlockTime = pb.LastBlockTime
 
-	vals, err := types.ValidatorSetFromProto(pb.Validators)
+	vals, err := types.ValidatorSetFromOCProto(pb.Validators)
 	if err != nil {
 		return nil, err
 	}
 	state.Validators = vals
 
-	nVals, err := types.ValidatorSetFromProto(pb.NextValidators)
+	nVals, err := types.ValidatorSetFromOCProto(pb.NextValidators)
 	if err != nil {
 		return nil, err
 	}
 	state.NextValidators = nVals
 
 	if state.LastBlockHeight >= 1 { // At Block 1 LastValidators is nil
-		lVals, err := types.ValidatorSetFromProto(pb.LastValidators)
+		lVals, err := types.ValidatorSetFromOCProto(pb.LastValidators)
 		if err != nil {
 			return nil, err
 		}
@@ -18,6 +18,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	cfg "github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	cryptoenc "github.com/Finschia/ostracon/crypto/encoding"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
@@ -1023,6 +1024,101 @@ func TestManyValidatorChangesSaveLoad(t *testing.T) {
 	}
 }
 
+// TestKeyRotationSaveLoad tests saving and loading a validator set in which a
+// validator rotated its key.
+func TestKeyRotationSaveLoad(t *testing.T) {
+	tearDown, stateDB, state := setupTestCase(t)
+	defer tearDown(t)
+	stateStore := sm.NewStore(stateDB)
+	require.Equal(t, int64(0), state.LastBlockHeight)
+	privKey, newPrivKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	address := privKey.PubKey().Address()
+	state.Validators = types.NewValidatorSet([]*types.Validator{
+		types.NewValidator(privKey.PubKey(), 10),
+		types.NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+	})
+	state.NextValidators = state.Validators.Copy()
+	err := stateStore.Save(state)
+	require.NoError(t, err)
+
+	// the rotation committed at 1 takes effect from 4 on
+	kr, err := types.NewKeyRotation(state.ChainID, address, privKey, newPrivKey, 4, 1, tmtime.Now())
+	require.NoError(t, err)
+	header, entropy, blockID, responses := makeHeaderPartsResponsesValPowerChange(state, 10)
+	state, err = sm.UpdateStateWithKeyRotations(state, blockID, &header, &entropy, types.KeyRotationList{kr},
+		responses, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, state.LastHeightValidatorsChanged)
+	err = stateStore.Save(state)
+	require.NoError(t, err)
+
+	header, entropy, blockID, responses = makeHeaderPartsResponsesValPowerChange(state, 10)
+	state, err = sm.UpdateState(state, blockID, &header, &entropy, responses, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, state.LastHeightValidatorsChanged)
+	err = stateStore.Save(state)
+	require.NoError(t, err)
+
+	// the validator keeps its address
+	for height, pubKey := range map[int64]crypto.PubKey{2: privKey.PubKey(), 3: privKey.PubKey(),
+		4: newPrivKey.PubKey()} {
+		loaded, err := stateStore.LoadValidatorKey(height, address)
+		require.NoError(t, err)
+		assert.Equal(t, pubKey, loaded, "height %d", height)
+	}
+	_, err = stateStore.LoadValidatorKey(4, newPrivKey.PubKey().Address())
+	assert.Equal(t, sm.ErrNoValidatorForHeight{Height: 4, Address: newPrivKey.PubKey().Address()}, err)
+
+	// the announced key is known before it takes effect
+	vals, err := stateStore.LoadValidators(3)
+	require.NoError(t, err)
+	_, val := vals.GetByAddress(address)
+	assert.Equal(t, newPrivKey.PubKey(), val.PubKeyAt(4))
+}
+
+// TestKeyRotationPruneKeyHistory tests that the keys replaced before the evidence
+// age are removed from the validator set.
+func TestKeyRotationPruneKeyHistory(t *testing.T) {
+	tearDown, _, state := setupTestCase(t)
+	defer tearDown(t)
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 2
+	privKeys := []crypto.PrivKey{ed25519.GenPrivKey(), ed25519.GenPrivKey(), ed25519.GenPrivKey()}
+	address := privKeys[0].PubKey().Address()
+	state.Validators = types.NewValidatorSet([]*types.Validator{
+		types.NewValidator(privKeys[0].PubKey(), 10),
+		types.NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+	})
+	state.NextValidators = state.Validators.Copy()
+
+	// the key rotates at 4 and 5
+	for i, activationHeight := range []int64{4, 5} {
+		kr, err := types.NewKeyRotation(state.ChainID, address, privKeys[i], privKeys[i+1], activationHeight,
+			state.LastBlockHeight+1, tmtime.Now())
+		require.NoError(t, err)
+		header, entropy, blockID, responses := makeHeaderPartsResponsesValPowerChange(state, 10)
+		state, err = sm.UpdateStateWithKeyRotations(state, blockID, &header, &entropy, types.KeyRotationList{kr},
+			responses, nil)
+		require.NoError(t, err)
+	}
+
+	// the first key is kept, the second one, last valid at 4, until 7
+	for height := int64(3); height <= 5; height++ {
+		header, entropy, blockID, responses := makeHeaderPartsResponsesValPowerChange(state, 10)
+		var err error
+		state, err = sm.UpdateState(state, blockID, &header, &entropy, responses, nil)
+		require.NoError(t, err)
+		_, val := state.NextValidators.GetByAddress(address)
+		assert.Equal(t, privKeys[2].PubKey(), val.PubKey)
+		if height < 5 {
+			assert.Len(t, val.KeyHistory, 3, "height %d", height)
+		} else {
+			assert.Equal(t, []types.ValidatorKey{{PubKey: privKeys[0].PubKey(), Height: 0},
+				{PubKey: privKeys[2].PubKey(), Height: 5}}, val.KeyHistory)
+			assert.EqualValues(t, 7, state.LastHeightValidatorsChanged)
+		}
+	}
+}
+
 func TestStateMakeBlock(t *testing.T) {
 	tearDown, _, state := setupTestCase(t)
 	defer tearDown(t)
@@ -10,6 +10,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/Finschia/ostracon/crypto"
 	tmmath "github.com/Finschia/ostracon/libs/math"
 	tmos "github.com/Finschia/ostracon/libs/os"
 	ocstate "github.com/Finschia/ostracon/proto/ostracon/state"
@@ -61,6 +62,8 @@ type Store interface {
 	Load() (State, error)
 	// LoadValidators loads the validator set at a given height
 	LoadValidators(int64) (*types.ValidatorSet, error)
+	// LoadValidatorKey loads the key of the validator with the given address at a given height
+	LoadValidatorKey(int64, types.Address) (crypto.PubKey, error)
 	// LoadProofHash loads the proof hash at a given height
 	LoadProofHash(int64) ([]byte, error)
 	// LoadABCIResponses loads the abciResponse for a given height
@@ -71,6 +74,12 @@ type Store interface {
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *ocstate.ABCIResponses) error
+	// SaveValidatorSets saves the validator set for each height between the given heights inclusive,
+	// e.g. when restoring historical data with state sync
+	SaveValidatorSets(int64, int64, *types.ValidatorSet) error
+	// SaveConsensusParams saves the consensus params for a given height, e.g. when restoring
+	// historical data with state sync
+	SaveConsensusParams(int64, tmproto.ConsensusParams) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
 	// PruneStates takes the height from which to start prning and which height stop at
@@ -277,7 +286,7 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 					return err
 				}
 
-				pvi, err := vip.ToProto()
+				pvi, err := vip.ToOCProto()
 				if err != nil {
 					return err
 				}
@@ -452,13 +461,13 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 				)
 		}
 
-		vs, err := types.ValidatorSetFromProto(valInfo2.ValidatorSet)
+		vs, err := types.ValidatorSetFromOCProto(valInfo2.ValidatorSet)
 		if err != nil {
 			return nil, err
 		}
 
 		vs.IncrementProposerPriority(tmmath.SafeConvertInt32(height - lastStoredHeight)) // mutate
-		vi2, err := vs.ToProto()
+		vi2, err := vs.ToOCProto()
 		if err != nil {
 			return nil, err
 		}
@@ -467,7 +476,7 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 		valInfo = valInfo2
 	}
 
-	vip, err := types.ValidatorSetFromProto(valInfo.ValidatorSet)
+	vip, err := types.ValidatorSetFromOCProto(valInfo.ValidatorSet)
 	if err != nil {
 		return nil, err
 	}
@@ -475,6 +484,21 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// LoadValidatorKey loads the key that the validator with address had at height. Its
+// address stays the same when the validator rotates its key.
+// Returns ErrNoValidatorForHeight if the validator wasn't in the validator set of the height.
+func (store dbStore) LoadValidatorKey(height int64, address types.Address) (crypto.PubKey, error) {
+	vals, err := store.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	_, val := vals.GetByAddress(address)
+	if val == nil {
+		return nil, ErrNoValidatorForHeight{Height: height, Address: address}
+	}
+	return val.PubKey, nil
+}
+
 func (store dbStore) LoadProofHash(height int64) ([]byte, error) {
 	if height == 0 {
 		return nil, ErrNoValSetForHeight{height}
@@ -487,13 +511,28 @@ func (store dbStore) LoadProofHash(height int64) ([]byte, error) {
 	return proofHash, nil
 }
 
+// SaveValidatorSets persists the given validator set for every height from lowerHeight to
+// upperHeight inclusive. Each height stores the full set, so that the proposer priorities are
+// not recalculated on load.
+func (store dbStore) SaveValidatorSets(lowerHeight, upperHeight int64, vals *types.ValidatorSet) error {
+	if lowerHeight <= 0 || lowerHeight > upperHeight {
+		return fmt.Errorf("invalid height range %v to %v", lowerHeight, upperHeight)
+	}
+	for height := lowerHeight; height <= upperHeight; height++ {
+		if err := store.saveValidatorsInfo(height, height, vals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return tmmath.MaxInt64(checkpointHeight, lastHeightChanged)
 }
 
 // CONTRACT: Returned ValidatorsInfo can be mutated.
-func loadValidatorsInfo(db dbm.DB, height int64) (*tmstate.ValidatorsInfo, error) {
+func loadValidatorsInfo(db dbm.DB, height int64) (*ocstate.ValidatorsInfo, error) {
 	buf, err := db.Get(calcValidatorsKey(height))
 	if err != nil {
 		return nil, err
@@ -503,7 +542,7 @@ func loadValidatorsInfo(db dbm.DB, height int64) (*tmstate.ValidatorsInfo, error
 		return nil, errors.New("loadValidatorsInfo: value retrieved from db is empty")
 	}
 
-	v := new(tmstate.ValidatorsInfo)
+	v := new(ocstate.ValidatorsInfo)
 	err = v.Unmarshal(buf)
 	if err != nil {
 		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
@@ -524,13 +563,13 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 	if lastHeightChanged > height {
 		return errors.New("lastHeightChanged cannot be greater than ValidatorsInfo height")
 	}
-	valInfo := &tmstate.ValidatorsInfo{
+	valInfo := &ocstate.ValidatorsInfo{
 		LastHeightChanged: lastHeightChanged,
 	}
 	// Only persist validator set if it was updated or checkpoint height (see
 	// valSetCheckpointInterval) is reached.
 	if height == lastHeightChanged || height%valSetCheckpointInterval == 0 {
-		pv, err := valSet.ToProto()
+		pv, err := valSet.ToOCProto()
 		if err != nil {
 			return err
 		}
@@ -646,6 +685,14 @@ func (store dbStore) saveConsensusParamsInfo(nextHeight, changeHeight int64, par
 	return nil
 }
 
+// SaveConsensusParams persists the consensus params for the given height.
+func (store dbStore) SaveConsensusParams(height int64, params tmproto.ConsensusParams) error {
+	if height <= 0 {
+		return fmt.Errorf("height %v must be greater than 0", height)
+	}
+	return store.saveConsensusParamsInfo(height, height, params)
+}
+
 func (store dbStore) Close() error {
 	return store.db.Close()
 }
@@ -57,6 +57,37 @@ func TestStoreLoadValidators(t *testing.T) {
 	assert.NotZero(t, loadedVals.Size())
 }
 
+func TestStoreSaveValidatorSets(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	val, _ := types.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+
+	require.Error(t, stateStore.SaveValidatorSets(0, 3, vals))
+	require.Error(t, stateStore.SaveValidatorSets(3, 2, vals))
+
+	require.NoError(t, stateStore.SaveValidatorSets(2, 4, vals))
+	for h := int64(2); h <= 4; h++ {
+		loadedVals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err)
+		assert.Equal(t, vals.Hash(), loadedVals.Hash())
+	}
+	_, err := stateStore.LoadValidators(1)
+	require.Error(t, err)
+}
+
+func TestStoreSaveConsensusParams(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	params := types.DefaultConsensusParams()
+	params.Block.MaxBytes = 1000
+
+	require.Error(t, stateStore.SaveConsensusParams(0, *params))
+
+	require.NoError(t, stateStore.SaveConsensusParams(3, *params))
+	loadedParams, err := stateStore.LoadConsensusParams(3)
+	require.NoError(t, err)
+	assert.Equal(t, *params, loadedParams)
+}
+
 func BenchmarkLoadValidators(b *testing.B) {
 	const valSetSize = 100
 
@@ -318,11 +349,11 @@ func sliceToMap(s []int64) map[int64]bool {
 }
 
 func validatorsInfoToByte(height, lastHeightChanged int64, valSet *types.ValidatorSet) ([]byte, error) {
-	valInfo := &tmstate.ValidatorsInfo{
+	valInfo := &ocstate.ValidatorsInfo{
 		LastHeightChanged: lastHeightChanged,
 	}
 	if height == lastHeightChanged || height%valSetCheckpointInterval == 0 {
-		pv, err := valSet.ToProto()
+		pv, err := valSet.ToOCProto()
 		if err != nil {
 			return nil, err
 		}
@@ -8,9 +8,9 @@ import (
 // TxPreCheck returns a function to filter transactions before processing.
 // The function limits the size of a transaction to the block's maximum data size.
 func TxPreCheck(state State) mempl.PreCheckFunc {
-	maxDataBytes := types.MaxDataBytesNoEvidence(
+	maxDataBytes := types.MaxDataBytesNoEvidenceForValidators(
 		state.ConsensusParams.Block.MaxBytes,
-		state.Validators.Size(),
+		state.Validators,
 	)
 	return mempl.PreCheckMaxBytes(maxDataBytes)
 }
@@ -9,7 +9,6 @@ import (
 
 	dbm "github.com/tendermint/tm-db"
 
-	"github.com/Finschia/ostracon/crypto/vrf"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
@@ -26,8 +25,8 @@ func TestTxFilter(t *testing.T) {
 		tx    types.Tx
 		isErr bool
 	}{
-		{types.Tx(tmrand.Bytes(2178 - vrf.ProofSize)), false},
-		{types.Tx(tmrand.Bytes(2189 - vrf.ProofSize)), true},
+		{types.Tx(tmrand.Bytes(2178 - types.MaxProofSize)), false},
+		{types.Tx(tmrand.Bytes(2189 - types.MaxProofSize)), true},
 		{types.Tx(tmrand.Bytes(3000)), true},
 	}
 
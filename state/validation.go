@@ -147,6 +147,18 @@ func validateBlock(state State, round int32, block *types.Block) error {
 		return types.NewErrEvidenceOverflow(max, got)
 	}
 
+	// Key rotations share the limit of evidence, and are verified against the next validator set,
+	// which they're recorded in.
+	if max, got := state.ConsensusParams.Evidence.MaxBytes-block.Evidence.ByteSize(),
+		block.KeyRotations.ByteSize(); got > max {
+		return fmt.Errorf("key rotations are too big; got %d bytes, max %d", got, max)
+	}
+	for i, kr := range block.KeyRotations {
+		if err := VerifyKeyRotation(state, kr); err != nil {
+			return fmt.Errorf("invalid key rotation (#%d): %w", i, err)
+		}
+	}
+
 	// validate round
 	// The block round must be less than or equal to the current round
 	// If some proposer proposes his ValidBlock as a proposal, then the proposal block round is less than current round
@@ -175,3 +187,57 @@ func validateBlock(state State, round int32, block *types.Block) error {
 
 	return nil
 }
+
+// VerifyKeyRotation verifies a KeyRotation against state, before the next block is applied. This
+// involves the following checks:
+//   - the rotation refers to a committed block, and hasn't expired like evidence of that block
+//   - the validator is in the next validator set, which the rotation is recorded in
+//   - the new key takes effect after the next validator set, and after the last key the validator
+//     announced
+//   - the new key is of a type the validator params allow and not used by any validator
+//   - the signatures of the last key the validator announced and of the new key are valid
+func VerifyKeyRotation(state State, kr *types.KeyRotation) error {
+	var (
+		height         = state.LastBlockHeight
+		evidenceParams = state.ConsensusParams.Evidence
+		valSet         = state.NextValidators
+	)
+
+	if kr.BlockHeight > height || kr.Timestamp.After(state.LastBlockTime) {
+		return fmt.Errorf("key rotation refers to block %d at %v, after the last block %d at %v",
+			kr.BlockHeight, kr.Timestamp, height, state.LastBlockTime)
+	}
+	if height-kr.BlockHeight > evidenceParams.MaxAgeNumBlocks &&
+		state.LastBlockTime.Sub(kr.Timestamp) > evidenceParams.MaxAgeDuration {
+		return fmt.Errorf("key rotation from height %d (created at: %v) is too old; min height is %d and it "+
+			"can not be older than %v", kr.BlockHeight, kr.Timestamp, height-evidenceParams.MaxAgeNumBlocks,
+			state.LastBlockTime.Add(-evidenceParams.MaxAgeDuration))
+	}
+
+	_, val := valSet.GetByAddress(kr.ValidatorAddress)
+	if val == nil {
+		return fmt.Errorf("address %X is not a validator", kr.ValidatorAddress)
+	}
+
+	if minHeight := height + 3; kr.ActivationHeight < minHeight {
+		return fmt.Errorf("activation height %d is too low; min height is %d", kr.ActivationHeight, minHeight)
+	}
+	if n := len(val.KeyHistory); n > 0 && kr.ActivationHeight <= val.KeyHistory[n-1].Height {
+		return fmt.Errorf("activation height %d is not after the one of the last key %d",
+			kr.ActivationHeight, val.KeyHistory[n-1].Height)
+	}
+
+	if !types.IsValidPubkeyType(state.ConsensusParams.Validator, kr.NewPubKey.Type()) {
+		return fmt.Errorf("new key type %s is not allowed", kr.NewPubKey.Type())
+	}
+	if _, v := valSet.GetByAddress(kr.NewPubKey.Address()); v != nil {
+		return fmt.Errorf("new key %v is the one of validator %X", kr.NewPubKey, v.Address)
+	}
+	for _, v := range valSet.Validators {
+		if v.HasPubKey(kr.NewPubKey) {
+			return fmt.Errorf("new key %v is used by validator %X", kr.NewPubKey, v.Address)
+		}
+	}
+
+	return kr.Verify(state.ChainID, val.LatestPubKey())
+}
@@ -12,6 +12,8 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	"github.com/Finschia/ostracon/libs/log"
@@ -355,3 +357,142 @@ func TestValidateBlockEntropy(t *testing.T) {
 		require.NoError(t, err, "height %d", height)
 	}
 }
+
+type testKeyRotationPool struct {
+	pending   types.KeyRotationList
+	committed types.KeyRotationList
+}
+
+func (pool *testKeyRotationPool) PendingKeyRotations(maxBytes int64) (types.KeyRotationList, int64) {
+	return pool.pending, pool.pending.ByteSize()
+}
+
+func (pool *testKeyRotationPool) AddKeyRotation(kr *types.KeyRotation) error {
+	pool.pending = append(pool.pending, kr)
+	return nil
+}
+
+func (pool *testKeyRotationPool) Update(state sm.State, krs types.KeyRotationList) {
+	pool.committed = krs
+}
+
+func TestValidateBlockKeyRotations(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(2, 1)
+	stateStore := sm.NewStore(stateDB)
+	krpool := &testKeyRotationPool{}
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		memmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithKeyRotationPool(krpool),
+	)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	proposerAddr := state.Validators.SelectProposer(state.LastProofHash, 1, 0).Address
+	state, _, lastCommit, err := makeAndCommitGoodBlock(state, 1, lastCommit, proposerAddr, blockExec, privVals, nil)
+	require.NoError(t, err)
+
+	privKey, newPrivKey := ed25519.GenPrivKeyFromSecret([]byte("test0")), ed25519.GenPrivKey()
+	address := privKey.PubKey().Address()
+	makeBlock := func(activationHeight int64) *types.Block {
+		kr, err := types.NewKeyRotation(chainID, address, privKey, newPrivKey, activationHeight, 1,
+			state.LastBlockTime)
+		require.NoError(t, err)
+		krpool.pending = types.KeyRotationList{kr}
+
+		proposerAddr := state.Validators.SelectProposer(state.LastProofHash, 2, 0).Address
+		proof, err := privVals[proposerAddr.String()].GenerateVRFProof(state.MakeHashMessage(0))
+		require.NoError(t, err)
+		block, _ := blockExec.CreateProposalBlock(2, state, lastCommit, proposerAddr, 0, proof, 0)
+		require.Equal(t, krpool.pending, block.KeyRotations)
+		return block
+	}
+
+	// the rotation committed at 2 can't take effect before 4
+	err = blockExec.ValidateBlock(state, 0, makeBlock(3))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "activation height 3 is too low")
+	}
+
+	block := makeBlock(4)
+	require.NoError(t, blockExec.ValidateBlock(state, 0, block))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header()}
+	state, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.NoError(t, err)
+	assert.Equal(t, block.KeyRotations, krpool.committed)
+	_, val := state.NextValidators.GetByAddress(address)
+	assert.Equal(t, newPrivKey.PubKey(), val.PubKeyAt(4))
+}
+
+func TestVerifyKeyRotation(t *testing.T) {
+	val := types.NewMockPV()
+	val2 := types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1), val2.ExtractIntoValidator(1)})
+	address := val.PrivKey.PubKey().Address()
+	newKey := ed25519.GenPrivKey()
+	blockTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the rotation is committed at 12 at the earliest, so it can take effect from 14 on
+	makeKeyRotation := func(address types.Address, privKey, newPrivKey crypto.PrivKey,
+		activationHeight int64) *types.KeyRotation {
+		kr, err := types.NewKeyRotation(chainID, address, privKey, newPrivKey, activationHeight, 10, blockTime)
+		require.NoError(t, err)
+		return kr
+	}
+	goodKr := makeKeyRotation(address, val.PrivKey, newKey, 14)
+
+	// the validator announced a key already
+	announcedValSet := valSet.Copy()
+	require.NoError(t, announcedValSet.RotateKey(address, ed25519.GenPrivKey().PubKey(), 20))
+
+	makeState := func(valSet *types.ValidatorSet, lastBlockHeight int64, lastBlockTime time.Time) sm.State {
+		return sm.State{
+			ChainID:         chainID,
+			LastBlockHeight: lastBlockHeight,
+			LastBlockTime:   lastBlockTime,
+			NextValidators:  valSet,
+			ConsensusParams: *types.DefaultConsensusParams(),
+		}
+	}
+	state := makeState(valSet, 11, blockTime.Add(time.Minute))
+	maxAge := state.ConsensusParams.Evidence
+
+	cases := []struct {
+		name  string
+		kr    *types.KeyRotation
+		state sm.State
+		valid bool
+	}{
+		{"good", goodKr, state, true},
+		{"too low activation height", makeKeyRotation(address, val.PrivKey, newKey, 13), state, false},
+		{"not a validator", makeKeyRotation(newKey.PubKey().Address(), newKey, ed25519.GenPrivKey(), 14),
+			state, false},
+		{"signed by wrong key", makeKeyRotation(address, val2.PrivKey, newKey, 14), state, false},
+		{"new key used by a validator", makeKeyRotation(address, val.PrivKey, val2.PrivKey, 14), state, false},
+		{"new key type not allowed", makeKeyRotation(address, val.PrivKey, bls12381.GenPrivKey(), 14), state,
+			false},
+		{"not after the announced key", goodKr, makeState(announcedValSet, 11, state.LastBlockTime), false},
+		{"not signed by the announced key", makeKeyRotation(address, val.PrivKey, newKey, 21),
+			makeState(announcedValSet, 11, state.LastBlockTime), false},
+		{"block not committed", goodKr, makeState(valSet, 9, state.LastBlockTime), false},
+		{"old, but not by time", makeKeyRotation(address, val.PrivKey, newKey, maxAge.MaxAgeNumBlocks+14),
+			makeState(valSet, maxAge.MaxAgeNumBlocks+11, state.LastBlockTime), true},
+		{"expired", makeKeyRotation(address, val.PrivKey, newKey, maxAge.MaxAgeNumBlocks+14),
+			makeState(valSet, maxAge.MaxAgeNumBlocks+11, blockTime.Add(maxAge.MaxAgeDuration+time.Second)),
+			false},
+	}
+
+	for _, c := range cases {
+		err := sm.VerifyKeyRotation(c.state, c.kr)
+		if c.valid {
+			assert.NoError(t, err, c.name)
+		} else {
+			assert.Error(t, err, c.name)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Finschia/ostracon/p2p"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/types"
+)
+
+const (
+	// lightBlockResponseTimeout is how long to wait for a peer to respond to a light block request.
+	lightBlockResponseTimeout = 30 * time.Second
+)
+
+var (
+	// errNoLightBlock is returned by Backfill() if no peer provided a valid light block for a height.
+	errNoLightBlock = errors.New("no peer provided a valid light block")
+)
+
+// Backfill fetches, verifies and stores the signed headers and validator sets before the state
+// synced height, so that the node can verify evidence and serve light blocks for them. It goes
+// back until both the evidence max age in blocks and in time have been covered, or the initial
+// height is reached. The light blocks are verified backwards from the trusted last block ID of the
+// state, like the light client does, and heights already in the block store are skipped.
+func (r *Reactor) Backfill(state sm.State) error {
+	params := state.ConsensusParams.Evidence
+	stopHeight := state.LastBlockHeight - params.MaxAgeNumBlocks
+	if stopHeight < state.InitialHeight {
+		stopHeight = state.InitialHeight
+	}
+	stopTime := state.LastBlockTime.Add(-params.MaxAgeDuration)
+
+	r.Logger.Info("Starting backfill", "startHeight", state.LastBlockHeight, "stopHeight", stopHeight,
+		"stopTime", stopTime)
+	return r.backfill(state.ChainID, state.LastBlockHeight, stopHeight, state.InitialHeight,
+		state.LastBlockID, stopTime)
+}
+
+func (r *Reactor) backfill(chainID string, startHeight, stopHeight, initialHeight int64,
+	trustedBlockID types.BlockID, stopTime time.Time) error {
+	// lastCommitHash is the LastCommitHash of the previously verified header, which covers the
+	// commit of the next height to verify. It is unknown for the start height, whose commit is
+	// verified against its validator set instead.
+	var lastCommitHash []byte
+	done := func(header *types.Header) bool {
+		return header.Height <= initialHeight ||
+			(header.Height <= stopHeight && header.Time.Before(stopTime))
+	}
+	verify := func(lb *types.LightBlock) error {
+		if err := lb.ValidateBasic(chainID); err != nil {
+			return err
+		}
+		if !lb.Commit.BlockID.Equals(trustedBlockID) {
+			return fmt.Errorf("expected block ID %v at height %v, got %v", trustedBlockID, lb.Height,
+				lb.Commit.BlockID)
+		}
+		if lastCommitHash == nil {
+			return lb.ValidatorSet.VerifyCommitLight(chainID, trustedBlockID, lb.Height, lb.Commit)
+		}
+		if !bytes.Equal(lb.Commit.Hash(), lastCommitHash) {
+			return fmt.Errorf("commit at height %v does not match the last commit hash of the next header",
+				lb.Height)
+		}
+		return nil
+	}
+
+	height := startHeight
+
+	// Heights may already be stored, e.g. by a node snapshot, in which case we only check that they
+	// are part of the trusted chain.
+	for ; height >= initialHeight; height-- {
+		meta := r.blockStore.LoadBlockMeta(height)
+		if meta == nil {
+			break
+		}
+		if !meta.BlockID.Equals(trustedBlockID) {
+			return fmt.Errorf("stored block ID %v at height %v does not match the trusted block ID %v",
+				meta.BlockID, height, trustedBlockID)
+		}
+		if done(&meta.Header) {
+			return nil
+		}
+		trustedBlockID = meta.Header.LastBlockID
+		lastCommitHash = meta.Header.LastCommitHash
+	}
+
+	for height >= initialHeight {
+		peers := r.lightBlockPeers()
+		if len(peers) == 0 {
+			return errors.New("no peers available to backfill from")
+		}
+
+		// Fetch a batch of consecutive heights concurrently from different peers, then verify them
+		// in reverse order, retrying any height that failed with the other peers.
+		batch := int(r.cfg.ChunkFetchers)
+		if batch > len(peers) {
+			batch = len(peers)
+		}
+		if batch < 1 {
+			batch = 1
+		}
+		if int64(batch) > height-initialHeight+1 {
+			batch = int(height - initialHeight + 1)
+		}
+		lightBlocks := make([]*types.LightBlock, batch)
+		var wg sync.WaitGroup
+		for i := 0; i < batch; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				lightBlocks[i], _ = r.requestLightBlock(height-int64(i), peers[i])
+			}(i)
+		}
+		wg.Wait()
+
+		for i, lb := range lightBlocks {
+			if lb == nil || verify(lb) != nil {
+				var err error
+				lb, err = r.fetchLightBlock(height-int64(i), peers, verify)
+				if err != nil {
+					return err
+				}
+			}
+			if err := r.blockStore.SaveSignedHeader(lb.SignedHeader, trustedBlockID); err != nil {
+				return fmt.Errorf("failed to save signed header at height %v: %w", lb.Height, err)
+			}
+			if err := r.stateStore.SaveValidatorSets(lb.Height, lb.Height, lb.ValidatorSet); err != nil {
+				return fmt.Errorf("failed to save validator set at height %v: %w", lb.Height, err)
+			}
+			r.Logger.Debug("Backfilled light block", "height", lb.Height, "hash", lb.Hash())
+			if done(lb.Header) {
+				r.Logger.Info("Backfill complete", "height", lb.Height)
+				return nil
+			}
+			trustedBlockID = lb.LastBlockID
+			lastCommitHash = lb.LastCommitHash
+		}
+		height -= int64(batch)
+	}
+	return nil
+}
+
+// fetchLightBlock fetches the light block at the given height, trying each peer in turn until
+// one provides a light block that passes verification.
+func (r *Reactor) fetchLightBlock(height int64, peers []p2p.Peer,
+	verify func(*types.LightBlock) error) (*types.LightBlock, error) {
+	for _, peer := range peers {
+		lb, err := r.requestLightBlock(height, peer)
+		if err != nil {
+			r.Logger.Debug("Failed to fetch light block", "height", height, "peer", peer.ID(), "err", err)
+			continue
+		}
+		if lb == nil {
+			continue
+		}
+		if err := verify(lb); err != nil {
+			r.Logger.Error("Received invalid light block", "height", height, "peer", peer.ID(), "err", err)
+			continue
+		}
+		return lb, nil
+	}
+	return nil, fmt.Errorf("failed to fetch light block at height %v: %w", height, errNoLightBlock)
+}
+
+func (r *Reactor) requestLightBlock(height int64, peer p2p.Peer) (*types.LightBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lightBlockResponseTimeout)
+	defer cancel()
+	return r.dispatcher.LightBlock(ctx, height, peer)
+}
+
+// lightBlockPeers returns the connected peers that serve light blocks.
+func (r *Reactor) lightBlockPeers() []p2p.Peer {
+	peers := make([]p2p.Peer, 0, r.Switch.Peers().Size())
+	for _, peer := range r.Switch.Peers().List() {
+		if ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo); ok && !ni.HasChannel(LightBlockChannel) {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
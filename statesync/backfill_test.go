@@ -0,0 +1,105 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/libs/log"
+	"github.com/Finschia/ostracon/p2p"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/store"
+)
+
+// makeTestServingReactors connects the given number of reactors serving the given stores with a
+// reactor backed by empty stores, returning the latter along with its stores.
+func makeTestServingReactors(
+	t *testing.T, servers int, stateStore sm.Store, blockStore sm.BlockStore) (*Reactor, sm.Store, *store.BlockStore) {
+	emptyStateStore := sm.NewStore(dbm.NewMemDB())
+	emptyBlockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	p2pConfig := config.DefaultP2PConfig()
+	p2pConfig.AllowDuplicateIP = true
+
+	size := servers + 1
+	reactors := make([]*Reactor, size)
+	initSwitch := func(i int, s *p2p.Switch, p2pConfig *config.P2PConfig) *p2p.Switch {
+		logger := log.TestingLogger()
+		cfg := config.DefaultStateSyncConfig()
+		if i < servers {
+			reactors[i] = NewReactor(*cfg, nil, nil, stateStore, blockStore, true, 1000)
+		} else {
+			reactors[i] = NewReactor(*cfg, nil, nil, emptyStateStore, emptyBlockStore, true, 1000)
+		}
+		reactors[i].SetLogger(logger)
+		reactors[i].SetSwitch(s)
+
+		s.AddReactor("STATESYNC", reactors[i])
+		s.SetLogger(logger)
+		return s
+	}
+	switches := p2p.MakeConnectedSwitches(p2pConfig, size, initSwitch, p2p.Connect2Switches)
+
+	t.Cleanup(func() {
+		for i := 0; i < size; i++ {
+			if err := switches[i].Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	return reactors[servers], emptyStateStore, emptyBlockStore
+}
+
+func TestReactor_Backfill(t *testing.T) {
+	state, _, stateStore, blockStore := makeNodeSnapshotChain(t, 10)
+
+	testcases := map[string]struct {
+		maxAgeNumBlocks int64
+		expectBase      int64
+	}{
+		"backfill to the initial height": {100, 1},
+		"backfill to the evidence age":   {3, 7},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			r, restoredStateStore, restoredBlockStore := makeTestServingReactors(t, 1, stateStore, blockStore)
+
+			// All blocks are older than the evidence max age duration, so the number of blocks decides.
+			state := state
+			state.ConsensusParams.Evidence.MaxAgeNumBlocks = tc.maxAgeNumBlocks
+			state.ConsensusParams.Evidence.MaxAgeDuration = time.Minute
+			state.LastBlockTime = state.LastBlockTime.Add(time.Hour)
+
+			require.NoError(t, r.Backfill(state))
+
+			assert.EqualValues(t, tc.expectBase, restoredBlockStore.Base())
+			assert.EqualValues(t, 10, restoredBlockStore.Height())
+			for h := tc.expectBase; h <= 10; h++ {
+				assert.Equal(t, blockStore.LoadBlockMeta(h).BlockID, restoredBlockStore.LoadBlockMeta(h).BlockID)
+				assert.Equal(t, blockStore.LoadBlockMeta(h).Header.Hash(),
+					restoredBlockStore.LoadBlockMeta(h).Header.Hash())
+				assert.Nil(t, restoredBlockStore.LoadBlock(h))
+
+				vals, err := restoredStateStore.LoadValidators(h)
+				require.NoError(t, err)
+				assert.Equal(t, state.Validators.Hash(), vals.Hash())
+			}
+			assert.Nil(t, restoredBlockStore.LoadBlockMeta(tc.expectBase-1))
+		})
+	}
+}
+
+func TestReactor_Backfill_Unavailable(t *testing.T) {
+	state, _, _, _ := makeNodeSnapshotChain(t, 3)
+	r, _, _ := makeTestServingReactors(t, 1, sm.NewStore(dbm.NewMemDB()), store.NewBlockStore(dbm.NewMemDB()))
+
+	// The serving peer doesn't have the light blocks.
+	require.ErrorIs(t, r.Backfill(state), errNoLightBlock)
+}
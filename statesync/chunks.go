@@ -1,6 +1,8 @@
 package statesync
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"os"
@@ -12,8 +14,13 @@ import (
 	"github.com/Finschia/ostracon/p2p"
 )
 
-// errDone is returned by chunkQueue.Next() when all chunks have been returned.
-var errDone = errors.New("chunk queue has completed")
+var (
+	// errDone is returned by chunkQueue.Next() when all chunks have been returned.
+	errDone = errors.New("chunk queue has completed")
+	// errChunkHashMismatch is returned by chunkQueue.Add() when a chunk doesn't match its hash in
+	// the snapshot metadata.
+	errChunkHashMismatch = errors.New("chunk does not match its hash")
+)
 
 // chunk contains data for a chunk.
 type chunk struct {
@@ -31,6 +38,7 @@ type chunkQueue struct {
 	tmsync.Mutex
 	snapshot       *snapshot                  // if this is nil, the queue has been closed
 	dir            string                     // temp dir for on-disk chunk storage
+	hashes         [][]byte                   // chunk hashes from the snapshot metadata, if any
 	chunkFiles     map[uint32]string          // path to temporary chunk file
 	chunkSenders   map[uint32]p2p.ID          // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
@@ -40,26 +48,95 @@ type chunkQueue struct {
 
 // newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage.
 // Callers must call Close() when done.
+//
+// If tempDir is given, the chunks are stored in a directory named after the snapshot, and verified
+// chunks left there by a previous, interrupted sync of the same snapshot are reused. Otherwise, a
+// new, randomly named directory in the OS temp dir is used.
 func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
-	dir, err := os.MkdirTemp(tempDir, "oc-statesync")
-	if err != nil {
-		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
-	}
 	if snapshot.Chunks == 0 {
 		return nil, errors.New("snapshot has no chunks")
 	}
-	return &chunkQueue{
+	var (
+		dir string
+		err error
+	)
+	if tempDir == "" {
+		dir, err = os.MkdirTemp(tempDir, "oc-statesync")
+	} else {
+		key := snapshot.Key()
+		dir = filepath.Join(tempDir, fmt.Sprintf("oc-statesync-%v-%v-%x", snapshot.Height, snapshot.Format,
+			key[:8]))
+		err = os.MkdirAll(dir, 0700)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
+	}
+	q := &chunkQueue{
 		snapshot:       snapshot,
 		dir:            dir,
+		hashes:         snapshot.ChunkHashes(),
 		chunkFiles:     make(map[uint32]string, snapshot.Chunks),
 		chunkSenders:   make(map[uint32]p2p.ID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
 		waiters:        make(map[uint32][]chan<- uint32),
-	}, nil
+	}
+	if err := q.resume(); err != nil {
+		return nil, err
+	}
+	return q, nil
 }
 
-// Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
+// resume adds the chunks already stored in the queue directory, removing any that don't match
+// their hash. They have no sender, and are not fetched again. Without chunk hashes in the snapshot
+// metadata, stored chunks can't be verified and are all removed.
+func (q *chunkQueue) resume() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return fmt.Errorf("unable to read temp dir for state sync chunks: %w", err)
+	}
+	for _, entry := range entries {
+		index, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil || entry.IsDir() || uint32(index) >= q.snapshot.Chunks {
+			continue
+		}
+		path := filepath.Join(q.dir, entry.Name())
+		if q.hashes == nil {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove chunk %v: %w", index, err)
+			}
+			continue
+		}
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to load chunk %v: %w", index, err)
+		}
+		if q.verify(uint32(index), body) != nil {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove chunk %v: %w", index, err)
+			}
+			continue
+		}
+		q.chunkFiles[uint32(index)] = path
+		q.chunkAllocated[uint32(index)] = true
+	}
+	return nil
+}
+
+// verify checks the chunk body against its hash from the snapshot metadata, if any.
+func (q *chunkQueue) verify(index uint32, body []byte) error {
+	if q.hashes == nil {
+		return nil
+	}
+	hash := sha256.Sum256(body)
+	if !bytes.Equal(hash[:], q.hashes[index]) {
+		return fmt.Errorf("chunk %v: %w", index, errChunkHashMismatch)
+	}
+	return nil
+}
+
+// Add adds a chunk to the queue. It ignores chunks that already exist, returning false. Chunks
+// that don't match their hash from the snapshot metadata are rejected with errChunkHashMismatch.
 func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if chunk == nil || chunk.Chunk == nil {
 		return false, errors.New("cannot add nil chunk")
@@ -81,9 +158,16 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if q.chunkFiles[chunk.Index] != "" {
 		return false, nil
 	}
+	if err := q.verify(chunk.Index, chunk.Chunk); err != nil {
+		return false, err
+	}
 
+	// The chunk is written to a temporary file first, so that an interrupted write isn't resumed.
 	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
-	err := os.WriteFile(path, chunk.Chunk, 0600)
+	err := os.WriteFile(path+".tmp", chunk.Chunk, 0600)
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
 	if err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
 	}
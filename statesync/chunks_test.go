@@ -1,14 +1,17 @@
 package statesync
 
 import (
+	"crypto/sha256"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Finschia/ostracon/p2p"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
 )
 
 func setupChunkQueue(t *testing.T) (*chunkQueue, func()) {
@@ -54,6 +57,122 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 	assert.Len(t, files, 0)
 }
 
+func TestNewChunkQueue_Resume(t *testing.T) {
+	var hashes [][]byte
+	for i := byte(0); i < 3; i++ {
+		hash := sha256.Sum256([]byte{3, 1, i})
+		hashes = append(hashes, hash[:])
+	}
+	metadata, err := (&ocssproto.SnapshotMetadata{ChunkHashes: hashes}).Marshal()
+	require.NoError(t, err)
+	s := &snapshot{
+		Height:   3,
+		Format:   1,
+		Chunks:   3,
+		Hash:     []byte{7},
+		Metadata: metadata,
+	}
+	dir := t.TempDir()
+	queue, err := newChunkQueue(s, dir)
+	require.NoError(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0}, Sender: "a"})
+	require.NoError(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 2, Chunk: []byte{3, 1, 2}, Sender: "a"})
+	require.NoError(t, err)
+
+	// A new queue for the same snapshot, e.g. after a restart, resumes with the stored chunks.
+	queue, err = newChunkQueue(s, dir)
+	require.NoError(t, err)
+	assert.True(t, queue.Has(0))
+	assert.False(t, queue.Has(1))
+	assert.True(t, queue.Has(2))
+
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, index)
+	_, err = queue.Allocate()
+	require.Equal(t, errDone, err)
+
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0}}, c)
+
+	// Another snapshot doesn't reuse the chunks.
+	other, err := newChunkQueue(&snapshot{Height: 3, Format: 1, Chunks: 3, Hash: []byte{8}, Metadata: metadata}, dir)
+	require.NoError(t, err)
+	assert.False(t, other.Has(0))
+	require.NoError(t, other.Close())
+
+	// The chunks are removed once the queue is closed.
+	require.NoError(t, queue.Close())
+	queue, err = newChunkQueue(s, dir)
+	require.NoError(t, err)
+	assert.False(t, queue.Has(0))
+	require.NoError(t, queue.Close())
+}
+
+func TestNewChunkQueue_Resume_ChunkHashes(t *testing.T) {
+	hash0 := sha256.Sum256([]byte{0})
+	hash1 := sha256.Sum256([]byte{1})
+	metadata, err := (&ocssproto.SnapshotMetadata{ChunkHashes: [][]byte{hash0[:], hash1[:]}}).Marshal()
+	require.NoError(t, err)
+	snapshot := &snapshot{Height: 3, Format: 1, Chunks: 2, Hash: []byte{7}, Metadata: metadata}
+
+	dir := t.TempDir()
+	queue, err := newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{0}})
+	require.NoError(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{1}})
+	require.NoError(t, err)
+
+	// Corrupt a stored chunk, which is then discarded when resuming.
+	require.NoError(t, os.WriteFile(filepath.Join(queue.dir, "1"), []byte{9}, 0600))
+	queue, err = newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	defer queue.Close()
+	assert.True(t, queue.Has(0))
+	assert.False(t, queue.Has(1))
+}
+
+func TestNewChunkQueue_Resume_NoChunkHashes(t *testing.T) {
+	s := &snapshot{Height: 3, Format: 1, Chunks: 2, Hash: []byte{7}}
+
+	dir := t.TempDir()
+	queue, err := newChunkQueue(s, dir)
+	require.NoError(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{0}})
+	require.NoError(t, err)
+
+	// Without chunk hashes, stored chunks can't be verified and aren't resumed.
+	queue, err = newChunkQueue(s, dir)
+	require.NoError(t, err)
+	defer queue.Close()
+	assert.False(t, queue.Has(0))
+	files, err := os.ReadDir(queue.dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestChunkQueue_Add_ChunkHashes(t *testing.T) {
+	hash0 := sha256.Sum256([]byte{0})
+	hash1 := sha256.Sum256([]byte{1})
+	metadata, err := (&ocssproto.SnapshotMetadata{ChunkHashes: [][]byte{hash0[:], hash1[:]}}).Marshal()
+	require.NoError(t, err)
+	queue, err := newChunkQueue(&snapshot{Height: 3, Format: 1, Chunks: 2, Hash: []byte{7}, Metadata: metadata}, "")
+	require.NoError(t, err)
+	defer queue.Close()
+
+	added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{0}})
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{0}})
+	require.ErrorIs(t, err, errChunkHashMismatch)
+	assert.False(t, added)
+	assert.False(t, queue.Has(1))
+}
+
 func TestChunkQueue(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
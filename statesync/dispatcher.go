@@ -0,0 +1,213 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	lightprovider "github.com/Finschia/ostracon/light/provider"
+	"github.com/Finschia/ostracon/p2p"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
+	"github.com/Finschia/ostracon/types"
+)
+
+var (
+	// errPeerBusy is returned by the dispatcher if a request to the peer is already in flight.
+	errPeerBusy = errors.New("a request to the peer is already in progress")
+	// errUnreachablePeer is returned by the dispatcher if the request couldn't be sent to the peer.
+	errUnreachablePeer = errors.New("unable to send request to the peer")
+)
+
+// dispatcher sends light block and params requests to peers and routes their responses back to
+// the callers. Only one request of each kind per peer can be in flight at a time, since responses
+// are matched by peer.
+type dispatcher struct {
+	mtx         tmsync.Mutex
+	calls       map[p2p.ID]chan *types.LightBlock
+	paramsCalls map[p2p.ID]chan *ocssproto.ParamsResponse
+}
+
+// newDispatcher creates a new dispatcher.
+func newDispatcher() *dispatcher {
+	return &dispatcher{
+		calls:       make(map[p2p.ID]chan *types.LightBlock),
+		paramsCalls: make(map[p2p.ID]chan *ocssproto.ParamsResponse),
+	}
+}
+
+// LightBlock requests the light block at the given height, or the latest one if 0, from the peer
+// and waits for the response. It returns nil if the peer doesn't have the light block.
+func (d *dispatcher) LightBlock(ctx context.Context, height int64, peer p2p.Peer) (*types.LightBlock, error) {
+	ch, err := d.dispatch(peer, height)
+	if err != nil {
+		return nil, err
+	}
+	defer d.release(peer.ID(), ch)
+
+	select {
+	case lb := <-ch:
+		if lb != nil && height != 0 && lb.Height != height {
+			return nil, fmt.Errorf("peer %v returned light block at height %v, expected %v",
+				peer.ID(), lb.Height, height)
+		}
+		return lb, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Respond passes a light block received from a peer to the pending request, if any.
+func (d *dispatcher) Respond(pb *ocproto.LightBlock, peer p2p.ID) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	ch, ok := d.calls[peer]
+	if !ok {
+		return fmt.Errorf("unsolicited light block response from peer %v", peer)
+	}
+	delete(d.calls, peer)
+
+	if pb == nil {
+		ch <- nil
+		return nil
+	}
+	lb, err := types.LightBlockFromProto(pb)
+	if err != nil {
+		ch <- nil
+		return err
+	}
+	ch <- lb
+	return nil
+}
+
+func (d *dispatcher) dispatch(peer p2p.Peer, height int64) (chan *types.LightBlock, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if _, ok := d.calls[peer.ID()]; ok {
+		return nil, errPeerBusy
+	}
+	ch := make(chan *types.LightBlock, 1)
+	d.calls[peer.ID()] = ch
+	if !peer.Send(LightBlockChannel, mustEncodeMsg(&ocssproto.LightBlockRequest{Height: uint64(height)})) {
+		delete(d.calls, peer.ID())
+		return nil, errUnreachablePeer
+	}
+	return ch, nil
+}
+
+// release removes the pending request to the peer, unless it was already answered.
+func (d *dispatcher) release(peer p2p.ID, ch chan *types.LightBlock) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.calls[peer] == ch {
+		delete(d.calls, peer)
+	}
+}
+
+// Params requests the consensus params and entropy at the given height from the peer and waits
+// for the response. Peers don't respond if they don't have them, so the context should have a
+// deadline.
+func (d *dispatcher) Params(ctx context.Context, height int64, peer p2p.Peer) (*ocssproto.ParamsResponse, error) {
+	d.mtx.Lock()
+	if _, ok := d.paramsCalls[peer.ID()]; ok {
+		d.mtx.Unlock()
+		return nil, errPeerBusy
+	}
+	ch := make(chan *ocssproto.ParamsResponse, 1)
+	d.paramsCalls[peer.ID()] = ch
+	if !peer.Send(ParamsChannel, mustEncodeMsg(&ocssproto.ParamsRequest{Height: uint64(height)})) {
+		delete(d.paramsCalls, peer.ID())
+		d.mtx.Unlock()
+		return nil, errUnreachablePeer
+	}
+	d.mtx.Unlock()
+
+	defer func() {
+		d.mtx.Lock()
+		defer d.mtx.Unlock()
+		if d.paramsCalls[peer.ID()] == ch {
+			delete(d.paramsCalls, peer.ID())
+		}
+	}()
+
+	select {
+	case resp := <-ch:
+		if resp.Height != uint64(height) {
+			return nil, fmt.Errorf("peer %v returned params at height %v, expected %v",
+				peer.ID(), resp.Height, height)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RespondParams passes params received from a peer to the pending request, if any.
+func (d *dispatcher) RespondParams(msg *ocssproto.ParamsResponse, peer p2p.ID) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	ch, ok := d.paramsCalls[peer]
+	if !ok {
+		return fmt.Errorf("unsolicited params response from peer %v", peer)
+	}
+	delete(d.paramsCalls, peer)
+	ch <- msg
+	return nil
+}
+
+// blockProvider is a light client provider fetching light blocks from a single peer over the p2p
+// network, so that the light client can be used without RPC servers.
+type blockProvider struct {
+	peer       p2p.Peer
+	chainID    string
+	dispatcher *dispatcher
+}
+
+var _ lightprovider.Provider = (*blockProvider)(nil)
+
+// newBlockProvider creates a light client provider for the given peer.
+func newBlockProvider(peer p2p.Peer, chainID string, dispatcher *dispatcher) *blockProvider {
+	return &blockProvider{
+		peer:       peer,
+		chainID:    chainID,
+		dispatcher: dispatcher,
+	}
+}
+
+// ChainID implements provider.Provider.
+func (p *blockProvider) ChainID() string {
+	return p.chainID
+}
+
+// LightBlock implements provider.Provider.
+func (p *blockProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, lightBlockResponseTimeout)
+	defer cancel()
+	lb, err := p.dispatcher.LightBlock(reqCtx, height, p.peer)
+	switch {
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case errors.Is(err, errPeerBusy), errors.Is(err, errUnreachablePeer), errors.Is(err, context.DeadlineExceeded):
+		return nil, lightprovider.ErrNoResponse
+	case err != nil:
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	case lb == nil:
+		return nil, lightprovider.ErrLightBlockNotFound
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence implements provider.Provider. Evidence can't be reported to a peer through the
+// state sync reactor, so this is a no-op.
+func (p *blockProvider) ReportEvidence(context.Context, types.Evidence) error {
+	return nil
+}
+
+// String implements fmt.Stringer.
+func (p *blockProvider) String() string {
+	return fmt.Sprintf("BlockProvider{%v}", p.peer.ID())
+}
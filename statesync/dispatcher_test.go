@@ -0,0 +1,191 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Finschia/ostracon/config"
+	lightprovider "github.com/Finschia/ostracon/light/provider"
+	"github.com/Finschia/ostracon/p2p"
+	p2pmocks "github.com/Finschia/ostracon/p2p/mocks"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
+)
+
+func TestDispatcher_LightBlock(t *testing.T) {
+	_, _, stateStore, blockStore := makeNodeSnapshotChain(t, 3)
+	cfg := config.DefaultStateSyncConfig()
+	server := NewReactor(*cfg, nil, nil, stateStore, blockStore, true, 1000)
+	d := newDispatcher()
+
+	// The peer is served by a reactor backed by the chain, and responds asynchronously.
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", LightBlockChannel, mock.Anything).Run(func(args mock.Arguments) {
+		msg, err := decodeMsg(args[1].([]byte))
+		require.NoError(t, err)
+		lb, err := server.localLightBlock(int64(msg.(*ocssproto.LightBlockRequest).Height))
+		require.NoError(t, err)
+		go func() {
+			require.NoError(t, d.Respond(lb, peer.ID()))
+		}()
+	}).Return(true)
+
+	lb, err := d.LightBlock(context.Background(), 2, peer)
+	require.NoError(t, err)
+	require.NotNil(t, lb)
+	assert.EqualValues(t, 2, lb.Height)
+	assert.Equal(t, blockStore.LoadBlockMeta(2).Header.Hash(), lb.Hash())
+	assert.Equal(t, blockStore.LoadBlockCommit(2).Hash(), lb.Commit.Hash())
+
+	// The peer doesn't have the light block.
+	lb, err = d.LightBlock(context.Background(), 4, peer)
+	require.NoError(t, err)
+	assert.Nil(t, lb)
+
+	// Responses without a pending request are rejected.
+	require.Error(t, d.Respond(nil, peer.ID()))
+}
+
+func TestDispatcher_LightBlock_Busy(t *testing.T) {
+	d := newDispatcher()
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", LightBlockChannel, mock.Anything).Return(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		_, err := d.LightBlock(ctx, 1, peer)
+		done <- err
+	}()
+	require.Eventually(t, func() bool {
+		d.mtx.Lock()
+		defer d.mtx.Unlock()
+		return len(d.calls) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := d.LightBlock(context.Background(), 2, peer)
+	require.ErrorIs(t, err, errPeerBusy)
+
+	require.ErrorIs(t, <-done, context.DeadlineExceeded)
+	assert.Empty(t, d.calls)
+}
+
+func TestDispatcher_LightBlock_Unreachable(t *testing.T) {
+	d := newDispatcher()
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", LightBlockChannel, mock.Anything).Return(false)
+
+	_, err := d.LightBlock(context.Background(), 1, peer)
+	require.ErrorIs(t, err, errUnreachablePeer)
+	assert.Empty(t, d.calls)
+}
+
+func TestDispatcher_LightBlock_WrongHeight(t *testing.T) {
+	_, _, stateStore, blockStore := makeNodeSnapshotChain(t, 3)
+	cfg := config.DefaultStateSyncConfig()
+	server := NewReactor(*cfg, nil, nil, stateStore, blockStore, true, 1000)
+	d := newDispatcher()
+
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", LightBlockChannel, mock.Anything).Run(func(args mock.Arguments) {
+		lb, err := server.localLightBlock(1)
+		require.NoError(t, err)
+		go func() {
+			require.NoError(t, d.Respond(lb, peer.ID()))
+		}()
+	}).Return(true)
+
+	_, err := d.LightBlock(context.Background(), 2, peer)
+	require.Error(t, err)
+}
+
+func TestDispatcher_Params(t *testing.T) {
+	chain, _, stateStore, blockStore := makeNodeSnapshotChain(t, 3)
+	cfg := config.DefaultStateSyncConfig()
+	server := NewReactor(*cfg, nil, nil, stateStore, blockStore, true, 1000)
+	d := newDispatcher()
+
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", ParamsChannel, mock.Anything).Run(func(args mock.Arguments) {
+		msg, err := decodeMsg(args[1].([]byte))
+		require.NoError(t, err)
+		resp, err := server.localParams(int64(msg.(*ocssproto.ParamsRequest).Height))
+		if err != nil {
+			return
+		}
+		go func() {
+			require.NoError(t, d.RespondParams(resp, peer.ID()))
+		}()
+	}).Return(true)
+
+	resp, err := d.Params(context.Background(), 2, peer)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.Height)
+	assert.Equal(t, chain.ConsensusParams, resp.ConsensusParams)
+	assert.EqualValues(t, blockStore.LoadBlock(2).Proof, resp.Block.Entropy.Proof)
+
+	// The peer doesn't respond if it doesn't have the params.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = d.Params(ctx, 4, peer)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Responses without a pending request are rejected.
+	require.Error(t, d.RespondParams(resp, peer.ID()))
+}
+
+func TestBlockProvider(t *testing.T) {
+	chain, _, stateStore, blockStore := makeNodeSnapshotChain(t, 3)
+	cfg := config.DefaultStateSyncConfig()
+	server := NewReactor(*cfg, nil, nil, stateStore, blockStore, true, 1000)
+	d := newDispatcher()
+
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", LightBlockChannel, mock.Anything).Run(func(args mock.Arguments) {
+		msg, err := decodeMsg(args[1].([]byte))
+		require.NoError(t, err)
+		lb, err := server.localLightBlock(int64(msg.(*ocssproto.LightBlockRequest).Height))
+		require.NoError(t, err)
+		go func() {
+			require.NoError(t, d.Respond(lb, peer.ID()))
+		}()
+	}).Return(true)
+
+	provider := newBlockProvider(peer, chain.ChainID, d)
+	assert.Equal(t, chain.ChainID, provider.ChainID())
+
+	lb, err := provider.LightBlock(context.Background(), 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, lb.Height)
+
+	// The latest light block is returned for height 0.
+	lb, err = provider.LightBlock(context.Background(), 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lb.Height)
+
+	_, err = provider.LightBlock(context.Background(), 4)
+	require.Equal(t, lightprovider.ErrLightBlockNotFound, err)
+
+	// Light blocks of another chain are rejected.
+	provider = newBlockProvider(peer, "other-chain", d)
+	_, err = provider.LightBlock(context.Background(), 2)
+	require.IsType(t, lightprovider.ErrBadLightBlock{}, err)
+
+	unreachable := &p2pmocks.Peer{}
+	unreachable.On("ID").Return(p2p.ID("unreachable"))
+	unreachable.On("Send", LightBlockChannel, mock.Anything).Return(false)
+	provider = newBlockProvider(unreachable, chain.ChainID, d)
+	_, err = provider.LightBlock(context.Background(), 2)
+	require.Equal(t, lightprovider.ErrNoResponse, err)
+}
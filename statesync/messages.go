@@ -7,6 +7,8 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
 )
 
 const (
@@ -14,20 +16,40 @@ const (
 	snapshotMsgSize = int(4e6)
 	// chunkMsgSize is the maximum size of a chunkResponseMessage
 	chunkMsgSize = int(16e6)
+	// lightBlockMsgSize is the maximum size of a lightBlockResponseMessage
+	lightBlockMsgSize = int(1e7)
+	// paramsMsgSize is the maximum size of a paramsResponseMessage
+	paramsMsgSize = int(1e5)
 )
 
 // mustEncodeMsg encodes a Protobuf message, panicing on error.
 func mustEncodeMsg(pb proto.Message) []byte {
-	msg := ssproto.Message{}
+	msg := ocssproto.Message{}
 	switch pb := pb.(type) {
 	case *ssproto.ChunkRequest:
-		msg.Sum = &ssproto.Message_ChunkRequest{ChunkRequest: pb}
+		msg.Sum = &ocssproto.Message_ChunkRequest{ChunkRequest: pb}
 	case *ssproto.ChunkResponse:
-		msg.Sum = &ssproto.Message_ChunkResponse{ChunkResponse: pb}
+		msg.Sum = &ocssproto.Message_ChunkResponse{ChunkResponse: pb}
 	case *ssproto.SnapshotsRequest:
-		msg.Sum = &ssproto.Message_SnapshotsRequest{SnapshotsRequest: pb}
+		msg.Sum = &ocssproto.Message_SnapshotsRequest{SnapshotsRequest: pb}
 	case *ssproto.SnapshotsResponse:
-		msg.Sum = &ssproto.Message_SnapshotsResponse{SnapshotsResponse: pb}
+		msg.Sum = &ocssproto.Message_SnapshotsResponse{SnapshotsResponse: pb}
+	case *ocssproto.NodeSnapshotsRequest:
+		msg.Sum = &ocssproto.Message_NodeSnapshotsRequest{NodeSnapshotsRequest: pb}
+	case *ocssproto.NodeSnapshotsResponse:
+		msg.Sum = &ocssproto.Message_NodeSnapshotsResponse{NodeSnapshotsResponse: pb}
+	case *ocssproto.NodeChunkRequest:
+		msg.Sum = &ocssproto.Message_NodeChunkRequest{NodeChunkRequest: pb}
+	case *ocssproto.NodeChunkResponse:
+		msg.Sum = &ocssproto.Message_NodeChunkResponse{NodeChunkResponse: pb}
+	case *ocssproto.LightBlockRequest:
+		msg.Sum = &ocssproto.Message_LightBlockRequest{LightBlockRequest: pb}
+	case *ocssproto.LightBlockResponse:
+		msg.Sum = &ocssproto.Message_LightBlockResponse{LightBlockResponse: pb}
+	case *ocssproto.ParamsRequest:
+		msg.Sum = &ocssproto.Message_ParamsRequest{ParamsRequest: pb}
+	case *ocssproto.ParamsResponse:
+		msg.Sum = &ocssproto.Message_ParamsResponse{ParamsResponse: pb}
 	default:
 		panic(fmt.Errorf("unknown message type %T", pb))
 	}
@@ -40,20 +62,36 @@ func mustEncodeMsg(pb proto.Message) []byte {
 
 // decodeMsg decodes a Protobuf message.
 func decodeMsg(bz []byte) (proto.Message, error) {
-	pb := &ssproto.Message{}
+	pb := &ocssproto.Message{}
 	err := proto.Unmarshal(bz, pb)
 	if err != nil {
 		return nil, err
 	}
 	switch msg := pb.Sum.(type) {
-	case *ssproto.Message_ChunkRequest:
+	case *ocssproto.Message_ChunkRequest:
 		return msg.ChunkRequest, nil
-	case *ssproto.Message_ChunkResponse:
+	case *ocssproto.Message_ChunkResponse:
 		return msg.ChunkResponse, nil
-	case *ssproto.Message_SnapshotsRequest:
+	case *ocssproto.Message_SnapshotsRequest:
 		return msg.SnapshotsRequest, nil
-	case *ssproto.Message_SnapshotsResponse:
+	case *ocssproto.Message_SnapshotsResponse:
 		return msg.SnapshotsResponse, nil
+	case *ocssproto.Message_NodeSnapshotsRequest:
+		return msg.NodeSnapshotsRequest, nil
+	case *ocssproto.Message_NodeSnapshotsResponse:
+		return msg.NodeSnapshotsResponse, nil
+	case *ocssproto.Message_NodeChunkRequest:
+		return msg.NodeChunkRequest, nil
+	case *ocssproto.Message_NodeChunkResponse:
+		return msg.NodeChunkResponse, nil
+	case *ocssproto.Message_LightBlockRequest:
+		return msg.LightBlockRequest, nil
+	case *ocssproto.Message_LightBlockResponse:
+		return msg.LightBlockResponse, nil
+	case *ocssproto.Message_ParamsRequest:
+		return msg.ParamsRequest, nil
+	case *ocssproto.Message_ParamsResponse:
+		return msg.ParamsResponse, nil
 	default:
 		return nil, fmt.Errorf("unknown message type %T", msg)
 	}
@@ -90,6 +128,56 @@ func validateMsg(pb proto.Message) error {
 		if msg.Chunks == 0 {
 			return errors.New("snapshot has no chunks")
 		}
+	case *ocssproto.NodeSnapshotsRequest:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+		if msg.Base == 0 || msg.Base > msg.Height {
+			return errors.New("base must be between 1 and height")
+		}
+	case *ocssproto.NodeSnapshotsResponse:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+		if msg.Base == 0 || msg.Base > msg.Height {
+			return errors.New("base must be between 1 and height")
+		}
+		if len(msg.Hash) == 0 {
+			return errors.New("snapshot has no hash")
+		}
+		if msg.Chunks == 0 {
+			return errors.New("snapshot has no chunks")
+		}
+	case *ocssproto.NodeChunkRequest:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+		if msg.Base == 0 || msg.Base > msg.Height {
+			return errors.New("base must be between 1 and height")
+		}
+	case *ocssproto.NodeChunkResponse:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+		if msg.Base == 0 || msg.Base > msg.Height {
+			return errors.New("base must be between 1 and height")
+		}
+		if msg.Missing && len(msg.Chunk) > 0 {
+			return errors.New("missing chunk cannot have contents")
+		}
+		if !msg.Missing && msg.Chunk == nil {
+			return errors.New("chunk cannot be nil")
+		}
+	case *ocssproto.LightBlockRequest:
+	case *ocssproto.LightBlockResponse:
+	case *ocssproto.ParamsRequest:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+	case *ocssproto.ParamsResponse:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
 	default:
 		return fmt.Errorf("unknown message type %T", msg)
 	}
@@ -9,6 +9,7 @@ import (
 
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
 	tmproto "github.com/Finschia/ostracon/proto/ostracon/types"
 )
 
@@ -70,6 +71,55 @@ func TestValidateMsg(t *testing.T) {
 		"SnapshotsResponse no hash": {
 			&ssproto.SnapshotsResponse{Height: 1, Format: 1, Chunks: 2, Hash: []byte{}},
 			false},
+
+		"NodeSnapshotsRequest valid":       {&ocssproto.NodeSnapshotsRequest{Height: 2, Base: 1}, true},
+		"NodeSnapshotsRequest 0 height":    {&ocssproto.NodeSnapshotsRequest{Height: 0, Base: 1}, false},
+		"NodeSnapshotsRequest 0 base":      {&ocssproto.NodeSnapshotsRequest{Height: 2, Base: 0}, false},
+		"NodeSnapshotsRequest base>height": {&ocssproto.NodeSnapshotsRequest{Height: 2, Base: 3}, false},
+
+		"NodeSnapshotsResponse valid": {
+			&ocssproto.NodeSnapshotsResponse{Height: 2, Base: 1, Chunks: 1, Hash: []byte{1}},
+			true},
+		"NodeSnapshotsResponse 0 base": {
+			&ocssproto.NodeSnapshotsResponse{Height: 2, Base: 0, Chunks: 1, Hash: []byte{1}},
+			false},
+		"NodeSnapshotsResponse 0 chunks": {
+			&ocssproto.NodeSnapshotsResponse{Height: 2, Base: 1, Hash: []byte{1}},
+			false},
+		"NodeSnapshotsResponse no hash": {
+			&ocssproto.NodeSnapshotsResponse{Height: 2, Base: 1, Chunks: 1},
+			false},
+
+		"NodeChunkRequest valid":    {&ocssproto.NodeChunkRequest{Height: 2, Base: 1, Index: 1}, true},
+		"NodeChunkRequest 0 height": {&ocssproto.NodeChunkRequest{Height: 0, Base: 1, Index: 1}, false},
+		"NodeChunkRequest 0 base":   {&ocssproto.NodeChunkRequest{Height: 2, Base: 0, Index: 1}, false},
+
+		"NodeChunkResponse valid": {
+			&ocssproto.NodeChunkResponse{Height: 2, Base: 1, Index: 1, Chunk: []byte{1}},
+			true},
+		"NodeChunkResponse 0 base": {
+			&ocssproto.NodeChunkResponse{Height: 2, Base: 0, Index: 1, Chunk: []byte{1}},
+			false},
+		"NodeChunkResponse nil body": {
+			&ocssproto.NodeChunkResponse{Height: 2, Base: 1, Index: 1},
+			false},
+		"NodeChunkResponse missing": {
+			&ocssproto.NodeChunkResponse{Height: 2, Base: 1, Index: 1, Missing: true},
+			true},
+		"NodeChunkResponse missing with body": {
+			&ocssproto.NodeChunkResponse{Height: 2, Base: 1, Index: 1, Missing: true, Chunk: []byte{1}},
+			false},
+
+		"LightBlockRequest valid":  {&ocssproto.LightBlockRequest{Height: 1}, true},
+		"LightBlockRequest latest": {&ocssproto.LightBlockRequest{Height: 0}, true},
+
+		"LightBlockResponse valid": {&ocssproto.LightBlockResponse{LightBlock: &tmproto.LightBlock{}}, true},
+		"LightBlockResponse empty": {&ocssproto.LightBlockResponse{}, true},
+
+		"ParamsRequest valid":     {&ocssproto.ParamsRequest{Height: 1}, true},
+		"ParamsRequest 0 height":  {&ocssproto.ParamsRequest{Height: 0}, false},
+		"ParamsResponse valid":    {&ocssproto.ParamsResponse{Height: 1}, true},
+		"ParamsResponse 0 height": {&ocssproto.ParamsResponse{Height: 0}, false},
 	}
 	for name, tc := range testcases {
 		tc := tc
@@ -96,6 +146,13 @@ func TestStateSyncVectors(t *testing.T) {
 		{"SnapshotsResponse", &ssproto.SnapshotsResponse{Height: 1, Format: 2, Chunks: 3, Hash: []byte("chuck hash"), Metadata: []byte("snapshot metadata")}, "1225080110021803220a636875636b20686173682a11736e617073686f74206d65746164617461"},
 		{"ChunkRequest", &ssproto.ChunkRequest{Height: 1, Format: 2, Index: 3}, "1a06080110021803"},
 		{"ChunkResponse", &ssproto.ChunkResponse{Height: 1, Format: 2, Index: 3, Chunk: []byte("it's a chunk")}, "2214080110021803220c697427732061206368756e6b"},
+		{"NodeSnapshotsRequest", &ocssproto.NodeSnapshotsRequest{Height: 2, Base: 1}, "c23e0408021001"},
+		{"NodeSnapshotsResponse", &ocssproto.NodeSnapshotsResponse{Height: 2, Base: 1, Chunks: 3, Hash: []byte("chuck hash")}, "ca3e12080210011803220a636875636b2068617368"},
+		{"NodeChunkRequest", &ocssproto.NodeChunkRequest{Height: 2, Base: 1, Index: 3}, "d23e06080210011803"},
+		{"NodeChunkResponse", &ocssproto.NodeChunkResponse{Height: 2, Base: 1, Index: 3, Chunk: []byte("it's a chunk")}, "da3e14080210011803220c697427732061206368756e6b"},
+		{"LightBlockRequest", &ocssproto.LightBlockRequest{Height: 1}, "e23e020801"},
+		{"LightBlockResponse", &ocssproto.LightBlockResponse{}, "ea3e00"},
+		{"ParamsRequest", &ocssproto.ParamsRequest{Height: 1}, "f23e020801"},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,504 @@
+package statesync
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math/rand"
+	"sort"
+	"time"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/libs/protoio"
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/p2p"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/types"
+)
+
+const (
+	// nodeSnapshotChunkSize is the maximum size of a node snapshot chunk.
+	nodeSnapshotChunkSize = int(10e6)
+	// nodeSnapshotItemSize is the maximum size of a single height in a node snapshot.
+	nodeSnapshotItemSize = int(types.MaxBlockSizeBytes) + snapshotMsgSize
+)
+
+var (
+	// errNoNodeSnapshots is returned by RestoreNodeSnapshot() if no peer provided a valid node snapshot.
+	errNoNodeSnapshots = errors.New("no suitable node snapshots found")
+)
+
+// nodeSnapshot contains data about a node snapshot, i.e. a snapshot of the consensus data kept by
+// the node itself (blocks, commits, validator sets and consensus params) as opposed to the app
+// snapshot. The contents are a stream of varint-delimited NodeSnapshotItems for the heights height
+// down to base, split into chunks. The hash is the SHA256 hash of the entire stream.
+type nodeSnapshot struct {
+	Height uint64
+	Base   uint64
+	Chunks uint32
+	Hash   []byte
+
+	chunks [][]byte // only set for snapshots built by the local node
+}
+
+// Key generates a node snapshot key, used for lookups.
+func (s *nodeSnapshot) Key() snapshotKey {
+	// Hash.Write() never returns an error.
+	hasher := sha256.New()
+	hasher.Write([]byte(fmt.Sprintf("%v:%v:%v", s.Height, s.Base, s.Chunks)))
+	hasher.Write(s.Hash)
+	var key snapshotKey
+	copy(key[:], hasher.Sum(nil))
+	return key
+}
+
+// nodeSnapshotItem is the decoded consensus data of a single height in a node snapshot.
+type nodeSnapshotItem struct {
+	block           *types.Block
+	validators      *types.ValidatorSet
+	consensusParams tmproto.ConsensusParams
+}
+
+// buildNodeSnapshot builds a node snapshot for the heights base to height from the local state
+// and block stores. The heights are listed in descending order, so that the restoring node can
+// verify them one by one along the hash chain.
+func buildNodeSnapshot(stateStore sm.Store, blockStore sm.BlockStore, base, height int64) (*nodeSnapshot, error) {
+	if base < blockStore.Base() || height > blockStore.Height() {
+		return nil, fmt.Errorf("blocks %v to %v are not available, block store has %v to %v",
+			base, height, blockStore.Base(), blockStore.Height())
+	}
+
+	buf := new(bytes.Buffer)
+	writer := protoio.NewDelimitedWriter(buf)
+	for h := height; h >= base; h-- {
+		block := blockStore.LoadBlock(h)
+		if block == nil {
+			return nil, fmt.Errorf("block at height %v not found", h)
+		}
+		pbb, err := block.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		vals, err := stateStore.LoadValidators(h)
+		if err != nil {
+			return nil, err
+		}
+		pbv, err := vals.ToOCProto()
+		if err != nil {
+			return nil, err
+		}
+		params, err := stateStore.LoadConsensusParams(h)
+		if err != nil {
+			return nil, err
+		}
+		_, err = writer.WriteMsg(&ocssproto.NodeSnapshotItem{
+			Block:           pbb,
+			ValidatorSet:    pbv,
+			ConsensusParams: params,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data := buf.Bytes()
+	hash := sha256.Sum256(data)
+	snapshot := &nodeSnapshot{
+		Height: uint64(height),
+		Base:   uint64(base),
+		Hash:   hash[:],
+	}
+	for len(data) > 0 {
+		size := nodeSnapshotChunkSize
+		if len(data) < size {
+			size = len(data)
+		}
+		snapshot.chunks = append(snapshot.chunks, data[:size])
+		data = data[size:]
+	}
+	snapshot.Chunks = uint32(len(snapshot.chunks))
+	return snapshot, nil
+}
+
+// maxNodeSnapshotChunks returns the number of chunks a node snapshot of the heights base to height
+// can have at most, with every height at the maximum item size.
+func maxNodeSnapshotChunks(height, base uint64) uint64 {
+	size := (height - base + 1) * uint64(nodeSnapshotItemSize+binary.MaxVarintLen64)
+	return (size + uint64(nodeSnapshotChunkSize) - 1) / uint64(nodeSnapshotChunkSize)
+}
+
+// nodeSnapshotItemFromProto decodes a node snapshot item.
+func nodeSnapshotItemFromProto(pb *ocssproto.NodeSnapshotItem) (*nodeSnapshotItem, error) {
+	block, err := types.BlockFromProto(pb.Block)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := types.ValidatorSetFromOCProto(pb.ValidatorSet)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeSnapshotItem{
+		block:           block,
+		validators:      vals,
+		consensusParams: pb.ConsensusParams,
+	}, nil
+}
+
+// nodeSnapshotVerifier decodes the contents of a node snapshot as its chunks arrive, and verifies
+// each height against the trusted state and commit obtained by state sync as soon as it is
+// decoded, so that an invalid snapshot is rejected at its first bad chunk. Since the state was
+// verified by the light client, it is enough to follow the hash chain backwards from the trusted
+// last block ID, which is why the snapshot lists the heights in descending order.
+//
+// The validator set hashes don't cover the proposer priorities, so they are re-derived from one
+// height to the next, and the last ones are checked against the validators of the state.
+type nodeSnapshotVerifier struct {
+	state    sm.State
+	snapshot *nodeSnapshot
+
+	hasher          hash.Hash
+	buf             []byte
+	expectedBlockID types.BlockID
+	items           []*nodeSnapshotItem // verified, in descending height order
+}
+
+// newNodeSnapshotVerifier creates a verifier of the node snapshot, checking the state and commit
+// it is verified against.
+func newNodeSnapshotVerifier(state sm.State, commit *types.Commit, snapshot *nodeSnapshot) (
+	*nodeSnapshotVerifier, error) {
+	if snapshot.Height != uint64(state.LastBlockHeight) {
+		return nil, fmt.Errorf("node snapshot ends at height %v, expected %v", snapshot.Height, state.LastBlockHeight)
+	}
+	if snapshot.Base > snapshot.Height {
+		return nil, fmt.Errorf("node snapshot base %v is above its height %v", snapshot.Base, snapshot.Height)
+	}
+	if !commit.BlockID.Equals(state.LastBlockID) {
+		return nil, fmt.Errorf("commit is for block %v, expected %v", commit.BlockID, state.LastBlockID)
+	}
+	return &nodeSnapshotVerifier{
+		state:           state,
+		snapshot:        snapshot,
+		hasher:          sha256.New(),
+		expectedBlockID: state.LastBlockID,
+	}, nil
+}
+
+// Add decodes and verifies the heights completed by the next chunk of the node snapshot.
+func (v *nodeSnapshotVerifier) Add(chunk []byte) error {
+	if len(chunk) > nodeSnapshotChunkSize {
+		return fmt.Errorf("node snapshot chunk of %v bytes exceeds the maximum of %v bytes",
+			len(chunk), nodeSnapshotChunkSize)
+	}
+	// Hash.Write() never returns an error.
+	v.hasher.Write(chunk)
+	v.buf = append(v.buf, chunk...)
+	for {
+		size, n := binary.Uvarint(v.buf)
+		if n == 0 {
+			return nil
+		}
+		if n < 0 || size > uint64(nodeSnapshotItemSize) {
+			return fmt.Errorf("node snapshot item exceeds the maximum of %v bytes", nodeSnapshotItemSize)
+		}
+		if uint64(len(v.buf)-n) < size {
+			return nil
+		}
+		pb := &ocssproto.NodeSnapshotItem{}
+		if err := pb.Unmarshal(v.buf[n : n+int(size)]); err != nil {
+			return err
+		}
+		v.buf = v.buf[n+int(size):]
+		item, err := nodeSnapshotItemFromProto(pb)
+		if err != nil {
+			return err
+		}
+		if err := v.verifyItem(item); err != nil {
+			return err
+		}
+	}
+}
+
+// verifyItem verifies the next height of the node snapshot, going down from the last block height
+// of the state.
+func (v *nodeSnapshotVerifier) verifyItem(item *nodeSnapshotItem) error {
+	if uint64(len(v.items)) == v.snapshot.Height-v.snapshot.Base+1 {
+		return fmt.Errorf("node snapshot has more than the expected %v heights", len(v.items))
+	}
+	block := item.block
+	if height := v.state.LastBlockHeight - int64(len(v.items)); block.Height != height {
+		return fmt.Errorf("expected height %v in node snapshot, got %v", height, block.Height)
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid block at height %v: %w", block.Height, err)
+	}
+	if block.ChainID != v.state.ChainID {
+		return fmt.Errorf("block at height %v has chain ID %q, expected %q",
+			block.Height, block.ChainID, v.state.ChainID)
+	}
+
+	blockID := types.BlockID{
+		Hash:          block.Hash(),
+		PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header(),
+	}
+	if !blockID.Equals(v.expectedBlockID) {
+		return fmt.Errorf("block at height %v has ID %v, expected %v", block.Height, blockID, v.expectedBlockID)
+	}
+	if !bytes.Equal(item.validators.Hash(), block.ValidatorsHash) {
+		return fmt.Errorf("validator set at height %v does not match the block header", block.Height)
+	}
+	if len(v.items) == 0 {
+		if !equalProposerPriorities(item.validators, v.state.LastValidators) {
+			return fmt.Errorf("proposer priorities at height %v do not match the state", block.Height)
+		}
+	} else {
+		upper := v.items[len(v.items)-1].validators
+		next, err := nextProposerPriorities(item.validators, upper)
+		if err != nil {
+			return fmt.Errorf("can't derive proposer priorities of height %v: %w", block.Height+1, err)
+		}
+		if !equalProposerPriorities(next, upper) {
+			return fmt.Errorf("proposer priorities at height %v do not follow from height %v",
+				block.Height+1, block.Height)
+		}
+	}
+	if !bytes.Equal(types.HashConsensusParams(item.consensusParams), block.ConsensusHash) {
+		return fmt.Errorf("consensus params at height %v do not match the block header", block.Height)
+	}
+
+	v.expectedBlockID = block.LastBlockID
+	v.items = append(v.items, item)
+	return nil
+}
+
+// Finish checks that the node snapshot is complete and matches the snapshot hash, and returns the
+// verified items in ascending height order.
+func (v *nodeSnapshotVerifier) Finish() ([]*nodeSnapshotItem, error) {
+	if len(v.buf) > 0 {
+		return nil, fmt.Errorf("node snapshot has %v trailing bytes", len(v.buf))
+	}
+	if uint64(len(v.items)) != v.snapshot.Height-v.snapshot.Base+1 {
+		return nil, fmt.Errorf("expected %v heights in node snapshot, got %v",
+			v.snapshot.Height-v.snapshot.Base+1, len(v.items))
+	}
+	if hash := v.hasher.Sum(nil); !bytes.Equal(hash, v.snapshot.Hash) {
+		return nil, fmt.Errorf("node snapshot hash mismatch, expected %X got %X", v.snapshot.Hash, hash)
+	}
+	items := make([]*nodeSnapshotItem, len(v.items))
+	for i, item := range v.items {
+		items[len(items)-1-i] = item
+	}
+	return items, nil
+}
+
+// nextProposerPriorities derives the proposer priorities of the validator set next from the
+// ones of the set vals of the previous height, the way the state updates them: the validator
+// changes are applied to vals and the priorities are incremented once.
+func nextProposerPriorities(vals, next *types.ValidatorSet) (*types.ValidatorSet, error) {
+	var changes []*types.Validator
+	for _, val := range vals.Validators {
+		if !next.HasAddress(val.Address) {
+			changes = append(changes, &types.Validator{Address: val.Address, PubKey: val.PubKey, VotingPower: 0})
+		}
+	}
+	for _, val := range next.Validators {
+		if _, prev := vals.GetByAddress(val.Address); prev == nil || prev.VotingPower != val.VotingPower {
+			changes = append(changes, &types.Validator{Address: val.Address, PubKey: val.PubKey,
+				VotingPower: val.VotingPower})
+		}
+	}
+
+	derived := vals.Copy()
+	if len(changes) > 0 {
+		if err := derived.UpdateWithChangeSet(changes); err != nil {
+			return nil, err
+		}
+	}
+	derived.IncrementProposerPriority(1)
+	return derived, nil
+}
+
+// equalProposerPriorities returns true if the validator sets have the same validators with the
+// same proposer priorities, in the same order.
+func equalProposerPriorities(vals, other *types.ValidatorSet) bool {
+	if vals.Size() != other.Size() {
+		return false
+	}
+	for i, val := range vals.Validators {
+		if !bytes.Equal(val.Address, other.Validators[i].Address) ||
+			val.ProposerPriority != other.Validators[i].ProposerPriority {
+			return false
+		}
+	}
+	return true
+}
+
+// saveNodeSnapshot stores the verified node snapshot items in the state and block stores. The
+// commit is used as the seen commit of the last block, the other blocks use the last commit of
+// their successor.
+func saveNodeSnapshot(stateStore sm.Store, blockStore sm.BlockStore, commit *types.Commit,
+	items []*nodeSnapshotItem) error {
+	if blockStore.Height() != 0 {
+		return fmt.Errorf("block store is not empty, it has blocks %v to %v", blockStore.Base(), blockStore.Height())
+	}
+	for i, item := range items {
+		height := item.block.Height
+		if err := stateStore.SaveValidatorSets(height, height, item.validators); err != nil {
+			return err
+		}
+		if err := stateStore.SaveConsensusParams(height, item.consensusParams); err != nil {
+			return err
+		}
+		seenCommit := commit
+		if i < len(items)-1 {
+			seenCommit = items[i+1].block.LastCommit
+		}
+		blockStore.SaveBlock(item.block, item.block.MakePartSet(types.BlockPartSizeBytes), seenCommit)
+	}
+	return nil
+}
+
+// nodeChunk is a chunk of a node snapshot received from a peer.
+type nodeChunk struct {
+	Height  uint64
+	Base    uint64
+	Index   uint32
+	Chunk   []byte
+	Missing bool
+	Sender  p2p.ID
+}
+
+// nodeSnapshotFetcher discovers node snapshots for a given height range across peers, and fetches
+// their chunks.
+type nodeSnapshotFetcher struct {
+	height uint64
+	base   uint64
+
+	mtx           tmsync.Mutex
+	snapshots     map[snapshotKey]*nodeSnapshot
+	snapshotPeers map[snapshotKey]map[p2p.ID]p2p.Peer
+	chunkCh       chan *nodeChunk
+}
+
+// newNodeSnapshotFetcher creates a new node snapshot fetcher.
+func newNodeSnapshotFetcher(height, base uint64) *nodeSnapshotFetcher {
+	return &nodeSnapshotFetcher{
+		height:        height,
+		base:          base,
+		snapshots:     make(map[snapshotKey]*nodeSnapshot),
+		snapshotPeers: make(map[snapshotKey]map[p2p.ID]p2p.Peer),
+		chunkCh:       make(chan *nodeChunk, 16),
+	}
+}
+
+// AddSnapshot adds a node snapshot advertised by a peer. It returns true if the snapshot was new.
+func (f *nodeSnapshotFetcher) AddSnapshot(peer p2p.Peer, snapshot *nodeSnapshot) (bool, error) {
+	if snapshot.Height != f.height || snapshot.Base != f.base {
+		return false, fmt.Errorf("unexpected node snapshot for heights %v to %v", snapshot.Base, snapshot.Height)
+	}
+	if snapshot.Chunks == 0 || uint64(snapshot.Chunks) > maxNodeSnapshotChunks(snapshot.Height, snapshot.Base) {
+		return false, fmt.Errorf("node snapshot for heights %v to %v can't have %v chunks",
+			snapshot.Base, snapshot.Height, snapshot.Chunks)
+	}
+	key := snapshot.Key()
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.snapshotPeers[key] == nil {
+		f.snapshotPeers[key] = make(map[p2p.ID]p2p.Peer)
+	}
+	f.snapshotPeers[key][peer.ID()] = peer
+	if _, ok := f.snapshots[key]; ok {
+		return false, nil
+	}
+	f.snapshots[key] = snapshot
+	return true, nil
+}
+
+// AddChunk passes a received chunk to the fetch in progress, if any. Chunks that are not expected
+// are dropped.
+func (f *nodeSnapshotFetcher) AddChunk(chunk *nodeChunk) {
+	select {
+	case f.chunkCh <- chunk:
+	default:
+	}
+}
+
+// Ranked returns the discovered node snapshots, ordered by the number of peers providing them.
+func (f *nodeSnapshotFetcher) Ranked() []*nodeSnapshot {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	candidates := make([]*nodeSnapshot, 0, len(f.snapshots))
+	for _, snapshot := range f.snapshots {
+		candidates = append(candidates, snapshot)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return len(f.snapshotPeers[candidates[i].Key()]) > len(f.snapshotPeers[candidates[j].Key()])
+	})
+	return candidates
+}
+
+// peers returns the peers providing a node snapshot, in random order.
+func (f *nodeSnapshotFetcher) peers(snapshot *nodeSnapshot) []p2p.Peer {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	peers := make([]p2p.Peer, 0, len(f.snapshotPeers[snapshot.Key()]))
+	for _, peer := range f.snapshotPeers[snapshot.Key()] {
+		peers = append(peers, peer)
+	}
+	rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+	return peers
+}
+
+// Fetch fetches all chunks of a node snapshot, trying each peer providing the snapshot in turn
+// until one returns the chunk within the timeout. Each chunk is passed to the verifier as it
+// arrives, so an invalid snapshot is abandoned at its first bad chunk, and the verified snapshot
+// items are returned.
+func (f *nodeSnapshotFetcher) Fetch(snapshot *nodeSnapshot, timeout time.Duration,
+	verifier *nodeSnapshotVerifier) ([]*nodeSnapshotItem, error) {
+	peers := f.peers(snapshot)
+	for index := uint32(0); index < snapshot.Chunks; index++ {
+		chunk, err := f.fetchChunk(peers, index, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if err = verifier.Add(chunk); err != nil {
+			return nil, fmt.Errorf("invalid node snapshot chunk %v: %w", index, err)
+		}
+	}
+	return verifier.Finish()
+}
+
+func (f *nodeSnapshotFetcher) fetchChunk(peers []p2p.Peer, index uint32, timeout time.Duration) ([]byte, error) {
+	for _, peer := range peers {
+		peer.Send(NodeChunkChannel, mustEncodeMsg(&ocssproto.NodeChunkRequest{
+			Height: f.height,
+			Base:   f.base,
+			Index:  index,
+		}))
+		timer := time.NewTimer(timeout)
+	wait:
+		for {
+			select {
+			case chunk := <-f.chunkCh:
+				if chunk.Height != f.height || chunk.Base != f.base || chunk.Index != index ||
+					chunk.Sender != peer.ID() {
+					continue
+				}
+				if chunk.Missing {
+					break wait
+				}
+				timer.Stop()
+				return chunk.Chunk, nil
+			case <-timer.C:
+				break wait
+			}
+		}
+		timer.Stop()
+	}
+	return nil, fmt.Errorf("failed to fetch node snapshot chunk %v: %w", index, errTimeout)
+}
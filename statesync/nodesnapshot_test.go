@@ -0,0 +1,400 @@
+package statesync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/Finschia/ostracon/crypto/vrf"
+	"github.com/Finschia/ostracon/libs/protoio"
+	"github.com/Finschia/ostracon/p2p"
+	p2pmocks "github.com/Finschia/ostracon/p2p/mocks"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
+	sm "github.com/Finschia/ostracon/state"
+	"github.com/Finschia/ostracon/store"
+	"github.com/Finschia/ostracon/types"
+	tmtime "github.com/Finschia/ostracon/types/time"
+)
+
+// makeNodeSnapshotChain generates a chain of the given height, returning the last state, the
+// commit for the last block and the stores containing the chain.
+func makeNodeSnapshotChain(t *testing.T, height int64) (sm.State, *types.Commit, sm.Store, *store.BlockStore) {
+	privVal := types.NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:     "test-chain",
+		GenesisTime: tmtime.Now(),
+		Validators:  []types.GenesisValidator{{PubKey: pubKey, Power: 10}},
+	})
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, stateStore.Save(state))
+
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for h := int64(1); h <= height; h++ {
+		proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+		require.NoError(t, err)
+		block, parts := state.MakeBlock(h, []types.Tx{types.Tx(time.Now().String())}, commit, nil,
+			state.Validators.Validators[0].Address, 0, proof)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		voteSet := types.NewVoteSet(state.ChainID, h, 0, tmproto.PrecommitType, state.Validators)
+		commit, err = types.MakeCommit(blockID, h, 0, voteSet, []types.PrivValidator{privVal}, tmtime.Now())
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, parts, commit)
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators.Copy()
+		state.LastProofHash, err = vrf.ProofToHash(vrf.Proof(proof))
+		require.NoError(t, err)
+		require.NoError(t, stateStore.Save(state))
+	}
+	return state, commit, stateStore, blockStore
+}
+
+func TestNodeSnapshot_BuildAndRestore(t *testing.T) {
+	state, commit, stateStore, blockStore := makeNodeSnapshotChain(t, 5)
+
+	snapshot, err := buildNodeSnapshot(stateStore, blockStore, 2, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, snapshot.Height)
+	assert.EqualValues(t, 2, snapshot.Base)
+	assert.EqualValues(t, 1, snapshot.Chunks)
+	require.Len(t, snapshot.chunks, 1)
+
+	verifier, err := newNodeSnapshotVerifier(state, commit, snapshot)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Add(snapshot.chunks[0]))
+	items, err := verifier.Finish()
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+	for i, item := range items {
+		assert.EqualValues(t, 2+i, item.block.Height)
+	}
+
+	restoredStateStore := sm.NewStore(dbm.NewMemDB())
+	restoredBlockStore := store.NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, saveNodeSnapshot(restoredStateStore, restoredBlockStore, commit, items))
+
+	assert.EqualValues(t, 2, restoredBlockStore.Base())
+	assert.EqualValues(t, 5, restoredBlockStore.Height())
+	for h := int64(2); h <= 5; h++ {
+		assert.Equal(t, blockStore.LoadBlock(h).Hash(), restoredBlockStore.LoadBlock(h).Hash())
+		assert.Equal(t, blockStore.LoadBlockMeta(h).BlockID, restoredBlockStore.LoadBlockMeta(h).BlockID)
+		assert.Equal(t, blockStore.LoadSeenCommit(h).Hash(), restoredBlockStore.LoadSeenCommit(h).Hash())
+
+		vals, err := restoredStateStore.LoadValidators(h)
+		require.NoError(t, err)
+		expectVals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err)
+		assert.Equal(t, expectVals.Hash(), vals.Hash())
+
+		params, err := restoredStateStore.LoadConsensusParams(h)
+		require.NoError(t, err)
+		assert.Equal(t, state.ConsensusParams, params)
+	}
+
+	// The block store is no longer empty, so the snapshot can't be saved again.
+	require.Error(t, saveNodeSnapshot(restoredStateStore, restoredBlockStore, commit, items))
+}
+
+func TestNodeSnapshot_Build_Unavailable(t *testing.T) {
+	_, _, stateStore, blockStore := makeNodeSnapshotChain(t, 3)
+
+	_, err := buildNodeSnapshot(stateStore, blockStore, 1, 4)
+	require.Error(t, err)
+}
+
+// splitNodeSnapshot splits the contents of a node snapshot into chunks of the given size.
+func splitNodeSnapshot(snapshot *nodeSnapshot, size int) [][]byte {
+	var data []byte
+	for _, chunk := range snapshot.chunks {
+		data = append(data, chunk...)
+	}
+	var chunks [][]byte
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return append(chunks, data)
+}
+
+// decodeNodeSnapshotItems decodes the items of a node snapshot without verifying them.
+func decodeNodeSnapshotItems(t *testing.T, snapshot *nodeSnapshot) []*nodeSnapshotItem {
+	var items []*nodeSnapshotItem
+	for _, chunk := range splitNodeSnapshot(snapshot, nodeSnapshotChunkSize) {
+		reader := protoio.NewDelimitedReader(bytes.NewReader(chunk), nodeSnapshotItemSize)
+		for {
+			pb := &ocssproto.NodeSnapshotItem{}
+			_, err := reader.ReadMsg(pb)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			item, err := nodeSnapshotItemFromProto(pb)
+			require.NoError(t, err)
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// verifyNodeSnapshotItems verifies the node snapshot items, in descending height order, the way
+// the verifier does as the chunks arrive.
+func verifyNodeSnapshotItems(state sm.State, commit *types.Commit, snapshot *nodeSnapshot,
+	items []*nodeSnapshotItem) error {
+	verifier, err := newNodeSnapshotVerifier(state, commit, snapshot)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := verifier.verifyItem(item); err != nil {
+			return err
+		}
+	}
+	if len(items) != int(snapshot.Height-snapshot.Base+1) {
+		return fmt.Errorf("expected %v heights in node snapshot, got %v", snapshot.Height-snapshot.Base+1, len(items))
+	}
+	return nil
+}
+
+func TestNodeSnapshotVerifier_Chunks(t *testing.T) {
+	state, commit, stateStore, blockStore := makeNodeSnapshotChain(t, 5)
+	snapshot, err := buildNodeSnapshot(stateStore, blockStore, 1, 5)
+	require.NoError(t, err)
+
+	// the items are decoded and verified across arbitrary chunk boundaries
+	for _, size := range []int{1, 7, 100, len(snapshot.chunks[0])} {
+		verifier, err := newNodeSnapshotVerifier(state, commit, snapshot)
+		require.NoError(t, err)
+		chunks := splitNodeSnapshot(snapshot, size)
+		for _, chunk := range chunks {
+			require.NoError(t, verifier.Add(chunk))
+		}
+		items, err := verifier.Finish()
+		require.NoError(t, err)
+		assert.Len(t, items, 5)
+	}
+
+	// a tampered chunk is rejected as soon as it completes an item
+	chunks := splitNodeSnapshot(snapshot, 100)
+	tampered := make([][]byte, len(chunks))
+	copy(tampered, chunks)
+	tampered[1] = append([]byte{}, chunks[1]...)
+	tampered[1][0]++
+	verifier, err := newNodeSnapshotVerifier(state, commit, snapshot)
+	require.NoError(t, err)
+	var failedAt int
+	for i, chunk := range tampered {
+		if err = verifier.Add(chunk); err != nil {
+			failedAt = i
+			break
+		}
+	}
+	require.Error(t, err)
+	assert.Less(t, failedAt, len(tampered)-1)
+
+	// a snapshot with the wrong hash is rejected once complete
+	verifier, err = newNodeSnapshotVerifier(state, commit, &nodeSnapshot{
+		Height: snapshot.Height, Base: snapshot.Base, Chunks: snapshot.Chunks, Hash: []byte{1}})
+	require.NoError(t, err)
+	require.NoError(t, verifier.Add(snapshot.chunks[0]))
+	_, err = verifier.Finish()
+	require.Error(t, err)
+
+	// an incomplete snapshot is rejected
+	verifier, err = newNodeSnapshotVerifier(state, commit, snapshot)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Add(chunks[0]))
+	_, err = verifier.Finish()
+	require.Error(t, err)
+
+	// an item above the maximum size is rejected before it is buffered
+	verifier, err = newNodeSnapshotVerifier(state, commit, snapshot)
+	require.NoError(t, err)
+	require.Error(t, verifier.Add(binary.AppendUvarint(nil, uint64(nodeSnapshotItemSize)+1)))
+}
+
+func TestNodeSnapshot_Verify(t *testing.T) {
+	state, commit, stateStore, blockStore := makeNodeSnapshotChain(t, 4)
+	snapshot, err := buildNodeSnapshot(stateStore, blockStore, 1, 4)
+	require.NoError(t, err)
+	otherVals, _ := types.RandValidatorSet(1, 10)
+
+	// the items are in descending height order, from 4 to 1
+	testcases := map[string]struct {
+		modify func(state *sm.State, commit *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem
+	}{
+		"wrong last block ID": {func(state *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			state.LastBlockID = types.BlockID{Hash: []byte{1}}
+			return items
+		}},
+		"wrong height": {func(state *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			state.LastBlockHeight++
+			return items
+		}},
+		"wrong chain ID": {func(state *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			state.ChainID = "other-chain"
+			return items
+		}},
+		"missing block": {func(_ *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			return append(items[:1:1], items[2:]...)
+		}},
+		"extra block": {func(_ *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			return append(items, items[len(items)-1])
+		}},
+		"wrong validators": {func(_ *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			items[2].validators = otherVals
+			return items
+		}},
+		"wrong proposer priorities": {func(_ *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			items[2].validators.Validators[0].ProposerPriority += 7
+			return items
+		}},
+		"wrong last proposer priorities": {func(state *sm.State, _ *types.Commit,
+			items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			state.LastValidators = state.LastValidators.Copy()
+			state.LastValidators.Validators[0].ProposerPriority += 7
+			return items
+		}},
+		"wrong consensus params": {func(_ *sm.State, _ *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			items[1].consensusParams.Block.MaxGas++
+			return items
+		}},
+		"wrong commit": {func(_ *sm.State, commit *types.Commit, items []*nodeSnapshotItem) []*nodeSnapshotItem {
+			commit.BlockID = types.BlockID{Hash: []byte{1}}
+			return items
+		}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			items := decodeNodeSnapshotItems(t, snapshot)
+			require.NoError(t, verifyNodeSnapshotItems(state, commit, snapshot, items))
+
+			state := state
+			commit := *commit
+			items = tc.modify(&state, &commit, items)
+			require.Error(t, verifyNodeSnapshotItems(state, &commit, snapshot, items))
+		})
+	}
+}
+
+func TestNodeSnapshot_NextProposerPriorities(t *testing.T) {
+	vals, _ := types.RandValidatorSet(4, 10)
+	vals.IncrementProposerPriority(3)
+	newVal, _ := types.RandValidator(false, 30)
+
+	// the validator changes of the state: one validator is added, one removed and one changes its
+	// power
+	next := vals.Copy()
+	require.NoError(t, next.UpdateWithChangeSet([]*types.Validator{
+		newVal,
+		{Address: vals.Validators[0].Address, PubKey: vals.Validators[0].PubKey, VotingPower: 0},
+		{Address: vals.Validators[1].Address, PubKey: vals.Validators[1].PubKey, VotingPower: 20},
+	}))
+	next.IncrementProposerPriority(1)
+
+	derived, err := nextProposerPriorities(vals, next)
+	require.NoError(t, err)
+	assert.True(t, equalProposerPriorities(next, derived))
+
+	next.Validators[2].ProposerPriority++
+	assert.False(t, equalProposerPriorities(next, derived))
+}
+
+func TestNodeSnapshotFetcher_Fetch(t *testing.T) {
+	state, commit, stateStore, blockStore := makeNodeSnapshotChain(t, 5)
+	built, err := buildNodeSnapshot(stateStore, blockStore, 2, 5)
+	require.NoError(t, err)
+	chunks := splitNodeSnapshot(built, len(built.chunks[0])/2+1)
+	require.Len(t, chunks, 2)
+	snapshot := &nodeSnapshot{Height: 5, Base: 2, Chunks: 2, Hash: built.Hash}
+	fetcher := newNodeSnapshotFetcher(5, 2)
+
+	// peerA doesn't have the chunks, peerB does.
+	peerA := &p2pmocks.Peer{}
+	peerA.On("ID").Return(p2p.ID("a"))
+	peerA.On("Send", NodeChunkChannel, mock.Anything).Run(func(args mock.Arguments) {
+		msg, err := decodeMsg(args[1].([]byte))
+		require.NoError(t, err)
+		req := msg.(*ocssproto.NodeChunkRequest)
+		go fetcher.AddChunk(&nodeChunk{Height: req.Height, Base: req.Base, Index: req.Index,
+			Missing: true, Sender: "a"})
+	}).Return(true)
+	peerB := &p2pmocks.Peer{}
+	peerB.On("ID").Return(p2p.ID("b"))
+	peerB.On("Send", NodeChunkChannel, mock.Anything).Run(func(args mock.Arguments) {
+		msg, err := decodeMsg(args[1].([]byte))
+		require.NoError(t, err)
+		req := msg.(*ocssproto.NodeChunkRequest)
+		go fetcher.AddChunk(&nodeChunk{Height: req.Height, Base: req.Base, Index: req.Index,
+			Chunk: chunks[req.Index], Sender: "b"})
+	}).Return(true)
+
+	added, err := fetcher.AddSnapshot(peerA, snapshot)
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = fetcher.AddSnapshot(peerB, &nodeSnapshot{Height: 5, Base: 2, Chunks: 2, Hash: built.Hash})
+	require.NoError(t, err)
+	assert.False(t, added)
+	_, err = fetcher.AddSnapshot(peerB, &nodeSnapshot{Height: 4, Base: 2, Chunks: 2, Hash: built.Hash})
+	require.Error(t, err)
+
+	require.Equal(t, []*nodeSnapshot{snapshot}, fetcher.Ranked())
+
+	verifier, err := newNodeSnapshotVerifier(state, commit, snapshot)
+	require.NoError(t, err)
+	items, err := fetcher.Fetch(snapshot, time.Second, verifier)
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+	for i, item := range items {
+		assert.Equal(t, blockStore.LoadBlock(int64(2+i)).Hash(), item.block.Hash())
+	}
+}
+
+func TestNodeSnapshotFetcher_AddSnapshot_Chunks(t *testing.T) {
+	fetcher := newNodeSnapshotFetcher(5, 2)
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("a"))
+
+	maxChunks := maxNodeSnapshotChunks(5, 2)
+	_, err := fetcher.AddSnapshot(peer, &nodeSnapshot{Height: 5, Base: 2, Chunks: uint32(maxChunks), Hash: []byte{1}})
+	require.NoError(t, err)
+	_, err = fetcher.AddSnapshot(peer, &nodeSnapshot{Height: 5, Base: 2, Chunks: uint32(maxChunks) + 1,
+		Hash: []byte{1}})
+	require.Error(t, err)
+	_, err = fetcher.AddSnapshot(peer, &nodeSnapshot{Height: 5, Base: 2, Chunks: 0, Hash: []byte{1}})
+	require.Error(t, err)
+}
+
+func TestNodeSnapshotFetcher_Fetch_Timeout(t *testing.T) {
+	state, commit, _, _ := makeNodeSnapshotChain(t, 5)
+	snapshot := &nodeSnapshot{Height: 5, Base: 2, Chunks: 1, Hash: []byte{1}}
+	fetcher := newNodeSnapshotFetcher(5, 2)
+
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("a"))
+	peer.On("Send", NodeChunkChannel, mock.Anything).Return(true)
+	_, err := fetcher.AddSnapshot(peer, snapshot)
+	require.NoError(t, err)
+
+	verifier, err := newNodeSnapshotVerifier(state, commit, snapshot)
+	require.NoError(t, err)
+	_, err = fetcher.Fetch(snapshot, 100*time.Millisecond, verifier)
+	require.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package statesync
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	tmsync "github.com/Finschia/ostracon/libs/sync"
+	"github.com/Finschia/ostracon/p2p"
+)
+
+const (
+	// minChunkRequestTimeout is the lowest adaptive chunk request timeout.
+	minChunkRequestTimeout = time.Second
+	// chunkTimeoutFactor is the multiple of a peer's average chunk latency to wait for a chunk.
+	chunkTimeoutFactor = 4
+	// peerScoreWeight is the weight of a new sample in the moving averages of a peer's score.
+	peerScoreWeight = 0.3
+	// maxPeerFailures caps the number of consecutive failures counted for a peer.
+	maxPeerFailures = 8
+	// pickScoreRatio is the lowest score, relative to the best one, of the peers picked from.
+	pickScoreRatio = 0.8
+)
+
+// peerScorer scores peers by their chunk throughput, so that chunks are requested from the fastest
+// peers, and adapts the chunk request timeout to the latency of each peer.
+type peerScorer struct {
+	tmsync.Mutex
+	maxTimeout time.Duration
+	peers      map[p2p.ID]*peerScore
+}
+
+// peerScore contains the chunk fetching statistics of a peer.
+type peerScore struct {
+	throughput float64       // moving average of bytes per second, 0 if there are no samples
+	latency    time.Duration // moving average of the chunk response time
+	failures   int           // consecutive failed requests
+	pending    map[uint32]*chunkRequest
+}
+
+// chunkRequest is a pending chunk request.
+type chunkRequest struct {
+	sent   time.Time
+	failed chan struct{}
+}
+
+// newPeerScorer creates a new peer scorer. The chunk request timeout never exceeds maxTimeout.
+func newPeerScorer(maxTimeout time.Duration) *peerScorer {
+	return &peerScorer{
+		maxTimeout: maxTimeout,
+		peers:      make(map[p2p.ID]*peerScore),
+	}
+}
+
+// get returns the score of the peer, creating it if needed. The caller must hold the mutex lock.
+func (s *peerScorer) get(peerID p2p.ID) *peerScore {
+	score, ok := s.peers[peerID]
+	if !ok {
+		score = &peerScore{pending: make(map[uint32]*chunkRequest)}
+		s.peers[peerID] = score
+	}
+	return score
+}
+
+// Pick returns a random peer among the ones with the best scores, or nil if there are no peers.
+// A peer's score is its throughput, divided by its number of pending requests and lowered for each
+// consecutive failure. Peers without samples score highest, so that every peer gets tried. Peers
+// scoring close to the best one are picked at random, so that concurrent fetchers spread their
+// requests instead of all sending them to the same peer.
+func (s *peerScorer) Pick(peers []p2p.Peer) p2p.Peer {
+	s.Lock()
+	defer s.Unlock()
+	if len(peers) == 0 {
+		return nil
+	}
+	values := make([]float64, len(peers))
+	bestScore := 0.0
+	for i, peer := range peers {
+		score := s.get(peer.ID())
+		value := score.throughput
+		if value == 0 {
+			value = math.MaxFloat64
+		}
+		values[i] = value / float64(1+len(score.pending)) / float64(uint(1)<<score.failures)
+		if values[i] > bestScore {
+			bestScore = values[i]
+		}
+	}
+	best := make([]p2p.Peer, 0, len(peers))
+	for i, peer := range peers {
+		if values[i] >= bestScore*pickScoreRatio {
+			best = append(best, peer)
+		}
+	}
+	return best[rand.Intn(len(best))] // nolint:gosec // G404: Use of weak random number generator
+}
+
+// Requested records a chunk request sent to the peer. It returns a channel that is closed if the
+// request fails before it times out, e.g. because the peer sent an invalid chunk.
+func (s *peerScorer) Requested(peerID p2p.ID, index uint32) <-chan struct{} {
+	s.Lock()
+	defer s.Unlock()
+	req := &chunkRequest{sent: time.Now(), failed: make(chan struct{})}
+	s.get(peerID).pending[index] = req
+	return req.failed
+}
+
+// Received records a chunk received from the peer, updating its score. Chunks that weren't
+// requested from the peer, or whose request already failed, are ignored.
+func (s *peerScorer) Received(peerID p2p.ID, index uint32, size int) {
+	s.Lock()
+	defer s.Unlock()
+	score, ok := s.peers[peerID]
+	if !ok {
+		return
+	}
+	req, ok := score.pending[index]
+	if !ok {
+		return
+	}
+	delete(score.pending, index)
+
+	elapsed := time.Since(req.sent)
+	if elapsed <= 0 {
+		elapsed = time.Nanosecond
+	}
+	throughput := float64(size) / elapsed.Seconds()
+	if score.throughput == 0 {
+		score.throughput = throughput
+		score.latency = elapsed
+	} else {
+		score.throughput = (1-peerScoreWeight)*score.throughput + peerScoreWeight*throughput
+		score.latency = time.Duration((1-peerScoreWeight)*float64(score.latency) +
+			peerScoreWeight*float64(elapsed))
+	}
+	score.failures = 0
+}
+
+// Failed records a failed chunk request to the peer, e.g. a timeout or an invalid chunk.
+func (s *peerScorer) Failed(peerID p2p.ID, index uint32) {
+	s.Lock()
+	defer s.Unlock()
+	score, ok := s.peers[peerID]
+	if !ok {
+		return
+	}
+	if req, ok := score.pending[index]; ok {
+		close(req.failed)
+		delete(score.pending, index)
+	}
+	if score.failures < maxPeerFailures {
+		score.failures++
+	}
+}
+
+// Timeout returns the chunk request timeout for the peer. It is a multiple of the peer's average
+// latency, doubled for each consecutive failure, and the maximum timeout for peers without samples.
+func (s *peerScorer) Timeout(peerID p2p.ID) time.Duration {
+	s.Lock()
+	defer s.Unlock()
+	score, ok := s.peers[peerID]
+	if !ok || score.throughput == 0 {
+		return s.maxTimeout
+	}
+	timeout := chunkTimeoutFactor * score.latency * time.Duration(uint(1)<<score.failures)
+	if timeout < minChunkRequestTimeout {
+		timeout = minChunkRequestTimeout
+	}
+	if timeout > s.maxTimeout {
+		timeout = s.maxTimeout
+	}
+	return timeout
+}
+
+// Remove removes the peer, failing its pending requests.
+func (s *peerScorer) Remove(peerID p2p.ID) {
+	s.Lock()
+	defer s.Unlock()
+	score, ok := s.peers[peerID]
+	if !ok {
+		return
+	}
+	for _, req := range score.pending {
+		close(req.failed)
+	}
+	delete(s.peers, peerID)
+}
@@ -0,0 +1,123 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Finschia/ostracon/p2p"
+	p2pmocks "github.com/Finschia/ostracon/p2p/mocks"
+)
+
+func TestPeerScorer_Pick(t *testing.T) {
+	peerA := &p2pmocks.Peer{}
+	peerA.On("ID").Return(p2p.ID("a"))
+	peerB := &p2pmocks.Peer{}
+	peerB.On("ID").Return(p2p.ID("b"))
+	peers := []p2p.Peer{peerA, peerB}
+
+	s := newPeerScorer(time.Minute)
+	assert.Nil(t, s.Pick(nil))
+
+	// Peers without samples are tried first, spreading requests between them.
+	first := s.Pick(peers)
+	require.Contains(t, peers, first)
+	s.Requested(first.ID(), 0)
+	second := s.Pick(peers)
+	require.NotEqual(t, first, second)
+	s.Requested(second.ID(), 1)
+
+	// Once both have sent chunks, the peer with the higher throughput is preferred.
+	time.Sleep(10 * time.Millisecond)
+	s.Received(first.ID(), 0, 1000)
+	s.Received(second.ID(), 1, 10)
+	require.Equal(t, first, s.Pick(peers))
+
+	// Failures lower the score.
+	for i := uint32(2); i < 2+maxPeerFailures; i++ {
+		s.Requested(first.ID(), i)
+		s.Failed(first.ID(), i)
+	}
+	require.Equal(t, second, s.Pick(peers))
+
+	// Removed peers are back to having no samples.
+	s.Remove(first.ID())
+	require.Equal(t, first, s.Pick(peers))
+}
+
+func TestPeerScorer_Pick_Random(t *testing.T) {
+	peerA := &p2pmocks.Peer{}
+	peerA.On("ID").Return(p2p.ID("a"))
+	peerB := &p2pmocks.Peer{}
+	peerB.On("ID").Return(p2p.ID("b"))
+	peerC := &p2pmocks.Peer{}
+	peerC.On("ID").Return(p2p.ID("c"))
+	peers := []p2p.Peer{peerA, peerB, peerC}
+
+	s := newPeerScorer(time.Minute)
+	for _, peer := range peers {
+		s.get(peer.ID()).throughput = 1000
+	}
+	s.peers["b"].throughput = 900
+	s.peers["c"].throughput = 100
+
+	// Peers scoring close to the best one are picked at random, others aren't picked at all.
+	picked := make(map[p2p.Peer]int)
+	for i := 0; i < 100; i++ {
+		picked[s.Pick(peers)]++
+	}
+	assert.Positive(t, picked[peerA])
+	assert.Positive(t, picked[peerB])
+	assert.Zero(t, picked[peerC])
+}
+
+func TestPeerScorer_Timeout(t *testing.T) {
+	s := newPeerScorer(10 * time.Second)
+
+	// Peers without samples get the maximum timeout.
+	assert.Equal(t, 10*time.Second, s.Timeout("a"))
+
+	// Fast peers get the minimum timeout, doubled for each failure up to the maximum.
+	s.Requested("a", 0)
+	s.Received("a", 0, 100)
+	assert.Equal(t, minChunkRequestTimeout, s.Timeout("a"))
+	s.peers["a"].latency = time.Second
+	assert.Equal(t, 4*time.Second, s.Timeout("a"))
+	s.Requested("a", 1)
+	s.Failed("a", 1)
+	assert.Equal(t, 8*time.Second, s.Timeout("a"))
+	s.Requested("a", 2)
+	s.Failed("a", 2)
+	assert.Equal(t, 10*time.Second, s.Timeout("a"))
+
+	// A received chunk resets the failures.
+	s.Requested("a", 3)
+	s.Received("a", 3, 100)
+	assert.Less(t, s.Timeout("a"), 4*time.Second)
+}
+
+func TestPeerScorer_Failed(t *testing.T) {
+	s := newPeerScorer(time.Minute)
+
+	failed := s.Requested("a", 0)
+	select {
+	case <-failed:
+		t.Fatal("request failed unexpectedly")
+	default:
+	}
+	s.Failed("a", 0)
+	_, ok := <-failed
+	assert.False(t, ok)
+
+	// Chunks whose request already failed don't update the score.
+	s.Received("a", 0, 100)
+	assert.Zero(t, s.peers["a"].throughput)
+
+	// Removing a peer fails its pending requests.
+	failed = s.Requested("a", 1)
+	s.Remove("a")
+	_, ok = <-failed
+	assert.False(t, ok)
+}
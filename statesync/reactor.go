@@ -2,6 +2,7 @@ package statesync
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/Finschia/ostracon/config"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
 	"github.com/Finschia/ostracon/p2p"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 	"github.com/Finschia/ostracon/proxy"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
@@ -21,8 +24,19 @@ const (
 	SnapshotChannel = byte(0x60)
 	// ChunkChannel exchanges chunk contents
 	ChunkChannel = byte(0x61)
+	// LightBlockChannel exchanges light blocks
+	LightBlockChannel = byte(0x62)
+	// ParamsChannel exchanges consensus params
+	ParamsChannel = byte(0x63)
+	// NodeSnapshotChannel exchanges node snapshot metadata
+	NodeSnapshotChannel = byte(0x64)
+	// NodeChunkChannel exchanges node snapshot chunk contents
+	NodeChunkChannel = byte(0x65)
 	// recentSnapshots is the number of recent snapshots to send and receive per peer.
 	recentSnapshots = 10
+	// nodeSnapshotInterval is how often the node checks for a new app snapshot to build a node
+	// snapshot for.
+	nodeSnapshotInterval = 10 * time.Second
 )
 
 // Reactor handles state sync, both restoring snapshots for the local node and serving snapshots
@@ -30,15 +44,27 @@ const (
 type Reactor struct {
 	p2p.BaseReactor
 
-	cfg       config.StateSyncConfig
-	conn      proxy.AppConnSnapshot
-	connQuery proxy.AppConnQuery
-	tempDir   string
+	cfg        config.StateSyncConfig
+	conn       proxy.AppConnSnapshot
+	connQuery  proxy.AppConnQuery
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	tempDir    string
 
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
-	mtx    tmsync.RWMutex
-	syncer *syncer
+	mtx         tmsync.RWMutex
+	syncer      *syncer
+	nodeFetcher *nodeSnapshotFetcher
+
+	// dispatcher routes light block and params responses to the requests made by backfill and
+	// the p2p state provider.
+	dispatcher *dispatcher
+
+	// The served node snapshot, built in the background for the latest app snapshot and kept
+	// since peers fetch its chunks one by one.
+	nodeSnapshotMtx tmsync.Mutex
+	nodeSnapshot    *nodeSnapshot
 }
 
 // NewReactor creates a new state sync reactor.
@@ -46,14 +72,20 @@ func NewReactor(
 	cfg config.StateSyncConfig,
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
 	async bool,
 	recvBufSize int,
 ) *Reactor {
 
 	r := &Reactor{
-		cfg:       cfg,
-		conn:      conn,
-		connQuery: connQuery,
+		cfg:        cfg,
+		conn:       conn,
+		connQuery:  connQuery,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		tempDir:    cfg.TempDir,
+		dispatcher: newDispatcher(),
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r, async, recvBufSize)
 
@@ -75,6 +107,30 @@ func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   10,
 			RecvMessageCapacity: chunkMsgSize,
 		},
+		{
+			ID:                  LightBlockChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: lightBlockMsgSize,
+		},
+		{
+			ID:                  ParamsChannel,
+			Priority:            2,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: paramsMsgSize,
+		},
+		{
+			ID:                  NodeSnapshotChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: snapshotMsgSize,
+		},
+		{
+			ID:                  NodeChunkChannel,
+			Priority:            3,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: chunkMsgSize,
+		},
 	}
 }
 
@@ -85,6 +141,9 @@ func (r *Reactor) OnStart() error {
 	if err != nil {
 		return err
 	}
+	if r.cfg.NodeSnapshotBlocks > 0 && r.conn != nil && r.stateStore != nil && r.blockStore != nil {
+		go r.nodeSnapshotRoutine()
+	}
 	return nil
 }
 
@@ -115,13 +174,13 @@ func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		r.Switch.StopPeerForError(src, err)
+		r.Switch.MarkPeerAsBad(src, err)
 		return
 	}
 	err = validateMsg(msg)
 	if err != nil {
 		r.Logger.Error("Invalid message", "peer", src, "msg", msg, "err", err)
-		r.Switch.StopPeerForError(src, err)
+		r.Switch.MarkPeerAsBad(src, err)
 		return
 	}
 
@@ -223,6 +282,135 @@ func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			r.Logger.Error("Received unknown message %T", msg)
 		}
 
+	case LightBlockChannel:
+		switch msg := msg.(type) {
+		case *ocssproto.LightBlockRequest:
+			r.Logger.Debug("Received light block request", "height", msg.Height, "peer", src.ID())
+			lb, err := r.localLightBlock(int64(msg.Height))
+			if err != nil {
+				r.Logger.Error("Failed to load light block", "height", msg.Height, "err", err)
+			}
+			src.Send(LightBlockChannel, mustEncodeMsg(&ocssproto.LightBlockResponse{LightBlock: lb}))
+
+		case *ocssproto.LightBlockResponse:
+			if err := r.dispatcher.Respond(msg.LightBlock, src.ID()); err != nil {
+				r.Logger.Error("Failed to handle light block response", "peer", src.ID(), "err", err)
+				return
+			}
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
+	case ParamsChannel:
+		switch msg := msg.(type) {
+		case *ocssproto.ParamsRequest:
+			r.Logger.Debug("Received params request", "height", msg.Height, "peer", src.ID())
+			resp, err := r.localParams(int64(msg.Height))
+			if err != nil {
+				// The requester times out, as with an unresponsive peer.
+				r.Logger.Error("Failed to load params", "height", msg.Height, "err", err)
+				return
+			}
+			src.Send(ParamsChannel, mustEncodeMsg(resp))
+
+		case *ocssproto.ParamsResponse:
+			if err := r.dispatcher.RespondParams(msg, src.ID()); err != nil {
+				r.Logger.Error("Failed to handle params response", "peer", src.ID(), "err", err)
+				return
+			}
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
+	case NodeSnapshotChannel:
+		switch msg := msg.(type) {
+		case *ocssproto.NodeSnapshotsRequest:
+			snapshot := r.servedNodeSnapshot(msg.Height, msg.Base)
+			if snapshot == nil {
+				r.Logger.Debug("Node snapshot not available", "height", msg.Height, "base", msg.Base,
+					"peer", src.ID())
+				return
+			}
+			r.Logger.Debug("Advertising node snapshot", "height", snapshot.Height, "base", snapshot.Base,
+				"peer", src.ID())
+			src.Send(chID, mustEncodeMsg(&ocssproto.NodeSnapshotsResponse{
+				Height: snapshot.Height,
+				Base:   snapshot.Base,
+				Chunks: snapshot.Chunks,
+				Hash:   snapshot.Hash,
+			}))
+
+		case *ocssproto.NodeSnapshotsResponse:
+			r.mtx.RLock()
+			defer r.mtx.RUnlock()
+			if r.nodeFetcher == nil {
+				r.Logger.Debug("Received unexpected node snapshot, no restore in progress")
+				return
+			}
+			r.Logger.Debug("Received node snapshot", "height", msg.Height, "base", msg.Base, "peer", src.ID())
+			_, err := r.nodeFetcher.AddSnapshot(src, &nodeSnapshot{
+				Height: msg.Height,
+				Base:   msg.Base,
+				Chunks: msg.Chunks,
+				Hash:   msg.Hash,
+			})
+			if err != nil {
+				r.Logger.Error("Failed to add node snapshot", "height", msg.Height, "base", msg.Base,
+					"peer", src.ID(), "err", err)
+				return
+			}
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
+	case NodeChunkChannel:
+		switch msg := msg.(type) {
+		case *ocssproto.NodeChunkRequest:
+			r.Logger.Debug("Received node chunk request", "height", msg.Height, "base", msg.Base,
+				"chunk", msg.Index, "peer", src.ID())
+			var chunk []byte
+			snapshot := r.servedNodeSnapshot(msg.Height, msg.Base)
+			if snapshot == nil {
+				r.Logger.Debug("Node snapshot not available", "height", msg.Height, "base", msg.Base,
+					"peer", src.ID())
+			} else if msg.Index < snapshot.Chunks {
+				chunk = snapshot.chunks[msg.Index]
+			}
+			r.Logger.Debug("Sending node chunk", "height", msg.Height, "base", msg.Base,
+				"chunk", msg.Index, "peer", src.ID())
+			src.Send(NodeChunkChannel, mustEncodeMsg(&ocssproto.NodeChunkResponse{
+				Height:  msg.Height,
+				Base:    msg.Base,
+				Index:   msg.Index,
+				Chunk:   chunk,
+				Missing: chunk == nil,
+			}))
+
+		case *ocssproto.NodeChunkResponse:
+			r.mtx.RLock()
+			defer r.mtx.RUnlock()
+			if r.nodeFetcher == nil {
+				r.Logger.Debug("Received unexpected node chunk, no restore in progress", "peer", src.ID())
+				return
+			}
+			r.Logger.Debug("Received node chunk", "height", msg.Height, "base", msg.Base,
+				"chunk", msg.Index, "peer", src.ID())
+			r.nodeFetcher.AddChunk(&nodeChunk{
+				Height:  msg.Height,
+				Base:    msg.Base,
+				Index:   msg.Index,
+				Chunk:   msg.Chunk,
+				Missing: msg.Missing,
+				Sender:  src.ID(),
+			})
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
 	default:
 		r.Logger.Error("Received message on invalid channel %x", chID)
 	}
@@ -262,6 +450,124 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	return snapshots, nil
 }
 
+// localLightBlock loads the light block at the given height, or the latest one if 0, from the
+// local stores. It returns nil if the light block is not available.
+func (r *Reactor) localLightBlock(height int64) (*ocproto.LightBlock, error) {
+	if r.stateStore == nil || r.blockStore == nil {
+		return nil, nil
+	}
+	if height == 0 {
+		height = r.blockStore.Height()
+	}
+	meta := r.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, nil
+	}
+	commit := r.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		// the latest height has no canonical commit yet
+		commit = r.blockStore.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, nil
+	}
+	vals, err := r.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &meta.Header,
+			Commit: commit,
+		},
+		ValidatorSet: vals,
+	}
+	return lb.ToProto()
+}
+
+// localParams loads the consensus params and block at the given height from the local stores.
+func (r *Reactor) localParams(height int64) (*ocssproto.ParamsResponse, error) {
+	if r.stateStore == nil || r.blockStore == nil {
+		return nil, errors.New("no stores available")
+	}
+	params, err := r.stateStore.LoadConsensusParams(height)
+	if err != nil {
+		return nil, err
+	}
+	block := r.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("no block at height %v", height)
+	}
+	pbb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &ocssproto.ParamsResponse{
+		Height:          uint64(height),
+		ConsensusParams: params,
+		Block:           pbb,
+	}, nil
+}
+
+// servedNodeSnapshot returns the node snapshot for the given heights if it is the one built in
+// the background, or nil otherwise. Node snapshots are never built on request, so that peers
+// can't make the node load arbitrary ranges of blocks.
+func (r *Reactor) servedNodeSnapshot(height, base uint64) *nodeSnapshot {
+	r.nodeSnapshotMtx.Lock()
+	defer r.nodeSnapshotMtx.Unlock()
+	if r.nodeSnapshot == nil || r.nodeSnapshot.Height != height || r.nodeSnapshot.Base != base {
+		return nil
+	}
+	return r.nodeSnapshot
+}
+
+// nodeSnapshotRoutine builds the node snapshot for the latest app snapshot whenever the app takes
+// a new one, since that is the height state syncing peers most likely restore.
+func (r *Reactor) nodeSnapshotRoutine() {
+	ticker := time.NewTicker(nodeSnapshotInterval)
+	defer ticker.Stop()
+	var lastHeight uint64
+	for {
+		snapshots, err := r.recentSnapshots(1)
+		if err != nil {
+			r.Logger.Error("Failed to list snapshots", "err", err)
+		} else if len(snapshots) > 0 && snapshots[0].Height != lastHeight {
+			lastHeight = snapshots[0].Height
+			if err = r.updateNodeSnapshot(int64(lastHeight)); err != nil {
+				r.Logger.Error("Failed to build node snapshot", "height", lastHeight, "err", err)
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-r.Quit():
+			return
+		}
+	}
+}
+
+// updateNodeSnapshot builds the node snapshot of the last NodeSnapshotBlocks blocks up to the
+// given height, the range RestoreNodeSnapshot requests for it, and serves it in place of the
+// previous one.
+func (r *Reactor) updateNodeSnapshot(height int64) error {
+	state, err := r.stateStore.Load()
+	if err != nil {
+		return err
+	}
+	base := height - r.cfg.NodeSnapshotBlocks + 1
+	if base < state.InitialHeight {
+		base = state.InitialHeight
+	}
+	snapshot, err := buildNodeSnapshot(r.stateStore, r.blockStore, base, height)
+	if err != nil {
+		return err
+	}
+	r.nodeSnapshotMtx.Lock()
+	r.nodeSnapshot = snapshot
+	r.nodeSnapshotMtx.Unlock()
+	r.Logger.Info("Built node snapshot", "height", height, "base", base, "chunks", snapshot.Chunks)
+	return nil
+}
+
 // Sync runs a state sync, returning the new state, previous state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
 func (r *Reactor) Sync(
@@ -289,3 +595,60 @@ func (r *Reactor) Sync(
 	r.mtx.Unlock()
 	return state, previousState, commit, err
 }
+
+// RestoreNodeSnapshot fetches a node snapshot of the last NodeSnapshotBlocks blocks up to the
+// state synced height from peers, and stores the blocks, commits, validator sets and consensus
+// params in the block and state stores. The snapshot is verified against the state and commit
+// returned by Sync. It does nothing if node snapshots are disabled.
+func (r *Reactor) RestoreNodeSnapshot(state sm.State, commit *types.Commit, discoveryTime time.Duration) error {
+	if r.cfg.NodeSnapshotBlocks == 0 {
+		return nil
+	}
+	height := state.LastBlockHeight
+	base := height - r.cfg.NodeSnapshotBlocks + 1
+	if base < state.InitialHeight {
+		base = state.InitialHeight
+	}
+
+	r.mtx.Lock()
+	if r.syncer != nil || r.nodeFetcher != nil {
+		r.mtx.Unlock()
+		return errors.New("a state sync is already in progress")
+	}
+	fetcher := newNodeSnapshotFetcher(uint64(height), uint64(base))
+	r.nodeFetcher = fetcher
+	r.mtx.Unlock()
+	defer func() {
+		r.mtx.Lock()
+		r.nodeFetcher = nil
+		r.mtx.Unlock()
+	}()
+
+	r.Logger.Info("Requesting node snapshots", "height", height, "base", base)
+	r.Switch.Broadcast(NodeSnapshotChannel, mustEncodeMsg(&ocssproto.NodeSnapshotsRequest{
+		Height: uint64(height),
+		Base:   uint64(base),
+	}))
+	if discoveryTime < minimumDiscoveryTime {
+		discoveryTime = minimumDiscoveryTime
+	}
+	time.Sleep(discoveryTime)
+
+	for _, snapshot := range fetcher.Ranked() {
+		verifier, err := newNodeSnapshotVerifier(state, commit, snapshot)
+		if err != nil {
+			return err
+		}
+		items, err := fetcher.Fetch(snapshot, r.cfg.ChunkRequestTimeout, verifier)
+		if err != nil {
+			r.Logger.Error("Rejected node snapshot", "hash", snapshot.Hash, "err", err)
+			continue
+		}
+		if err = saveNodeSnapshot(r.stateStore, r.blockStore, commit, items); err != nil {
+			return err
+		}
+		r.Logger.Info("Restored node snapshot", "height", height, "base", base)
+		return nil
+	}
+	return errNoNodeSnapshots
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/Finschia/ostracon/libs/log"
 	"github.com/Finschia/ostracon/p2p"
 	p2pmocks "github.com/Finschia/ostracon/p2p/mocks"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
 	"github.com/Finschia/ostracon/proxy"
 	proxymocks "github.com/Finschia/ostracon/proxy/mocks"
 	sm "github.com/Finschia/ostracon/state"
@@ -71,7 +72,7 @@ func TestReactor_Receive_ChunkRequest(t *testing.T) {
 
 			// Start a reactor and send a ssproto.ChunkRequest, then wait for and check response
 			cfg := config.DefaultStateSyncConfig()
-			r := NewReactor(*cfg, conn, nil, true, 1000)
+			r := NewReactor(*cfg, conn, nil, nil, nil, true, 1000)
 			err := r.Start()
 			require.NoError(t, err)
 			t.Cleanup(func() {
@@ -149,7 +150,7 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 
 			// Start a reactor and send a SnapshotsRequestMessage, then wait for and check responses
 			cfg := config.DefaultStateSyncConfig()
-			r := NewReactor(*cfg, conn, nil, true, 1000)
+			r := NewReactor(*cfg, conn, nil, nil, nil, true, 1000)
 			err := r.Start()
 			require.NoError(t, err)
 			t.Cleanup(func() {
@@ -168,6 +169,98 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_Receive_NodeSnapshot(t *testing.T) {
+	_, _, stateStore, blockStore := makeNodeSnapshotChain(t, 5)
+	expect, err := buildNodeSnapshot(stateStore, blockStore, 2, 5)
+	require.NoError(t, err)
+
+	// The node snapshot is only built for the latest app snapshot, at height 5, never on request.
+	testcases := map[string]struct {
+		blocks         int64
+		request        *ocssproto.NodeSnapshotsRequest
+		expectSnapshot bool
+	}{
+		"node snapshot is returned":     {4, &ocssproto.NodeSnapshotsRequest{Height: 5, Base: 2}, true},
+		"node snapshots are disabled":   {0, &ocssproto.NodeSnapshotsRequest{Height: 5, Base: 2}, false},
+		"node snapshot of other blocks": {3, &ocssproto.NodeSnapshotsRequest{Height: 5, Base: 2}, false},
+		"node snapshot of other height": {4, &ocssproto.NodeSnapshotsRequest{Height: 4, Base: 1}, false},
+		"node snapshot is unavailable:": {10, &ocssproto.NodeSnapshotsRequest{Height: 6, Base: 2}, false},
+	}
+
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// Mock peer to store responses, if any
+			peer := &p2pmocks.Peer{}
+			peer.On("ID").Return(p2p.ID("id"))
+			var snapshotResponse *ocssproto.NodeSnapshotsResponse
+			var chunkResponse *ocssproto.NodeChunkResponse
+			peer.On("Send", NodeSnapshotChannel, mock.Anything).Run(func(args mock.Arguments) {
+				msg, err := decodeMsg(args[1].([]byte))
+				require.NoError(t, err)
+				snapshotResponse = msg.(*ocssproto.NodeSnapshotsResponse)
+			}).Return(true)
+			peer.On("Send", NodeChunkChannel, mock.Anything).Run(func(args mock.Arguments) {
+				msg, err := decodeMsg(args[1].([]byte))
+				require.NoError(t, err)
+				chunkResponse = msg.(*ocssproto.NodeChunkResponse)
+			}).Return(true)
+
+			// Mock ABCI connection to return the latest app snapshot
+			conn := &proxymocks.AppConnSnapshot{}
+			conn.On("ListSnapshotsSync", abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+				Snapshots: []*abci.Snapshot{{Height: 5, Format: 1, Chunks: 1, Hash: []byte{1}}},
+			}, nil)
+
+			// Start a reactor, wait for it to build its node snapshot and send the requests, then
+			// check the responses
+			cfg := config.DefaultStateSyncConfig()
+			cfg.NodeSnapshotBlocks = tc.blocks
+			r := NewReactor(*cfg, conn, nil, stateStore, blockStore, true, 1000)
+			err := r.Start()
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				if err := r.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+			if tc.blocks > 0 {
+				require.Eventually(t, func() bool {
+					r.nodeSnapshotMtx.Lock()
+					defer r.nodeSnapshotMtx.Unlock()
+					return r.nodeSnapshot != nil
+				}, time.Second, 10*time.Millisecond)
+			}
+
+			r.Receive(NodeSnapshotChannel, peer, mustEncodeMsg(tc.request))
+			r.Receive(NodeChunkChannel, peer, mustEncodeMsg(&ocssproto.NodeChunkRequest{
+				Height: tc.request.Height,
+				Base:   tc.request.Base,
+				Index:  0,
+			}))
+			if !tc.expectSnapshot {
+				assert.Nil(t, snapshotResponse)
+				assert.Equal(t, &ocssproto.NodeChunkResponse{
+					Height: tc.request.Height, Base: tc.request.Base, Index: 0, Missing: true,
+				}, chunkResponse)
+				return
+			}
+			assert.Equal(t, &ocssproto.NodeSnapshotsResponse{
+				Height: expect.Height,
+				Base:   expect.Base,
+				Chunks: expect.Chunks,
+				Hash:   expect.Hash,
+			}, snapshotResponse)
+			assert.Equal(t, &ocssproto.NodeChunkResponse{
+				Height: expect.Height,
+				Base:   expect.Base,
+				Index:  0,
+				Chunk:  expect.chunks[0],
+			}, chunkResponse)
+		})
+	}
+}
+
 func makeTestStateSyncReactor(
 	t *testing.T, appHash string, height int64, snapshot *snapshot, chunks []*chunk) *Reactor {
 	connSnapshot := makeMockAppConnSnapshot(appHash, snapshot, chunks)
@@ -183,7 +276,7 @@ func makeTestStateSyncReactor(
 	initSwitch := func(i int, s *p2p.Switch, p2pConfig *config.P2PConfig) *p2p.Switch {
 		logger := log.TestingLogger()
 		cfg := config.DefaultStateSyncConfig()
-		reactors[i] = NewReactor(*cfg, connSnapshot, connQuery, true, 1000)
+		reactors[i] = NewReactor(*cfg, connSnapshot, connQuery, nil, nil, true, 1000)
 		reactors[i].SetLogger(logger)
 		reactors[i].SetSwitch(s)
 
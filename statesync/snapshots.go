@@ -8,6 +8,7 @@ import (
 
 	tmsync "github.com/Finschia/ostracon/libs/sync"
 	"github.com/Finschia/ostracon/p2p"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
 )
 
 // snapshotKey is a snapshot key used for lookups.
@@ -38,6 +39,24 @@ func (s *snapshot) Key() snapshotKey {
 	return key
 }
 
+// ChunkHashes returns the SHA-256 hashes of the chunks, if the snapshot metadata lists them for
+// every chunk. Otherwise, the chunks can't be verified before they are applied and nil is returned.
+func (s *snapshot) ChunkHashes() [][]byte {
+	var metadata ocssproto.SnapshotMetadata
+	if err := metadata.Unmarshal(s.Metadata); err != nil {
+		return nil
+	}
+	if len(metadata.ChunkHashes) == 0 || len(metadata.ChunkHashes) != int(s.Chunks) {
+		return nil
+	}
+	for _, hash := range metadata.ChunkHashes {
+		if len(hash) != sha256.Size {
+			return nil
+		}
+	}
+	return metadata.ChunkHashes
+}
+
 // snapshotPool discovers and aggregates snapshots across peers.
 type snapshotPool struct {
 	tmsync.Mutex
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"crypto/sha256"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/Finschia/ostracon/p2p"
 	p2pmocks "github.com/Finschia/ostracon/p2p/mocks"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
 )
 
 func TestSnapshot_Key(t *testing.T) {
@@ -38,6 +40,35 @@ func TestSnapshot_Key(t *testing.T) {
 	}
 }
 
+func TestSnapshot_ChunkHashes(t *testing.T) {
+	hashes := [][]byte{make([]byte, sha256.Size), make([]byte, sha256.Size)}
+	metadata := func(hashes ...[]byte) []byte {
+		bz, err := (&ocssproto.SnapshotMetadata{ChunkHashes: hashes}).Marshal()
+		require.NoError(t, err)
+		return bz
+	}
+
+	testcases := map[string]struct {
+		metadata []byte
+		expect   [][]byte
+	}{
+		"chunk hashes":     {metadata(hashes...), hashes},
+		"no metadata":      {nil, nil},
+		"other metadata":   {[]byte{0xff, 0xff}, nil},
+		"missing hash":     {metadata(hashes[0]), nil},
+		"invalid hash":     {metadata(hashes[0], []byte{1}), nil},
+		"no chunk hashes":  {metadata(), nil},
+		"extra chunk hash": {metadata(hashes[0], hashes[0], hashes[0]), nil},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := &snapshot{Height: 3, Format: 1, Chunks: 2, Hash: []byte{1}, Metadata: tc.metadata}
+			assert.Equal(t, tc.expect, s.ChunkHashes())
+		})
+	}
+}
+
 func TestSnapshotPool_Add(t *testing.T) {
 	peer := &p2pmocks.Peer{}
 	peer.On("ID").Return(p2p.ID("id"))
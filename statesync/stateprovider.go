@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -9,7 +10,6 @@ import (
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	dbm "github.com/tendermint/tm-db"
 
-	"github.com/Finschia/ostracon/crypto/vrf"
 	"github.com/Finschia/ostracon/libs/log"
 	tmsync "github.com/Finschia/ostracon/libs/sync"
 	"github.com/Finschia/ostracon/light"
@@ -17,6 +17,9 @@ import (
 	lighthttp "github.com/Finschia/ostracon/light/provider/http"
 	lightrpc "github.com/Finschia/ostracon/light/rpc"
 	lightdb "github.com/Finschia/ostracon/light/store/db"
+	"github.com/Finschia/ostracon/p2p"
+	ocssproto "github.com/Finschia/ostracon/proto/ostracon/statesync"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 	rpchttp "github.com/Finschia/ostracon/rpc/client/http"
 	sm "github.com/Finschia/ostracon/state"
 	"github.com/Finschia/ostracon/types"
@@ -46,6 +49,7 @@ type lightClientStateProvider struct {
 }
 
 // NewLightClientStateProvider creates a new StateProvider using a light client and RPC clients.
+// The given options are passed to the light client, e.g. to broadcast evidence of detected attacks.
 func NewLightClientStateProvider(
 	ctx context.Context,
 	chainID string,
@@ -54,6 +58,7 @@ func NewLightClientStateProvider(
 	servers []string,
 	trustOptions light.TrustOptions,
 	logger log.Logger,
+	options ...light.Option,
 ) (StateProvider, error) {
 	servers = uniqServers(servers)
 	if len(servers) < 2 {
@@ -74,8 +79,9 @@ func NewLightClientStateProvider(
 		providerRemotes[provider] = server
 	}
 
+	options = append([]light.Option{light.Logger(logger), light.MaxRetryAttempts(5)}, options...)
 	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
-		lightdb.New(dbm.NewMemDB(), ""), light.Logger(logger), light.MaxRetryAttempts(5))
+		lightdb.New(dbm.NewMemDB(), ""), options...)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +150,49 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	s.Lock()
 	defer s.Unlock()
 
+	state, lastLightBlock, currentLightBlock, err := s.lightBlockState(ctx, height)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	// We'll also need to fetch consensus params and last proof hash via RPC, using light client verification.
+	primaryURL, ok := s.providers[s.lc.Primary()]
+	if !ok || primaryURL == "" {
+		return sm.State{}, fmt.Errorf("could not find address for primary light client provider")
+	}
+	primaryRPC, err := rpcClient(primaryURL)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
+	}
+	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
+
+	resultConsensusParams, err := rpcclient.ConsensusParams(ctx, &currentLightBlock.Height)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
+			currentLightBlock.Height+1, err)
+	}
+	state.ConsensusParams = resultConsensusParams.ConsensusParams
+	state.Version.Consensus.App = state.ConsensusParams.Version.AppVersion
+	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
+
+	resultBlock, err := rpcclient.Block(ctx, &lastLightBlock.Height)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to fetch block for height %v: %w",
+			lastLightBlock.Height, err)
+	}
+	proofHash, err := lastProofHash(lastLightBlock, resultBlock.Block.Proof.Bytes())
+	if err != nil {
+		return sm.State{}, err
+	}
+	state.LastProofHash = proofHash
+	return state, nil
+}
+
+// lightBlockState builds a state object at the given height from the verified light blocks,
+// except for the consensus params and the last proof hash which are not part of them. It also
+// returns the last and current light blocks the state was built from.
+func (s *lightClientStateProvider) lightBlockState(
+	ctx context.Context, height uint64) (sm.State, *types.LightBlock, *types.LightBlock, error) {
 	state := sm.State{
 		ChainID:       s.lc.ChainID(),
 		Version:       s.version,
@@ -163,15 +212,15 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	// the validator set at the snapshot height then this only takes effect at height+2.
 	lastLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
 	if err != nil {
-		return sm.State{}, err
+		return sm.State{}, nil, nil, err
 	}
 	currentLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
 	if err != nil {
-		return sm.State{}, err
+		return sm.State{}, nil, nil, err
 	}
 	nextLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), time.Now())
 	if err != nil {
-		return sm.State{}, err
+		return sm.State{}, nil, nil, err
 	}
 
 	state.Version = tmstate.Version{
@@ -188,32 +237,108 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	state.NextValidators = nextLightBlock.ValidatorSet
 	state.LastHeightValidatorsChanged = nextLightBlock.Height
 
-	// We'll also need to fetch consensus params and last proof hash via RPC, using light client verification.
-	primaryURL, ok := s.providers[s.lc.Primary()]
-	if !ok || primaryURL == "" {
-		return sm.State{}, fmt.Errorf("could not find address for primary light client provider")
+	return state, lastLightBlock, currentLightBlock, nil
+}
+
+// p2pStateProvider is a state provider using the light client with light blocks, consensus
+// params and entropy fetched from peers over the p2p network, so that no RPC servers are needed.
+type p2pStateProvider struct {
+	*lightClientStateProvider
+	dispatcher *dispatcher
+	peers      map[lightprovider.Provider]p2p.Peer
+}
+
+// NewP2PStateProvider creates a new StateProvider using a light client with the connected peers
+// serving light blocks as providers. It waits until at least 2 such peers are connected, or the
+// context is done. The given options are passed to the light client.
+func (r *Reactor) NewP2PStateProvider(
+	ctx context.Context,
+	chainID string,
+	version tmstate.Version,
+	initialHeight int64,
+	trustOptions light.TrustOptions,
+	logger log.Logger,
+	options ...light.Option,
+) (StateProvider, error) {
+	peers := r.lightBlockPeers()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for len(peers) < 2 {
+		select {
+		case <-ticker.C:
+			peers = r.lightBlockPeers()
+		case <-ctx.Done():
+			return nil, fmt.Errorf("at least 2 peers are required, got %v: %w", len(peers), ctx.Err())
+		}
 	}
-	primaryRPC, err := rpcClient(primaryURL)
+
+	providers := make([]lightprovider.Provider, 0, len(peers))
+	providerPeers := make(map[lightprovider.Provider]p2p.Peer)
+	for _, peer := range peers {
+		provider := newBlockProvider(peer, chainID, r.dispatcher)
+		providers = append(providers, provider)
+		// We store the peers keyed by provider, so we can find the primary provider used by the
+		// light client and fetch consensus parameters from it.
+		providerPeers[provider] = peer
+	}
+
+	options = append([]light.Option{light.Logger(logger), light.MaxRetryAttempts(5)}, options...)
+	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
+		lightdb.New(dbm.NewMemDB(), ""), options...)
 	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
+		return nil, err
 	}
-	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
+	return &p2pStateProvider{
+		lightClientStateProvider: &lightClientStateProvider{
+			lc:            lc,
+			version:       version,
+			initialHeight: initialHeight,
+		},
+		dispatcher: r.dispatcher,
+		peers:      providerPeers,
+	}, nil
+}
 
-	resultConsensusParams, err := rpcclient.ConsensusParams(ctx, &currentLightBlock.Height)
+// State implements StateProvider.
+func (s *p2pStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	state, lastLightBlock, currentLightBlock, err := s.lightBlockState(ctx, height)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	// We'll also need to fetch consensus params and last proof hash from the primary. The consensus
+	// params are verified against the header. The entropy isn't covered by it, so we verify the
+	// whole block against the part set header of the verified block ID instead.
+	peer, ok := s.peers[s.lc.Primary()]
+	if !ok {
+		return sm.State{}, fmt.Errorf("could not find peer for primary light client provider")
+	}
+
+	params, err := s.params(ctx, currentLightBlock.Height, peer)
 	if err != nil {
 		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
-			nextLightBlock.Height, err)
+			currentLightBlock.Height, err)
 	}
-	state.ConsensusParams = resultConsensusParams.ConsensusParams
+	if !bytes.Equal(types.HashConsensusParams(params.ConsensusParams), currentLightBlock.ConsensusHash) {
+		return sm.State{}, fmt.Errorf("consensus parameters for height %v do not match the consensus hash %X",
+			currentLightBlock.Height, currentLightBlock.ConsensusHash)
+	}
+	state.ConsensusParams = params.ConsensusParams
 	state.Version.Consensus.App = state.ConsensusParams.Version.AppVersion
 	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
 
-	resultBlock, err := rpcclient.Block(ctx, &lastLightBlock.Height)
+	params, err = s.params(ctx, lastLightBlock.Height, peer)
 	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to fetch block for height %v: %w",
-			lastLightBlock.Height, err)
+		return sm.State{}, fmt.Errorf("unable to fetch entropy for height %v: %w", lastLightBlock.Height, err)
+	}
+	block, err := verifiedBlock(lastLightBlock, params.Block)
+	if err != nil {
+		return sm.State{}, err
 	}
-	proofHash, err := vrf.ProofToHash(resultBlock.Block.Proof.Bytes())
+	proofHash, err := lastProofHash(lastLightBlock, block.Proof.Bytes())
 	if err != nil {
 		return sm.State{}, err
 	}
@@ -221,6 +346,45 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	return state, nil
 }
 
+func (s *p2pStateProvider) params(
+	ctx context.Context, height int64, peer p2p.Peer) (*ocssproto.ParamsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, lightBlockResponseTimeout)
+	defer cancel()
+	return s.dispatcher.Params(ctx, height, peer)
+}
+
+// verifiedBlock converts the given block and checks that it is the block of lastLightBlock,
+// including the parts of it that aren't covered by the header hash such as the entropy.
+func verifiedBlock(lastLightBlock *types.LightBlock, pbb *ocproto.Block) (*types.Block, error) {
+	block, err := types.BlockFromProto(pbb)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block for height %v: %w", lastLightBlock.Height, err)
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid block for height %v: %w", lastLightBlock.Height, err)
+	}
+	blockID := types.BlockID{
+		Hash:          block.Hash(),
+		PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header(),
+	}
+	if !lastLightBlock.Commit.BlockID.Equals(blockID) {
+		return nil, fmt.Errorf("block %v for height %v does not match the trusted block %v",
+			blockID, lastLightBlock.Height, lastLightBlock.Commit.BlockID)
+	}
+	return block, nil
+}
+
+// lastProofHash returns the VRF output of the proof of the block of lastLightBlock, which depends on
+// the key type of its proposer.
+func lastProofHash(lastLightBlock *types.LightBlock, proof []byte) ([]byte, error) {
+	_, proposer := lastLightBlock.ValidatorSet.GetByAddress(lastLightBlock.ProposerAddress)
+	if proposer == nil {
+		return nil, fmt.Errorf("proposer %X of height %v is not in its validator set",
+			lastLightBlock.ProposerAddress, lastLightBlock.Height)
+	}
+	return types.ProofToHash(proposer.PubKey, proof)
+}
+
 // rpcClient sets up a new RPC client
 func rpcClient(server string) (*rpchttp.HTTP, error) {
 	if !strings.Contains(server, "://") {
@@ -17,6 +17,7 @@ import (
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
 	"github.com/Finschia/ostracon/config"
+	"github.com/Finschia/ostracon/crypto/vrf"
 	"github.com/Finschia/ostracon/libs/log"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	"github.com/Finschia/ostracon/light"
@@ -113,6 +114,57 @@ func TestNewLightClientStateProvider(t *testing.T) {
 	}
 }
 
+func TestP2PStateProvider(t *testing.T) {
+	chain, _, stateStore, blockStore := makeNodeSnapshotChain(t, 6)
+	r, _, _ := makeTestServingReactors(t, 2, stateStore, blockStore)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sp, err := r.NewP2PStateProvider(ctx, chain.ChainID, chain.Version, chain.InitialHeight,
+		light.TrustOptions{
+			Period: time.Hour,
+			Height: 1,
+			Hash:   blockStore.LoadBlockMeta(1).Header.Hash(),
+		}, log.TestingLogger())
+	require.NoError(t, err)
+
+	appHash, err := sp.AppHash(ctx, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, blockStore.LoadBlockMeta(4).Header.AppHash, appHash)
+
+	c, err := sp.Commit(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, blockStore.LoadBlockCommit(3).Hash(), c.Hash())
+
+	expect, err := stateStore.Load()
+	require.NoError(t, err)
+	proof, err := vrf.ProofToHash(vrf.Proof(blockStore.LoadBlock(3).Proof))
+	require.NoError(t, err)
+
+	s, err := sp.State(ctx, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, s.LastBlockHeight)
+	assert.Equal(t, blockStore.LoadBlockMeta(3).BlockID, s.LastBlockID)
+	assert.Equal(t, expect.ConsensusParams, s.ConsensusParams)
+	assert.Equal(t, expect.Validators.Hash(), s.Validators.Hash())
+	assert.EqualValues(t, proof, s.LastProofHash)
+}
+
+func TestP2PStateProvider_NoPeers(t *testing.T) {
+	chain, _, stateStore, blockStore := makeNodeSnapshotChain(t, 1)
+	r, _, _ := makeTestServingReactors(t, 1, stateStore, blockStore)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := r.NewP2PStateProvider(ctx, chain.ChainID, chain.Version, chain.InitialHeight,
+		light.TrustOptions{
+			Period: time.Hour,
+			Height: 1,
+			Hash:   blockStore.LoadBlockMeta(1).Header.Hash(),
+		}, log.TestingLogger())
+	require.Error(t, err)
+}
+
 const (
 	height = int64(1)
 	round  = int32(0)
@@ -249,3 +301,30 @@ func validatorsFunc(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr
 		Total:       size,
 	}, nil
 }
+
+func TestVerifiedBlock(t *testing.T) {
+	chain, _, _, blockStore := makeNodeSnapshotChain(t, 3)
+	block := blockStore.LoadBlock(2)
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &block.Header, Commit: blockStore.LoadBlockCommit(2)},
+		ValidatorSet: chain.Validators,
+	}
+
+	pbb, err := block.ToProto()
+	require.NoError(t, err)
+	verified, err := verifiedBlock(lb, pbb)
+	require.NoError(t, err)
+	assert.Equal(t, block.Proof, verified.Proof)
+
+	// The entropy isn't covered by the header hash, but it must still match the part set header.
+	pbb, err = block.ToProto()
+	require.NoError(t, err)
+	pbb.Entropy.Round++
+	_, err = verifiedBlock(lb, pbb)
+	require.Error(t, err)
+
+	pbb, err = blockStore.LoadBlock(3).ToProto()
+	require.NoError(t, err)
+	_, err = verifiedBlock(lb, pbb)
+	require.Error(t, err)
+}
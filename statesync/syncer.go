@@ -60,6 +60,7 @@ type syncer struct {
 	tempDir       string
 	chunkFetchers int32
 	retryTimeout  time.Duration
+	peerScores    *peerScorer
 
 	mtx    tmsync.RWMutex
 	chunks *chunkQueue
@@ -84,6 +85,7 @@ func newSyncer(
 		tempDir:       tempDir,
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
+		peerScores:    newPeerScorer(cfg.ChunkRequestTimeout),
 	}
 }
 
@@ -96,10 +98,15 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 		return false, errors.New("no state sync in progress")
 	}
 	added, err := s.chunks.Add(chunk)
+	if errors.Is(err, errChunkHashMismatch) {
+		// Refetch the chunk right away, preferably from another peer.
+		s.peerScores.Failed(chunk.Sender, chunk.Index)
+	}
 	if err != nil {
 		return false, err
 	}
 	if added {
+		s.peerScores.Received(chunk.Sender, chunk.Index, len(chunk.Chunk))
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
 	} else {
@@ -134,6 +141,7 @@ func (s *syncer) AddPeer(peer p2p.Peer) {
 func (s *syncer) RemovePeer(peer p2p.Peer) {
 	s.logger.Debug("Removing peer from sync", "peer", peer.ID())
 	s.snapshots.RemovePeer(peer.ID())
+	s.peerScores.Remove(peer.ID())
 }
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
@@ -451,41 +459,48 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
-		ticker := time.NewTicker(s.retryTimeout)
-		defer ticker.Stop()
-
-		s.requestChunk(snapshot, index)
+		peerID, timeout, failed := s.requestChunk(snapshot, index)
+		timer := time.NewTimer(timeout)
 
 		select {
 		case <-chunks.WaitFor(index):
 			next = true
 
-		case <-ticker.C:
+		case <-failed:
+			next = false
+
+		case <-timer.C:
+			s.peerScores.Failed(peerID, index)
 			next = false
 
 		case <-ctx.Done():
+			timer.Stop()
 			return
 		}
 
-		ticker.Stop()
+		timer.Stop()
 	}
 }
 
-// requestChunk requests a chunk from a peer.
-func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
-	peer := s.snapshots.GetPeer(snapshot)
+// requestChunk requests a chunk from the peer with the best score. It returns the peer, the time
+// to wait for the chunk, and a channel that is closed if the request fails before then.
+func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) (p2p.ID, time.Duration, <-chan struct{}) {
+	peer := s.peerScores.Pick(s.snapshots.GetPeers(snapshot))
 	if peer == nil {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", snapshot.Hash)
-		return
+		return "", s.retryTimeout, nil
 	}
+	timeout := s.peerScores.Timeout(peer.ID())
 	s.logger.Debug("Requesting snapshot chunk", "height", snapshot.Height,
-		"format", snapshot.Format, "chunk", chunk, "peer", peer.ID())
+		"format", snapshot.Format, "chunk", chunk, "peer", peer.ID(), "timeout", timeout)
+	failed := s.peerScores.Requested(peer.ID(), chunk)
 	peer.Send(ChunkChannel, mustEncodeMsg(&ssproto.ChunkRequest{
 		Height: snapshot.Height,
 		Format: snapshot.Format,
 		Index:  chunk,
 	}))
+	return peer.ID(), timeout, failed
 }
 
 // verifyApp verifies the sync, checking the app hash, last block height and app version
@@ -203,7 +203,7 @@ func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 // and it comes from the block.LastCommit for `height+1`.
 // If no commit is found for the given height, it returns nil.
 func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
-	var pbc = new(tmproto.Commit)
+	var pbc = new(ocproto.Commit)
 	bz, err := bs.db.Get(calcBlockCommitKey(height))
 	if err != nil {
 		panic(err)
@@ -226,7 +226,7 @@ func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 // This is useful when we've seen a commit, but there has not yet been
 // a new block at `height + 1` that includes this commit in its block.LastCommit.
 func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
-	var pbc = new(tmproto.Commit)
+	var pbc = new(ocproto.Commit)
 	bz, err := bs.db.Get(calcSeenCommitKey(height))
 	if err != nil {
 		panic(err)
@@ -417,6 +417,64 @@ func (bs *BlockStore) saveState() {
 	SaveBlockStoreState(&bss, bs.db)
 }
 
+// SaveSignedHeader saves the header and commit of a block that is not stored locally, used by e.g.
+// the state sync reactor when backfilling the heights before the snapshot. Only the block meta and
+// block commit are persisted, so LoadBlock returns nil for these heights. The signed header must be
+// below the base, or the first one saved to an empty store.
+func (bs *BlockStore) SaveSignedHeader(sh *types.SignedHeader, blockID types.BlockID) error {
+	height := sh.Height
+	bs.mtx.RLock()
+	base := bs.base
+	bs.mtx.RUnlock()
+	if base > 0 && height >= base {
+		return fmt.Errorf("cannot save signed header at height %v, it is not lower than base height %v",
+			height, base)
+	}
+	if g, w := height, base-1; base > 0 && g != w {
+		return fmt.Errorf("BlockStore can only save contiguous signed headers. Wanted %v, got %v", w, g)
+	}
+
+	// NOTE: the block size and number of txs are unknown without the block data.
+	blockMeta := &types.BlockMeta{
+		BlockID:   blockID,
+		BlockSize: -1,
+		Header:    *sh.Header,
+		NumTxs:    -1,
+	}
+	metaBytes, err := proto.Marshal(blockMeta.ToProto())
+	if err != nil {
+		return fmt.Errorf("unable to marshal block meta: %w", err)
+	}
+	commitBytes, err := proto.Marshal(sh.Commit.ToProto())
+	if err != nil {
+		return fmt.Errorf("unable to marshal commit: %w", err)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(calcBlockMetaKey(height), metaBytes); err != nil {
+		return err
+	}
+	if err := batch.Set(calcBlockHashKey(sh.Hash()), []byte(fmt.Sprintf("%d", height))); err != nil {
+		return err
+	}
+	if err := batch.Set(calcBlockCommitKey(height), commitBytes); err != nil {
+		return err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	bs.mtx.Lock()
+	bs.base = height
+	if bs.height == 0 {
+		bs.height = height
+	}
+	bs.mtx.Unlock()
+	bs.saveState()
+	return nil
+}
+
 // SaveSeenCommit saves a seen commit, used by e.g. the state sync reactor when bootstrapping node.
 func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) error {
 	pbc := seenCommit.ToProto()
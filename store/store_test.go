@@ -580,6 +580,46 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	require.Nil(t, blockAtHeightPlus2, "expecting an unsuccessful load of Height()+2")
 }
 
+func TestSaveSignedHeader(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewOCLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	makeSignedHeader := func(height int64) (*types.SignedHeader, types.BlockID) {
+		block := makeBlock(height, state, makeTestCommit(height-1, tmtime.Now()))
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(2).Header()}
+		return &types.SignedHeader{Header: &block.Header, Commit: makeTestCommit(height, tmtime.Now())}, blockID
+	}
+
+	// The first signed header sets both base and height of an empty store.
+	sh, blockID := makeSignedHeader(10)
+	require.NoError(t, bs.SaveSignedHeader(sh, blockID))
+	require.EqualValues(t, 10, bs.Base())
+	require.EqualValues(t, 10, bs.Height())
+
+	// Signed headers must be saved backwards, contiguously.
+	sh, blockID = makeSignedHeader(10)
+	require.Error(t, bs.SaveSignedHeader(sh, blockID))
+	sh, blockID = makeSignedHeader(8)
+	require.Error(t, bs.SaveSignedHeader(sh, blockID))
+	sh, blockID = makeSignedHeader(9)
+	require.NoError(t, bs.SaveSignedHeader(sh, blockID))
+	require.EqualValues(t, 9, bs.Base())
+	require.EqualValues(t, 10, bs.Height())
+
+	meta := bs.LoadBlockMeta(9)
+	require.NotNil(t, meta)
+	require.Equal(t, blockID, meta.BlockID)
+	require.Equal(t, sh.Hash(), meta.Header.Hash())
+	require.EqualValues(t, -1, meta.NumTxs)
+	require.Equal(t, sh.Commit.Hash(), bs.LoadBlockCommit(9).Hash())
+	require.Nil(t, bs.LoadBlock(9))
+
+	// The base and height are persisted.
+	bss := LoadBlockStoreState(bs.db)
+	require.EqualValues(t, 9, bss.Base)
+	require.EqualValues(t, 10, bss.Height)
+}
+
 func doFn(fn func() (interface{}, error)) (res interface{}, err error, panicErr error) {
 	defer func() {
 		if r := recover(); r != nil {
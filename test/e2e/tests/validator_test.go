@@ -6,7 +6,6 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/Finschia/ostracon/crypto/vrf"
 	e2e "github.com/Finschia/ostracon/test/e2e/pkg"
 	"github.com/Finschia/ostracon/types"
 )
@@ -83,7 +82,9 @@ func TestValidator_Propose(t *testing.T) {
 		expectCount := 0
 		proposeCount := 0
 		for _, block := range blocks {
-			proofHash, _ := vrf.ProofToHash(block.Proof.Bytes())
+			_, blockProposer := valSchedule.Set.GetByAddress(block.ProposerAddress)
+			require.NotNil(t, blockProposer, "unknown proposer of height %v", block.Height)
+			proofHash, _ := types.ProofToHash(blockProposer.PubKey, block.Proof.Bytes())
 			proposer := valSchedule.Set.SelectProposer(proofHash, block.Height, block.Round)
 			if bytes.Equal(proposer.Address, address) {
 				expectCount++
@@ -14,10 +14,10 @@ import (
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/merkle"
 	"github.com/Finschia/ostracon/crypto/tmhash"
-	"github.com/Finschia/ostracon/crypto/vrf"
 	"github.com/Finschia/ostracon/libs/bits"
 	tmbytes "github.com/Finschia/ostracon/libs/bytes"
 	tmmath "github.com/Finschia/ostracon/libs/math"
@@ -35,7 +35,7 @@ const (
 	// 🏺 Note that this value is the encoded size of the ProtocolBuffer. See TestMaxEntropyBytes() for how Tendermint
 	//  calculates this value. Add/remove Ostracon-specific field sizes to/from this heuristically determined constant.
 	MaxEntropyBytes int64 = (1 + 5) + // +Round
-		(2 + int64(vrf.ProofSize)) // +Proof
+		(2 + int64(MaxProofSize)) // +Proof
 
 	// MaxOverheadForBlock - maximum overhead to encode a block (up to
 	// MaxBlockSizeBytes in size) not including it's parts except Data.
@@ -57,6 +57,9 @@ type Block struct {
 	Evidence   EvidenceData `json:"evidence"`
 	LastCommit *Commit      `json:"last_commit"`
 	Entropy    `json:"entropy"`
+
+	// KeyRotations are bound to the block by its part set, like Entropy.
+	KeyRotations KeyRotationList `json:"key_rotations,omitempty"`
 }
 
 // MakeBlock returns a new block with an empty header, except what can be
@@ -101,6 +104,10 @@ func (b *Block) ValidateBasic() error {
 	if err := b.LastCommit.ValidateBasic(); err != nil {
 		return fmt.Errorf("wrong LastCommit: %v", err)
 	}
+	// The signatures of LastCommit are votes of the consensus, so they can't be aggregated.
+	if b.LastCommit.IsAggregated() {
+		return errors.New("aggregated LastCommit")
+	}
 
 	if !bytes.Equal(b.LastCommitHash, b.LastCommit.Hash()) {
 		return fmt.Errorf("wrong Header.LastCommitHash. Expected %v, got %v",
@@ -136,6 +143,10 @@ func (b *Block) ValidateBasic() error {
 		return fmt.Errorf("invalid entropy: %w", err)
 	}
 
+	if err := b.KeyRotations.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid key rotations: %w", err)
+	}
+
 	return nil
 }
 
@@ -225,6 +236,7 @@ func (b *Block) String() string {
 // Evidence
 // LastCommit
 // Entropy
+// KeyRotations
 // Hash
 func (b *Block) StringIndented(indent string) string {
 	if b == nil {
@@ -236,12 +248,14 @@ func (b *Block) StringIndented(indent string) string {
 %s  %v
 %s  %v
 %s  %v
+%s  %v
 %s}#%v`,
 		indent, b.Header.StringIndented(indent+"  "),
 		indent, b.Data.StringIndented(indent+"  "),
 		indent, b.Evidence.StringIndented(indent+"  "),
 		indent, b.LastCommit.StringIndented(indent+"  "),
 		indent, b.Entropy.StringIndented(indent+"  "),
+		indent, b.KeyRotations.StringIndented(indent+"  "),
 		indent, b.Hash())
 }
 
@@ -272,6 +286,13 @@ func (b *Block) ToProto() (*ocproto.Block, error) {
 	}
 	pb.Evidence = *protoEvidence
 
+	// a block without key rotations is encoded like before they were added
+	if len(b.KeyRotations) > 0 {
+		if pb.KeyRotations, err = b.KeyRotations.ToProto(); err != nil {
+			return nil, err
+		}
+	}
+
 	return pb, nil
 }
 
@@ -309,6 +330,9 @@ func BlockFromProto(bp *ocproto.Block) (*Block, error) {
 		return nil, err
 	}
 	b.Entropy = vp
+	if b.KeyRotations, err = KeyRotationListFromProto(bp.KeyRotations); err != nil {
+		return nil, err
+	}
 
 	return b, b.ValidateBasic()
 }
@@ -319,10 +343,22 @@ func BlockFromProto(bp *ocproto.Block) (*Block, error) {
 //
 // XXX: Panics on negative result.
 func MaxDataBytes(maxBytes, evidenceBytes int64, valsCount int) int64 {
+	return maxDataBytes(maxBytes, evidenceBytes, MaxCommitBytes(valsCount))
+}
+
+// MaxDataBytesForValidators is MaxDataBytes for a block whose last commit is
+// signed by vals. See MaxCommitBytesForValidators.
+//
+// XXX: Panics on negative result.
+func MaxDataBytesForValidators(maxBytes, evidenceBytes int64, vals *ValidatorSet) int64 {
+	return maxDataBytes(maxBytes, evidenceBytes, MaxCommitBytesForValidators(vals))
+}
+
+func maxDataBytes(maxBytes, evidenceBytes, commitBytes int64) int64 {
 	maxDataBytes := maxBytes -
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
-		MaxCommitBytes(valsCount) -
+		commitBytes -
 		evidenceBytes -
 		MaxEntropyBytes
 
@@ -343,11 +379,23 @@ func MaxDataBytes(maxBytes, evidenceBytes int64, valsCount int) int64 {
 //
 // XXX: Panics on negative result.
 func MaxDataBytesNoEvidence(maxBytes int64, valsCount int) int64 {
+	return maxDataBytesNoEvidence(maxBytes, MaxCommitBytes(valsCount))
+}
+
+// MaxDataBytesNoEvidenceForValidators is MaxDataBytesNoEvidence for a block
+// whose last commit is signed by vals. See MaxCommitBytesForValidators.
+//
+// XXX: Panics on negative result.
+func MaxDataBytesNoEvidenceForValidators(maxBytes int64, vals *ValidatorSet) int64 {
+	return maxDataBytesNoEvidence(maxBytes, MaxCommitBytesForValidators(vals))
+}
+
+func maxDataBytesNoEvidence(maxBytes, commitBytes int64) int64 {
 	maxDataBytes := maxBytes -
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
 		MaxEntropyBytes -
-		MaxCommitBytes(valsCount)
+		commitBytes
 
 	if maxDataBytes < 0 {
 		panic(fmt.Sprintf(
@@ -643,6 +691,8 @@ const (
 		(1 + 20 + 1) + // ValidatorAddress
 		(1 + 17 + 1) + // Timestamp
 		(1 + ed25519.SignatureSize + 1) // Signature
+	// MaxBLSCommitSigBytes is MaxCommitSigBytes for a validator with a BLS12-381 key, whose signature is 96 bytes.
+	MaxBLSCommitSigBytes int64 = MaxCommitSigBytes - ed25519.SignatureSize + bls12381.SignatureSize
 )
 
 // CommitSig is a part of the Vote included in a Commit.
@@ -669,6 +719,22 @@ func MaxCommitBytes(valCount int) int64 {
 	return MaxCommitOverheadBytes + ((MaxCommitSigBytes + protoEncodingOverhead) * int64(valCount))
 }
 
+// MaxCommitBytesForValidators is MaxCommitBytes for the commit of vals, sizing the
+// signature of each validator by its key type. Unlike MaxCommitBytes, it accounts
+// for validators with BLS12-381 keys.
+func MaxCommitBytesForValidators(vals *ValidatorSet) int64 {
+	bls := 0
+	for _, val := range vals.Validators {
+		if val.PubKey != nil && val.PubKey.Type() == bls12381.KeyType {
+			bls++
+		}
+	}
+	// From the repeated commit sig field, whose length takes one more byte with a
+	// BLS12-381 signature
+	var blsProtoEncodingOverhead int64 = 3
+	return MaxCommitBytes(vals.Size()-bls) + (MaxBLSCommitSigBytes+blsProtoEncodingOverhead)*int64(bls)
+}
+
 // NewCommitSigAbsent returns new CommitSig with BlockIDFlagAbsent. Other
 // fields are all empty.
 func NewCommitSigAbsent() CommitSig {
@@ -775,13 +841,15 @@ func (cs *CommitSig) ToProto() *tmproto.CommitSig {
 // FromProto sets a protobuf CommitSig to the given pointer.
 // It returns an error if the CommitSig is invalid.
 func (cs *CommitSig) FromProto(csp tmproto.CommitSig) error {
+	cs.fromProto(csp)
+	return cs.ValidateBasic()
+}
 
+func (cs *CommitSig) fromProto(csp tmproto.CommitSig) {
 	cs.BlockIDFlag = BlockIDFlag(csp.BlockIdFlag)
 	cs.ValidatorAddress = csp.ValidatorAddress
 	cs.Timestamp = csp.Timestamp
 	cs.Signature = csp.Signature
-
-	return cs.ValidateBasic()
 }
 
 //-------------------------------------
@@ -798,6 +866,12 @@ type Commit struct {
 	BlockID    BlockID     `json:"block_id"`
 	Signatures []CommitSig `json:"signatures"`
 
+	// AggregatedSignature is the signature aggregated from the signatures of the
+	// CommitSigs marked in AggregatedSigners, which carry no signature themselves.
+	// See AggregateSignatures.
+	AggregatedSignature []byte         `json:"aggregated_signature,omitempty"`
+	AggregatedSigners   *bits.BitArray `json:"aggregated_signers,omitempty"`
+
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
 	// unmarshaling.
@@ -935,11 +1009,22 @@ func (commit *Commit) ValidateBasic() error {
 		if len(commit.Signatures) == 0 {
 			return errors.New("no signatures in commit")
 		}
+		if err := commit.validateAggregation(); err != nil {
+			return err
+		}
 		for i, commitSig := range commit.Signatures {
-			if err := commitSig.ValidateBasic(); err != nil {
+			var err error
+			if commit.isAggregated(i) {
+				err = commitSig.validateAggregated()
+			} else {
+				err = commitSig.ValidateBasic()
+			}
+			if err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %v", i, err)
 			}
 		}
+	} else if commit.IsAggregated() {
+		return errors.New("aggregated signature in empty commit")
 	}
 	return nil
 }
@@ -960,6 +1045,13 @@ func (commit *Commit) Hash() tmbytes.HexBytes {
 
 			bs[i] = bz
 		}
+		if commit.IsAggregated() {
+			bz, err := commit.AggregatedSigners.ToProto().Marshal()
+			if err != nil {
+				panic(err)
+			}
+			bs = append(bs, commit.AggregatedSignature, bz)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -990,12 +1082,12 @@ func (commit *Commit) StringIndented(indent string) string {
 }
 
 // ToProto converts Commit to protobuf
-func (commit *Commit) ToProto() *tmproto.Commit {
+func (commit *Commit) ToProto() *ocproto.Commit {
 	if commit == nil {
 		return nil
 	}
 
-	c := new(tmproto.Commit)
+	c := new(ocproto.Commit)
 	sigs := make([]tmproto.CommitSig, len(commit.Signatures))
 	for i := range commit.Signatures {
 		sigs[i] = *commit.Signatures[i].ToProto()
@@ -1005,13 +1097,15 @@ func (commit *Commit) ToProto() *tmproto.Commit {
 	c.Height = commit.Height
 	c.Round = commit.Round
 	c.BlockID = commit.BlockID.ToProto()
+	c.AggregatedSignature = commit.AggregatedSignature
+	c.AggregatedSigners = commit.AggregatedSigners.ToProto()
 
 	return c
 }
 
 // FromProto sets a protobuf Commit to the given pointer.
 // It returns an error if the commit is invalid.
-func CommitFromProto(cp *tmproto.Commit) (*Commit, error) {
+func CommitFromProto(cp *ocproto.Commit) (*Commit, error) {
 	if cp == nil {
 		return nil, errors.New("nil Commit")
 	}
@@ -1025,13 +1119,27 @@ func CommitFromProto(cp *tmproto.Commit) (*Commit, error) {
 		return nil, err
 	}
 
+	if cp.AggregatedSigners != nil {
+		commit.AggregatedSigners = new(bits.BitArray)
+		commit.AggregatedSigners.FromProto(cp.AggregatedSigners)
+	}
+	commit.AggregatedSignature = cp.AggregatedSignature
 	sigs := make([]CommitSig, len(cp.Signatures))
+	commit.Signatures = sigs
+	if err := commit.validateAggregation(); err != nil {
+		return nil, err
+	}
+
 	for i := range cp.Signatures {
+		if commit.isAggregated(i) {
+			// validated below, as the signature is in the aggregated signature
+			sigs[i].fromProto(cp.Signatures[i])
+			continue
+		}
 		if err := sigs[i].FromProto(cp.Signatures[i]); err != nil {
 			return nil, err
 		}
 	}
-	commit.Signatures = sigs
 
 	commit.Height = cp.Height
 	commit.Round = cp.Round
@@ -1173,13 +1281,13 @@ func (data *EvidenceData) StringIndented(indent string) string {
 }
 
 // ToProto converts EvidenceData to protobuf
-func (data *EvidenceData) ToProto() (*tmproto.EvidenceList, error) {
+func (data *EvidenceData) ToProto() (*ocproto.EvidenceList, error) {
 	if data == nil {
 		return nil, errors.New("nil evidence data")
 	}
 
-	evi := new(tmproto.EvidenceList)
-	eviBzs := make([]tmproto.Evidence, len(data.Evidence))
+	evi := new(ocproto.EvidenceList)
+	eviBzs := make([]ocproto.Evidence, len(data.Evidence))
 	for i := range data.Evidence {
 		protoEvi, err := EvidenceToProto(data.Evidence[i])
 		if err != nil {
@@ -1193,7 +1301,7 @@ func (data *EvidenceData) ToProto() (*tmproto.EvidenceList, error) {
 }
 
 // FromProto sets a protobuf EvidenceData to the given pointer.
-func (data *EvidenceData) FromProto(eviData *tmproto.EvidenceList) error {
+func (data *EvidenceData) FromProto(eviData *ocproto.EvidenceList) error {
 	if eviData == nil {
 		return errors.New("nil evidenceData")
 	}
@@ -20,6 +20,8 @@ import (
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/merkle"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	"github.com/Finschia/ostracon/crypto/vrf"
@@ -129,7 +131,7 @@ func TestBlockValidateBasic(t *testing.T) {
 			blk.Round = -1
 		}, true},
 		{"Incorrect Proof Size", func(blk *Block) {
-			blk.Proof = []byte("wrong proof size")
+			blk.Proof = make([]byte, MaxProofSize+1)
 		}, true},
 	}
 	for i, tc := range testCases {
@@ -313,13 +315,17 @@ func TestMaxCommitBytes(t *testing.T) {
 		BlockIDFlag:      BlockIDFlagNil,
 		ValidatorAddress: crypto.AddressHash([]byte("validator_address")),
 		Timestamp:        timestamp,
-		Signature:        crypto.CRandBytes(MaxSignatureSize),
+		Signature:        crypto.CRandBytes(ed25519.SignatureSize),
 	}
 
 	pbSig := cs.ToProto()
 	// test that a single commit sig doesn't exceed max commit sig bytes
 	assert.EqualValues(t, MaxCommitSigBytes, int64(pbSig.Size()))
 
+	blsCS := cs
+	blsCS.Signature = crypto.CRandBytes(bls12381.SignatureSize)
+	assert.EqualValues(t, MaxBLSCommitSigBytes, int64(blsCS.ToProto().Size()))
+
 	// check size with a single commit
 	commit := &Commit{
 		Height: math.MaxInt64,
@@ -346,6 +352,17 @@ func TestMaxCommitBytes(t *testing.T) {
 	pb = commit.ToProto()
 
 	assert.EqualValues(t, MaxCommitBytes(MaxVotesCount), int64(pb.Size()))
+
+	// the signatures of validators with BLS12-381 keys are larger
+	vals := NewValidatorSet([]*Validator{
+		NewValidator(ed25519.GenPrivKey().PubKey(), 1),
+		NewValidator(bls12381.GenPrivKey().PubKey(), 1),
+		NewValidator(bls12381.GenPrivKey().PubKey(), 1),
+	})
+	commit.Signatures = []CommitSig{cs, blsCS, blsCS}
+	assert.EqualValues(t, MaxCommitBytesForValidators(vals), int64(commit.ToProto().Size()))
+	vals = NewValidatorSet([]*Validator{NewValidator(ed25519.GenPrivKey().PubKey(), 1)})
+	assert.EqualValues(t, MaxCommitBytes(1), MaxCommitBytesForValidators(vals))
 }
 
 func TestCommitHash(t *testing.T) {
@@ -623,15 +640,15 @@ func TestBlockMaxDataBytes(t *testing.T) {
 	}{
 		0:  {-10, 1, 0, true, 0},
 		1:  {10, 1, 0, true, 0},
-		2:  {849 + int64(vrf.ProofSize), 1, 0, true, 0},
-		3:  {850 + int64(vrf.ProofSize), 1, 0, false, 0},
-		4:  {851 + int64(vrf.ProofSize), 1, 0, false, 1},
-		5:  {960 + int64(vrf.ProofSize), 2, 0, true, 0},
-		6:  {961 + int64(vrf.ProofSize), 2, 0, false, 0},
-		7:  {962 + int64(vrf.ProofSize), 2, 0, false, 1},
-		8:  {1060 + int64(vrf.ProofSize), 2, 100, true, 0},
-		9:  {1061 + int64(vrf.ProofSize), 2, 100, false, 0},
-		10: {1062 + int64(vrf.ProofSize), 2, 100, false, 1},
+		2:  {849 + int64(MaxProofSize), 1, 0, true, 0},
+		3:  {850 + int64(MaxProofSize), 1, 0, false, 0},
+		4:  {851 + int64(MaxProofSize), 1, 0, false, 1},
+		5:  {960 + int64(MaxProofSize), 2, 0, true, 0},
+		6:  {961 + int64(MaxProofSize), 2, 0, false, 0},
+		7:  {962 + int64(MaxProofSize), 2, 0, false, 1},
+		8:  {1060 + int64(MaxProofSize), 2, 100, true, 0},
+		9:  {1061 + int64(MaxProofSize), 2, 100, false, 0},
+		10: {1062 + int64(MaxProofSize), 2, 100, false, 1},
 	}
 
 	for i, tc := range testCases {
@@ -658,12 +675,12 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}{
 		0: {-10, 1, true, 0},
 		1: {10, 1, true, 0},
-		2: {849 + int64(vrf.ProofSize), 1, true, 0},
-		3: {850 + int64(vrf.ProofSize), 1, false, 0},
-		4: {851 + int64(vrf.ProofSize), 1, false, 1},
-		5: {960 + int64(vrf.ProofSize), 2, true, 0},
-		6: {961 + int64(vrf.ProofSize), 2, false, 0},
-		7: {962 + int64(vrf.ProofSize), 2, false, 1},
+		2: {849 + int64(MaxProofSize), 1, true, 0},
+		3: {850 + int64(MaxProofSize), 1, false, 0},
+		4: {851 + int64(MaxProofSize), 1, false, 1},
+		5: {960 + int64(MaxProofSize), 2, true, 0},
+		6: {961 + int64(MaxProofSize), 2, false, 0},
+		7: {962 + int64(MaxProofSize), 2, false, 1},
 	}
 
 	for i, tc := range testCases {
@@ -825,6 +842,14 @@ func TestBlockProtoBuf(t *testing.T) {
 
 	b3 := MakeBlock(h, []Tx{}, c1, []Evidence{}, TestConsensusVersion)
 	b3.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+
+	b4 := MakeBlock(h, []Tx{Tx([]byte{1})}, c1, []Evidence{}, TestConsensusVersion)
+	b4.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+	privKey := ed25519.GenPrivKey()
+	kr, err := NewKeyRotation("block-test-chain", privKey.PubKey().Address(), privKey, ed25519.GenPrivKey(),
+		h+2, h-1, evidenceTime)
+	require.NoError(t, err)
+	b4.KeyRotations = KeyRotationList{kr}
 	testCases := []struct {
 		msg      string
 		b1       *Block
@@ -835,6 +860,7 @@ func TestBlockProtoBuf(t *testing.T) {
 		{"b1", b1, true, true},
 		{"b2", b2, true, true},
 		{"b3", b3, true, true},
+		{"b4 with key rotations", b4, true, true},
 	}
 	for _, tc := range testCases {
 		pb, err := tc.b1.ToProto()
@@ -852,6 +878,7 @@ func TestBlockProtoBuf(t *testing.T) {
 			require.EqualValues(t, tc.b1.Evidence.Evidence, block.Evidence.Evidence, tc.msg)
 			require.EqualValues(t, *tc.b1.LastCommit, *block.LastCommit, tc.msg)
 			require.EqualValues(t, tc.b1.Entropy, block.Entropy, tc.msg)
+			require.EqualValues(t, tc.b1.KeyRotations, block.KeyRotations, tc.msg)
 		} else {
 			require.Error(t, err, tc.msg)
 		}
@@ -1109,7 +1136,7 @@ func TestEntropyValidateBasic(t *testing.T) {
 			entropy.Round = -1
 		}, true},
 		{"Invalid Proof", func(entropy *Entropy) {
-			entropy.Proof = make([]byte, vrf.ProofSize-1)
+			entropy.Proof = make([]byte, MaxProofSize+1)
 		}, true},
 	}
 	for i, tc := range testCases {
@@ -1128,7 +1155,7 @@ func TestEntropyValidateBasic(t *testing.T) {
 }
 
 func TestMaxEntropyBytes(t *testing.T) {
-	proof := make([]byte, vrf.ProofSize)
+	proof := make([]byte, MaxProofSize)
 	for i := 0; i < len(proof); i++ {
 		proof[i] = 0xFF
 	}
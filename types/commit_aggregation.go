@@ -0,0 +1,137 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/libs/bits"
+)
+
+// AggregateSignatures returns a copy of the commit in which the signatures of the validators
+// with BLS12-381 keys that voted for the block are aggregated into one AggregatedSignature.
+// vals must be the validator set that signed the commit. If fewer than two signatures can be
+// aggregated, the commit itself is returned.
+//
+// An aggregated commit is verified by ValidatorSet.VerifyCommit* like any other, with one product
+// of pairings for all the aggregated signatures. It can't be turned back into votes, so it is
+// meant for light clients and never used as the LastCommit of a block.
+func (commit *Commit) AggregateSignatures(vals *ValidatorSet) (*Commit, error) {
+	if commit.IsAggregated() {
+		return commit, nil
+	}
+	if vals.Size() != len(commit.Signatures) {
+		return nil, NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
+	}
+
+	signers := bits.NewBitArray(len(commit.Signatures))
+	sigs := make([][]byte, 0, len(commit.Signatures))
+	for idx, commitSig := range commit.Signatures {
+		if !commitSig.ForBlock() {
+			continue
+		}
+		if _, ok := vals.Validators[idx].PubKey.(bls12381.PubKey); !ok {
+			continue
+		}
+		signers.SetIndex(idx, true)
+		sigs = append(sigs, commitSig.Signature)
+	}
+	if len(sigs) < 2 {
+		return commit, nil
+	}
+	aggregatedSig, err := bls12381.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSigs := make([]CommitSig, len(commit.Signatures))
+	copy(commitSigs, commit.Signatures)
+	for idx := range commitSigs {
+		if signers.GetIndex(idx) {
+			commitSigs[idx].Signature = nil
+		}
+	}
+	aggregated := NewCommit(commit.Height, commit.Round, commit.BlockID, commitSigs)
+	aggregated.AggregatedSignature = aggregatedSig
+	aggregated.AggregatedSigners = signers
+	return aggregated, nil
+}
+
+// IsAggregated returns true if some signatures of the commit are aggregated into
+// AggregatedSignature.
+func (commit *Commit) IsAggregated() bool {
+	return commit != nil && (commit.AggregatedSigners != nil || len(commit.AggregatedSignature) != 0)
+}
+
+// isAggregated returns true if the signature of the CommitSig at valIdx is aggregated.
+func (commit *Commit) isAggregated(valIdx int) bool {
+	return commit.AggregatedSigners != nil && commit.AggregatedSigners.GetIndex(valIdx)
+}
+
+func (commit *Commit) validateAggregation() error {
+	if commit.AggregatedSigners == nil {
+		if len(commit.AggregatedSignature) != 0 {
+			return errors.New("aggregated signature without aggregated signers")
+		}
+		return nil
+	}
+	signers := commit.AggregatedSigners
+	if signers.Size() != len(commit.Signatures) || len(signers.Elems) != (signers.Size()+63)/64 {
+		return fmt.Errorf("wrong AggregatedSigners size: expected %d bits, got %d bits and %d elems",
+			len(commit.Signatures), signers.Size(), len(signers.Elems))
+	}
+	if signers.IsEmpty() {
+		return errors.New("no aggregated signers")
+	}
+	if len(commit.AggregatedSignature) != bls12381.SignatureSize {
+		return fmt.Errorf("expected AggregatedSignature size to be %d bytes, got %d bytes",
+			bls12381.SignatureSize, len(commit.AggregatedSignature))
+	}
+	return nil
+}
+
+// validateAggregated performs basic validation of a CommitSig whose signature is aggregated.
+func (cs CommitSig) validateAggregated() error {
+	if !cs.ForBlock() {
+		return fmt.Errorf("aggregated signature with BlockIDFlag %v", cs.BlockIDFlag)
+	}
+	if len(cs.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected ValidatorAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize,
+			len(cs.ValidatorAddress),
+		)
+	}
+	if len(cs.Signature) != 0 {
+		return errors.New("signature of an aggregated signer is present")
+	}
+	return nil
+}
+
+// aggregatedSignature collects the keys and the sign bytes of the signers of an aggregated
+// signature, to verify them all at once.
+type aggregatedSignature struct {
+	pubKeys []bls12381.PubKey
+	msgs    [][]byte
+}
+
+func (a *aggregatedSignature) add(pubKey crypto.PubKey, msg []byte) error {
+	blsPubKey, ok := pubKey.(bls12381.PubKey)
+	if !ok {
+		return fmt.Errorf("key type %s can't be aggregated", pubKey.Type())
+	}
+	a.pubKeys = append(a.pubKeys, blsPubKey)
+	a.msgs = append(a.msgs, msg)
+	return nil
+}
+
+func (a *aggregatedSignature) empty() bool {
+	return len(a.pubKeys) == 0
+}
+
+func (a *aggregatedSignature) verify(sig []byte) bool {
+	if a.empty() {
+		return len(sig) == 0
+	}
+	return bls12381.VerifyAggregatedSignature(a.pubKeys, a.msgs, sig)
+}
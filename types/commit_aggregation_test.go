@@ -0,0 +1,182 @@
+package types
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	tmmath "github.com/Finschia/ostracon/libs/math"
+	tmtime "github.com/Finschia/ostracon/types/time"
+)
+
+// randMixedValidatorSet returns a validator set of numBLS validators with BLS12-381 keys and
+// numEd25519 validators with ed25519 keys, all with the same voting power.
+func randMixedValidatorSet(numBLS, numEd25519 int) (*ValidatorSet, []PrivValidator) {
+	privVals := make([]PrivValidator, 0, numBLS+numEd25519)
+	vals := make([]*Validator, 0, numBLS+numEd25519)
+	for i := 0; i < numBLS+numEd25519; i++ {
+		var privKey crypto.PrivKey = ed25519.GenPrivKey()
+		if i < numBLS {
+			privKey = bls12381.GenPrivKey()
+		}
+		privVal := NewMockPVWithParams(privKey, false, false)
+		privVals = append(privVals, privVal)
+		vals = append(vals, privVal.ExtractIntoValidator(10))
+	}
+	sort.Sort(PrivValidatorsByAddress(privVals))
+	return NewValidatorSet(vals), privVals
+}
+
+func makeAggregatedCommit(t *testing.T, blockID BlockID, numBLS, numEd25519 int) (*Commit, *Commit, *ValidatorSet) {
+	valSet, privVals := randMixedValidatorSet(numBLS, numEd25519)
+	voteSet := NewVoteSet("test_chain_id", 3, 0, tmproto.PrecommitType, valSet)
+	commit, err := MakeCommit(blockID, 3, 0, voteSet, privVals, tmtime.Now())
+	require.NoError(t, err)
+	aggregated, err := commit.AggregateSignatures(valSet)
+	require.NoError(t, err)
+	return commit, aggregated, valSet
+}
+
+func firstAggregatedSigner(commit *Commit) int {
+	for idx := range commit.Signatures {
+		if commit.AggregatedSigners.GetIndex(idx) {
+			return idx
+		}
+	}
+	return -1
+}
+
+func TestCommitAggregateSignatures(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	commit, aggregated, valSet := makeAggregatedCommit(t, blockID, 3, 1)
+
+	require.True(t, aggregated.IsAggregated())
+	assert.False(t, commit.IsAggregated())
+	require.NoError(t, commit.ValidateBasic())
+	assert.Len(t, aggregated.AggregatedSignature, bls12381.SignatureSize)
+	for idx, val := range valSet.Validators {
+		_, isBLS := val.PubKey.(bls12381.PubKey)
+		assert.Equal(t, isBLS, aggregated.AggregatedSigners.GetIndex(idx))
+		if isBLS {
+			assert.Empty(t, aggregated.Signatures[idx].Signature)
+		} else {
+			assert.Equal(t, commit.Signatures[idx].Signature, aggregated.Signatures[idx].Signature)
+		}
+		// the original commit is left untouched
+		assert.NotEmpty(t, commit.Signatures[idx].Signature)
+	}
+	require.NoError(t, aggregated.ValidateBasic())
+	assert.NotEqual(t, commit.Hash(), aggregated.Hash())
+
+	assert.NoError(t, valSet.VerifyCommit("test_chain_id", blockID, 3, aggregated))
+	assert.NoError(t, valSet.VerifyCommitLight("test_chain_id", blockID, 3, aggregated))
+	assert.NoError(t, valSet.VerifyCommitLightTrusting("test_chain_id", aggregated,
+		tmmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// aggregating twice is a no-op
+	again, err := aggregated.AggregateSignatures(valSet)
+	require.NoError(t, err)
+	assert.Equal(t, aggregated, again)
+
+	// a commit with a single BLS12-381 signature isn't aggregated
+	commit, aggregated, _ = makeAggregatedCommit(t, blockID, 1, 3)
+	assert.Equal(t, commit, aggregated)
+}
+
+func TestCommitAggregateSignaturesProto(t *testing.T) {
+	_, aggregated, _ := makeAggregatedCommit(t, makeBlockIDRandom(), 3, 1)
+
+	pb := aggregated.ToProto()
+	bz, err := pb.Marshal()
+	require.NoError(t, err)
+	pb.Reset()
+	require.NoError(t, pb.Unmarshal(bz))
+
+	commit, err := CommitFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, aggregated.Signatures, commit.Signatures)
+	assert.Equal(t, aggregated.AggregatedSignature, commit.AggregatedSignature)
+	assert.Equal(t, aggregated.AggregatedSigners.String(), commit.AggregatedSigners.String())
+	assert.Equal(t, aggregated.Hash(), commit.Hash())
+}
+
+func TestCommitAggregateSignaturesValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		malleateCommit func(*Commit)
+		expectErr      bool
+	}{
+		{"Aggregated commit", func(com *Commit) {}, false},
+		{"Signature of an aggregated signer", func(com *Commit) {
+			com.Signatures[firstAggregatedSigner(com)].Signature = []byte{0}
+		}, true},
+		{"Aggregated absent signer", func(com *Commit) {
+			com.Signatures[firstAggregatedSigner(com)] = NewCommitSigAbsent()
+		}, true},
+		{"No aggregated signature", func(com *Commit) { com.AggregatedSignature = nil }, true},
+		{"Wrong aggregated signature size", func(com *Commit) {
+			com.AggregatedSignature = com.AggregatedSignature[1:]
+		}, true},
+		{"No aggregated signers", func(com *Commit) { com.AggregatedSigners = nil }, true},
+		{"Wrong aggregated signers size", func(com *Commit) {
+			com.Signatures = append(com.Signatures, NewCommitSigAbsent())
+		}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			_, com, _ := makeAggregatedCommit(t, makeBlockIDRandom(), 3, 1)
+			tc.malleateCommit(com)
+			assert.Equal(t, tc.expectErr, com.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
+func TestValidatorSet_VerifyCommit_AggregatedSignature(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		blockID = makeBlockIDRandom()
+		trust   = tmmath.Fraction{Numerator: 1, Denominator: 3}
+	)
+	_, aggregated, valSet := makeAggregatedCommit(t, blockID, 3, 1)
+
+	// another aggregated signature
+	_, other, _ := makeAggregatedCommit(t, blockID, 3, 1)
+	aggregated.AggregatedSignature = other.AggregatedSignature
+	for _, err := range []error{
+		valSet.VerifyCommit(chainID, blockID, 3, aggregated),
+		valSet.VerifyCommitLight(chainID, blockID, 3, aggregated),
+		valSet.VerifyCommitLightTrusting(chainID, aggregated, trust),
+	} {
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "wrong aggregated signature")
+		}
+	}
+
+	// a trusted set that misses an aggregated signer can't verify the aggregated signature
+	_, aggregated, valSet = makeAggregatedCommit(t, blockID, 3, 1)
+	for _, val := range valSet.Validators {
+		if _, ok := val.PubKey.(bls12381.PubKey); ok {
+			require.NoError(t, valSet.UpdateWithChangeSet([]*Validator{NewValidator(val.PubKey, 0)}))
+			break
+		}
+	}
+	err := valSet.VerifyCommitLightTrusting(chainID, aggregated, trust)
+	assert.IsType(t, ErrNotEnoughVotingPowerSigned{}, err)
+}
+
+func TestBlockValidateBasicAggregatedLastCommit(t *testing.T) {
+	_, aggregated, _ := makeAggregatedCommit(t, makeBlockIDRandom(), 3, 1)
+	block := MakeBlock(4, []Tx{Tx("Hello World")}, aggregated, nil, TestConsensusVersion)
+	block.ProposerAddress = aggregated.Signatures[0].ValidatorAddress
+	err := block.ValidateBasic()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "aggregated LastCommit")
+	}
+}
@@ -163,6 +163,10 @@ func (b *EventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return b.Publish(EventNewEvidence, evidence)
 }
 
+func (b *EventBus) PublishEventPendingEvidence(evidence EventDataPendingEvidence) error {
+	return b.Publish(EventPendingEvidence, evidence)
+}
+
 func (b *EventBus) PublishEventVote(data EventDataVote) error {
 	return b.Publish(EventVote, data)
 }
@@ -260,6 +264,10 @@ func (NopEventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return nil
 }
 
+func (NopEventBus) PublishEventPendingEvidence(evidence EventDataPendingEvidence) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventVote(data EventDataVote) error {
 	return nil
 }
@@ -20,6 +20,7 @@ const (
 	EventNewBlock            = "NewBlock"
 	EventNewBlockHeader      = "NewBlockHeader"
 	EventNewEvidence         = "NewEvidence"
+	EventPendingEvidence     = "PendingEvidence"
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
@@ -50,6 +51,7 @@ func init() {
 	tmjson.RegisterType(EventDataNewBlock{}, "ostracon/event/NewBlock")
 	tmjson.RegisterType(EventDataNewBlockHeader{}, "ostracon/event/NewBlockHeader")
 	tmjson.RegisterType(EventDataNewEvidence{}, "ostracon/event/NewEvidence")
+	tmjson.RegisterType(EventDataPendingEvidence{}, "ostracon/event/PendingEvidence")
 	tmjson.RegisterType(EventDataTx{}, "ostracon/event/Tx")
 	tmjson.RegisterType(EventDataRoundState{}, "ostracon/event/RoundState")
 	tmjson.RegisterType(EventDataNewRound{}, "ostracon/event/NewRound")
@@ -83,6 +85,12 @@ type EventDataNewEvidence struct {
 	Height int64 `json:"height"`
 }
 
+// EventDataPendingEvidence is fired when evidence is first added to the evidence pool,
+// before it's committed in a block.
+type EventDataPendingEvidence struct {
+	Evidence Evidence `json:"evidence"`
+}
+
 // All txs fire EventDataTx
 type EventDataTx struct {
 	abci.TxResult
@@ -151,6 +159,7 @@ var (
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
 	EventQueryNewRound            = QueryForEvent(EventNewRound)
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
+	EventQueryPendingEvidence     = QueryForEvent(EventPendingEvidence)
 	EventQueryPolka               = QueryForEvent(EventPolka)
 	EventQueryRelock              = QueryForEvent(EventRelock)
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
@@ -179,6 +188,11 @@ type BlockEventPublisher interface {
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
 }
 
+// EvidenceEventPublisher publishes evidence pool related events
+type EvidenceEventPublisher interface {
+	PublishEventPendingEvidence(evidence EventDataPendingEvidence) error
+}
+
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
@@ -12,10 +12,12 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/merkle"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 )
 
 func MaxEvidenceBytes(ev Evidence) int64 {
@@ -29,6 +31,13 @@ func MaxEvidenceBytes(ev Evidence) int64 {
 	case *LightClientAttackEvidence:
 		// FIXME 🏺 need this?
 		return 0
+	case *DuplicateProposalEvidence:
+		return (1 + MaxProposalBytes + 2) + // ProposalA
+			(1 + MaxProposalBytes + 2) + // ProposalB
+			(1 + 20 + 1) + // ProposerAddress
+			(1 + 9) + // TotalVotingPower
+			(1 + 9) + // ValidatorPower
+			(1 + 17 + 1) // Timestamp
 	default:
 		panic(fmt.Sprintf("unsupported evidence: %+v", ev))
 	}
@@ -197,6 +206,168 @@ func DuplicateVoteEvidenceFromProto(pb *tmproto.DuplicateVoteEvidence) (*Duplica
 	return dve, dve.ValidateBasic()
 }
 
+//--------------------------------------------------------------------------------------
+
+// DuplicateProposalEvidence contains evidence of a proposer signing two conflicting proposals,
+// e.g. proposals of blocks with different entropies to grind the proposer election.
+type DuplicateProposalEvidence struct {
+	ProposalA       *Proposal `json:"proposal_a"`
+	ProposalB       *Proposal `json:"proposal_b"`
+	ProposerAddress Address   `json:"proposer_address"`
+
+	// abci specific information
+	TotalVotingPower int64
+	ValidatorPower   int64
+	Timestamp        time.Time
+}
+
+var _ Evidence = &DuplicateProposalEvidence{}
+
+// NewDuplicateProposalEvidence creates DuplicateProposalEvidence with right ordering given
+// two conflicting proposals of the proposer. If one of the proposals is nil, or the proposer
+// isn't in valSet, evidence returned is nil as well
+func NewDuplicateProposalEvidence(proposal1, proposal2 *Proposal, proposer Address, blockTime time.Time,
+	valSet *ValidatorSet) *DuplicateProposalEvidence {
+	var proposalA, proposalB *Proposal
+	if proposal1 == nil || proposal2 == nil || valSet == nil {
+		return nil
+	}
+	idx, val := valSet.GetByAddress(proposer)
+	if idx == -1 {
+		return nil
+	}
+
+	if strings.Compare(proposal1.BlockID.Key(), proposal2.BlockID.Key()) == -1 {
+		proposalA = proposal1
+		proposalB = proposal2
+	} else {
+		proposalA = proposal2
+		proposalB = proposal1
+	}
+	return &DuplicateProposalEvidence{
+		ProposalA:        proposalA,
+		ProposalB:        proposalB,
+		ProposerAddress:  proposer,
+		TotalVotingPower: valSet.TotalVotingPower(),
+		ValidatorPower:   val.VotingPower,
+		Timestamp:        blockTime,
+	}
+}
+
+// ABCI returns the application relevant representation of the evidence. ABCI has no
+// evidence type for conflicting proposals, so it is passed as DUPLICATE_VOTE: both are
+// equivocations of a validator signing two messages for the same height and round.
+func (dpe *DuplicateProposalEvidence) ABCI() []abci.Evidence {
+	return []abci.Evidence{{
+		Type: abci.EvidenceType_DUPLICATE_VOTE,
+		Validator: abci.Validator{
+			Address: dpe.ProposerAddress,
+			Power:   dpe.ValidatorPower,
+		},
+		Height:           dpe.ProposalA.Height,
+		Time:             dpe.Timestamp,
+		TotalVotingPower: dpe.TotalVotingPower,
+	}}
+}
+
+// Bytes returns the proto-encoded evidence as a byte array.
+func (dpe *DuplicateProposalEvidence) Bytes() []byte {
+	pbe := dpe.ToProto()
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Hash returns the hash of the evidence.
+func (dpe *DuplicateProposalEvidence) Hash() []byte {
+	return tmhash.Sum(dpe.Bytes())
+}
+
+// Height returns the height of the infraction
+func (dpe *DuplicateProposalEvidence) Height() int64 {
+	return dpe.ProposalA.Height
+}
+
+// String returns a string representation of the evidence.
+func (dpe *DuplicateProposalEvidence) String() string {
+	return fmt.Sprintf("DuplicateProposalEvidence{ProposalA: %v, ProposalB: %v, Proposer: %v}",
+		dpe.ProposalA, dpe.ProposalB, dpe.ProposerAddress)
+}
+
+// Time returns the time of the infraction
+func (dpe *DuplicateProposalEvidence) Time() time.Time {
+	return dpe.Timestamp
+}
+
+// ValidateBasic performs basic validation.
+func (dpe *DuplicateProposalEvidence) ValidateBasic() error {
+	if dpe == nil {
+		return errors.New("empty duplicate proposal evidence")
+	}
+
+	if dpe.ProposalA == nil || dpe.ProposalB == nil {
+		return fmt.Errorf("one or both of the proposals are empty %v, %v", dpe.ProposalA, dpe.ProposalB)
+	}
+	if err := dpe.ProposalA.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid ProposalA: %w", err)
+	}
+	if err := dpe.ProposalB.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid ProposalB: %w", err)
+	}
+	if len(dpe.ProposerAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected ProposerAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(dpe.ProposerAddress))
+	}
+	// Enforce Proposals are lexicographically sorted on blockID
+	if strings.Compare(dpe.ProposalA.BlockID.Key(), dpe.ProposalB.BlockID.Key()) >= 0 {
+		return errors.New("duplicate proposals in invalid order")
+	}
+	return nil
+}
+
+// ToProto encodes DuplicateProposalEvidence to protobuf
+func (dpe *DuplicateProposalEvidence) ToProto() *ocproto.DuplicateProposalEvidence {
+	return &ocproto.DuplicateProposalEvidence{
+		ProposalA:        dpe.ProposalA.ToProto(),
+		ProposalB:        dpe.ProposalB.ToProto(),
+		ProposerAddress:  dpe.ProposerAddress,
+		TotalVotingPower: dpe.TotalVotingPower,
+		ValidatorPower:   dpe.ValidatorPower,
+		Timestamp:        dpe.Timestamp,
+	}
+}
+
+// DuplicateProposalEvidenceFromProto decodes protobuf into DuplicateProposalEvidence
+func DuplicateProposalEvidenceFromProto(pb *ocproto.DuplicateProposalEvidence) (*DuplicateProposalEvidence, error) {
+	if pb == nil {
+		return nil, errors.New("nil duplicate proposal evidence")
+	}
+
+	pA, err := ProposalFromProto(pb.ProposalA)
+	if err != nil {
+		return nil, err
+	}
+
+	pB, err := ProposalFromProto(pb.ProposalB)
+	if err != nil {
+		return nil, err
+	}
+
+	dpe := &DuplicateProposalEvidence{
+		ProposalA:        pA,
+		ProposalB:        pB,
+		ProposerAddress:  pb.ProposerAddress,
+		TotalVotingPower: pb.TotalVotingPower,
+		ValidatorPower:   pb.ValidatorPower,
+		Timestamp:        pb.Timestamp,
+	}
+
+	return dpe, dpe.ValidateBasic()
+}
+
 //------------------------------------ LIGHT EVIDENCE --------------------------------------
 
 // LightClientAttackEvidence is a generalized evidence that captures all forms of known attacks on
@@ -383,22 +554,22 @@ func (l *LightClientAttackEvidence) ValidateBasic() error {
 }
 
 // ToProto encodes LightClientAttackEvidence to protobuf
-func (l *LightClientAttackEvidence) ToProto() (*tmproto.LightClientAttackEvidence, error) {
+func (l *LightClientAttackEvidence) ToProto() (*ocproto.LightClientAttackEvidence, error) {
 	conflictingBlock, err := l.ConflictingBlock.ToProto()
 	if err != nil {
 		return nil, err
 	}
 
-	byzVals := make([]*tmproto.Validator, len(l.ByzantineValidators))
+	byzVals := make([]*ocproto.Validator, len(l.ByzantineValidators))
 	for idx, val := range l.ByzantineValidators {
-		valpb, err := val.ToProto()
+		valpb, err := val.ToOCProto()
 		if err != nil {
 			return nil, err
 		}
 		byzVals[idx] = valpb
 	}
 
-	return &tmproto.LightClientAttackEvidence{
+	return &ocproto.LightClientAttackEvidence{
 		ConflictingBlock:    conflictingBlock,
 		CommonHeight:        l.CommonHeight,
 		ByzantineValidators: byzVals,
@@ -408,7 +579,7 @@ func (l *LightClientAttackEvidence) ToProto() (*tmproto.LightClientAttackEvidenc
 }
 
 // LightClientAttackEvidenceFromProto decodes protobuf
-func LightClientAttackEvidenceFromProto(lpb *tmproto.LightClientAttackEvidence) (*LightClientAttackEvidence, error) {
+func LightClientAttackEvidenceFromProto(lpb *ocproto.LightClientAttackEvidence) (*LightClientAttackEvidence, error) {
 	if lpb == nil {
 		return nil, errors.New("empty light client attack evidence")
 	}
@@ -420,7 +591,7 @@ func LightClientAttackEvidenceFromProto(lpb *tmproto.LightClientAttackEvidence)
 
 	byzVals := make([]*Validator, len(lpb.ByzantineValidators))
 	for idx, valpb := range lpb.ByzantineValidators {
-		val, err := ValidatorFromProto(valpb)
+		val, err := ValidatorFromOCProto(valpb)
 		if err != nil {
 			return nil, err
 		}
@@ -479,7 +650,7 @@ func (evl EvidenceList) Has(evidence Evidence) bool {
 
 // EvidenceToProto is a generalized function for encoding evidence that conforms to the
 // evidence interface to protobuf
-func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
+func EvidenceToProto(evidence Evidence) (*ocproto.Evidence, error) {
 	if evidence == nil {
 		return nil, errors.New("nil evidence")
 	}
@@ -487,8 +658,8 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 	switch evi := evidence.(type) {
 	case *DuplicateVoteEvidence:
 		pbev := evi.ToProto()
-		return &tmproto.Evidence{
-			Sum: &tmproto.Evidence_DuplicateVoteEvidence{
+		return &ocproto.Evidence{
+			Sum: &ocproto.Evidence_DuplicateVoteEvidence{
 				DuplicateVoteEvidence: pbev,
 			},
 		}, nil
@@ -498,12 +669,20 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &tmproto.Evidence{
-			Sum: &tmproto.Evidence_LightClientAttackEvidence{
+		return &ocproto.Evidence{
+			Sum: &ocproto.Evidence_LightClientAttackEvidence{
 				LightClientAttackEvidence: pbev,
 			},
 		}, nil
 
+	case *DuplicateProposalEvidence:
+		pbev := evi.ToProto()
+		return &ocproto.Evidence{
+			Sum: &ocproto.Evidence_DuplicateProposalEvidence{
+				DuplicateProposalEvidence: pbev,
+			},
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("toproto: evidence is not recognized: %T", evi)
 	}
@@ -511,16 +690,18 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 
 // EvidenceFromProto is a generalized function for decoding protobuf into the
 // evidence interface
-func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
+func EvidenceFromProto(evidence *ocproto.Evidence) (Evidence, error) {
 	if evidence == nil {
 		return nil, errors.New("nil evidence")
 	}
 
 	switch evi := evidence.Sum.(type) {
-	case *tmproto.Evidence_DuplicateVoteEvidence:
+	case *ocproto.Evidence_DuplicateVoteEvidence:
 		return DuplicateVoteEvidenceFromProto(evi.DuplicateVoteEvidence)
-	case *tmproto.Evidence_LightClientAttackEvidence:
+	case *ocproto.Evidence_LightClientAttackEvidence:
 		return LightClientAttackEvidenceFromProto(evi.LightClientAttackEvidence)
+	case *ocproto.Evidence_DuplicateProposalEvidence:
+		return DuplicateProposalEvidenceFromProto(evi.DuplicateProposalEvidence)
 	default:
 		return nil, errors.New("evidence is not recognized")
 	}
@@ -529,6 +710,7 @@ func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
 func init() {
 	tmjson.RegisterType(&DuplicateVoteEvidence{}, "ostracon/DuplicateVoteEvidence")
 	tmjson.RegisterType(&LightClientAttackEvidence{}, "ostracon/LightClientAttackEvidence")
+	tmjson.RegisterType(&DuplicateProposalEvidence{}, "ostracon/DuplicateProposalEvidence")
 }
 
 //-------------------------------------------- ERRORS --------------------------------------
@@ -591,6 +773,26 @@ func NewMockDuplicateVoteEvidenceWithValidator(height int64, time time.Time,
 	return NewDuplicateVoteEvidence(voteA, voteB, time, NewValidatorSet([]*Validator{val}))
 }
 
+// assumes voting power to be 10 and validator to be the only one in the set, so the proposer of
+// every round
+func NewMockDuplicateProposalEvidenceWithValidator(height int64, time time.Time,
+	pv PrivValidator, chainID string) *DuplicateProposalEvidence {
+	pubKey, _ := pv.GetPubKey()
+	val := NewValidator(pubKey, 10)
+	proposalA := NewProposal(height, 0, -1, randBlockID())
+	proposalA.Timestamp = time
+	pA := proposalA.ToProto()
+	_ = pv.SignProposal(chainID, pA)
+	proposalA.Signature = pA.Signature
+	proposalB := NewProposal(height, 0, -1, randBlockID())
+	proposalB.Timestamp = time
+	pB := proposalB.ToProto()
+	_ = pv.SignProposal(chainID, pB)
+	proposalB.Signature = pB.Signature
+	return NewDuplicateProposalEvidence(proposalA, proposalB, pubKey.Address(), time,
+		NewValidatorSet([]*Validator{val}))
+}
+
 func makeMockVote(height int64, round, index int32, addr Address,
 	blockID BlockID, time time.Time) *Vote {
 	return &Vote{
@@ -8,10 +8,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
 	"github.com/Finschia/ostracon/version"
@@ -33,7 +35,7 @@ func TestMaxEvidenceBytes(t *testing.T) {
 	// year int, month Month, day, hour, min, sec, nsec int, loc *Location
 	timestamp := time.Date(math.MaxInt64, 0, 0, 0, 0, 0, math.MaxInt64, time.UTC)
 
-	val := NewMockPV()
+	val := NewMockPVWithParams(bls12381.GenPrivKey(), false, false) // the largest signatures
 	randomPartHash := tmrand.Bytes(int(tmrand.Uint32() % 1000))
 	randomHash1 := tmrand.Bytes(int(tmrand.Uint32() % 1000))
 	randomHash2 := tmrand.Bytes(int(tmrand.Uint32() % 1000))
@@ -51,6 +53,21 @@ func TestMaxEvidenceBytes(t *testing.T) {
 	bz, err := ev.ToProto().Marshal()
 	require.NoError(t, err)
 	assert.EqualValues(t, MaxEvidenceBytes(ev), len(bz))
+
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	pev := &DuplicateProposalEvidence{
+		ProposalA:        makeProposal(t, val, chainID, math.MaxInt64, math.MaxInt32, -1, blockID, timestamp),
+		ProposalB:        makeProposal(t, val, chainID, math.MaxInt64, math.MaxInt32, -1, blockID2, timestamp),
+		ProposerAddress:  pubKey.Address(),
+		TotalVotingPower: math.MaxInt64,
+		ValidatorPower:   math.MaxInt64,
+		Timestamp:        timestamp,
+	}
+
+	bz, err = pev.ToProto().Marshal()
+	require.NoError(t, err)
+	assert.EqualValues(t, MaxEvidenceBytes(pev), len(bz))
 }
 
 func randomDuplicatedVoteEvidence(t *testing.T) *DuplicateVoteEvidence {
@@ -119,6 +136,56 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 	}
 }
 
+func TestDuplicateProposalEvidence(t *testing.T) {
+	const height = int64(13)
+	val := NewMockPV()
+	ev := NewMockDuplicateProposalEvidenceWithValidator(height, time.Now(), val, "mock-chain-id")
+	assert.Equal(t, ev.Hash(), tmhash.Sum(ev.Bytes()))
+	assert.NotNil(t, ev.String())
+	assert.Equal(t, ev.Height(), height)
+	assert.NoError(t, ev.ValidateBasic())
+
+	abciEv := ev.ABCI()
+	require.Len(t, abciEv, 1)
+	assert.Equal(t, abci.EvidenceType_DUPLICATE_VOTE, abciEv[0].Type)
+	assert.Equal(t, ev.ProposerAddress.Bytes(), abciEv[0].Validator.Address)
+	assert.Equal(t, height, abciEv[0].Height)
+}
+
+func TestDuplicateProposalEvidenceValidation(t *testing.T) {
+	val := NewMockPV()
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+
+	testCases := []struct {
+		testName         string
+		malleateEvidence func(*DuplicateProposalEvidence)
+		expectErr        bool
+	}{
+		{"Good DuplicateProposalEvidence", func(ev *DuplicateProposalEvidence) {}, false},
+		{"Nil proposal A", func(ev *DuplicateProposalEvidence) { ev.ProposalA = nil }, true},
+		{"Nil proposal B", func(ev *DuplicateProposalEvidence) { ev.ProposalB = nil }, true},
+		{"Invalid proposal", func(ev *DuplicateProposalEvidence) { ev.ProposalA.Signature = nil }, true},
+		{"Invalid proposer address", func(ev *DuplicateProposalEvidence) { ev.ProposerAddress = []byte("addr") }, true},
+		{"Invalid proposal order", func(ev *DuplicateProposalEvidence) {
+			ev.ProposalA, ev.ProposalB = ev.ProposalB, ev.ProposalA
+		}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			proposal1 := makeProposal(t, val, chainID, 10, 1, -1, blockID, defaultVoteTime)
+			proposal2 := makeProposal(t, val, chainID, 10, 1, -1, blockID2, defaultVoteTime)
+			valSet := NewValidatorSet([]*Validator{val.ExtractIntoValidator(10)})
+			ev := NewDuplicateProposalEvidence(proposal1, proposal2, val.PrivKey.PubKey().Address(),
+				defaultVoteTime, valSet)
+			tc.malleateEvidence(ev)
+			assert.Equal(t, tc.expectErr, ev.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestLightClientAttackEvidenceBasic(t *testing.T) {
 	height := int64(5)
 	commonHeight := height - 1
@@ -283,6 +350,19 @@ func makeVote(
 	return v
 }
 
+func makeProposal(
+	t *testing.T, val PrivValidator, chainID string, height int64, round, polRound int32, blockID BlockID,
+	time time.Time) *Proposal {
+	p := NewProposal(height, round, polRound, blockID)
+	p.Timestamp = time
+
+	ppb := p.ToProto()
+	err := val.SignProposal(chainID, ppb)
+	require.NoError(t, err)
+	p.Signature = ppb.Signature
+	return p
+}
+
 func makeHeaderRandom() *Header {
 	return &Header{
 		Version:            tmversion.Consensus{Block: version.BlockProtocol, App: version.AppProtocol},
@@ -311,6 +391,11 @@ func TestEvidenceProto(t *testing.T) {
 	v := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 1, 0x01, blockID, defaultVoteTime)
 	v2 := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 2, 0x01, blockID2, defaultVoteTime)
 
+	// -------- Proposals --------
+	p := makeProposal(t, val, chainID, math.MaxInt64, 1, -1, blockID, defaultVoteTime)
+	p2 := makeProposal(t, val, chainID, math.MaxInt64, 1, -1, blockID2, defaultVoteTime)
+	proposer := val.PrivKey.PubKey().Address()
+
 	// -------- SignedHeaders --------
 	const height int64 = 37
 
@@ -338,6 +423,12 @@ func TestEvidenceProto(t *testing.T) {
 		{"DuplicateVoteEvidence nil voteB", &DuplicateVoteEvidence{VoteA: v, VoteB: nil}, false, true},
 		{"DuplicateVoteEvidence nil voteA", &DuplicateVoteEvidence{VoteA: nil, VoteB: v}, false, true},
 		{"DuplicateVoteEvidence success", &DuplicateVoteEvidence{VoteA: v2, VoteB: v}, false, false},
+		{"DuplicateProposalEvidence empty fail", &DuplicateProposalEvidence{}, false, true},
+		{"DuplicateProposalEvidence nil proposalB",
+			&DuplicateProposalEvidence{ProposalA: p, ProposalB: nil, ProposerAddress: proposer}, false, true},
+		{"DuplicateProposalEvidence success",
+			NewDuplicateProposalEvidence(p, p2, proposer, defaultVoteTime, NewValidatorSet([]*Validator{
+				val.ExtractIntoValidator(10)})), false, false},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -11,6 +11,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/Finschia/ostracon/crypto"
+	cryptoenc "github.com/Finschia/ostracon/crypto/encoding"
 	tmbytes "github.com/Finschia/ostracon/libs/bytes"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmos "github.com/Finschia/ostracon/libs/os"
@@ -98,6 +99,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		if len(v.Address) == 0 {
 			genDoc.Validators[i].Address = v.PubKey.Address()
 		}
+		// the validators are passed to the application in Tendermint's PublicKey
+		if _, err := cryptoenc.PubKeyToProto(v.PubKey); err != nil {
+			return fmt.Errorf("the genesis file cannot contain validators with keys unsupported by ABCI: %v: %w", v, err)
+		}
 	}
 
 	if genDoc.GenesisTime.IsZero() {
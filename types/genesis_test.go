@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmjson "github.com/Finschia/ostracon/libs/json"
 	tmtime "github.com/Finschia/ostracon/types/time"
@@ -57,6 +58,14 @@ func TestGenesisBad(t *testing.T) {
 	}
 }
 
+func TestGenesisUnsupportedABCIKey(t *testing.T) {
+	genDoc := &GenesisDoc{
+		ChainID:    "test-chain",
+		Validators: []GenesisValidator{{PubKey: bls12381.GenPrivKey().PubKey(), Power: 10}},
+	}
+	assert.Error(t, genDoc.ValidateAndComplete())
+}
+
 func TestGenesisGood(t *testing.T) {
 	// test a good one by raw json
 	genDocBytes := []byte(
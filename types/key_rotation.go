@@ -0,0 +1,326 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	ce "github.com/Finschia/ostracon/crypto/encoding"
+	"github.com/Finschia/ostracon/crypto/merkle"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+	tmmath "github.com/Finschia/ostracon/libs/math"
+	"github.com/Finschia/ostracon/libs/protoio"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
+)
+
+// MaxKeyRotationBytes is the maximum size of a protobuf encoded key rotation, with the keys and
+// signatures of BLS12-381, the biggest ones.
+const MaxKeyRotationBytes int64 = (1 + 20 + 1) + // ValidatorAddress
+	(1 + (2 + bls12381.PubKeySize + 1) + 1) + // NewPubKey
+	(1 + 9) + // ActivationHeight
+	(1 + 9) + // BlockHeight
+	(1 + 17 + 1) + // Timestamp
+	(1 + bls12381.SignatureSize + 1) + // Signature
+	(1 + bls12381.SignatureSize + 1) // NewKeySignature
+
+// KeyRotation is a message signed by a validator to replace its consensus key by NewPubKey from
+// ActivationHeight on. The validator keeps its address, so it stays the same validator to the
+// application and in evidence.
+//
+// It is signed by the last key the validator announced, and by the new key to prove its
+// possession. Like evidence, it carries the height and time of a committed block, which it has to
+// be committed within the evidence max age of.
+//
+// Key rotations are gossiped on their own channel and committed in the KeyRotations of a block.
+// They aren't sent to the application.
+type KeyRotation struct {
+	ValidatorAddress Address       `json:"validator_address"`
+	NewPubKey        crypto.PubKey `json:"new_pub_key"`
+	ActivationHeight int64         `json:"activation_height"`
+
+	// the last committed block when the rotation is signed
+	BlockHeight int64     `json:"block_height"`
+	Timestamp   time.Time `json:"timestamp"`
+
+	Signature       []byte `json:"signature"`
+	NewKeySignature []byte `json:"new_key_signature"`
+}
+
+// NewKeyRotation creates a KeyRotation of the validator with address to the key of newPrivKey,
+// signed by privKey and newPrivKey. blockHeight and blockTime are the ones of the last committed
+// block.
+func NewKeyRotation(chainID string, address Address, privKey, newPrivKey crypto.PrivKey,
+	activationHeight, blockHeight int64, blockTime time.Time) (*KeyRotation, error) {
+	kr := &KeyRotation{
+		ValidatorAddress: address,
+		NewPubKey:        newPrivKey.PubKey(),
+		ActivationHeight: activationHeight,
+		BlockHeight:      blockHeight,
+		Timestamp:        blockTime,
+	}
+	signBytes := kr.SignBytes(chainID)
+	var err error
+	if kr.Signature, err = privKey.Sign(signBytes); err != nil {
+		return nil, err
+	}
+	if kr.NewKeySignature, err = newPrivKey.Sign(signBytes); err != nil {
+		return nil, err
+	}
+	return kr, kr.ValidateBasic()
+}
+
+// Bytes returns the proto-encoded key rotation as a byte array.
+func (kr *KeyRotation) Bytes() []byte {
+	pbkr, err := kr.ToProto()
+	if err != nil {
+		panic(err)
+	}
+	bz, err := pbkr.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Hash returns the hash of the key rotation.
+func (kr *KeyRotation) Hash() []byte {
+	return tmhash.Sum(kr.Bytes())
+}
+
+// Height returns the height of the block the key rotation refers to
+func (kr *KeyRotation) Height() int64 {
+	return kr.BlockHeight
+}
+
+// String returns a string representation of the key rotation.
+func (kr *KeyRotation) String() string {
+	return fmt.Sprintf("KeyRotation{Validator: %v, NewPubKey: %v, ActivationHeight: %d, BlockHeight: %d}",
+		kr.ValidatorAddress, kr.NewPubKey, kr.ActivationHeight, kr.BlockHeight)
+}
+
+// Time returns the time of the block the key rotation refers to
+func (kr *KeyRotation) Time() time.Time {
+	return kr.Timestamp
+}
+
+// SignBytes returns the bytes that both the current and the new key sign.
+func (kr *KeyRotation) SignBytes(chainID string) []byte {
+	pk, err := ce.PubKeyToOCProto(kr.NewPubKey)
+	if err != nil {
+		panic(err)
+	}
+	pb := ocproto.CanonicalKeyRotation{
+		ValidatorAddress: kr.ValidatorAddress,
+		NewPubKey:        pk,
+		ActivationHeight: kr.ActivationHeight,
+		Height:           kr.BlockHeight,
+		Timestamp:        kr.Timestamp,
+		ChainID:          chainID,
+	}
+	bz, err := protoio.MarshalDelimited(&pb)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Verify checks that pubKey, the last key the validator announced, and the new key signed the key
+// rotation.
+func (kr *KeyRotation) Verify(chainID string, pubKey crypto.PubKey) error {
+	signBytes := kr.SignBytes(chainID)
+	if !pubKey.VerifySignature(signBytes, kr.Signature) {
+		return errors.New("invalid signature")
+	}
+	if !kr.NewPubKey.VerifySignature(signBytes, kr.NewKeySignature) {
+		return errors.New("invalid signature of the new key")
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation.
+func (kr *KeyRotation) ValidateBasic() error {
+	if kr == nil {
+		return errors.New("empty key rotation")
+	}
+	if len(kr.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected ValidatorAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(kr.ValidatorAddress))
+	}
+	if kr.NewPubKey == nil {
+		return errors.New("missing new public key")
+	}
+	if kr.BlockHeight <= 0 {
+		return errors.New("non positive BlockHeight")
+	}
+	if kr.ActivationHeight <= kr.BlockHeight {
+		return fmt.Errorf("ActivationHeight %d is not after BlockHeight %d", kr.ActivationHeight, kr.BlockHeight)
+	}
+	if len(kr.Signature) == 0 {
+		return errors.New("missing signature")
+	}
+	if len(kr.Signature) > MaxSignatureSize {
+		return fmt.Errorf("signature is too big %d (max: %d)", len(kr.Signature), MaxSignatureSize)
+	}
+	if len(kr.NewKeySignature) == 0 {
+		return errors.New("missing signature of the new key")
+	}
+	if len(kr.NewKeySignature) > MaxSignatureSize {
+		return fmt.Errorf("signature of the new key is too big %d (max: %d)", len(kr.NewKeySignature),
+			MaxSignatureSize)
+	}
+	return nil
+}
+
+// ToProto encodes KeyRotation to protobuf
+func (kr *KeyRotation) ToProto() (*ocproto.KeyRotation, error) {
+	if kr == nil {
+		return nil, errors.New("nil key rotation")
+	}
+	pk, err := ce.PubKeyToOCProto(kr.NewPubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ocproto.KeyRotation{
+		ValidatorAddress: kr.ValidatorAddress,
+		NewPubKey:        pk,
+		ActivationHeight: kr.ActivationHeight,
+		Height:           kr.BlockHeight,
+		Timestamp:        kr.Timestamp,
+		Signature:        kr.Signature,
+		NewKeySignature:  kr.NewKeySignature,
+	}, nil
+}
+
+// KeyRotationFromProto decodes protobuf into KeyRotation
+func KeyRotationFromProto(pb *ocproto.KeyRotation) (*KeyRotation, error) {
+	if pb == nil {
+		return nil, errors.New("nil key rotation")
+	}
+
+	pk, err := ce.PubKeyFromOCProto(&pb.NewPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	kr := &KeyRotation{
+		ValidatorAddress: pb.ValidatorAddress,
+		NewPubKey:        pk,
+		ActivationHeight: pb.ActivationHeight,
+		BlockHeight:      pb.Height,
+		Timestamp:        pb.Timestamp,
+		Signature:        pb.Signature,
+		NewKeySignature:  pb.NewKeySignature,
+	}
+
+	return kr, kr.ValidateBasic()
+}
+
+//------------------------------------------------------------------------------------------
+
+// KeyRotationList is the list of the key rotations of a block.
+type KeyRotationList []*KeyRotation
+
+// Hash returns the simple merkle root hash of the KeyRotationList.
+func (krl KeyRotationList) Hash() []byte {
+	bzs := make([][]byte, len(krl))
+	for i, kr := range krl {
+		bzs[i] = kr.Bytes()
+	}
+	return merkle.HashFromByteSlices(bzs)
+}
+
+// Has returns true if the key rotation is in the KeyRotationList.
+func (krl KeyRotationList) Has(kr *KeyRotation) bool {
+	for _, other := range krl {
+		if bytes.Equal(kr.Hash(), other.Hash()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBasic performs basic validation of the key rotations, and checks that neither a
+// validator nor a new key is in two of them. They couldn't be committed together, since
+// the rotations of a validator are signed by the last key it announced.
+func (krl KeyRotationList) ValidateBasic() error {
+	rotatedVals := make(map[string]struct{}, len(krl))
+	newKeys := make(map[string]struct{}, len(krl))
+	for i, kr := range krl {
+		if err := kr.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid key rotation (#%d): %w", i, err)
+		}
+		if _, ok := rotatedVals[string(kr.ValidatorAddress)]; ok {
+			return fmt.Errorf("duplicate key rotation of validator %X (#%d)", kr.ValidatorAddress, i)
+		}
+		rotatedVals[string(kr.ValidatorAddress)] = struct{}{}
+		if _, ok := newKeys[string(kr.NewPubKey.Bytes())]; ok {
+			return fmt.Errorf("duplicate new key %v (#%d)", kr.NewPubKey, i)
+		}
+		newKeys[string(kr.NewPubKey.Bytes())] = struct{}{}
+	}
+	return nil
+}
+
+// ByteSize returns the size the key rotations add to a protobuf encoded block. A block without key
+// rotations is encoded without the field.
+func (krl KeyRotationList) ByteSize() int64 {
+	if len(krl) == 0 {
+		return 0
+	}
+	pb, err := krl.ToProto()
+	if err != nil {
+		panic(err)
+	}
+	return int64((&ocproto.Block{KeyRotations: pb}).Size())
+}
+
+// StringIndented returns a string representation of the key rotations.
+func (krl KeyRotationList) StringIndented(indent string) string {
+	krStrings := make([]string, tmmath.MinInt(len(krl), 21))
+	for i, kr := range krl {
+		if i == 20 {
+			krStrings[i] = fmt.Sprintf("... (%v total)", len(krl))
+			break
+		}
+		krStrings[i] = fmt.Sprintf("KeyRotation:%v", kr)
+	}
+	return fmt.Sprintf(`KeyRotationList{
+%s  %v
+%s}`,
+		indent, strings.Join(krStrings, "\n"+indent+"  "),
+		indent)
+}
+
+// ToProto converts KeyRotationList to protobuf
+func (krl KeyRotationList) ToProto() (*ocproto.KeyRotationList, error) {
+	pb := &ocproto.KeyRotationList{KeyRotations: make([]*ocproto.KeyRotation, len(krl))}
+	for i, kr := range krl {
+		pbkr, err := kr.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		pb.KeyRotations[i] = pbkr
+	}
+	return pb, nil
+}
+
+// KeyRotationListFromProto decodes protobuf into KeyRotationList. A nil list decodes to nil.
+func KeyRotationListFromProto(pb *ocproto.KeyRotationList) (KeyRotationList, error) {
+	if pb == nil || len(pb.KeyRotations) == 0 {
+		return nil, nil
+	}
+	krl := make(KeyRotationList, len(pb.KeyRotations))
+	for i, pbkr := range pb.KeyRotations {
+		kr, err := KeyRotationFromProto(pbkr)
+		if err != nil {
+			return nil, err
+		}
+		krl[i] = kr
+	}
+	return krl, nil
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/tmhash"
+)
+
+func TestMaxKeyRotationBytes(t *testing.T) {
+	const chainID = "mychain"
+	privKey := bls12381.GenPrivKey()
+	timestamp := time.Date(math.MaxInt64, 0, 0, 0, 0, 0, math.MaxInt64, time.UTC)
+	kr, err := NewKeyRotation(chainID, privKey.PubKey().Address(), privKey, bls12381.GenPrivKey(), math.MaxInt64,
+		math.MaxInt64-1, timestamp)
+	require.NoError(t, err)
+
+	pbkr, err := kr.ToProto()
+	require.NoError(t, err)
+	bz, err := pbkr.Marshal()
+	require.NoError(t, err)
+	assert.EqualValues(t, MaxKeyRotationBytes, len(bz))
+}
+
+func TestKeyRotation(t *testing.T) {
+	const chainID = "mychain"
+	privKey, newPrivKey := ed25519.GenPrivKey(), bls12381.GenPrivKey()
+	address := privKey.PubKey().Address()
+	kr, err := NewKeyRotation(chainID, address, privKey, newPrivKey, 15, 13, defaultVoteTime)
+	require.NoError(t, err)
+	assert.Equal(t, kr.Hash(), tmhash.Sum(kr.Bytes()))
+	assert.NotNil(t, kr.String())
+	assert.Equal(t, int64(13), kr.Height())
+	assert.Equal(t, defaultVoteTime, kr.Time())
+
+	assert.NoError(t, kr.Verify(chainID, privKey.PubKey()))
+	assert.Error(t, kr.Verify("otherchain", privKey.PubKey()))
+	assert.Error(t, kr.Verify(chainID, ed25519.GenPrivKey().PubKey()))
+
+	// the new key must have signed it
+	kr.NewPubKey = bls12381.GenPrivKey().PubKey()
+	assert.Error(t, kr.Verify(chainID, privKey.PubKey()))
+}
+
+func TestKeyRotationValidation(t *testing.T) {
+	const chainID = "mychain"
+	privKey := ed25519.GenPrivKey()
+
+	testCases := []struct {
+		testName  string
+		malleate  func(*KeyRotation)
+		expectErr bool
+	}{
+		{"Good KeyRotation", func(kr *KeyRotation) {}, false},
+		{"Invalid validator address", func(kr *KeyRotation) { kr.ValidatorAddress = []byte("addr") }, true},
+		{"Nil new key", func(kr *KeyRotation) { kr.NewPubKey = nil }, true},
+		{"Non positive block height", func(kr *KeyRotation) { kr.BlockHeight = 0 }, true},
+		{"Activation height not after block height", func(kr *KeyRotation) { kr.ActivationHeight = 10 }, true},
+		{"Nil signature", func(kr *KeyRotation) { kr.Signature = nil }, true},
+		{"Too big signature", func(kr *KeyRotation) { kr.Signature = make([]byte, MaxSignatureSize+1) }, true},
+		{"Nil signature of the new key", func(kr *KeyRotation) { kr.NewKeySignature = nil }, true},
+		{"Too big signature of the new key", func(kr *KeyRotation) {
+			kr.NewKeySignature = make([]byte, MaxSignatureSize+1)
+		}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			kr, err := NewKeyRotation(chainID, privKey.PubKey().Address(), privKey, ed25519.GenPrivKey(), 12, 10,
+				defaultVoteTime)
+			require.NoError(t, err)
+			tc.malleate(kr)
+			assert.Equal(t, tc.expectErr, kr.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
+func TestKeyRotationList(t *testing.T) {
+	const chainID = "mychain"
+	makeKeyRotation := func(privKey, newPrivKey crypto.PrivKey) *KeyRotation {
+		kr, err := NewKeyRotation(chainID, privKey.PubKey().Address(), privKey, newPrivKey, 12, 10,
+			defaultVoteTime)
+		require.NoError(t, err)
+		return kr
+	}
+	privKey, privKey2, newPrivKey := ed25519.GenPrivKey(), ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	kr := makeKeyRotation(privKey, newPrivKey)
+	krl := KeyRotationList{kr}
+
+	assert.NotNil(t, krl.Hash())
+	assert.True(t, krl.Has(kr))
+	assert.False(t, krl.Has(makeKeyRotation(privKey2, newPrivKey)))
+	assert.NoError(t, krl.ValidateBasic())
+	assert.Zero(t, KeyRotationList{}.ByteSize())
+	assert.Positive(t, krl.ByteSize())
+
+	pb, err := krl.ToProto()
+	require.NoError(t, err)
+	krl2, err := KeyRotationListFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, krl, krl2)
+	krl2, err = KeyRotationListFromProto(nil)
+	require.NoError(t, err)
+	assert.Nil(t, krl2)
+
+	// a validator can't rotate its key twice in a list, nor two validators to the same key
+	otherKey := ed25519.GenPrivKey()
+	assert.Error(t, KeyRotationList{kr, makeKeyRotation(privKey, otherKey)}.ValidateBasic())
+	assert.Error(t, KeyRotationList{kr, makeKeyRotation(privKey2, newPrivKey)}.ValidateBasic())
+	assert.NoError(t, KeyRotationList{kr, makeKeyRotation(privKey2, otherKey)}.ValidateBasic())
+}
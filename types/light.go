@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 )
 
 // LightBlock is a SignedHeader and a ValidatorSet.
@@ -63,18 +63,18 @@ func (lb LightBlock) StringIndented(indent string) string {
 }
 
 // ToProto converts the LightBlock to protobuf
-func (lb *LightBlock) ToProto() (*tmproto.LightBlock, error) {
+func (lb *LightBlock) ToProto() (*ocproto.LightBlock, error) {
 	if lb == nil {
 		return nil, nil
 	}
 
-	lbp := new(tmproto.LightBlock)
+	lbp := new(ocproto.LightBlock)
 	var err error
 	if lb.SignedHeader != nil {
 		lbp.SignedHeader = lb.SignedHeader.ToProto()
 	}
 	if lb.ValidatorSet != nil {
-		lbp.ValidatorSet, err = lb.ValidatorSet.ToProto()
+		lbp.ValidatorSet, err = lb.ValidatorSet.ToOCProto()
 		if err != nil {
 			return nil, err
 		}
@@ -85,7 +85,7 @@ func (lb *LightBlock) ToProto() (*tmproto.LightBlock, error) {
 
 // LightBlockFromProto converts from protobuf back into the Lightblock.
 // An error is returned if either the validator set or signed header are invalid
-func LightBlockFromProto(pb *tmproto.LightBlock) (*LightBlock, error) {
+func LightBlockFromProto(pb *ocproto.LightBlock) (*LightBlock, error) {
 	if pb == nil {
 		return nil, errors.New("nil light block")
 	}
@@ -101,7 +101,7 @@ func LightBlockFromProto(pb *tmproto.LightBlock) (*LightBlock, error) {
 	}
 
 	if pb.ValidatorSet != nil {
-		vals, err := ValidatorSetFromProto(pb.ValidatorSet)
+		vals, err := ValidatorSetFromOCProto(pb.ValidatorSet)
 		if err != nil {
 			return nil, err
 		}
@@ -176,12 +176,12 @@ func (sh SignedHeader) StringIndented(indent string) string {
 }
 
 // ToProto converts SignedHeader to protobuf
-func (sh *SignedHeader) ToProto() *tmproto.SignedHeader {
+func (sh *SignedHeader) ToProto() *ocproto.SignedHeader {
 	if sh == nil {
 		return nil
 	}
 
-	psh := new(tmproto.SignedHeader)
+	psh := new(ocproto.SignedHeader)
 	if sh.Header != nil {
 		psh.Header = sh.Header.ToProto()
 	}
@@ -194,7 +194,7 @@ func (sh *SignedHeader) ToProto() *tmproto.SignedHeader {
 
 // FromProto sets a protobuf SignedHeader to the given pointer.
 // It returns an error if the header or the commit is invalid.
-func SignedHeaderFromProto(shp *tmproto.SignedHeader) (*SignedHeader, error) {
+func SignedHeaderFromProto(shp *ocproto.SignedHeader) (*SignedHeader, error) {
 	if shp == nil {
 		return nil, errors.New("nil SignedHeader")
 	}
@@ -22,6 +22,12 @@ type PrivValidator interface {
 	GenerateVRFProof(message []byte) (crypto.Proof, error)
 }
 
+// KeySwitcher is implemented by the private validators that can rotate the key
+// of their validator: they switch to the new key once the rotation is active.
+type KeySwitcher interface {
+	SwitchKey(pubKey crypto.PubKey) error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
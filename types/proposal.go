@@ -7,6 +7,7 @@ import (
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	tmbytes "github.com/Finschia/ostracon/libs/bytes"
 	"github.com/Finschia/ostracon/libs/protoio"
 	tmtime "github.com/Finschia/ostracon/types/time"
@@ -15,6 +16,7 @@ import (
 var (
 	ErrInvalidBlockPartSignature = errors.New("error invalid block part signature")
 	ErrInvalidBlockPartHash      = errors.New("error invalid block part hash")
+	ErrInvalidProposalSignature  = errors.New("error invalid proposal signature")
 )
 
 // Proposal defines a block proposal for the consensus.
@@ -33,6 +35,15 @@ type Proposal struct {
 	Signature []byte    `json:"signature"`
 }
 
+// MaxProposalBytes is the maximum size of a proto-encoded Proposal.
+const MaxProposalBytes int64 = (1 + 1) + // Type
+	(1 + 9) + // Height
+	(1 + 5) + // Round
+	(1 + 10) + // POLRound
+	(1 + 76 + 1) + // BlockID
+	(1 + 17 + 1) + // Timestamp
+	(1 + bls12381.SignatureSize + 1) // Signature
+
 // NewProposal returns a new Proposal.
 // If there is no POLRound, polRound should be -1.
 func NewProposal(height int64, round int32, polRound int32, blockID BlockID) *Proposal {
@@ -56,9 +56,10 @@ func (oc2pb) Header(header *Header) tmproto.Header {
 	}
 }
 
+// The address stays the one of the validator's first key when it rotates its key.
 func (oc2pb) Validator(val *Validator) abci.Validator {
 	return abci.Validator{
-		Address: val.PubKey.Address(),
+		Address: val.Address,
 		Power:   val.VotingPower,
 	}
 }
@@ -1,15 +1,16 @@
 package types
 
 import (
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	tmmath "github.com/Finschia/ostracon/libs/math"
 )
 
 var (
 	// MaxSignatureSize is a maximum allowed signature size for the Proposal
-	// and Vote.
+	// and Vote. BLS12-381 signatures are the largest ones.
 	// XXX: secp256k1 does not have Size nor MaxSize defined.
-	MaxSignatureSize = tmmath.MaxInt(ed25519.SignatureSize, 64)
+	MaxSignatureSize = tmmath.MaxInt(ed25519.SignatureSize, bls12381.SignatureSize)
 )
 
 // Signable is an interface for all signable things.
@@ -4,6 +4,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/batch"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/secp256k1"
+	"github.com/Finschia/ostracon/crypto/sr25519"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	"github.com/Finschia/ostracon/crypto/vrf"
 	tmtime "github.com/Finschia/ostracon/types/time"
@@ -40,14 +46,106 @@ func ValidateHash(h []byte) error {
 	return nil
 }
 
+// MaxProofSize is the size of the largest VRF proof of the key types that support the VRF.
+const MaxProofSize = max(vrf.ProofSize, secp256k1.ProofSize, sr25519.ProofSize, bls12381.ProofSize)
+
 // ValidateProof returns an error if the proof is not empty, but its
-// size != vrf.ProofSize.
+// size > MaxProofSize. The exact size depends on the key type of the prover,
+// and is checked when the proof is verified.
 func ValidateProof(h []byte) error {
-	if len(h) > 0 && len(h) != vrf.ProofSize {
-		return fmt.Errorf("expected size to be %d bytes, got %d bytes",
-			vrf.ProofSize,
+	if len(h) > MaxProofSize {
+		return fmt.Errorf("expected size to be at most %d bytes, got %d bytes",
+			MaxProofSize,
 			len(h),
 		)
 	}
 	return nil
 }
+
+// ProofToHash returns the VRF output of a proof generated by the private key of pubKey, without
+// verifying the proof.
+func ProofToHash(pubKey crypto.PubKey, proof crypto.Proof) (crypto.Output, error) {
+	switch pubKey.(type) {
+	case ed25519.PubKey:
+		output, err := vrf.ProofToHash(vrf.Proof(proof))
+		return crypto.Output(output), err
+	case secp256k1.PubKey:
+		return secp256k1.ProofToHash(proof)
+	case sr25519.PubKey:
+		return sr25519.ProofToHash(proof)
+	case bls12381.PubKey:
+		return bls12381.ProofToHash(proof)
+	default:
+		return nil, fmt.Errorf("VRF is not supported by the key type %s", pubKey.Type())
+	}
+}
+
+// batchVerifyThreshold is the minimum number of signatures of a key type for verifying them in a
+// batch rather than one by one.
+const batchVerifyThreshold = 2
+
+// commitSigVerifier collects signatures of a commit and verifies them at once, in a batch for
+// each key type that supports batch verification and one by one for the others.
+type commitSigVerifier struct {
+	sigs []commitSigEntry
+}
+
+type commitSigEntry struct {
+	valIdx int
+	pubKey crypto.PubKey
+	msg    []byte
+	sig    []byte
+}
+
+func (v *commitSigVerifier) add(valIdx int, pubKey crypto.PubKey, msg, sig []byte) {
+	v.sigs = append(v.sigs, commitSigEntry{valIdx: valIdx, pubKey: pubKey, msg: msg, sig: sig})
+}
+
+// verify returns an error for the first invalid signature, in the order they were added.
+func (v *commitSigVerifier) verify() error {
+	for i, ok := range v.verified() {
+		if !ok {
+			return fmt.Errorf("wrong signature (#%d): %X", v.sigs[i].valIdx, v.sigs[i].sig)
+		}
+	}
+	return nil
+}
+
+// verified returns whether each signature is valid. The signatures of a batch that fails are
+// verified one by one.
+func (v *commitSigVerifier) verified() []bool {
+	batches := make(map[string][]int) // key type -> indices into v.sigs
+	for i, entry := range v.sigs {
+		if batch.SupportsBatchVerifier(entry.pubKey) {
+			keyType := entry.pubKey.Type()
+			batches[keyType] = append(batches[keyType], i)
+		}
+	}
+
+	verified := make([]bool, len(v.sigs))
+	for _, indices := range batches {
+		if len(indices) < batchVerifyThreshold {
+			continue
+		}
+		bv, _ := batch.CreateBatchVerifier(v.sigs[indices[0]].pubKey)
+		// the signatures refused by the batch verifier are verified one by one
+		added := make([]int, 0, len(indices))
+		for _, i := range indices {
+			if err := bv.Add(v.sigs[i].pubKey, v.sigs[i].msg, v.sigs[i].sig); err == nil {
+				added = append(added, i)
+			}
+		}
+		if len(added) > 0 && bv.Verify() {
+			for _, i := range added {
+				verified[i] = true
+			}
+		}
+	}
+
+	for i, entry := range v.sigs {
+		if !verified[i] {
+			verified[i] = entry.pubKey.VerifySignature(entry.msg, entry.sig)
+		}
+	}
+	return verified
+}
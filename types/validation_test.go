@@ -0,0 +1,163 @@
+package types
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+	"github.com/hdevalence/ed25519consensus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/secp256k1"
+	"github.com/Finschia/ostracon/crypto/sr25519"
+	tmmath "github.com/Finschia/ostracon/libs/math"
+)
+
+func TestProofToHash(t *testing.T) {
+	message := MakeRoundHash([]byte("last proof hash"), 10, 0)
+	for _, privKey := range []crypto.PrivKey{
+		ed25519.GenPrivKey(),
+		secp256k1.GenPrivKey(),
+		sr25519.GenPrivKey(),
+		bls12381.GenPrivKey(),
+	} {
+		t.Run(privKey.Type(), func(t *testing.T) {
+			proof, err := privKey.VRFProve(message)
+			require.NoError(t, err)
+			var entropy Entropy
+			entropy.Populate(0, proof)
+			require.NoError(t, entropy.ValidateBasic())
+
+			output, err := privKey.PubKey().VRFVerify(proof, message)
+			require.NoError(t, err)
+			hash, err := ProofToHash(privKey.PubKey(), proof)
+			require.NoError(t, err)
+			assert.Equal(t, output, hash)
+
+			// the output selects a proposer like any other proof hash
+			vals := NewValidatorSet([]*Validator{
+				NewValidator(privKey.PubKey(), 10),
+				NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+			})
+			assert.NotNil(t, vals.SelectProposer(hash, 11, 0))
+		})
+	}
+
+	_, err := ProofToHash(pubKeyEddie{}, make([]byte, MaxProofSize))
+	assert.Error(t, err)
+}
+
+func TestCommitSigVerifier(t *testing.T) {
+	privKeys := []crypto.PrivKey{
+		ed25519.GenPrivKey(),
+		sr25519.GenPrivKey(),
+		ed25519.GenPrivKey(),
+		secp256k1.GenPrivKey(),
+		sr25519.GenPrivKey(),
+		ed25519.GenPrivKey(),
+	}
+	msgs := make([][]byte, len(privKeys))
+	sigs := make([][]byte, len(privKeys))
+	for i, privKey := range privKeys {
+		msgs[i] = crypto.CRandBytes(32)
+		sig, err := privKey.Sign(msgs[i])
+		require.NoError(t, err)
+		sigs[i] = sig
+	}
+	verifier := func(malleate func(i int, sig []byte) []byte) *commitSigVerifier {
+		var v commitSigVerifier
+		for i, privKey := range privKeys {
+			sig := append([]byte{}, sigs[i]...)
+			v.add(i+10, privKey.PubKey(), msgs[i], malleate(i, sig))
+		}
+		return &v
+	}
+
+	assert.NoError(t, verifier(func(i int, sig []byte) []byte { return sig }).verify())
+	assert.NoError(t, new(commitSigVerifier).verify())
+
+	for bad := range privKeys {
+		err := verifier(func(i int, sig []byte) []byte {
+			if i == bad {
+				sig[3] ^= 0x01
+			}
+			return sig
+		}).verify()
+		if assert.Error(t, err, privKeys[bad].Type()) {
+			assert.Contains(t, err.Error(), fmt.Sprintf("wrong signature (#%d)", bad+10))
+		}
+	}
+
+	// a malformed signature in a batch
+	err := verifier(func(i int, sig []byte) []byte {
+		if i == 2 {
+			return sig[1:]
+		}
+		return sig
+	}).verify()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#12)")
+	}
+}
+
+// signWithTorsion signs msg with a nonce point R having an order 2 component, which ZIP 215
+// accepts but VerifySignature doesn't.
+func signWithTorsion(t *testing.T, privKey ed25519.PrivKey, msg []byte) []byte {
+	h := sha512.Sum512(privKey[:32])
+	a, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	require.NoError(t, err)
+	r, err := edwards25519.NewScalar().SetUniformBytes(crypto.CRandBytes(64))
+	require.NoError(t, err)
+	// (0, -1), the point of order 2
+	torsion, err := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	require.NoError(t, err)
+	t2, err := new(edwards25519.Point).SetBytes(torsion)
+	require.NoError(t, err)
+	R := new(edwards25519.Point).ScalarBaseMult(r)
+	R.Add(R, t2)
+
+	kh := sha512.New()
+	kh.Write(R.Bytes())
+	kh.Write(privKey[32:])
+	kh.Write(msg)
+	k, err := edwards25519.NewScalar().SetUniformBytes(kh.Sum(nil))
+	require.NoError(t, err)
+	S := edwards25519.NewScalar().MultiplyAdd(k, a, r)
+	return append(R.Bytes(), S.Bytes()...)
+}
+
+func TestVerifyCommitRefusesTorsionSignatures(t *testing.T) {
+	const (
+		chainID = "test_chain_id"
+		h       = int64(3)
+	)
+	// a single signature is verified alone, several ones in a batch
+	for _, n := range []int{1, 4} {
+		n := n
+		t.Run(fmt.Sprintf("%d validators", n), func(t *testing.T) {
+			blockID := makeBlockIDRandom()
+			voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, n, 10)
+			commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+			require.NoError(t, err)
+
+			privKey := vals[0].(MockPV).PrivKey.(ed25519.PrivKey)
+			msg := commit.VoteSignBytes(chainID, 0)
+			sig := signWithTorsion(t, privKey, msg)
+			require.True(t, ed25519consensus.Verify(stded25519.PublicKey(privKey.PubKey().Bytes()), msg, sig))
+			commit.Signatures[0].Signature = sig
+
+			assert.Error(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+			assert.Error(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+			assert.Error(t, valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 1, Denominator: 3}))
+		})
+	}
+}
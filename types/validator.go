@@ -11,6 +11,7 @@ import (
 	"github.com/Finschia/ostracon/crypto"
 	ce "github.com/Finschia/ostracon/crypto/encoding"
 	tmrand "github.com/Finschia/ostracon/libs/rand"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 )
 
 // Volatile state for each Validator
@@ -22,6 +23,18 @@ type Validator struct {
 	VotingPower int64         `json:"voting_power"`
 
 	ProposerPriority int64 `json:"proposer_priority"`
+
+	// KeyHistory holds the keys of a validator that rotated its key and the heights from which
+	// they are valid, oldest first. The first one is the key the address is derived from, and the
+	// last ones may have been announced but not be valid yet. The keys replaced before the
+	// evidence age are pruned, see ValidatorSet.PruneKeyHistory.
+	KeyHistory []ValidatorKey `json:"key_history,omitempty"`
+}
+
+// ValidatorKey is a consensus key of a validator and the height from which it is valid.
+type ValidatorKey struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+	Height int64         `json:"height"`
 }
 
 // NewValidator returns a new validator with the given pubkey and voting power.
@@ -51,6 +64,25 @@ func (v *Validator) ValidateBasic() error {
 		return fmt.Errorf("validator address is the wrong size: %v", v.Address)
 	}
 
+	if len(v.KeyHistory) != 0 {
+		hasPubKey := false
+		for i, key := range v.KeyHistory {
+			if key.PubKey == nil {
+				return fmt.Errorf("validator key #%d does not have a public key", i)
+			}
+			if i > 0 && key.Height <= v.KeyHistory[i-1].Height {
+				return fmt.Errorf("validator key #%d is not valid after the previous one", i)
+			}
+			hasPubKey = hasPubKey || key.PubKey.Equals(v.PubKey)
+		}
+		if !bytes.Equal(v.KeyHistory[0].PubKey.Address(), v.Address) {
+			return errors.New("validator address does not match its first key")
+		}
+		if !hasPubKey {
+			return errors.New("validator public key is not in its key history")
+		}
+	}
+
 	return nil
 }
 
@@ -58,9 +90,47 @@ func (v *Validator) ValidateBasic() error {
 // Panics if the validator is nil.
 func (v *Validator) Copy() *Validator {
 	vCopy := *v
+	if v.KeyHistory != nil {
+		vCopy.KeyHistory = make([]ValidatorKey, len(v.KeyHistory))
+		copy(vCopy.KeyHistory, v.KeyHistory)
+	}
 	return &vCopy
 }
 
+// PubKeyAt returns the key of the validator valid at height. Rotations announced after the height
+// of its validator set are unknown, so the key of a later height may be wrong.
+func (v *Validator) PubKeyAt(height int64) crypto.PubKey {
+	for i := len(v.KeyHistory) - 1; i >= 0; i-- {
+		if v.KeyHistory[i].Height <= height {
+			return v.KeyHistory[i].PubKey
+		}
+	}
+	return v.PubKey
+}
+
+// LatestPubKey returns the last key the validator announced, which may not be valid yet. It signs
+// the next rotation of the validator's key.
+func (v *Validator) LatestPubKey() crypto.PubKey {
+	if len(v.KeyHistory) == 0 {
+		return v.PubKey
+	}
+	return v.KeyHistory[len(v.KeyHistory)-1].PubKey
+}
+
+// HasPubKey returns true if pubKey is the current key of the validator or one it rotated from or
+// announced.
+func (v *Validator) HasPubKey(pubKey crypto.PubKey) bool {
+	if v.PubKey.Equals(pubKey) {
+		return true
+	}
+	for _, key := range v.KeyHistory {
+		if key.PubKey.Equals(pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
 // Returns the one with higher ProposerPriority.
 func (v *Validator) CompareProposerPriority(other *Validator) *Validator {
 	if v == nil {
@@ -113,17 +183,22 @@ func ValidatorListString(vals []*Validator) string {
 
 // Bytes computes the unique encoding of a validator with a given voting power.
 // These are the bytes that gets hashed in consensus. It excludes address
-// as its redundant with the pubkey. This also excludes ProposerPriority
-// which changes every round.
+// as its redundant with the pubkey, or the first key of the key history.
+// This also excludes ProposerPriority which changes every round.
 func (v *Validator) Bytes() []byte {
-	pk, err := ce.PubKeyToProto(v.PubKey)
+	pk, err := ce.PubKeyToOCProto(v.PubKey)
+	if err != nil {
+		panic(err)
+	}
+	keyHistory, err := keyHistoryToProto(v.KeyHistory)
 	if err != nil {
 		panic(err)
 	}
 
-	pbv := tmproto.SimpleValidator{
+	pbv := ocproto.SimpleValidator{
 		PubKey:      &pk,
 		VotingPower: v.VotingPower,
+		KeyHistory:  keyHistory,
 	}
 
 	bz, err := pbv.Marshal()
@@ -174,6 +249,87 @@ func ValidatorFromProto(vp *tmproto.Validator) (*Validator, error) {
 	return v, nil
 }
 
+// ToOCProto converts Validator to Ostracon's protobuf, which unlike ToProto
+// supports BLS12-381 keys and keeps the key history.
+func (v *Validator) ToOCProto() (*ocproto.Validator, error) {
+	if v == nil {
+		return nil, errors.New("nil validator")
+	}
+
+	pk, err := ce.PubKeyToOCProto(v.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	keyHistory, err := keyHistoryToProto(v.KeyHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := ocproto.Validator{
+		Address:          v.Address,
+		PubKey:           pk,
+		VotingPower:      v.VotingPower,
+		ProposerPriority: v.ProposerPriority,
+		KeyHistory:       keyHistory,
+	}
+
+	return &vp, nil
+}
+
+// ValidatorFromOCProto returns the Validator of Ostracon's protobuf.
+// It returns an error if the public key is invalid.
+func ValidatorFromOCProto(vp *ocproto.Validator) (*Validator, error) {
+	if vp == nil {
+		return nil, errors.New("nil validator")
+	}
+
+	pk, err := ce.PubKeyFromOCProto(&vp.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	v := new(Validator)
+	v.Address = vp.GetAddress()
+	v.PubKey = pk
+	v.VotingPower = vp.GetVotingPower()
+	v.ProposerPriority = vp.GetProposerPriority()
+	v.KeyHistory, err = keyHistoryFromProto(vp.KeyHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func keyHistoryToProto(keyHistory []ValidatorKey) ([]ocproto.ValidatorKey, error) {
+	if len(keyHistory) == 0 {
+		return nil, nil
+	}
+	pbKeys := make([]ocproto.ValidatorKey, len(keyHistory))
+	for i, key := range keyHistory {
+		pk, err := ce.PubKeyToOCProto(key.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		pbKeys[i] = ocproto.ValidatorKey{PubKey: pk, Height: key.Height}
+	}
+	return pbKeys, nil
+}
+
+func keyHistoryFromProto(pbKeys []ocproto.ValidatorKey) ([]ValidatorKey, error) {
+	if len(pbKeys) == 0 {
+		return nil, nil
+	}
+	keyHistory := make([]ValidatorKey, len(pbKeys))
+	for i, pbKey := range pbKeys {
+		pk, err := ce.PubKeyFromOCProto(&pbKey.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		keyHistory[i] = ValidatorKey{PubKey: pk, Height: pbKey.Height}
+	}
+	return keyHistory, nil
+}
+
 //----------------------------------------
 // RandValidator
 
@@ -12,9 +12,11 @@ import (
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	"github.com/Finschia/ostracon/crypto"
 	"github.com/Finschia/ostracon/crypto/merkle"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	tmmath "github.com/Finschia/ostracon/libs/math"
+	ocproto "github.com/Finschia/ostracon/proto/ostracon/types"
 )
 
 const (
@@ -280,6 +282,87 @@ func (vals *ValidatorSet) GetByIndex(index int32) (address []byte, val *Validato
 	return val.Address, val.Copy()
 }
 
+// GetByPubKey returns an index of the validator whose current key is pubKey and
+// validator itself (copy) if found. Otherwise, -1 and nil are returned. Its address
+// differs from the one of pubKey if the validator rotated its key.
+func (vals *ValidatorSet) GetByPubKey(pubKey crypto.PubKey) (index int32, val *Validator) {
+	for idx, val := range vals.Validators {
+		if val.PubKey.Equals(pubKey) {
+			return int32(idx), val.Copy()
+		}
+	}
+	return -1, nil
+}
+
+// RotateKey records that the validator with address rotates its key to pubKey from
+// height on. The key of the validator doesn't change until ActivateKeys is called
+// with that height.
+func (vals *ValidatorSet) RotateKey(address Address, pubKey crypto.PubKey, height int64) error {
+	idx, _ := vals.GetByAddress(address)
+	if idx == -1 {
+		return fmt.Errorf("validator %X is not in the validator set", address)
+	}
+	val := vals.Validators[idx]
+	if n := len(val.KeyHistory); n != 0 && val.KeyHistory[n-1].Height >= height {
+		return fmt.Errorf("validator %X already rotates its key at height %d",
+			address, val.KeyHistory[n-1].Height)
+	}
+	for _, other := range vals.Validators {
+		if other.HasPubKey(pubKey) || bytes.Equal(other.Address, pubKey.Address()) {
+			return fmt.Errorf("key %v is already used by validator %X", pubKey, other.Address)
+		}
+	}
+
+	keyHistory := make([]ValidatorKey, 0, len(val.KeyHistory)+2)
+	if len(val.KeyHistory) == 0 {
+		keyHistory = append(keyHistory, ValidatorKey{PubKey: val.PubKey, Height: 0})
+	}
+	keyHistory = append(keyHistory, val.KeyHistory...)
+	val.KeyHistory = append(keyHistory, ValidatorKey{PubKey: pubKey, Height: height})
+	return nil
+}
+
+// ActivateKeys sets the key of each validator to the one valid at height, and
+// returns true if any key changed.
+func (vals *ValidatorSet) ActivateKeys(height int64) bool {
+	changed := false
+	for _, val := range vals.Validators {
+		if pubKey := val.PubKeyAt(height); !pubKey.Equals(val.PubKey) {
+			val.PubKey = pubKey
+			changed = true
+		}
+	}
+	return changed
+}
+
+// PruneKeyHistory removes from the key history of each validator the keys replaced more than
+// maxAge blocks before height, the height of the keys set by ActivateKeys, for which evidence
+// has expired. It returns true if any key was removed. The first key is kept since the address
+// of the validator derives from it, so PubKeyAt returns it for the heights of the removed keys.
+func (vals *ValidatorSet) PruneKeyHistory(height, maxAge int64) bool {
+	pruned := false
+	for _, val := range vals.Validators {
+		n := len(val.KeyHistory)
+		if n <= 2 {
+			continue
+		}
+		keyHistory := make([]ValidatorKey, 0, n)
+		keyHistory = append(keyHistory, val.KeyHistory[0])
+		for i := 1; i < n; i++ {
+			// the key was last valid at the height before the next one's
+			if i+1 < n && height-(val.KeyHistory[i+1].Height-1) > maxAge {
+				continue
+			}
+			keyHistory = append(keyHistory, val.KeyHistory[i])
+		}
+		if len(keyHistory) < n {
+			val.KeyHistory = keyHistory
+			pruned = true
+		}
+	}
+	return pruned
+}
+
 // Size returns the length of the validator set.
 func (vals *ValidatorSet) Size() int {
 	return len(vals.Validators)
@@ -420,6 +503,24 @@ func verifyUpdates(
 	return tvpAfterRemovals + removedPower, nil
 }
 
+// verifyNewKeys checks that the validators to be added don't use a key that a validator of
+// vals rotated from or to.
+func verifyNewKeys(updates []*Validator, vals *ValidatorSet) error {
+	for _, valUpdate := range updates {
+		if valUpdate.PubKey == nil || vals.HasAddress(valUpdate.Address) {
+			continue
+		}
+		for _, val := range vals.Validators {
+			// The others have the key of their address.
+			if len(val.KeyHistory) != 0 && val.HasPubKey(valUpdate.PubKey) {
+				return fmt.Errorf("key %v of validator %X is used by validator %X",
+					valUpdate.PubKey, valUpdate.Address, val.Address)
+			}
+		}
+	}
+	return nil
+}
+
 func numNewValidators(updates []*Validator, vals *ValidatorSet) int {
 	numNewValidators := 0
 	for _, valUpdate := range updates {
@@ -481,7 +582,10 @@ func (vals *ValidatorSet) applyUpdates(updates []*Validator) {
 			// Apply add or update.
 			merged[i] = updates[0]
 			if bytes.Equal(existing[0].Address, updates[0].Address) {
-				// Validator is present in both, advance existing.
+				// Validator is present in both, advance existing. It keeps its key, which
+				// it may have rotated.
+				merged[i].PubKey = existing[0].PubKey
+				merged[i].KeyHistory = existing[0].KeyHistory
 				existing = existing[1:]
 			}
 			updates = updates[1:]
@@ -588,6 +692,11 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 		return err
 	}
 
+	// Verify that the new validators don't use the rotated keys of others.
+	if err := verifyNewKeys(updates, vals); err != nil {
+		return err
+	}
+
 	// Compute the priorities for updates.
 	computeNewPriorities(updates, vals, tvpAfterUpdatesBeforeRemovals)
 
@@ -629,6 +738,8 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 // application that depends on the LastCommitInfo sent in BeginBlock, which
 // includes which validators signed. For instance, Gaia incentivizes proposers
 // with a bonus for including more than +2/3 of the signatures.
+//
+// The signatures of the key types that support it are verified in batches.
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	height int64, commit *Commit) error {
 
@@ -651,6 +762,10 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 
 	talliedVotingPower := int64(0)
 	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3 // FIXME: 🏺 arithmetic overflow
+	var (
+		sigs       commitSigVerifier
+		aggregated aggregatedSignature
+	)
 	for idx, commitSig := range commit.Signatures {
 		if commitSig.Absent() {
 			continue // OK, some signatures can be absent.
@@ -662,10 +777,15 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		if commit.isAggregated(idx) {
+			// Verified with the others below.
+			if err := aggregated.add(val.PubKey, voteSignBytes); err != nil {
+				return fmt.Errorf("wrong aggregated signature (#%d): %w", idx, err)
+			}
+		} else {
+			// Verified with the others below.
+			sigs.add(idx, val.PubKey, voteSignBytes, commitSig.Signature)
 		}
-		// Good!
 		if commitSig.ForBlock() {
 			talliedVotingPower += val.VotingPower
 		}
@@ -675,6 +795,13 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 		// }
 	}
 
+	if err := sigs.verify(); err != nil {
+		return err
+	}
+	if !aggregated.verify(commit.AggregatedSignature) {
+		return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+	}
+
 	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
 		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
 	}
@@ -710,25 +837,55 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 
 	talliedVotingPower := int64(0)
 	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3 // FIXME: 🏺 arithmetic overflow
+	var (
+		sigs                  commitSigVerifier
+		aggregated            aggregatedSignature
+		aggregatedVotingPower int64
+	)
 	for idx, commitSig := range commit.Signatures {
+		// The vals and commit have a 1-to-1 correspondance.
+		// This means we don't need the validator address or to do any lookup.
+		if commit.isAggregated(idx) {
+			// The aggregated signature covers all its signers, so they are all collected and
+			// verified at once below.
+			val := vals.Validators[idx]
+			voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+			if err := aggregated.add(val.PubKey, voteSignBytes); err != nil {
+				return fmt.Errorf("wrong aggregated signature (#%d): %w", idx, err)
+			}
+			if commitSig.ForBlock() {
+				aggregatedVotingPower += val.VotingPower
+			}
+			continue
+		}
+
 		// No need to verify absent or nil votes.
 		if !commitSig.ForBlock() {
 			continue
 		}
 
-		// The vals and commit have a 1-to-1 correspondance.
-		// This means we don't need the validator address or to do any lookup.
 		val := vals.Validators[idx]
 
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-		}
+		sigs.add(idx, val.PubKey, voteSignBytes, commitSig.Signature)
 
 		talliedVotingPower += val.VotingPower
 
 		// return as soon as +2/3 of the signatures are verified
+		if talliedVotingPower > votingPowerNeeded {
+			return sigs.verify()
+		}
+	}
+
+	if err := sigs.verify(); err != nil {
+		return err
+	}
+	if !aggregated.empty() {
+		if !aggregated.verify(commit.AggregatedSignature) {
+			return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+		}
+		talliedVotingPower += aggregatedVotingPower
 		if talliedVotingPower > votingPowerNeeded {
 			return nil
 		}
@@ -745,7 +902,21 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 //
 // This method is primarily used by the light client and does not check all the
 // signatures.
+//
+// The signatures are verified with the keys the validators announced for the
+// height of the commit. A validator may have rotated its key after the height of
+// the set though: use VerifyCommitLightTrustingRotated to skip its signature.
 func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Commit, trustLevel tmmath.Fraction) error {
+	return vals.VerifyCommitLightTrustingRotated(chainID, commit, trustLevel, nil)
+}
+
+// VerifyCommitLightTrustingRotated is like VerifyCommitLightTrusting, but doesn't count the
+// signatures of the validators whose key at the height of the commit is different in laterVals,
+// a validator set of a later height which knows of the keys rotated after the height of this
+// set. As laterVals isn't trusted, the signatures made with those keys aren't verified nor
+// counted, and any other invalid signature fails the verification.
+func (vals *ValidatorSet) VerifyCommitLightTrustingRotated(chainID string, commit *Commit,
+	trustLevel tmmath.Fraction, laterVals *ValidatorSet) error {
 	// sanity check
 	if trustLevel.Denominator == 0 {
 		return errors.New("trustLevel has zero Denominator")
@@ -763,9 +934,17 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 	}
 	votingPowerNeeded := totalVotingPowerMulByNumerator / int64(trustLevel.Denominator)
 
+	var (
+		sigs                  commitSigVerifier
+		aggregated            aggregatedSignature
+		aggregatedVotingPower int64
+		// The aggregated signature can only be verified if all its signers are known.
+		aggregatedUnknown bool
+	)
 	for idx, commitSig := range commit.Signatures {
+		aggregatedSig := commit.isAggregated(idx)
 		// No need to verify absent or nil votes.
-		if !commitSig.ForBlock() {
+		if !commitSig.ForBlock() && !aggregatedSig {
 			continue
 		}
 
@@ -773,25 +952,64 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 		// check for each vote if its validator is already known.
 		valIdx, val := vals.GetByAddress(commitSig.ValidatorAddress)
 
-		if val != nil {
-			// check for double vote of validator on the same commit
-			if firstIndex, ok := seenVals[valIdx]; ok {
-				secondIndex := idx
-				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+		if val == nil {
+			if aggregatedSig {
+				aggregatedUnknown = true
 			}
-			seenVals[valIdx] = idx
+			continue
+		}
 
-			// Verify Signature
-			voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-			if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-				return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		// check for double vote of validator on the same commit
+		if firstIndex, ok := seenVals[valIdx]; ok {
+			secondIndex := idx
+			return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+		}
+		seenVals[valIdx] = idx
+
+		pubKey := val.PubKeyAt(commit.Height)
+		if laterVals != nil {
+			if _, laterVal := laterVals.GetByAddress(val.Address); laterVal != nil &&
+				!laterVal.PubKeyAt(commit.Height).Equals(pubKey) {
+				// The key was rotated after the height of the set.
+				if aggregatedSig {
+					aggregatedUnknown = true
+				}
+				continue
 			}
+		}
 
-			talliedVotingPower += val.VotingPower
-
-			if talliedVotingPower > votingPowerNeeded {
-				return nil
+		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+		if aggregatedSig {
+			// Verified with the others below.
+			if err := aggregated.add(pubKey, voteSignBytes); err != nil {
+				return fmt.Errorf("wrong aggregated signature (#%d): %w", idx, err)
 			}
+			if commitSig.ForBlock() {
+				aggregatedVotingPower += val.VotingPower
+			}
+			continue
+		}
+
+		// Verify Signature
+		sigs.add(idx, pubKey, voteSignBytes, commitSig.Signature)
+
+		talliedVotingPower += val.VotingPower
+
+		if talliedVotingPower > votingPowerNeeded {
+			return sigs.verify()
+		}
+	}
+
+	if err := sigs.verify(); err != nil {
+		return err
+	}
+	if !aggregated.empty() && !aggregatedUnknown {
+		if !aggregated.verify(commit.AggregatedSignature) {
+			return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+		}
+		talliedVotingPower += aggregatedVotingPower
+		if talliedVotingPower > votingPowerNeeded {
+			return nil
 		}
 	}
 
@@ -980,6 +1198,52 @@ func ValidatorSetFromProto(vp *tmproto.ValidatorSet) (*ValidatorSet, error) {
 	return vals, vals.ValidateBasic()
 }
 
+// ToOCProto converts ValidatorSet to Ostracon's protobuf, which unlike ToProto
+// supports BLS12-381 keys and keeps the key histories.
+func (vals *ValidatorSet) ToOCProto() (*ocproto.ValidatorSet, error) {
+	if vals.IsNilOrEmpty() {
+		return &ocproto.ValidatorSet{}, nil // validator set should never be nil
+	}
+
+	vp := new(ocproto.ValidatorSet)
+	valsProto := make([]*ocproto.Validator, len(vals.Validators))
+	for i := 0; i < len(vals.Validators); i++ {
+		valp, err := vals.Validators[i].ToOCProto()
+		if err != nil {
+			return nil, err
+		}
+		valsProto[i] = valp
+	}
+	vp.Validators = valsProto
+
+	vp.TotalVotingPower = vals.totalVotingPower
+
+	return vp, nil
+}
+
+// ValidatorSetFromOCProto returns the ValidatorSet of Ostracon's protobuf.
+// It returns an error if any of the validators from the set is invalid
+func ValidatorSetFromOCProto(vp *ocproto.ValidatorSet) (*ValidatorSet, error) {
+	if vp == nil {
+		return nil, errors.New("nil validator set") // validator set should never be nil, bigger issues are at play if empty
+	}
+	vals := new(ValidatorSet)
+
+	valsProto := make([]*Validator, len(vp.Validators))
+	for i := 0; i < len(vp.Validators); i++ {
+		v, err := ValidatorFromOCProto(vp.Validators[i])
+		if err != nil {
+			return nil, err
+		}
+		valsProto[i] = v
+	}
+	vals.Validators = valsProto
+
+	vals.totalVotingPower = vp.GetTotalVotingPower()
+
+	return vals, vals.ValidateBasic()
+}
+
 // ValidatorSetFromExistingValidators takes an existing array of validators and
 // rebuilds the exact same validator set that corresponds to it without
 // changing the proposer priority or power if any of the validators fail
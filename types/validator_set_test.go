@@ -793,6 +793,156 @@ func TestValidatorSet_VerifyCommitLightTrusting_ReturnsAsSoonAsTrustLevelOfVotin
 	assert.NoError(t, err)
 }
 
+func TestValidatorSetRotateKey(t *testing.T) {
+	valSet, _ := RandValidatorSet(3, 10)
+	val0, val1 := valSet.Validators[0].Copy(), valSet.Validators[1].Copy()
+	newPubKey := ed25519.GenPrivKey().PubKey()
+	hash := valSet.Hash()
+
+	require.NoError(t, valSet.RotateKey(val0.Address, newPubKey, 5))
+	assert.NotEqual(t, hash, valSet.Hash(), "the announced key must change the hash")
+	assert.NoError(t, valSet.ValidateBasic())
+
+	// invalid rotations
+	assert.Error(t, valSet.RotateKey(ed25519.GenPrivKey().PubKey().Address(), ed25519.GenPrivKey().PubKey(), 6))
+	assert.Error(t, valSet.RotateKey(val0.Address, ed25519.GenPrivKey().PubKey(), 5))
+	assert.Error(t, valSet.RotateKey(val1.Address, newPubKey, 6))
+	assert.Error(t, valSet.RotateKey(val1.Address, val0.PubKey, 6))
+
+	_, val := valSet.GetByAddress(val0.Address)
+	assert.Equal(t, val0.PubKey, val.PubKeyAt(4))
+	assert.Equal(t, newPubKey, val.PubKeyAt(5))
+	assert.Equal(t, newPubKey, val.LatestPubKey())
+	assert.True(t, val.HasPubKey(val0.PubKey))
+	assert.True(t, val.HasPubKey(newPubKey))
+
+	// the key changes at the activation height only
+	assert.False(t, valSet.ActivateKeys(4))
+	assert.Equal(t, val0.PubKey, valSet.Validators[0].PubKey)
+	assert.True(t, valSet.ActivateKeys(5))
+	idx, val := valSet.GetByPubKey(newPubKey)
+	assert.EqualValues(t, 0, idx)
+	assert.Equal(t, val0.Address, val.Address)
+	idx, val = valSet.GetByPubKey(val0.PubKey)
+	assert.EqualValues(t, -1, idx)
+	assert.Nil(t, val)
+	assert.NoError(t, valSet.ValidateBasic())
+
+	pbValSet, err := valSet.ToOCProto()
+	require.NoError(t, err)
+	valSet2, err := ValidatorSetFromOCProto(pbValSet)
+	require.NoError(t, err)
+	assert.Equal(t, valSet, valSet2)
+
+	// power changes, which the application sends with the first key, keep the key
+	require.NoError(t, valSet.UpdateWithChangeSet([]*Validator{NewValidator(val0.PubKey, 20)}))
+	_, val = valSet.GetByAddress(val0.Address)
+	assert.EqualValues(t, 20, val.VotingPower)
+	assert.Equal(t, newPubKey, val.PubKey)
+	assert.Len(t, val.KeyHistory, 2)
+
+	// new validators can't use a rotated key
+	assert.Error(t, valSet.UpdateWithChangeSet([]*Validator{NewValidator(newPubKey, 10)}))
+}
+
+func TestValidatorSetPruneKeyHistory(t *testing.T) {
+	valSet, _ := RandValidatorSet(3, 10)
+	address := valSet.Validators[0].Address
+	firstKey := valSet.Validators[0].PubKey
+	keys := make([]crypto.PubKey, 4)
+	for i := range keys {
+		keys[i] = ed25519.GenPrivKey().PubKey()
+		require.NoError(t, valSet.RotateKey(address, keys[i], int64(5*(i+1))))
+	}
+	// keys[3] is announced for height 20 but not valid yet
+	valSet.ActivateKeys(19)
+	_, val := valSet.GetByAddress(address)
+	require.Equal(t, keys[2], val.PubKey)
+	require.Len(t, val.KeyHistory, 5)
+
+	// the evidence of the heights of the replaced keys hasn't expired
+	assert.False(t, valSet.PruneKeyHistory(19, 10))
+	// keys[0] was last valid at height 9
+	hash := valSet.Hash()
+	assert.True(t, valSet.PruneKeyHistory(19, 9))
+	assert.NotEqual(t, hash, valSet.Hash())
+	_, val = valSet.GetByAddress(address)
+	assert.Equal(t, []ValidatorKey{{firstKey, 0}, {keys[1], 10}, {keys[2], 15}, {keys[3], 20}}, val.KeyHistory)
+	assert.False(t, valSet.PruneKeyHistory(19, 9))
+
+	// the first key, the current one and the announced one are kept
+	assert.True(t, valSet.PruneKeyHistory(19, 0))
+	_, val = valSet.GetByAddress(address)
+	assert.Equal(t, []ValidatorKey{{firstKey, 0}, {keys[2], 15}, {keys[3], 20}}, val.KeyHistory)
+	assert.Equal(t, keys[2], val.PubKey)
+	assert.NoError(t, valSet.ValidateBasic())
+
+	valSet.ActivateKeys(20)
+	assert.True(t, valSet.PruneKeyHistory(20, 0))
+	_, val = valSet.GetByAddress(address)
+	assert.Equal(t, []ValidatorKey{{firstKey, 0}, {keys[3], 20}}, val.KeyHistory)
+	assert.Equal(t, keys[3], val.PubKey)
+	assert.NoError(t, valSet.ValidateBasic())
+	assert.False(t, valSet.PruneKeyHistory(1000, 0))
+}
+
+func TestValidatorSet_VerifyCommitLightTrusting_KeyRotation(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+		newVal  = NewMockPV()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// the 1st validator signs with its new key
+	newPubKey, err := newVal.GetPubKey()
+	require.NoError(t, err)
+	rotatedValSet := valSet.Copy()
+	require.NoError(t, rotatedValSet.RotateKey(valSet.Validators[0].Address, newPubKey, h))
+	announcedValSet := rotatedValSet.Copy()
+	require.True(t, rotatedValSet.ActivateKeys(h))
+
+	vote := voteSet.GetByIndex(0)
+	v := vote.ToProto()
+	err = newVal.SignVote(chainID, v)
+	require.NoError(t, err)
+	vote.Signature = v.Signature
+	commit.Signatures[0] = vote.CommitSig()
+
+	assert.NoError(t, rotatedValSet.VerifyCommitLight(chainID, blockID, h, commit))
+	assert.Error(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+
+	// a set that doesn't know the rotation fails on the signature made with the new key
+	trust := tmmath.Fraction{Numerator: 1, Denominator: 3}
+	err = valSet.VerifyCommitLightTrusting(chainID, commit, trust)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#0)")
+	}
+
+	// unless it's given a later set that knows the rotation: the signature isn't counted then
+	err = valSet.VerifyCommitLightTrustingRotated(chainID, commit, trust, rotatedValSet)
+	assert.NoError(t, err)
+	err = valSet.VerifyCommitLightTrustingRotated(chainID, commit, tmmath.Fraction{Numerator: 3, Denominator: 4},
+		rotatedValSet)
+	assert.IsType(t, ErrNotEnoughVotingPowerSigned{}, err)
+
+	// the other invalid signatures still fail
+	commit.Signatures[1].Signature = commit.Signatures[2].Signature
+	err = valSet.VerifyCommitLightTrustingRotated(chainID, commit, trust, rotatedValSet)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#1)")
+	}
+	commit.Signatures[1] = voteSet.GetByIndex(1).CommitSig()
+
+	// a set that knows the rotation, though it's not active yet, verifies it with the new key
+	err = announcedValSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 3, Denominator: 4})
+	assert.NoError(t, err)
+}
+
 func TestEmptySet(t *testing.T) {
 
 	var valList []*Validator
@@ -5,6 +5,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Finschia/ostracon/crypto/bls12381"
+	"github.com/Finschia/ostracon/crypto/ed25519"
 )
 
 func TestValidatorProtoBuf(t *testing.T) {
@@ -18,6 +21,7 @@ func TestValidatorProtoBuf(t *testing.T) {
 		{"success validator", val, true, true},
 		{"failure empty", &Validator{}, false, false},
 		{"failure nil", nil, false, false},
+		{"failure BLS12-381 key", NewValidator(bls12381.GenPrivKey().PubKey(), 10), false, false},
 	}
 	for _, tc := range testCases {
 		protoVal, err := tc.v1.ToProto()
@@ -38,9 +42,46 @@ func TestValidatorProtoBuf(t *testing.T) {
 	}
 }
 
+func TestValidatorOCProtoBuf(t *testing.T) {
+	val, _ := RandValidator(true, 100)
+	rotatedVal := val.Copy()
+	rotatedVal.PubKey = ed25519.GenPrivKey().PubKey()
+	rotatedVal.KeyHistory = []ValidatorKey{{PubKey: val.PubKey, Height: 0}, {PubKey: rotatedVal.PubKey, Height: 7}}
+	testCases := []struct {
+		msg      string
+		v1       *Validator
+		expPass1 bool
+		expPass2 bool
+	}{
+		{"success validator", val, true, true},
+		{"success validator with key history", rotatedVal, true, true},
+		{"success BLS12-381 key", NewValidator(bls12381.GenPrivKey().PubKey(), 10), true, true},
+		{"failure empty", &Validator{}, false, false},
+		{"failure nil", nil, false, false},
+	}
+	for _, tc := range testCases {
+		protoVal, err := tc.v1.ToOCProto()
+
+		if tc.expPass1 {
+			require.NoError(t, err, tc.msg)
+		} else {
+			require.Error(t, err, tc.msg)
+		}
+
+		val, err := ValidatorFromOCProto(protoVal)
+		if tc.expPass2 {
+			require.NoError(t, err, tc.msg)
+			require.Equal(t, tc.v1, val, tc.msg)
+		} else {
+			require.Error(t, err, tc.msg)
+		}
+	}
+}
+
 func TestValidatorValidateBasic(t *testing.T) {
 	priv := NewMockPV()
 	pubKey, _ := priv.GetPubKey()
+	newPubKey := ed25519.GenPrivKey().PubKey()
 	testCases := []struct {
 		val *Validator
 		err bool
@@ -84,6 +125,51 @@ func TestValidatorValidateBasic(t *testing.T) {
 			err: true,
 			msg: "validator address is the wrong size: 61",
 		},
+		{
+			val: &Validator{
+				PubKey:     pubKey,
+				Address:    pubKey.Address(),
+				KeyHistory: []ValidatorKey{{PubKey: pubKey, Height: 0}, {PubKey: nil, Height: 3}},
+			},
+			err: true,
+			msg: "validator key #1 does not have a public key",
+		},
+		{
+			val: &Validator{
+				PubKey:     newPubKey,
+				Address:    pubKey.Address(),
+				KeyHistory: []ValidatorKey{{PubKey: pubKey, Height: 3}, {PubKey: newPubKey, Height: 3}},
+			},
+			err: true,
+			msg: "validator key #1 is not valid after the previous one",
+		},
+		{
+			val: &Validator{
+				PubKey:     newPubKey,
+				Address:    pubKey.Address(),
+				KeyHistory: []ValidatorKey{{PubKey: newPubKey, Height: 0}},
+			},
+			err: true,
+			msg: "validator address does not match its first key",
+		},
+		{
+			val: &Validator{
+				PubKey:     ed25519.GenPrivKey().PubKey(),
+				Address:    pubKey.Address(),
+				KeyHistory: []ValidatorKey{{PubKey: pubKey, Height: 0}, {PubKey: newPubKey, Height: 3}},
+			},
+			err: true,
+			msg: "validator public key is not in its key history",
+		},
+		{
+			val: &Validator{
+				PubKey:     newPubKey,
+				Address:    pubKey.Address(),
+				KeyHistory: []ValidatorKey{{PubKey: pubKey, Height: 0}, {PubKey: newPubKey, Height: 3}},
+			},
+			err: false,
+			msg: "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -9,7 +9,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/Finschia/ostracon/crypto"
-	"github.com/Finschia/ostracon/crypto/ed25519"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	tmbytes "github.com/Finschia/ostracon/libs/bytes"
 	"github.com/Finschia/ostracon/libs/protoio"
 )
@@ -67,7 +67,7 @@ const MaxVoteBytes int64 = (1 + 1) + // Type
 	(1 + 17 + 1) + // Timestamp
 	(1 + 20 + 1) + // ValidatorAddress
 	(1 + 5) + // ValidatorIndex
-	(1 + ed25519.SignatureSize + 1) // Signature
+	(1 + bls12381.SignatureSize + 1) // Signature
 
 // CommitSig converts the Vote to a CommitSig.
 func (vote *Vote) CommitSig() CommitSig {
@@ -159,6 +159,12 @@ func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 	if !bytes.Equal(pubKey.Address(), vote.ValidatorAddress) {
 		return ErrVoteInvalidValidatorAddress
 	}
+	return vote.verifySignature(chainID, pubKey)
+}
+
+// verifySignature verifies the signature of the vote without checking that the address of pubKey
+// is ValidatorAddress, which it isn't if the validator rotated its key.
+func (vote *Vote) verifySignature(chainID string, pubKey crypto.PubKey) error {
 	v := vote.ToProto()
 	if !pubKey.VerifySignature(VoteSignBytes(chainID, v), vote.Signature) {
 		return ErrVoteInvalidSignature
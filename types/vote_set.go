@@ -159,7 +159,8 @@ func (voteSet *VoteSet) AddVote(vote *Vote) (added bool, err error) {
 	voteSet.mtx.Lock()
 	defer voteSet.mtx.Unlock()
 
-	return voteSet.addVote(vote, vote.Verify)
+	// The address of the vote is checked against the validator set, whose key may have been rotated.
+	return voteSet.addVote(vote, vote.verifySignature)
 }
 
 // NOTE: Validates as much as possible before attempting to verify the signature.
@@ -12,6 +12,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/Finschia/ostracon/crypto"
+	"github.com/Finschia/ostracon/crypto/bls12381"
 	"github.com/Finschia/ostracon/crypto/ed25519"
 	"github.com/Finschia/ostracon/crypto/tmhash"
 	"github.com/Finschia/ostracon/libs/protoio"
@@ -239,7 +240,7 @@ func TestMaxVoteBytes(t *testing.T) {
 		},
 	}
 
-	privVal := NewMockPV()
+	privVal := NewMockPVWithParams(bls12381.GenPrivKey(), false, false) // the largest signatures
 	pbVote := vote.ToProto()
 	err := privVal.SignVote("test_chain_id", pbVote)
 	require.NoError(t, err)